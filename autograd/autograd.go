@@ -0,0 +1,203 @@
+// Package autograd provides reverse-mode automatic differentiation of
+// expressions built from NumGo arrays
+package autograd
+
+import (
+	"fmt"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Variable is a node of a computation graph: an array value, the operation
+// that produced it and, after Backward, its gradient. Every operation on a
+// Variable records its inputs and a gradient function, so the graph built
+// by the forward pass is the tape that Backward replays in reverse.
+type Variable struct {
+	// Value is the result of the forward computation, stored as Float64
+	Value *tensor.NDArray
+	
+	// Grad is the gradient of the last Backward output with respect to
+	// Value, accumulated over calls. It is nil until Backward reaches the
+	// variable and is only kept for variables created by NewVariable.
+	Grad *tensor.NDArray
+	
+	requiresGrad bool
+	parents      []*Variable
+	
+	// backward maps the gradient of the output to the gradients of the
+	// parents, in order; nil entries contribute nothing
+	backward func(grad *tensor.NDArray) []*tensor.NDArray
+}
+
+// NewVariable creates a leaf variable whose gradient Backward computes,
+// such as a model parameter
+func NewVariable(value *tensor.NDArray) *Variable {
+	return &Variable{Value: toFloat64(value), requiresGrad: true}
+}
+
+// Constant creates a leaf variable that is not differentiated, such as
+// input data
+func Constant(value *tensor.NDArray) *Variable {
+	return &Variable{Value: toFloat64(value)}
+}
+
+// Scalar creates a one-element constant
+func Scalar(value float64) *Variable {
+	return Constant(tensor.FromSliceFloat64([]float64{value}, 1))
+}
+
+// RequiresGrad reports whether gradients flow to v
+func (v *Variable) RequiresGrad() bool {
+	return v.requiresGrad
+}
+
+// Shape returns the shape of the value
+func (v *Variable) Shape() []int {
+	return v.Value.Shape()
+}
+
+// ZeroGrad clears the accumulated gradient
+func (v *Variable) ZeroGrad() {
+	v.Grad = nil
+}
+
+// Detach returns a constant holding the same value, cut off from the graph
+func (v *Variable) Detach() *Variable {
+	return &Variable{Value: v.Value}
+}
+
+// Backward computes the gradient of v, which must hold a single element,
+// with respect to every variable it was computed from, adding the result to
+// the Grad of each leaf created by NewVariable
+func (v *Variable) Backward() {
+	if v.Value.Size() != 1 {
+		panic(fmt.Sprintf("Backward requires a single-element output, got shape %v", v.Value.Shape()))
+	}
+	if !v.requiresGrad {
+		return
+	}
+	
+	// Order the graph so that every node comes after all of its consumers
+	var order []*Variable
+	visited := make(map[*Variable]bool)
+	var visit func(n *Variable)
+	visit = func(n *Variable) {
+		if visited[n] || !n.requiresGrad {
+			return
+		}
+		visited[n] = true
+		for _, p := range n.parents {
+			visit(p)
+		}
+		order = append(order, n)
+	}
+	visit(v)
+	
+	grads := map[*Variable]*tensor.NDArray{v: tensor.Ones(v.Value.Shape(), tensor.Float64)}
+	for i := len(order) - 1; i >= 0; i-- {
+		n := order[i]
+		g := grads[n]
+		if g == nil {
+			continue
+		}
+		if n.backward == nil {
+			if n.Grad == nil {
+				n.Grad = g
+			} else {
+				n.Grad = n.Grad.Add(g)
+			}
+			continue
+		}
+		for j, pg := range n.backward(g) {
+			p := n.parents[j]
+			if pg == nil || !p.requiresGrad {
+				continue
+			}
+			pg = unbroadcast(pg, p.Value.Shape())
+			if prev := grads[p]; prev != nil {
+				pg = prev.Add(pg)
+			}
+			grads[p] = pg
+		}
+	}
+}
+
+// record creates the result of an operation on parents
+func record(value *tensor.NDArray, backward func(grad *tensor.NDArray) []*tensor.NDArray, parents ...*Variable) *Variable {
+	out := &Variable{Value: toFloat64(value)}
+	for _, p := range parents {
+		if p.requiresGrad {
+			out.requiresGrad = true
+			out.parents = parents
+			out.backward = backward
+			break
+		}
+	}
+	return out
+}
+
+// toFloat64 returns a as a Float64 array, converting only if needed
+func toFloat64(a *tensor.NDArray) *tensor.NDArray {
+	if a.DType() == tensor.Float64 {
+		return a
+	}
+	return tensor.FromSliceFloat64(a.ToSliceFloat64(), a.Shape()...)
+}
+
+// apply returns f applied to each element of a as a Float64 array
+func apply(a *tensor.NDArray, f func(float64) float64) *tensor.NDArray {
+	values := a.ToSliceFloat64()
+	for i, x := range values {
+		values[i] = f(x)
+	}
+	return tensor.FromSliceFloat64(values, a.Shape()...)
+}
+
+// unbroadcast sums grad over the axes along which a value of the given
+// shape was broadcast, giving a gradient of that shape
+func unbroadcast(grad *tensor.NDArray, shape []int) *tensor.NDArray {
+	gshape := grad.Shape()
+	if shapeEqual(gshape, shape) {
+		return grad
+	}
+	size := 1
+	for _, s := range shape {
+		size *= s
+	}
+	if len(shape) > len(gshape) || size == grad.Size() {
+		// Only the layout differs, as for a one-element value
+		return grad.Reshape(shape...)
+	}
+	
+	offset := len(gshape) - len(shape)
+	strides := make([]int, len(gshape))
+	stride := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		if shape[i] != 1 {
+			strides[i+offset] = stride
+		}
+		stride *= shape[i]
+	}
+	out := make([]float64, size)
+	for i, g := range grad.ToSliceFloat64() {
+		rem, at := i, 0
+		for ax := len(gshape) - 1; ax >= 0; ax-- {
+			at += (rem % gshape[ax]) * strides[ax]
+			rem /= gshape[ax]
+		}
+		out[at] += g
+	}
+	return tensor.FromSliceFloat64(out, shape...)
+}
+
+func shapeEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package autograd
+
+import (
+	"math"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func sliceClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// numericGrad estimates the gradient of f at x by central differences
+func numericGrad(f func(x *Variable) *Variable, x *tensor.NDArray) []float64 {
+	values := x.ToSliceFloat64()
+	grad := make([]float64, len(values))
+	const h = 1e-6
+	for i := range values {
+		at := func(d float64) float64 {
+			shifted := append([]float64{}, values...)
+			shifted[i] += d
+			return f(Constant(tensor.FromSliceFloat64(shifted, x.Shape()...))).Value.GetFloat64(0)
+		}
+		grad[i] = (at(h) - at(-h)) / (2 * h)
+	}
+	return grad
+}
+
+func TestGradients(t *testing.T) {
+	x := tensor.FromSliceFloat64([]float64{0.5, 1.2, 2.0, 0.3, 0.9, 1.7}, 2, 3)
+	w := Constant(tensor.FromSliceFloat64([]float64{1, -2, 0.5, 3, -1, 2}, 2, 3))
+	row := Constant(tensor.FromSliceFloat64([]float64{0.2, -0.7, 1.5}, 3))
+	m := Constant(tensor.FromSliceFloat64([]float64{1, 2, -1, 0.5, 0, 3}, 3, 2))
+	
+	cases := []struct {
+		name string
+		f    func(x *Variable) *Variable
+	}{
+		{"Add", func(x *Variable) *Variable { return x.Add(row).Mul(w).Sum() }},
+		{"Sub", func(x *Variable) *Variable { return row.Sub(x).Mul(w).Sum() }},
+		{"Mul", func(x *Variable) *Variable { return x.Mul(x).Mul(w).Sum() }},
+		{"Div", func(x *Variable) *Variable { return row.Div(x).Add(x.Div(Scalar(3))).Mul(w).Sum() }},
+		{"MatMul", func(x *Variable) *Variable { return x.MatMul(m).Pow(2).Sum() }},
+		{"Pow", func(x *Variable) *Variable { return x.Pow(3).Mul(w).Sum() }},
+		{"Sqrt", func(x *Variable) *Variable { return x.Sqrt().Mul(w).Sum() }},
+		{"Exp", func(x *Variable) *Variable { return x.Exp().Mul(w).Mean() }},
+		{"Log", func(x *Variable) *Variable { return x.Log().Mul(w).Sum() }},
+		{"Sin", func(x *Variable) *Variable { return x.Sin().Mul(w).Sum() }},
+		{"Cos", func(x *Variable) *Variable { return x.Cos().Mul(w).Sum() }},
+		{"Tanh", func(x *Variable) *Variable { return x.Tanh().Mul(w).Sum() }},
+		{"Sigmoid", func(x *Variable) *Variable { return x.Sigmoid().Mul(w).Sum() }},
+		{"ReLU", func(x *Variable) *Variable { return x.AddScalar(-1).ReLU().Mul(w).Sum() }},
+		{"SumAxis", func(x *Variable) *Variable { return x.SumAxis(-1).Pow(2).Sum() }},
+		{"MeanAxis", func(x *Variable) *Variable { return x.MeanAxis(0).Mul(row).Sum() }},
+		{"Reshape", func(x *Variable) *Variable { return x.Reshape(3, 2).T().Mul(w).Sum() }},
+		{"Neg", func(x *Variable) *Variable { return x.Neg().MulScalar(2).Mul(w).Sum() }},
+	}
+	for _, c := range cases {
+		v := NewVariable(x)
+		c.f(v).Backward()
+		if want := numericGrad(c.f, x); !sliceClose(v.Grad.ToSliceFloat64(), want, 1e-5) {
+			t.Errorf("%s: expected %v, got %v", c.name, want, v.Grad.ToSliceFloat64())
+		}
+	}
+}
+
+func TestBackwardAccumulates(t *testing.T) {
+	x := NewVariable(tensor.FromSliceFloat64([]float64{3}, 1))
+	y := x.Mul(x).Add(x)
+	y.Backward()
+	if got := x.Grad.GetFloat64(0); got != 7 {
+		t.Errorf("expected d(x²+x)/dx = 7, got %f", got)
+	}
+	y.Backward()
+	if got := x.Grad.GetFloat64(0); got != 14 {
+		t.Errorf("expected gradients to accumulate to 14, got %f", got)
+	}
+	x.ZeroGrad()
+	if x.Grad != nil {
+		t.Error("expected ZeroGrad to clear the gradient")
+	}
+	
+	c := Constant(tensor.FromSliceFloat64([]float64{1, 2}, 2))
+	c.Mul(c).Sum().Backward()
+	if c.Grad != nil || c.RequiresGrad() {
+		t.Error("expected no gradient for a constant")
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a non-scalar output")
+		}
+	}()
+	NewVariable(tensor.FromSliceFloat64([]float64{1, 2}, 2)).MulScalar(2).Backward()
+}
+
+func TestLinearRegression(t *testing.T) {
+	// Fit y = 2x - 1 by gradient descent on the mean squared error
+	x := Constant(tensor.FromSliceFloat64([]float64{0, 1, 2, 3, 4}, 5, 1))
+	y := Constant(tensor.FromSliceFloat64([]float64{-1, 1, 3, 5, 7}, 5, 1))
+	w := NewVariable(tensor.Zeros([]int{1, 1}, tensor.Float64))
+	b := NewVariable(tensor.Zeros([]int{1}, tensor.Float64))
+	for step := 0; step < 2000; step++ {
+		w.ZeroGrad()
+		b.ZeroGrad()
+		x.MatMul(w).Add(b).Sub(y).Pow(2).Mean().Backward()
+		w.Value = w.Value.Sub(w.Grad.MulScalar(0.05))
+		b.Value = b.Value.Sub(b.Grad.MulScalar(0.05))
+	}
+	if got := []float64{w.Value.GetFloat64(0, 0), b.Value.GetFloat64(0)}; !sliceClose(got, []float64{2, -1}, 1e-6) {
+		t.Errorf("expected w = 2, b = -1, got %v", got)
+	}
+}
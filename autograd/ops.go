@@ -0,0 +1,206 @@
+package autograd
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/linalg"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Add returns v + w with NumPy broadcasting
+func (v *Variable) Add(w *Variable) *Variable {
+	return record(v.Value.Add(w.Value), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g, g}
+	}, v, w)
+}
+
+// Sub returns v - w with NumPy broadcasting
+func (v *Variable) Sub(w *Variable) *Variable {
+	return record(v.Value.Sub(w.Value), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g, g.Neg()}
+	}, v, w)
+}
+
+// Mul returns the elementwise product v * w with NumPy broadcasting
+func (v *Variable) Mul(w *Variable) *Variable {
+	return record(v.Value.Mul(w.Value), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Mul(w.Value), g.Mul(v.Value)}
+	}, v, w)
+}
+
+// Div returns the elementwise quotient v / w with NumPy broadcasting
+func (v *Variable) Div(w *Variable) *Variable {
+	return record(v.Value.Div(w.Value), func(g *tensor.NDArray) []*tensor.NDArray {
+		gw := g.Mul(v.Value).Div(w.Value.Mul(w.Value)).Neg()
+		return []*tensor.NDArray{g.Div(w.Value), gw}
+	}, v, w)
+}
+
+// Neg returns -v
+func (v *Variable) Neg() *Variable {
+	return record(v.Value.Neg(), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Neg()}
+	}, v)
+}
+
+// AddScalar returns v + c
+func (v *Variable) AddScalar(c float64) *Variable {
+	return record(v.Value.AddScalar(c), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g}
+	}, v)
+}
+
+// MulScalar returns v * c
+func (v *Variable) MulScalar(c float64) *Variable {
+	return record(v.Value.MulScalar(c), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.MulScalar(c)}
+	}, v)
+}
+
+// MatMul returns the matrix product of the 2D variables v and w
+func (v *Variable) MatMul(w *Variable) *Variable {
+	return record(linalg.MatMul(v.Value, w.Value), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{linalg.MatMul(g, w.Value.T()), linalg.MatMul(v.Value.T(), g)}
+	}, v, w)
+}
+
+// Pow returns v raised elementwise to the power p
+func (v *Variable) Pow(p float64) *Variable {
+	return record(apply(v.Value, func(x float64) float64 { return math.Pow(x, p) }), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Mul(apply(v.Value, func(x float64) float64 { return p * math.Pow(x, p-1) }))}
+	}, v)
+}
+
+// Sqrt returns the elementwise square root of v
+func (v *Variable) Sqrt() *Variable {
+	out := apply(v.Value, math.Sqrt)
+	return record(out, func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Div(out.MulScalar(2))}
+	}, v)
+}
+
+// Exp returns the elementwise exponential of v
+func (v *Variable) Exp() *Variable {
+	out := apply(v.Value, math.Exp)
+	return record(out, func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Mul(out)}
+	}, v)
+}
+
+// Log returns the elementwise natural logarithm of v
+func (v *Variable) Log() *Variable {
+	return record(apply(v.Value, math.Log), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Div(v.Value)}
+	}, v)
+}
+
+// Sin returns the elementwise sine of v
+func (v *Variable) Sin() *Variable {
+	return record(apply(v.Value, math.Sin), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Mul(apply(v.Value, math.Cos))}
+	}, v)
+}
+
+// Cos returns the elementwise cosine of v
+func (v *Variable) Cos() *Variable {
+	return record(apply(v.Value, math.Cos), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Mul(apply(v.Value, math.Sin)).Neg()}
+	}, v)
+}
+
+// Tanh returns the elementwise hyperbolic tangent of v
+func (v *Variable) Tanh() *Variable {
+	out := apply(v.Value, math.Tanh)
+	return record(out, func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Mul(apply(out, func(y float64) float64 { return 1 - y*y }))}
+	}, v)
+}
+
+// Sigmoid returns the elementwise logistic function 1 / (1 + exp(-v))
+func (v *Variable) Sigmoid() *Variable {
+	out := apply(v.Value, func(x float64) float64 { return 1 / (1 + math.Exp(-x)) })
+	return record(out, func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Mul(apply(out, func(y float64) float64 { return y * (1 - y) }))}
+	}, v)
+}
+
+// ReLU returns the elementwise maximum of v and zero
+func (v *Variable) ReLU() *Variable {
+	return record(apply(v.Value, func(x float64) float64 { return math.Max(x, 0) }), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Mul(apply(v.Value, func(x float64) float64 {
+			if x > 0 {
+				return 1
+			}
+			return 0
+		}))}
+	}, v)
+}
+
+// Sum returns the sum of all elements as a one-element variable
+func (v *Variable) Sum() *Variable {
+	return record(tensor.FromSliceFloat64([]float64{v.Value.Sum()}, 1), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{tensor.Full(v.Value.Shape(), g.GetFloat64(0), tensor.Float64)}
+	}, v)
+}
+
+// Mean returns the mean of all elements as a one-element variable
+func (v *Variable) Mean() *Variable {
+	n := float64(v.Value.Size())
+	return record(tensor.FromSliceFloat64([]float64{v.Value.Sum() / n}, 1), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{tensor.Full(v.Value.Shape(), g.GetFloat64(0)/n, tensor.Float64)}
+	}, v)
+}
+
+// SumAxis sums v along axis, removing it. As with NDArray.SumAxis, summing
+// a 1D variable gives one element.
+func (v *Variable) SumAxis(axis int) *Variable {
+	ax := normalizeAxis(v, axis)
+	return record(v.Value.SumAxis(ax), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{expandAxis(g, v.Value.Shape(), ax)}
+	}, v)
+}
+
+// MeanAxis averages v along axis, removing it
+func (v *Variable) MeanAxis(axis int) *Variable {
+	ax := normalizeAxis(v, axis)
+	n := float64(v.Value.Shape()[ax])
+	return record(v.Value.SumAxis(ax).MulScalar(1/n), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{expandAxis(g.MulScalar(1/n), v.Value.Shape(), ax)}
+	}, v)
+}
+
+// Reshape returns v with a new shape of the same size
+func (v *Variable) Reshape(shape ...int) *Variable {
+	return record(v.Value.Reshape(shape...), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.Reshape(v.Value.Shape()...)}
+	}, v)
+}
+
+// T returns the transpose of v, reversing its axes
+func (v *Variable) T() *Variable {
+	return record(v.Value.T(), func(g *tensor.NDArray) []*tensor.NDArray {
+		return []*tensor.NDArray{g.T()}
+	}, v)
+}
+
+// normalizeAxis resolves a possibly negative axis against v's dimensions
+func normalizeAxis(v *Variable, axis int) int {
+	ax := axis
+	if ax < 0 {
+		ax += v.Value.Ndim()
+	}
+	if ax < 0 || ax >= v.Value.Ndim() {
+		panic(fmt.Sprintf("axis %d is out of bounds for array of dimension %d", axis, v.Value.Ndim()))
+	}
+	return ax
+}
+
+// expandAxis broadcasts the gradient of a reduction along axis back to the
+// shape of its input
+func expandAxis(g *tensor.NDArray, shape []int, axis int) *tensor.NDArray {
+	if len(shape) > 1 {
+		g = g.ExpandDims(axis)
+	}
+	return tensor.Zeros(shape, tensor.Float64).Add(g)
+}
@@ -0,0 +1,151 @@
+// Package bigfloat provides arrays of arbitrary-precision floats backed by
+// math/big, for checking ill-conditioned float64 computations at higher
+// precision
+package bigfloat
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// DefaultPrec is the mantissa precision in bits used when 0 is given
+const DefaultPrec = 256
+
+// Array is an n-dimensional array of big.Float values with a fixed
+// precision. Elements are stored in row-major order.
+type Array struct {
+	data  []*big.Float
+	shape []int
+	prec  uint
+}
+
+// New creates an array of zeros with the given precision in bits
+func New(shape []int, prec uint) *Array {
+	if prec == 0 {
+		prec = DefaultPrec
+	}
+	size := 1
+	for _, dim := range shape {
+		if dim < 0 {
+			panic(fmt.Sprintf("negative dimension in shape %v", shape))
+		}
+		size *= dim
+	}
+	data := make([]*big.Float, size)
+	for i := range data {
+		data[i] = new(big.Float).SetPrec(prec)
+	}
+	return &Array{data: data, shape: append([]int(nil), shape...), prec: prec}
+}
+
+// FromNDArray converts a real array without rounding. Integers, including
+// Uint64 values above MaxInt64, are exact as long as prec is at least 64.
+func FromNDArray(a *tensor.NDArray, prec uint) *Array {
+	if a.DType().IsComplex() {
+		panic(fmt.Sprintf("cannot convert %s array to big floats", a.DType()))
+	}
+	out := New(a.Shape(), prec)
+	switch {
+	case a.DType() == tensor.Uint64:
+		for i, v := range a.ToSliceInt64() {
+			out.data[i].SetUint64(uint64(v))
+		}
+	case a.DType().IsInt() || a.DType() == tensor.Bool:
+		for i, v := range a.ToSliceInt64() {
+			out.data[i].SetInt64(v)
+		}
+	default:
+		for i, v := range a.ToSliceFloat64() {
+			if math.IsNaN(v) {
+				panic(fmt.Sprintf("cannot convert NaN at flat index %d to a big float", i))
+			}
+			out.data[i].SetFloat64(v)
+		}
+	}
+	return out
+}
+
+// FromStrings parses decimal or hexadecimal floats such as "0.1" or "1e-30",
+// which keeps values that float64 cannot represent exactly
+func FromStrings(values []string, prec uint, shape ...int) (*Array, error) {
+	out := New(shape, prec)
+	if len(values) != len(out.data) {
+		return nil, fmt.Errorf("cannot reshape %d values into shape %v", len(values), shape)
+	}
+	for i, s := range values {
+		if _, _, err := out.data[i].Parse(s, 0); err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return out, nil
+}
+
+// Shape returns the shape of the array
+func (a *Array) Shape() []int {
+	return append([]int(nil), a.shape...)
+}
+
+// Size returns the number of elements
+func (a *Array) Size() int {
+	return len(a.data)
+}
+
+// Prec returns the mantissa precision in bits
+func (a *Array) Prec() uint {
+	return a.prec
+}
+
+// WithPrec returns a copy rounded to a new precision
+func (a *Array) WithPrec(prec uint) *Array {
+	out := New(a.shape, prec)
+	for i, x := range a.data {
+		out.data[i].Set(x)
+	}
+	return out
+}
+
+// At returns a copy of the element at the given indices
+func (a *Array) At(indices ...int) *big.Float {
+	return new(big.Float).Copy(a.data[a.flatIndex(indices)])
+}
+
+// Set stores x, rounded to the precision of a, at the given indices
+func (a *Array) Set(x *big.Float, indices ...int) {
+	a.data[a.flatIndex(indices)].Set(x)
+}
+
+// ToNDArray rounds every element to the nearest float64
+func (a *Array) ToNDArray() *tensor.NDArray {
+	out := make([]float64, len(a.data))
+	for i, x := range a.data {
+		out[i], _ = x.Float64()
+	}
+	return tensor.FromSliceFloat64(out, a.shape...)
+}
+
+// Text formats every element with big.Float.Text, e.g. Text('g', 40)
+func (a *Array) Text(format byte, digits int) []string {
+	out := make([]string, len(a.data))
+	for i, x := range a.data {
+		out[i] = x.Text(format, digits)
+	}
+	return out
+}
+
+// flatIndex returns the row-major position of the element at indices
+func (a *Array) flatIndex(indices []int) int {
+	if len(indices) != len(a.shape) {
+		panic(fmt.Sprintf("expected %d indices, got %d", len(a.shape), len(indices)))
+	}
+	flat := 0
+	for i, idx := range indices {
+		if idx < 0 || idx >= a.shape[i] {
+			panic(fmt.Sprintf("index %d out of bounds for axis %d with size %d", idx, i, a.shape[i]))
+		}
+		flat = flat*a.shape[i] + idx
+	}
+	return flat
+}
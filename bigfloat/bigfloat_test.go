@@ -0,0 +1,72 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func TestSumCancellation(t *testing.T) {
+	// float64 loses the 1 entirely
+	a := tensor.FromSliceFloat64([]float64{1e20, 1, -1e20}, 3)
+	if got, _ := FromNDArray(a, 0).Sum().Float64(); got != 1 {
+		t.Errorf("expected 1, got %v", got)
+	}
+	
+	// Integers above 2^53 stay exact
+	u := tensor.FromSliceInt64([]int64{1<<62 + 1}, 1)
+	if got := FromNDArray(u, 128).At(0).Text('f', 0); got != "4611686018427387905" {
+		t.Errorf("expected 4611686018427387905, got %s", got)
+	}
+}
+
+func TestElementwise(t *testing.T) {
+	a, err := FromStrings([]string{"0.1", "0.2", "0.3", "0.4"}, 200, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	row, _ := FromStrings([]string{"10", "100"}, 100, 2)
+	prod := a.Mul(row)
+	if prod.Prec() != 200 || prod.Shape()[0] != 2 {
+		t.Fatalf("expected 2x2 product at 200 bits, got %v at %d", prod.Shape(), prod.Prec())
+	}
+	want := []string{"1", "20", "3", "40"}
+	for i, s := range prod.Text('g', 30) {
+		if s != want[i] {
+			t.Errorf("element %d: expected %s, got %s", i, want[i], s)
+		}
+	}
+	
+	if got := a.SumAxis(0).Text('g', 20); got[0] != "0.4" || got[1] != "0.6" {
+		t.Errorf("expected [0.4 0.6], got %v", got)
+	}
+	if got := a.Sub(a).Max().Sign(); got != 0 {
+		t.Errorf("expected x-x = 0, got sign %d", got)
+	}
+	two, _ := FromStrings([]string{"2"}, 0, 1)
+	if got, _ := two.Sqrt().Mul(two.Sqrt()).Sub(two).Abs().Max().Float64(); got > 1e-70 {
+		t.Errorf("sqrt(2)^2 - 2 too large at 256 bits: %v", got)
+	}
+	if got := two.Div(New([]int{1}, 0)).At(0); !got.IsInf() {
+		t.Errorf("expected +Inf, got %v", got)
+	}
+	if _, err := FromStrings([]string{"x"}, 0, 1); err == nil {
+		t.Error("expected parse error")
+	}
+}
+
+func TestMatMul(t *testing.T) {
+	// Hilbert-like residual: A*x - b vanishes at high precision
+	a := FromNDArray(tensor.FromSliceFloat64([]float64{1, 0.5, 0.5, 1.0 / 3}, 2, 2), 0)
+	x := FromNDArray(tensor.FromSliceFloat64([]float64{1, 1}, 2, 1), 0)
+	got := MatMul(a, x).ToNDArray().ToSliceFloat64()
+	if got[0] != 1.5 || math.Abs(got[1]-(0.5+1.0/3)) > 1e-15 {
+		t.Errorf("unexpected product %v", got)
+	}
+	d := Dot(FromNDArray(tensor.FromSliceFloat64([]float64{3, 4}, 2), 0), FromNDArray(tensor.FromSliceFloat64([]float64{3, 4}, 2), 0))
+	if d.Cmp(big.NewFloat(25)) != 0 {
+		t.Errorf("expected 25, got %v", d)
+	}
+}
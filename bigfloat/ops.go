@@ -0,0 +1,221 @@
+package bigfloat
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Add performs element-wise addition with broadcasting. The result has the
+// larger precision of the two operands, as do all binary operations.
+func (a *Array) Add(b *Array) *Array {
+	return a.binary(b, (*big.Float).Add)
+}
+
+// Sub performs element-wise subtraction with broadcasting
+func (a *Array) Sub(b *Array) *Array {
+	return a.binary(b, (*big.Float).Sub)
+}
+
+// Mul performs element-wise multiplication with broadcasting
+func (a *Array) Mul(b *Array) *Array {
+	return a.binary(b, (*big.Float).Mul)
+}
+
+// Div performs element-wise division with broadcasting. A nonzero value
+// divided by zero gives ±Inf; big.Float has no NaN, so 0/0 panics.
+func (a *Array) Div(b *Array) *Array {
+	return a.binary(b, (*big.Float).Quo)
+}
+
+// Neg returns the element-wise negation
+func (a *Array) Neg() *Array {
+	return a.unary((*big.Float).Neg)
+}
+
+// Abs returns the element-wise absolute value
+func (a *Array) Abs() *Array {
+	return a.unary((*big.Float).Abs)
+}
+
+// Sqrt returns the element-wise square root. Negative elements panic.
+func (a *Array) Sqrt() *Array {
+	return a.unary((*big.Float).Sqrt)
+}
+
+// Sum returns the sum of all elements at the precision of a
+func (a *Array) Sum() *big.Float {
+	sum := new(big.Float).SetPrec(a.prec)
+	for _, x := range a.data {
+		sum.Add(sum, x)
+	}
+	return sum
+}
+
+// Prod returns the product of all elements at the precision of a
+func (a *Array) Prod() *big.Float {
+	prod := new(big.Float).SetPrec(a.prec).SetInt64(1)
+	for _, x := range a.data {
+		prod.Mul(prod, x)
+	}
+	return prod
+}
+
+// Mean returns the mean of all elements
+func (a *Array) Mean() *big.Float {
+	if len(a.data) == 0 {
+		panic("mean of empty array")
+	}
+	sum := a.Sum()
+	return sum.Quo(sum, new(big.Float).SetInt64(int64(len(a.data))))
+}
+
+// Min returns a copy of the smallest element
+func (a *Array) Min() *big.Float {
+	return a.extreme(-1)
+}
+
+// Max returns a copy of the largest element
+func (a *Array) Max() *big.Float {
+	return a.extreme(1)
+}
+
+// SumAxis sums along an axis, removing it from the shape
+func (a *Array) SumAxis(axis int) *Array {
+	if axis < 0 {
+		axis += len(a.shape)
+	}
+	if axis < 0 || axis >= len(a.shape) {
+		panic(fmt.Sprintf("axis %d out of bounds for %d-D array", axis, len(a.shape)))
+	}
+	outer, n, inner := 1, a.shape[axis], 1
+	for _, dim := range a.shape[:axis] {
+		outer *= dim
+	}
+	for _, dim := range a.shape[axis+1:] {
+		inner *= dim
+	}
+	shape := append(append([]int(nil), a.shape[:axis]...), a.shape[axis+1:]...)
+	out := New(shape, a.prec)
+	for o := 0; o < outer; o++ {
+		for k := 0; k < n; k++ {
+			for i := 0; i < inner; i++ {
+				sum := out.data[o*inner+i]
+				sum.Add(sum, a.data[(o*n+k)*inner+i])
+			}
+		}
+	}
+	return out
+}
+
+// Dot returns the inner product of two 1D arrays of the same length
+func Dot(a, b *Array) *big.Float {
+	if len(a.shape) != 1 || len(b.shape) != 1 || a.shape[0] != b.shape[0] {
+		panic(fmt.Sprintf("dot product requires 1D arrays of the same length, got shapes %v and %v", a.shape, b.shape))
+	}
+	sum := new(big.Float).SetPrec(max(a.prec, b.prec))
+	term := new(big.Float).SetPrec(sum.Prec())
+	for i, x := range a.data {
+		sum.Add(sum, term.Mul(x, b.data[i]))
+	}
+	return sum
+}
+
+// MatMul multiplies two 2D arrays
+func MatMul(a, b *Array) *Array {
+	if len(a.shape) != 2 || len(b.shape) != 2 || a.shape[1] != b.shape[0] {
+		panic(fmt.Sprintf("cannot multiply arrays of shapes %v and %v", a.shape, b.shape))
+	}
+	m, k, n := a.shape[0], a.shape[1], b.shape[1]
+	out := New([]int{m, n}, max(a.prec, b.prec))
+	term := new(big.Float).SetPrec(out.prec)
+	for i := 0; i < m; i++ {
+		for p := 0; p < k; p++ {
+			x := a.data[i*k+p]
+			for j := 0; j < n; j++ {
+				sum := out.data[i*n+j]
+				sum.Add(sum, term.Mul(x, b.data[p*n+j]))
+			}
+		}
+	}
+	return out
+}
+
+// binary applies op element-wise with numpy broadcasting rules
+func (a *Array) binary(b *Array, op func(z, x, y *big.Float) *big.Float) *Array {
+	ndim := max(len(a.shape), len(b.shape))
+	shape := make([]int, ndim)
+	for i := range shape {
+		da, db := dimFromEnd(a.shape, ndim-1-i), dimFromEnd(b.shape, ndim-1-i)
+		switch {
+		case da == db || db == 1:
+			shape[i] = da
+		case da == 1:
+			shape[i] = db
+		default:
+			panic(fmt.Sprintf("shapes %v and %v cannot be broadcast together", a.shape, b.shape))
+		}
+	}
+	
+	out := New(shape, max(a.prec, b.prec))
+	ai, bi := sourceIndices(a.shape, shape), sourceIndices(b.shape, shape)
+	for i, z := range out.data {
+		op(z, a.data[ai[i]], b.data[bi[i]])
+	}
+	return out
+}
+
+// unary applies op to every element
+func (a *Array) unary(op func(z, x *big.Float) *big.Float) *Array {
+	out := New(a.shape, a.prec)
+	for i, z := range out.data {
+		op(z, a.data[i])
+	}
+	return out
+}
+
+// extreme returns the smallest (sign -1) or largest (sign 1) element
+func (a *Array) extreme(sign int) *big.Float {
+	if len(a.data) == 0 {
+		panic("zero-size array has no minimum or maximum")
+	}
+	best := a.data[0]
+	for _, x := range a.data[1:] {
+		if x.Cmp(best) == sign {
+			best = x
+		}
+	}
+	return new(big.Float).Copy(best)
+}
+
+// dimFromEnd returns the length of the axis k places from the end of shape,
+// or 1 if shape has fewer axes
+func dimFromEnd(shape []int, k int) int {
+	if k >= len(shape) {
+		return 1
+	}
+	return shape[len(shape)-1-k]
+}
+
+// sourceIndices maps each element of an array of shape target to the flat
+// index it reads from an array of shape src broadcast to target
+func sourceIndices(src, target []int) []int {
+	size := 1
+	for _, dim := range target {
+		size *= dim
+	}
+	out := make([]int, size)
+	offset := len(target) - len(src)
+	for i := range out {
+		rem, flat, stride := i, 0, 1
+		for axis := len(target) - 1; axis >= offset; axis-- {
+			idx := rem % target[axis]
+			rem /= target[axis]
+			if dim := src[axis-offset]; dim != 1 {
+				flat += idx * stride
+				stride *= dim
+			}
+		}
+		out[i] = flat
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package cluster
+
+import (
+	"math"
+	"sort"
+	"testing"
+	
+	"github.com/iSundram/NumGo/random"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// blobs returns 50 points around each of the given centres
+func blobs(rng *random.Generator, centres [][2]float64) *tensor.NDArray {
+	noise := rng.Normal(0, 0.3, 50*len(centres), 2).ToSliceFloat64()
+	for i := range noise {
+		noise[i] += centres[i/100][i%2]
+	}
+	return tensor.FromSliceFloat64(noise, 50*len(centres), 2)
+}
+
+func TestKMeans(t *testing.T) {
+	centres := [][2]float64{{0, 0}, {10, 0}, {0, 10}}
+	data := blobs(random.New(3), centres)
+	r := KMeans(data, 3, KMeansOptions{NInit: 3, Rand: random.New(5)})
+	
+	if !r.Converged || r.Iterations < 1 {
+		t.Errorf("expected convergence, got %d iterations", r.Iterations)
+	}
+	// Each blob is one cluster, with its centroid near the true centre
+	labels := r.Labels.ToSliceInt64()
+	for b, centre := range centres {
+		l := labels[50*b]
+		for i := 50 * b; i < 50*(b+1); i++ {
+			if labels[i] != l {
+				t.Fatalf("blob %d is split across clusters: %v", b, labels[50*b:50*(b+1)])
+			}
+		}
+		cx, cy := r.Centroids.GetFloat64(int(l), 0), r.Centroids.GetFloat64(int(l), 1)
+		if math.Hypot(cx-centre[0], cy-centre[1]) > 0.2 {
+			t.Errorf("blob %d: expected a centroid near %v, got (%f, %f)", b, centre, cx, cy)
+		}
+	}
+	
+	inertia := 0.0
+	x := data.ToSliceFloat64()
+	for i, l := range labels {
+		dx := x[2*i] - r.Centroids.GetFloat64(int(l), 0)
+		dy := x[2*i+1] - r.Centroids.GetFloat64(int(l), 1)
+		inertia += dx*dx + dy*dy
+	}
+	if math.Abs(inertia-r.Inertia) > 1e-9 {
+		t.Errorf("expected inertia %f, got %f", inertia, r.Inertia)
+	}
+	
+	pred := r.Predict(tensor.FromSliceFloat64([]float64{9, 1, -1, 9}, 2, 2)).ToSliceInt64()
+	if pred[0] != labels[50] || pred[1] != labels[100] {
+		t.Errorf("expected predictions [%d %d], got %v", labels[50], labels[100], pred)
+	}
+}
+
+func TestKMeansDuplicates(t *testing.T) {
+	// Fewer distinct points than clusters still gives k centroids
+	data := tensor.FromSliceFloat64([]float64{1, 1, 1, 1, 5, 5}, 3, 2)
+	r := KMeans(data, 3, KMeansOptions{Rand: random.New(1)})
+	if r.Inertia != 0 || r.Centroids.Shape()[0] != 3 {
+		t.Errorf("expected zero inertia with 3 centroids, got %f and %v", r.Inertia, r.Centroids.Shape())
+	}
+	got := r.Centroids.ToSliceFloat64()
+	rows := []float64{got[0] + got[1], got[2] + got[3], got[4] + got[5]}
+	sort.Float64s(rows)
+	if rows[0] != 2 || rows[2] != 10 {
+		t.Errorf("expected centroids at the data points, got %v", got)
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for k larger than the number of points")
+		}
+	}()
+	KMeans(data, 4, KMeansOptions{})
+}
@@ -0,0 +1,226 @@
+// Package cluster provides clustering algorithms for NumGo arrays
+package cluster
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/random"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// KMeansOptions controls KMeans. The zero value runs k-means++ once with a
+// time-seeded generator.
+type KMeansOptions struct {
+	// MaxIter bounds the iterations of each run. Zero selects 300.
+	MaxIter int
+	// Tol stops a run once the summed squared movement of the centroids
+	// is at most Tol times the mean variance of the features. Zero
+	// selects 1e-4.
+	Tol float64
+	// NInit is the number of runs from different seeds; the run with the
+	// lowest inertia is returned. Zero selects 1.
+	NInit int
+	// Rand draws the k-means++ seeds. Nil selects random.NewDefault().
+	Rand *random.Generator
+}
+
+// KMeansResult reports the clustering found by KMeans
+type KMeansResult struct {
+	// Centroids holds the cluster centres, shape (k, d)
+	Centroids *tensor.NDArray
+	// Labels holds the cluster of each point, an Int64 array of shape (n)
+	Labels *tensor.NDArray
+	// Inertia is the sum of squared distances from the points to their
+	// centroids
+	Inertia float64
+	// Iterations is the number of iterations of the returned run
+	Iterations int
+	// Converged reports whether the returned run reached the tolerance
+	Converged bool
+}
+
+// KMeans partitions the rows of data, a 2D array of shape (n, d), into k
+// clusters with Lloyd's algorithm from k-means++ initial centroids, like
+// sklearn.cluster.KMeans
+func KMeans(data *tensor.NDArray, k int, opts KMeansOptions) *KMeansResult {
+	if data.Ndim() != 2 {
+		panic(fmt.Sprintf("KMeans requires a 2D array of points, got shape %v", data.Shape()))
+	}
+	n, d := data.Shape()[0], data.Shape()[1]
+	if k < 1 || k > n {
+		panic(fmt.Sprintf("k = %d must be between 1 and the number of points %d", k, n))
+	}
+	if opts.MaxIter == 0 {
+		opts.MaxIter = 300
+	}
+	if opts.Tol == 0 {
+		opts.Tol = 1e-4
+	}
+	if opts.NInit == 0 {
+		opts.NInit = 1
+	}
+	if opts.Rand == nil {
+		opts.Rand = random.NewDefault()
+	}
+	
+	x := data.ToSliceFloat64()
+	tol := opts.Tol * meanVariance(x, n, d)
+	var best *KMeansResult
+	for run := 0; run < opts.NInit; run++ {
+		r := lloyd(x, n, d, kMeansPlusPlus(x, n, d, k, opts.Rand), opts.MaxIter, tol)
+		if best == nil || r.Inertia < best.Inertia {
+			best = r
+		}
+	}
+	return best
+}
+
+// Predict returns the index of the nearest centroid to each row of x, an
+// array of shape (m, d), as an Int64 array of shape (m)
+func (r *KMeansResult) Predict(x *tensor.NDArray) *tensor.NDArray {
+	k, d := r.Centroids.Shape()[0], r.Centroids.Shape()[1]
+	if x.Ndim() != 2 || x.Shape()[1] != d {
+		panic(fmt.Sprintf("expected points of shape (m, %d), got %v", d, x.Shape()))
+	}
+	m := x.Shape()[0]
+	labels := make([]int64, m)
+	assign(x.ToSliceFloat64(), m, d, r.Centroids.ToSliceFloat64(), k, labels, make([]float64, m))
+	return tensor.FromSliceInt64(labels, m)
+}
+
+// kMeansPlusPlus chooses k initial centroids, each after the first drawn
+// with probability proportional to its squared distance from the nearest
+// centroid already chosen
+func kMeansPlusPlus(x []float64, n, d, k int, rng *random.Generator) []float64 {
+	centroids := make([]float64, 0, k*d)
+	first := int(rng.Rand(1).GetFloat64(0) * float64(n))
+	centroids = append(centroids, x[first*d:(first+1)*d]...)
+	
+	dist := make([]float64, n)
+	for i := range dist {
+		dist[i] = sqDist(x[i*d:(i+1)*d], centroids)
+	}
+	for c := 1; c < k; c++ {
+		total := 0.0
+		for _, v := range dist {
+			total += v
+		}
+		next := n - 1
+		target := rng.Rand(1).GetFloat64(0) * total
+		for i, v := range dist {
+			target -= v
+			if target < 0 {
+				next = i
+				break
+			}
+		}
+		if total == 0 {
+			// Fewer distinct points than clusters; any point will do
+			next = c
+		}
+		center := x[next*d : (next+1)*d]
+		centroids = append(centroids, center...)
+		for i := range dist {
+			dist[i] = math.Min(dist[i], sqDist(x[i*d:(i+1)*d], center))
+		}
+	}
+	return centroids
+}
+
+// lloyd alternates assigning points to their nearest centroid and moving
+// each centroid to the mean of its points
+func lloyd(x []float64, n, d int, centroids []float64, maxIter int, tol float64) *KMeansResult {
+	k := len(centroids) / d
+	labels := make([]int64, n)
+	dist := make([]float64, n)
+	r := &KMeansResult{}
+	for r.Iterations < maxIter {
+		r.Iterations++
+		assign(x, n, d, centroids, k, labels, dist)
+		
+		sums := make([]float64, k*d)
+		counts := make([]int, k)
+		for i, l := range labels {
+			counts[l]++
+			for j := 0; j < d; j++ {
+				sums[int(l)*d+j] += x[i*d+j]
+			}
+		}
+		shift := 0.0
+		for c := 0; c < k; c++ {
+			if counts[c] == 0 {
+				// Move an empty cluster to the point farthest from its
+				// centroid
+				far := 0
+				for i := range dist {
+					if dist[i] > dist[far] {
+						far = i
+					}
+				}
+				dist[far] = 0
+				copy(sums[c*d:(c+1)*d], x[far*d:(far+1)*d])
+				counts[c] = 1
+			}
+			for j := 0; j < d; j++ {
+				v := sums[c*d+j] / float64(counts[c])
+				shift += (v - centroids[c*d+j]) * (v - centroids[c*d+j])
+				centroids[c*d+j] = v
+			}
+		}
+		if shift <= tol {
+			r.Converged = true
+			break
+		}
+	}
+	
+	r.Inertia = assign(x, n, d, centroids, k, labels, dist)
+	r.Centroids = tensor.FromSliceFloat64(centroids, k, d)
+	r.Labels = tensor.FromSliceInt64(labels, n)
+	return r
+}
+
+// assign labels each point with its nearest centroid, storing the squared
+// distance in dist, and returns the total of those distances
+func assign(x []float64, n, d int, centroids []float64, k int, labels []int64, dist []float64) float64 {
+	total := 0.0
+	for i := 0; i < n; i++ {
+		p := x[i*d : (i+1)*d]
+		best, bestDist := 0, math.Inf(1)
+		for c := 0; c < k; c++ {
+			if dd := sqDist(p, centroids[c*d:(c+1)*d]); dd < bestDist {
+				best, bestDist = c, dd
+			}
+		}
+		labels[i], dist[i] = int64(best), bestDist
+		total += bestDist
+	}
+	return total
+}
+
+// sqDist returns the squared Euclidean distance between p and the first
+// len(p) values of q
+func sqDist(p, q []float64) float64 {
+	sum := 0.0
+	for j := range p {
+		diff := p[j] - q[j]
+		sum += diff * diff
+	}
+	return sum
+}
+
+// meanVariance returns the mean over features of their population variance
+func meanVariance(x []float64, n, d int) float64 {
+	total := 0.0
+	for j := 0; j < d; j++ {
+		mean := 0.0
+		for i := 0; i < n; i++ {
+			mean += x[i*d+j]
+		}
+		mean /= float64(n)
+		for i := 0; i < n; i++ {
+			total += (x[i*d+j] - mean) * (x[i*d+j] - mean)
+		}
+	}
+	return total / float64(n*d)
+}
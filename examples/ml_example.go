@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"math"
 	
+	"github.com/iSundram/NumGo/autograd"
 	"github.com/iSundram/NumGo/linalg"
+	"github.com/iSundram/NumGo/nn"
+	"github.com/iSundram/NumGo/optimize"
 	"github.com/iSundram/NumGo/random"
 	"github.com/iSundram/NumGo/tensor"
 )
@@ -40,7 +43,7 @@ func main() {
 	XWithBias := tensor.Stack([]*tensor.NDArray{ones, X}, 1)
 	fmt.Printf("Design matrix shape: %v\n\n", XWithBias.Shape())
 	
-	// 3. Solve normal equations: beta = (X^T X)^-1 X^T y
+	// 3. Solve normal equations: (X^T X) beta = X^T y
 	fmt.Println("3. Solving normal equations...")
 	
 	// X^T X
@@ -51,11 +54,9 @@ func main() {
 	yReshaped := y.Reshape(n, 1)
 	XTy := linalg.MatMul(XT, yReshaped)
 	
-	// (X^T X)^-1
-	XTXInv := linalg.Inv(XTX)
-	
-	// beta = (X^T X)^-1 X^T y
-	beta := linalg.MatMul(XTXInv, XTy)
+	// Solve for beta directly instead of forming (X^T X)^-1,
+	// which is both faster and numerically more stable
+	beta := linalg.Solve(XTX, XTy)
 	
 	intercept := beta.GetFloat64(0, 0)
 	slope := beta.GetFloat64(1, 0)
@@ -64,6 +65,21 @@ func main() {
 	fmt.Printf("  Intercept: %.4f (true: 3.0)\n", intercept)
 	fmt.Printf("  Slope: %.4f (true: 2.0)\n\n", slope)
 	
+	// The same fit by iteratively minimizing the mean squared error, whose
+	// gradient is 2/n X^T (X beta - y)
+	fmt.Println("Refitting by minimizing the MSE with L-BFGS...")
+	loss := func(b *tensor.NDArray) float64 {
+		r := linalg.MatMul(XWithBias, b.Reshape(2, 1)).Sub(yReshaped)
+		return r.Pow(2).Mean()
+	}
+	lossGrad := func(b *tensor.NDArray) *tensor.NDArray {
+		r := linalg.MatMul(XWithBias, b.Reshape(2, 1)).Sub(yReshaped)
+		return linalg.MatMul(XT, r).MulScalar(2 / float64(n)).Reshape(2)
+	}
+	fit := optimize.LBFGS(loss, lossGrad, tensor.Zeros([]int{2}, tensor.Float64), optimize.Options{})
+	fmt.Printf("  Intercept: %.4f, Slope: %.4f after %d iterations\n\n",
+		fit.X.GetFloat64(0), fit.X.GetFloat64(1), fit.Iterations)
+	
 	// 4. Make predictions
 	fmt.Println("4. Making predictions...")
 	yPred := linalg.MatMul(XWithBias, beta)
@@ -152,5 +168,40 @@ func main() {
 	fmt.Printf("Sample shuffled indices: [%d, %d, %d, ...]\n\n",
 		indices.GetInt64(0), indices.GetInt64(1), indices.GetInt64(2))
 	
+	// 11. Train a small neural network to tell points above the true line
+	// from points below it
+	fmt.Println("11. Training a classifier with nn and Adam:")
+	labels := tensor.Zeros([]int{n}, tensor.Int64)
+	for i := 0; i < n; i++ {
+		if y.GetFloat64(i) > 2*X.GetFloat64(i)+3 {
+			labels.SetInt64(1, i)
+		}
+	}
+	// Scale the features to similar ranges so one learning rate suits both
+	features := autograd.Constant(tensor.Stack([]*tensor.NDArray{X.MulScalar(0.1), y.MulScalar(0.05)}, 1))
+	model := nn.Sequential{nn.NewLinear(2, 16, rng), nn.ReLU{}, nn.NewLinear(16, 2, rng)}
+	opt := nn.NewAdam(model.Parameters(), 0.05)
+	for epoch := 1; epoch <= 300; epoch++ {
+		opt.ZeroGrad()
+		loss := nn.SoftmaxCrossEntropy(model.Forward(features), labels)
+		loss.Backward()
+		opt.Step()
+		if epoch%100 == 0 {
+			fmt.Printf("  Epoch %d: loss %.4f\n", epoch, loss.Value.GetFloat64(0))
+		}
+	}
+	probs := nn.Softmax(model.Forward(features)).Value
+	correct := 0
+	for i := 0; i < n; i++ {
+		predicted := int64(0)
+		if probs.GetFloat64(i, 1) > 0.5 {
+			predicted = 1
+		}
+		if predicted == labels.GetInt64(i) {
+			correct++
+		}
+	}
+	fmt.Printf("  Training accuracy: %d/%d\n\n", correct, n)
+	
 	fmt.Println("=== End of advanced example ===")
 }
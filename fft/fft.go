@@ -0,0 +1,220 @@
+// Package fft provides discrete Fourier transforms for NumGo arrays
+package fft
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Norm selects how a transform pair is scaled
+type Norm int
+
+const (
+	// Backward leaves forward transforms unscaled and scales inverse
+	// transforms by 1/n
+	Backward Norm = iota
+	// Ortho scales both directions by 1/sqrt(n), making the transform unitary
+	Ortho
+	// Forward scales forward transforms by 1/n and leaves inverse transforms
+	// unscaled
+	Forward
+)
+
+// Options configures a transform along one axis
+type Options struct {
+	// N is the length of the transform along the axis. Shorter inputs are
+	// zero-padded and longer ones truncated. Zero keeps the input length,
+	// except for IRFFT where it selects 2*(m-1) for an m-point input.
+	N int
+	// Norm is the normalization mode, Backward by default
+	Norm Norm
+}
+
+// FFT computes the one-dimensional discrete Fourier transform along axis
+func FFT(a *tensor.NDArray, axis int) *tensor.NDArray {
+	return FFTWith(a, axis, Options{})
+}
+
+// FFTWith is FFT with explicit options
+func FFTWith(a *tensor.NDArray, axis int, opts Options) *tensor.NDArray {
+	ax := normalizeAxis(a, axis)
+	n := transformLength("FFT", opts.N, a.Shape()[ax])
+	scale := complex(scaleFactor(opts.Norm, n, false), 0)
+	return mapLanes(a, ax, n, n, func(lane []complex128) []complex128 {
+		return scaled(transform(lane, false), scale)
+	})
+}
+
+// IFFT computes the one-dimensional inverse discrete Fourier transform
+// along axis, so that IFFT(FFT(a)) == a
+func IFFT(a *tensor.NDArray, axis int) *tensor.NDArray {
+	return IFFTWith(a, axis, Options{})
+}
+
+// IFFTWith is IFFT with explicit options
+func IFFTWith(a *tensor.NDArray, axis int, opts Options) *tensor.NDArray {
+	ax := normalizeAxis(a, axis)
+	n := transformLength("IFFT", opts.N, a.Shape()[ax])
+	scale := complex(scaleFactor(opts.Norm, n, true), 0)
+	return mapLanes(a, ax, n, n, func(lane []complex128) []complex128 {
+		return scaled(transform(lane, true), scale)
+	})
+}
+
+// RFFT computes the discrete Fourier transform of real input along axis.
+// Only the n/2+1 non-negative frequency terms are returned, since the rest
+// follow from Hermitian symmetry.
+func RFFT(a *tensor.NDArray, axis int) *tensor.NDArray {
+	return RFFTWith(a, axis, Options{})
+}
+
+// RFFTWith is RFFT with explicit options
+func RFFTWith(a *tensor.NDArray, axis int, opts Options) *tensor.NDArray {
+	if a.DType().IsComplex() {
+		panic("RFFT requires a real array")
+	}
+	ax := normalizeAxis(a, axis)
+	n := transformLength("RFFT", opts.N, a.Shape()[ax])
+	scale := complex(scaleFactor(opts.Norm, n, false), 0)
+	return mapLanes(a, ax, n, n/2+1, func(lane []complex128) []complex128 {
+		return scaled(transform(lane, false)[:n/2+1], scale)
+	})
+}
+
+// IRFFT inverts RFFT, returning a real array. The input holds the
+// non-negative frequency terms; the imaginary parts of the zero and (for
+// even n) Nyquist terms are ignored.
+func IRFFT(a *tensor.NDArray, axis int) *tensor.NDArray {
+	return IRFFTWith(a, axis, Options{})
+}
+
+// IRFFTWith is IRFFT with explicit options. Options.N is the length of the
+// real output, which must be given to recover odd-length signals.
+func IRFFTWith(a *tensor.NDArray, axis int, opts Options) *tensor.NDArray {
+	ax := normalizeAxis(a, axis)
+	n := opts.N
+	if n == 0 {
+		n = 2 * (a.Shape()[ax] - 1)
+	}
+	n = transformLength("IRFFT", n, n)
+	scale := scaleFactor(opts.Norm, n, true)
+	
+	spectrum := mapLanes(a, ax, n/2+1, n, func(lane []complex128) []complex128 {
+		full := make([]complex128, n)
+		full[0] = complex(real(lane[0]), 0)
+		for k := 1; k < len(lane); k++ {
+			full[k] = lane[k]
+			full[n-k] = cmplx.Conj(lane[k])
+		}
+		if n%2 == 0 {
+			full[n/2] = complex(real(lane[n/2]), 0)
+		}
+		return transform(full, true)
+	})
+	
+	values := spectrum.ToSliceComplex128()
+	result := make([]float64, len(values))
+	for i, v := range values {
+		result[i] = real(v) * scale
+	}
+	return tensor.FromSliceFloat64(result, spectrum.Shape()...)
+}
+
+// scaleFactor returns the factor applied to an n-point transform in the
+// given direction under norm
+func scaleFactor(norm Norm, n int, inverse bool) float64 {
+	switch norm {
+	case Backward:
+		if inverse {
+			return 1 / float64(n)
+		}
+		return 1
+	case Ortho:
+		return 1 / math.Sqrt(float64(n))
+	case Forward:
+		if inverse {
+			return 1
+		}
+		return 1 / float64(n)
+	default:
+		panic(fmt.Sprintf("unknown normalization mode %d", norm))
+	}
+}
+
+// scaled multiplies x by scale in place and returns it
+func scaled(x []complex128, scale complex128) []complex128 {
+	if scale == 1 {
+		return x
+	}
+	for i := range x {
+		x[i] *= scale
+	}
+	return x
+}
+
+// transformLength validates the requested transform length n, falling
+// back to the axis length when n is zero
+func transformLength(name string, n, axisLen int) int {
+	if n == 0 {
+		n = axisLen
+	}
+	if n < 1 {
+		panic(fmt.Sprintf("%s: invalid number of data points %d", name, n))
+	}
+	return n
+}
+
+// mapLanes applies f to every 1D lane of a along axis. Each lane is
+// zero-padded or truncated to inLen before the call, and f must return
+// outLen values. The result is a complex128 array whose axis has length
+// outLen.
+func mapLanes(a *tensor.NDArray, axis, inLen, outLen int, f func([]complex128) []complex128) *tensor.NDArray {
+	shape := a.Shape()
+	data := a.ToSliceComplex128()
+	
+	length := shape[axis]
+	outer, inner := 1, 1
+	for _, s := range shape[:axis] {
+		outer *= s
+	}
+	for _, s := range shape[axis+1:] {
+		inner *= s
+	}
+	
+	outShape := append([]int{}, shape...)
+	outShape[axis] = outLen
+	result := make([]complex128, outer*outLen*inner)
+	
+	lane := make([]complex128, inLen)
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			for k := range lane {
+				lane[k] = 0
+				if k < length {
+					lane[k] = data[(o*length+k)*inner+i]
+				}
+			}
+			out := f(lane)
+			for k, v := range out {
+				result[(o*outLen+k)*inner+i] = v
+			}
+		}
+	}
+	
+	return tensor.FromSliceComplex128(result, outShape...)
+}
+
+// normalizeAxis resolves a possibly negative axis against a's dimensions
+func normalizeAxis(a *tensor.NDArray, axis int) int {
+	ax := axis
+	if ax < 0 {
+		ax += a.Ndim()
+	}
+	if ax < 0 || ax >= a.Ndim() {
+		panic(fmt.Sprintf("axis %d is out of bounds for array of dimension %d", axis, a.Ndim()))
+	}
+	return ax
+}
@@ -0,0 +1,287 @@
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// testSignal returns a deterministic complex signal of length n
+func testSignal(n int) []complex128 {
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(math.Sin(float64(i)*1.3)+0.25*float64(i%3), math.Cos(float64(i)*0.7))
+	}
+	return x
+}
+
+func closeComplex(a, b []complex128, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if cmplx.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFFTMatchesDFT(t *testing.T) {
+	sizes := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 12, 15, 16, 17, 18, 20, 64, 97, 202}
+	for _, n := range sizes {
+		x := testSignal(n)
+		got := FFT(tensor.FromSliceComplex128(x, n), 0).ToSliceComplex128()
+		want := naiveDFT(x, false)
+		if !closeComplex(got, want, 1e-9*float64(n)) {
+			t.Errorf("FFT of length %d does not match the direct DFT", n)
+		}
+		
+		back := IFFT(tensor.FromSliceComplex128(got, n), 0).ToSliceComplex128()
+		if !closeComplex(back, x, 1e-12*float64(n)) {
+			t.Errorf("IFFT(FFT(x)) != x for length %d", n)
+		}
+	}
+}
+
+func TestFFTKnownValues(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3, 4}, 4)
+	got := FFT(a, 0).ToSliceComplex128()
+	want := []complex128{10, complex(-2, 2), -2, complex(-2, -2)}
+	if !closeComplex(got, want, 1e-12) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if FFT(a, 0).DType() != tensor.Complex128 {
+		t.Errorf("expected complex128 output")
+	}
+}
+
+func TestFFTPadAndTruncate(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3}, 3)
+	
+	padded := FFTWith(a, 0, Options{N: 5}).ToSliceComplex128()
+	want := naiveDFT([]complex128{1, 2, 3, 0, 0}, false)
+	if !closeComplex(padded, want, 1e-12) {
+		t.Errorf("padded FFT: expected %v, got %v", want, padded)
+	}
+	
+	truncated := FFTWith(a, 0, Options{N: 2}).ToSliceComplex128()
+	if !closeComplex(truncated, []complex128{3, -1}, 1e-12) {
+		t.Errorf("truncated FFT: got %v", truncated)
+	}
+}
+
+func TestFFTAxis(t *testing.T) {
+	data := testSignal(12)
+	a := tensor.FromSliceComplex128(data, 3, 4)
+	
+	rows := FFT(a, -1)
+	cols := FFT(a, 0)
+	if s := cols.Shape(); s[0] != 3 || s[1] != 4 {
+		t.Fatalf("expected shape [3 4], got %v", s)
+	}
+	
+	for i := 0; i < 3; i++ {
+		want := naiveDFT(data[i*4:(i+1)*4], false)
+		for k := 0; k < 4; k++ {
+			if cmplx.Abs(rows.GetComplex128(i, k)-want[k]) > 1e-12 {
+				t.Fatalf("row %d: FFT along axis -1 mismatch", i)
+			}
+		}
+	}
+	for j := 0; j < 4; j++ {
+		want := naiveDFT([]complex128{data[j], data[4+j], data[8+j]}, false)
+		for k := 0; k < 3; k++ {
+			if cmplx.Abs(cols.GetComplex128(k, j)-want[k]) > 1e-12 {
+				t.Fatalf("column %d: FFT along axis 0 mismatch", j)
+			}
+		}
+	}
+}
+
+func TestRFFTRoundTrip(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 8, 9, 31} {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = math.Sin(float64(i)*0.9) + float64(i%4)
+		}
+		a := tensor.FromSliceFloat64(x, n)
+		
+		spec := RFFT(a, 0)
+		if spec.Shape()[0] != n/2+1 {
+			t.Fatalf("RFFT length %d: expected %d terms, got %d", n, n/2+1, spec.Shape()[0])
+		}
+		full := FFT(a, 0).ToSliceComplex128()
+		if !closeComplex(spec.ToSliceComplex128(), full[:n/2+1], 1e-10) {
+			t.Errorf("RFFT length %d does not match FFT", n)
+		}
+		
+		back := IRFFTWith(spec, 0, Options{N: n})
+		if back.DType() != tensor.Float64 {
+			t.Fatalf("expected float64 output from IRFFT")
+		}
+		for i, v := range back.ToSliceFloat64() {
+			if math.Abs(v-x[i]) > 1e-10 {
+				t.Errorf("IRFFT(RFFT(x)) length %d: index %d expected %f, got %f", n, i, x[i], v)
+				break
+			}
+		}
+	}
+}
+
+func TestIRFFTDefaultLength(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	out := IRFFT(RFFT(tensor.FromSliceFloat64(x, 2, 4), 1), 1)
+	if s := out.Shape(); s[0] != 2 || s[1] != 4 {
+		t.Fatalf("expected shape [2 4], got %v", s)
+	}
+	for i, v := range out.ToSliceFloat64() {
+		if math.Abs(v-x[i]) > 1e-12 {
+			t.Fatalf("index %d: expected %f, got %f", i, x[i], v)
+		}
+	}
+}
+
+func TestRFFTRejectsComplex(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for complex input")
+		}
+	}()
+	RFFT(tensor.FromSliceComplex128([]complex128{1, 2}, 2), 0)
+}
+
+func TestNormModes(t *testing.T) {
+	x := testSignal(6)
+	a := tensor.FromSliceComplex128(x, 6)
+	plain := naiveDFT(x, false)
+	
+	for _, tc := range []struct {
+		norm  Norm
+		scale float64
+	}{{Backward, 1}, {Ortho, 1 / math.Sqrt(6)}, {Forward, 1.0 / 6}} {
+		got := FFTWith(a, 0, Options{Norm: tc.norm}).ToSliceComplex128()
+		for k := range got {
+			if cmplx.Abs(got[k]-plain[k]*complex(tc.scale, 0)) > 1e-12 {
+				t.Fatalf("norm %d: index %d expected %v, got %v", tc.norm, k, plain[k]*complex(tc.scale, 0), got[k])
+			}
+		}
+		
+		spec := tensor.FromSliceComplex128(got, 6)
+		back := IFFTWith(spec, 0, Options{Norm: tc.norm}).ToSliceComplex128()
+		if !closeComplex(back, x, 1e-12) {
+			t.Errorf("norm %d: inverse does not round trip", tc.norm)
+		}
+	}
+}
+
+func TestFFT2(t *testing.T) {
+	data := testSignal(12)
+	a := tensor.FromSliceComplex128(data, 3, 4)
+	
+	got := FFT2(a)
+	want := FFT(FFT(a, 0), 1)
+	if !closeComplex(got.ToSliceComplex128(), want.ToSliceComplex128(), 1e-12) {
+		t.Errorf("FFT2 does not match successive 1D transforms")
+	}
+	
+	// Direct 2D DFT of one term
+	var sum complex128
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 4; j++ {
+			sum += data[i*4+j] * twiddle(i*1, 3, false) * twiddle(j*2, 4, false)
+		}
+	}
+	if cmplx.Abs(got.GetComplex128(1, 2)-sum) > 1e-12 {
+		t.Errorf("FFT2[1,2]: expected %v, got %v", sum, got.GetComplex128(1, 2))
+	}
+	
+	back := IFFT2(got).ToSliceComplex128()
+	if !closeComplex(back, data, 1e-12) {
+		t.Errorf("IFFT2(FFT2(a)) != a")
+	}
+}
+
+func TestFFTNWithShapeAndNorm(t *testing.T) {
+	data := testSignal(24)
+	a := tensor.FromSliceComplex128(data, 2, 3, 4)
+	
+	all := FFTN(a)
+	want := FFT(FFT(FFT(a, 0), 1), 2)
+	if !closeComplex(all.ToSliceComplex128(), want.ToSliceComplex128(), 1e-12) {
+		t.Errorf("FFTN over all axes does not match successive 1D transforms")
+	}
+	
+	ortho := FFTNWith(a, nil, NOptions{Norm: Ortho}).ToSliceComplex128()
+	scale := complex(1/math.Sqrt(24), 0)
+	for i, v := range want.ToSliceComplex128() {
+		if cmplx.Abs(ortho[i]-v*scale) > 1e-12 {
+			t.Fatalf("ortho FFTN: index %d mismatch", i)
+		}
+	}
+	
+	padded := FFTNWith(a, []int{2, 0}, NOptions{Shape: []int{6, 4}})
+	if s := padded.Shape(); s[0] != 4 || s[1] != 3 || s[2] != 6 {
+		t.Errorf("expected shape [4 3 6], got %v", s)
+	}
+}
+
+func TestFFTFreq(t *testing.T) {
+	cases := []struct {
+		n    int
+		want []float64
+	}{
+		{4, []float64{0, 1, -2, -1}},
+		{5, []float64{0, 1, 2, -2, -1}},
+	}
+	for _, c := range cases {
+		got := FFTFreq(c.n, 0.5).ToSliceFloat64()
+		for i := range c.want {
+			want := c.want[i] / (0.5 * float64(c.n))
+			if math.Abs(got[i]-want) > 1e-15 {
+				t.Errorf("FFTFreq(%d): index %d expected %f, got %f", c.n, i, want, got[i])
+			}
+		}
+	}
+	
+	r := RFFTFreq(5, 1).ToSliceFloat64()
+	if len(r) != 3 || r[2] != 0.4 {
+		t.Errorf("RFFTFreq(5, 1): got %v", r)
+	}
+}
+
+func TestFFTShift(t *testing.T) {
+	for _, n := range []int{4, 5} {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = float64(i)
+		}
+		a := tensor.FromSliceFloat64(x, n)
+		
+		shifted := FFTShift(a).ToSliceFloat64()
+		// The zero-frequency term lands at index n/2
+		if shifted[n/2] != 0 {
+			t.Errorf("FFTShift length %d: got %v", n, shifted)
+		}
+		back := IFFTShift(FFTShift(a)).ToSliceFloat64()
+		for i := range x {
+			if back[i] != x[i] {
+				t.Fatalf("IFFTShift(FFTShift(a)) length %d: got %v", n, back)
+			}
+		}
+	}
+	
+	m := tensor.FromSliceInt64([]int64{0, 1, 2, 3, 4, 5}, 2, 3)
+	rows := FFTShift(m, 1)
+	if rows.DType() != tensor.Int64 {
+		t.Fatalf("expected dtype to be preserved")
+	}
+	want := []int64{2, 0, 1, 5, 3, 4}
+	for i, v := range rows.ToSliceInt64() {
+		if v != want[i] {
+			t.Fatalf("FFTShift along axis 1: expected %v, got %v", want, rows.ToSliceInt64())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package fft
+
+import (
+	"fmt"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// NOptions configures a transform over several axes
+type NOptions struct {
+	// Shape gives the transform length for each axis, with the same padding
+	// and truncation rules as Options.N. Nil keeps the input lengths.
+	Shape []int
+	// Norm is the normalization mode, Backward by default
+	Norm Norm
+}
+
+// FFT2 computes the two-dimensional discrete Fourier transform over the
+// last two axes
+func FFT2(a *tensor.NDArray) *tensor.NDArray {
+	return FFTN(a, -2, -1)
+}
+
+// IFFT2 computes the two-dimensional inverse discrete Fourier transform
+// over the last two axes
+func IFFT2(a *tensor.NDArray) *tensor.NDArray {
+	return IFFTN(a, -2, -1)
+}
+
+// FFTN computes the N-dimensional discrete Fourier transform over the
+// given axes, or over all axes when none are given
+func FFTN(a *tensor.NDArray, axes ...int) *tensor.NDArray {
+	return FFTNWith(a, axes, NOptions{})
+}
+
+// FFTNWith is FFTN with explicit options
+func FFTNWith(a *tensor.NDArray, axes []int, opts NOptions) *tensor.NDArray {
+	return transformAxes(a, axes, opts, FFTWith)
+}
+
+// IFFTN computes the N-dimensional inverse discrete Fourier transform over
+// the given axes, or over all axes when none are given
+func IFFTN(a *tensor.NDArray, axes ...int) *tensor.NDArray {
+	return IFFTNWith(a, axes, NOptions{})
+}
+
+// IFFTNWith is IFFTN with explicit options
+func IFFTNWith(a *tensor.NDArray, axes []int, opts NOptions) *tensor.NDArray {
+	return transformAxes(a, axes, opts, IFFTWith)
+}
+
+// transformAxes applies a 1D transform along each axis in turn. The
+// per-axis scale factors multiply, so every normalization mode carries
+// over to the full transform.
+func transformAxes(a *tensor.NDArray, axes []int, opts NOptions, apply func(*tensor.NDArray, int, Options) *tensor.NDArray) *tensor.NDArray {
+	if len(axes) == 0 {
+		axes = make([]int, a.Ndim())
+		for i := range axes {
+			axes[i] = i
+		}
+	}
+	if opts.Shape != nil && len(opts.Shape) != len(axes) {
+		panic(fmt.Sprintf("shape %v does not match %d axes", opts.Shape, len(axes)))
+	}
+	
+	result := a
+	for i, axis := range axes {
+		axisOpts := Options{Norm: opts.Norm}
+		if opts.Shape != nil {
+			axisOpts.N = opts.Shape[i]
+		}
+		result = apply(result, axis, axisOpts)
+	}
+	if result == a {
+		// No axes to transform (a 0-d array); still return a complex copy
+		return tensor.FromSliceComplex128(a.ToSliceComplex128(), a.Shape()...)
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package fft
+
+import (
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// FFTFreq returns the sample frequencies of an n-point FFT with sample
+// spacing d, in the order FFT produces them:
+// [0, 1, ..., (n-1)/2, -(n/2), ..., -1] / (d*n)
+func FFTFreq(n int, d float64) *tensor.NDArray {
+	if n < 1 {
+		panic("FFTFreq requires n >= 1")
+	}
+	freqs := make([]float64, n)
+	for i := range freqs {
+		k := i
+		if i >= (n+1)/2 {
+			k = i - n
+		}
+		freqs[i] = float64(k) / (d * float64(n))
+	}
+	return tensor.FromSliceFloat64(freqs, n)
+}
+
+// RFFTFreq returns the sample frequencies of an n-point RFFT with sample
+// spacing d: [0, 1, ..., n/2] / (d*n)
+func RFFTFreq(n int, d float64) *tensor.NDArray {
+	if n < 1 {
+		panic("RFFTFreq requires n >= 1")
+	}
+	freqs := make([]float64, n/2+1)
+	for i := range freqs {
+		freqs[i] = float64(i) / (d * float64(n))
+	}
+	return tensor.FromSliceFloat64(freqs, len(freqs))
+}
+
+// FFTShift moves the zero-frequency term to the centre of the spectrum
+// along the given axes, or all axes when none are given
+func FFTShift(a *tensor.NDArray, axes ...int) *tensor.NDArray {
+	return rollAxes(a, axes, func(n int) int { return n / 2 })
+}
+
+// IFFTShift undoes FFTShift, including for odd lengths
+func IFFTShift(a *tensor.NDArray, axes ...int) *tensor.NDArray {
+	return rollAxes(a, axes, func(n int) int { return -(n / 2) })
+}
+
+// rollAxes returns a copy of a with each listed axis rolled forward by
+// shift(length) positions
+func rollAxes(a *tensor.NDArray, axes []int, shift func(int) int) *tensor.NDArray {
+	shape := a.Shape()
+	shifts := make([]int, len(shape))
+	if len(axes) == 0 {
+		for ax, n := range shape {
+			shifts[ax] = shift(n)
+		}
+	}
+	for _, axis := range axes {
+		ax := normalizeAxis(a, axis)
+		shifts[ax] = shift(shape[ax])
+	}
+	
+	result := a.Copy()
+	src := make([]int, len(shape))
+	dst := make([]int, len(shape))
+	for flat := 0; flat < a.Size(); flat++ {
+		rem := flat
+		for ax := len(shape) - 1; ax >= 0; ax-- {
+			src[ax] = rem % shape[ax]
+			rem /= shape[ax]
+			dst[ax] = ((src[ax]+shifts[ax])%shape[ax] + shape[ax]) % shape[ax]
+		}
+		copyElement(result, dst, a, src)
+	}
+	return result
+}
+
+// copyElement stores src[srcIdx] into dst[dstIdx] without losing precision
+// for complex or integer dtypes
+func copyElement(dst *tensor.NDArray, dstIdx []int, src *tensor.NDArray, srcIdx []int) {
+	switch dtype := src.DType(); {
+	case dtype.IsComplex():
+		dst.SetComplex128(src.GetComplex128(srcIdx...), dstIdx...)
+	case dtype.IsInt():
+		dst.SetInt64(src.GetInt64(srcIdx...), dstIdx...)
+	default:
+		dst.SetFloat64(src.GetFloat64(srcIdx...), dstIdx...)
+	}
+}
@@ -0,0 +1,203 @@
+package fft
+
+import (
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+// bluesteinMinFactor is the smallest prime factor handled by Bluestein's
+// algorithm rather than a direct O(p^2) butterfly
+const bluesteinMinFactor = 17
+
+// transform returns the discrete Fourier transform of x, or the
+// unnormalized inverse transform when inverse is true:
+//
+//	X[k] = sum_j x[j] exp(-+2 pi i j k / n)
+//
+// Powers of two use the iterative radix-2 algorithm, lengths with small
+// prime factors the recursive mixed-radix Cooley-Tukey algorithm, and
+// anything else Bluestein's chirp-z algorithm, so every length costs
+// O(n log n).
+func transform(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	out := make([]complex128, n)
+	copy(out, x)
+	if n <= 1 {
+		return out
+	}
+	
+	switch {
+	case n&(n-1) == 0:
+		radix2(out, inverse)
+		return out
+	case largestPrimeFactor(n) < bluesteinMinFactor:
+		return mixedRadix(out, inverse)
+	default:
+		return bluestein(out, inverse)
+	}
+}
+
+// twiddle returns exp(-+2 pi i k / n), reducing k first so that the
+// angle stays accurate for large indices
+func twiddle(k, n int, inverse bool) complex128 {
+	k %= n
+	if k < 0 {
+		k += n
+	}
+	angle := -2 * math.Pi * float64(k) / float64(n)
+	if inverse {
+		angle = -angle
+	}
+	s, c := math.Sincos(angle)
+	return complex(c, s)
+}
+
+// radix2 transforms x in place; len(x) must be a power of two
+func radix2(x []complex128, inverse bool) {
+	n := len(x)
+	shift := 64 - bits.TrailingZeros(uint(n))
+	for i := range x {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	
+	for size := 2; size <= n; size <<= 1 {
+		half := size / 2
+		step := twiddle(1, size, inverse)
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < half; k++ {
+				// Recompute periodically to limit error accumulation
+				if k&63 == 0 {
+					w = twiddle(k, size, inverse)
+				}
+				u := x[start+k]
+				v := w * x[start+k+half]
+				x[start+k] = u + v
+				x[start+k+half] = u - v
+				w *= step
+			}
+		}
+	}
+}
+
+// mixedRadix splits x by its smallest prime factor p into p interleaved
+// subsequences, transforms them recursively and combines them with
+// p-point butterflies
+func mixedRadix(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	p := smallestPrimeFactor(n)
+	if p == n {
+		return naiveDFT(x, inverse)
+	}
+	m := n / p
+	
+	subs := make([][]complex128, p)
+	for r := 0; r < p; r++ {
+		sub := make([]complex128, m)
+		for k := 0; k < m; k++ {
+			sub[k] = x[k*p+r]
+		}
+		subs[r] = transform(sub, inverse)
+	}
+	
+	out := make([]complex128, n)
+	terms := make([]complex128, p)
+	for k := 0; k < m; k++ {
+		for r := 0; r < p; r++ {
+			terms[r] = twiddle(r*k, n, inverse) * subs[r][k]
+		}
+		for q := 0; q < p; q++ {
+			var sum complex128
+			for r := 0; r < p; r++ {
+				sum += terms[r] * twiddle(r*q, p, inverse)
+			}
+			out[k+q*m] = sum
+		}
+	}
+	return out
+}
+
+// naiveDFT evaluates the transform directly in O(n^2)
+func naiveDFT(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	out := make([]complex128, n)
+	for k := range out {
+		var sum complex128
+		for j, v := range x {
+			sum += v * twiddle(j*k%n, n, inverse)
+		}
+		out[k] = sum
+	}
+	return out
+}
+
+// bluestein rewrites the transform as a convolution with the chirp
+// exp(-+pi i j^2 / n), evaluated with power-of-two FFTs
+func bluestein(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	m := 1
+	for m < 2*n-1 {
+		m <<= 1
+	}
+	
+	// chirp[j] = exp(-+pi i j^2 / n); j^2 is reduced mod 2n for accuracy
+	chirp := make([]complex128, n)
+	for j := range chirp {
+		chirp[j] = twiddle(j*j%(2*n), 2*n, inverse)
+	}
+	
+	a := make([]complex128, m)
+	b := make([]complex128, m)
+	for j, v := range x {
+		a[j] = v * chirp[j]
+	}
+	b[0] = cmplx.Conj(chirp[0])
+	for j := 1; j < n; j++ {
+		b[j] = cmplx.Conj(chirp[j])
+		b[m-j] = cmplx.Conj(chirp[j])
+	}
+	
+	radix2(a, false)
+	radix2(b, false)
+	for i := range a {
+		a[i] *= b[i]
+	}
+	radix2(a, true)
+	
+	out := make([]complex128, n)
+	scale := complex(1/float64(m), 0)
+	for k := range out {
+		out[k] = a[k] * scale * chirp[k]
+	}
+	return out
+}
+
+
+// smallestPrimeFactor returns the smallest prime dividing n > 1
+func smallestPrimeFactor(n int) int {
+	for f := 2; f*f <= n; f++ {
+		if n%f == 0 {
+			return f
+		}
+	}
+	return n
+}
+
+// largestPrimeFactor returns the largest prime dividing n > 1
+func largestPrimeFactor(n int) int {
+	largest := 1
+	for f := 2; f*f <= n; f++ {
+		for n%f == 0 {
+			largest = f
+			n /= f
+		}
+	}
+	if n > 1 {
+		largest = n
+	}
+	return largest
+}
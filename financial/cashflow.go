@@ -0,0 +1,131 @@
+package financial
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	
+	"github.com/iSundram/NumGo/polynomial"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// cashflows returns the rows of values, a 1D series of cash flows or a 2D
+// array with one series per row
+func cashflows(values *tensor.NDArray) ([][]float64, []int) {
+	flat := values.ToSliceFloat64()
+	switch values.Ndim() {
+	case 1:
+		return [][]float64{flat}, nil
+	case 2:
+		m, t := values.Shape()[0], values.Shape()[1]
+		out := make([][]float64, m)
+		for i := range out {
+			out[i] = flat[i*t : (i+1)*t]
+		}
+		return out, []int{m}
+	}
+	panic(fmt.Sprintf("expected a 1D or 2D array of cash flows, got shape %v", values.Shape()))
+}
+
+// NPV returns the net present value of the cash flows in values, the first
+// of which occurs now, at each of the discount rates in rate. values is a
+// single 1D series, giving a result with the shape of rate, or a 2D array
+// of series, one per row, giving the shape of rate followed by the number
+// of rows.
+func NPV(rate, values *tensor.NDArray) *tensor.NDArray {
+	series, rows := cashflows(values)
+	rates := rate.ToSliceFloat64()
+	out := make([]float64, 0, len(rates)*len(series))
+	for _, r := range rates {
+		for _, s := range series {
+			out = append(out, npv(r, s))
+		}
+	}
+	shape := append(append([]int{}, rate.Shape()...), rows...)
+	return tensor.FromSliceFloat64(out, shape...)
+}
+
+func npv(r float64, values []float64) float64 {
+	sum, discount := 0.0, 1.0
+	for _, v := range values {
+		sum += v / discount
+		discount *= 1 + r
+	}
+	return sum
+}
+
+// IRR returns the internal rate of return of each series of cash flows,
+// the rate at which their NPV is zero. When there are several such rates
+// the one closest to zero is returned, and when there is none the result
+// is NaN. A 1D series gives a one-element result and a 2D array one
+// element per row.
+func IRR(values *tensor.NDArray) *tensor.NDArray {
+	series, _ := cashflows(values)
+	out := make([]float64, len(series))
+	for i, s := range series {
+		out[i] = irr(s)
+	}
+	return tensor.FromSliceFloat64(out, len(out))
+}
+
+// irr solves NPV = 0 as a polynomial in x = 1/(1+r), whose positive real
+// roots give the candidate rates, then polishes the chosen rate with
+// Newton's method
+func irr(values []float64) float64 {
+	best := math.NaN()
+	for _, x := range polynomial.New(values...).Roots() {
+		if math.Abs(imag(x)) > 1e-8*cmplx.Abs(x) || real(x) <= 0 {
+			continue
+		}
+		r := 1/real(x) - 1
+		if math.IsNaN(best) || math.Abs(r) < math.Abs(best) {
+			best = r
+		}
+	}
+	if math.IsNaN(best) {
+		return best
+	}
+	for iter := 0; iter < 20; iter++ {
+		f, df, discount := 0.0, 0.0, 1.0
+		for t, v := range values {
+			f += v / discount
+			df -= float64(t) * v / (discount * (1 + best))
+			discount *= 1 + best
+		}
+		if df == 0 {
+			break
+		}
+		step := f / df
+		best -= step
+		if math.Abs(step) < 1e-15*math.Max(1, math.Abs(best)) {
+			break
+		}
+	}
+	return best
+}
+
+// MIRR returns the modified internal rate of return of each series of cash
+// flows, with outflows discounted at financeRate and inflows compounded at
+// reinvestRate. Series without both an inflow and an outflow give NaN. The
+// result has the same shape as for IRR.
+func MIRR(values *tensor.NDArray, financeRate, reinvestRate float64) *tensor.NDArray {
+	series, _ := cashflows(values)
+	out := make([]float64, len(series))
+	for i, s := range series {
+		n := len(s)
+		var pos, neg float64
+		for t, v := range s {
+			if v > 0 {
+				pos += v * math.Pow(1+reinvestRate, float64(n-1-t))
+			} else {
+				neg += v / math.Pow(1+financeRate, float64(t))
+			}
+		}
+		if pos == 0 || neg == 0 {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = math.Pow(-pos/neg, 1/float64(n-1)) - 1
+	}
+	return tensor.FromSliceFloat64(out, len(out))
+}
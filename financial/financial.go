@@ -0,0 +1,175 @@
+// Package financial provides time-value-of-money and cash-flow functions
+// for NumGo arrays, mirroring numpy-financial
+package financial
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// When says whether payments are due at the end or the beginning of each
+// period
+type When int
+
+const (
+	// End is an ordinary annuity, paying at the end of each period
+	End When = iota
+	// Begin is an annuity due, paying at the start of each period
+	Begin
+)
+
+// FV returns the future value of an investment with present value pv and
+// periodic payment pmt after nper periods at interest rate rate per
+// period. As in numpy-financial, money paid out is negative. The arguments
+// broadcast against each other.
+func FV(rate, nper, pmt, pv *tensor.NDArray, when When) *tensor.NDArray {
+	return elementwise(func(v []float64) float64 {
+		r, n, pmt, pv := v[0], v[1], v[2], v[3]
+		if r == 0 {
+			return -(pv + pmt*n)
+		}
+		t := math.Pow(1+r, n)
+		return -(pv*t + pmt*annuity(r, t, when))
+	}, rate, nper, pmt, pv)
+}
+
+// PV returns the present value of a series of nper payments pmt at
+// interest rate rate per period followed by the future value fv
+func PV(rate, nper, pmt, fv *tensor.NDArray, when When) *tensor.NDArray {
+	return elementwise(func(v []float64) float64 {
+		r, n, pmt, fv := v[0], v[1], v[2], v[3]
+		if r == 0 {
+			return -(fv + pmt*n)
+		}
+		t := math.Pow(1+r, n)
+		return -(fv + pmt*annuity(r, t, when)) / t
+	}, rate, nper, pmt, fv)
+}
+
+// PMT returns the payment per period that pays off the present value pv
+// over nper periods at interest rate rate, leaving the future value fv
+func PMT(rate, nper, pv, fv *tensor.NDArray, when When) *tensor.NDArray {
+	return elementwise(func(v []float64) float64 {
+		r, n, pv, fv := v[0], v[1], v[2], v[3]
+		if r == 0 {
+			return -(fv + pv) / n
+		}
+		t := math.Pow(1+r, n)
+		return -(fv + pv*t) / annuity(r, t, when)
+	}, rate, nper, pv, fv)
+}
+
+// NPer returns the number of periods needed to go from the present value
+// pv to the future value fv with payment pmt at interest rate rate
+func NPer(rate, pmt, pv, fv *tensor.NDArray, when When) *tensor.NDArray {
+	return elementwise(func(v []float64) float64 {
+		r, pmt, pv, fv := v[0], v[1], v[2], v[3]
+		if r == 0 {
+			return -(fv + pv) / pmt
+		}
+		z := pmt * (1 + r*float64(when)) / r
+		return math.Log((z-fv)/(pv+z)) / math.Log(1+r)
+	}, rate, pmt, pv, fv)
+}
+
+// Rate returns the interest rate per period that takes the present value
+// pv to the future value fv over nper periods with payment pmt, found by
+// Newton's method from a guess of 0.1. Elements where the iteration does
+// not converge are NaN.
+func Rate(nper, pmt, pv, fv *tensor.NDArray, when When) *tensor.NDArray {
+	w := float64(when)
+	return elementwise(func(v []float64) float64 {
+		n, pmt, pv, fv := v[0], v[1], v[2], v[3]
+		r := 0.1
+		for iter := 0; iter < 100; iter++ {
+			// f is the future value balance, which is zero at the rate
+			t := math.Pow(1+r, n)
+			dt := n * math.Pow(1+r, n-1)
+			f := pv*t + pmt*(1+r*w)*(t-1)/r + fv
+			df := pv*dt + pmt*(w*(t-1)/r+(1+r*w)*(dt*r-(t-1))/(r*r))
+			step := f / df
+			r -= step
+			if math.Abs(step) < 1e-10*math.Max(1, math.Abs(r)) {
+				return r
+			}
+		}
+		return math.NaN()
+	}, nper, pmt, pv, fv)
+}
+
+// annuity returns the factor (1 + r*when)((1+r)^n - 1)/r by which the
+// payment grows to its future value, given t = (1+r)^n
+func annuity(r, t float64, when When) float64 {
+	return (1 + r*float64(when)) * (t - 1) / r
+}
+
+// elementwise broadcasts the arguments against each other and applies f
+// to the values at each position, returning a Float64 array
+func elementwise(f func(v []float64) float64, args ...*tensor.NDArray) *tensor.NDArray {
+	var shape []int
+	for _, a := range args {
+		shape = broadcastShape(shape, a.Shape())
+	}
+	size := 1
+	for _, s := range shape {
+		size *= s
+	}
+	
+	values := make([][]float64, len(args))
+	strides := make([][]int, len(args))
+	for i, a := range args {
+		values[i] = a.ToSliceFloat64()
+		strides[i] = broadcastStrides(a.Shape(), shape)
+	}
+	out := make([]float64, size)
+	v := make([]float64, len(args))
+	for k := range out {
+		for i := range args {
+			rem, at := k, 0
+			for ax := len(shape) - 1; ax >= 0; ax-- {
+				at += (rem % shape[ax]) * strides[i][ax]
+				rem /= shape[ax]
+			}
+			v[i] = values[i][at]
+		}
+		out[k] = f(v)
+	}
+	return tensor.FromSliceFloat64(out, shape...)
+}
+
+// broadcastShape returns the shape that arrays of shapes a and b broadcast
+// to
+func broadcastShape(a, b []int) []int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	out := append([]int{}, a...)
+	offset := len(a) - len(b)
+	for i, s := range b {
+		switch {
+		case out[offset+i] == s || s == 1:
+		case out[offset+i] == 1:
+			out[offset+i] = s
+		default:
+			panic(fmt.Sprintf("shapes %v and %v cannot be broadcast together", a, b))
+		}
+	}
+	return out
+}
+
+// broadcastStrides returns the element strides of a C-ordered array of the
+// given shape when broadcast to target, with zero along broadcast axes
+func broadcastStrides(shape, target []int) []int {
+	strides := make([]int, len(target))
+	offset := len(target) - len(shape)
+	stride := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		if shape[i] != 1 {
+			strides[offset+i] = stride
+		}
+		stride *= shape[i]
+	}
+	return strides
+}
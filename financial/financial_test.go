@@ -0,0 +1,98 @@
+package financial
+
+import (
+	"math"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func sliceClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.IsNaN(a[i]) != math.IsNaN(b[i]) || math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func scalar(x float64) *tensor.NDArray {
+	return tensor.FromSliceFloat64([]float64{x}, 1)
+}
+
+// Expected values are from numpy-financial
+func TestTimeValue(t *testing.T) {
+	cases := []struct {
+		name string
+		got  *tensor.NDArray
+		want float64
+	}{
+		{"FV", FV(scalar(0.05/12), scalar(120), scalar(-100), scalar(-100), End), 15692.928894335748},
+		{"FV begin", FV(scalar(0.05/12), scalar(120), scalar(-100), scalar(-100), Begin), 15757.629844104777},
+		{"FV zero rate", FV(scalar(0), scalar(10), scalar(-100), scalar(-50), End), 1050},
+		{"PV", PV(scalar(0.05/12), scalar(120), scalar(-100), scalar(15692.928894335748), End), -100},
+		{"PMT", PMT(scalar(0.075/12), scalar(180), scalar(200000), scalar(0), End), -1854.0247200054619},
+		{"NPer", NPer(scalar(0.07/12), scalar(-150), scalar(8000), scalar(0), End), 64.07334877066185},
+		{"Rate", Rate(scalar(10), scalar(0), scalar(-3500), scalar(10000), End), 0.11069085371075271},
+	}
+	for _, c := range cases {
+		if got := c.got.GetFloat64(0); math.Abs(got-c.want) > 1e-8*math.Max(1, math.Abs(c.want)) {
+			t.Errorf("%s: expected %.12g, got %.12g", c.name, c.want, got)
+		}
+	}
+	
+	// Round trip through Rate for an annuity due
+	fv := FV(scalar(0.04), scalar(20), scalar(-250), scalar(1000), Begin)
+	if got := Rate(scalar(20), scalar(-250), scalar(1000), fv, Begin).GetFloat64(0); math.Abs(got-0.04) > 1e-10 {
+		t.Errorf("expected rate 0.04, got %.12g", got)
+	}
+	
+	// Rates broadcast against a column of loan terms
+	rates := tensor.FromSliceFloat64([]float64{0.01, 0.02, 0.03}, 3)
+	terms := tensor.FromSliceFloat64([]float64{12, 24}, 2, 1)
+	pmt := PMT(rates, terms, scalar(1000), scalar(0), End)
+	if shape := pmt.Shape(); len(shape) != 2 || shape[0] != 2 || shape[1] != 3 {
+		t.Fatalf("expected shape (2, 3), got %v", shape)
+	}
+	for i, n := range []float64{12, 24} {
+		for j, r := range []float64{0.01, 0.02, 0.03} {
+			want := -1000 * r / (1 - math.Pow(1+r, -n))
+			if got := pmt.GetFloat64(i, j); math.Abs(got-want) > 1e-9 {
+				t.Errorf("PMT(%g, %g): expected %f, got %f", r, n, want, got)
+			}
+		}
+	}
+}
+
+func TestCashFlows(t *testing.T) {
+	flows := tensor.FromSliceFloat64([]float64{-100, 39, 59, 55, 20}, 5)
+	if got := NPV(scalar(0.281), flows).ToSliceFloat64(); !sliceClose(got, []float64{-0.008478591638410471}, 1e-12) {
+		t.Errorf("expected NPV -0.0084786, got %v", got)
+	}
+	if got := IRR(flows).ToSliceFloat64(); !sliceClose(got, []float64{0.28094842115996066}, 1e-12) {
+		t.Errorf("expected IRR 0.2809484, got %v", got)
+	}
+	
+	matrix := tensor.FromSliceFloat64([]float64{-100, 39, 59, 55, 20, -100, 0, 0, 0, 121.550625, 100, 100, 100, 100, 100}, 3, 5)
+	if got := IRR(matrix).ToSliceFloat64(); !sliceClose(got, []float64{0.28094842115996066, 0.05, math.NaN()}, 1e-10) {
+		t.Errorf("expected IRR per row [0.2809 0.05 NaN], got %v", got)
+	}
+	npv := NPV(tensor.FromSliceFloat64([]float64{0, 0.05}, 2), matrix)
+	if shape := npv.Shape(); len(shape) != 2 || shape[0] != 2 || shape[1] != 3 {
+		t.Fatalf("expected shape (2, 3), got %v", shape)
+	}
+	if got := npv.GetFloat64(1, 1); math.Abs(got) > 1e-10 {
+		t.Errorf("expected zero NPV at the IRR, got %g", got)
+	}
+	if got := npv.GetFloat64(0, 2); got != 500 {
+		t.Errorf("expected undiscounted NPV 500, got %g", got)
+	}
+	
+	mirr := tensor.FromSliceFloat64([]float64{-4500, -800, 800, 800, 600, 600, 800, 800, 700, 3000}, 10)
+	if got := MIRR(mirr, 0.08, 0.055).ToSliceFloat64(); !sliceClose(got, []float64{0.06659717503155349}, 1e-12) {
+		t.Errorf("expected MIRR 0.0666, got %v", got)
+	}
+}
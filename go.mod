@@ -1,3 +1,5 @@
 module github.com/iSundram/NumGo
 
 go 1.24.9
+
+require gonum.org/v1/gonum v0.17.0
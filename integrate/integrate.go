@@ -0,0 +1,64 @@
+// Package integrate provides numerical integration of functions and
+// sampled data for NumGo arrays
+package integrate
+
+import (
+	"fmt"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// normalizeAxis resolves a possibly negative axis against a's dimensions
+func normalizeAxis(a *tensor.NDArray, axis int) int {
+	ax := axis
+	if ax < 0 {
+		ax += a.Ndim()
+	}
+	if ax < 0 || ax >= a.Ndim() {
+		panic(fmt.Sprintf("axis %d is out of bounds for array of dimension %d", axis, a.Ndim()))
+	}
+	return ax
+}
+
+// reduceAxis applies f to every 1D lane of a along axis and returns the
+// results with that axis removed. As with SumAxis, reducing a 1D array
+// gives a one-element array.
+func reduceAxis(a *tensor.NDArray, axis int, f func([]float64) float64) *tensor.NDArray {
+	ax := normalizeAxis(a, axis)
+	shape := a.Shape()
+	data := a.ToSliceFloat64()
+	outer, inner := 1, 1
+	for _, s := range shape[:ax] {
+		outer *= s
+	}
+	for _, s := range shape[ax+1:] {
+		inner *= s
+	}
+	length := shape[ax]
+	
+	result := make([]float64, outer*inner)
+	lane := make([]float64, length)
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			for k := range lane {
+				lane[k] = data[(o*length+k)*inner+i]
+			}
+			result[o*inner+i] = f(lane)
+		}
+	}
+	
+	outShape := append(append([]int{}, shape[:ax]...), shape[ax+1:]...)
+	if len(outShape) == 0 {
+		outShape = []int{1}
+	}
+	return tensor.FromSliceFloat64(result, outShape...)
+}
+
+// vector returns the elements of the 1D array a, panicking with a message
+// naming it otherwise
+func vector(a *tensor.NDArray, name string) []float64 {
+	if a.Ndim() != 1 {
+		panic(fmt.Sprintf("%s must be a 1D array", name))
+	}
+	return a.ToSliceFloat64()
+}
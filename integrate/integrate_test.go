@@ -0,0 +1,205 @@
+package integrate
+
+import (
+	"math"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func sliceClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestQuad(t *testing.T) {
+	cases := []struct {
+		name string
+		f    func(float64) float64
+		a, b float64
+		want float64
+	}{
+		{"sin", math.Sin, 0, math.Pi, 2},
+		{"reversed", math.Sin, math.Pi, 0, -2},
+		{"sqrt", math.Sqrt, 0, 1, 2.0 / 3},
+		{"log singularity", math.Log, 0, 1, -1},
+		{"peak", func(x float64) float64 { return 1 / (1e-4 + x*x) }, -1, 1, 2 * 100 * math.Atan(100)},
+		{"gaussian", func(x float64) float64 { return math.Exp(-x * x) }, math.Inf(-1), math.Inf(1), math.Sqrt(math.Pi)},
+		{"exp tail", func(x float64) float64 { return math.Exp(-x) }, 1, math.Inf(1), math.Exp(-1)},
+		{"lower tail", func(x float64) float64 { return 1 / (1 + x*x) }, math.Inf(-1), 0, math.Pi / 2},
+	}
+	for _, c := range cases {
+		res := Quad(c.f, c.a, c.b)
+		if !res.Converged || math.Abs(res.Value-c.want) > 1e-7*math.Max(1, math.Abs(c.want)) {
+			t.Errorf("%s: expected %v, got %+v", c.name, c.want, res)
+		}
+		if math.Abs(res.Value-c.want) > res.AbsErr+1e-12 {
+			t.Errorf("%s: error %v exceeds the estimate %v", c.name, math.Abs(res.Value-c.want), res.AbsErr)
+		}
+	}
+	
+	if res := QuadWith(math.Log, 0, 1, QuadOptions{Limit: 2}); res.Converged {
+		t.Errorf("expected no convergence with 2 subintervals, got %+v", res)
+	}
+}
+
+func TestTrapzSimpson(t *testing.T) {
+	// Simpson's rule is exact for cubics with an odd number of samples,
+	// and for quadratics with an even number or uneven spacing
+	cubic := func(x float64) float64 { return x*x*x - 2*x + 1 }
+	quadratic := func(x float64) float64 { return 3*x*x - x + 2 }
+	sample := func(f func(float64) float64, xs []float64) *tensor.NDArray {
+		ys := make([]float64, len(xs))
+		for i, x := range xs {
+			ys[i] = f(x)
+		}
+		return tensor.FromSliceFloat64(ys, len(ys))
+	}
+	
+	even := []float64{0, 0.5, 1, 1.5, 2}
+	if got := Simpson(sample(cubic, even), nil, 0.5); math.Abs(got-2) > 1e-12 {
+		t.Errorf("cubic: expected 2, got %v", got)
+	}
+	uneven := []float64{0, 0.3, 1, 1.2, 2, 2.5}
+	x := tensor.FromSliceFloat64(uneven, len(uneven))
+	if got, want := Simpson(sample(quadratic, uneven), x, 0), 2.5*2.5*2.5-2.5*2.5/2+5; math.Abs(got-want) > 1e-12 {
+		t.Errorf("quadratic: expected %v, got %v", want, got)
+	}
+	if got := Trapz(sample(quadratic, []float64{0, 1, 2}), nil, 1); got != 11 {
+		t.Errorf("trapz: got %v", got)
+	}
+	
+	y := tensor.FromSliceFloat64([]float64{0, 1, 4, 9, 0, 2, 4, 6}, 2, 4)
+	if got := TrapzAxis(y, nil, 1, 1).ToSliceFloat64(); !sliceClose(got, []float64{9.5, 9}, 1e-12) {
+		t.Errorf("TrapzAxis: got %v", got)
+	}
+	if got := SimpsonAxis(y, nil, 1, 0).ToSliceFloat64(); !sliceClose(got, []float64{0, 1.5, 4, 7.5}, 1e-12) {
+		t.Errorf("SimpsonAxis: got %v", got)
+	}
+}
+
+func TestRomberg(t *testing.T) {
+	n := 17
+	ys := make([]float64, n)
+	dx := 1.0 / float64(n-1)
+	for i := range ys {
+		ys[i] = math.Exp(float64(i) * dx)
+	}
+	y := tensor.FromSliceFloat64(ys, n)
+	if got, want := Romberg(y, dx), math.E-1; math.Abs(got-want) > 1e-12 {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := RombergAxis(y.Reshape(1, n), dx, -1).ToSliceFloat64(); math.Abs(got[0]-(math.E-1)) > 1e-12 {
+		t.Errorf("RombergAxis: got %v", got)
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for 6 samples")
+		}
+	}()
+	Romberg(tensor.Ones([]int{6}, tensor.Float64), 1)
+}
+
+func TestSolveIVPRK45(t *testing.T) {
+	// Exponential decay, the scipy.integrate.solve_ivp documentation
+	// example: the same steps are taken and the same solution found
+	decay := func(t float64, y *tensor.NDArray) *tensor.NDArray { return y.MulScalar(-0.5) }
+	y0 := tensor.FromSliceFloat64([]float64{2, 4, 8}, 3)
+	res := SolveIVP(decay, 0, 10, y0, IVPOptions{})
+	if !res.Success {
+		t.Fatalf("integration failed: %s", res.Message)
+	}
+	wantT := []float64{0, 0.11487653, 1.26364188, 3.06061781, 4.81611105, 6.57445806, 8.33328988, 10}
+	if got := res.T.ToSliceFloat64(); !sliceClose(got, wantT, 1e-8) {
+		t.Errorf("expected times %v, got %v", wantT, got)
+	}
+	m := len(wantT)
+	if s := res.Y.Shape(); s[0] != 3 || s[1] != m {
+		t.Fatalf("expected shape [3 %d], got %v", m, s)
+	}
+	for i, want := range []float64{0.01350781, 0.02701562, 0.05403123} {
+		if got := res.Y.GetFloat64(i, m-1); math.Abs(got-want) > 1e-8 {
+			t.Errorf("y%d(10): expected %v, got %v", i, want, got)
+		}
+	}
+	
+	// Harmonic oscillator on requested times, integrated backwards too
+	oscillator := func(t float64, y *tensor.NDArray) *tensor.NDArray {
+		v := y.ToSliceFloat64()
+		return tensor.FromSliceFloat64([]float64{v[1], -v[0]}, 2)
+	}
+	teval := []float64{0, 0.5, 1.7, 3, 6}
+	res = SolveIVP(oscillator, 0, 6, tensor.FromSliceFloat64([]float64{0, 1}, 2), IVPOptions{
+		TEval: tensor.FromSliceFloat64(teval, len(teval)),
+		RTol:  1e-8,
+		ATol:  1e-10,
+	})
+	if got := res.T.ToSliceFloat64(); !sliceClose(got, teval, 0) {
+		t.Fatalf("expected times %v, got %v", teval, got)
+	}
+	for j, tj := range teval {
+		if got := res.Y.GetFloat64(0, j); math.Abs(got-math.Sin(tj)) > 1e-7 {
+			t.Errorf("y(%v): expected %v, got %v", tj, math.Sin(tj), got)
+		}
+	}
+	
+	back := SolveIVP(oscillator, 6, 0, tensor.FromSliceFloat64([]float64{math.Sin(6), math.Cos(6)}, 2), IVPOptions{RTol: 1e-8, ATol: 1e-10})
+	last := back.T.Size() - 1
+	if got := back.Y.GetFloat64(1, last); math.Abs(got-1) > 1e-7 {
+		t.Errorf("backwards: expected y'(0) = 1, got %v", got)
+	}
+}
+
+func TestSolveIVPBDF(t *testing.T) {
+	// A stiff linear system with eigenvalues -1 and -1000:
+	// y0' = -y0, y1' = 1000 (y0 - y1) has y1 -> y0 after a fast transient
+	stiff := func(t float64, y *tensor.NDArray) *tensor.NDArray {
+		v := y.ToSliceFloat64()
+		return tensor.FromSliceFloat64([]float64{-v[0], 1000 * (v[0] - v[1])}, 2)
+	}
+	exact := func(t float64) []float64 {
+		c := 1000.0 / 999
+		return []float64{math.Exp(-t), c*math.Exp(-t) + (1-c)*math.Exp(-1000*t)}
+	}
+	y0 := tensor.FromSliceFloat64([]float64{1, 1}, 2)
+	teval := tensor.FromSliceFloat64([]float64{0.001, 0.5, 2, 5}, 4)
+	
+	jac := func(t float64, y *tensor.NDArray) *tensor.NDArray {
+		return tensor.FromSliceFloat64([]float64{-1, 0, 1000, -1000}, 2, 2)
+	}
+	for _, opts := range []IVPOptions{
+		{Method: BDF, TEval: teval, RTol: 1e-6, ATol: 1e-9},
+		{Method: BDF, TEval: teval, RTol: 1e-6, ATol: 1e-9, Jacobian: jac},
+	} {
+		res := SolveIVP(stiff, 0, 5, y0, opts)
+		if !res.Success {
+			t.Fatalf("integration failed: %s", res.Message)
+		}
+		for j, tj := range teval.ToSliceFloat64() {
+			want := exact(tj)
+			for i := range want {
+				if got := res.Y.GetFloat64(i, j); math.Abs(got-want[i]) > 1e-4 {
+					t.Errorf("y%d(%v): expected %v, got %v", i, tj, want[i], got)
+				}
+			}
+		}
+		if res.NJev == 0 || res.NLU == 0 {
+			t.Errorf("expected Jacobian evaluations and factorizations, got %d and %d", res.NJev, res.NLU)
+		}
+	}
+	
+	// The explicit method needs far more evaluations on a stiff problem
+	implicit := SolveIVP(stiff, 0, 5, y0, IVPOptions{Method: BDF})
+	explicit := SolveIVP(stiff, 0, 5, y0, IVPOptions{})
+	if implicit.NFev*5 > explicit.NFev {
+		t.Errorf("BDF used %d evaluations, RK45 %d", implicit.NFev, explicit.NFev)
+	}
+}
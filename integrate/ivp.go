@@ -0,0 +1,775 @@
+package integrate
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/linalg"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// VectorField returns dy/dt at time t, as an array with the same number
+// of elements as y
+type VectorField func(t float64, y *tensor.NDArray) *tensor.NDArray
+
+// ODEMethod selects the integrator used by SolveIVP
+type ODEMethod int
+
+const (
+	// RK45 is the explicit Dormand-Prince 5(4) Runge-Kutta method, suited
+	// to non-stiff problems
+	RK45 ODEMethod = iota
+	// BDF is the implicit variable-order (1 to 5) backward differentiation
+	// formula method, suited to stiff problems
+	BDF
+)
+
+// String returns the SciPy name of the method
+func (m ODEMethod) String() string {
+	switch m {
+	case RK45:
+		return "RK45"
+	case BDF:
+		return "BDF"
+	default:
+		return fmt.Sprintf("ODEMethod(%d)", int(m))
+	}
+}
+
+// IVPOptions controls SolveIVP. The zero value selects the defaults.
+type IVPOptions struct {
+	// Method selects the integrator
+	Method ODEMethod
+	// TEval lists the times at which to store the solution, inside the
+	// integration interval and ordered in the direction of integration.
+	// Nil stores the solution at every step taken.
+	TEval *tensor.NDArray
+	// RTol and ATol are the relative and absolute error tolerances of a
+	// step. Zero selects 1e-3 and 1e-6.
+	RTol, ATol float64
+	// MaxStep bounds the step size. Zero means unbounded.
+	MaxStep float64
+	// FirstStep is the initial step size. Zero chooses it automatically.
+	FirstStep float64
+	// Jacobian returns the n x n matrix df/dy for BDF. Nil approximates
+	// it by finite differences.
+	Jacobian func(t float64, y *tensor.NDArray) *tensor.NDArray
+}
+
+// IVPResult holds the solution of an initial value problem
+type IVPResult struct {
+	// T holds the m solution times
+	T *tensor.NDArray
+	// Y holds the solution, with shape (n, m): column j is y(T[j])
+	Y *tensor.NDArray
+	// NFev, NJev and NLU count evaluations of the vector field and the
+	// Jacobian and LU factorizations
+	NFev, NJev, NLU int
+	// Success reports whether the end of the interval was reached
+	Success bool
+	// Message describes the reason for termination
+	Message string
+}
+
+// odeSystem wraps a vector field over flat slices, counting evaluations
+type odeSystem struct {
+	f    VectorField
+	jac  func(t float64, y *tensor.NDArray) *tensor.NDArray
+	n    int
+	nfev int
+	njev int
+	nlu  int
+}
+
+// eval returns f(t, y)
+func (s *odeSystem) eval(t float64, y []float64) []float64 {
+	s.nfev++
+	dy := s.f(t, tensor.FromSliceFloat64(append([]float64{}, y...), s.n))
+	if dy.Size() != s.n {
+		panic(fmt.Sprintf("vector field returned %d elements, expected %d", dy.Size(), s.n))
+	}
+	return dy.ToSliceFloat64()
+}
+
+// jacobian returns df/dy at (t, y) as an n x n array, by forward
+// differences from fy = f(t, y) when no Jacobian was supplied
+func (s *odeSystem) jacobian(t float64, y, fy []float64) *tensor.NDArray {
+	s.njev++
+	if s.jac != nil {
+		j := s.jac(t, tensor.FromSliceFloat64(append([]float64{}, y...), s.n))
+		if j.Size() != s.n*s.n {
+			panic(fmt.Sprintf("Jacobian has %d elements, expected %d", j.Size(), s.n*s.n))
+		}
+		return j.Reshape(s.n, s.n)
+	}
+	
+	n := s.n
+	data := make([]float64, n*n)
+	yh := append([]float64{}, y...)
+	for j := 0; j < n; j++ {
+		h := math.Sqrt(machineEpsilon) * math.Max(1, math.Abs(y[j]))
+		yh[j] = y[j] + h
+		h = yh[j] - y[j]
+		fh := s.eval(t, yh)
+		yh[j] = y[j]
+		for i := 0; i < n; i++ {
+			data[i*n+j] = (fh[i] - fy[i]) / h
+		}
+	}
+	return tensor.FromSliceFloat64(data, n, n)
+}
+
+// machineEpsilon is the float64 machine epsilon
+const machineEpsilon = 2.220446049250313e-16
+
+// stepper advances an ODE solution one step at a time
+type stepper interface {
+	// step advances the solution, reporting false if the step size
+	// became too small
+	step() bool
+	// current returns the time and state after the last step
+	current() (float64, []float64)
+	// dense interpolates the solution within the last step
+	dense(t float64) []float64
+}
+
+// SolveIVP integrates dy/dt = f(t, y) from t0 to tf with y(t0) = y0, a 1D
+// array, using adaptive step size control. Integration runs backwards when
+// tf < t0.
+func SolveIVP(f VectorField, t0, tf float64, y0 *tensor.NDArray, opts IVPOptions) IVPResult {
+	y := vector(y0, "y0")
+	n := len(y)
+	rtol, atol := opts.RTol, opts.ATol
+	if rtol <= 0 {
+		rtol = 1e-3
+	}
+	rtol = math.Max(rtol, 100*machineEpsilon)
+	if atol <= 0 {
+		atol = 1e-6
+	}
+	maxStep := opts.MaxStep
+	if maxStep <= 0 {
+		maxStep = math.Inf(1)
+	}
+	direction := 1.0
+	if tf < t0 {
+		direction = -1
+	}
+	
+	var teval []float64
+	if opts.TEval != nil {
+		teval = vector(opts.TEval, "TEval")
+		for i, t := range teval {
+			if direction*(t-t0) < 0 || direction*(t-tf) > 0 {
+				panic(fmt.Sprintf("TEval value %v is outside the integration interval [%v, %v]", t, t0, tf))
+			}
+			if i > 0 && direction*(t-teval[i-1]) < 0 {
+				panic("TEval must be ordered in the direction of integration")
+			}
+		}
+	}
+	
+	sys := &odeSystem{f: f, jac: opts.Jacobian, n: n}
+	var s stepper
+	switch opts.Method {
+	case RK45:
+		s = newRK45(sys, t0, tf, y, direction, rtol, atol, maxStep, opts.FirstStep)
+	case BDF:
+		s = newBDF(sys, t0, tf, y, direction, rtol, atol, maxStep, opts.FirstStep)
+	default:
+		panic(fmt.Sprintf("unknown ODE method %d", int(opts.Method)))
+	}
+	
+	var ts []float64
+	var ys [][]float64
+	next := 0
+	if teval == nil {
+		ts, ys = append(ts, t0), append(ys, append([]float64{}, y...))
+	}
+	for next < len(teval) && teval[next] == t0 {
+		ts, ys = append(ts, t0), append(ys, append([]float64{}, y...))
+		next++
+	}
+	
+	success := true
+	message := "The solver successfully reached the end of the integration interval."
+	t := t0
+	for direction*(t-tf) < 0 {
+		if !s.step() {
+			success = false
+			message = "Required step size is less than spacing between numbers."
+			break
+		}
+		var yt []float64
+		t, yt = s.current()
+		if teval == nil {
+			ts, ys = append(ts, t), append(ys, append([]float64{}, yt...))
+			continue
+		}
+		for next < len(teval) && direction*(teval[next]-t) <= 0 {
+			ts, ys = append(ts, teval[next]), append(ys, s.dense(teval[next]))
+			next++
+		}
+	}
+	
+	m := len(ts)
+	out := make([]float64, n*m)
+	for j, col := range ys {
+		for i, v := range col {
+			out[i*m+j] = v
+		}
+	}
+	return IVPResult{
+		T:       tensor.FromSliceFloat64(ts, m),
+		Y:       tensor.FromSliceFloat64(out, n, m),
+		NFev:    sys.nfev,
+		NJev:    sys.njev,
+		NLU:     sys.nlu,
+		Success: success,
+		Message: message,
+	}
+}
+
+// rmsNorm returns the root-mean-square of x[i] / scale[i]
+func rmsNorm(x, scale []float64) float64 {
+	if len(x) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for i, v := range x {
+		r := v / scale[i]
+		sum += r * r
+	}
+	return math.Sqrt(sum / float64(len(x)))
+}
+
+// minStepSize is the smallest step the solvers take at time t
+func minStepSize(t, direction float64) float64 {
+	return 10 * math.Abs(math.Nextafter(t, direction*math.Inf(1))-t)
+}
+
+// initialStep chooses a first step size for a method whose error
+// estimate has the given order, following Hairer, Norsett and Wanner
+func initialStep(sys *odeSystem, t0, tf float64, y0, f0 []float64, direction float64, order int, rtol, atol float64) float64 {
+	length := math.Abs(tf - t0)
+	if length == 0 || len(y0) == 0 {
+		return length
+	}
+	scale := make([]float64, len(y0))
+	for i, v := range y0 {
+		scale[i] = atol + math.Abs(v)*rtol
+	}
+	d0, d1 := rmsNorm(y0, scale), rmsNorm(f0, scale)
+	h0 := 1e-6
+	if d0 >= 1e-5 && d1 >= 1e-5 {
+		h0 = 0.01 * d0 / d1
+	}
+	h0 = math.Min(h0, length)
+	
+	y1 := make([]float64, len(y0))
+	for i := range y1 {
+		y1[i] = y0[i] + h0*direction*f0[i]
+	}
+	f1 := sys.eval(t0+h0*direction, y1)
+	diff := make([]float64, len(f0))
+	for i := range diff {
+		diff[i] = f1[i] - f0[i]
+	}
+	d2 := rmsNorm(diff, scale) / h0
+	
+	h1 := math.Max(1e-6, h0*1e-3)
+	if d1 > 1e-15 || d2 > 1e-15 {
+		h1 = math.Pow(0.01/math.Max(d1, d2), 1/float64(order+1))
+	}
+	return math.Min(math.Min(100*h0, h1), length)
+}
+
+// Dormand-Prince 5(4) coefficients, the error estimator and the quartic
+// dense output coefficients used by scipy.integrate.RK45
+var (
+	rkC = [6]float64{0, 1.0 / 5, 3.0 / 10, 4.0 / 5, 8.0 / 9, 1}
+	rkA = [6][5]float64{
+		{},
+		{1.0 / 5},
+		{3.0 / 40, 9.0 / 40},
+		{44.0 / 45, -56.0 / 15, 32.0 / 9},
+		{19372.0 / 6561, -25360.0 / 2187, 64448.0 / 6561, -212.0 / 729},
+		{9017.0 / 3168, -355.0 / 33, 46732.0 / 5247, 49.0 / 176, -5103.0 / 18656},
+	}
+	rkB = [6]float64{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84}
+	rkE = [7]float64{-71.0 / 57600, 0, 71.0 / 16695, -71.0 / 1920, 17253.0 / 339200, -22.0 / 525, 1.0 / 40}
+	rkP = [7][4]float64{
+		{1, -8048581381.0 / 2820520608, 8663915743.0 / 2820520608, -12715105075.0 / 11282082432},
+		{},
+		{0, 131558114200.0 / 32700410799, -68118460800.0 / 10900136933, 87487479700.0 / 32700410799},
+		{0, -1754552775.0 / 470086768, 14199869525.0 / 1410260304, -10690763975.0 / 1880347072},
+		{0, 127303824393.0 / 49829197408, -318862633887.0 / 49829197408, 701980252875.0 / 199316789632},
+		{0, -282668133.0 / 205662961, 2019193451.0 / 616988883, -1453857185.0 / 822651844},
+		{0, 40617522.0 / 29380423, -110615467.0 / 29380423, 69997945.0 / 29380423},
+	}
+)
+
+// rk45 is the Dormand-Prince stepper
+type rk45 struct {
+	sys              *odeSystem
+	t, tf, direction float64
+	y, f             []float64
+	hAbs, maxStep    float64
+	rtol, atol       float64
+	tOld, h          float64
+	yOld             []float64
+	k                [7][]float64
+}
+
+func newRK45(sys *odeSystem, t0, tf float64, y0 []float64, direction, rtol, atol, maxStep, firstStep float64) *rk45 {
+	f0 := sys.eval(t0, y0)
+	hAbs := math.Abs(firstStep)
+	if hAbs == 0 {
+		hAbs = initialStep(sys, t0, tf, y0, f0, direction, 4, rtol, atol)
+	}
+	return &rk45{sys: sys, t: t0, tf: tf, direction: direction, y: y0, f: f0, hAbs: hAbs, maxStep: maxStep, rtol: rtol, atol: atol}
+}
+
+func (s *rk45) current() (float64, []float64) {
+	return s.t, s.y
+}
+
+func (s *rk45) step() bool {
+	n := len(s.y)
+	minStep := minStepSize(s.t, s.direction)
+	hAbs := math.Max(math.Min(s.hAbs, s.maxStep), minStep)
+	
+	rejected := false
+	for {
+		if hAbs < minStep {
+			return false
+		}
+		tNew := s.t + s.direction*hAbs
+		if s.direction*(tNew-s.tf) > 0 {
+			tNew = s.tf
+		}
+		h := tNew - s.t
+		hAbs = math.Abs(h)
+		
+		var k [7][]float64
+		k[0] = s.f
+		yStage := make([]float64, n)
+		for stage := 1; stage < 6; stage++ {
+			for i := range yStage {
+				sum := 0.0
+				for j := 0; j < stage; j++ {
+					sum += rkA[stage][j] * k[j][i]
+				}
+				yStage[i] = s.y[i] + h*sum
+			}
+			k[stage] = s.sys.eval(s.t+rkC[stage]*h, yStage)
+		}
+		yNew := make([]float64, n)
+		for i := range yNew {
+			sum := 0.0
+			for j := 0; j < 6; j++ {
+				sum += rkB[j] * k[j][i]
+			}
+			yNew[i] = s.y[i] + h*sum
+		}
+		k[6] = s.sys.eval(tNew, yNew)
+		
+		errEst := make([]float64, n)
+		scale := make([]float64, n)
+		for i := range errEst {
+			sum := 0.0
+			for j := 0; j < 7; j++ {
+				sum += rkE[j] * k[j][i]
+			}
+			errEst[i] = h * sum
+			scale[i] = s.atol + math.Max(math.Abs(s.y[i]), math.Abs(yNew[i]))*s.rtol
+		}
+		errNorm := rmsNorm(errEst, scale)
+		
+		if errNorm < 1 {
+			factor := 10.0
+			if errNorm > 0 {
+				factor = math.Min(10, 0.9*math.Pow(errNorm, -0.2))
+			}
+			if rejected {
+				factor = math.Min(1, factor)
+			}
+			s.tOld, s.yOld, s.h, s.k = s.t, s.y, h, k
+			s.t, s.y, s.f = tNew, yNew, k[6]
+			s.hAbs = hAbs * factor
+			return true
+		}
+		hAbs *= math.Max(0.2, 0.9*math.Pow(errNorm, -0.2))
+		rejected = true
+	}
+}
+
+func (s *rk45) dense(t float64) []float64 {
+	x := (t - s.tOld) / s.h
+	var q [4]float64
+	p := x
+	for j := range q {
+		q[j] = p
+		p *= x
+	}
+	out := make([]float64, len(s.yOld))
+	for i := range out {
+		sum := 0.0
+		for j, kj := range s.k {
+			sum += kj[i] * (rkP[j][0]*q[0] + rkP[j][1]*q[1] + rkP[j][2]*q[2] + rkP[j][3]*q[3])
+		}
+		out[i] = s.yOld[i] + s.h*sum
+	}
+	return out
+}
+
+// BDF method constants, as in scipy.integrate.BDF: the NDF modification
+// coefficients kappa give better stability at orders 1 to 4
+const (
+	bdfMaxOrder      = 5
+	bdfNewtonMaxIter = 4
+	bdfMinFactor     = 0.2
+	bdfMaxFactor     = 10
+)
+
+var (
+	bdfKappa = [bdfMaxOrder + 1]float64{0, -0.1850, -1.0 / 9, -0.0823, -0.0415, 0}
+	bdfGamma [bdfMaxOrder + 1]float64
+	bdfAlpha [bdfMaxOrder + 1]float64
+	bdfError [bdfMaxOrder + 1]float64
+)
+
+func init() {
+	for k := 1; k <= bdfMaxOrder; k++ {
+		bdfGamma[k] = bdfGamma[k-1] + 1/float64(k)
+	}
+	for k := range bdfAlpha {
+		bdfAlpha[k] = (1 - bdfKappa[k]) * bdfGamma[k]
+		bdfError[k] = bdfKappa[k]*bdfGamma[k] + 1/float64(k+1)
+	}
+}
+
+// bdf is the variable-order, quasi-constant step size BDF stepper. The
+// solution history is kept as backward differences d[0..order+2].
+type bdf struct {
+	sys              *odeSystem
+	t, tf, direction float64
+	y                []float64
+	hAbs, maxStep    float64
+	rtol, atol       float64
+	newtonTol        float64
+	d                [][]float64
+	order            int
+	equalSteps       int
+	jac              *tensor.NDArray
+	lu               *linalg.LUFactorization
+	
+	// Dense output of the last step
+	tOld, h    float64
+	denseOrder int
+	denseD     [][]float64
+}
+
+func newBDF(sys *odeSystem, t0, tf float64, y0 []float64, direction, rtol, atol, maxStep, firstStep float64) *bdf {
+	f0 := sys.eval(t0, y0)
+	hAbs := math.Abs(firstStep)
+	if hAbs == 0 {
+		hAbs = initialStep(sys, t0, tf, y0, f0, direction, 1, rtol, atol)
+	}
+	n := len(y0)
+	d := make([][]float64, bdfMaxOrder+3)
+	for i := range d {
+		d[i] = make([]float64, n)
+	}
+	copy(d[0], y0)
+	for i, v := range f0 {
+		d[1][i] = v * hAbs * direction
+	}
+	return &bdf{
+		sys:       sys,
+		t:         t0,
+		tf:        tf,
+		direction: direction,
+		y:         y0,
+		hAbs:      hAbs,
+		maxStep:   maxStep,
+		rtol:      rtol,
+		atol:      atol,
+		newtonTol: math.Max(10*machineEpsilon/rtol, math.Min(0.03, math.Sqrt(rtol))),
+		d:         d,
+		order:     1,
+		jac:       sys.jacobian(t0, y0, f0),
+	}
+}
+
+func (s *bdf) current() (float64, []float64) {
+	return s.t, s.y
+}
+
+// bdfR returns the (order+1) x (order+1) matrix that rescales backward
+// differences for a step size change by factor
+func bdfR(order int, factor float64) [][]float64 {
+	m := make([][]float64, order+1)
+	for i := range m {
+		m[i] = make([]float64, order+1)
+	}
+	for j := range m[0] {
+		m[0][j] = 1
+	}
+	for i := 1; i <= order; i++ {
+		for j := 1; j <= order; j++ {
+			m[i][j] = m[i-1][j] * (float64(i) - 1 - factor*float64(j)) / float64(i)
+		}
+	}
+	return m
+}
+
+// changeD rescales the differences for a step size change by factor
+func (s *bdf) changeD(factor float64) {
+	order := s.order
+	r, u := bdfR(order, factor), bdfR(order, 1)
+	ru := make([][]float64, order+1)
+	for i := range ru {
+		ru[i] = make([]float64, order+1)
+		for j := range ru[i] {
+			for k := 0; k <= order; k++ {
+				ru[i][j] += r[i][k] * u[k][j]
+			}
+		}
+	}
+	n := len(s.y)
+	updated := make([][]float64, order+1)
+	for i := range updated {
+		updated[i] = make([]float64, n)
+		for k := 0; k <= order; k++ {
+			c := ru[k][i]
+			if c == 0 {
+				continue
+			}
+			for e := 0; e < n; e++ {
+				updated[i][e] += c * s.d[k][e]
+			}
+		}
+	}
+	copy(s.d, updated)
+}
+
+// newton solves the implicit BDF equation by simplified Newton iterations
+// from the predictor, returning whether it converged, the iteration count,
+// the solution and its difference from the predictor
+func (s *bdf) newton(tNew float64, predict []float64, c float64, psi, scale []float64) (bool, int, []float64, []float64) {
+	n := len(predict)
+	y := append([]float64{}, predict...)
+	d := make([]float64, n)
+	rhs := make([]float64, n)
+	oldNorm := -1.0
+	for k := 0; k < bdfNewtonMaxIter; k++ {
+		f := s.sys.eval(tNew, y)
+		for i, v := range f {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return false, k + 1, y, d
+			}
+			rhs[i] = c*v - psi[i] - d[i]
+		}
+		dy := s.lu.Solve(tensor.FromSliceFloat64(rhs, n)).ToSliceFloat64()
+		dyNorm := rmsNorm(dy, scale)
+		rate := -1.0
+		if oldNorm > 0 {
+			rate = dyNorm / oldNorm
+			if rate >= 1 || math.Pow(rate, float64(bdfNewtonMaxIter-k))/(1-rate)*dyNorm > s.newtonTol {
+				return false, k + 1, y, d
+			}
+		}
+		for i := range y {
+			y[i] += dy[i]
+			d[i] += dy[i]
+		}
+		if dyNorm == 0 || (rate >= 0 && rate/(1-rate)*dyNorm < s.newtonTol) {
+			return true, k + 1, y, d
+		}
+		oldNorm = dyNorm
+	}
+	return false, bdfNewtonMaxIter, y, d
+}
+
+// factorize computes the LU factorization of I - c J
+func (s *bdf) factorize(c float64) bool {
+	n := len(s.y)
+	jac := s.jac.ToSliceFloat64()
+	data := make([]float64, n*n)
+	for i := range data {
+		data[i] = -c * jac[i]
+	}
+	for i := 0; i < n; i++ {
+		data[i*n+i]++
+	}
+	s.sys.nlu++
+	s.lu = linalg.LUFactor(tensor.FromSliceFloat64(data, n, n))
+	return !s.lu.IsSingular()
+}
+
+func (s *bdf) step() bool {
+	n := len(s.y)
+	t := s.t
+	minStep := minStepSize(t, s.direction)
+	hAbs := s.hAbs
+	if hAbs > s.maxStep {
+		s.changeD(s.maxStep / hAbs)
+		hAbs = s.maxStep
+		s.equalSteps = 0
+	} else if hAbs < minStep {
+		s.changeD(minStep / hAbs)
+		hAbs = minStep
+		s.equalSteps = 0
+	}
+	
+	order := s.order
+	currentJac := false
+	var tNew float64
+	var yNew, d, scale []float64
+	var errNorm, safety float64
+	for {
+		if hAbs < minStep {
+			return false
+		}
+		tNew = t + s.direction*hAbs
+		if s.direction*(tNew-s.tf) > 0 {
+			tNew = s.tf
+			s.changeD(math.Abs(tNew-t) / hAbs)
+			s.equalSteps = 0
+			s.lu = nil
+		}
+		h := tNew - t
+		hAbs = math.Abs(h)
+		
+		predict := make([]float64, n)
+		for k := 0; k <= order; k++ {
+			for i := range predict {
+				predict[i] += s.d[k][i]
+			}
+		}
+		scale = make([]float64, n)
+		psi := make([]float64, n)
+		for i := range scale {
+			scale[i] = s.atol + s.rtol*math.Abs(predict[i])
+			for k := 1; k <= order; k++ {
+				psi[i] += s.d[k][i] * bdfGamma[k]
+			}
+			psi[i] /= bdfAlpha[order]
+		}
+		
+		c := h / bdfAlpha[order]
+		converged := false
+		var iterations int
+		for !converged {
+			if s.lu == nil && !s.factorize(c) {
+				s.lu = nil
+				break
+			}
+			converged, iterations, yNew, d = s.newton(tNew, predict, c, psi, scale)
+			if !converged {
+				if currentJac {
+					break
+				}
+				s.jac = s.sys.jacobian(tNew, predict, s.sys.eval(tNew, predict))
+				s.lu = nil
+				currentJac = true
+			}
+		}
+		if !converged {
+			hAbs *= 0.5
+			s.changeD(0.5)
+			s.equalSteps = 0
+			s.lu = nil
+			continue
+		}
+		
+		safety = 0.9 * float64(2*bdfNewtonMaxIter+1) / float64(2*bdfNewtonMaxIter+iterations)
+		errEst := make([]float64, n)
+		for i := range scale {
+			scale[i] = s.atol + s.rtol*math.Abs(yNew[i])
+			errEst[i] = bdfError[order] * d[i]
+		}
+		errNorm = rmsNorm(errEst, scale)
+		if errNorm <= 1 {
+			break
+		}
+		factor := math.Max(bdfMinFactor, safety*math.Pow(errNorm, -1/float64(order+1)))
+		hAbs *= factor
+		s.changeD(factor)
+		s.equalSteps = 0
+	}
+	
+	s.equalSteps++
+	s.tOld = t
+	s.t, s.y, s.hAbs = tNew, yNew, hAbs
+	
+	// Update the differences with the new point
+	for i := range d {
+		s.d[order+2][i] = d[i] - s.d[order+1][i]
+	}
+	copy(s.d[order+1], d)
+	for k := order; k >= 0; k-- {
+		for i := range d {
+			s.d[k][i] += s.d[k+1][i]
+		}
+	}
+	
+	if s.equalSteps >= order+1 {
+		// Choose the order whose error estimate allows the largest step
+		norms := [3]float64{math.Inf(1), errNorm, math.Inf(1)}
+		if order > 1 {
+			e := make([]float64, n)
+			for i := range e {
+				e[i] = bdfError[order-1] * s.d[order][i]
+			}
+			norms[0] = rmsNorm(e, scale)
+		}
+		if order < bdfMaxOrder {
+			e := make([]float64, n)
+			for i := range e {
+				e[i] = bdfError[order+1] * s.d[order+2][i]
+			}
+			norms[2] = rmsNorm(e, scale)
+		}
+		best, bestFactor := 0, -1.0
+		for k, norm := range norms {
+			factor := math.Pow(norm, -1/float64(order+k))
+			if norm == 0 {
+				factor = math.Inf(1)
+			}
+			if factor > bestFactor {
+				best, bestFactor = k, factor
+			}
+		}
+		s.order += best - 1
+		factor := math.Min(bdfMaxFactor, safety*bestFactor)
+		s.hAbs *= factor
+		s.changeD(factor)
+		s.equalSteps = 0
+		s.lu = nil
+	}
+	
+	s.h = s.hAbs * s.direction
+	s.denseOrder = s.order
+	s.denseD = make([][]float64, s.order+1)
+	for k := range s.denseD {
+		s.denseD[k] = append([]float64{}, s.d[k]...)
+	}
+	return true
+}
+
+// dense evaluates the interpolating polynomial through the last order+1
+// solution points, spaced h apart and ending at the current time
+func (s *bdf) dense(t float64) []float64 {
+	out := append([]float64{}, s.denseD[0]...)
+	p := 1.0
+	for j := 0; j < s.denseOrder; j++ {
+		shift := s.t - s.h*float64(j)
+		p *= (t - shift) / (s.h * float64(j+1))
+		for i := range out {
+			out[i] += s.denseD[j+1][i] * p
+		}
+	}
+	return out
+}
@@ -0,0 +1,140 @@
+package integrate
+
+import (
+	"fmt"
+	"math/bits"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// spacing returns the sample points for lanes of length n: the elements
+// of x when it is non-nil, otherwise nil to indicate even spacing
+func spacing(x *tensor.NDArray, n int) []float64 {
+	if x == nil {
+		return nil
+	}
+	xs := vector(x, "x")
+	if len(xs) != n {
+		panic(fmt.Sprintf("x has %d points, but y has %d samples along the axis", len(xs), n))
+	}
+	return xs
+}
+
+// Trapz integrates the 1D samples y with the trapezoidal rule. The
+// samples are at the points x, or evenly spaced by dx when x is nil.
+func Trapz(y, x *tensor.NDArray, dx float64) float64 {
+	ys := vector(y, "y")
+	return trapz(ys, spacing(x, len(ys)), dx)
+}
+
+// TrapzAxis integrates every lane of y along axis with the trapezoidal
+// rule, with samples at the points x or evenly spaced by dx
+func TrapzAxis(y, x *tensor.NDArray, dx float64, axis int) *tensor.NDArray {
+	xs := spacing(x, y.Shape()[normalizeAxis(y, axis)])
+	return reduceAxis(y, axis, func(lane []float64) float64 { return trapz(lane, xs, dx) })
+}
+
+func trapz(y, x []float64, dx float64) float64 {
+	sum := 0.0
+	for i := 1; i < len(y); i++ {
+		h := dx
+		if x != nil {
+			h = x[i] - x[i-1]
+		}
+		sum += h * (y[i] + y[i-1]) / 2
+	}
+	return sum
+}
+
+// Simpson integrates the 1D samples y with the composite Simpson's rule.
+// The samples are at the points x, or evenly spaced by dx when x is nil.
+// With an even number of samples the last interval is integrated with the
+// quadratic through the final three points, as scipy.integrate.simpson
+// does.
+func Simpson(y, x *tensor.NDArray, dx float64) float64 {
+	ys := vector(y, "y")
+	return simpson(ys, spacing(x, len(ys)), dx)
+}
+
+// SimpsonAxis integrates every lane of y along axis with Simpson's rule,
+// with samples at the points x or evenly spaced by dx
+func SimpsonAxis(y, x *tensor.NDArray, dx float64, axis int) *tensor.NDArray {
+	xs := spacing(x, y.Shape()[normalizeAxis(y, axis)])
+	return reduceAxis(y, axis, func(lane []float64) float64 { return simpson(lane, xs, dx) })
+}
+
+func simpson(y, x []float64, dx float64) float64 {
+	n := len(y)
+	if n < 3 {
+		return trapz(y, x, dx)
+	}
+	step := func(i int) float64 {
+		if x == nil {
+			return dx
+		}
+		return x[i+1] - x[i]
+	}
+	
+	// Pairs of intervals, each integrated by the parabola through three
+	// points
+	last := n - 1
+	if n%2 == 0 {
+		last = n - 2
+	}
+	sum := 0.0
+	for i := 0; i+2 <= last; i += 2 {
+		h0, h1 := step(i), step(i+1)
+		sum += (h0 + h1) / 6 * ((2-h1/h0)*y[i] + (h0+h1)*(h0+h1)/(h0*h1)*y[i+1] + (2-h0/h1)*y[i+2])
+	}
+	
+	if n%2 == 0 {
+		h0, h1 := step(n-3), step(n-2)
+		alpha := (2*h1*h1 + 3*h0*h1) / (6 * (h0 + h1))
+		beta := (h1*h1 + 3*h0*h1) / (6 * h0)
+		eta := h1 * h1 * h1 / (6 * h0 * (h0 + h1))
+		sum += alpha*y[n-1] + beta*y[n-2] - eta*y[n-3]
+	}
+	return sum
+}
+
+// Romberg integrates the 1D samples y, evenly spaced by dx, with Romberg
+// extrapolation of the trapezoidal rule. The number of samples must be
+// 2^k + 1.
+func Romberg(y *tensor.NDArray, dx float64) float64 {
+	return romberg(vector(y, "y"), dx)
+}
+
+// RombergAxis integrates every lane of y along axis with Romberg's
+// method, with samples evenly spaced by dx
+func RombergAxis(y *tensor.NDArray, dx float64, axis int) *tensor.NDArray {
+	return reduceAxis(y, axis, func(lane []float64) float64 { return romberg(lane, dx) })
+}
+
+func romberg(y []float64, dx float64) float64 {
+	intervals := len(y) - 1
+	if intervals < 1 || intervals&(intervals-1) != 0 {
+		panic(fmt.Sprintf("Romberg requires 2^k + 1 samples, got %d", len(y)))
+	}
+	k := bits.TrailingZeros(uint(intervals))
+	
+	h := float64(intervals) * dx
+	row := make([]float64, k+1)
+	prev := make([]float64, k+1)
+	row[0] = h * (y[0] + y[intervals]) / 2
+	for i := 1; i <= k; i++ {
+		copy(prev, row)
+		stride := intervals >> i
+		sum := 0.0
+		for j := stride; j < intervals; j += 2 * stride {
+			sum += y[j]
+		}
+		h /= 2
+		row[0] = prev[0]/2 + h*sum
+		factor := 1.0
+		for m := 1; m <= i; m++ {
+			factor *= 4
+			row[m] = row[m-1] + (row[m-1]-prev[m-1])/(factor-1)
+		}
+	}
+	return row[k]
+}
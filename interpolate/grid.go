@@ -0,0 +1,269 @@
+package interpolate
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// RegularGridInterpolator interpolates values sampled on a rectilinear grid
+// in any number of dimensions. Linear is multilinear (bilinear in 2-D),
+// Nearest picks the closest grid point and Cubic is a tensor product of
+// not-a-knot cubic splines (bicubic in 2-D). It is immutable and safe for
+// concurrent use.
+type RegularGridInterpolator struct {
+	grid   [][]float64
+	shape  []int
+	values []float64
+	kind   Kind
+	opts   Options
+	slope  []float64 // spline derivatives along the last axis, for Cubic
+}
+
+// NewRegularGridInterpolator creates an interpolator of values, whose
+// shape is (len(points[0]), len(points[1]), ...), on the grid with strictly
+// ascending coordinates points. Points outside the grid panic; use
+// NewRegularGridInterpolatorWith to fill or extrapolate instead.
+func NewRegularGridInterpolator(points []*tensor.NDArray, values *tensor.NDArray, kind Kind) RegularGridInterpolator {
+	return NewRegularGridInterpolatorWith(points, values, kind, Options{})
+}
+
+// NewRegularGridInterpolatorWith is NewRegularGridInterpolator with control
+// over points outside the grid. Cubic interpolation needs at least 4 points
+// along every axis, the others at least 2.
+func NewRegularGridInterpolatorWith(points []*tensor.NDArray, values *tensor.NDArray, kind Kind, opts Options) RegularGridInterpolator {
+	minPoints := 2
+	switch kind {
+	case Linear, Nearest:
+	case Cubic:
+		minPoints = 4
+	default:
+		panic(fmt.Sprintf("%s interpolation is not supported on grids", kind))
+	}
+	if values.DType().IsComplex() {
+		panic("interpolation of complex data is not supported")
+	}
+	shape := values.Shape()
+	if len(points) == 0 || len(points) != len(shape) {
+		panic(fmt.Sprintf("there are %d point arrays, but values has %d dimensions", len(points), len(shape)))
+	}
+	
+	grid := make([][]float64, len(points))
+	for d, p := range points {
+		if p.Ndim() != 1 {
+			panic(fmt.Sprintf("the points in dimension %d must be a 1D array", d))
+		}
+		g := p.ToSliceFloat64()
+		if len(g) != shape[d] {
+			panic(fmt.Sprintf("there are %d points in dimension %d, but values has %d", len(g), d, shape[d]))
+		}
+		if len(g) < minPoints {
+			panic(fmt.Sprintf("at least %d points are required in dimension %d, got %d", minPoints, d, len(g)))
+		}
+		for i := 1; i < len(g); i++ {
+			if !(g[i] > g[i-1]) {
+				panic(fmt.Sprintf("the points in dimension %d must be strictly ascending", d))
+			}
+		}
+		grid[d] = g
+	}
+	
+	g := RegularGridInterpolator{
+		grid:   grid,
+		shape:  append([]int{}, shape...),
+		values: values.ToSliceFloat64(),
+		kind:   kind,
+		opts:   opts,
+	}
+	if kind == Cubic {
+		last := grid[len(grid)-1]
+		n := len(last)
+		g.slope = make([]float64, len(g.values))
+		for start := 0; start < len(g.values); start += n {
+			copy(g.slope[start:start+n], notAKnotSlopes(last, g.values[start:start+n]))
+		}
+	}
+	return g
+}
+
+// Ndim returns the number of grid dimensions
+func (g RegularGridInterpolator) Ndim() int {
+	return len(g.grid)
+}
+
+// At evaluates the interpolant at a single point given by one coordinate
+// per grid dimension
+func (g RegularGridInterpolator) At(point ...float64) float64 {
+	if len(point) != len(g.grid) {
+		panic(fmt.Sprintf("expected a point with %d coordinates, got %d", len(g.grid), len(point)))
+	}
+	for d, v := range point {
+		if math.IsNaN(v) {
+			return math.NaN()
+		}
+		axis := g.grid[d]
+		if v >= axis[0] && v <= axis[len(axis)-1] {
+			continue
+		}
+		switch g.opts.Bounds {
+		case Fill:
+			return g.opts.FillValue
+		case Extrapolate:
+		default:
+			panic(fmt.Sprintf("%v is outside the grid range [%v, %v] in dimension %d", v, axis[0], axis[len(axis)-1], d))
+		}
+	}
+	
+	switch g.kind {
+	case Nearest:
+		return g.nearest(point)
+	case Cubic:
+		return g.cubic(point)
+	default:
+		return g.linear(point)
+	}
+}
+
+// Eval evaluates the interpolant at the points xi, an array of shape
+// (..., ndim) holding one point per row, returning an array of shape (...).
+// A single point given as a 1D array gives a one-element array.
+func (g RegularGridInterpolator) Eval(xi *tensor.NDArray) *tensor.NDArray {
+	shape := xi.Shape()
+	ndim := len(g.grid)
+	if len(shape) == 0 || shape[len(shape)-1] != ndim {
+		panic(fmt.Sprintf("xi has shape %v, but the last axis must have length %d", shape, ndim))
+	}
+	coords := xi.ToSliceFloat64()
+	out := make([]float64, len(coords)/ndim)
+	for i := range out {
+		out[i] = g.At(coords[i*ndim : (i+1)*ndim]...)
+	}
+	outShape := shape[:len(shape)-1]
+	if len(outShape) == 0 {
+		outShape = []int{1}
+	}
+	return tensor.FromSliceFloat64(out, outShape...)
+}
+
+// linear interpolates multilinearly between the 2^ndim corners of the cell
+// containing point
+func (g RegularGridInterpolator) linear(point []float64) float64 {
+	ndim := len(g.grid)
+	index := make([]int, ndim)
+	weight := make([]float64, ndim)
+	for d, v := range point {
+		axis := g.grid[d]
+		i := interval(axis, v)
+		index[d] = i
+		weight[d] = (v - axis[i]) / (axis[i+1] - axis[i])
+	}
+	
+	sum := 0.0
+	for corner := 0; corner < 1<<ndim; corner++ {
+		w := 1.0
+		offset := 0
+		for d := 0; d < ndim; d++ {
+			i := index[d]
+			if corner>>(ndim-1-d)&1 == 1 {
+				w *= weight[d]
+				i++
+			} else {
+				w *= 1 - weight[d]
+			}
+			offset = offset*g.shape[d] + i
+		}
+		if w != 0 {
+			sum += w * g.values[offset]
+		}
+	}
+	return sum
+}
+
+// nearest returns the value at the grid point closest to point, rounding
+// halfway coordinates down
+func (g RegularGridInterpolator) nearest(point []float64) float64 {
+	offset := 0
+	for d, v := range point {
+		axis := g.grid[d]
+		i := interval(axis, v)
+		if v > (axis[i]+axis[i+1])/2 {
+			i++
+		}
+		offset = offset*g.shape[d] + i
+	}
+	return g.values[offset]
+}
+
+// cubic evaluates the tensor-product spline at point. The last axis uses
+// the splines precomputed for every grid line; each remaining axis is
+// collapsed by fitting a spline through the values of the previous step.
+func (g RegularGridInterpolator) cubic(point []float64) float64 {
+	last := len(g.grid) - 1
+	axis := g.grid[last]
+	n := len(axis)
+	i := interval(axis, point[last])
+	current := make([]float64, len(g.values)/n)
+	for k := range current {
+		j := k*n + i
+		current[k] = hermite(axis[i], axis[i+1], g.values[j], g.values[j+1], g.slope[j], g.slope[j+1], point[last])
+	}
+	
+	for d := last - 1; d >= 0; d-- {
+		axis = g.grid[d]
+		n = len(axis)
+		i = interval(axis, point[d])
+		next := make([]float64, len(current)/n)
+		for k := range next {
+			lane := current[k*n : (k+1)*n]
+			slope := notAKnotSlopes(axis, lane)
+			next[k] = hermite(axis[i], axis[i+1], lane[i], lane[i+1], slope[i], slope[i+1], point[d])
+		}
+		current = next
+	}
+	return current[0]
+}
+
+// Interp2D interpolates a surface z = f(x, y) sampled on a rectilinear
+// grid, with bilinear (Linear), nearest-neighbour (Nearest) or bicubic
+// (Cubic) interpolation
+type Interp2D struct {
+	grid RegularGridInterpolator
+}
+
+// NewInterp2D creates an interpolator of z, whose shape is
+// (len(y), len(x)) as for an image or a meshgrid, over strictly ascending
+// x and y. Points outside the grid panic; use NewInterp2DWith to fill or
+// extrapolate instead.
+func NewInterp2D(x, y, z *tensor.NDArray, kind Kind) Interp2D {
+	return NewInterp2DWith(x, y, z, kind, Options{})
+}
+
+// NewInterp2DWith is NewInterp2D with control over points outside the grid
+func NewInterp2DWith(x, y, z *tensor.NDArray, kind Kind, opts Options) Interp2D {
+	if z.Ndim() != 2 {
+		panic("z must be a 2D array")
+	}
+	return Interp2D{grid: NewRegularGridInterpolatorWith([]*tensor.NDArray{y, x}, z, kind, opts)}
+}
+
+// At evaluates the surface at (x, y)
+func (f Interp2D) At(x, y float64) float64 {
+	return f.grid.At(y, x)
+}
+
+// Eval evaluates the surface on the grid spanned by the 1D arrays xs and
+// ys, returning an array of shape (len(ys), len(xs))
+func (f Interp2D) Eval(xs, ys *tensor.NDArray) *tensor.NDArray {
+	if xs.Ndim() != 1 || ys.Ndim() != 1 {
+		panic("xs and ys must be 1D arrays")
+	}
+	xv, yv := xs.ToSliceFloat64(), ys.ToSliceFloat64()
+	out := make([]float64, len(yv)*len(xv))
+	for i, y := range yv {
+		for j, x := range xv {
+			out[i*len(xv)+j] = f.grid.At(y, x)
+		}
+	}
+	return tensor.FromSliceFloat64(out, len(yv), len(xv))
+}
@@ -0,0 +1,170 @@
+package interpolate
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Interp1D interpolates a function of one variable from samples y = f(x).
+// It is immutable and safe for concurrent use.
+type Interp1D struct {
+	x, y  []float64
+	kind  Kind
+	opts  Options
+	slope []float64 // spline derivatives at the knots, for Cubic
+}
+
+// NewInterp1D creates an interpolator through the points (x[i], y[i]),
+// which need not be sorted. Points outside the range of x panic; use
+// NewInterp1DWith to fill or extrapolate instead.
+func NewInterp1D(x, y *tensor.NDArray, kind Kind) Interp1D {
+	return NewInterp1DWith(x, y, kind, Options{})
+}
+
+// NewInterp1DWith is NewInterp1D with control over out-of-range points.
+// Cubic interpolation needs at least 4 points, the others at least 2.
+func NewInterp1DWith(x, y *tensor.NDArray, kind Kind, opts Options) Interp1D {
+	minPoints := 2
+	switch kind {
+	case Linear, Nearest, Previous, Next:
+	case Cubic:
+		minPoints = 4
+	default:
+		panic(fmt.Sprintf("unknown interpolation kind %d", int(kind)))
+	}
+	
+	xs, ys := sortedSamples(x, y, minPoints)
+	f := Interp1D{x: xs, y: ys, kind: kind, opts: opts}
+	if kind == Cubic {
+		f.slope = notAKnotSlopes(xs, ys)
+	}
+	return f
+}
+
+// Kind returns the interpolation method
+func (f Interp1D) Kind() Kind {
+	return f.kind
+}
+
+// At evaluates the interpolant at a single point
+func (f Interp1D) At(v float64) float64 {
+	n := len(f.x)
+	if v < f.x[0] || v > f.x[n-1] {
+		switch f.opts.Bounds {
+		case Fill:
+			return f.opts.FillValue
+		case Extrapolate:
+		default:
+			panic(fmt.Sprintf("%v is outside the interpolation range [%v, %v]", v, f.x[0], f.x[n-1]))
+		}
+	}
+	if math.IsNaN(v) {
+		return math.NaN()
+	}
+	
+	i := interval(f.x, v)
+	x0, x1 := f.x[i], f.x[i+1]
+	y0, y1 := f.y[i], f.y[i+1]
+	switch f.kind {
+	case Nearest:
+		if v <= (x0+x1)/2 {
+			return y0
+		}
+		return y1
+	case Previous:
+		if v >= x1 {
+			return y1
+		}
+		return y0
+	case Next:
+		if v <= x0 {
+			return y0
+		}
+		return y1
+	case Cubic:
+		return hermite(x0, x1, y0, y1, f.slope[i], f.slope[i+1], v)
+	default:
+		return y0 + (y1-y0)*(v-x0)/(x1-x0)
+	}
+}
+
+// Eval evaluates the interpolant at every element of xs, returning a
+// float64 array of the same shape
+func (f Interp1D) Eval(xs *tensor.NDArray) *tensor.NDArray {
+	values := xs.ToSliceFloat64()
+	for i, v := range values {
+		values[i] = f.At(v)
+	}
+	return tensor.FromSliceFloat64(values, xs.Shape()...)
+}
+
+// hermite evaluates the cubic on [x0, x1] with values y0, y1 and
+// derivatives s0, s1 at the ends
+func hermite(x0, x1, y0, y1, s0, s1, v float64) float64 {
+	h := x1 - x0
+	m := (y1 - y0) / h
+	t := v - x0
+	c2 := (3*m - 2*s0 - s1) / h
+	c3 := (s0 + s1 - 2*m) / (h * h)
+	return y0 + t*(s0+t*(c2+t*c3))
+}
+
+// notAKnotSlopes returns the knot derivatives of the cubic spline through
+// (x, y) whose third derivative is continuous at x[1] and x[n-2]. The
+// conditions form a tridiagonal system, solved with the Thomas algorithm.
+func notAKnotSlopes(x, y []float64) []float64 {
+	n := len(x)
+	dx := make([]float64, n-1)
+	m := make([]float64, n-1)
+	for i := range dx {
+		dx[i] = x[i+1] - x[i]
+		m[i] = (y[i+1] - y[i]) / dx[i]
+	}
+	
+	sub := make([]float64, n)
+	diag := make([]float64, n)
+	sup := make([]float64, n)
+	rhs := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		sub[i] = dx[i]
+		diag[i] = 2 * (dx[i-1] + dx[i])
+		sup[i] = dx[i-1]
+		rhs[i] = 3 * (dx[i]*m[i-1] + dx[i-1]*m[i])
+	}
+	
+	d := x[2] - x[0]
+	diag[0] = dx[1]
+	sup[0] = d
+	rhs[0] = ((dx[0]+2*d)*dx[1]*m[0] + dx[0]*dx[0]*m[1]) / d
+	
+	d = x[n-1] - x[n-3]
+	diag[n-1] = dx[n-3]
+	sub[n-1] = d
+	rhs[n-1] = (dx[n-2]*dx[n-2]*m[n-3] + (2*d+dx[n-2])*dx[n-3]*m[n-2]) / d
+	
+	return solveTridiagonal(sub, diag, sup, rhs)
+}
+
+// solveTridiagonal solves the tridiagonal system with sub-, main and
+// super-diagonals sub, diag and sup, where sub[0] and sup[n-1] are unused
+func solveTridiagonal(sub, diag, sup, rhs []float64) []float64 {
+	n := len(diag)
+	c := make([]float64, n)
+	d := make([]float64, n)
+	c[0] = sup[0] / diag[0]
+	d[0] = rhs[0] / diag[0]
+	for i := 1; i < n; i++ {
+		denom := diag[i] - sub[i]*c[i-1]
+		c[i] = sup[i] / denom
+		d[i] = (rhs[i] - sub[i]*d[i-1]) / denom
+	}
+	
+	out := make([]float64, n)
+	out[n-1] = d[n-1]
+	for i := n - 2; i >= 0; i-- {
+		out[i] = d[i] - c[i]*out[i+1]
+	}
+	return out
+}
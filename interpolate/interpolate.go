@@ -0,0 +1,107 @@
+// Package interpolate provides interpolation of sampled data for NumGo
+// arrays
+package interpolate
+
+import (
+	"fmt"
+	"sort"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Kind selects the interpolation method
+type Kind int
+
+const (
+	// Linear joins neighbouring samples with straight lines
+	Linear Kind = iota
+	// Nearest takes the value of the closest sample, rounding halfway
+	// points down
+	Nearest
+	// Previous takes the value of the sample at or before the point
+	Previous
+	// Next takes the value of the sample at or after the point
+	Next
+	// Cubic uses a C2 cubic spline with not-a-knot end conditions
+	Cubic
+)
+
+// String returns the SciPy name of the kind
+func (k Kind) String() string {
+	switch k {
+	case Linear:
+		return "linear"
+	case Nearest:
+		return "nearest"
+	case Previous:
+		return "previous"
+	case Next:
+		return "next"
+	case Cubic:
+		return "cubic"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// Bounds selects what happens to points outside the sampled range
+type Bounds int
+
+const (
+	// Strict panics on points outside the sampled range
+	Strict Bounds = iota
+	// Fill returns Options.FillValue outside the sampled range
+	Fill
+	// Extrapolate extends the end pieces of the interpolant
+	Extrapolate
+)
+
+// Options configures an interpolator. The zero value panics on points
+// outside the sampled range, like SciPy's bounds_error=True.
+type Options struct {
+	Bounds Bounds
+	// FillValue is returned outside the sampled range when Bounds is Fill.
+	// Use math.NaN() for SciPy's default.
+	FillValue float64
+}
+
+// sortedSamples returns copies of the 1D arrays x and y sorted by x,
+// panicking if they differ in length, are too short or repeat an x value
+func sortedSamples(x, y *tensor.NDArray, minPoints int) ([]float64, []float64) {
+	if x.Ndim() != 1 || y.Ndim() != 1 {
+		panic("x and y must be 1D arrays")
+	}
+	if y.DType().IsComplex() {
+		panic("interpolation of complex data is not supported")
+	}
+	xs, ys := x.ToSliceFloat64(), y.ToSliceFloat64()
+	if len(xs) != len(ys) {
+		panic(fmt.Sprintf("x and y must have the same length, got %d and %d", len(xs), len(ys)))
+	}
+	if len(xs) < minPoints {
+		panic(fmt.Sprintf("at least %d points are required, got %d", minPoints, len(xs)))
+	}
+	
+	order := make([]int, len(xs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return xs[order[i]] < xs[order[j]] })
+	
+	sx := make([]float64, len(xs))
+	sy := make([]float64, len(ys))
+	for i, k := range order {
+		sx[i], sy[i] = xs[k], ys[k]
+		if i > 0 && !(sx[i] > sx[i-1]) {
+			panic(fmt.Sprintf("x values must be distinct, got %v twice", sx[i]))
+		}
+	}
+	return sx, sy
+}
+
+// interval returns the index i of the interval [x[i], x[i+1]] containing
+// v, clamped to the first and last intervals
+func interval(x []float64, v float64) int {
+	i := sort.SearchFloat64s(x, v) - 1
+	return min(max(i, 0), len(x)-2)
+}
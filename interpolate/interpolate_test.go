@@ -0,0 +1,206 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func sliceClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestInterp1DKinds(t *testing.T) {
+	x := tensor.FromSliceFloat64([]float64{0, 1, 2, 3}, 4)
+	y := tensor.FromSliceFloat64([]float64{0, 10, 20, 0}, 4)
+	at := tensor.FromSliceFloat64([]float64{0, 0.25, 0.5, 1, 2.75, 3}, 6)
+	
+	cases := []struct {
+		kind Kind
+		want []float64
+	}{
+		{Linear, []float64{0, 2.5, 5, 10, 5, 0}},
+		{Nearest, []float64{0, 0, 0, 10, 0, 0}},
+		{Previous, []float64{0, 0, 0, 10, 20, 0}},
+		{Next, []float64{0, 10, 10, 10, 0, 0}},
+	}
+	for _, c := range cases {
+		got := NewInterp1D(x, y, c.kind).Eval(at).ToSliceFloat64()
+		if !sliceClose(got, c.want, 1e-12) {
+			t.Errorf("%s: expected %v, got %v", c.kind, c.want, got)
+		}
+	}
+}
+
+func TestInterp1DUnsorted(t *testing.T) {
+	x := tensor.FromSliceFloat64([]float64{2, 0, 1}, 3)
+	y := tensor.FromSliceFloat64([]float64{4, 0, 1}, 3)
+	if got := NewInterp1D(x, y, Linear).At(1.5); got != 2.5 {
+		t.Errorf("expected 2.5, got %v", got)
+	}
+}
+
+func TestInterp1DCubic(t *testing.T) {
+	// Not-a-knot splines reproduce cubics exactly, even on uneven grids
+	cubic := func(v float64) float64 { return 2 - v + 0.5*v*v - 0.25*v*v*v }
+	xs := []float64{-1, -0.3, 0.4, 2, 2.5, 4}
+	ys := make([]float64, len(xs))
+	for i, v := range xs {
+		ys[i] = cubic(v)
+	}
+	f := NewInterp1DWith(tensor.FromSliceFloat64(xs, len(xs)), tensor.FromSliceFloat64(ys, len(ys)), Cubic, Options{Bounds: Extrapolate})
+	for _, v := range []float64{-1.5, -1, 0, 1.1, 2.2, 3.9, 5} {
+		if got, want := f.At(v), cubic(v); math.Abs(got-want) > 1e-10 {
+			t.Errorf("At(%v): expected %v, got %v", v, want, got)
+		}
+	}
+	
+	// A unit-spaced sampled sine is followed to within a few hundredths
+	n := 8
+	sx := make([]float64, n)
+	sy := make([]float64, n)
+	for i := range sx {
+		sx[i] = float64(i)
+		sy[i] = math.Sin(sx[i])
+	}
+	g := NewInterp1D(tensor.FromSliceFloat64(sx, n), tensor.FromSliceFloat64(sy, n), Cubic)
+	for _, v := range []float64{0.5, 3.3, 6.5} {
+		if got := g.At(v); math.Abs(got-math.Sin(v)) > 0.03 {
+			t.Errorf("At(%v): expected about %v, got %v", v, math.Sin(v), got)
+		}
+	}
+}
+
+func TestInterp1DBounds(t *testing.T) {
+	x := tensor.FromSliceFloat64([]float64{0, 1, 2}, 3)
+	y := tensor.FromSliceFloat64([]float64{1, 3, 5}, 3)
+	
+	if got := NewInterp1DWith(x, y, Linear, Options{Bounds: Extrapolate}).At(3); got != 7 {
+		t.Errorf("extrapolate: expected 7, got %v", got)
+	}
+	if got := NewInterp1DWith(x, y, Linear, Options{Bounds: Fill, FillValue: -1}).At(-0.5); got != -1 {
+		t.Errorf("fill: expected -1, got %v", got)
+	}
+	if got := NewInterp1DWith(x, y, Linear, Options{Bounds: Fill, FillValue: math.NaN()}).At(2.5); !math.IsNaN(got) {
+		t.Errorf("fill: expected NaN, got %v", got)
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an out-of-range point")
+		}
+	}()
+	NewInterp1D(x, y, Linear).At(2.5)
+}
+
+func TestInterp1DPanics(t *testing.T) {
+	cases := map[string]func(){
+		"duplicate x": func() {
+			NewInterp1D(tensor.FromSliceFloat64([]float64{0, 1, 1}, 3), tensor.FromSliceFloat64([]float64{0, 1, 2}, 3), Linear)
+		},
+		"length mismatch": func() {
+			NewInterp1D(tensor.FromSliceFloat64([]float64{0, 1}, 2), tensor.FromSliceFloat64([]float64{0, 1, 2}, 3), Linear)
+		},
+		"too few for cubic": func() {
+			NewInterp1D(tensor.FromSliceFloat64([]float64{0, 1, 2}, 3), tensor.FromSliceFloat64([]float64{0, 1, 2}, 3), Cubic)
+		},
+	}
+	for name, f := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected a panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestRegularGridInterpolator(t *testing.T) {
+	// A function linear in each coordinate is reproduced by Linear
+	xs := []float64{0, 1, 3}
+	ys := []float64{-1, 0, 2, 5}
+	f := func(x, y float64) float64 { return 1 + 2*x - y + 0.5*x*y }
+	values := make([]float64, 0, len(xs)*len(ys))
+	for _, x := range xs {
+		for _, y := range ys {
+			values = append(values, f(x, y))
+		}
+	}
+	points := []*tensor.NDArray{tensor.FromSliceFloat64(xs, 3), tensor.FromSliceFloat64(ys, 4)}
+	g := NewRegularGridInterpolator(points, tensor.FromSliceFloat64(values, 3, 4), Linear)
+	
+	xi := tensor.FromSliceFloat64([]float64{0.5, -0.5, 2, 4, 3, 5}, 3, 2)
+	got := g.Eval(xi).ToSliceFloat64()
+	want := []float64{f(0.5, -0.5), f(2, 4), f(3, 5)}
+	if !sliceClose(got, want, 1e-12) {
+		t.Errorf("linear: expected %v, got %v", want, got)
+	}
+	
+	single := g.Eval(tensor.FromSliceFloat64([]float64{1, 2}, 2))
+	if s := single.Shape(); len(s) != 1 || s[0] != 1 || single.GetFloat64(0) != f(1, 2) {
+		t.Errorf("single point: expected [%v], got %v", f(1, 2), single.ToSliceFloat64())
+	}
+	
+	near := NewRegularGridInterpolator(points, tensor.FromSliceFloat64(values, 3, 4), Nearest)
+	if got, want := near.At(2.1, 0.9), f(3, 0); got != want {
+		t.Errorf("nearest: expected %v, got %v", want, got)
+	}
+	if got, want := near.At(0.5, 1), f(0, 0); got != want {
+		t.Errorf("nearest halfway: expected %v, got %v", want, got)
+	}
+}
+
+func TestRegularGridInterpolatorCubic(t *testing.T) {
+	// Bicubic splines reproduce products of cubics exactly
+	f := func(x, y float64) float64 { return (1 - x + x*x*x) * (2 + y - 0.5*y*y) }
+	xs := []float64{0, 0.5, 1.5, 2, 3}
+	ys := []float64{-2, -1, 0, 0.5, 1, 2}
+	values := make([]float64, 0, len(xs)*len(ys))
+	for _, x := range xs {
+		for _, y := range ys {
+			values = append(values, f(x, y))
+		}
+	}
+	points := []*tensor.NDArray{tensor.FromSliceFloat64(xs, len(xs)), tensor.FromSliceFloat64(ys, len(ys))}
+	g := NewRegularGridInterpolatorWith(points, tensor.FromSliceFloat64(values, len(xs), len(ys)), Cubic, Options{Bounds: Extrapolate})
+	for _, p := range [][2]float64{{0.3, 1.7}, {2.9, -1.9}, {1, 0.25}, {3.5, 2.5}} {
+		if got, want := g.At(p[0], p[1]), f(p[0], p[1]); math.Abs(got-want) > 1e-10 {
+			t.Errorf("At(%v): expected %v, got %v", p, want, got)
+		}
+	}
+}
+
+func TestInterp2D(t *testing.T) {
+	x := tensor.FromSliceFloat64([]float64{0, 1, 2}, 3)
+	y := tensor.FromSliceFloat64([]float64{0, 10}, 2)
+	z := tensor.FromSliceFloat64([]float64{0, 1, 2, 10, 11, 12}, 2, 3)
+	f := NewInterp2D(x, y, z, Linear)
+	
+	if got := f.At(1.5, 5); got != 6.5 {
+		t.Errorf("expected 6.5, got %v", got)
+	}
+	out := f.Eval(tensor.FromSliceFloat64([]float64{0, 0.5, 2}, 3), tensor.FromSliceFloat64([]float64{2.5, 10}, 2))
+	if s := out.Shape(); len(s) != 2 || s[0] != 2 || s[1] != 3 {
+		t.Fatalf("expected shape [2 3], got %v", s)
+	}
+	want := []float64{2.5, 3, 4.5, 10, 10.5, 12}
+	if got := out.ToSliceFloat64(); !sliceClose(got, want, 1e-12) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	
+	filled := NewInterp2DWith(x, y, z, Linear, Options{Bounds: Fill, FillValue: -1})
+	if got := filled.At(2.5, 0); got != -1 {
+		t.Errorf("fill: expected -1, got %v", got)
+	}
+}
@@ -0,0 +1,563 @@
+package io
+
+import (
+	"encoding/binary"
+	"fmt"
+	goio "io"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Arrow support speaks the Arrow IPC format, the encapsulated FlatBuffers
+// messages exchanged by Arrow libraries and Flight services, so that data
+// moves between NumGo and any Arrow implementation without a dependency
+// on one. Buffers are copied in both directions since NDArrays own their
+// memory.
+
+// arrowVersion is MetadataVersion V5
+const arrowVersion = 4
+
+// Message header types
+const (
+	arrowSchema      = 1
+	arrowRecordBatch = 3
+	arrowTensor      = 4
+)
+
+// Type union members
+const (
+	arrowInt           = 2
+	arrowFloatingPoint = 3
+	arrowBool          = 6
+	arrowFixedSizeList = 16
+)
+
+// arrowField describes a column of a record batch: a primitive array, or
+// a fixed-size list of listSize primitive values per row
+type arrowField struct {
+	name     string
+	dtype    tensor.DType
+	listSize int
+}
+
+// WriteArrowTensor writes a as an Arrow IPC tensor message, as produced
+// by pyarrow.ipc.write_tensor. Boolean and complex arrays have no Arrow
+// tensor type.
+func WriteArrowTensor(w goio.Writer, a *tensor.NDArray) error {
+	dtype := a.DType()
+	if dtype == tensor.Bool || dtype.IsComplex() {
+		return fmt.Errorf("dtype %s cannot be stored in an Arrow tensor", dtype)
+	}
+	typeID, typ, err := arrowType(dtype)
+	if err != nil {
+		return err
+	}
+	shape := a.Shape()
+	dims := make(fbVector, len(shape))
+	strides := make([]byte, 8*len(shape))
+	stride := dtype.ItemSize()
+	for d := len(shape) - 1; d >= 0; d-- {
+		dims[d] = fbTable{fbInt64(int64(shape[d]))}
+		binary.LittleEndian.PutUint64(strides[8*d:], uint64(stride))
+		stride *= shape[d]
+	}
+	data := a.Data()
+	buffer := binary.LittleEndian.AppendUint64(nil, 0)
+	buffer = binary.LittleEndian.AppendUint64(buffer, uint64(len(data)))
+	
+	header := fbTable{fbScalar{1, typeID}, typ, dims, fbStructs{8, strides}, fbStruct(buffer)}
+	return writeArrowMessage(w, arrowTensor, header, [][]byte{data})
+}
+
+// ReadArrowTensor reads an Arrow IPC tensor message from r. Tensors with
+// any strides, such as column-major ones, are returned in C order.
+func ReadArrowTensor(r goio.Reader) (*tensor.NDArray, error) {
+	msg, body, err := readArrowMessage(r)
+	if err != nil {
+		return nil, err
+	}
+	if msg.buf == nil {
+		return nil, fmt.Errorf("unexpected end of Arrow stream")
+	}
+	if msg.scalar(1, 1, 0) != arrowTensor {
+		return nil, fmt.Errorf("Arrow message is not a tensor")
+	}
+	t, ok := msg.table(2)
+	if !ok {
+		return nil, fmt.Errorf("Arrow tensor message has no header")
+	}
+	typ, ok := t.table(1)
+	if !ok {
+		return nil, fmt.Errorf("Arrow tensor has no type")
+	}
+	dtype, err := arrowDType(byte(t.scalar(0, 1, 0)), typ)
+	if err != nil {
+		return nil, err
+	}
+	itemsize := dtype.ItemSize()
+	
+	var shape []int
+	n := 1
+	for _, dim := range t.tables(2) {
+		s := int64(dim.scalar(0, 8, 0))
+		if s < 0 || s > 0 && int64(n) > math.MaxInt/int64(itemsize)/s {
+			return nil, fmt.Errorf("Arrow tensor dimension %d is invalid or too large", s)
+		}
+		shape = append(shape, int(s))
+		n *= int(s)
+	}
+	strides := make([]int, len(shape))
+	stride := itemsize
+	for d := len(shape) - 1; d >= 0; d-- {
+		strides[d] = stride
+		stride *= shape[d]
+	}
+	if start, n := t.vector(3, 8); n == len(shape) && n > 0 {
+		for d := range strides {
+			strides[d] = int(int64(binary.LittleEndian.Uint64(t.buf[start+8*d:])))
+		}
+	}
+	at := t.field(4)
+	if at < 0 || at+16 > len(t.buf) {
+		return nil, fmt.Errorf("Arrow tensor has no data buffer")
+	}
+	offset := int(binary.LittleEndian.Uint64(t.buf[at:]))
+	length := int(binary.LittleEndian.Uint64(t.buf[at+8:]))
+	if offset < 0 || length < 0 || offset+length > len(body) {
+		return nil, fmt.Errorf("Arrow tensor buffer is out of range")
+	}
+	src := body[offset : offset+length]
+	
+	// Gather the elements in C order through the strides
+	data := make([]byte, n*itemsize)
+	index := make([]int, len(shape))
+	for i := 0; i < n; i++ {
+		pos := 0
+		for d, k := range index {
+			pos += k * strides[d]
+		}
+		if pos < 0 || pos+itemsize > len(src) {
+			return nil, fmt.Errorf("Arrow tensor strides exceed its buffer")
+		}
+		copy(data[i*itemsize:], src[pos:pos+itemsize])
+		for d := len(index) - 1; d >= 0; d-- {
+			index[d]++
+			if index[d] < shape[d] {
+				break
+			}
+			index[d] = 0
+		}
+	}
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	return tensor.FromBytes(data, dtype, shape...), nil
+}
+
+// WriteArrowStream writes t as an Arrow IPC stream holding a schema and
+// one record batch. 1D columns become primitive Arrow arrays and 2D
+// columns of shape (rows, k) become fixed-size lists of k values per row.
+// Entries flagged in t.Missing are written as nulls.
+func WriteArrowStream(w goio.Writer, t *Table) error {
+	if len(t.Names) != len(t.Columns) {
+		return fmt.Errorf("table has %d names for %d columns", len(t.Names), len(t.Columns))
+	}
+	rows := t.Len()
+	fields := make(fbVector, len(t.Columns))
+	var nodes, buffers []byte
+	var body [][]byte
+	offset := 0
+	addBuffer := func(b []byte) {
+		buffers = binary.LittleEndian.AppendUint64(buffers, uint64(offset))
+		buffers = binary.LittleEndian.AppendUint64(buffers, uint64(len(b)))
+		body = append(body, b)
+		offset += (len(b) + 7) / 8 * 8
+	}
+	addNode := func(length, nulls int) {
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(length))
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(nulls))
+	}
+	
+	for i, col := range t.Columns {
+		shape := col.Shape()
+		if len(shape) != 1 && len(shape) != 2 || shape[0] != rows {
+			return fmt.Errorf("column %q has shape %v; expected (%d) or (%d, k)", t.Names[i], shape, rows, rows)
+		}
+		typeID, typ, err := arrowType(col.DType())
+		if err != nil {
+			return fmt.Errorf("column %q: %w", t.Names[i], err)
+		}
+		field := fbTable{t.Names[i], fbBool(true), fbScalar{1, typeID}, typ, nil, fbVector{}}
+		
+		// Validity bitmap from the missing mask, omitted without nulls
+		var validity []byte
+		nulls := 0
+		if i < len(t.Missing) && t.Missing[i] != nil {
+			missing := t.Missing[i].ToSliceFloat64()
+			bits := make([]byte, (rows+7)/8)
+			for r, m := range missing {
+				if m != 0 {
+					nulls++
+				} else {
+					bits[r/8] |= 1 << (r % 8)
+				}
+			}
+			if nulls > 0 {
+				validity = bits
+			}
+		}
+		addNode(rows, nulls)
+		addBuffer(validity)
+		
+		values := col.Data()
+		if col.DType() == tensor.Bool {
+			values = packBits(values)
+		}
+		if len(shape) == 2 {
+			k := shape[1]
+			field = fbTable{t.Names[i], fbBool(true), fbScalar{1, arrowFixedSizeList}, fbTable{fbInt32(int32(k))}, nil,
+				fbVector{fbTable{"item", fbBool(false), fbScalar{1, typeID}, typ, nil, fbVector{}}}}
+			addNode(rows*k, 0)
+			addBuffer(nil)
+		}
+		addBuffer(values)
+		fields[i] = field
+	}
+	
+	if err := writeArrowMessage(w, arrowSchema, fbTable{nil, fields}, nil); err != nil {
+		return err
+	}
+	batch := fbTable{fbInt64(int64(rows)), fbStructs{16, nodes}, fbStructs{16, buffers}}
+	if err := writeArrowMessage(w, arrowRecordBatch, batch, body); err != nil {
+		return err
+	}
+	_, err := w.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0})
+	return err
+}
+
+// ReadArrowStream reads an Arrow IPC stream of numeric and boolean
+// columns, concatenating its record batches into one table. Fixed-size
+// list columns become 2D columns and nulls are flagged in Table.Missing.
+func ReadArrowStream(r goio.Reader) (*Table, error) {
+	msg, _, err := readArrowMessage(r)
+	if err != nil {
+		return nil, err
+	}
+	if msg.buf == nil || msg.scalar(1, 1, 0) != arrowSchema {
+		return nil, fmt.Errorf("Arrow stream does not start with a schema")
+	}
+	schema, ok := msg.table(2)
+	if !ok {
+		return nil, fmt.Errorf("Arrow schema message has no header")
+	}
+	if schema.scalar(0, 2, 0) != 0 {
+		return nil, fmt.Errorf("big-endian Arrow streams are not supported")
+	}
+	var fields []arrowField
+	for _, f := range schema.tables(1) {
+		field, err := parseArrowField(f)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	
+	columns := make([][]*tensor.NDArray, len(fields))
+	masks := make([][]*tensor.NDArray, len(fields))
+	for {
+		msg, body, err := readArrowMessage(r)
+		if err == goio.EOF || err == nil && msg.buf == nil {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if msg.scalar(1, 1, 0) != arrowRecordBatch {
+			return nil, fmt.Errorf("unsupported Arrow message type %d", msg.scalar(1, 1, 0))
+		}
+		batch, ok := msg.table(2)
+		if !ok {
+			return nil, fmt.Errorf("Arrow record batch message has no header")
+		}
+		if batch.field(3) >= 0 {
+			return nil, fmt.Errorf("compressed Arrow record batches are not supported")
+		}
+		cols, missing, err := readArrowBatch(batch, body, fields)
+		if err != nil {
+			return nil, err
+		}
+		for i := range fields {
+			columns[i] = append(columns[i], cols[i])
+			masks[i] = append(masks[i], missing[i])
+		}
+	}
+	
+	t := &Table{}
+	for i, f := range fields {
+		t.Names = append(t.Names, f.name)
+		if len(columns[i]) == 0 {
+			shape := []int{0}
+			if f.listSize > 0 {
+				shape = append(shape, f.listSize)
+			}
+			t.Columns = append(t.Columns, tensor.Zeros(shape, f.dtype))
+			t.Missing = append(t.Missing, tensor.Zeros([]int{0}, tensor.Bool))
+			continue
+		}
+		t.Columns = append(t.Columns, tensor.Concatenate(columns[i], 0))
+		t.Missing = append(t.Missing, tensor.Concatenate(masks[i], 0))
+	}
+	return t, nil
+}
+
+// readArrowBatch decodes the columns of a record batch from its body
+func readArrowBatch(batch fbTab, body []byte, fields []arrowField) ([]*tensor.NDArray, []*tensor.NDArray, error) {
+	rows := int(batch.scalar(0, 8, 0))
+	nodeStart, nodeCount := batch.vector(1, 16)
+	bufStart, bufCount := batch.vector(2, 16)
+	node, buffer := 0, 0
+	nextNode := func() (int, int, error) {
+		if node >= nodeCount {
+			return 0, 0, fmt.Errorf("Arrow record batch has too few field nodes")
+		}
+		at := nodeStart + 16*node
+		node++
+		return int(binary.LittleEndian.Uint64(batch.buf[at:])), int(binary.LittleEndian.Uint64(batch.buf[at+8:])), nil
+	}
+	nextBuffer := func() ([]byte, error) {
+		if buffer >= bufCount {
+			return nil, fmt.Errorf("Arrow record batch has too few buffers")
+		}
+		at := bufStart + 16*buffer
+		buffer++
+		offset := int(binary.LittleEndian.Uint64(batch.buf[at:]))
+		length := int(binary.LittleEndian.Uint64(batch.buf[at+8:]))
+		if offset < 0 || length < 0 || offset+length > len(body) {
+			return nil, fmt.Errorf("Arrow buffer is out of range")
+		}
+		return body[offset : offset+length], nil
+	}
+	
+	var cols, masks []*tensor.NDArray
+	for _, f := range fields {
+		length, nulls, err := nextNode()
+		if err != nil {
+			return nil, nil, err
+		}
+		if length != rows {
+			return nil, nil, fmt.Errorf("Arrow column %q has %d rows, expected %d", f.name, length, rows)
+		}
+		validity, err := nextBuffer()
+		if err != nil {
+			return nil, nil, err
+		}
+		mask := tensor.Zeros([]int{rows}, tensor.Bool)
+		if nulls > 0 && len(validity) > 0 {
+			if len(validity) < (rows+7)/8 {
+				return nil, nil, fmt.Errorf("Arrow validity bitmap of %q is too short", f.name)
+			}
+			for r := 0; r < rows; r++ {
+				if validity[r/8]&(1<<(r%8)) == 0 {
+					mask.SetFloat64(1, r)
+				}
+			}
+		}
+		
+		shape := []int{rows}
+		count := rows
+		if f.listSize > 0 {
+			if _, _, err := nextNode(); err != nil {
+				return nil, nil, err
+			}
+			if _, err := nextBuffer(); err != nil {
+				return nil, nil, err
+			}
+			shape = append(shape, f.listSize)
+			count *= f.listSize
+		}
+		values, err := nextBuffer()
+		if err != nil {
+			return nil, nil, err
+		}
+		var data []byte
+		if f.dtype == tensor.Bool {
+			if len(values) < (count+7)/8 {
+				return nil, nil, fmt.Errorf("Arrow column %q is too short", f.name)
+			}
+			data = unpackBits(values, count)
+		} else {
+			if len(values) < count*f.dtype.ItemSize() {
+				return nil, nil, fmt.Errorf("Arrow column %q is too short", f.name)
+			}
+			data = values[:count*f.dtype.ItemSize()]
+		}
+		cols = append(cols, tensor.FromBytes(data, f.dtype, shape...))
+		masks = append(masks, mask)
+	}
+	return cols, masks, nil
+}
+
+// parseArrowField decodes a schema field into a column description
+func parseArrowField(f fbTab) (arrowField, error) {
+	field := arrowField{name: f.str(0)}
+	if f.ref(4) >= 0 {
+		return field, fmt.Errorf("dictionary-encoded Arrow column %q is not supported", field.name)
+	}
+	typeID := byte(f.scalar(2, 1, 0))
+	typ, ok := f.table(3)
+	if !ok {
+		return field, fmt.Errorf("Arrow column %q has no type", field.name)
+	}
+	if typeID == arrowFixedSizeList {
+		field.listSize = int(int32(typ.scalar(0, 4, 0)))
+		children := f.tables(5)
+		if len(children) != 1 || field.listSize <= 0 {
+			return field, fmt.Errorf("invalid Arrow fixed-size list column %q", field.name)
+		}
+		f = children[0]
+		typeID = byte(f.scalar(2, 1, 0))
+		if typ, ok = f.table(3); !ok {
+			return field, fmt.Errorf("Arrow column %q has no item type", field.name)
+		}
+	}
+	dtype, err := arrowDType(typeID, typ)
+	if err != nil {
+		return field, fmt.Errorf("Arrow column %q: %w", field.name, err)
+	}
+	field.dtype = dtype
+	return field, nil
+}
+
+// arrowType returns the Type union member describing dtype
+func arrowType(dtype tensor.DType) (uint64, fbTable, error) {
+	switch {
+	case dtype == tensor.Bool:
+		return arrowBool, fbTable{}, nil
+	case dtype == tensor.Float32:
+		return arrowFloatingPoint, fbTable{fbInt16(1)}, nil
+	case dtype == tensor.Float64:
+		return arrowFloatingPoint, fbTable{fbInt16(2)}, nil
+	case dtype.IsInt():
+		signed := dtype <= tensor.Int64
+		return arrowInt, fbTable{fbInt32(int32(8 * dtype.ItemSize())), fbBool(signed)}, nil
+	}
+	return 0, nil, fmt.Errorf("dtype %s has no Arrow equivalent", dtype)
+}
+
+// arrowDType maps an Arrow Type union member to a dtype
+func arrowDType(typeID byte, typ fbTab) (tensor.DType, error) {
+	switch typeID {
+	case arrowBool:
+		return tensor.Bool, nil
+	case arrowFloatingPoint:
+		switch typ.scalar(0, 2, 0) {
+		case 1:
+			return tensor.Float32, nil
+		case 2:
+			return tensor.Float64, nil
+		}
+		return 0, fmt.Errorf("half-precision Arrow floats are not supported")
+	case arrowInt:
+		signed := typ.scalar(1, 1, 0) != 0
+		types := map[uint64][2]tensor.DType{
+			8:  {tensor.Uint8, tensor.Int8},
+			16: {tensor.Uint16, tensor.Int16},
+			32: {tensor.Uint32, tensor.Int32},
+			64: {tensor.Uint64, tensor.Int64},
+		}
+		pair, ok := types[typ.scalar(0, 4, 0)]
+		if !ok {
+			return 0, fmt.Errorf("unsupported Arrow integer width")
+		}
+		if signed {
+			return pair[1], nil
+		}
+		return pair[0], nil
+	}
+	return 0, fmt.Errorf("unsupported Arrow type %d", typeID)
+}
+
+// writeArrowMessage writes an encapsulated IPC message: the continuation
+// marker, the metadata length, the Message flatbuffer padded to 8 bytes
+// and the body buffers, each padded to 8 bytes
+func writeArrowMessage(w goio.Writer, headerType uint64, header fbTable, buffers [][]byte) error {
+	bodyLength := 0
+	for _, b := range buffers {
+		bodyLength += (len(b) + 7) / 8 * 8
+	}
+	meta := fbEncode(fbTable{fbInt16(arrowVersion), fbScalar{1, headerType}, header, fbInt64(int64(bodyLength))})
+	for len(meta)%8 != 0 {
+		meta = append(meta, 0)
+	}
+	
+	out := []byte{0xFF, 0xFF, 0xFF, 0xFF}
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(meta)))
+	out = append(out, meta...)
+	for _, b := range buffers {
+		out = append(out, b...)
+		out = append(out, make([]byte, (len(b)+7)/8*8-len(b))...)
+	}
+	_, err := w.Write(out)
+	return err
+}
+
+// readArrowMessage reads an encapsulated IPC message and its body. The
+// end-of-stream marker yields a zero message; streams written before the
+// continuation marker was introduced are also accepted.
+func readArrowMessage(r goio.Reader) (fbTab, []byte, error) {
+	var prefix [4]byte
+	if _, err := goio.ReadFull(r, prefix[:]); err != nil {
+		return fbTab{}, nil, err
+	}
+	size := binary.LittleEndian.Uint32(prefix[:])
+	if size == 0xFFFFFFFF {
+		if _, err := goio.ReadFull(r, prefix[:]); err != nil {
+			return fbTab{}, nil, fmt.Errorf("reading Arrow message length: %w", err)
+		}
+		size = binary.LittleEndian.Uint32(prefix[:])
+	}
+	if size == 0 {
+		return fbTab{}, nil, nil
+	}
+	meta := make([]byte, size)
+	if _, err := goio.ReadFull(r, meta); err != nil {
+		return fbTab{}, nil, fmt.Errorf("reading Arrow message: %w", err)
+	}
+	msg, err := fbRoot(meta)
+	if err != nil {
+		return fbTab{}, nil, err
+	}
+	if v := msg.scalar(0, 2, 0); v < 3 {
+		return fbTab{}, nil, fmt.Errorf("unsupported Arrow metadata version V%d", v+1)
+	}
+	bodyLength := int64(msg.scalar(3, 8, 0))
+	if bodyLength < 0 || bodyLength > 1<<40 {
+		return fbTab{}, nil, fmt.Errorf("invalid Arrow body length %d", bodyLength)
+	}
+	body := make([]byte, bodyLength)
+	if _, err := goio.ReadFull(r, body); err != nil {
+		return fbTab{}, nil, fmt.Errorf("reading Arrow message body: %w", err)
+	}
+	return msg, body, nil
+}
+
+// packBits packs one byte per boolean into an LSB-first bitmap
+func packBits(values []byte) []byte {
+	bits := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v != 0 {
+			bits[i/8] |= 1 << (i % 8)
+		}
+	}
+	return bits
+}
+
+// unpackBits expands the first n bits of an LSB-first bitmap
+func unpackBits(bits []byte, n int) []byte {
+	values := make([]byte, n)
+	for i := range values {
+		values[i] = bits[i/8] >> (i % 8) & 1
+	}
+	return values
+}
@@ -0,0 +1,281 @@
+package io
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// A minimal FlatBuffers encoder and decoder, enough for the Arrow IPC
+// metadata. Objects are laid out front to back: each table is preceded by
+// its vtable and followed by the objects it references, so every offset
+// points forward as the format requires.
+
+// fbTable is a table under construction; fields are indexed by their id
+// in the schema and nil fields are omitted
+type fbTable []any
+
+// fbScalar is a scalar field of 1, 2, 4 or 8 bytes
+type fbScalar struct {
+	size int
+	bits uint64
+}
+
+// fbStruct is a struct stored inline, aligned to 8 bytes
+type fbStruct []byte
+
+// fbVector is a vector of tables or strings
+type fbVector []any
+
+// fbStructs is a vector of structs or scalars of the given element width
+type fbStructs struct {
+	width int
+	data  []byte
+}
+
+func fbInt8(v int8) fbScalar   { return fbScalar{1, uint64(uint8(v))} }
+func fbInt16(v int16) fbScalar { return fbScalar{2, uint64(uint16(v))} }
+func fbInt32(v int32) fbScalar { return fbScalar{4, uint64(uint32(v))} }
+func fbInt64(v int64) fbScalar { return fbScalar{8, uint64(v)} }
+
+func fbBool(v bool) fbScalar {
+	if v {
+		return fbScalar{1, 1}
+	}
+	return fbScalar{1, 0}
+}
+
+// fbBuilder accumulates the encoded buffer
+type fbBuilder struct {
+	buf []byte
+}
+
+// fbEncode serializes the root table
+func fbEncode(root fbTable) []byte {
+	b := &fbBuilder{buf: make([]byte, 4)}
+	pos := b.object(root)
+	binary.LittleEndian.PutUint32(b.buf, uint32(pos))
+	return b.buf
+}
+
+// pad aligns the buffer so that the next write lands at n mod align
+func (b *fbBuilder) pad(align, n int) {
+	for len(b.buf)%align != n {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+// object writes a table, string or vector and returns its position
+func (b *fbBuilder) object(obj any) int {
+	switch o := obj.(type) {
+	case string:
+		b.pad(4, 0)
+		pos := len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(o)))
+		b.buf = append(append(b.buf, o...), 0)
+		return pos
+		
+	case fbStructs:
+		b.pad(8, 4)
+		pos := len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(o.data)/o.width))
+		b.buf = append(b.buf, o.data...)
+		return pos
+		
+	case fbVector:
+		b.pad(4, 0)
+		pos := len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(o)))
+		slots := len(b.buf)
+		b.buf = append(b.buf, make([]byte, 4*len(o))...)
+		for i, item := range o {
+			b.patch(slots+4*i, b.object(item))
+		}
+		return pos
+		
+	case fbTable:
+		return b.table(o)
+	}
+	panic(fmt.Sprintf("unsupported flatbuffer object %T", obj))
+}
+
+// patch stores the offset from slot to target at slot
+func (b *fbBuilder) patch(slot, target int) {
+	binary.LittleEndian.PutUint32(b.buf[slot:], uint32(target-slot))
+}
+
+// table writes the vtable and body of t followed by the objects its
+// fields reference
+func (b *fbBuilder) table(t fbTable) int {
+	// Lay out the body: the vtable offset, then each field at its natural
+	// alignment relative to an 8-byte aligned table start
+	offsets := make([]int, len(t))
+	size := 4
+	for i, f := range t {
+		var width, align int
+		switch v := f.(type) {
+		case nil:
+			continue
+		case fbScalar:
+			width, align = v.size, v.size
+		case fbStruct:
+			width, align = len(v), 8
+		default:
+			width, align = 4, 4
+		}
+		size = (size + align - 1) / align * align
+		offsets[i] = size
+		size += width
+	}
+	size = (size + 7) / 8 * 8
+	
+	b.pad(2, 0)
+	vtable := len(b.buf)
+	b.buf = binary.LittleEndian.AppendUint16(b.buf, uint16(4+2*len(t)))
+	b.buf = binary.LittleEndian.AppendUint16(b.buf, uint16(size))
+	for _, off := range offsets {
+		b.buf = binary.LittleEndian.AppendUint16(b.buf, uint16(off))
+	}
+	b.pad(8, 0)
+	pos := len(b.buf)
+	b.buf = append(b.buf, make([]byte, size)...)
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(int32(pos-vtable)))
+	
+	for i, f := range t {
+		at := pos + offsets[i]
+		switch v := f.(type) {
+		case nil:
+		case fbScalar:
+			for k := 0; k < v.size; k++ {
+				b.buf[at+k] = byte(v.bits >> (8 * k))
+			}
+		case fbStruct:
+			copy(b.buf[at:], v)
+		}
+	}
+	for i, f := range t {
+		switch f.(type) {
+		case nil, fbScalar, fbStruct:
+		default:
+			b.patch(pos+offsets[i], b.object(f))
+		}
+	}
+	return pos
+}
+
+// fbTab reads a table from an encoded buffer
+type fbTab struct {
+	buf []byte
+	pos int
+}
+
+// fbRoot returns the root table of buf
+func fbRoot(buf []byte) (fbTab, error) {
+	if len(buf) < 4 {
+		return fbTab{}, fmt.Errorf("truncated flatbuffer")
+	}
+	t := fbTab{buf, int(binary.LittleEndian.Uint32(buf))}
+	if !t.valid() {
+		return fbTab{}, fmt.Errorf("invalid flatbuffer")
+	}
+	return t, nil
+}
+
+// valid checks that the table and its vtable lie inside the buffer
+func (t fbTab) valid() bool {
+	if t.pos < 0 || t.pos+4 > len(t.buf) {
+		return false
+	}
+	vt := t.pos - int(int32(binary.LittleEndian.Uint32(t.buf[t.pos:])))
+	return vt >= 0 && vt+4 <= len(t.buf) && vt+int(binary.LittleEndian.Uint16(t.buf[vt:])) <= len(t.buf)
+}
+
+// field returns the position of field id, or -1 if it is absent
+func (t fbTab) field(id int) int {
+	vt := t.pos - int(int32(binary.LittleEndian.Uint32(t.buf[t.pos:])))
+	vtsize := int(binary.LittleEndian.Uint16(t.buf[vt:]))
+	if 4+2*id+2 > vtsize {
+		return -1
+	}
+	off := int(binary.LittleEndian.Uint16(t.buf[vt+4+2*id:]))
+	if off == 0 {
+		return -1
+	}
+	return t.pos + off
+}
+
+// scalar reads an unsigned field of the given width, or def if absent
+func (t fbTab) scalar(id, width int, def uint64) uint64 {
+	at := t.field(id)
+	if at < 0 || at+width > len(t.buf) {
+		return def
+	}
+	var v uint64
+	for k := width - 1; k >= 0; k-- {
+		v = v<<8 | uint64(t.buf[at+k])
+	}
+	return v
+}
+
+// ref follows the offset stored in field id, returning -1 if absent
+func (t fbTab) ref(id int) int {
+	at := t.field(id)
+	if at < 0 || at+4 > len(t.buf) {
+		return -1
+	}
+	target := at + int(binary.LittleEndian.Uint32(t.buf[at:]))
+	if target >= len(t.buf) {
+		return -1
+	}
+	return target
+}
+
+// table returns the table referenced by field id
+func (t fbTab) table(id int) (fbTab, bool) {
+	at := t.ref(id)
+	if at < 0 {
+		return fbTab{}, false
+	}
+	sub := fbTab{t.buf, at}
+	return sub, sub.valid()
+}
+
+// str returns the string in field id, or "" if absent
+func (t fbTab) str(id int) string {
+	at := t.ref(id)
+	if at < 0 || at+4 > len(t.buf) {
+		return ""
+	}
+	n := int(binary.LittleEndian.Uint32(t.buf[at:]))
+	if at+4+n > len(t.buf) {
+		return ""
+	}
+	return string(t.buf[at+4 : at+4+n])
+}
+
+// vector returns the position of the first element and the length of the
+// vector in field id, checking that elements of the given width fit
+func (t fbTab) vector(id, width int) (int, int) {
+	at := t.ref(id)
+	if at < 0 || at+4 > len(t.buf) {
+		return 0, 0
+	}
+	n := int(binary.LittleEndian.Uint32(t.buf[at:]))
+	if at+4+n*width > len(t.buf) {
+		return 0, 0
+	}
+	return at + 4, n
+}
+
+// tables returns the tables of a vector of tables in field id
+func (t fbTab) tables(id int) []fbTab {
+	start, n := t.vector(id, 4)
+	out := make([]fbTab, 0, n)
+	for i := 0; i < n; i++ {
+		at := start + 4*i
+		sub := fbTab{t.buf, at + int(binary.LittleEndian.Uint32(t.buf[at:]))}
+		if sub.valid() {
+			out = append(out, sub)
+		}
+	}
+	return out
+}
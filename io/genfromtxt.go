@@ -0,0 +1,224 @@
+package io
+
+import (
+	"bufio"
+	"fmt"
+	goio "io"
+	"math"
+	"os"
+	"strings"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// GenFromTxtOptions controls how GenFromTxt parses delimited text,
+// mirroring the arguments of numpy.genfromtxt
+type GenFromTxtOptions struct {
+	// Delimiter separates the values of a row. The zero value splits on
+	// runs of whitespace, in which case empty fields cannot be detected
+	// and missing values must be written with a MissingValues marker.
+	Delimiter string
+	
+	// Comments starts a comment that runs to the end of the line. The zero
+	// value is "#".
+	Comments string
+	
+	// SkipHeader is the number of leading lines to skip
+	SkipHeader int
+	
+	// Names reads the column names from the first line after SkipHeader,
+	// even if it is commented out. Otherwise columns are named "f0", "f1",
+	// and so on.
+	Names bool
+	
+	// UseCols selects and orders the columns to read; negative indices
+	// count from the end of the row. Nil reads every column.
+	UseCols []int
+	
+	// DTypes sets the dtype of columns by name. Columns not listed are
+	// Float64.
+	DTypes map[string]tensor.DType
+	
+	// MissingValues lists markers such as "NA" that denote a missing
+	// value, in addition to an empty field
+	MissingValues []string
+	
+	// FillValues sets the value used for missing or invalid entries by
+	// column name. The default is NaN for floating-point and complex
+	// columns and -1 for integer columns.
+	FillValues map[string]float64
+	
+	// SkipInvalid drops rows with the wrong number of columns instead of
+	// returning an error
+	SkipInvalid bool
+}
+
+// Table holds the named, individually typed columns read by GenFromTxt,
+// the counterpart of a NumPy structured array
+type Table struct {
+	// Names are the column names in order
+	Names []string
+	
+	// Columns are arrays of equal length along the first axis, one per
+	// name. They are 1D except for Arrow fixed-size list columns, which
+	// are 2D.
+	Columns []*tensor.NDArray
+	
+	// Missing are 1D Bool arrays marking the entries that were missing or
+	// invalid and hold a fill value
+	Missing []*tensor.NDArray
+}
+
+// Len returns the number of rows in the table
+func (t *Table) Len() int {
+	if len(t.Columns) == 0 {
+		return 0
+	}
+	return t.Columns[0].Size()
+}
+
+// Column returns the column with the given name
+func (t *Table) Column(name string) *tensor.NDArray {
+	for i, n := range t.Names {
+		if n == name {
+			return t.Columns[i]
+		}
+	}
+	panic(fmt.Sprintf("no column named %q", name))
+}
+
+// Matrix returns the table as a 2D Float64 array with one column per name.
+// Every column must be 1D.
+func (t *Table) Matrix() *tensor.NDArray {
+	rows := t.Len()
+	out := tensor.Zeros([]int{rows, len(t.Columns)}, tensor.Float64)
+	for j, col := range t.Columns {
+		if col.Ndim() != 1 {
+			panic(fmt.Sprintf("column %q is not 1D", t.Names[j]))
+		}
+		for i, v := range col.ToSliceFloat64() {
+			out.SetFloat64(v, i, j)
+		}
+	}
+	return out
+}
+
+// GenFromTxt reads a table of numbers that may contain missing or invalid
+// entries from the file at path
+func GenFromTxt(path string, opts GenFromTxtOptions) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadGenFromTxt(f, opts)
+}
+
+// ReadGenFromTxt is GenFromTxt reading from r. Missing entries and entries
+// that do not parse as their column's dtype are replaced by the column's
+// fill value and flagged in Table.Missing.
+func ReadGenFromTxt(r goio.Reader, opts GenFromTxtOptions) (*Table, error) {
+	comments := opts.Comments
+	if comments == "" {
+		comments = "#"
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<30)
+	
+	var header []string
+	var rows [][]string
+	width := -1
+	for n := 1; scanner.Scan(); n++ {
+		if n <= opts.SkipHeader {
+			continue
+		}
+		line := scanner.Text()
+		if opts.Names && header == nil {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" {
+				continue
+			}
+			header = splitTxtLine(strings.TrimSpace(strings.TrimPrefix(trimmed, comments)), opts.Delimiter)
+			width = len(header)
+			continue
+		}
+		if i := strings.Index(line, comments); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := splitTxtLine(line, opts.Delimiter)
+		if width < 0 {
+			width = len(fields)
+		}
+		if len(fields) != width {
+			if opts.SkipInvalid {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: expected %d columns, got %d", n, width, len(fields))
+		}
+		rows = append(rows, fields)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if width < 0 {
+		width = 0
+	}
+	
+	cols := opts.UseCols
+	if cols == nil {
+		cols = make([]int, width)
+		for j := range cols {
+			cols[j] = j
+		}
+	}
+	
+	missing := map[string]bool{"": true}
+	for _, m := range opts.MissingValues {
+		missing[m] = true
+	}
+	
+	t := &Table{}
+	for j, c := range cols {
+		if c < 0 {
+			c += width
+		}
+		if c < 0 || c >= width {
+			return nil, fmt.Errorf("column %d is out of range for %d columns", cols[j], width)
+		}
+		name := fmt.Sprintf("f%d", j)
+		if header != nil {
+			name = header[c]
+		}
+		dtype, ok := opts.DTypes[name]
+		if !ok {
+			dtype = tensor.Float64
+		}
+		fill, ok := opts.FillValues[name]
+		if !ok {
+			fill = math.NaN()
+			if dtype.IsInt() {
+				fill = -1
+			}
+		}
+		
+		col := tensor.Zeros([]int{len(rows)}, dtype)
+		mask := tensor.Zeros([]int{len(rows)}, tensor.Bool)
+		for i, row := range rows {
+			if missing[row[c]] || setTxtValue(col, row[c], i) != nil {
+				if dtype.IsComplex() {
+					col.SetComplex128(complex(fill, 0), i)
+				} else {
+					col.SetFloat64(fill, i)
+				}
+				mask.SetFloat64(1, i)
+			}
+		}
+		t.Names = append(t.Names, name)
+		t.Columns = append(t.Columns, col)
+		t.Missing = append(t.Missing, mask)
+	}
+	return t, nil
+}
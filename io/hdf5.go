@@ -0,0 +1,385 @@
+package io
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// The HDF5 support reads and writes the subset of the format used by
+// h5py and libhdf5 with default settings: version 0 to 3 superblocks,
+// symbol table and compact link groups, and contiguous, compact or chunked
+// datasets of integer, floating-point, boolean (h5py's enum) and complex
+// (h5py's {r, i} compound) elements. Chunks indexed by version 1 B-trees
+// may be deflate and shuffle filtered. Files are written with a version 0
+// superblock, which every HDF5 release can read.
+
+// h5Signature starts every HDF5 superblock
+const h5Signature = "\x89HDF\r\n\x1a\n"
+
+// h5Undefined is the address of storage that was never allocated
+const h5Undefined = ^uint64(0)
+
+// Object header message types
+const (
+	h5MsgNil         = 0x00
+	h5MsgDataspace   = 0x01
+	h5MsgLinkInfo    = 0x02
+	h5MsgDatatype    = 0x03
+	h5MsgFillValue   = 0x05
+	h5MsgLink        = 0x06
+	h5MsgLayout      = 0x08
+	h5MsgFilters     = 0x0B
+	h5MsgContinue    = 0x10
+	h5MsgSymbolTable = 0x11
+)
+
+// Filter identifiers
+const (
+	h5FilterDeflate    = 1
+	h5FilterShuffle    = 2
+	h5FilterFletcher32 = 3
+)
+
+// H5Options controls how datasets are stored by SaveH5With and WriteH5.
+// The zero value stores every dataset contiguously and uncompressed.
+type H5Options struct {
+	// Chunks is the chunk shape of every dataset. It must match the rank
+	// of each array; dimensions larger than the array are clamped. Nil
+	// picks a chunk shape automatically when chunking is needed.
+	Chunks []int
+	
+	// Compression is the gzip level from 1 to 9 applied to each chunk, or
+	// 0 for none. Compression implies chunked storage.
+	Compression int
+	
+	// Shuffle applies the byte shuffle filter before compression, which
+	// usually improves the compression ratio of numeric data
+	Shuffle bool
+}
+
+// chunked reports whether datasets are stored in chunks
+func (opts H5Options) chunked() bool {
+	return opts.Chunks != nil || opts.Compression > 0 || opts.Shuffle
+}
+
+// SaveH5 writes arrays as datasets of a new HDF5 file at path. Keys are
+// dataset paths; slashes create intermediate groups, so "train/x" is the
+// dataset x of the group train.
+func SaveH5(path string, datasets map[string]*tensor.NDArray) error {
+	return SaveH5With(path, datasets, H5Options{})
+}
+
+// SaveH5With is SaveH5 with control over chunking and compression
+func SaveH5With(path string, datasets map[string]*tensor.NDArray, opts H5Options) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteH5(f, datasets, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadH5 reads every dataset of the HDF5 file at path, keyed by its path
+// without the leading slash
+func LoadH5(path string) (map[string]*tensor.NDArray, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return ReadH5(f, info.Size())
+}
+
+// LoadH5Dataset reads the single dataset at the given path, such as
+// "train/x", from the HDF5 file at path
+func LoadH5Dataset(path, name string) (*tensor.NDArray, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	r, err := newH5Reader(f, info.Size())
+	if err != nil {
+		return nil, err
+	}
+	return r.dataset(name)
+}
+
+// h5Datatype describes how elements are stored on disk
+type h5Datatype struct {
+	dtype     tensor.DType
+	size      int
+	bigEndian bool
+}
+
+// encodeH5Datatype returns the datatype message for dtype, using the
+// layouts h5py writes for booleans and complex numbers
+func encodeH5Datatype(dtype tensor.DType) ([]byte, error) {
+	switch {
+	case dtype == tensor.Bool:
+		base, _ := encodeH5Datatype(tensor.Int8)
+		msg := h5DatatypeHeader(8, 2, 1)
+		msg = append(msg, base...)
+		msg = append(msg, h5PadName("FALSE")...)
+		msg = append(msg, h5PadName("TRUE")...)
+		return append(msg, 0, 1), nil
+	case dtype.IsComplex():
+		half := tensor.Float64
+		if dtype == tensor.Complex64 {
+			half = tensor.Float32
+		}
+		member, _ := encodeH5Datatype(half)
+		size := dtype.ItemSize()
+		msg := h5DatatypeHeader(6, 2, size)
+		for i, name := range []string{"r", "i"} {
+			msg = append(msg, h5PadName(name)...)
+			msg = binary.LittleEndian.AppendUint32(msg, uint32(i*size/2))
+			// Dimensionality, permutation and sizes of array members
+			msg = append(msg, make([]byte, 28)...)
+			msg = append(msg, member...)
+		}
+		return msg, nil
+	case dtype.IsInt():
+		size := dtype.ItemSize()
+		flags := 0
+		if dtype <= tensor.Int64 {
+			flags = 0x08
+		}
+		msg := h5DatatypeHeader(0, flags, size)
+		msg = binary.LittleEndian.AppendUint16(msg, 0)
+		return binary.LittleEndian.AppendUint16(msg, uint16(size*8)), nil
+	case dtype == tensor.Float32:
+		msg := h5DatatypeHeader(1, 0x20|31<<8, 4)
+		msg = binary.LittleEndian.AppendUint16(msg, 0)
+		msg = binary.LittleEndian.AppendUint16(msg, 32)
+		msg = append(msg, 23, 8, 0, 23)
+		return binary.LittleEndian.AppendUint32(msg, 127), nil
+	case dtype == tensor.Float64:
+		msg := h5DatatypeHeader(1, 0x20|63<<8, 8)
+		msg = binary.LittleEndian.AppendUint16(msg, 0)
+		msg = binary.LittleEndian.AppendUint16(msg, 64)
+		msg = append(msg, 52, 11, 0, 52)
+		return binary.LittleEndian.AppendUint32(msg, 1023), nil
+	}
+	return nil, fmt.Errorf("dtype %s cannot be stored in HDF5", dtype)
+}
+
+// h5DatatypeHeader starts a version 1 datatype message
+func h5DatatypeHeader(class, flags, size int) []byte {
+	msg := []byte{byte(class | 1<<4), byte(flags), byte(flags >> 8), byte(flags >> 16)}
+	return binary.LittleEndian.AppendUint32(msg, uint32(size))
+}
+
+// h5PadName null-terminates name and pads it to a multiple of 8 bytes
+func h5PadName(name string) []byte {
+	b := make([]byte, (len(name)+8)/8*8)
+	copy(b, name)
+	return b
+}
+
+// decodeH5Datatype parses a datatype message, returning the element
+// layout and the number of bytes consumed
+func decodeH5Datatype(b []byte) (h5Datatype, int, error) {
+	if len(b) < 8 {
+		return h5Datatype{}, 0, fmt.Errorf("truncated HDF5 datatype")
+	}
+	class, version := int(b[0]&0x0F), int(b[0]>>4)
+	flags := int(b[1]) | int(b[2])<<8 | int(b[3])<<16
+	size := int(binary.LittleEndian.Uint32(b[4:]))
+	dt := h5Datatype{size: size, bigEndian: flags&1 != 0}
+	
+	switch class {
+	case 0:
+		signed := flags&0x08 != 0
+		types := map[int][2]tensor.DType{
+			1: {tensor.Uint8, tensor.Int8},
+			2: {tensor.Uint16, tensor.Int16},
+			4: {tensor.Uint32, tensor.Int32},
+			8: {tensor.Uint64, tensor.Int64},
+		}
+		pair, ok := types[size]
+		if !ok {
+			return dt, 0, fmt.Errorf("unsupported HDF5 integer size %d", size)
+		}
+		dt.dtype = pair[0]
+		if signed {
+			dt.dtype = pair[1]
+		}
+		return dt, 12, nil
+		
+	case 1:
+		switch size {
+		case 4:
+			dt.dtype = tensor.Float32
+		case 8:
+			dt.dtype = tensor.Float64
+		default:
+			return dt, 0, fmt.Errorf("unsupported HDF5 float size %d", size)
+		}
+		return dt, 20, nil
+		
+	case 6:
+		n := flags & 0xFFFF
+		pos := 8
+		var members []h5Datatype
+		var offsets []int
+		for i := 0; i < n; i++ {
+			end := pos
+			for end < len(b) && b[end] != 0 {
+				end++
+			}
+			if end >= len(b) {
+				return dt, 0, fmt.Errorf("truncated HDF5 compound datatype")
+			}
+			if version < 3 {
+				pos += (end - pos + 8) / 8 * 8
+			} else {
+				pos = end + 1
+			}
+			var offset int
+			switch {
+			case version < 3:
+				offset = int(binary.LittleEndian.Uint32(b[pos:]))
+				pos += 4
+				if version == 1 {
+					pos += 28
+				}
+			default:
+				width := 1
+				for size >= 1<<(8*width) {
+					width++
+				}
+				for k := 0; k < width; k++ {
+					offset |= int(b[pos+k]) << (8 * k)
+				}
+				pos += width
+			}
+			member, used, err := decodeH5Datatype(b[pos:])
+			if err != nil {
+				return dt, 0, err
+			}
+			pos += used
+			members = append(members, member)
+			offsets = append(offsets, offset)
+		}
+		if n == 2 && members[0].dtype == members[1].dtype && members[0].dtype.IsFloat() &&
+			offsets[0] == 0 && offsets[1] == members[0].size && size == 2*members[0].size &&
+			members[0].bigEndian == members[1].bigEndian {
+			dt.dtype = tensor.Complex128
+			if members[0].dtype == tensor.Float32 {
+				dt.dtype = tensor.Complex64
+			}
+			dt.bigEndian = members[0].bigEndian
+			return dt, pos, nil
+		}
+		return dt, 0, fmt.Errorf("unsupported HDF5 compound datatype")
+		
+	case 8:
+		n := flags & 0xFFFF
+		base, used, err := decodeH5Datatype(b[8:])
+		if err != nil {
+			return dt, 0, err
+		}
+		pos := 8 + used
+		var names []string
+		for i := 0; i < n; i++ {
+			end := pos
+			for end < len(b) && b[end] != 0 {
+				end++
+			}
+			if end >= len(b) {
+				return dt, 0, fmt.Errorf("truncated HDF5 enum datatype")
+			}
+			names = append(names, string(b[pos:end]))
+			if version < 3 {
+				pos += (end - pos + 8) / 8 * 8
+			} else {
+				pos = end + 1
+			}
+		}
+		pos += n * base.size
+		dt = base
+		if base.size == 1 && strings.Join(names, ",") == "FALSE,TRUE" {
+			dt.dtype = tensor.Bool
+		}
+		return dt, pos, nil
+	}
+	return dt, 0, fmt.Errorf("unsupported HDF5 datatype class %d", class)
+}
+
+// copyChunk copies the elements a chunk with the given dimensions and
+// element offset shares with an array of the given shape, from the chunk
+// into the array data or, if toChunk is set, the other way round.
+// Elements of the chunk beyond the array bounds are left untouched.
+func copyChunk(data []byte, shape []int, chunk []byte, cdims, offset []int, itemsize int, toChunk bool) {
+	rank := len(shape)
+	last := cdims[rank-1]
+	if offset[rank-1]+last > shape[rank-1] {
+		last = shape[rank-1] - offset[rank-1]
+	}
+	if last <= 0 {
+		return
+	}
+	
+	// Walk the rows of the chunk, copying the in-bounds part of each
+	index := make([]int, rank)
+	for {
+		inside := true
+		src, dst := 0, 0
+		for d := 0; d < rank; d++ {
+			if offset[d]+index[d] >= shape[d] {
+				inside = false
+				break
+			}
+			src = src*cdims[d] + index[d]
+			dst = dst*shape[d] + offset[d] + index[d]
+		}
+		if inside {
+			a := data[dst*itemsize : (dst+last)*itemsize]
+			c := chunk[src*itemsize : (src+last)*itemsize]
+			if toChunk {
+				copy(c, a)
+			} else {
+				copy(a, c)
+			}
+		}
+		
+		d := rank - 2
+		for ; d >= 0; d-- {
+			index[d]++
+			if index[d] < cdims[d] {
+				break
+			}
+			index[d] = 0
+		}
+		if d < 0 {
+			return
+		}
+	}
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,678 @@
+package io
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	goio "io"
+	"strings"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// h5Reader resolves addresses of an HDF5 file opened for reading
+type h5Reader struct {
+	r          goio.ReaderAt
+	size       int64
+	base       uint64
+	offsetSize int
+	lengthSize int
+	root       uint64
+}
+
+// h5Msg is a message read from an object header
+type h5Msg struct {
+	typ   int
+	flags byte
+	data  []byte
+}
+
+// h5FilterStage is one stage of a dataset's filter pipeline
+type h5FilterStage struct {
+	id     int
+	values []uint32
+}
+
+// ReadH5 reads every dataset of an HDF5 file of the given size from r,
+// keyed by its path without the leading slash
+func ReadH5(r goio.ReaderAt, size int64) (map[string]*tensor.NDArray, error) {
+	h, err := newH5Reader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	arrays := map[string]*tensor.NDArray{}
+	err = h.walk(h.root, "", map[uint64]bool{}, func(name string, msgs []h5Msg) error {
+		a, err := h.readDataset(msgs)
+		if err != nil {
+			return fmt.Errorf("reading %q: %w", name, err)
+		}
+		arrays[name] = a
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return arrays, nil
+}
+
+// newH5Reader locates and parses the superblock, which may follow a user
+// block of 512 bytes or a larger power of two
+func newH5Reader(r goio.ReaderAt, size int64) (*h5Reader, error) {
+	h := &h5Reader{r: r, size: size}
+	for at := int64(0); at+8 <= size; at = max(512, 2*at) {
+		sig := make([]byte, 8)
+		if _, err := r.ReadAt(sig, at); err != nil {
+			return nil, err
+		}
+		if string(sig) != h5Signature {
+			continue
+		}
+		
+		b := make([]byte, min(int64(128), size-at))
+		if _, err := r.ReadAt(b, at); err != nil && err != goio.EOF {
+			return nil, err
+		}
+		if len(b) < 16 {
+			break
+		}
+		switch version := b[8]; version {
+		case 0, 1:
+			h.offsetSize, h.lengthSize = int(b[13]), int(b[14])
+			pos := 24
+			if version == 1 {
+				pos += 4
+			}
+			if len(b) < pos+8*h.offsetSize {
+				break
+			}
+			h.base = h.uint(b[pos:], h.offsetSize)
+			// Skip the free space, end of file and driver addresses and
+			// the root entry's name offset
+			h.root = h.uint(b[pos+5*h.offsetSize:], h.offsetSize)
+		case 2, 3:
+			h.offsetSize, h.lengthSize = int(b[9]), int(b[10])
+			if len(b) < 12+4*h.offsetSize {
+				break
+			}
+			h.base = h.uint(b[12:], h.offsetSize)
+			h.root = h.uint(b[12+3*h.offsetSize:], h.offsetSize)
+		default:
+			return nil, fmt.Errorf("unsupported HDF5 superblock version %d", version)
+		}
+		if h.offsetSize == 0 || h.offsetSize > 8 || h.lengthSize == 0 || h.lengthSize > 8 {
+			return nil, fmt.Errorf("invalid HDF5 superblock")
+		}
+		return h, nil
+	}
+	return nil, fmt.Errorf("not an HDF5 file: superblock signature not found")
+}
+
+// uint decodes a little-endian unsigned integer of the given width
+func (h *h5Reader) uint(b []byte, width int) uint64 {
+	var v uint64
+	for i := width - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	if width < 8 && v == 1<<(8*width)-1 {
+		return h5Undefined
+	}
+	return v
+}
+
+// read returns n bytes at the given file address
+func (h *h5Reader) read(addr uint64, n int) ([]byte, error) {
+	if addr == h5Undefined || int64(h.base+addr)+int64(n) > h.size || n < 0 {
+		return nil, fmt.Errorf("HDF5 address %#x is out of range", addr)
+	}
+	b := make([]byte, n)
+	if _, err := h.r.ReadAt(b, int64(h.base+addr)); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// messages reads the messages of the object header at addr, following
+// continuation blocks
+func (h *h5Reader) messages(addr uint64) ([]h5Msg, error) {
+	prefix, err := h.read(addr, int(min(int64(40), h.size-int64(h.base+addr))))
+	if err != nil {
+		return nil, err
+	}
+	
+	type block struct {
+		addr uint64
+		size int
+	}
+	var blocks []block
+	var hdrSize int
+	trackOrder := false
+	v2 := bytes.HasPrefix(prefix, []byte("OHDR"))
+	if v2 {
+		if len(prefix) < 7 || prefix[4] != 2 {
+			return nil, fmt.Errorf("unsupported HDF5 object header")
+		}
+		flags := prefix[5]
+		pos := 6
+		if flags&0x20 != 0 {
+			pos += 16
+		}
+		if flags&0x10 != 0 {
+			pos += 4
+		}
+		width := 1 << (flags & 3)
+		if len(prefix) < pos+width {
+			return nil, fmt.Errorf("truncated HDF5 object header")
+		}
+		size := int(h.uint(prefix[pos:], width))
+		pos += width
+		blocks = append(blocks, block{addr + uint64(pos), size})
+		trackOrder = flags&0x04 != 0
+		hdrSize = 4
+		if trackOrder {
+			hdrSize = 6
+		}
+	} else {
+		if len(prefix) < 16 || prefix[0] != 1 {
+			return nil, fmt.Errorf("unsupported HDF5 object header version %d", prefix[0])
+		}
+		blocks = append(blocks, block{addr + 16, int(binary.LittleEndian.Uint32(prefix[8:]))})
+		hdrSize = 8
+	}
+	
+	var msgs []h5Msg
+	for i := 0; i < len(blocks); i++ {
+		if i > 64 {
+			return nil, fmt.Errorf("too many HDF5 object header continuations")
+		}
+		b, err := h.read(blocks[i].addr, blocks[i].size)
+		if err != nil {
+			return nil, err
+		}
+		if v2 && i > 0 {
+			if !bytes.HasPrefix(b, []byte("OCHK")) {
+				return nil, fmt.Errorf("bad HDF5 continuation block signature")
+			}
+			b = b[4 : len(b)-4]
+		}
+		for pos := 0; pos+hdrSize <= len(b); {
+			var typ, size int
+			var flags byte
+			if v2 {
+				typ, size, flags = int(b[pos]), int(binary.LittleEndian.Uint16(b[pos+1:])), b[pos+3]
+			} else {
+				typ, size, flags = int(binary.LittleEndian.Uint16(b[pos:])), int(binary.LittleEndian.Uint16(b[pos+2:])), b[pos+4]
+			}
+			pos += hdrSize
+			if pos+size > len(b) {
+				return nil, fmt.Errorf("truncated HDF5 object header message")
+			}
+			data := b[pos : pos+size]
+			pos += size
+			
+			switch typ {
+			case h5MsgNil:
+			case h5MsgContinue:
+				if len(data) < h.offsetSize+h.lengthSize {
+					return nil, fmt.Errorf("truncated HDF5 continuation message")
+				}
+				next := h.uint(data, h.offsetSize)
+				length := int(h.uint(data[h.offsetSize:], h.lengthSize))
+				blocks = append(blocks, block{next, length})
+			default:
+				msgs = append(msgs, h5Msg{typ, flags, data})
+			}
+		}
+	}
+	return msgs, nil
+}
+
+// h5Link is a named hard link from a group to an object header
+type h5Link struct {
+	name string
+	addr uint64
+}
+
+// links lists the members of a group; other objects have none
+func (h *h5Reader) links(msgs []h5Msg) ([]h5Link, error) {
+	var links []h5Link
+	for _, m := range msgs {
+		switch m.typ {
+		case h5MsgSymbolTable:
+			btree := h.uint(m.data, h.offsetSize)
+			heap := h.uint(m.data[h.offsetSize:], h.offsetSize)
+			names, err := h.localHeap(heap)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.symbolTable(btree, names, &links, 0); err != nil {
+				return nil, err
+			}
+			
+		case h5MsgLinkInfo:
+			pos := 2
+			if m.data[1]&1 != 0 {
+				pos += 8
+			}
+			if h.uint(m.data[pos:], h.offsetSize) != h5Undefined {
+				return nil, fmt.Errorf("HDF5 groups with dense link storage are not supported")
+			}
+			
+		case h5MsgLink:
+			d := m.data
+			flags := d[1]
+			pos := 2
+			linkType := byte(0)
+			if flags&0x08 != 0 {
+				linkType = d[pos]
+				pos++
+			}
+			if flags&0x04 != 0 {
+				pos += 8
+			}
+			if flags&0x10 != 0 {
+				pos++
+			}
+			width := 1 << (flags & 3)
+			n := int(h.uint(d[pos:], width))
+			pos += width
+			name := string(d[pos : pos+n])
+			pos += n
+			if linkType == 0 {
+				links = append(links, h5Link{name, h.uint(d[pos:], h.offsetSize)})
+			}
+		}
+	}
+	return links, nil
+}
+
+// localHeap returns the data segment of the local heap at addr
+func (h *h5Reader) localHeap(addr uint64) ([]byte, error) {
+	hdr, err := h.read(addr, 8+2*h.lengthSize+h.offsetSize)
+	if err != nil {
+		return nil, err
+	}
+	if string(hdr[:4]) != "HEAP" {
+		return nil, fmt.Errorf("bad HDF5 local heap signature")
+	}
+	size := int(h.uint(hdr[8:], h.lengthSize))
+	return h.read(h.uint(hdr[8+2*h.lengthSize:], h.offsetSize), size)
+}
+
+// symbolTable collects the entries of a group B-tree and its symbol table
+// nodes
+func (h *h5Reader) symbolTable(addr uint64, names []byte, links *[]h5Link, depth int) error {
+	if depth > 32 {
+		return fmt.Errorf("HDF5 group B-tree is too deep")
+	}
+	node, err := h.btreeNode(addr, 0, h.lengthSize)
+	if err != nil {
+		return err
+	}
+	for _, child := range node.addrs {
+		if node.level > 0 {
+			if err := h.symbolTable(child, names, links, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		entrySize := 2*h.offsetSize + 24
+		hdr, err := h.read(child, 8)
+		if err != nil {
+			return err
+		}
+		if string(hdr[:4]) != "SNOD" {
+			return fmt.Errorf("bad HDF5 symbol table node signature")
+		}
+		n := int(binary.LittleEndian.Uint16(hdr[6:]))
+		entries, err := h.read(child+8, n*entrySize)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			e := entries[i*entrySize:]
+			offset := int(h.uint(e, h.offsetSize))
+			if offset >= len(names) {
+				return fmt.Errorf("HDF5 link name is out of range")
+			}
+			end := bytes.IndexByte(names[offset:], 0)
+			if end < 0 {
+				end = len(names) - offset
+			}
+			*links = append(*links, h5Link{string(names[offset : offset+end]), h.uint(e[h.offsetSize:], h.offsetSize)})
+		}
+	}
+	return nil
+}
+
+// h5Node holds the children and keys of a version 1 B-tree node
+type h5Node struct {
+	level int
+	addrs []uint64
+	keys  [][]byte
+}
+
+// btreeNode reads the version 1 B-tree node of the given type at addr
+func (h *h5Reader) btreeNode(addr uint64, typ byte, keySize int) (h5Node, error) {
+	hdrSize := 8 + 2*h.offsetSize
+	hdr, err := h.read(addr, hdrSize)
+	if err != nil {
+		return h5Node{}, err
+	}
+	if string(hdr[:4]) != "TREE" || hdr[4] != typ {
+		return h5Node{}, fmt.Errorf("bad HDF5 B-tree node")
+	}
+	node := h5Node{level: int(hdr[5])}
+	n := int(binary.LittleEndian.Uint16(hdr[6:]))
+	b, err := h.read(addr+uint64(hdrSize), n*(keySize+h.offsetSize)+keySize)
+	if err != nil {
+		return h5Node{}, err
+	}
+	for i := 0; i < n; i++ {
+		pos := i * (keySize + h.offsetSize)
+		node.keys = append(node.keys, b[pos:pos+keySize])
+		node.addrs = append(node.addrs, h.uint(b[pos+keySize:], h.offsetSize))
+	}
+	return node, nil
+}
+
+// walk visits every dataset reachable from the group at addr, skipping
+// objects already seen through another link
+func (h *h5Reader) walk(addr uint64, prefix string, seen map[uint64]bool, visit func(string, []h5Msg) error) error {
+	seen[addr] = true
+	msgs, err := h.messages(addr)
+	if err != nil {
+		return err
+	}
+	links, err := h.links(msgs)
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if seen[link.addr] {
+			continue
+		}
+		name := prefix + link.name
+		child, err := h.messages(link.addr)
+		if err != nil {
+			return err
+		}
+		if h5IsDataset(child) {
+			seen[link.addr] = true
+			if err := visit(name, child); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := h.walk(link.addr, name+"/", seen, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// h5IsDataset reports whether an object has a storage layout
+func h5IsDataset(msgs []h5Msg) bool {
+	for _, m := range msgs {
+		if m.typ == h5MsgLayout {
+			return true
+		}
+	}
+	return false
+}
+
+// dataset follows a slash-separated path from the root group and reads
+// the dataset it names
+func (h *h5Reader) dataset(name string) (*tensor.NDArray, error) {
+	addr := h.root
+	for _, part := range strings.Split(strings.Trim(name, "/"), "/") {
+		msgs, err := h.messages(addr)
+		if err != nil {
+			return nil, err
+		}
+		links, err := h.links(msgs)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for _, link := range links {
+			if link.name == part {
+				addr, found = link.addr, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("HDF5 dataset %q not found", name)
+		}
+	}
+	msgs, err := h.messages(addr)
+	if err != nil {
+		return nil, err
+	}
+	if !h5IsDataset(msgs) {
+		return nil, fmt.Errorf("HDF5 object %q is not a dataset", name)
+	}
+	return h.readDataset(msgs)
+}
+
+// readDataset decodes the dataspace, datatype, filters and layout of a
+// dataset and reads its data
+func (h *h5Reader) readDataset(msgs []h5Msg) (*tensor.NDArray, error) {
+	var shape []int
+	var dt h5Datatype
+	var filters []h5FilterStage
+	var layout []byte
+	haveType := false
+	for _, m := range msgs {
+		if m.flags&0x02 != 0 && (m.typ == h5MsgDatatype || m.typ == h5MsgDataspace) {
+			return nil, fmt.Errorf("shared HDF5 datatypes and dataspaces are not supported")
+		}
+		switch m.typ {
+		case h5MsgDataspace:
+			s, err := h.dataspace(m.data)
+			if err != nil {
+				return nil, err
+			}
+			shape = s
+		case h5MsgDatatype:
+			t, _, err := decodeH5Datatype(m.data)
+			if err != nil {
+				return nil, err
+			}
+			dt, haveType = t, true
+		case h5MsgFilters:
+			f, err := h5ParseFilters(m.data)
+			if err != nil {
+				return nil, err
+			}
+			filters = f
+		case h5MsgLayout:
+			layout = m.data
+		}
+	}
+	if shape == nil || !haveType || layout == nil {
+		return nil, fmt.Errorf("HDF5 dataset is missing its dataspace, datatype or layout")
+	}
+	if dt.size != dt.dtype.ItemSize() {
+		return nil, fmt.Errorf("unsupported HDF5 element size %d", dt.size)
+	}
+	
+	n := 1
+	for _, s := range shape {
+		n *= s
+	}
+	itemsize := dt.size
+	data := make([]byte, n*itemsize)
+	if len(layout) < 2 || layout[0] < 3 {
+		return nil, fmt.Errorf("unsupported HDF5 layout message version %d", layout[0])
+	}
+	switch class := layout[1]; {
+	case class == 0:
+		size := int(binary.LittleEndian.Uint16(layout[2:]))
+		copy(data, layout[4:4+size])
+	case class == 1:
+		addr := h.uint(layout[2:], h.offsetSize)
+		if addr != h5Undefined && len(data) > 0 {
+			b, err := h.read(addr, len(data))
+			if err != nil {
+				return nil, err
+			}
+			copy(data, b)
+		}
+	case class == 2 && layout[0] == 3:
+		ndims := int(layout[2])
+		if ndims != len(shape)+1 {
+			return nil, fmt.Errorf("HDF5 chunk rank does not match the dataspace")
+		}
+		btree := h.uint(layout[3:], h.offsetSize)
+		cdims := make([]int, len(shape))
+		for d := range cdims {
+			cdims[d] = int(binary.LittleEndian.Uint32(layout[3+h.offsetSize+4*d:]))
+		}
+		if btree != h5Undefined {
+			if err := h.readChunks(btree, data, shape, cdims, itemsize, filters, 0); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported HDF5 layout class %d (version %d)", class, layout[0])
+	}
+	
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if dt.bigEndian {
+		order = binary.BigEndian
+	}
+	return tensor.FromBytesOrder(data, dt.dtype, order, shape...), nil
+}
+
+// dataspace decodes the dimensions of a dataspace message; a scalar has
+// no dimensions
+func (h *h5Reader) dataspace(b []byte) ([]int, error) {
+	rank := int(b[1])
+	pos := 8
+	switch b[0] {
+	case 1:
+	case 2:
+		pos = 4
+		if b[3] == 2 {
+			return nil, fmt.Errorf("null HDF5 dataspaces are not supported")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported HDF5 dataspace version %d", b[0])
+	}
+	shape := make([]int, rank)
+	for d := range shape {
+		shape[d] = int(h.uint(b[pos+d*h.lengthSize:], h.lengthSize))
+	}
+	return shape, nil
+}
+
+// h5ParseFilters decodes a filter pipeline message
+func h5ParseFilters(b []byte) ([]h5FilterStage, error) {
+	version, n := b[0], int(b[1])
+	pos := 2
+	if version == 1 {
+		pos = 8
+	} else if version != 2 {
+		return nil, fmt.Errorf("unsupported HDF5 filter pipeline version %d", version)
+	}
+	var stages []h5FilterStage
+	for i := 0; i < n; i++ {
+		id := int(binary.LittleEndian.Uint16(b[pos:]))
+		pos += 2
+		nameLen := 0
+		if version == 1 || id >= 256 {
+			nameLen = int(binary.LittleEndian.Uint16(b[pos:]))
+			pos += 2
+		}
+		nvalues := int(binary.LittleEndian.Uint16(b[pos+2:]))
+		pos += 4
+		if version == 1 {
+			nameLen = (nameLen + 7) / 8 * 8
+		}
+		pos += nameLen
+		stage := h5FilterStage{id: id}
+		for k := 0; k < nvalues; k++ {
+			stage.values = append(stage.values, binary.LittleEndian.Uint32(b[pos:]))
+			pos += 4
+		}
+		if version == 1 && nvalues%2 == 1 {
+			pos += 4
+		}
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
+// readChunks reads the chunks indexed by the B-tree at addr into data,
+// undoing the filters of each
+func (h *h5Reader) readChunks(addr uint64, data []byte, shape, cdims []int, itemsize int, filters []h5FilterStage, depth int) error {
+	if depth > 32 {
+		return fmt.Errorf("HDF5 chunk B-tree is too deep")
+	}
+	keySize := 8 + 8*(len(shape)+1)
+	node, err := h.btreeNode(addr, 1, keySize)
+	if err != nil {
+		return err
+	}
+	chunkBytes := itemsize
+	for _, c := range cdims {
+		chunkBytes *= c
+	}
+	for i, child := range node.addrs {
+		if node.level > 0 {
+			if err := h.readChunks(child, data, shape, cdims, itemsize, filters, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		key := node.keys[i]
+		size := int(binary.LittleEndian.Uint32(key))
+		mask := binary.LittleEndian.Uint32(key[4:])
+		offset := make([]int, len(shape))
+		for d := range offset {
+			offset[d] = int(binary.LittleEndian.Uint64(key[8+8*d:]))
+		}
+		chunk, err := h.read(child, size)
+		if err != nil {
+			return err
+		}
+		for f := len(filters) - 1; f >= 0; f-- {
+			if mask&(1<<f) != 0 {
+				continue
+			}
+			switch filters[f].id {
+			case h5FilterDeflate:
+				zr, err := zlib.NewReader(bytes.NewReader(chunk))
+				if err != nil {
+					return err
+				}
+				chunk, err = goio.ReadAll(zr)
+				if err != nil {
+					return err
+				}
+			case h5FilterShuffle:
+				width := itemsize
+				if len(filters[f].values) > 0 {
+					width = int(filters[f].values[0])
+				}
+				chunk = h5Unshuffle(chunk, width)
+			case h5FilterFletcher32:
+				if len(chunk) < 4 {
+					return fmt.Errorf("truncated HDF5 chunk checksum")
+				}
+				chunk = chunk[:len(chunk)-4]
+			default:
+				return fmt.Errorf("unsupported HDF5 filter %d", filters[f].id)
+			}
+		}
+		if len(chunk) < chunkBytes {
+			return fmt.Errorf("HDF5 chunk holds %d bytes, expected %d", len(chunk), chunkBytes)
+		}
+		copyChunk(data, shape, chunk, cdims, offset, itemsize, false)
+	}
+	return nil
+}
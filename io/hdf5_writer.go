@@ -0,0 +1,480 @@
+package io
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	goio "io"
+	"sort"
+	"strings"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// B-tree and symbol table node capacities implied by a version 0
+// superblock
+const (
+	h5GroupLeafK     = 4
+	h5GroupInternalK = 16
+	h5ChunkK         = 32
+)
+
+// h5Group is a group being written, holding datasets and subgroups
+type h5Group struct {
+	datasets map[string]*tensor.NDArray
+	groups   map[string]*h5Group
+}
+
+// h5Writer lays out a file sequentially in memory; the address of each
+// structure is the length of the buffer when it is written
+type h5Writer struct {
+	buf  bytes.Buffer
+	opts H5Options
+}
+
+// h5Child is an entry of a B-tree node: the address of a child node,
+// symbol table node or chunk, with the first and last keys it spans
+type h5Child struct {
+	addr        uint64
+	first, last []byte
+}
+
+// WriteH5 writes arrays as datasets of an HDF5 file to w. Keys are dataset
+// paths as for SaveH5.
+func WriteH5(w goio.Writer, datasets map[string]*tensor.NDArray, opts H5Options) error {
+	if opts.Compression < 0 || opts.Compression > 9 {
+		return fmt.Errorf("HDF5 compression level %d is not between 0 and 9", opts.Compression)
+	}
+	root := &h5Group{datasets: map[string]*tensor.NDArray{}, groups: map[string]*h5Group{}}
+	for _, name := range sortedKeys(datasets) {
+		if err := root.add(name, datasets[name]); err != nil {
+			return err
+		}
+	}
+	
+	hw := &h5Writer{opts: opts}
+	hw.buf.Write(make([]byte, 96))
+	rootAddr, btree, heap, err := hw.writeGroup(root)
+	if err != nil {
+		return err
+	}
+	
+	// Version 0 superblock with the root group's symbol table entry
+	sb := []byte(h5Signature)
+	sb = append(sb, 0, 0, 0, 0, 0, 8, 8, 0)
+	sb = binary.LittleEndian.AppendUint16(sb, h5GroupLeafK)
+	sb = binary.LittleEndian.AppendUint16(sb, h5GroupInternalK)
+	sb = binary.LittleEndian.AppendUint32(sb, 0)
+	sb = binary.LittleEndian.AppendUint64(sb, 0)
+	sb = binary.LittleEndian.AppendUint64(sb, h5Undefined)
+	sb = binary.LittleEndian.AppendUint64(sb, uint64(hw.buf.Len()))
+	sb = binary.LittleEndian.AppendUint64(sb, h5Undefined)
+	sb = append(sb, h5SymbolEntry(0, rootAddr, btree, heap)...)
+	copy(hw.buf.Bytes(), sb)
+	
+	_, err = w.Write(hw.buf.Bytes())
+	return err
+}
+
+// add places the array under its slash-separated path
+func (g *h5Group) add(name string, a *tensor.NDArray) error {
+	parts := strings.Split(strings.Trim(name, "/"), "/")
+	for i, part := range parts {
+		if part == "" || part == "." {
+			return fmt.Errorf("invalid HDF5 dataset name %q", name)
+		}
+		if i == len(parts)-1 {
+			break
+		}
+		if _, ok := g.datasets[part]; ok {
+			return fmt.Errorf("HDF5 name %q is both a dataset and a group", part)
+		}
+		sub, ok := g.groups[part]
+		if !ok {
+			sub = &h5Group{datasets: map[string]*tensor.NDArray{}, groups: map[string]*h5Group{}}
+			g.groups[part] = sub
+		}
+		g = sub
+	}
+	last := parts[len(parts)-1]
+	if _, ok := g.groups[last]; ok {
+		return fmt.Errorf("HDF5 name %q is both a dataset and a group", last)
+	}
+	if _, ok := g.datasets[last]; ok {
+		return fmt.Errorf("duplicate HDF5 dataset %q", name)
+	}
+	g.datasets[last] = a
+	return nil
+}
+
+// addr returns the address the next structure will be written at
+func (hw *h5Writer) addr() uint64 {
+	return uint64(hw.buf.Len())
+}
+
+// align pads the buffer to a multiple of 8 bytes
+func (hw *h5Writer) align() {
+	for hw.buf.Len()%8 != 0 {
+		hw.buf.WriteByte(0)
+	}
+}
+
+// writeGroup writes the members of g followed by its local heap, symbol
+// table nodes, B-tree and object header. It returns the addresses of the
+// object header, B-tree and heap.
+func (hw *h5Writer) writeGroup(g *h5Group) (header, btree, heap uint64, err error) {
+	type entry struct {
+		name                string
+		header, btree, heap uint64
+		group               bool
+	}
+	var entries []entry
+	for _, name := range sortedKeys(g.groups) {
+		h, b, hp, err := hw.writeGroup(g.groups[name])
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		entries = append(entries, entry{name, h, b, hp, true})
+	}
+	for _, name := range sortedKeys(g.datasets) {
+		h, err := hw.writeDataset(g.datasets[name])
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("writing %q: %w", name, err)
+		}
+		entries = append(entries, entry{name: name, header: h})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	
+	// Local heap holding the names, starting with the empty string
+	segment := make([]byte, 8)
+	offsets := make([]uint64, len(entries))
+	for i, e := range entries {
+		offsets[i] = uint64(len(segment))
+		segment = append(segment, h5PadName(e.name)...)
+	}
+	heap = hw.addr()
+	hw.buf.WriteString("HEAP")
+	hw.buf.Write([]byte{0, 0, 0, 0})
+	binary.Write(&hw.buf, binary.LittleEndian, uint64(len(segment)))
+	binary.Write(&hw.buf, binary.LittleEndian, uint64(1))
+	binary.Write(&hw.buf, binary.LittleEndian, hw.addr()+8)
+	hw.buf.Write(segment)
+	
+	// Symbol table nodes of up to 2K entries each
+	var leaves []h5Child
+	for start := 0; start < len(entries); start += 2 * h5GroupLeafK {
+		end := min(start+2*h5GroupLeafK, len(entries))
+		node := hw.addr()
+		hw.buf.WriteString("SNOD")
+		hw.buf.Write([]byte{1, 0})
+		binary.Write(&hw.buf, binary.LittleEndian, uint16(end-start))
+		for i := start; i < end; i++ {
+			e := entries[i]
+			if e.group {
+				hw.buf.Write(h5SymbolEntry(offsets[i], e.header, e.btree, e.heap))
+			} else {
+				hw.buf.Write(h5SymbolEntry(offsets[i], e.header, h5Undefined, h5Undefined))
+			}
+		}
+		hw.buf.Write(make([]byte, (2*h5GroupLeafK-(end-start))*40))
+		leaves = append(leaves, h5Child{
+			addr:  node,
+			first: binary.LittleEndian.AppendUint64(nil, 0),
+			last:  binary.LittleEndian.AppendUint64(nil, offsets[end-1]),
+		})
+	}
+	btree = hw.writeBTree(0, leaves, 2*h5GroupInternalK, 8)
+	
+	msg := binary.LittleEndian.AppendUint64(nil, btree)
+	msg = binary.LittleEndian.AppendUint64(msg, heap)
+	header = hw.writeObjectHeader([][]byte{h5Message(h5MsgSymbolTable, msg)})
+	return header, btree, heap, nil
+}
+
+// h5SymbolEntry encodes a symbol table entry, caching the B-tree and heap
+// addresses of groups in its scratch pad
+func h5SymbolEntry(name, header, btree, heap uint64) []byte {
+	b := binary.LittleEndian.AppendUint64(nil, name)
+	b = binary.LittleEndian.AppendUint64(b, header)
+	if btree == h5Undefined {
+		return append(b, make([]byte, 24)...)
+	}
+	b = binary.LittleEndian.AppendUint32(b, 1)
+	b = binary.LittleEndian.AppendUint32(b, 0)
+	b = binary.LittleEndian.AppendUint64(b, btree)
+	return binary.LittleEndian.AppendUint64(b, heap)
+}
+
+// writeBTree writes a version 1 B-tree over children, adding levels until
+// the root fits in one node, and returns the root address. Group trees
+// (type 0) key each child by the last name below it and chunk trees
+// (type 1) by the first chunk offset.
+func (hw *h5Writer) writeBTree(typ byte, children []h5Child, twoK, keySize int) uint64 {
+	for level := 0; ; level++ {
+		var parents []h5Child
+		for start := 0; start < len(children) || start == 0; start += twoK {
+			end := min(start+twoK, len(children))
+			node := children[start:end]
+			addr := hw.addr()
+			hw.buf.WriteString("TREE")
+			hw.buf.Write([]byte{typ, byte(level)})
+			binary.Write(&hw.buf, binary.LittleEndian, uint16(len(node)))
+			binary.Write(&hw.buf, binary.LittleEndian, h5Undefined)
+			binary.Write(&hw.buf, binary.LittleEndian, h5Undefined)
+			
+			keys := make([][]byte, len(node)+1)
+			keys[0] = make([]byte, keySize)
+			if len(node) > 0 {
+				keys[0] = node[0].first
+				keys[len(node)] = node[len(node)-1].last
+			}
+			for i := 1; i < len(node); i++ {
+				if typ == 0 {
+					keys[i] = node[i-1].last
+				} else {
+					keys[i] = node[i].first
+				}
+			}
+			for i, child := range node {
+				hw.buf.Write(keys[i])
+				binary.Write(&hw.buf, binary.LittleEndian, child.addr)
+			}
+			hw.buf.Write(keys[len(node)])
+			hw.buf.Write(make([]byte, (twoK-len(node))*(keySize+8)))
+			parents = append(parents, h5Child{addr: addr, first: keys[0], last: keys[len(node)]})
+			if len(children) == 0 {
+				break
+			}
+		}
+		if len(parents) == 1 {
+			return parents[0].addr
+		}
+		children = parents
+	}
+}
+
+// h5Message encodes an object header message, padded to 8 bytes as
+// version 1 object headers require
+func h5Message(typ uint16, data []byte) []byte {
+	size := (len(data) + 7) / 8 * 8
+	b := binary.LittleEndian.AppendUint16(nil, typ)
+	b = binary.LittleEndian.AppendUint16(b, uint16(size))
+	b = append(b, 0, 0, 0, 0)
+	b = append(b, data...)
+	return append(b, make([]byte, size-len(data))...)
+}
+
+// writeObjectHeader writes a version 1 object header holding messages
+func (hw *h5Writer) writeObjectHeader(messages [][]byte) uint64 {
+	hw.align()
+	addr := hw.addr()
+	size := 0
+	for _, m := range messages {
+		size += len(m)
+	}
+	hw.buf.Write([]byte{1, 0})
+	binary.Write(&hw.buf, binary.LittleEndian, uint16(len(messages)))
+	binary.Write(&hw.buf, binary.LittleEndian, uint32(1))
+	binary.Write(&hw.buf, binary.LittleEndian, uint32(size))
+	hw.buf.Write(make([]byte, 4))
+	for _, m := range messages {
+		hw.buf.Write(m)
+	}
+	return addr
+}
+
+// writeDataset writes the data of a followed by its object header and
+// returns the header address
+func (hw *h5Writer) writeDataset(a *tensor.NDArray) (uint64, error) {
+	datatype, err := encodeH5Datatype(a.DType())
+	if err != nil {
+		return 0, err
+	}
+	shape := a.Shape()
+	itemsize := a.DType().ItemSize()
+	
+	space := []byte{1, byte(len(shape)), 0, 0, 0, 0, 0, 0}
+	for _, s := range shape {
+		space = binary.LittleEndian.AppendUint64(space, uint64(s))
+	}
+	messages := [][]byte{
+		h5Message(h5MsgDataspace, space),
+		h5Message(h5MsgDatatype, datatype),
+	}
+	
+	if !hw.opts.chunked() {
+		data := a.Data()
+		addr := h5Undefined
+		if len(data) > 0 {
+			hw.align()
+			addr = hw.addr()
+			hw.buf.Write(data)
+		}
+		layout := []byte{3, 1}
+		layout = binary.LittleEndian.AppendUint64(layout, addr)
+		layout = binary.LittleEndian.AppendUint64(layout, uint64(len(data)))
+		messages = append(messages,
+			h5Message(h5MsgFillValue, []byte{2, 2, 2, 0}),
+			h5Message(h5MsgLayout, layout))
+		return hw.writeObjectHeader(messages), nil
+	}
+	
+	cdims, err := hw.chunkShape(shape, itemsize)
+	if err != nil {
+		return 0, err
+	}
+	var filters []byte
+	nfilters := 0
+	if hw.opts.Shuffle {
+		filters = append(filters, h5Filter(h5FilterShuffle, uint32(itemsize))...)
+		nfilters++
+	}
+	if hw.opts.Compression > 0 {
+		filters = append(filters, h5Filter(h5FilterDeflate, uint32(hw.opts.Compression))...)
+		nfilters++
+	}
+	
+	// Write every chunk in row-major order of its grid position. Edge
+	// chunks are stored at full size, padded with zeros.
+	keySize := 8 + 8*(len(shape)+1)
+	var chunks []h5Child
+	grid := make([]int, len(shape))
+	total := 1
+	for d, s := range shape {
+		grid[d] = (s + cdims[d] - 1) / cdims[d]
+		total *= grid[d]
+	}
+	chunkBytes := itemsize
+	for _, c := range cdims {
+		chunkBytes *= c
+	}
+	data := a.Data()
+	offset := make([]int, len(shape))
+	for n := 0; n < total; n++ {
+		rem := n
+		for d := len(shape) - 1; d >= 0; d-- {
+			offset[d] = rem % grid[d] * cdims[d]
+			rem /= grid[d]
+		}
+		chunk := make([]byte, chunkBytes)
+		copyChunk(data, shape, chunk, cdims, offset, itemsize, true)
+		if hw.opts.Shuffle {
+			chunk = h5Shuffle(chunk, itemsize)
+		}
+		if hw.opts.Compression > 0 {
+			var z bytes.Buffer
+			zw, _ := zlib.NewWriterLevel(&z, hw.opts.Compression)
+			zw.Write(chunk)
+			zw.Close()
+			chunk = z.Bytes()
+		}
+		addr := hw.addr()
+		hw.buf.Write(chunk)
+		
+		first := binary.LittleEndian.AppendUint32(nil, uint32(len(chunk)))
+		first = binary.LittleEndian.AppendUint32(first, 0)
+		last := make([]byte, 8, keySize)
+		for d := range shape {
+			first = binary.LittleEndian.AppendUint64(first, uint64(offset[d]))
+			last = binary.LittleEndian.AppendUint64(last, uint64(offset[d]+cdims[d]))
+		}
+		first = binary.LittleEndian.AppendUint64(first, 0)
+		last = binary.LittleEndian.AppendUint64(last, 0)
+		chunks = append(chunks, h5Child{addr: addr, first: first, last: last})
+	}
+	hw.align()
+	btree := h5Undefined
+	if len(chunks) > 0 {
+		btree = hw.writeBTree(1, chunks, 2*h5ChunkK, keySize)
+	}
+	
+	layout := []byte{3, 2, byte(len(shape) + 1)}
+	layout = binary.LittleEndian.AppendUint64(layout, btree)
+	for _, c := range cdims {
+		layout = binary.LittleEndian.AppendUint32(layout, uint32(c))
+	}
+	layout = binary.LittleEndian.AppendUint32(layout, uint32(itemsize))
+	messages = append(messages, h5Message(h5MsgFillValue, []byte{2, 3, 2, 0}))
+	if nfilters > 0 {
+		pipeline := append([]byte{1, byte(nfilters), 0, 0, 0, 0, 0, 0}, filters...)
+		messages = append(messages, h5Message(h5MsgFilters, pipeline))
+	}
+	messages = append(messages, h5Message(h5MsgLayout, layout))
+	return hw.writeObjectHeader(messages), nil
+}
+
+// chunkShape returns the chunk dimensions for an array of the given shape,
+// halving the largest dimension of the whole array until a chunk is at
+// most 64 KiB unless a chunk shape was given
+func (hw *h5Writer) chunkShape(shape []int, itemsize int) ([]int, error) {
+	cdims := make([]int, len(shape))
+	if hw.opts.Chunks != nil {
+		if len(hw.opts.Chunks) != len(shape) {
+			return nil, fmt.Errorf("chunk shape %v does not match array shape %v", hw.opts.Chunks, shape)
+		}
+		for d, c := range hw.opts.Chunks {
+			if c <= 0 {
+				return nil, fmt.Errorf("invalid chunk shape %v", hw.opts.Chunks)
+			}
+			cdims[d] = min(c, max(shape[d], 1))
+		}
+		return cdims, nil
+	}
+	
+	bytes := itemsize
+	for d, s := range shape {
+		cdims[d] = max(s, 1)
+		bytes *= cdims[d]
+	}
+	for bytes > 64*1024 {
+		largest := 0
+		for d := range cdims {
+			if cdims[d] > cdims[largest] {
+				largest = d
+			}
+		}
+		if cdims[largest] == 1 {
+			break
+		}
+		half := (cdims[largest] + 1) / 2
+		bytes = bytes / cdims[largest] * half
+		cdims[largest] = half
+	}
+	return cdims, nil
+}
+
+// h5Filter encodes a filter pipeline entry with one client data value
+func h5Filter(id uint16, value uint32) []byte {
+	b := binary.LittleEndian.AppendUint16(nil, id)
+	b = binary.LittleEndian.AppendUint16(b, 0)
+	b = binary.LittleEndian.AppendUint16(b, 1)
+	b = binary.LittleEndian.AppendUint16(b, 1)
+	b = binary.LittleEndian.AppendUint32(b, value)
+	return binary.LittleEndian.AppendUint32(b, 0)
+}
+
+// h5Shuffle groups the i-th bytes of every element together
+func h5Shuffle(data []byte, itemsize int) []byte {
+	n := len(data) / itemsize
+	out := make([]byte, len(data))
+	for i := 0; i < n; i++ {
+		for b := 0; b < itemsize; b++ {
+			out[b*n+i] = data[i*itemsize+b]
+		}
+	}
+	return out
+}
+
+// h5Unshuffle reverses h5Shuffle
+func h5Unshuffle(data []byte, itemsize int) []byte {
+	n := len(data) / itemsize
+	out := make([]byte, len(data))
+	copy(out, data)
+	for i := 0; i < n; i++ {
+		for b := 0; b < itemsize; b++ {
+			out[i*itemsize+b] = data[b*n+i]
+		}
+	}
+	return out
+}
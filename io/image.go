@@ -0,0 +1,192 @@
+package io
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	goio "io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// ImageOptions controls how arrays are encoded by SaveImageWith and
+// WriteImage
+type ImageOptions struct {
+	// Format is "png" or "jpeg". SaveImageWith infers it from the file
+	// extension when empty; WriteImage defaults to "png".
+	Format string
+	
+	// Quality is the JPEG quality from 1 to 100. The zero value is 75.
+	Quality int
+}
+
+// FromImage converts img to a Uint8 array of shape (H, W, C). Grayscale
+// images have one channel, opaque images three (RGB) and all others four
+// (RGBA, not premultiplied).
+func FromImage(img image.Image) *tensor.NDArray {
+	h, w, c := imageShape(img)
+	data := make([]byte, h*w*c)
+	imagePixels(img, c, func(i int, v uint16) {
+		data[i] = uint8(v >> 8)
+	})
+	return tensor.WrapBytes(data, tensor.Uint8, h, w, c)
+}
+
+// FromImageFloat is FromImage with Float32 values scaled to [0, 1]. 16-bit
+// images keep their full precision.
+func FromImageFloat(img image.Image) *tensor.NDArray {
+	h, w, c := imageShape(img)
+	data := make([]byte, 4*h*w*c)
+	imagePixels(img, c, func(i int, v uint16) {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(float32(v)/0xffff))
+	})
+	return tensor.WrapBytes(data, tensor.Float32, h, w, c)
+}
+
+// imageShape returns the (H, W, C) shape FromImage gives img
+func imageShape(img image.Image) (h, w, c int) {
+	b := img.Bounds()
+	switch img.ColorModel() {
+	case color.GrayModel, color.Gray16Model:
+		c = 1
+	default:
+		c = 4
+		if o, ok := img.(interface{ Opaque() bool }); ok && o.Opaque() {
+			c = 3
+		}
+	}
+	return b.Dy(), b.Dx(), c
+}
+
+// imagePixels calls set with the C-order index and 16-bit value of every
+// channel of every pixel of img
+func imagePixels(img image.Image, channels int, set func(i int, v uint16)) {
+	b := img.Bounds()
+	i := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if channels == 1 {
+				set(i, color.Gray16Model.Convert(img.At(x, y)).(color.Gray16).Y)
+				i++
+				continue
+			}
+			p := color.NRGBA64Model.Convert(img.At(x, y)).(color.NRGBA64)
+			for _, v := range []uint16{p.R, p.G, p.B, p.A}[:channels] {
+				set(i, v)
+				i++
+			}
+		}
+	}
+}
+
+// ToImage converts an array of shape (H, W) or (H, W, C), with C of 1, 3 or
+// 4, to an image. Floating-point values are taken to lie in [0, 1] and
+// integers in [0, 255]; values outside the range are clipped. One channel
+// gives an *image.Gray and three or four an *image.NRGBA.
+func ToImage(a *tensor.NDArray) image.Image {
+	shape := a.Shape()
+	c := 1
+	switch {
+	case len(shape) == 3 && (shape[2] == 1 || shape[2] == 3 || shape[2] == 4):
+		c = shape[2]
+	case len(shape) != 2:
+		panic(fmt.Sprintf("expected an array of shape (H, W) or (H, W, C) with C of 1, 3 or 4, got %v", shape))
+	}
+	h, w := shape[0], shape[1]
+	
+	scale := 1.0
+	if a.DType().IsFloat() {
+		scale = 255
+	}
+	values := a.ToSliceFloat64()
+	pix := make([]uint8, len(values))
+	for i, v := range values {
+		pix[i] = uint8(math.Round(math.Max(0, math.Min(255, v*scale))))
+	}
+	
+	rect := image.Rect(0, 0, w, h)
+	if c == 1 {
+		return &image.Gray{Pix: pix, Stride: w, Rect: rect}
+	}
+	img := image.NewNRGBA(rect)
+	for i := 0; i < h*w; i++ {
+		copy(img.Pix[4*i:4*i+c], pix[c*i:c*i+c])
+		if c == 3 {
+			img.Pix[4*i+3] = 255
+		}
+	}
+	return img
+}
+
+// LoadImage decodes the PNG or JPEG file at path with FromImage
+func LoadImage(path string) (*tensor.NDArray, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadImage(f)
+}
+
+// ReadImage decodes a PNG or JPEG image from r with FromImage
+func ReadImage(r goio.Reader) (*tensor.NDArray, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+	return FromImage(img), nil
+}
+
+// SaveImage encodes a with ToImage and writes it to path as PNG or JPEG,
+// chosen by the file extension
+func SaveImage(path string, a *tensor.NDArray) error {
+	return SaveImageWith(path, a, ImageOptions{})
+}
+
+// SaveImageWith is SaveImage with an explicit format or JPEG quality
+func SaveImageWith(path string, a *tensor.NDArray, opts ImageOptions) error {
+	if opts.Format == "" {
+		switch ext := strings.ToLower(filepath.Ext(path)); ext {
+		case ".png":
+			opts.Format = "png"
+		case ".jpg", ".jpeg":
+			opts.Format = "jpeg"
+		default:
+			return fmt.Errorf("cannot infer image format from extension %q", ext)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteImage(f, a, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteImage encodes a with ToImage and writes it to w in the format given
+// by opts
+func WriteImage(w goio.Writer, a *tensor.NDArray, opts ImageOptions) error {
+	img := ToImage(a)
+	switch strings.ToLower(opts.Format) {
+	case "", "png":
+		return png.Encode(w, img)
+	case "jpeg", "jpg":
+		quality := opts.Quality
+		if quality == 0 {
+			quality = jpeg.DefaultQuality
+		}
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	default:
+		return fmt.Errorf("unsupported image format %q", opts.Format)
+	}
+}
@@ -0,0 +1,1447 @@
+package io
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"image"
+	goio "io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func sliceClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func shapeEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWriteNPYMatchesNumPy(t *testing.T) {
+	// The bytes of np.save(f, np.arange(3))
+	header := "{'descr': '<i8', 'fortran_order': False, 'shape': (3,), }"
+	want := []byte("\x93NUMPY\x01\x00\x76\x00" + header + strings.Repeat(" ", 117-len(header)) + "\n")
+	for _, v := range []int64{0, 1, 2} {
+		want = binary.LittleEndian.AppendUint64(want, uint64(v))
+	}
+	
+	var buf bytes.Buffer
+	if err := WriteNPY(&buf, tensor.FromSliceInt64([]int64{0, 1, 2}, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("expected\n%q\ngot\n%q", want, buf.Bytes())
+	}
+}
+
+func TestNPYRoundTrip(t *testing.T) {
+	values := []float64{1, 0, 3, 4, 5, 126}
+	dtypes := []tensor.DType{
+		tensor.Bool, tensor.Int8, tensor.Int16, tensor.Int32, tensor.Int64,
+		tensor.Uint8, tensor.Uint16, tensor.Uint32, tensor.Uint64,
+		tensor.Float32, tensor.Float64, tensor.Complex64, tensor.Complex128,
+	}
+	for _, dtype := range dtypes {
+		a := tensor.Zeros([]int{2, 3}, dtype)
+		for i, v := range values {
+			if dtype == tensor.Bool && v != 0 {
+				v = 1
+			}
+			a.SetComplex128(complex(v, 0), i/3, i%3)
+		}
+		for _, fortran := range []bool{false, true} {
+			var buf bytes.Buffer
+			if err := WriteNPYWith(&buf, a, NPYOptions{FortranOrder: fortran}); err != nil {
+				t.Fatalf("%s: %v", dtype, err)
+			}
+			if buf.Len()%64 != int(a.Size()*dtype.ItemSize())%64 {
+				t.Errorf("%s: data does not start on a 64-byte boundary", dtype)
+			}
+			got, err := ReadNPY(&buf)
+			if err != nil {
+				t.Fatalf("%s: %v", dtype, err)
+			}
+			if got.DType() != dtype || !shapeEqual(got.Shape(), []int{2, 3}) {
+				t.Fatalf("%s: got %s with shape %v", dtype, got.DType(), got.Shape())
+			}
+			if !bytes.Equal(got.Data(), a.Data()) {
+				t.Errorf("%s (fortran %v): expected %v, got %v", dtype, fortran, a.ToSliceFloat64(), got.ToSliceFloat64())
+			}
+		}
+	}
+}
+
+func TestNPYFortranLayout(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	var buf bytes.Buffer
+	if err := WriteNPYWith(&buf, a, NPYOptions{FortranOrder: true}); err != nil {
+		t.Fatal(err)
+	}
+	raw := buf.Bytes()
+	if !bytes.Contains(raw, []byte("'fortran_order': True")) {
+		t.Errorf("header does not declare Fortran order: %q", raw[:64])
+	}
+	data := raw[len(raw)-48:]
+	got := make([]float64, 6)
+	for i := range got {
+		got[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+	if want := []float64{1, 4, 2, 5, 3, 6}; !sliceClose(got, want, 0) {
+		t.Errorf("expected column-major data %v, got %v", want, got)
+	}
+}
+
+func TestReadNPYBigEndianV2(t *testing.T) {
+	// A version 2.0 file with big-endian float32 and complex64 data
+	build := func(descr string, data []byte, shape string) []byte {
+		header := "{'descr': '" + descr + "', 'fortran_order': False, 'shape': " + shape + ", }\n"
+		out := []byte("\x93NUMPY\x02\x00")
+		out = binary.LittleEndian.AppendUint32(out, uint32(len(header)))
+		return append(append(out, header...), data...)
+	}
+	
+	var f32 []byte
+	for _, v := range []float32{1.5, -2} {
+		f32 = binary.BigEndian.AppendUint32(f32, math.Float32bits(v))
+	}
+	a, err := ReadNPY(bytes.NewReader(build(">f4", f32, "(2,)")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := a.ToSliceFloat64(); !sliceClose(got, []float64{1.5, -2}, 0) {
+		t.Errorf("float32: expected [1.5 -2], got %v", got)
+	}
+	
+	var c64 []byte
+	c64 = binary.BigEndian.AppendUint32(c64, math.Float32bits(3))
+	c64 = binary.BigEndian.AppendUint32(c64, math.Float32bits(-4))
+	c, err := ReadNPY(bytes.NewReader(build(">c8", c64, "()")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.ToSliceComplex128(); len(got) != 1 || got[0] != complex(3, -4) {
+		t.Errorf("complex64 scalar: expected [(3-4i)], got %v", got)
+	}
+}
+
+func TestWriteNPYBigEndian(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1.5, -2, 3, 4}, 2, 2)
+	var buf bytes.Buffer
+	if err := WriteNPYWith(&buf, a, NPYOptions{ByteOrder: binary.BigEndian, FortranOrder: true}); err != nil {
+		t.Fatal(err)
+	}
+	raw := buf.Bytes()
+	if !bytes.Contains(raw, []byte("'descr': '>f8'")) {
+		t.Errorf("expected a big-endian descr in %q", raw[:64])
+	}
+	// Column-major, so the second element stored is a[1][0]
+	if got := math.Float64frombits(binary.BigEndian.Uint64(raw[len(raw)-24:])); got != 3 {
+		t.Errorf("expected big-endian 3, got %v", got)
+	}
+	back, err := ReadNPY(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(back.Data(), a.Data()) {
+		t.Errorf("round trip gave %v", back.ToSliceFloat64())
+	}
+	
+	// Complex elements swap each part separately
+	c := tensor.FromSliceComplex128([]complex128{complex(1.5, -2), 3i}, 2)
+	buf.Reset()
+	if err := WriteNPYWith(&buf, c, NPYOptions{ByteOrder: binary.BigEndian}); err != nil {
+		t.Fatal(err)
+	}
+	raw = buf.Bytes()
+	if got := math.Float64frombits(binary.BigEndian.Uint64(raw[len(raw)-24:])); got != -2 {
+		t.Errorf("expected big-endian imaginary part -2, got %v", got)
+	}
+	if back, err = ReadNPY(&buf); err != nil || !bytes.Equal(back.Data(), c.Data()) {
+		t.Errorf("complex round trip gave %v (%v)", back, err)
+	}
+}
+
+func TestReadNPYErrors(t *testing.T) {
+	cases := map[string]string{
+		"bad magic":        "\x93NUMPX\x01\x00",
+		"truncated":        "\x93NUMPY\x01\x00\x40\x00{'descr'",
+		"object dtype":     "\x93NUMPY\x01\x00\x3c\x00{'descr': '|O', 'fortran_order': False, 'shape': (1,), }    \n",
+		"structured dtype": "\x93NUMPY\x01\x00\x3c\x00{'descr': [('a', '<f8')], 'fortran_order': False, 'shape': (1,)}\n",
+	}
+	for name, data := range cases {
+		if _, err := ReadNPY(strings.NewReader(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	
+	// Shapes that overflow or promise more data than the stream holds
+	for _, shape := range []string{"(99999999999999999999,)", "(4611686018427387904, 4)", "(1099511627776,)", "(2, 3)"} {
+		header := "{'descr': '<f8', 'fortran_order': False, 'shape': " + shape + ", }\n"
+		data := []byte("\x93NUMPY\x01\x00")
+		data = binary.LittleEndian.AppendUint16(data, uint16(len(header)))
+		data = append(append(data, header...), make([]byte, 16)...)
+		if _, err := ReadNPY(bytes.NewReader(data)); err == nil {
+			t.Errorf("shape %s: expected an error", shape)
+		}
+		
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create("a.npy")
+		w.Write(data)
+		zw.Close()
+		if _, err := ReadNPZ(bytes.NewReader(buf.Bytes()), int64(buf.Len())); err == nil {
+			t.Errorf("shape %s: expected an error from ReadNPZ", shape)
+		}
+	}
+}
+
+func TestSaveLoadNPY(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.npy")
+	a := tensor.FromSliceFloat64([]float64{0.5, -1, math.Inf(1)}, 3, 1)
+	if err := SaveNPY(path, a); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadNPY(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shapeEqual(got.Shape(), []int{3, 1}) || !bytes.Equal(got.Data(), a.Data()) {
+		t.Errorf("expected %v, got %v", a.ToSliceFloat64(), got.ToSliceFloat64())
+	}
+	if _, err := LoadNPY(filepath.Join(t.TempDir(), "missing.npy")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestLoadNPYMmap(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.npy")
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	if err := SaveNPY(path, a); err != nil {
+		t.Fatal(err)
+	}
+	
+	m, err := LoadNPYMmap(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shapeEqual(m.Array.Shape(), []int{2, 3}) || m.Array.Sum() != 21 {
+		t.Errorf("expected %v, got %v", a.ToSliceFloat64(), m.Array.ToSliceFloat64())
+	}
+	m.Array.SetFloat64(-1, 1, 2)
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadNPY(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := got.GetFloat64(1, 2); v != -1 {
+		t.Errorf("expected the write to reach the file, got %f", v)
+	}
+	
+	m, err = LoadNPYMmap(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if !m.Array.IsFrozen() || m.Array.GetFloat64(0, 1) != 2 {
+		t.Errorf("expected a frozen array with 2 at [0,1], got %v", m.Array.ToSliceFloat64())
+	}
+	
+	fortran := filepath.Join(dir, "f.npy")
+	var buf bytes.Buffer
+	if err := WriteNPYWith(&buf, a, NPYOptions{FortranOrder: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fortran, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadNPYMmap(fortran, true); err == nil {
+		t.Error("expected an error for Fortran-ordered data")
+	}
+	buf.Reset()
+	if err := WriteNPY(&buf, a); err != nil {
+		t.Fatal(err)
+	}
+	short := filepath.Join(dir, "short.npy")
+	if err := os.WriteFile(short, buf.Bytes()[:buf.Len()-8], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadNPYMmap(short, true); err == nil {
+		t.Error("expected an error for a truncated file")
+	}
+	
+	for _, shape := range []string{"(4611686018427387904, 4)", "(1099511627776,)"} {
+		header := "{'descr': '<f8', 'fortran_order': False, 'shape': " + shape + ", }\n"
+		data := []byte("\x93NUMPY\x01\x00")
+		data = binary.LittleEndian.AppendUint16(data, uint16(len(header)))
+		data = append(append(data, header...), make([]byte, 16)...)
+		huge := filepath.Join(dir, "huge.npy")
+		if err := os.WriteFile(huge, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadNPYMmap(huge, true); err == nil {
+			t.Errorf("shape %s: expected an error", shape)
+		}
+	}
+}
+
+func TestNPZ(t *testing.T) {
+	arrays := map[string]*tensor.NDArray{
+		"weights": tensor.FromSliceFloat64([]float64{0.25, -1, 3, 8}, 2, 2),
+		"bias":    tensor.FromSliceFloat32([]float32{1, 2}, 2),
+		"steps":   tensor.FromSliceInt64([]int64{100}, 1),
+	}
+	
+	dir := t.TempDir()
+	for _, compress := range []bool{false, true} {
+		path := filepath.Join(dir, "model.npz")
+		if err := SaveNPZWith(path, arrays, NPZOptions{Compress: compress}); err != nil {
+			t.Fatal(err)
+		}
+		got, err := LoadNPZ(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(arrays) {
+			t.Fatalf("expected %d arrays, got %d", len(arrays), len(got))
+		}
+		for name, want := range arrays {
+			a, ok := got[name]
+			if !ok {
+				t.Fatalf("missing array %q", name)
+			}
+			if a.DType() != want.DType() || !shapeEqual(a.Shape(), want.Shape()) || !bytes.Equal(a.Data(), want.Data()) {
+				t.Errorf("%s (compress %v): expected %v, got %v", name, compress, want.ToSliceFloat64(), a.ToSliceFloat64())
+			}
+		}
+	}
+	
+	// Compression shrinks repetitive data, and archives read from memory
+	zeros := map[string]*tensor.NDArray{"z": tensor.Zeros([]int{1000}, tensor.Float64)}
+	var plain, packed bytes.Buffer
+	if err := WriteNPZ(&plain, zeros, NPZOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteNPZ(&packed, zeros, NPZOptions{Compress: true}); err != nil {
+		t.Fatal(err)
+	}
+	if packed.Len() >= plain.Len()/10 {
+		t.Errorf("compressed archive is %d bytes, uncompressed %d", packed.Len(), plain.Len())
+	}
+	got, err := ReadNPZ(bytes.NewReader(packed.Bytes()), int64(packed.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if z := got["z"]; z == nil || z.Size() != 1000 || z.Sum() != 0 {
+		t.Errorf("unexpected array %v", z)
+	}
+}
+
+func TestReadTxt(t *testing.T) {
+	text := "# x y z\n1 2 3\n\n4.5  -5 6e1  # trailing\n"
+	a, err := ReadTxt(strings.NewReader(text), LoadTxtOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.DType() != tensor.Float64 || !shapeEqual(a.Shape(), []int{2, 3}) {
+		t.Fatalf("expected 2x3 float64 array, got %s %v", a.DType(), a.Shape())
+	}
+	if !sliceClose(a.ToSliceFloat64(), []float64{1, 2, 3, 4.5, -5, 60}, 0) {
+		t.Errorf("unexpected values %v", a.ToSliceFloat64())
+	}
+	
+	csv := "id,name,score,rank\n7,a,0.5,1\n8,b,0.25,2\n9,c,1,3\n"
+	b, err := ReadTxt(strings.NewReader(csv), LoadTxtOptions{Delimiter: ",", SkipRows: 1, UseCols: []int{-1, 0}, DType: tensor.Int64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.DType() != tensor.Int64 || !shapeEqual(b.Shape(), []int{3, 2}) {
+		t.Fatalf("expected 3x2 int64 array, got %s %v", b.DType(), b.Shape())
+	}
+	got := b.ToSliceInt64()
+	want := []int64{1, 7, 2, 8, 3, 9}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	
+	c, err := ReadTxt(strings.NewReader("(1+2j) -3.5j\n"), LoadTxtOptions{DType: tensor.Complex128})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cv := c.ToSliceComplex128(); cv[0] != 1+2i || cv[1] != -3.5i {
+		t.Errorf("unexpected complex values %v", cv)
+	}
+	
+	for _, bad := range []string{"1 2\n3\n", "1 x\n"} {
+		if _, err := ReadTxt(strings.NewReader(bad), LoadTxtOptions{}); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+	if _, err := ReadTxt(strings.NewReader("1 2\n"), LoadTxtOptions{UseCols: []int{2}}); err == nil {
+		t.Error("expected an error for an out of range column")
+	}
+}
+
+func TestWriteTxt(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2.5, -3, 4}, 2, 2)
+	var buf bytes.Buffer
+	if err := WriteTxt(&buf, a, SaveTxtOptions{Fmt: "%g", Delimiter: ",", Header: "a,b"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "# a,b\n1,2.5\n-3,4\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+	
+	buf.Reset()
+	if err := WriteTxt(&buf, tensor.FromSliceInt64([]int64{1 << 60, -2}, 2), SaveTxtOptions{Fmt: "%d"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "1152921504606846976\n-2\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+	
+	c := tensor.FromSliceComplex128([]complex128{1 - 2i, 0.5i}, 1, 2)
+	buf.Reset()
+	if err := WriteTxt(&buf, c, SaveTxtOptions{Fmt: "%g"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "(1-2j) (0+0.5j)\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+	
+	if err := WriteTxt(&buf, tensor.Zeros([]int{2, 2, 2}, tensor.Float64), SaveTxtOptions{}); err == nil {
+		t.Error("expected an error for a 3D array")
+	}
+	
+	// The default format round trips through LoadTxt
+	path := filepath.Join(t.TempDir(), "a.txt")
+	x := tensor.FromSliceFloat64([]float64{math.Pi, -1e-300, 12345.678}, 3)
+	if err := SaveTxt(path, x); err != nil {
+		t.Fatal(err)
+	}
+	y, err := LoadTxt(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shapeEqual(y.Shape(), []int{3, 1}) || !sliceClose(y.ToSliceFloat64(), x.ToSliceFloat64(), 0) {
+		t.Errorf("expected %v, got %v", x.ToSliceFloat64(), y.ToSliceFloat64())
+	}
+}
+
+func TestGenFromTxt(t *testing.T) {
+	text := "# generated by sensor\n" +
+		"# id, temp, count, flag\n" +
+		"1, 20.5, 3, a\n" +
+		"2, , 4, b\n" +
+		"3, NA, x, c\n" +
+		"4, 22, , d\n" +
+		"5, 1, 2\n"
+	opts := GenFromTxtOptions{
+		Delimiter:     ",",
+		SkipHeader:    1,
+		Names:         true,
+		UseCols:       []int{0, 1, 2},
+		DTypes:        map[string]tensor.DType{"id": tensor.Int64, "count": tensor.Int32},
+		MissingValues: []string{"NA"},
+		FillValues:    map[string]float64{"count": 0},
+		SkipInvalid:   true,
+	}
+	tbl, err := ReadGenFromTxt(strings.NewReader(text), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(tbl.Names, ",") != "id,temp,count" || tbl.Len() != 4 {
+		t.Fatalf("unexpected table %v with %d rows", tbl.Names, tbl.Len())
+	}
+	if tbl.Column("id").DType() != tensor.Int64 || tbl.Column("temp").DType() != tensor.Float64 || tbl.Column("count").DType() != tensor.Int32 {
+		t.Error("unexpected column dtypes")
+	}
+	
+	temp := tbl.Column("temp").ToSliceFloat64()
+	if temp[0] != 20.5 || !math.IsNaN(temp[1]) || !math.IsNaN(temp[2]) || temp[3] != 22 {
+		t.Errorf("unexpected temp column %v", temp)
+	}
+	if !sliceClose(tbl.Column("count").ToSliceFloat64(), []float64{3, 4, 0, 0}, 0) {
+		t.Errorf("unexpected count column %v", tbl.Column("count").ToSliceFloat64())
+	}
+	wantMissing := [][]float64{{0, 0, 0, 0}, {0, 1, 1, 0}, {0, 0, 1, 1}}
+	for j, m := range tbl.Missing {
+		if !sliceClose(m.ToSliceFloat64(), wantMissing[j], 0) {
+			t.Errorf("column %d: expected missing mask %v, got %v", j, wantMissing[j], m.ToSliceFloat64())
+		}
+	}
+	
+	m := tbl.Matrix()
+	if !shapeEqual(m.Shape(), []int{4, 3}) || m.GetFloat64(3, 0) != 4 || m.GetFloat64(0, 2) != 3 {
+		t.Errorf("unexpected matrix %v", m.ToSliceFloat64())
+	}
+	
+	// Without names, columns are numbered and integer fills default to -1
+	plain, err := ReadGenFromTxt(strings.NewReader("1 2\n3 NA\n"), GenFromTxtOptions{
+		MissingValues: []string{"NA"},
+		DTypes:        map[string]tensor.DType{"f1": tensor.Int64},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(plain.Names, ",") != "f0,f1" || !sliceClose(plain.Column("f1").ToSliceFloat64(), []float64{2, -1}, 0) {
+		t.Errorf("unexpected table %v %v", plain.Names, plain.Column("f1").ToSliceFloat64())
+	}
+	
+	opts.SkipInvalid = false
+	if _, err := ReadGenFromTxt(strings.NewReader(text), opts); err == nil {
+		t.Error("expected an error for a short row")
+	}
+}
+
+func TestH5RoundTrip(t *testing.T) {
+	datasets := map[string]*tensor.NDArray{
+		"f64":          tensor.FromSliceFloat64([]float64{1.5, -2, 3.25, 4, 5, 6}, 2, 3),
+		"f32":          tensor.FromSliceFloat32([]float32{0.5, 1}, 2),
+		"i64":          tensor.FromSliceInt64([]int64{-1 << 40, 7, 9}, 3),
+		"c128":         tensor.FromSliceComplex128([]complex128{1 + 2i, -3i}, 2),
+		"train/x":      tensor.FromSliceFloat64([]float64{1, 2, 3, 4}, 2, 2),
+		"train/meta/n": tensor.FromSliceInt64([]int64{4}, 1),
+	}
+	for _, dt := range []tensor.DType{tensor.Bool, tensor.Int8, tensor.Int16, tensor.Int32, tensor.Uint8, tensor.Uint16, tensor.Uint32, tensor.Uint64, tensor.Complex64} {
+		a := tensor.Zeros([]int{3, 2}, dt)
+		for i := 0; i < 3; i++ {
+			if dt.IsComplex() {
+				a.SetComplex128(complex(float64(i), 1), i, 1)
+			} else {
+				a.SetFloat64(float64(i), i, 1)
+			}
+		}
+		datasets["types/"+dt.String()] = a
+	}
+	
+	for _, opts := range []H5Options{{}, {Shuffle: true}, {Compression: 6, Shuffle: true}} {
+		var buf bytes.Buffer
+		if err := WriteH5(&buf, datasets, opts); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(buf.Bytes(), []byte("\x89HDF\r\n\x1a\n\x00")) {
+			t.Fatal("expected a version 0 superblock")
+		}
+		got, err := ReadH5(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("%+v: %v", opts, err)
+		}
+		if len(got) != len(datasets) {
+			t.Fatalf("%+v: expected %d datasets, got %d", opts, len(datasets), len(got))
+		}
+		for name, want := range datasets {
+			a := got[name]
+			if a == nil {
+				t.Fatalf("%+v: missing dataset %q", opts, name)
+			}
+			if a.DType() != want.DType() || !shapeEqual(a.Shape(), want.Shape()) || !bytes.Equal(a.Data(), want.Data()) {
+				t.Errorf("%+v: dataset %q does not round trip", opts, name)
+			}
+		}
+	}
+}
+
+func TestH5Chunked(t *testing.T) {
+	// A 3D array with partial edge chunks and enough chunks to need a
+	// multi-level chunk B-tree
+	n := 10 * 11 * 13
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = math.Sin(float64(i))
+	}
+	a := tensor.FromSliceFloat64(values, 10, 11, 13)
+	
+	path := filepath.Join(t.TempDir(), "data.h5")
+	for _, opts := range []H5Options{{Chunks: []int{2, 3, 4}}, {Chunks: []int{3, 20, 5}, Compression: 9}, {Compression: 1}} {
+		if err := SaveH5With(path, map[string]*tensor.NDArray{"grid": a}, opts); err != nil {
+			t.Fatal(err)
+		}
+		got, err := LoadH5Dataset(path, "/grid")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !shapeEqual(got.Shape(), []int{10, 11, 13}) || !sliceClose(got.ToSliceFloat64(), values, 0) {
+			t.Errorf("%+v: chunked dataset does not round trip", opts)
+		}
+	}
+	
+	// Compression shrinks repetitive data
+	zeros := map[string]*tensor.NDArray{"z": tensor.Zeros([]int{100, 100}, tensor.Float64)}
+	var plain, packed bytes.Buffer
+	WriteH5(&plain, zeros, H5Options{})
+	WriteH5(&packed, zeros, H5Options{Compression: 4})
+	if packed.Len() >= plain.Len()/10 {
+		t.Errorf("compressed file is %d bytes, uncompressed %d", packed.Len(), plain.Len())
+	}
+}
+
+func TestH5ManyDatasets(t *testing.T) {
+	// Enough members to need several symbol table nodes and a multi-level
+	// group B-tree
+	datasets := map[string]*tensor.NDArray{}
+	for i := 0; i < 300; i++ {
+		datasets[fmt.Sprintf("d%03d", i)] = tensor.FromSliceInt64([]int64{int64(i)}, 1)
+	}
+	path := filepath.Join(t.TempDir(), "many.h5")
+	if err := SaveH5(path, datasets); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadH5(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 300 {
+		t.Fatalf("expected 300 datasets, got %d", len(got))
+	}
+	for i := 0; i < 300; i++ {
+		if a := got[fmt.Sprintf("d%03d", i)]; a == nil || a.GetInt64(0) != int64(i) {
+			t.Fatalf("dataset d%03d does not round trip", i)
+		}
+	}
+	a, err := LoadH5Dataset(path, "d150")
+	if err != nil || a.GetInt64(0) != 150 {
+		t.Errorf("LoadH5Dataset: %v", err)
+	}
+}
+
+func TestH5Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.h5")
+	a := tensor.FromSliceFloat64([]float64{1, 2}, 2)
+	if err := SaveH5(path, map[string]*tensor.NDArray{"g": a, "g/x": a}); err == nil {
+		t.Error("expected an error for a name used as dataset and group")
+	}
+	if err := SaveH5With(path, map[string]*tensor.NDArray{"x": a}, H5Options{Chunks: []int{1, 1}}); err == nil {
+		t.Error("expected an error for a chunk shape of the wrong rank")
+	}
+	if err := SaveH5(path, map[string]*tensor.NDArray{"x": a}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadH5Dataset(path, "y"); err == nil {
+		t.Error("expected an error for a missing dataset")
+	}
+	if _, err := ReadH5(strings.NewReader("not an hdf5 file"), 16); err == nil {
+		t.Error("expected an error for a file without a superblock")
+	}
+}
+
+func TestArrowTensor(t *testing.T) {
+	for _, a := range []*tensor.NDArray{
+		tensor.FromSliceFloat64([]float64{1, 2, 3, 4, 5, 6}, 2, 3),
+		tensor.FromSliceFloat32([]float32{0.5, -1}, 2),
+		tensor.FromSliceInt64([]int64{-1 << 50, 3, 5, 7}, 2, 1, 2),
+		tensor.Zeros([]int{3}, tensor.Uint16),
+	} {
+		var buf bytes.Buffer
+		if err := WriteArrowTensor(&buf, a); err != nil {
+			t.Fatal(err)
+		}
+		raw := buf.Bytes()
+		if !bytes.HasPrefix(raw, []byte{0xFF, 0xFF, 0xFF, 0xFF}) || binary.LittleEndian.Uint32(raw[4:])%8 != 0 {
+			t.Fatal("expected an 8-byte aligned encapsulated message")
+		}
+		
+		// Both the current framing and the pre-1.0 one without the
+		// continuation marker are read
+		for _, framed := range [][]byte{raw, raw[4:]} {
+			got, err := ReadArrowTensor(bytes.NewReader(framed))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.DType() != a.DType() || !shapeEqual(got.Shape(), a.Shape()) || !bytes.Equal(got.Data(), a.Data()) {
+				t.Errorf("tensor %v does not round trip: got %v", a.ToSliceFloat64(), got.ToSliceFloat64())
+			}
+		}
+	}
+	
+	if err := WriteArrowTensor(&bytes.Buffer{}, tensor.FromSliceComplex128([]complex128{1i}, 1)); err == nil {
+		t.Error("expected an error for a complex tensor")
+	}
+	if _, err := ReadArrowTensor(strings.NewReader("\xff\xff\xff\xff\x00\x00\x00\x00")); err == nil {
+		t.Error("expected an error at the end of the stream")
+	}
+	
+	// Negative and overflowing dimensions are rejected
+	var buf bytes.Buffer
+	if err := WriteArrowTensor(&buf, tensor.Zeros([]int{7, 333}, tensor.Int16)); err != nil {
+		t.Fatal(err)
+	}
+	dim := binary.LittleEndian.AppendUint64(nil, 333)
+	at := bytes.Index(buf.Bytes(), dim)
+	if at < 0 || bytes.LastIndex(buf.Bytes(), dim) != at {
+		t.Fatal("expected the dimension to be encoded once")
+	}
+	for _, d := range []int64{-1, 1 << 62} {
+		patched := bytes.Clone(buf.Bytes())
+		binary.LittleEndian.PutUint64(patched[at:], uint64(d))
+		if _, err := ReadArrowTensor(bytes.NewReader(patched)); err == nil {
+			t.Errorf("dimension %d: expected an error", d)
+		}
+	}
+}
+
+func TestArrowStream(t *testing.T) {
+	flags := tensor.FromSliceFloat64([]float64{1, 0, 1, 1, 0, 0, 0, 0, 1, 1}, 10)
+	in := &Table{
+		Names: []string{"id", "score", "flag", "embedding"},
+		Columns: []*tensor.NDArray{
+			tensor.Zeros([]int{10}, tensor.Int32),
+			tensor.FromSliceFloat32([]float32{0.5, 1, 1.5, 2, 2.5, 3, 3.5, 4, 4.5, 5}, 10),
+			tensor.Zeros([]int{10}, tensor.Bool),
+			tensor.FromSliceInt64(make([]int64, 30), 10, 3),
+		},
+	}
+	for i, v := range flags.ToSliceFloat64() {
+		in.Columns[0].SetInt64(int64(i), i)
+		in.Columns[2].SetFloat64(v, i)
+		in.Columns[3].SetInt64(int64(i), i, 2)
+	}
+	scoreMissing := tensor.Zeros([]int{10}, tensor.Bool)
+	scoreMissing.SetFloat64(1, 3)
+	scoreMissing.SetFloat64(1, 9)
+	in.Missing = []*tensor.NDArray{nil, scoreMissing}
+	
+	var buf bytes.Buffer
+	if err := WriteArrowStream(&buf, in); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}) {
+		t.Error("expected an end-of-stream marker")
+	}
+	
+	// Two batches of the same schema are concatenated
+	stream := buf.Bytes()[:buf.Len()-8]
+	schemaLen := 8 + int(binary.LittleEndian.Uint32(stream[4:]))
+	twice := append(append(append([]byte{}, stream...), stream[schemaLen:]...), 0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0)
+	
+	for copies, data := range [][]byte{buf.Bytes(), twice} {
+		out, err := ReadArrowStream(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(out.Names, ",") != "id,score,flag,embedding" || out.Len() != 10*(copies+1) {
+			t.Fatalf("unexpected table %v with %d rows", out.Names, out.Len())
+		}
+		for j, col := range out.Columns {
+			want := in.Columns[j]
+			if col.DType() != want.DType() || col.Ndim() != want.Ndim() || !bytes.Equal(col.Data()[:len(want.Data())], want.Data()) {
+				t.Errorf("column %s does not round trip", out.Names[j])
+			}
+		}
+		if m := out.Missing[1].ToSliceFloat64(); m[3] != 1 || m[9] != 1 || m[0] != 0 || out.Missing[0].Sum() != 0 {
+			t.Errorf("unexpected missing mask %v", m)
+		}
+	}
+	
+	if err := WriteArrowStream(&buf, &Table{Names: []string{"c"}, Columns: []*tensor.NDArray{tensor.FromSliceComplex128([]complex128{1}, 1)}}); err == nil {
+		t.Error("expected an error for a complex column")
+	}
+}
+
+// thriftField is a field of a Thrift compact struct built for test files.
+// Values are int64 (encoded as the given type), string, bool, a nested
+// []thriftField struct or a thriftItems list.
+type thriftField struct {
+	id  int16
+	typ byte
+	v   any
+}
+
+type thriftItems struct {
+	elem  byte
+	items []any
+}
+
+func encodeThriftStruct(fields []thriftField) []byte {
+	var b []byte
+	last := int16(0)
+	for _, f := range fields {
+		typ := f.typ
+		if typ == thriftTrue && !f.v.(bool) {
+			typ = thriftFalse
+		}
+		if delta := f.id - last; delta > 0 && delta <= 15 {
+			b = append(b, byte(delta)<<4|typ)
+		} else {
+			b = append(b, typ)
+			b = binary.AppendUvarint(b, uint64(f.id)<<1^uint64(f.id>>15))
+		}
+		last = f.id
+		if typ != thriftTrue && typ != thriftFalse {
+			b = append(b, encodeThriftValue(typ, f.v)...)
+		}
+	}
+	return append(b, thriftStop)
+}
+
+func encodeThriftValue(typ byte, v any) []byte {
+	switch typ {
+	case thriftI32, thriftI64:
+		n := v.(int64)
+		return binary.AppendUvarint(nil, uint64(n<<1^n>>63))
+	case thriftBinary:
+		s := v.(string)
+		return append(binary.AppendUvarint(nil, uint64(len(s))), s...)
+	case thriftStructT:
+		return encodeThriftStruct(v.([]thriftField))
+	case thriftList:
+		l := v.(thriftItems)
+		b := []byte{byte(len(l.items))<<4 | l.elem}
+		if len(l.items) >= 15 {
+			b = binary.AppendUvarint([]byte{0xF0 | l.elem}, uint64(len(l.items)))
+		}
+		for _, item := range l.items {
+			b = append(b, encodeThriftValue(l.elem, item)...)
+		}
+		return b
+	}
+	panic("unsupported Thrift type")
+}
+
+// snappyLiteral encodes data as a Snappy block of a single literal
+func snappyLiteral(data []byte) []byte {
+	b := binary.AppendUvarint(nil, uint64(len(data)))
+	if len(data) <= 60 {
+		b = append(b, byte(len(data)-1)<<2)
+	} else {
+		b = append(b, 60<<2, byte(len(data)-1))
+	}
+	return append(b, data...)
+}
+
+func gzipBytes(data []byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	return buf.Bytes()
+}
+
+// parquetPage is a page of a test column chunk: its header fields and
+// stored bytes
+type parquetPage struct {
+	header       []thriftField
+	uncompressed int
+	data         []byte
+}
+
+func dataPageV1(count int, encoding int64, raw []byte) parquetPage {
+	return parquetPage{
+		header: []thriftField{{5, thriftStructT, []thriftField{
+			{1, thriftI32, int64(count)}, {2, thriftI32, encoding}, {3, thriftI32, int64(3)}, {4, thriftI32, int64(3)},
+		}}},
+		uncompressed: len(raw),
+		data:         raw,
+	}
+}
+
+// parquetChunk is a test column chunk with its codec and min/max
+// statistics
+type parquetChunk struct {
+	physical int64
+	codec    int64
+	pages    []parquetPage
+	stats    [2]string
+}
+
+// buildParquet lays out a Parquet file from the column chunks of each row
+// group
+func buildParquet(schema []any, groups [][]parquetChunk, rows []int64) []byte {
+	file := []byte(parquetMagic)
+	var rowGroups []any
+	total := int64(0)
+	for g, chunks := range groups {
+		var columns []any
+		for _, c := range chunks {
+			start := int64(len(file))
+			dictOffset, dataOffset := int64(-1), int64(-1)
+			for _, p := range c.pages {
+				typ := int64(parquetDataPage)
+				for _, f := range p.header {
+					switch f.id {
+					case 7:
+						typ = parquetDictionaryPage
+					case 8:
+						typ = parquetDataPageV2
+					}
+				}
+				if typ == parquetDictionaryPage {
+					dictOffset = int64(len(file))
+				} else if dataOffset < 0 {
+					dataOffset = int64(len(file))
+				}
+				header := append([]thriftField{
+					{1, thriftI32, typ}, {2, thriftI32, int64(p.uncompressed)}, {3, thriftI32, int64(len(p.data))},
+				}, p.header...)
+				file = append(file, encodeThriftStruct(header)...)
+				file = append(file, p.data...)
+			}
+			if dataOffset < 0 {
+				dataOffset = start
+			}
+			meta := []thriftField{
+				{1, thriftI32, c.physical},
+				{2, thriftList, thriftItems{thriftI32, []any{int64(0)}}},
+				{3, thriftList, thriftItems{thriftBinary, []any{"col"}}},
+				{4, thriftI32, c.codec},
+				{5, thriftI64, rows[g]},
+				{6, thriftI64, int64(len(file)) - start},
+				{7, thriftI64, int64(len(file)) - start},
+				{9, thriftI64, dataOffset},
+			}
+			if dictOffset >= 0 {
+				meta = append(meta, thriftField{11, thriftI64, dictOffset})
+			}
+			if c.stats[0] != "" {
+				meta = append(meta, thriftField{12, thriftStructT, []thriftField{
+					{5, thriftBinary, c.stats[1]}, {6, thriftBinary, c.stats[0]},
+				}})
+			}
+			columns = append(columns, []thriftField{{2, thriftI64, start}, {3, thriftStructT, meta}})
+		}
+		rowGroups = append(rowGroups, []thriftField{
+			{1, thriftList, thriftItems{thriftStructT, columns}},
+			{2, thriftI64, int64(0)},
+			{3, thriftI64, rows[g]},
+		})
+		total += rows[g]
+	}
+	
+	footer := encodeThriftStruct([]thriftField{
+		{1, thriftI32, int64(1)},
+		{2, thriftList, thriftItems{thriftStructT, schema}},
+		{3, thriftI64, total},
+		{4, thriftList, thriftItems{thriftStructT, rowGroups}},
+	})
+	file = append(file, footer...)
+	file = binary.LittleEndian.AppendUint32(file, uint32(len(footer)))
+	return append(file, parquetMagic...)
+}
+
+func testParquetFile(corrupt bool) []byte {
+	le64 := func(vs ...int64) []byte {
+		var b []byte
+		for _, v := range vs {
+			b = binary.LittleEndian.AppendUint64(b, uint64(v))
+		}
+		return b
+	}
+	f64 := func(vs ...float64) []byte {
+		var b []byte
+		for _, v := range vs {
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+		}
+		return b
+	}
+	le32 := func(vs ...int32) []byte {
+		var b []byte
+		for _, v := range vs {
+			b = binary.LittleEndian.AppendUint32(b, uint32(v))
+		}
+		return b
+	}
+	
+	schema := []any{
+		[]thriftField{{4, thriftBinary, "schema"}, {5, thriftI32, int64(5)}},
+		[]thriftField{{1, thriftI32, int64(parquetInt64)}, {3, thriftI32, int64(0)}, {4, thriftBinary, "id"}},
+		[]thriftField{{1, thriftI32, int64(parquetDouble)}, {3, thriftI32, int64(1)}, {4, thriftBinary, "x"}},
+		[]thriftField{{1, thriftI32, int64(parquetBoolean)}, {3, thriftI32, int64(0)}, {4, thriftBinary, "flag"}},
+		[]thriftField{{1, thriftI32, int64(parquetInt32)}, {3, thriftI32, int64(0)}, {4, thriftBinary, "small"}, {6, thriftI32, int64(16)}},
+		[]thriftField{{1, thriftI32, int64(6)}, {3, thriftI32, int64(1)}, {4, thriftBinary, "name"}},
+	}
+	
+	// Row group 0: x is Snappy-compressed with nulls at rows 1 and 4, and
+	// small is dictionary encoded
+	xRaw := append(le32(2), 0x03, 0x0D)
+	xRaw = append(xRaw, f64(0.5, 2.5, 3.5)...)
+	xPage := dataPageV1(5, parquetPlain, xRaw)
+	xPage.data = snappyLiteral(xRaw)
+	if corrupt {
+		xPage.data = []byte{0xFF, 0xFF}
+	}
+	dict := parquetPage{
+		header:       []thriftField{{7, thriftStructT, []thriftField{{1, thriftI32, int64(3)}, {2, thriftI32, int64(parquetPlain)}}}},
+		uncompressed: 12,
+		data:         le32(3, -2, 7),
+	}
+	group0 := []parquetChunk{
+		{physical: parquetInt64, pages: []parquetPage{dataPageV1(5, parquetPlain, le64(0, 1, 2, 3, 4))}, stats: [2]string{string(le64(0)), string(le64(4))}},
+		{physical: parquetDouble, codec: 1, pages: []parquetPage{xPage}},
+		{physical: parquetBoolean, pages: []parquetPage{dataPageV1(5, parquetPlain, []byte{0x0D})}},
+		{physical: parquetInt32, pages: []parquetPage{dict, dataPageV1(5, parquetRLEDictionary, []byte{0x02, 0x04, 0x00, 0x03, 0x21, 0x00})}},
+		{physical: 6},
+	}
+	
+	// Row group 1: x is a gzip-compressed version 2 page with a null at
+	// row 5, and small is BYTE_STREAM_SPLIT encoded
+	xValues := f64(6.5, 7.5, 8.5, 9.5)
+	xV2 := parquetPage{
+		header: []thriftField{{8, thriftStructT, []thriftField{
+			{1, thriftI32, int64(5)}, {2, thriftI32, int64(1)}, {3, thriftI32, int64(5)},
+			{4, thriftI32, int64(parquetPlain)}, {5, thriftI32, int64(2)}, {6, thriftI32, int64(0)},
+		}}},
+		uncompressed: 2 + len(xValues),
+		data:         append([]byte{0x03, 0x1E}, gzipBytes(xValues)...),
+	}
+	idPage := dataPageV1(5, parquetPlain, le64(5, 6, 7, 8, 9))
+	idPage.data = snappyLiteral(idPage.data)
+	group1 := []parquetChunk{
+		{physical: parquetInt64, codec: 1, pages: []parquetPage{idPage}, stats: [2]string{string(le64(5)), string(le64(9))}},
+		{physical: parquetDouble, codec: 2, pages: []parquetPage{xV2}},
+		{physical: parquetBoolean, pages: []parquetPage{dataPageV1(5, parquetPlain, []byte{0x18})}},
+		{physical: parquetInt32, pages: []parquetPage{dataPageV1(5, parquetByteStreamSplit, append([]byte{1, 2, 3, 4, 5}, make([]byte, 15)...))}},
+		{physical: 6},
+	}
+	return buildParquet(schema, [][]parquetChunk{group0, group1}, []int64{5, 5})
+}
+
+func TestReadParquet(t *testing.T) {
+	file := testParquetFile(false)
+	read := func(opts ParquetOptions) *Table {
+		t.Helper()
+		tbl, err := ReadParquet(bytes.NewReader(file), int64(len(file)), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tbl
+	}
+	
+	tbl := read(ParquetOptions{})
+	if strings.Join(tbl.Names, ",") != "id,x,flag,small" || tbl.Len() != 10 {
+		t.Fatalf("unexpected table %v with %d rows", tbl.Names, tbl.Len())
+	}
+	if !sliceClose(tbl.Column("id").ToSliceFloat64(), []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, 0) {
+		t.Errorf("unexpected id column %v", tbl.Column("id").ToSliceFloat64())
+	}
+	if !sliceClose(tbl.Column("x").ToSliceFloat64(), []float64{0.5, 0, 2.5, 3.5, 0, 0, 6.5, 7.5, 8.5, 9.5}, 0) {
+		t.Errorf("unexpected x column %v", tbl.Column("x").ToSliceFloat64())
+	}
+	if !sliceClose(tbl.Missing[1].ToSliceFloat64(), []float64{0, 1, 0, 0, 1, 1, 0, 0, 0, 0}, 0) || tbl.Missing[0].Sum() != 0 {
+		t.Errorf("unexpected missing mask %v", tbl.Missing[1].ToSliceFloat64())
+	}
+	if tbl.Column("flag").DType() != tensor.Bool || !sliceClose(tbl.Column("flag").ToSliceFloat64(), []float64{1, 0, 1, 1, 0, 0, 0, 0, 1, 1}, 0) {
+		t.Errorf("unexpected flag column %v", tbl.Column("flag").ToSliceFloat64())
+	}
+	if tbl.Column("small").DType() != tensor.Int16 || !sliceClose(tbl.Column("small").ToSliceFloat64(), []float64{3, 3, -2, 3, 7, 1, 2, 3, 4, 5}, 0) {
+		t.Errorf("unexpected small column %v", tbl.Column("small").ToSliceFloat64())
+	}
+	
+	sub := read(ParquetOptions{Columns: []string{"small", "id"}, RowGroups: []int{1}})
+	if strings.Join(sub.Names, ",") != "small,id" || !sliceClose(sub.Columns[1].ToSliceFloat64(), []float64{5, 6, 7, 8, 9}, 0) {
+		t.Errorf("unexpected row group selection %v %v", sub.Names, sub.Columns[1].ToSliceFloat64())
+	}
+	rng := read(ParquetOptions{Columns: []string{"id"}, FirstRow: 3, NumRows: 4})
+	if !sliceClose(rng.Columns[0].ToSliceFloat64(), []float64{3, 4, 5, 6}, 0) {
+		t.Errorf("unexpected row range %v", rng.Columns[0].ToSliceFloat64())
+	}
+	filtered := read(ParquetOptions{Columns: []string{"id"}, Filters: []ParquetFilter{{Column: "x", Min: 3, Max: 8}}})
+	if !sliceClose(filtered.Columns[0].ToSliceFloat64(), []float64{3, 6, 7}, 0) {
+		t.Errorf("unexpected filtered rows %v", filtered.Columns[0].ToSliceFloat64())
+	}
+	
+	// Statistics let the filter skip the unreadable first row group
+	bad := testParquetFile(true)
+	if _, err := ReadParquet(bytes.NewReader(bad), int64(len(bad)), ParquetOptions{}); err == nil {
+		t.Error("expected an error for a corrupt page")
+	}
+	pushed, err := ReadParquet(bytes.NewReader(bad), int64(len(bad)), ParquetOptions{Filters: []ParquetFilter{{Column: "id", Min: 7, Max: 100}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sliceClose(pushed.Column("id").ToSliceFloat64(), []float64{7, 8, 9}, 0) {
+		t.Errorf("unexpected pushed-down rows %v", pushed.Column("id").ToSliceFloat64())
+	}
+	
+	for _, opts := range []ParquetOptions{{Columns: []string{"missing"}}, {Columns: []string{"name"}}, {RowGroups: []int{2}}} {
+		if _, err := ReadParquet(bytes.NewReader(file), int64(len(file)), opts); err == nil {
+			t.Errorf("expected an error for %+v", opts)
+		}
+	}
+	if _, err := ReadParquet(strings.NewReader("not a parquet file"), 18, ParquetOptions{}); err == nil {
+		t.Error("expected an error for a file without the Parquet magic number")
+	}
+}
+
+func TestSnappyDecode(t *testing.T) {
+	// A literal followed by an overlapping copy
+	got, err := snappyDecode([]byte{12, 0x0C, 'a', 'b', 'c', 'd', 0x11, 0x04})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "abcdabcdabcd" {
+		t.Errorf("expected abcdabcdabcd, got %q", got)
+	}
+	if _, err := snappyDecode([]byte{5, 0x0C, 'a'}); err == nil {
+		t.Error("expected an error for truncated data")
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	// The large array spans several blocks
+	big := make([]float64, 600000)
+	for i := range big {
+		big[i] = float64(i % 1000)
+	}
+	arrays := []*tensor.NDArray{
+		tensor.FromSliceFloat64(big, 600, 1000),
+		tensor.FromSliceInt64([]int64{-3, 1 << 62}, 2),
+		tensor.FromSliceComplex128([]complex128{1 - 1i}, 1, 1),
+		tensor.Zeros([]int{0, 4}, tensor.Float32),
+		tensor.Ones([]int{3}, tensor.Bool),
+	}
+	
+	dir := t.TempDir()
+	for _, opts := range []SnapshotOptions{{}, {Compression: Gzip, Level: 1}} {
+		path := filepath.Join(dir, "ckpt.ngs")
+		if err := SaveWith(path, opts, arrays...); err != nil {
+			t.Fatal(err)
+		}
+		got, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(arrays) {
+			t.Fatalf("%s: expected %d arrays, got %d", opts.Compression, len(arrays), len(got))
+		}
+		for i, want := range arrays {
+			if got[i].DType() != want.DType() || !shapeEqual(got[i].Shape(), want.Shape()) || !bytes.Equal(got[i].Data(), want.Data()) {
+				t.Errorf("%s: array %d does not round trip", opts.Compression, i)
+			}
+		}
+	}
+	
+	// Streaming through a buffer; compression pays off on repetitive data
+	var plain, packed bytes.Buffer
+	for _, c := range []struct {
+		buf  *bytes.Buffer
+		opts SnapshotOptions
+	}{{&plain, SnapshotOptions{}}, {&packed, SnapshotOptions{Compression: Gzip}}} {
+		sw, err := NewSnapshotWriter(c.buf, c.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sw.Write(arrays[0]); err != nil {
+			t.Fatal(err)
+		}
+		if err := sw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if packed.Len() >= plain.Len()/10 {
+		t.Errorf("compressed snapshot is %d bytes, uncompressed %d", packed.Len(), plain.Len())
+	}
+	sr, err := NewSnapshotReader(bytes.NewReader(packed.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, err := sr.Next(); err != nil || a.Size() != len(big) {
+		t.Fatalf("unexpected first array: %v", err)
+	}
+	if _, err := sr.Next(); err != goio.EOF {
+		t.Errorf("expected io.EOF after the last array, got %v", err)
+	}
+	
+	// Corruption and truncation are detected
+	corrupt := append([]byte{}, plain.Bytes()...)
+	corrupt[len(corrupt)/2] ^= 0xFF
+	truncated := plain.Bytes()[:plain.Len()-1]
+	for name, data := range map[string][]byte{"corrupt": corrupt, "truncated": truncated} {
+		sr, err := NewSnapshotReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sr.Next(); err == nil {
+			if _, err := sr.Next(); err == nil || err == goio.EOF {
+				t.Errorf("expected an error for the %s snapshot", name)
+			}
+		}
+	}
+	if _, err := NewSnapshotReader(strings.NewReader("NOTASNAPSHOT")); err == nil {
+		t.Error("expected an error for a bad magic string")
+	}
+	
+	// Dimensions whose product overflows are rejected, even when each one
+	// is small enough on its own
+	var empty bytes.Buffer
+	sw, err := NewSnapshotWriter(&empty, SnapshotOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.Write(tensor.Zeros([]int{0, 1, 1}, tensor.Float64)); err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var dims []byte
+	for _, d := range []uint64{0, 1, 1} {
+		dims = binary.LittleEndian.AppendUint64(dims, d)
+	}
+	at := bytes.Index(empty.Bytes(), dims)
+	if at < 0 {
+		t.Fatal("expected the shape in the snapshot")
+	}
+	huge := bytes.Clone(empty.Bytes())
+	for i, d := range []uint64{1 << 24, 1 << 24, 1 << 16} {
+		binary.LittleEndian.PutUint64(huge[at+8*i:], d)
+	}
+	if sr, err = NewSnapshotReader(bytes.NewReader(huge)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sr.Next(); err == nil {
+		t.Error("expected an error for an overflowing shape")
+	}
+}
+
+func TestImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(10 * i)
+	}
+	a := FromImage(img)
+	if a.DType() != tensor.Uint8 || !shapeEqual(a.Shape(), []int{2, 3, 4}) {
+		t.Fatalf("expected Uint8 (2, 3, 4), got %s %v", a.DType(), a.Shape())
+	}
+	if !bytes.Equal(a.Data(), img.Pix) {
+		t.Errorf("expected %v, got %v", img.Pix, a.Data())
+	}
+	if got := FromImageFloat(img).GetFloat64(1, 2, 3); math.Abs(got-230.0/255) > 1e-6 {
+		t.Errorf("expected %f, got %f", 230.0/255, got)
+	}
+	
+	path := filepath.Join(t.TempDir(), "a.png")
+	if err := SaveImage(path, a); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadImage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Data(), a.Data()) {
+		t.Errorf("PNG round trip: expected %v, got %v", a.Data(), got.Data())
+	}
+	
+	gray := tensor.FromSliceFloat64([]float64{0, 0.5, 1, 2}, 2, 2)
+	var buf bytes.Buffer
+	if err := WriteImage(&buf, gray, ImageOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ReadImage(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0, 128, 255, 255}; !shapeEqual(got.Shape(), []int{2, 2, 1}) || !bytes.Equal(got.Data(), want) {
+		t.Errorf("expected %v with shape (2, 2, 1), got %v %v", want, got.Data(), got.Shape())
+	}
+	
+	rgb := tensor.Zeros([]int{8, 8, 3}, tensor.Uint8)
+	buf.Reset()
+	if err := WriteImage(&buf, rgb, ImageOptions{Format: "jpeg", Quality: 90}); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ReadImage(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shapeEqual(got.Shape(), []int{8, 8, 3}) {
+		t.Errorf("expected a JPEG to load as (8, 8, 3), got %v", got.Shape())
+	}
+	
+	if err := SaveImage(filepath.Join(t.TempDir(), "a.bmp"), a); err == nil {
+		t.Error("expected an error for an unknown extension")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a 2-channel array")
+		}
+	}()
+	ToImage(tensor.Zeros([]int{2, 2, 2}, tensor.Uint8))
+}
+
+func TestWAV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.wav")
+	stereo := tensor.Zeros([]int{3, 2}, tensor.Int16)
+	for i, v := range []int64{0, 1, -32768, 32767, 5, -5} {
+		stereo.SetInt64(v, i/2, i%2)
+	}
+	if err := SaveWAV(path, stereo, 44100); err != nil {
+		t.Fatal(err)
+	}
+	got, rate, err := LoadWAV(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate != 44100 || got.DType() != tensor.Int16 || !bytes.Equal(got.Data(), stereo.Data()) {
+		t.Errorf("expected %v at 44100 Hz, got %s %v at %d Hz", stereo.ToSliceFloat64(), got.DType(), got.ToSliceFloat64(), rate)
+	}
+	
+	mono := tensor.FromSliceFloat64([]float64{0.25, -0.5, 1}, 3)
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, mono, 8000); err != nil {
+		t.Fatal(err)
+	}
+	got, _, err = ReadWAV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shapeEqual(got.Shape(), []int{3, 1}) || !sliceClose(got.ToSliceFloat64(), mono.ToSliceFloat64(), 0) {
+		t.Errorf("expected %v with shape (3, 1), got %v %v", mono.ToSliceFloat64(), got.ToSliceFloat64(), got.Shape())
+	}
+	
+	// 24-bit WAVE_FORMAT_EXTENSIBLE with a LIST chunk before the data
+	fmtChunk := make([]byte, 40)
+	binary.LittleEndian.PutUint16(fmtChunk, 0xfffe)
+	binary.LittleEndian.PutUint16(fmtChunk[2:], 1)
+	binary.LittleEndian.PutUint32(fmtChunk[4:], 16000)
+	binary.LittleEndian.PutUint16(fmtChunk[14:], 24)
+	binary.LittleEndian.PutUint16(fmtChunk[24:], 1)
+	var raw bytes.Buffer
+	raw.WriteString("RIFF\x00\x00\x00\x00WAVE")
+	chunk := func(id string, body []byte) {
+		raw.WriteString(id)
+		binary.Write(&raw, binary.LittleEndian, uint32(len(body)))
+		raw.Write(body)
+		if len(body)%2 == 1 {
+			raw.WriteByte(0)
+		}
+	}
+	chunk("fmt ", fmtChunk)
+	chunk("LIST", []byte("INFOx"))
+	chunk("data", []byte{0x01, 0x00, 0x00, 0xff, 0xff, 0xff})
+	got, rate, err = ReadWAV(&raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate != 16000 || got.DType() != tensor.Int32 || got.GetInt64(0, 0) != 256 || got.GetInt64(1, 0) != -256 {
+		t.Errorf("expected [256 -256] at 16000 Hz, got %v at %d Hz", got.ToSliceFloat64(), rate)
+	}
+	
+	if _, _, err := ReadWAV(strings.NewReader("RIFF\x00\x00\x00\x00AVI ")); err == nil {
+		t.Error("expected an error for a non-WAV file")
+	}
+	if err := WriteWAV(&buf, tensor.Zeros([]int{2}, tensor.Int64), 8000); err == nil {
+		t.Error("expected an error for Int64 samples")
+	}
+}
+
+func TestONNXTensor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "w.pb")
+	a := tensor.FromSliceFloat64([]float64{1, -2, 3.5, 4, 5, 6}, 2, 3)
+	if err := SaveONNXTensor(path, a, "weight"); err != nil {
+		t.Fatal(err)
+	}
+	got, name, err := LoadONNXTensor(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "weight" || got.DType() != tensor.Float64 || !shapeEqual(got.Shape(), []int{2, 3}) || !bytes.Equal(got.Data(), a.Data()) {
+		t.Errorf("round trip gave %q %s %v %v", name, got.DType(), got.Shape(), got.ToSliceFloat64())
+	}
+	
+	// dims [2], INT64, packed int64_data [-1, 300], name "x"
+	msg := []byte{0x08, 0x02, 0x10, 0x07, 0x3a, 0x0c,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0xac, 0x02,
+		0x42, 0x01, 'x'}
+	got, name, err = ReadONNXTensor(bytes.NewReader(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "x" || got.DType() != tensor.Int64 || got.GetInt64(0) != -1 || got.GetInt64(1) != 300 {
+		t.Errorf("expected int64 [-1 300] named x, got %q %s %v", name, got.DType(), got.ToSliceInt64())
+	}
+	
+	// A FLOAT scalar with unpacked float_data, and INT8 widened to int32
+	msg = []byte{0x10, 0x01, 0x25, 0x00, 0x00, 0xc0, 0x3f}
+	if got, _, err = ReadONNXTensor(bytes.NewReader(msg)); err != nil || !shapeEqual(got.Shape(), []int{1}) || got.GetFloat64(0) != 1.5 {
+		t.Errorf("expected scalar 1.5, got %v (%v)", got, err)
+	}
+	msg = []byte{0x08, 0x02, 0x10, 0x03, 0x2a, 0x0b, 0x05, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}
+	if got, _, err = ReadONNXTensor(bytes.NewReader(msg)); err != nil || got.DType() != tensor.Int8 || got.GetInt64(0) != 5 || got.GetInt64(1) != -2 {
+		t.Errorf("expected int8 [5 -2], got %v (%v)", got, err)
+	}
+	
+	for _, bad := range [][]byte{
+		{0x10, 0x0a},                   // FLOAT16
+		{0x08, 0x02, 0x10, 0x01, 0x25}, // truncated
+		{0x08, 0x03, 0x10, 0x02, 0x4a, 0x02, 0x01, 0x02}, // short raw_data
+		{0x10, 0x01, 0x70, 0x01},                         // external data
+		// dims [2^30 2^30 16] whose size wraps to match empty raw_data
+		{0x08, 0x80, 0x80, 0x80, 0x80, 0x04, 0x08, 0x80, 0x80, 0x80, 0x80, 0x04, 0x08, 0x10, 0x10, 0x01, 0x4a, 0x00},
+	} {
+		if _, _, err := ReadONNXTensor(bytes.NewReader(bad)); err == nil {
+			t.Errorf("expected an error for % x", bad)
+		}
+	}
+}
+
+func TestTensorProto(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3, 4, 5, 6}, 3, 2)
+	msg := ToProto(a)
+	// dtype FLOAT64 (11), packed shape [3 2], then the raw data
+	if !bytes.Equal(msg[:8], []byte{0x08, 0x0b, 0x12, 0x02, 0x03, 0x02, 0x1a, 0x30}) {
+		t.Errorf("unexpected message header % x", msg[:8])
+	}
+	got, err := FromProto(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shapeEqual(got.Shape(), []int{3, 2}) || !bytes.Equal(got.Data(), a.Data()) {
+		t.Errorf("round trip gave %v %v", got.Shape(), got.ToSliceFloat64())
+	}
+	
+	zeros := tensor.Zeros([]int{1000}, tensor.Int32)
+	msg, err = ToProtoWith(zeros, ProtoOptions{Compression: Gzip})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msg) > 200 {
+		t.Errorf("expected compression, got %d bytes", len(msg))
+	}
+	if got, err = FromProto(msg); err != nil || got.DType() != tensor.Int32 || !bytes.Equal(got.Data(), zeros.Data()) {
+		t.Errorf("compressed round trip failed: %v", err)
+	}
+	
+	// An empty shape is a scalar
+	if got, err = FromProto([]byte{0x08, 0x06, 0x1a, 0x01, 0x07}); err != nil || !shapeEqual(got.Shape(), []int{1}) || got.GetInt64(0) != 7 {
+		t.Errorf("expected uint8 scalar 7, got %v (%v)", got, err)
+	}
+	
+	for _, bad := range [][]byte{
+		{0x12, 0x01, 0x02},                         // no dtype
+		{0x08, 0x0b, 0x12, 0x01, 0x02},             // missing data
+		{0x08, 0x06, 0x1a, 0x01, 0x07, 0x20, 0x01}, // not gzip data
+		{0x08, 0x06, 0x1a, 0x01, 0x07, 0x20, 0x09}, // unknown compression
+	} {
+		if _, err := FromProto(bad); err == nil {
+			t.Errorf("expected an error for % x", bad)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package io
+
+import (
+	"fmt"
+	"os"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// MappedNPY is a .npy file mapped into memory by LoadNPYMmap. Array shares
+// its buffer with the mapping, so elements are paged in from the file only
+// as they are touched.
+type MappedNPY struct {
+	// Array views the file's data; it must not be used after Close
+	Array *tensor.NDArray
+	
+	release func() error
+}
+
+// LoadNPYMmap maps the .npy file at path into memory instead of reading it,
+// like NumPy's np.load(path, mmap_mode="r") or mmap_mode="r+". A read-only
+// mapping returns a frozen array; otherwise writes to the array go straight
+// to the file. The file must hold little-endian, C-ordered data. On
+// platforms without mmap the data is read into memory and, for writable
+// mappings, written back by Close.
+func LoadNPYMmap(path string, readonly bool) (*MappedNPY, error) {
+	flag := os.O_RDWR
+	if readonly {
+		flag = os.O_RDONLY
+	}
+	f, err := os.OpenFile(path, flag, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	
+	h, err := readNPYHeader(f)
+	if err != nil {
+		return nil, err
+	}
+	if h.swap {
+		return nil, fmt.Errorf("cannot map big-endian .npy data of type %s", h.dtype)
+	}
+	if h.fortran && len(h.shape) > 1 {
+		return nil, fmt.Errorf("cannot map Fortran-ordered .npy data")
+	}
+	
+	n := h.nbytes
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size()-int64(h.offset) < int64(n) {
+		return nil, fmt.Errorf("reading .npy data: file holds %d bytes of data, need %d", info.Size()-int64(h.offset), n)
+	}
+	
+	mapping, release, err := mmapFile(f, h.offset+n, !readonly)
+	if err != nil {
+		return nil, fmt.Errorf("mapping %s: %w", path, err)
+	}
+	if len(mapping)-h.offset != n {
+		release()
+		return nil, fmt.Errorf("mapping %s: mapped %d bytes, need %d", path, len(mapping), h.offset+n)
+	}
+	arr := tensor.WrapBytes(mapping[h.offset:h.offset+n], h.dtype, h.shape...)
+	if readonly {
+		arr.Freeze()
+	}
+	return &MappedNPY{Array: arr, release: release}, nil
+}
+
+// Close unmaps the file. Calling Close more than once has no effect.
+func (m *MappedNPY) Close() error {
+	if m.release == nil {
+		return nil
+	}
+	release := m.release
+	m.release = nil
+	return release()
+}
@@ -0,0 +1,31 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package io
+
+import (
+	"os"
+)
+
+// mmapFile emulates a mapping by reading the first length bytes of f. For
+// writable mappings the returned function writes the buffer back.
+func mmapFile(f *os.File, length int, writable bool) ([]byte, func() error, error) {
+	data := make([]byte, length)
+	if _, err := f.ReadAt(data, 0); err != nil {
+		return nil, nil, err
+	}
+	name := f.Name()
+	return data, func() error {
+		if !writable {
+			return nil
+		}
+		g, err := os.OpenFile(name, os.O_WRONLY, 0)
+		if err != nil {
+			return err
+		}
+		if _, err := g.WriteAt(data, 0); err != nil {
+			g.Close()
+			return err
+		}
+		return g.Close()
+	}, nil
+}
@@ -0,0 +1,22 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package io
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile maps the first length bytes of f as a shared mapping, so writes
+// reach the file. The returned function unmaps it.
+func mmapFile(f *os.File, length int, writable bool) ([]byte, func() error, error) {
+	prot := syscall.PROT_READ
+	if writable {
+		prot |= syscall.PROT_WRITE
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, length, prot, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,409 @@
+// Package io provides reading and writing of NumGo arrays in NumPy and
+// other file formats
+package io
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	goio "io"
+	"math"
+	"os"
+	"strings"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// npyMagic starts every .npy file
+const npyMagic = "\x93NUMPY"
+
+// npyDescr maps dtypes to the NumPy type strings written to .npy headers
+var npyDescr = map[tensor.DType]string{
+	tensor.Bool:       "|b1",
+	tensor.Int8:       "|i1",
+	tensor.Int16:      "<i2",
+	tensor.Int32:      "<i4",
+	tensor.Int64:      "<i8",
+	tensor.Uint8:      "|u1",
+	tensor.Uint16:     "<u2",
+	tensor.Uint32:     "<u4",
+	tensor.Uint64:     "<u8",
+	tensor.Float32:    "<f4",
+	tensor.Float64:    "<f8",
+	tensor.Complex64:  "<c8",
+	tensor.Complex128: "<c16",
+}
+
+// NPYOptions controls how arrays are written to .npy files. The zero value
+// writes little-endian, C-ordered data.
+type NPYOptions struct {
+	// FortranOrder stores the data in column-major order
+	FortranOrder bool
+	
+	// ByteOrder is the byte order of the stored elements; nil selects
+	// little-endian. binary.BigEndian writes types such as ">f8".
+	ByteOrder binary.ByteOrder
+}
+
+// SaveNPY writes a to the file at path in NumPy .npy format
+func SaveNPY(path string, a *tensor.NDArray) error {
+	return SaveNPYWith(path, a, NPYOptions{})
+}
+
+// SaveNPYWith is SaveNPY with control over the data layout and byte order
+func SaveNPYWith(path string, a *tensor.NDArray, opts NPYOptions) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteNPYWith(f, a, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteNPY writes a to w in NumPy .npy format
+func WriteNPY(w goio.Writer, a *tensor.NDArray) error {
+	return WriteNPYWith(w, a, NPYOptions{})
+}
+
+// WriteNPYWith is WriteNPY with control over the data layout and byte
+// order. A version 1.0 header is written unless the header needs the
+// larger length field of version 2.0.
+func WriteNPYWith(w goio.Writer, a *tensor.NDArray, opts NPYOptions) error {
+	descr, ok := npyDescr[a.DType()]
+	if !ok {
+		return fmt.Errorf("dtype %s cannot be stored in .npy format", a.DType())
+	}
+	byteOrder := opts.ByteOrder
+	if byteOrder == nil {
+		byteOrder = binary.LittleEndian
+	}
+	if byteOrder.Uint16([]byte{0, 1}) == 1 && descr[0] == '<' {
+		descr = ">" + descr[1:]
+	}
+	shape := a.Shape()
+	
+	dims := make([]string, len(shape))
+	for i, s := range shape {
+		dims[i] = fmt.Sprint(s)
+	}
+	tuple := "(" + strings.Join(dims, ", ") + ")"
+	if len(shape) == 1 {
+		tuple = "(" + dims[0] + ",)"
+	}
+	order := "False"
+	if opts.FortranOrder {
+		order = "True"
+	}
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': %s, 'shape': %s, }", descr, order, tuple)
+	
+	// Pad with spaces and a newline so the data starts on a 64-byte
+	// boundary
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	prefix := len(npyMagic) + 4
+	if len(header)+1+prefix > 65535 {
+		prefix += 2
+	}
+	total := (prefix + len(header) + 1 + 63) / 64 * 64
+	header += strings.Repeat(" ", total-prefix-len(header)-1) + "\n"
+	if prefix == len(npyMagic)+4 {
+		buf.Write([]byte{1, 0})
+		binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	} else {
+		buf.Write([]byte{2, 0})
+		binary.Write(&buf, binary.LittleEndian, uint32(len(header)))
+	}
+	buf.WriteString(header)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	
+	data := a.DataOrder(byteOrder)
+	if opts.FortranOrder && len(shape) > 1 {
+		data = a.Transpose().DataOrder(byteOrder)
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// LoadNPY reads an array from the .npy file at path
+func LoadNPY(path string) (*tensor.NDArray, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadNPY(f)
+}
+
+// ReadNPY reads an array in NumPy .npy format from r. Header versions 1.0
+// to 3.0, both byte orders and C and Fortran order are supported; the
+// result is always a C-ordered array in native byte order. A NumPy scalar
+// (shape ()) is returned as a one-element 1D array.
+func ReadNPY(r goio.Reader) (*tensor.NDArray, error) {
+	h, err := readNPYHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Read rather than allocate up front so a header claiming more data
+	// than the stream holds fails without a huge allocation
+	data, err := goio.ReadAll(goio.LimitReader(r, int64(h.nbytes)))
+	if err != nil {
+		return nil, fmt.Errorf("reading .npy data: %w", err)
+	}
+	if len(data) != h.nbytes {
+		return nil, fmt.Errorf("reading .npy data: got %d bytes, need %d", len(data), h.nbytes)
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if h.swap {
+		order = binary.BigEndian
+	}
+	
+	if h.fortran && len(h.shape) > 1 {
+		reversed := make([]int, len(h.shape))
+		for i, s := range h.shape {
+			reversed[len(h.shape)-1-i] = s
+		}
+		return tensor.FromBytesOrder(data, h.dtype, order, reversed...).Transpose(), nil
+	}
+	return tensor.FromBytesOrder(data, h.dtype, order, h.shape...), nil
+}
+
+// npyHeader is the decoded header of a .npy file
+type npyHeader struct {
+	dtype   tensor.DType
+	swap    bool
+	fortran bool
+	shape   []int
+	offset  int // byte offset of the array data from the start of the file
+	nbytes  int // length of the array data in bytes
+}
+
+// readNPYHeader reads the magic string and header of a .npy file, leaving r
+// positioned at the start of the array data
+func readNPYHeader(r goio.Reader) (npyHeader, error) {
+	var h npyHeader
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := goio.ReadFull(r, prefix); err != nil {
+		return h, fmt.Errorf("reading .npy magic string: %w", err)
+	}
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return h, fmt.Errorf("not a .npy file: bad magic string %q", prefix[:len(npyMagic)])
+	}
+	
+	var headerLen int
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return h, fmt.Errorf("reading .npy header length: %w", err)
+		}
+		headerLen = int(n)
+		h.offset = len(prefix) + 2 + headerLen
+	case 2, 3:
+		var n uint32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return h, fmt.Errorf("reading .npy header length: %w", err)
+		}
+		headerLen = int(n)
+		h.offset = len(prefix) + 4 + headerLen
+	default:
+		return h, fmt.Errorf("unsupported .npy format version %d.%d", major, prefix[len(npyMagic)+1])
+	}
+	header := make([]byte, headerLen)
+	if _, err := goio.ReadFull(r, header); err != nil {
+		return h, fmt.Errorf("reading .npy header: %w", err)
+	}
+	
+	descr, fortran, shape, err := parseNPYHeader(string(header))
+	if err != nil {
+		return h, err
+	}
+	dtype, swap, err := parseDescr(descr)
+	if err != nil {
+		return h, err
+	}
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	itemsize := dtype.ItemSize()
+	size := 1
+	for _, d := range shape {
+		if d > 0 && size > math.MaxInt/itemsize/d {
+			return h, fmt.Errorf(".npy shape %v is too large", shape)
+		}
+		size *= d
+	}
+	h.dtype, h.swap, h.fortran, h.shape = dtype, swap, fortran, shape
+	h.nbytes = size * itemsize
+	return h, nil
+}
+
+// parseDescr converts a NumPy type string such as "<f8" to a dtype,
+// reporting whether the data is big-endian and must be byte-swapped
+func parseDescr(descr string) (tensor.DType, bool, error) {
+	if len(descr) < 3 {
+		return 0, false, fmt.Errorf("unsupported .npy dtype %q", descr)
+	}
+	swap := false
+	switch descr[0] {
+	case '<', '|', '=':
+	case '>':
+		swap = true
+	default:
+		return 0, false, fmt.Errorf("unsupported .npy dtype %q", descr)
+	}
+	for dtype, d := range npyDescr {
+		if d[1:] == descr[1:] {
+			return dtype, swap && dtype.ItemSize() > 1, nil
+		}
+	}
+	return 0, false, fmt.Errorf("unsupported .npy dtype %q", descr)
+}
+
+// parseNPYHeader extracts the fields of a .npy header, a Python dict
+// literal such as {'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }
+func parseNPYHeader(header string) (descr string, fortran bool, shape []int, err error) {
+	p := &literalParser{s: strings.TrimSpace(header)}
+	fields, err := p.dict()
+	if err != nil {
+		return "", false, nil, fmt.Errorf("invalid .npy header %q: %w", header, err)
+	}
+	
+	var ok bool
+	if descr, ok = fields["descr"].(string); !ok {
+		return "", false, nil, fmt.Errorf("unsupported .npy header %q: descr must be a simple type string", header)
+	}
+	if fortran, ok = fields["fortran_order"].(bool); !ok {
+		return "", false, nil, fmt.Errorf("invalid .npy header %q: missing fortran_order", header)
+	}
+	if shape, ok = fields["shape"].([]int); !ok {
+		return "", false, nil, fmt.Errorf("invalid .npy header %q: missing shape", header)
+	}
+	return descr, fortran, shape, nil
+}
+
+// literalParser parses the subset of Python literals found in .npy
+// headers: a dict with string keys whose values are strings, booleans or
+// tuples of integers
+type literalParser struct {
+	s   string
+	pos int
+}
+
+func (p *literalParser) skipSpace() {
+	for p.pos < len(p.s) && strings.ContainsRune(" \t\r\n", rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips spaces and the byte c, reporting whether it was present
+func (p *literalParser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *literalParser) dict() (map[string]any, error) {
+	if !p.consume('{') {
+		return nil, fmt.Errorf("expected '{'")
+	}
+	fields := make(map[string]any)
+	for !p.consume('}') {
+		key, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(':') {
+			return nil, fmt.Errorf("expected ':' after %q", key)
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = value
+		if !p.consume(',') {
+			if !p.consume('}') {
+				return nil, fmt.Errorf("expected ',' or '}' after %q", key)
+			}
+			break
+		}
+	}
+	return fields, nil
+}
+
+func (p *literalParser) str() (string, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) || (p.s[p.pos] != '\'' && p.s[p.pos] != '"') {
+		return "", fmt.Errorf("expected a string at offset %d", p.pos)
+	}
+	quote := p.s[p.pos]
+	end := strings.IndexByte(p.s[p.pos+1:], quote)
+	if end < 0 {
+		return "", fmt.Errorf("unterminated string at offset %d", p.pos)
+	}
+	value := p.s[p.pos+1 : p.pos+1+end]
+	p.pos += end + 2
+	return value, nil
+}
+
+func (p *literalParser) value() (any, error) {
+	p.skipSpace()
+	rest := p.s[p.pos:]
+	switch {
+	case strings.HasPrefix(rest, "True"):
+		p.pos += 4
+		return true, nil
+	case strings.HasPrefix(rest, "False"):
+		p.pos += 5
+		return false, nil
+	case strings.HasPrefix(rest, "("):
+		return p.tuple()
+	case strings.HasPrefix(rest, "'"), strings.HasPrefix(rest, "\""):
+		return p.str()
+	default:
+		return nil, fmt.Errorf("unsupported value at offset %d", p.pos)
+	}
+}
+
+func (p *literalParser) tuple() ([]int, error) {
+	p.consume('(')
+	values := []int{}
+	for !p.consume(')') {
+		p.skipSpace()
+		start := p.pos
+		for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+		if p.pos == start {
+			return nil, fmt.Errorf("expected an integer at offset %d", p.pos)
+		}
+		n := 0
+		for _, c := range p.s[start:p.pos] {
+			d := int(c - '0')
+			if n > (math.MaxInt-d)/10 {
+				return nil, fmt.Errorf("integer at offset %d is too large", start)
+			}
+			n = n*10 + d
+		}
+		p.skipSpace()
+		if p.pos < len(p.s) && p.s[p.pos] == 'L' {
+			p.pos++
+		}
+		values = append(values, n)
+		if !p.consume(',') {
+			if !p.consume(')') {
+				return nil, fmt.Errorf("expected ',' or ')' at offset %d", p.pos)
+			}
+			break
+		}
+	}
+	return values, nil
+}
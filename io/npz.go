@@ -0,0 +1,101 @@
+package io
+
+import (
+	"archive/zip"
+	"fmt"
+	goio "io"
+	"os"
+	"sort"
+	"strings"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// NPZOptions controls how archives are written by SaveNPZWith. The zero
+// value stores the arrays uncompressed, like numpy.savez.
+type NPZOptions struct {
+	// Compress deflates each entry, like numpy.savez_compressed
+	Compress bool
+}
+
+// SaveNPZ writes arrays to the file at path as a NumPy .npz archive, with
+// one .npy entry per map key
+func SaveNPZ(path string, arrays map[string]*tensor.NDArray) error {
+	return SaveNPZWith(path, arrays, NPZOptions{})
+}
+
+// SaveNPZWith is SaveNPZ with optional compression
+func SaveNPZWith(path string, arrays map[string]*tensor.NDArray, opts NPZOptions) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteNPZ(f, arrays, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteNPZ writes arrays to w as a NumPy .npz archive. Entries are written
+// in sorted key order so that the output is deterministic.
+func WriteNPZ(w goio.Writer, arrays map[string]*tensor.NDArray, opts NPZOptions) error {
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	
+	method := zip.Store
+	if opts.Compress {
+		method = zip.Deflate
+	}
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		entry, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: method})
+		if err != nil {
+			return err
+		}
+		if err := WriteNPY(entry, arrays[name]); err != nil {
+			return fmt.Errorf("writing %q: %w", name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// LoadNPZ reads every array of the .npz archive at path, keyed by entry
+// name without the .npy extension
+func LoadNPZ(path string) (map[string]*tensor.NDArray, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return readNPZ(&zr.Reader)
+}
+
+// ReadNPZ reads every array of a .npz archive of the given size from r
+func ReadNPZ(r goio.ReaderAt, size int64) (map[string]*tensor.NDArray, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return readNPZ(zr)
+}
+
+func readNPZ(zr *zip.Reader) (map[string]*tensor.NDArray, error) {
+	arrays := make(map[string]*tensor.NDArray, len(zr.File))
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		a, err := ReadNPY(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %w", file.Name, err)
+		}
+		arrays[strings.TrimSuffix(file.Name, ".npy")] = a
+	}
+	return arrays, nil
+}
@@ -0,0 +1,227 @@
+package io
+
+import (
+	"encoding/binary"
+	"fmt"
+	goio "io"
+	"math"
+	"os"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// TensorProto field numbers
+const (
+	onnxDims         = 1
+	onnxDataType     = 2
+	onnxSegment      = 3
+	onnxFloatData    = 4
+	onnxInt32Data    = 5
+	onnxInt64Data    = 7
+	onnxName         = 8
+	onnxRawData      = 9
+	onnxDoubleData   = 10
+	onnxUint64Data   = 11
+	onnxDataLocation = 14
+)
+
+// onnxTypes maps dtypes to TensorProto.DataType values
+var onnxTypes = map[tensor.DType]uint64{
+	tensor.Float32:    1,
+	tensor.Uint8:      2,
+	tensor.Int8:       3,
+	tensor.Uint16:     4,
+	tensor.Int16:      5,
+	tensor.Int32:      6,
+	tensor.Int64:      7,
+	tensor.Bool:       9,
+	tensor.Float64:    11,
+	tensor.Uint32:     12,
+	tensor.Uint64:     13,
+	tensor.Complex64:  14,
+	tensor.Complex128: 15,
+}
+
+// onnxTypeNames names the TensorProto data types NumGo has no dtype for
+var onnxTypeNames = map[uint64]string{
+	0:  "UNDEFINED",
+	8:  "STRING",
+	10: "FLOAT16",
+	16: "BFLOAT16",
+}
+
+// LoadONNXTensor reads a serialized ONNX TensorProto from the file at path,
+// such as the input_0.pb files of the ONNX test data. It returns the array
+// and the tensor name.
+func LoadONNXTensor(path string) (*tensor.NDArray, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+	return ReadONNXTensor(f)
+}
+
+// ReadONNXTensor reads a serialized ONNX TensorProto from r. Data may be in
+// raw_data or in the typed fields (float_data, int32_data and so on).
+// Tensors with external data or segments and the string and 16-bit float
+// types are not supported. A scalar (no dims) is returned as a one-element
+// 1D array.
+func ReadONNXTensor(r goio.Reader) (*tensor.NDArray, string, error) {
+	buf, err := goio.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+	return decodeONNXTensor(buf)
+}
+
+// decodeONNXTensor decodes a TensorProto message
+func decodeONNXTensor(buf []byte) (*tensor.NDArray, string, error) {
+	m, err := decodeProto(buf)
+	if err != nil {
+		return nil, "", fmt.Errorf("decoding ONNX tensor: %w", err)
+	}
+	name := string(m.bytes(onnxName))
+	if m.uint(onnxDataLocation) != 0 {
+		return nil, "", fmt.Errorf("ONNX tensor %q uses external data", name)
+	}
+	if len(m[onnxSegment]) > 0 {
+		return nil, "", fmt.Errorf("ONNX tensor %q is segmented", name)
+	}
+	
+	code := m.uint(onnxDataType)
+	dtype, ok := tensor.DType(0), false
+	for dt, c := range onnxTypes {
+		if c == code {
+			dtype, ok = dt, true
+		}
+	}
+	if !ok {
+		if n, known := onnxTypeNames[code]; known {
+			return nil, "", fmt.Errorf("unsupported ONNX data type %s", n)
+		}
+		return nil, "", fmt.Errorf("unknown ONNX data type %d", code)
+	}
+	
+	dims, err := m.repeated(onnxDims, protoVarint)
+	if err != nil {
+		return nil, "", err
+	}
+	shape := make([]int, len(dims))
+	size := 1
+	for i, d := range dims {
+		if int64(d) < 0 {
+			return nil, "", fmt.Errorf("invalid ONNX tensor dimension %d", int64(d))
+		}
+		// Protobuf messages are limited to 2 GiB, which bounds the size too
+		if d > math.MaxInt32 || d > 0 && size > math.MaxInt32/int(d) {
+			return nil, "", fmt.Errorf("ONNX tensor shape %v is too large", dims)
+		}
+		shape[i] = int(d)
+		size *= shape[i]
+	}
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	
+	itemsize := dtype.ItemSize()
+	data := m.bytes(onnxRawData)
+	if data == nil {
+		if data, err = onnxTypedData(m, dtype, size); err != nil {
+			return nil, "", err
+		}
+	}
+	if len(data) != size*itemsize {
+		return nil, "", fmt.Errorf("ONNX tensor %q has %d bytes of data for shape %v", name, len(data), dims)
+	}
+	return tensor.FromBytes(data, dtype, shape...), name, nil
+}
+
+// onnxTypedData packs the values of the typed data field for dtype into a
+// little-endian buffer
+func onnxTypedData(m protoMessage, dtype tensor.DType, size int) ([]byte, error) {
+	var field, wire int
+	switch dtype {
+	case tensor.Float32, tensor.Complex64:
+		field, wire = onnxFloatData, protoFixed32
+	case tensor.Float64, tensor.Complex128:
+		field, wire = onnxDoubleData, protoFixed64
+	case tensor.Int64:
+		field, wire = onnxInt64Data, protoVarint
+	case tensor.Uint32, tensor.Uint64:
+		field, wire = onnxUint64Data, protoVarint
+	default:
+		// Narrower integers and booleans are widened to int32
+		field, wire = onnxInt32Data, protoVarint
+	}
+	values, err := m.repeated(field, wire)
+	if err != nil {
+		return nil, err
+	}
+	
+	itemsize := dtype.ItemSize()
+	if dtype.IsComplex() {
+		// The real and imaginary parts are stored as separate values
+		itemsize /= 2
+	}
+	if len(values)*itemsize != size*dtype.ItemSize() {
+		return nil, fmt.Errorf("ONNX tensor has %d values for %d elements of %s", len(values), size, dtype)
+	}
+	data := make([]byte, len(values)*itemsize)
+	for i, v := range values {
+		switch itemsize {
+		case 1:
+			data[i] = byte(v)
+		case 2:
+			binary.LittleEndian.PutUint16(data[2*i:], uint16(v))
+		case 4:
+			binary.LittleEndian.PutUint32(data[4*i:], uint32(v))
+		case 8:
+			binary.LittleEndian.PutUint64(data[8*i:], v)
+		}
+	}
+	return data, nil
+}
+
+// SaveONNXTensor writes a to the file at path as a serialized ONNX
+// TensorProto with the given name
+func SaveONNXTensor(path string, a *tensor.NDArray, name string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteONNXTensor(f, a, name); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteONNXTensor writes a to w as a serialized ONNX TensorProto with the
+// given name. The data is stored in raw_data, as ONNX recommends.
+func WriteONNXTensor(w goio.Writer, a *tensor.NDArray, name string) error {
+	buf, err := encodeONNXTensor(a, name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// encodeONNXTensor encodes a as a TensorProto message
+func encodeONNXTensor(a *tensor.NDArray, name string) ([]byte, error) {
+	code, ok := onnxTypes[a.DType()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dtype %s for ONNX", a.DType())
+	}
+	var b protoBuilder
+	for _, d := range a.Shape() {
+		b.varint(onnxDims, uint64(d))
+	}
+	b.varint(onnxDataType, code)
+	if name != "" {
+		b.bytes(onnxName, []byte(name))
+	}
+	b.bytes(onnxRawData, a.Data())
+	return b.buf, nil
+}
@@ -0,0 +1,766 @@
+package io
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	goio "io"
+	"math"
+	"os"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Parquet support reads flat files of numeric and boolean columns, the
+// layout of a DataFrame written by pandas, Arrow or Spark. Data pages of
+// version 1 and 2 with PLAIN, dictionary, RLE and BYTE_STREAM_SPLIT
+// encodings are decoded, compressed with Snappy, gzip or not at all.
+
+// parquetMagic starts and ends every Parquet file
+const parquetMagic = "PAR1"
+
+// Physical types
+const (
+	parquetBoolean = 0
+	parquetInt32   = 1
+	parquetInt64   = 2
+	parquetFloat   = 4
+	parquetDouble  = 5
+)
+
+// Encodings
+const (
+	parquetPlain           = 0
+	parquetPlainDictionary = 2
+	parquetRLE             = 3
+	parquetRLEDictionary   = 8
+	parquetByteStreamSplit = 9
+)
+
+// Page types
+const (
+	parquetDataPage       = 0
+	parquetDictionaryPage = 2
+	parquetDataPageV2     = 3
+)
+
+// ParquetFilter keeps the rows whose value in Column lies in [Min, Max].
+// Row groups whose statistics show no value in the range are skipped
+// without being read.
+type ParquetFilter struct {
+	Column   string
+	Min, Max float64
+}
+
+// ParquetOptions selects what LoadParquetWith and ReadParquet read. The
+// zero value reads every numeric and boolean column of every row.
+type ParquetOptions struct {
+	// Columns lists the columns to read in order. Nil reads every column
+	// of a supported type in schema order.
+	Columns []string
+	
+	// RowGroups lists the indices of the row groups to read. Nil reads
+	// all of them.
+	RowGroups []int
+	
+	// FirstRow and NumRows restrict the result to a range of rows of the
+	// file; row groups outside the range are skipped. NumRows 0 reads to
+	// the end.
+	FirstRow, NumRows int64
+	
+	// Filters must all hold for a row to be kept. Null values never
+	// match.
+	Filters []ParquetFilter
+}
+
+// parquetColumn describes a top-level column of the schema
+type parquetColumn struct {
+	name     string
+	index    int
+	physical int64
+	dtype    tensor.DType
+	optional bool
+}
+
+// LoadParquet reads every numeric and boolean column of the Parquet file
+// at path into a table; null values are flagged in Table.Missing
+func LoadParquet(path string) (*Table, error) {
+	return LoadParquetWith(path, ParquetOptions{})
+}
+
+// LoadParquetWith is LoadParquet with column, row group and row selection
+func LoadParquetWith(path string, opts ParquetOptions) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return ReadParquet(f, info.Size(), opts)
+}
+
+// ReadParquet reads a Parquet file of the given size from r
+func ReadParquet(r goio.ReaderAt, size int64, opts ParquetOptions) (*Table, error) {
+	meta, err := readParquetFooter(r, size)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := parquetSchema(meta)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]parquetColumn{}
+	for _, c := range columns {
+		byName[c.name] = c
+	}
+	
+	selected := columns[:0:0]
+	if opts.Columns == nil {
+		for _, c := range columns {
+			if c.dtype != tensor.DType(-1) {
+				selected = append(selected, c)
+			}
+		}
+	} else {
+		for _, name := range opts.Columns {
+			c, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("Parquet column %q not found", name)
+			}
+			if c.dtype == tensor.DType(-1) {
+				return nil, fmt.Errorf("Parquet column %q is not numeric", name)
+			}
+			selected = append(selected, c)
+		}
+	}
+	
+	// Filter columns are read alongside the selected ones
+	reads := append([]parquetColumn{}, selected...)
+	filterCol := make([]int, len(opts.Filters))
+	for i, f := range opts.Filters {
+		c, ok := byName[f.Column]
+		if !ok {
+			return nil, fmt.Errorf("Parquet filter column %q not found", f.Column)
+		}
+		if c.dtype == tensor.DType(-1) {
+			return nil, fmt.Errorf("Parquet filter column %q is not numeric", f.Column)
+		}
+		filterCol[i] = -1
+		for j, r := range reads {
+			if r.name == c.name {
+				filterCol[i] = j
+			}
+		}
+		if filterCol[i] < 0 {
+			filterCol[i] = len(reads)
+			reads = append(reads, c)
+		}
+	}
+	
+	groups := meta.list(4)
+	wanted := make([]bool, len(groups))
+	if opts.RowGroups == nil {
+		for i := range wanted {
+			wanted[i] = true
+		}
+	}
+	for _, g := range opts.RowGroups {
+		if g < 0 || g >= len(groups) {
+			return nil, fmt.Errorf("Parquet row group %d is out of range for %d groups", g, len(groups))
+		}
+		wanted[g] = true
+	}
+	lastRow := int64(math.MaxInt64)
+	if opts.NumRows > 0 {
+		lastRow = opts.FirstRow + opts.NumRows
+	}
+	
+	parts := make([][]*tensor.NDArray, len(reads))
+	masks := make([][]*tensor.NDArray, len(reads))
+	var start int64
+	for g, item := range groups {
+		group, _ := item.(thriftStruct)
+		rows := group.int(3, 0)
+		first, last := max(start, opts.FirstRow), min(start+rows, lastRow)
+		groupStart := start
+		start += rows
+		if !wanted[g] || first >= last {
+			continue
+		}
+		chunks := group.list(1)
+		if parquetSkipGroup(chunks, opts.Filters, byName) {
+			continue
+		}
+		for j, c := range reads {
+			if c.index >= len(chunks) {
+				return nil, fmt.Errorf("Parquet row group %d has no column %q", g, c.name)
+			}
+			chunk, _ := chunks[c.index].(thriftStruct)
+			values, missing, err := readParquetChunk(r, size, chunk, c, rows)
+			if err != nil {
+				return nil, fmt.Errorf("reading Parquet column %q: %w", c.name, err)
+			}
+			lo, hi := int(first-groupStart), int(last-groupStart)
+			parts[j] = append(parts[j], sliceRows(values, lo, hi))
+			masks[j] = append(masks[j], sliceRows(missing, lo, hi))
+		}
+	}
+	
+	cols := make([]*tensor.NDArray, len(reads))
+	missing := make([]*tensor.NDArray, len(reads))
+	for j, c := range reads {
+		if len(parts[j]) == 0 {
+			cols[j] = tensor.Zeros([]int{0}, c.dtype)
+			missing[j] = tensor.Zeros([]int{0}, tensor.Bool)
+			continue
+		}
+		cols[j] = tensor.Concatenate(parts[j], 0)
+		missing[j] = tensor.Concatenate(masks[j], 0)
+	}
+	
+	if len(opts.Filters) > 0 && len(reads) > 0 {
+		n := cols[0].Size()
+		var keep []int
+		for i := 0; i < n; i++ {
+			ok := true
+			for k, f := range opts.Filters {
+				j := filterCol[k]
+				if missing[j].GetFloat64(i) != 0 {
+					ok = false
+					break
+				}
+				if v := cols[j].GetFloat64(i); v < f.Min || v > f.Max {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				keep = append(keep, i)
+			}
+		}
+		for j := range cols {
+			cols[j] = takeRows(cols[j], keep)
+			missing[j] = takeRows(missing[j], keep)
+		}
+	}
+	
+	t := &Table{}
+	for j, c := range selected {
+		t.Names = append(t.Names, c.name)
+		t.Columns = append(t.Columns, cols[j])
+		t.Missing = append(t.Missing, missing[j])
+	}
+	return t, nil
+}
+
+// readParquetFooter reads the file metadata from the end of the file
+func readParquetFooter(r goio.ReaderAt, size int64) (thriftStruct, error) {
+	if size < 12 {
+		return nil, fmt.Errorf("not a Parquet file: too short")
+	}
+	tail := make([]byte, 8)
+	if _, err := r.ReadAt(tail, size-8); err != nil {
+		return nil, err
+	}
+	if string(tail[4:]) != parquetMagic {
+		return nil, fmt.Errorf("not a Parquet file: bad magic number")
+	}
+	n := int64(binary.LittleEndian.Uint32(tail))
+	if n > size-12 {
+		return nil, fmt.Errorf("invalid Parquet footer length %d", n)
+	}
+	buf := make([]byte, n)
+	if _, err := r.ReadAt(buf, size-8-n); err != nil {
+		return nil, err
+	}
+	meta, _, err := decodeThrift(buf)
+	if err != nil {
+		return nil, fmt.Errorf("reading Parquet metadata: %w", err)
+	}
+	return meta, nil
+}
+
+// parquetSchema lists the top-level columns. Columns whose type has no
+// dtype get dtype -1; nested or repeated columns are rejected.
+func parquetSchema(meta thriftStruct) ([]parquetColumn, error) {
+	elems := meta.list(2)
+	if len(elems) == 0 {
+		return nil, fmt.Errorf("Parquet file has no schema")
+	}
+	var columns []parquetColumn
+	for i, item := range elems[1:] {
+		e, _ := item.(thriftStruct)
+		name := e.str(4)
+		if e.int(5, 0) > 0 || e.int(3, 0) == 2 {
+			return nil, fmt.Errorf("nested or repeated Parquet column %q is not supported", name)
+		}
+		c := parquetColumn{name: name, index: i, physical: e.int(1, -1), optional: e.int(3, 0) == 1}
+		c.dtype = parquetDType(c.physical, e.int(6, -1))
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
+// parquetDType maps a physical and converted type to a dtype, or -1
+func parquetDType(physical, converted int64) tensor.DType {
+	switch physical {
+	case parquetBoolean:
+		return tensor.Bool
+	case parquetFloat:
+		return tensor.Float32
+	case parquetDouble:
+		return tensor.Float64
+	case parquetInt32:
+		switch converted {
+		case 11:
+			return tensor.Uint8
+		case 12:
+			return tensor.Uint16
+		case 13:
+			return tensor.Uint32
+		case 15:
+			return tensor.Int8
+		case 16:
+			return tensor.Int16
+		}
+		return tensor.Int32
+	case parquetInt64:
+		if converted == 14 {
+			return tensor.Uint64
+		}
+		return tensor.Int64
+	}
+	return tensor.DType(-1)
+}
+
+// parquetSkipGroup reports whether the column statistics of a row group
+// prove that no row passes the filters
+func parquetSkipGroup(chunks []any, filters []ParquetFilter, byName map[string]parquetColumn) bool {
+	for _, f := range filters {
+		c := byName[f.Column]
+		if c.index >= len(chunks) {
+			continue
+		}
+		chunk, _ := chunks[c.index].(thriftStruct)
+		stats := chunk.sub(3).sub(12)
+		if stats == nil {
+			continue
+		}
+		lo, hi := stats.bytes(6), stats.bytes(5)
+		if lo == nil || hi == nil {
+			lo, hi = stats.bytes(2), stats.bytes(1)
+		}
+		min, okMin := parquetStat(lo, c)
+		max, okMax := parquetStat(hi, c)
+		if okMin && okMax && (max < f.Min || min > f.Max) {
+			return true
+		}
+	}
+	return false
+}
+
+// parquetStat decodes a PLAIN-encoded statistics value
+func parquetStat(b []byte, c parquetColumn) (float64, bool) {
+	switch {
+	case c.physical == parquetBoolean && len(b) == 1:
+		return float64(b[0]), true
+	case c.physical == parquetInt32 && len(b) == 4:
+		v := binary.LittleEndian.Uint32(b)
+		if c.dtype.IsInt() && c.dtype >= tensor.Uint8 {
+			return float64(v), true
+		}
+		return float64(int32(v)), true
+	case c.physical == parquetInt64 && len(b) == 8:
+		v := binary.LittleEndian.Uint64(b)
+		if c.dtype == tensor.Uint64 {
+			return float64(v), true
+		}
+		return float64(int64(v)), true
+	case c.physical == parquetFloat && len(b) == 4:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), true
+	case c.physical == parquetDouble && len(b) == 8:
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), true
+	}
+	return 0, false
+}
+
+// readParquetChunk decodes the pages of a column chunk into a 1D array of
+// rows values and a Bool mask of the null entries
+func readParquetChunk(r goio.ReaderAt, size int64, chunk thriftStruct, c parquetColumn, rows int64) (*tensor.NDArray, *tensor.NDArray, error) {
+	meta := chunk.sub(3)
+	if meta == nil {
+		return nil, nil, fmt.Errorf("column chunk has no metadata")
+	}
+	if meta.int(1, -1) != c.physical {
+		return nil, nil, fmt.Errorf("column chunk type does not match the schema")
+	}
+	offset := meta.int(9, -1)
+	if dict := meta.int(11, 0); dict > 0 && dict < offset {
+		offset = dict
+	}
+	length := meta.int(7, -1)
+	if offset < 0 || length < 0 || offset+length > size {
+		return nil, nil, fmt.Errorf("column chunk is out of range")
+	}
+	buf := make([]byte, length)
+	if _, err := r.ReadAt(buf, offset); err != nil {
+		return nil, nil, err
+	}
+	codec := meta.int(4, 0)
+	
+	width := 1
+	switch c.physical {
+	case parquetInt32, parquetFloat:
+		width = 4
+	case parquetInt64, parquetDouble:
+		width = 8
+	}
+	values := make([]byte, 0, int(rows)*width)
+	missing := make([]byte, 0, rows)
+	var dict []byte
+	
+	for pos := 0; int64(len(missing)) < rows; {
+		if pos >= len(buf) {
+			return nil, nil, fmt.Errorf("column chunk ends after %d of %d values", len(missing), rows)
+		}
+		header, n, err := decodeThrift(buf[pos:])
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading page header: %w", err)
+		}
+		pos += n
+		compressed := int(header.int(3, -1))
+		if compressed < 0 || pos+compressed > len(buf) {
+			return nil, nil, fmt.Errorf("page is out of range")
+		}
+		page := buf[pos : pos+compressed]
+		pos += compressed
+		uncompressed := int(header.int(2, 0))
+		
+		switch header.int(1, -1) {
+		case parquetDictionaryPage:
+			data, err := parquetDecompress(codec, page, uncompressed)
+			if err != nil {
+				return nil, nil, err
+			}
+			n := int(header.sub(7).int(1, 0))
+			if c.physical == parquetBoolean || n*width > len(data) {
+				return nil, nil, fmt.Errorf("invalid dictionary page")
+			}
+			dict = data[:n*width]
+			
+		case parquetDataPage:
+			h := header.sub(5)
+			data, err := parquetDecompress(codec, page, uncompressed)
+			if err != nil {
+				return nil, nil, err
+			}
+			count := int(h.int(1, 0))
+			defs := data[:0]
+			if c.optional {
+				if len(data) < 4 {
+					return nil, nil, fmt.Errorf("truncated definition levels")
+				}
+				n := int(binary.LittleEndian.Uint32(data))
+				if 4+n > len(data) {
+					return nil, nil, fmt.Errorf("truncated definition levels")
+				}
+				defs = data[4 : 4+n]
+				data = data[4+n:]
+			}
+			values, missing, err = appendParquetPage(values, missing, data, defs, count, c, h.int(2, 0), width, dict)
+			if err != nil {
+				return nil, nil, err
+			}
+			
+		case parquetDataPageV2:
+			h := header.sub(8)
+			defLen, repLen := int(h.int(5, 0)), int(h.int(6, 0))
+			if repLen+defLen > len(page) {
+				return nil, nil, fmt.Errorf("truncated page levels")
+			}
+			defs := page[repLen : repLen+defLen]
+			data := page[repLen+defLen:]
+			if h.bool(7, true) {
+				if data, err = parquetDecompress(codec, data, uncompressed-repLen-defLen); err != nil {
+					return nil, nil, err
+				}
+			}
+			if !c.optional {
+				defs = defs[:0]
+			}
+			values, missing, err = appendParquetPage(values, missing, data, defs, int(h.int(1, 0)), c, h.int(4, 0), width, dict)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if int64(len(missing)) != rows {
+		return nil, nil, fmt.Errorf("column chunk holds %d values, expected %d", len(missing), rows)
+	}
+	
+	col := parquetValues(values, c, width)
+	return col, tensor.FromBytes(missing, tensor.Bool, len(missing)), nil
+}
+
+// appendParquetPage decodes count entries of a data page, appending each
+// value (zero for nulls) and its null flag
+func appendParquetPage(values, missing, data, defs []byte, count int, c parquetColumn, encoding int64, width int, dict []byte) ([]byte, []byte, error) {
+	present := count
+	var levels []uint64
+	if len(defs) > 0 {
+		var err error
+		if levels, err = decodeHybrid(defs, 1, count); err != nil {
+			return nil, nil, fmt.Errorf("decoding definition levels: %w", err)
+		}
+		present = 0
+		for _, l := range levels {
+			if l != 0 {
+				present++
+			}
+		}
+	}
+	
+	var decoded []byte
+	switch {
+	case c.physical == parquetBoolean && encoding == parquetPlain:
+		if len(data) < (present+7)/8 {
+			return nil, nil, fmt.Errorf("truncated boolean page")
+		}
+		decoded = unpackBits(data, present)
+	case c.physical == parquetBoolean && encoding == parquetRLE:
+		if len(data) < 4 {
+			return nil, nil, fmt.Errorf("truncated boolean page")
+		}
+		bits, err := decodeHybrid(data[4:], 1, present)
+		if err != nil {
+			return nil, nil, err
+		}
+		decoded = make([]byte, present)
+		for i, b := range bits {
+			decoded[i] = byte(b)
+		}
+	case encoding == parquetPlain:
+		if len(data) < present*width {
+			return nil, nil, fmt.Errorf("truncated page")
+		}
+		decoded = data[:present*width]
+	case encoding == parquetByteStreamSplit:
+		if len(data) < present*width {
+			return nil, nil, fmt.Errorf("truncated page")
+		}
+		decoded = make([]byte, present*width)
+		for i := 0; i < present; i++ {
+			for k := 0; k < width; k++ {
+				decoded[i*width+k] = data[k*present+i]
+			}
+		}
+	case encoding == parquetRLEDictionary || encoding == parquetPlainDictionary:
+		if dict == nil || len(data) < 1 {
+			return nil, nil, fmt.Errorf("dictionary page missing")
+		}
+		indices, err := decodeHybrid(data[1:], int(data[0]), present)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decoding dictionary indices: %w", err)
+		}
+		decoded = make([]byte, present*width)
+		for i, idx := range indices {
+			if int(idx+1)*width > len(dict) {
+				return nil, nil, fmt.Errorf("dictionary index %d is out of range", idx)
+			}
+			copy(decoded[i*width:], dict[int(idx)*width:int(idx+1)*width])
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported Parquet encoding %d", encoding)
+	}
+	
+	if levels == nil {
+		return append(values, decoded...), append(missing, make([]byte, count)...), nil
+	}
+	zero := make([]byte, width)
+	next := 0
+	for _, l := range levels {
+		if l != 0 {
+			values = append(values, decoded[next*width:(next+1)*width]...)
+			missing = append(missing, 0)
+			next++
+		} else {
+			values = append(values, zero...)
+			missing = append(missing, 1)
+		}
+	}
+	return values, missing, nil
+}
+
+// parquetValues converts decoded physical values to the column's dtype
+func parquetValues(values []byte, c parquetColumn, width int) *tensor.NDArray {
+	n := len(values) / width
+	if c.dtype.ItemSize() == width {
+		return tensor.FromBytes(values, c.dtype, n)
+	}
+	out := tensor.Zeros([]int{n}, c.dtype)
+	for i := 0; i < n; i++ {
+		out.SetInt64(int64(int32(binary.LittleEndian.Uint32(values[4*i:]))), i)
+	}
+	return out
+}
+
+// decodeHybrid decodes n values of the given bit width from the Parquet
+// RLE/bit-packing hybrid encoding
+func decodeHybrid(data []byte, width, n int) ([]uint64, error) {
+	if width < 0 || width > 64 {
+		return nil, fmt.Errorf("invalid bit width %d", width)
+	}
+	out := make([]uint64, 0, n)
+	pos := 0
+	for len(out) < n {
+		header, k := binary.Uvarint(data[pos:])
+		if k <= 0 {
+			return nil, fmt.Errorf("truncated RLE data")
+		}
+		pos += k
+		if header&1 == 1 {
+			// Bit-packed groups of 8 values, least significant bit first
+			count := int(header>>1) * 8
+			nbytes := int(header>>1) * width
+			if pos+nbytes > len(data) {
+				return nil, fmt.Errorf("truncated bit-packed data")
+			}
+			for i := 0; i < count && len(out) < n; i++ {
+				var v uint64
+				for b := 0; b < width; b++ {
+					bit := i*width + b
+					v |= uint64(data[pos+bit/8]>>(bit%8)&1) << b
+				}
+				out = append(out, v)
+			}
+			pos += nbytes
+		} else {
+			count := int(header >> 1)
+			nbytes := (width + 7) / 8
+			if pos+nbytes > len(data) {
+				return nil, fmt.Errorf("truncated RLE run")
+			}
+			var v uint64
+			for b := 0; b < nbytes; b++ {
+				v |= uint64(data[pos+b]) << (8 * b)
+			}
+			pos += nbytes
+			for i := 0; i < count && len(out) < n; i++ {
+				out = append(out, v)
+			}
+		}
+	}
+	return out, nil
+}
+
+// parquetDecompress decompresses a page with the column chunk's codec
+func parquetDecompress(codec int64, data []byte, size int) ([]byte, error) {
+	switch codec {
+	case 0:
+		return data, nil
+	case 1:
+		return snappyDecode(data)
+	case 2:
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		out := make([]byte, 0, size)
+		buf := bytes.NewBuffer(out)
+		_, err = buf.ReadFrom(zr)
+		return buf.Bytes(), err
+	}
+	return nil, fmt.Errorf("unsupported Parquet compression codec %d", codec)
+}
+
+// snappyDecode decompresses a raw Snappy block
+func snappyDecode(src []byte) ([]byte, error) {
+	n, k := binary.Uvarint(src)
+	if k <= 0 || n > 1<<32 {
+		return nil, fmt.Errorf("invalid Snappy length")
+	}
+	dst := make([]byte, 0, n)
+	for pos := k; pos < len(src); {
+		tag := src[pos]
+		pos++
+		var length, offset int
+		switch tag & 3 {
+		case 0:
+			length = int(tag>>2) + 1
+			if length > 60 {
+				extra := length - 60
+				if pos+extra > len(src) {
+					return nil, fmt.Errorf("truncated Snappy literal")
+				}
+				length = 0
+				for b := 0; b < extra; b++ {
+					length |= int(src[pos+b]) << (8 * b)
+				}
+				length++
+				pos += extra
+			}
+			if length < 0 || pos+length > len(src) {
+				return nil, fmt.Errorf("truncated Snappy literal")
+			}
+			dst = append(dst, src[pos:pos+length]...)
+			pos += length
+			continue
+		case 1:
+			if pos >= len(src) {
+				return nil, fmt.Errorf("truncated Snappy copy")
+			}
+			length = int(tag>>2&7) + 4
+			offset = int(tag>>5)<<8 | int(src[pos])
+			pos++
+		case 2:
+			if pos+2 > len(src) {
+				return nil, fmt.Errorf("truncated Snappy copy")
+			}
+			length = int(tag>>2) + 1
+			offset = int(binary.LittleEndian.Uint16(src[pos:]))
+			pos += 2
+		case 3:
+			if pos+4 > len(src) {
+				return nil, fmt.Errorf("truncated Snappy copy")
+			}
+			length = int(tag>>2) + 1
+			offset = int(binary.LittleEndian.Uint32(src[pos:]))
+			pos += 4
+		}
+		if offset <= 0 || offset > len(dst) {
+			return nil, fmt.Errorf("invalid Snappy copy offset")
+		}
+		// Copies may overlap their own output
+		for i := 0; i < length; i++ {
+			dst = append(dst, dst[len(dst)-offset])
+		}
+	}
+	if uint64(len(dst)) != n {
+		return nil, fmt.Errorf("Snappy data decodes to %d bytes, expected %d", len(dst), n)
+	}
+	return dst, nil
+}
+
+// sliceRows returns rows [lo, hi) of a 1D array
+func sliceRows(a *tensor.NDArray, lo, hi int) *tensor.NDArray {
+	if lo == 0 && hi == a.Size() {
+		return a
+	}
+	w := a.ItemSize()
+	return tensor.FromBytes(a.Data()[lo*w:hi*w], a.DType(), hi-lo)
+}
+
+// takeRows gathers the given rows of a 1D array
+func takeRows(a *tensor.NDArray, rows []int) *tensor.NDArray {
+	w := a.ItemSize()
+	src := a.Data()
+	data := make([]byte, 0, len(rows)*w)
+	for _, r := range rows {
+		data = append(data, src[r*w:(r+1)*w]...)
+	}
+	return tensor.FromBytes(data, a.DType(), len(rows))
+}
@@ -0,0 +1,166 @@
+package io
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// A minimal protobuf wire-format encoder and decoder, enough for ONNX
+// TensorProto messages and NumGo's own Tensor message. Messages decode to
+// protoMessage maps holding the values of each field in order: varint and
+// fixed-width values as uint64 and length-delimited values as []byte.
+
+// protoMessage holds the values of a decoded message by field number
+type protoMessage map[int][]any
+
+// Wire types
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+// decodeProto decodes the fields of the message in buf. Nested messages
+// stay []byte until they are decoded in turn.
+func decodeProto(buf []byte) (protoMessage, error) {
+	m := protoMessage{}
+	for pos := 0; pos < len(buf); {
+		key, n := binary.Uvarint(buf[pos:])
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid protobuf field key")
+		}
+		pos += n
+		field := int(key >> 3)
+		if field == 0 || key>>3 > 1<<29 {
+			return nil, fmt.Errorf("invalid protobuf field number %d", key>>3)
+		}
+		var value any
+		switch key & 7 {
+		case protoVarint:
+			v, n := binary.Uvarint(buf[pos:])
+			if n <= 0 {
+				return nil, fmt.Errorf("invalid protobuf varint")
+			}
+			pos += n
+			value = v
+		case protoFixed64:
+			if pos+8 > len(buf) {
+				return nil, fmt.Errorf("truncated protobuf data")
+			}
+			value = binary.LittleEndian.Uint64(buf[pos:])
+			pos += 8
+		case protoFixed32:
+			if pos+4 > len(buf) {
+				return nil, fmt.Errorf("truncated protobuf data")
+			}
+			value = uint64(binary.LittleEndian.Uint32(buf[pos:]))
+			pos += 4
+		case protoBytes:
+			l, n := binary.Uvarint(buf[pos:])
+			if n <= 0 || l > uint64(len(buf)-pos-n) {
+				return nil, fmt.Errorf("truncated protobuf data")
+			}
+			pos += n
+			value = buf[pos : pos+int(l)]
+			pos += int(l)
+		default:
+			return nil, fmt.Errorf("unsupported protobuf wire type %d", key&7)
+		}
+		m[field] = append(m[field], value)
+	}
+	return m, nil
+}
+
+// uint returns the last value of a scalar field, or zero if it is absent
+func (m protoMessage) uint(field int) uint64 {
+	values := m[field]
+	if len(values) == 0 {
+		return 0
+	}
+	v, _ := values[len(values)-1].(uint64)
+	return v
+}
+
+// bytes returns the last value of a length-delimited field, or nil if it is
+// absent
+func (m protoMessage) bytes(field int) []byte {
+	values := m[field]
+	if len(values) == 0 {
+		return nil
+	}
+	b, _ := values[len(values)-1].([]byte)
+	return b
+}
+
+// repeated returns the values of a repeated scalar field whose elements have
+// the given wire type, accepting both packed and unpacked encodings
+func (m protoMessage) repeated(field int, wire int) ([]uint64, error) {
+	var out []uint64
+	for _, value := range m[field] {
+		packed, ok := value.([]byte)
+		if !ok {
+			out = append(out, value.(uint64))
+			continue
+		}
+		for pos := 0; pos < len(packed); {
+			switch wire {
+			case protoVarint:
+				v, n := binary.Uvarint(packed[pos:])
+				if n <= 0 {
+					return nil, fmt.Errorf("invalid packed protobuf varint")
+				}
+				out = append(out, v)
+				pos += n
+			case protoFixed32:
+				if pos+4 > len(packed) {
+					return nil, fmt.Errorf("truncated packed protobuf field")
+				}
+				out = append(out, uint64(binary.LittleEndian.Uint32(packed[pos:])))
+				pos += 4
+			case protoFixed64:
+				if pos+8 > len(packed) {
+					return nil, fmt.Errorf("truncated packed protobuf field")
+				}
+				out = append(out, binary.LittleEndian.Uint64(packed[pos:]))
+				pos += 8
+			}
+		}
+	}
+	return out, nil
+}
+
+// protoBuilder accumulates an encoded message
+type protoBuilder struct {
+	buf []byte
+}
+
+func (b *protoBuilder) key(field, wire int) {
+	b.buf = binary.AppendUvarint(b.buf, uint64(field)<<3|uint64(wire))
+}
+
+// varint appends a varint field
+func (b *protoBuilder) varint(field int, v uint64) {
+	b.key(field, protoVarint)
+	b.buf = binary.AppendUvarint(b.buf, v)
+}
+
+// bytes appends a length-delimited field
+func (b *protoBuilder) bytes(field int, v []byte) {
+	b.key(field, protoBytes)
+	b.buf = binary.AppendUvarint(b.buf, uint64(len(v)))
+	b.buf = append(b.buf, v...)
+}
+
+// packed appends a packed repeated varint field, omitted when empty as in
+// proto3
+func (b *protoBuilder) packed(field int, values []int) {
+	if len(values) == 0 {
+		return
+	}
+	var data []byte
+	for _, v := range values {
+		data = binary.AppendUvarint(data, uint64(v))
+	}
+	b.bytes(field, data)
+}
@@ -0,0 +1,313 @@
+package io
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	goio "io"
+	"math"
+	"os"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// A snapshot is NumGo's own framed binary format for checkpoints. After an
+// 8-byte magic string and a version, each array is a frame:
+//
+//	'A', dtype, codec, ndim, shape (uint64 each),
+//	blocks of (stored length, raw length, data) ending with a zero length,
+//	CRC-32 of the raw data
+//
+// and the stream ends with 'E'. Data is split into blocks so arrays of any
+// size are compressed and checked without being buffered whole. All
+// integers are little-endian; lengths are uint32.
+
+// snapshotMagic starts every snapshot
+const snapshotMagic = "NUMGOSNP"
+
+// snapshotVersion is the format version written
+const snapshotVersion = 1
+
+// snapshotBlock is the amount of raw data per block
+const snapshotBlock = 1 << 22
+
+// Compression selects how snapshot data is compressed
+type Compression int
+
+const (
+	// NoCompression stores data as is, the fastest option
+	NoCompression Compression = iota
+	// Gzip compresses each block with gzip
+	Gzip
+)
+
+// String returns the name of the compression
+func (c Compression) String() string {
+	switch c {
+	case NoCompression:
+		return "none"
+	case Gzip:
+		return "gzip"
+	}
+	return fmt.Sprintf("Compression(%d)", int(c))
+}
+
+// SnapshotOptions controls how snapshots are written. The zero value
+// writes uncompressed data.
+type SnapshotOptions struct {
+	Compression Compression
+	
+	// Level is the gzip level from 1 to 9; 0 uses the default
+	Level int
+}
+
+// Save writes arrays to the file at path as an uncompressed snapshot
+func Save(path string, arrays ...*tensor.NDArray) error {
+	return SaveWith(path, SnapshotOptions{}, arrays...)
+}
+
+// SaveWith is Save with optional compression
+func SaveWith(path string, opts SnapshotOptions, arrays ...*tensor.NDArray) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(f)
+	sw, err := NewSnapshotWriter(bw, opts)
+	if err == nil {
+		for _, a := range arrays {
+			if err = sw.Write(a); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil {
+		err = sw.Close()
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads every array of the snapshot at path
+func Load(path string) ([]*tensor.NDArray, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	sr, err := NewSnapshotReader(bufio.NewReader(f))
+	if err != nil {
+		return nil, err
+	}
+	var arrays []*tensor.NDArray
+	for {
+		a, err := sr.Next()
+		if err == goio.EOF {
+			return arrays, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		arrays = append(arrays, a)
+	}
+}
+
+// SnapshotWriter writes arrays to a snapshot stream one at a time
+type SnapshotWriter struct {
+	w    goio.Writer
+	opts SnapshotOptions
+}
+
+// NewSnapshotWriter writes the snapshot header to w and returns a writer
+// for its arrays
+func NewSnapshotWriter(w goio.Writer, opts SnapshotOptions) (*SnapshotWriter, error) {
+	if opts.Compression != NoCompression && opts.Compression != Gzip {
+		return nil, fmt.Errorf("unsupported snapshot compression %s", opts.Compression)
+	}
+	if opts.Level < 0 || opts.Level > 9 {
+		return nil, fmt.Errorf("gzip level %d is not between 1 and 9", opts.Level)
+	}
+	header := binary.LittleEndian.AppendUint32([]byte(snapshotMagic), snapshotVersion)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &SnapshotWriter{w: w, opts: opts}, nil
+}
+
+// Write appends a to the snapshot
+func (sw *SnapshotWriter) Write(a *tensor.NDArray) error {
+	shape := a.Shape()
+	if len(shape) > 255 {
+		return fmt.Errorf("array of %d dimensions cannot be stored in a snapshot", len(shape))
+	}
+	header := []byte{'A', byte(a.DType()), byte(sw.opts.Compression), byte(len(shape))}
+	for _, s := range shape {
+		header = binary.LittleEndian.AppendUint64(header, uint64(s))
+	}
+	if _, err := sw.w.Write(header); err != nil {
+		return err
+	}
+	
+	data := a.Data()
+	level := sw.opts.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	var buf bytes.Buffer
+	for start := 0; start < len(data); start += snapshotBlock {
+		raw := data[start:min(start+snapshotBlock, len(data))]
+		stored := raw
+		if sw.opts.Compression == Gzip {
+			buf.Reset()
+			zw, _ := gzip.NewWriterLevel(&buf, level)
+			zw.Write(raw)
+			if err := zw.Close(); err != nil {
+				return err
+			}
+			stored = buf.Bytes()
+		}
+		lengths := binary.LittleEndian.AppendUint32(nil, uint32(len(stored)))
+		lengths = binary.LittleEndian.AppendUint32(lengths, uint32(len(raw)))
+		if _, err := sw.w.Write(lengths); err != nil {
+			return err
+		}
+		if _, err := sw.w.Write(stored); err != nil {
+			return err
+		}
+	}
+	trailer := binary.LittleEndian.AppendUint32(nil, 0)
+	trailer = binary.LittleEndian.AppendUint32(trailer, crc32.ChecksumIEEE(data))
+	_, err := sw.w.Write(trailer)
+	return err
+}
+
+// Close writes the end marker. It does not close the underlying writer.
+func (sw *SnapshotWriter) Close() error {
+	_, err := sw.w.Write([]byte{'E'})
+	return err
+}
+
+// SnapshotReader reads the arrays of a snapshot stream one at a time
+type SnapshotReader struct {
+	r    goio.Reader
+	done bool
+}
+
+// NewSnapshotReader checks the snapshot header at the start of r
+func NewSnapshotReader(r goio.Reader) (*SnapshotReader, error) {
+	header := make([]byte, len(snapshotMagic)+4)
+	if _, err := goio.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("reading snapshot header: %w", err)
+	}
+	if string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, fmt.Errorf("not a NumGo snapshot: bad magic string")
+	}
+	if v := binary.LittleEndian.Uint32(header[len(snapshotMagic):]); v != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", v)
+	}
+	return &SnapshotReader{r: r}, nil
+}
+
+// Next returns the next array, or io.EOF after the last one
+func (sr *SnapshotReader) Next() (*tensor.NDArray, error) {
+	if sr.done {
+		return nil, goio.EOF
+	}
+	var header [4]byte
+	if _, err := goio.ReadFull(sr.r, header[:1]); err != nil {
+		if err == goio.EOF {
+			return nil, fmt.Errorf("snapshot ends without an end marker: %w", goio.ErrUnexpectedEOF)
+		}
+		return nil, err
+	}
+	if header[0] == 'E' {
+		sr.done = true
+		return nil, goio.EOF
+	}
+	if header[0] != 'A' {
+		return nil, fmt.Errorf("corrupt snapshot: bad frame marker %#x", header[0])
+	}
+	if _, err := goio.ReadFull(sr.r, header[1:]); err != nil {
+		return nil, fmt.Errorf("reading snapshot frame: %w", err)
+	}
+	dtype, codec := tensor.DType(header[1]), Compression(header[2])
+	if dtype.ItemSize() == 0 || dtype > tensor.Complex128 {
+		return nil, fmt.Errorf("corrupt snapshot: unknown dtype %d", header[1])
+	}
+	if codec != NoCompression && codec != Gzip {
+		return nil, fmt.Errorf("unsupported snapshot compression %s", codec)
+	}
+	shape := make([]int, header[3])
+	size := 1
+	for d := range shape {
+		var dim uint64
+		if err := binary.Read(sr.r, binary.LittleEndian, &dim); err != nil {
+			return nil, fmt.Errorf("reading snapshot shape: %w", err)
+		}
+		if dim > 0 && uint64(size) > uint64(math.MaxInt/dtype.ItemSize())/dim {
+			return nil, fmt.Errorf("corrupt snapshot: dimension %d is too large", dim)
+		}
+		shape[d] = int(dim)
+		size *= shape[d]
+	}
+	
+	total := size * dtype.ItemSize()
+	data := make([]byte, 0, min(total, 1<<30))
+	for {
+		var lengths [8]byte
+		if _, err := goio.ReadFull(sr.r, lengths[:4]); err != nil {
+			return nil, fmt.Errorf("reading snapshot block: %w", err)
+		}
+		stored := binary.LittleEndian.Uint32(lengths[:4])
+		if stored == 0 {
+			break
+		}
+		if _, err := goio.ReadFull(sr.r, lengths[4:]); err != nil {
+			return nil, fmt.Errorf("reading snapshot block: %w", err)
+		}
+		raw := int(binary.LittleEndian.Uint32(lengths[4:]))
+		if raw > snapshotBlock || len(data)+raw > total {
+			return nil, fmt.Errorf("corrupt snapshot: block of %d bytes exceeds the array", raw)
+		}
+		block := make([]byte, stored)
+		if _, err := goio.ReadFull(sr.r, block); err != nil {
+			return nil, fmt.Errorf("reading snapshot block: %w", err)
+		}
+		if codec == Gzip {
+			zr, err := gzip.NewReader(bytes.NewReader(block))
+			if err != nil {
+				return nil, fmt.Errorf("corrupt snapshot block: %w", err)
+			}
+			if block, err = goio.ReadAll(goio.LimitReader(zr, int64(raw)+1)); err != nil {
+				return nil, fmt.Errorf("corrupt snapshot block: %w", err)
+			}
+		}
+		if len(block) != raw {
+			return nil, fmt.Errorf("corrupt snapshot: block holds %d bytes, expected %d", len(block), raw)
+		}
+		data = append(data, block...)
+	}
+	var sum uint32
+	if err := binary.Read(sr.r, binary.LittleEndian, &sum); err != nil {
+		return nil, fmt.Errorf("reading snapshot checksum: %w", err)
+	}
+	if len(data) != total {
+		return nil, fmt.Errorf("corrupt snapshot: %d bytes of data, expected %d", len(data), total)
+	}
+	if crc32.ChecksumIEEE(data) != sum {
+		return nil, fmt.Errorf("corrupt snapshot: checksum mismatch")
+	}
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	return tensor.FromBytes(data, dtype, shape...), nil
+}
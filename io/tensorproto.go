@@ -0,0 +1,126 @@
+package io
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	goio "io"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Field numbers of the Tensor message in tensor.proto. Its DType enum is
+// the tensor dtype plus one, leaving zero unspecified.
+const (
+	protoTensorDType       = 1
+	protoTensorShape       = 2
+	protoTensorData        = 3
+	protoTensorCompression = 4
+)
+
+// ProtoOptions controls how arrays are encoded as Tensor messages. The zero
+// value stores data uncompressed.
+type ProtoOptions struct {
+	Compression Compression
+	
+	// Level is the gzip level from 1 to 9; 0 uses the default
+	Level int
+}
+
+// ToProto encodes a as a numgo.Tensor protobuf message (see tensor.proto),
+// ready to embed in a gRPC request or response
+func ToProto(a *tensor.NDArray) []byte {
+	b, _ := ToProtoWith(a, ProtoOptions{})
+	return b
+}
+
+// ToProtoWith is ToProto with optional compression
+func ToProtoWith(a *tensor.NDArray, opts ProtoOptions) ([]byte, error) {
+	data := a.Data()
+	switch opts.Compression {
+	case NoCompression:
+	case Gzip:
+		level := opts.Level
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		var buf bytes.Buffer
+		zw, err := gzip.NewWriterLevel(&buf, level)
+		if err != nil {
+			return nil, err
+		}
+		zw.Write(data)
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		data = buf.Bytes()
+	default:
+		return nil, fmt.Errorf("unknown compression %s", opts.Compression)
+	}
+	
+	var b protoBuilder
+	b.varint(protoTensorDType, uint64(a.DType())+1)
+	b.packed(protoTensorShape, a.Shape())
+	if len(data) > 0 {
+		b.bytes(protoTensorData, data)
+	}
+	if opts.Compression != NoCompression {
+		b.varint(protoTensorCompression, uint64(opts.Compression))
+	}
+	return b.buf, nil
+}
+
+// FromProto decodes a numgo.Tensor protobuf message. A scalar (empty shape)
+// is returned as a one-element 1D array.
+func FromProto(msg []byte) (*tensor.NDArray, error) {
+	m, err := decodeProto(msg)
+	if err != nil {
+		return nil, fmt.Errorf("decoding tensor message: %w", err)
+	}
+	code := m.uint(protoTensorDType)
+	if code == 0 || code > uint64(tensor.Complex128)+1 {
+		return nil, fmt.Errorf("invalid tensor message dtype %d", code)
+	}
+	dtype := tensor.DType(code - 1)
+	
+	dims, err := m.repeated(protoTensorShape, protoVarint)
+	if err != nil {
+		return nil, err
+	}
+	shape := make([]int, len(dims))
+	size := 1
+	for i, d := range dims {
+		// Protobuf messages are limited to 2 GiB, which bounds the size too
+		if d > math.MaxInt32 || d > 0 && size > math.MaxInt32/int(d) {
+			return nil, fmt.Errorf("tensor message shape %v is too large", dims)
+		}
+		shape[i] = int(d)
+		size *= shape[i]
+	}
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	nbytes := size * dtype.ItemSize()
+	
+	data := m.bytes(protoTensorData)
+	switch c := Compression(m.uint(protoTensorCompression)); c {
+	case NoCompression:
+	case Gzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("decompressing tensor message: %w", err)
+		}
+		// Read at most one byte more than expected so a bad message cannot
+		// expand without bound
+		if data, err = goio.ReadAll(goio.LimitReader(zr, int64(nbytes)+1)); err != nil {
+			return nil, fmt.Errorf("decompressing tensor message: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown tensor message compression %d", int(c))
+	}
+	if len(data) != nbytes {
+		return nil, fmt.Errorf("tensor message has %d bytes of data for %d elements of %s", len(data), size, dtype)
+	}
+	return tensor.FromBytes(data, dtype, shape...), nil
+}
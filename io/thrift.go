@@ -0,0 +1,238 @@
+package io
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// A decoder for the Thrift compact protocol used by Parquet metadata.
+// Structs decode to thriftStruct maps keyed by field id, lists to []any,
+// integers to int64, binary fields to []byte, booleans to bool and
+// doubles to float64.
+
+// thriftStruct holds the fields of a decoded struct by id
+type thriftStruct map[int16]any
+
+// Compact protocol type codes
+const (
+	thriftStop    = 0
+	thriftTrue    = 1
+	thriftFalse   = 2
+	thriftByte    = 3
+	thriftI16     = 4
+	thriftI32     = 5
+	thriftI64     = 6
+	thriftDouble  = 7
+	thriftBinary  = 8
+	thriftList    = 9
+	thriftSet     = 10
+	thriftMap     = 11
+	thriftStructT = 12
+)
+
+// thriftDecoder reads compact protocol values from a buffer
+type thriftDecoder struct {
+	buf   []byte
+	pos   int
+	depth int
+}
+
+// decodeThrift decodes the struct at the start of buf and returns it with
+// the number of bytes consumed
+func decodeThrift(buf []byte) (thriftStruct, int, error) {
+	d := &thriftDecoder{buf: buf}
+	s, err := d.readStruct()
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, d.pos, nil
+}
+
+func (d *thriftDecoder) byte() (byte, error) {
+	if d.pos >= len(d.buf) {
+		return 0, fmt.Errorf("truncated Thrift data")
+	}
+	b := d.buf[d.pos]
+	d.pos++
+	return b, nil
+}
+
+func (d *thriftDecoder) varint() (uint64, error) {
+	v, n := binary.Uvarint(d.buf[d.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid Thrift varint")
+	}
+	d.pos += n
+	return v, nil
+}
+
+func (d *thriftDecoder) zigzag() (int64, error) {
+	v, err := d.varint()
+	return int64(v>>1) ^ -int64(v&1), err
+}
+
+func (d *thriftDecoder) readStruct() (thriftStruct, error) {
+	d.depth++
+	defer func() { d.depth-- }()
+	if d.depth > 64 {
+		return nil, fmt.Errorf("Thrift data is nested too deeply")
+	}
+	s := thriftStruct{}
+	var id int16
+	for {
+		header, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		typ := header & 0x0F
+		if typ == thriftStop {
+			return s, nil
+		}
+		if delta := header >> 4; delta != 0 {
+			id += int16(delta)
+		} else {
+			v, err := d.zigzag()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		var value any
+		switch typ {
+		case thriftTrue:
+			value = true
+		case thriftFalse:
+			value = false
+		default:
+			if value, err = d.value(typ); err != nil {
+				return nil, err
+			}
+		}
+		s[id] = value
+	}
+}
+
+// value reads a value of the given type code
+func (d *thriftDecoder) value(typ byte) (any, error) {
+	switch typ {
+	case thriftTrue, thriftFalse:
+		// Booleans inside lists and maps take a byte of their own
+		b, err := d.byte()
+		return b == thriftTrue, err
+	case thriftByte:
+		b, err := d.byte()
+		return int64(int8(b)), err
+	case thriftI16, thriftI32, thriftI64:
+		return d.zigzag()
+	case thriftDouble:
+		if d.pos+8 > len(d.buf) {
+			return nil, fmt.Errorf("truncated Thrift data")
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d.buf[d.pos:]))
+		d.pos += 8
+		return v, nil
+	case thriftBinary:
+		n, err := d.varint()
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(d.buf)-d.pos) {
+			return nil, fmt.Errorf("truncated Thrift data")
+		}
+		b := d.buf[d.pos : d.pos+int(n)]
+		d.pos += int(n)
+		return b, nil
+	case thriftList, thriftSet:
+		header, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		n := uint64(header >> 4)
+		if n == 15 {
+			if n, err = d.varint(); err != nil {
+				return nil, err
+			}
+		}
+		if n > uint64(len(d.buf)-d.pos) {
+			return nil, fmt.Errorf("truncated Thrift data")
+		}
+		items := make([]any, n)
+		for i := range items {
+			if items[i], err = d.value(header & 0x0F); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case thriftMap:
+		n, err := d.varint()
+		if err != nil || n == 0 {
+			return map[any]any{}, err
+		}
+		types, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(d.buf)-d.pos) {
+			return nil, fmt.Errorf("truncated Thrift data")
+		}
+		m := map[any]any{}
+		for i := uint64(0); i < n; i++ {
+			k, err := d.value(types >> 4)
+			if err != nil {
+				return nil, err
+			}
+			v, err := d.value(types & 0x0F)
+			if err != nil {
+				return nil, err
+			}
+			if b, ok := k.([]byte); ok {
+				k = string(b)
+			}
+			m[k] = v
+		}
+		return m, nil
+	case thriftStructT:
+		return d.readStruct()
+	}
+	return nil, fmt.Errorf("unknown Thrift type %d", typ)
+}
+
+// int returns integer field id, or def if it is absent
+func (s thriftStruct) int(id int16, def int64) int64 {
+	if v, ok := s[id].(int64); ok {
+		return v
+	}
+	return def
+}
+
+// bool returns boolean field id, or def if it is absent
+func (s thriftStruct) bool(id int16, def bool) bool {
+	if v, ok := s[id].(bool); ok {
+		return v
+	}
+	return def
+}
+
+// bytes returns binary field id, or nil if it is absent
+func (s thriftStruct) bytes(id int16) []byte {
+	b, _ := s[id].([]byte)
+	return b
+}
+
+// str returns binary field id as a string
+func (s thriftStruct) str(id int16) string {
+	return string(s.bytes(id))
+}
+
+// sub returns struct field id, or nil if it is absent
+func (s thriftStruct) sub(id int16) thriftStruct {
+	v, _ := s[id].(thriftStruct)
+	return v
+}
+
+// list returns list field id, or nil if it is absent
+func (s thriftStruct) list(id int16) []any {
+	v, _ := s[id].([]any)
+	return v
+}
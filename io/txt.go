@@ -0,0 +1,311 @@
+package io
+
+import (
+	"bufio"
+	"fmt"
+	goio "io"
+	"os"
+	"strconv"
+	"strings"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// LoadTxtOptions controls how delimited text is parsed by LoadTxtWith and
+// ReadTxt, mirroring the arguments of numpy.loadtxt
+type LoadTxtOptions struct {
+	// Delimiter separates the values of a row. The zero value splits on
+	// runs of whitespace.
+	Delimiter string
+	
+	// Comments starts a comment that runs to the end of the line. The zero
+	// value is "#".
+	Comments string
+	
+	// SkipRows is the number of leading lines to skip, such as a header
+	SkipRows int
+	
+	// UseCols selects and orders the columns to read; negative indices
+	// count from the end of the row. Nil reads every column.
+	UseCols []int
+	
+	// DType is the dtype of the result. The zero value (Bool) means
+	// Float64.
+	DType tensor.DType
+}
+
+// SaveTxtOptions controls how arrays are written by SaveTxtWith and
+// WriteTxt, mirroring the arguments of numpy.savetxt
+type SaveTxtOptions struct {
+	// Fmt is the fmt verb used for each value. The zero value is "%.18e".
+	Fmt string
+	
+	// Delimiter separates the values of a row. The zero value is a space.
+	Delimiter string
+	
+	// Header is written before the data, each line prefixed by Comments
+	Header string
+	
+	// Footer is written after the data, each line prefixed by Comments
+	Footer string
+	
+	// Comments prefixes the header and footer lines. The zero value is
+	// "# ".
+	Comments string
+}
+
+// LoadTxt reads a whitespace-delimited table of numbers from the file at
+// path into a 2D Float64 array
+func LoadTxt(path string) (*tensor.NDArray, error) {
+	return LoadTxtWith(path, LoadTxtOptions{})
+}
+
+// LoadTxtWith is LoadTxt with control over the delimiter, skipped rows,
+// selected columns and dtype
+func LoadTxtWith(path string, opts LoadTxtOptions) (*tensor.NDArray, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadTxt(f, opts)
+}
+
+// ReadTxt reads a table of numbers from r into a 2D array with one row per
+// non-empty line. Every row must have the same number of columns. Complex
+// values may be written as in Python, e.g. "(1+2j)".
+func ReadTxt(r goio.Reader, opts LoadTxtOptions) (*tensor.NDArray, error) {
+	dtype := opts.DType
+	if dtype == tensor.Bool {
+		dtype = tensor.Float64
+	}
+	rows, lines, err := readTxtRows(r, opts.Delimiter, opts.Comments, opts.SkipRows)
+	if err != nil {
+		return nil, err
+	}
+	
+	cols := -1
+	for i, row := range rows {
+		if opts.UseCols != nil {
+			selected := make([]string, len(opts.UseCols))
+			for j, c := range opts.UseCols {
+				if c < 0 {
+					c += len(row)
+				}
+				if c < 0 || c >= len(row) {
+					return nil, fmt.Errorf("line %d: column %d is out of range for %d columns", lines[i], opts.UseCols[j], len(row))
+				}
+				selected[j] = row[c]
+			}
+			row = selected
+			rows[i] = row
+		}
+		if cols < 0 {
+			cols = len(row)
+		} else if len(row) != cols {
+			return nil, fmt.Errorf("line %d: expected %d columns, got %d", lines[i], cols, len(row))
+		}
+	}
+	if cols < 0 {
+		cols = 0
+	}
+	
+	out := tensor.Zeros([]int{len(rows), cols}, dtype)
+	for i, row := range rows {
+		for j, field := range row {
+			if err := setTxtValue(out, field, i, j); err != nil {
+				return nil, fmt.Errorf("line %d, column %d: %w", lines[i], j, err)
+			}
+		}
+	}
+	return out, nil
+}
+
+// readTxtRows splits the data lines of r into fields, skipping the first
+// skip lines, comments and blank lines. The 1-based line number of each
+// row is returned alongside it for error messages.
+func readTxtRows(r goio.Reader, delimiter, comments string, skip int) ([][]string, []int, error) {
+	if comments == "" {
+		comments = "#"
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<30)
+	
+	var rows [][]string
+	var lines []int
+	for n := 1; scanner.Scan(); n++ {
+		if n <= skip {
+			continue
+		}
+		line := scanner.Text()
+		if i := strings.Index(line, comments); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		rows = append(rows, splitTxtLine(line, delimiter))
+		lines = append(lines, n)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return rows, lines, nil
+}
+
+// splitTxtLine splits line into trimmed fields at delimiter, or at runs of
+// whitespace if delimiter is empty
+func splitTxtLine(line, delimiter string) []string {
+	if delimiter == "" {
+		return strings.Fields(line)
+	}
+	fields := strings.Split(line, delimiter)
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields
+}
+
+// setTxtValue parses field according to the dtype of a and stores it at
+// the given indices
+func setTxtValue(a *tensor.NDArray, field string, indices ...int) error {
+	dtype := a.DType()
+	switch {
+	case dtype.IsComplex():
+		v, err := parseComplex(field)
+		if err != nil {
+			return err
+		}
+		a.SetComplex128(v, indices...)
+	case dtype >= tensor.Uint8 && dtype <= tensor.Uint64:
+		v, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return err
+		}
+		a.SetInt64(int64(v), indices...)
+	case dtype.IsInt():
+		v, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return err
+		}
+		a.SetInt64(v, indices...)
+	default:
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return err
+		}
+		a.SetFloat64(v, indices...)
+	}
+	return nil
+}
+
+// parseComplex parses a complex number written by Go or Python, accepting
+// surrounding parentheses and a "j" imaginary unit
+func parseComplex(field string) (complex128, error) {
+	s := strings.TrimSuffix(strings.TrimPrefix(field, "("), ")")
+	if strings.HasSuffix(s, "j") {
+		s = s[:len(s)-1] + "i"
+	}
+	return strconv.ParseComplex(s, 128)
+}
+
+// SaveTxt writes a 1D or 2D array to the file at path as text, one row
+// per line
+func SaveTxt(path string, a *tensor.NDArray) error {
+	return SaveTxtWith(path, a, SaveTxtOptions{})
+}
+
+// SaveTxtWith is SaveTxt with control over the number format, delimiter,
+// header and footer
+func SaveTxtWith(path string, a *tensor.NDArray, opts SaveTxtOptions) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteTxt(f, a, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteTxt writes a 1D or 2D array to w as text. A 1D array is written
+// one value per line. Complex values are written as "(re+imj)" so that
+// NumPy can read them back.
+func WriteTxt(w goio.Writer, a *tensor.NDArray, opts SaveTxtOptions) error {
+	format := opts.Fmt
+	if format == "" {
+		format = "%.18e"
+	}
+	delimiter := opts.Delimiter
+	if delimiter == "" {
+		delimiter = " "
+	}
+	comments := opts.Comments
+	if comments == "" {
+		comments = "# "
+	}
+	
+	shape := a.Shape()
+	var rows, cols int
+	switch len(shape) {
+	case 1:
+		rows, cols = shape[0], 1
+	case 2:
+		rows, cols = shape[0], shape[1]
+	default:
+		return fmt.Errorf("SaveTxt expects a 1D or 2D array, got %dD", len(shape))
+	}
+	
+	bw := bufio.NewWriter(w)
+	writeComment(bw, opts.Header, comments)
+	
+	// Integer verbs such as "%d" format integer arrays exactly
+	complexData := a.DType().IsComplex()
+	intData := a.DType().IsInt() && strings.HasSuffix(format, "d")
+	var values []float64
+	var ivalues []int64
+	var cvalues []complex128
+	switch {
+	case complexData:
+		cvalues = a.ToSliceComplex128()
+	case intData:
+		ivalues = a.ToSliceInt64()
+	default:
+		values = a.ToSliceFloat64()
+	}
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if j > 0 {
+				bw.WriteString(delimiter)
+			}
+			k := i*cols + j
+			if complexData {
+				re := fmt.Sprintf(format, real(cvalues[k]))
+				im := fmt.Sprintf(format, imag(cvalues[k]))
+				if !strings.HasPrefix(im, "-") {
+					im = "+" + im
+				}
+				bw.WriteString("(" + re + im + "j)")
+			} else if intData {
+				fmt.Fprintf(bw, format, ivalues[k])
+			} else {
+				fmt.Fprintf(bw, format, values[k])
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	
+	writeComment(bw, opts.Footer, comments)
+	return bw.Flush()
+}
+
+// writeComment writes each line of text prefixed by comments
+func writeComment(w *bufio.Writer, text, comments string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		w.WriteString(comments + line + "\n")
+	}
+}
@@ -0,0 +1,189 @@
+package io
+
+import (
+	"encoding/binary"
+	"fmt"
+	goio "io"
+	"os"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// WAV format codes from the fmt chunk
+const (
+	wavPCM        = 1
+	wavFloat      = 3
+	wavExtensible = 0xfffe
+)
+
+// LoadWAV reads the PCM WAV file at path. It returns the samples as an
+// array of shape (samples, channels) together with the sample rate in Hz.
+func LoadWAV(path string) (*tensor.NDArray, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	return ReadWAV(f)
+}
+
+// ReadWAV reads a PCM WAV stream from r, like scipy.io.wavfile.read. 8-bit
+// data loads as Uint8, 16- and 32-bit integer data as Int16 and Int32, and
+// IEEE float data as Float32 or Float64. 24-bit data loads as Int32 scaled
+// to fill the 32-bit range.
+func ReadWAV(r goio.Reader) (*tensor.NDArray, int, error) {
+	var riff [12]byte
+	if _, err := goio.ReadFull(r, riff[:]); err != nil {
+		return nil, 0, fmt.Errorf("reading WAV header: %w", err)
+	}
+	if string(riff[:4]) != "RIFF" || string(riff[8:]) != "WAVE" {
+		return nil, 0, fmt.Errorf("not a WAV file: bad RIFF header %q", riff[:])
+	}
+	
+	var format, channels, bits, rate int
+	for {
+		var head [8]byte
+		if _, err := goio.ReadFull(r, head[:]); err != nil {
+			if rate == 0 {
+				return nil, 0, fmt.Errorf("WAV file has no fmt chunk")
+			}
+			return nil, 0, fmt.Errorf("WAV file has no data chunk")
+		}
+		id, size := string(head[:4]), int(binary.LittleEndian.Uint32(head[4:]))
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, 0, fmt.Errorf("WAV fmt chunk of %d bytes is too short", size)
+			}
+			chunk := make([]byte, size+size%2)
+			if _, err := goio.ReadFull(r, chunk); err != nil {
+				return nil, 0, fmt.Errorf("reading WAV fmt chunk: %w", err)
+			}
+			format = int(binary.LittleEndian.Uint16(chunk))
+			channels = int(binary.LittleEndian.Uint16(chunk[2:]))
+			rate = int(binary.LittleEndian.Uint32(chunk[4:]))
+			bits = int(binary.LittleEndian.Uint16(chunk[14:]))
+			if format == wavExtensible && size >= 26 {
+				// The subformat GUID starts with the format code
+				format = int(binary.LittleEndian.Uint16(chunk[24:]))
+			}
+		case "data":
+			if rate == 0 {
+				return nil, 0, fmt.Errorf("WAV data chunk precedes the fmt chunk")
+			}
+			data := make([]byte, size)
+			if _, err := goio.ReadFull(r, data); err != nil {
+				return nil, 0, fmt.Errorf("reading WAV data: %w", err)
+			}
+			a, err := wavSamples(data, format, channels, bits)
+			return a, rate, err
+		default:
+			if _, err := goio.CopyN(goio.Discard, r, int64(size+size%2)); err != nil {
+				return nil, 0, fmt.Errorf("skipping WAV %q chunk: %w", id, err)
+			}
+		}
+	}
+}
+
+// wavSamples converts the contents of a data chunk to a (samples, channels)
+// array
+func wavSamples(data []byte, format, channels, bits int) (*tensor.NDArray, error) {
+	if channels < 1 {
+		return nil, fmt.Errorf("WAV file has %d channels", channels)
+	}
+	var dtype tensor.DType
+	switch {
+	case format == wavPCM && bits == 8:
+		dtype = tensor.Uint8
+	case format == wavPCM && bits == 16:
+		dtype = tensor.Int16
+	case format == wavPCM && (bits == 24 || bits == 32):
+		dtype = tensor.Int32
+	case format == wavFloat && bits == 32:
+		dtype = tensor.Float32
+	case format == wavFloat && bits == 64:
+		dtype = tensor.Float64
+	default:
+		return nil, fmt.Errorf("unsupported WAV format %d with %d bits per sample", format, bits)
+	}
+	
+	frame := channels * bits / 8
+	n := len(data) / frame
+	data = data[:n*frame]
+	if bits == 24 {
+		wide := make([]byte, 4*n*channels)
+		for i := 0; i < n*channels; i++ {
+			copy(wide[4*i+1:4*i+4], data[3*i:3*i+3])
+		}
+		data = wide
+	}
+	return tensor.FromBytes(data, dtype, n, channels), nil
+}
+
+// SaveWAV writes a to path as a WAV file with the given sample rate in Hz
+func SaveWAV(path string, a *tensor.NDArray, rate int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteWAV(f, a, rate); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteWAV writes a to w in WAV format. a is an array of shape (samples,
+// channels), or a 1D array for mono audio. The dtype picks the sample
+// format: Uint8, Int16 and Int32 are written as PCM and Float32 and Float64
+// as IEEE float.
+func WriteWAV(w goio.Writer, a *tensor.NDArray, rate int) error {
+	shape := a.Shape()
+	channels := 1
+	switch len(shape) {
+	case 1:
+	case 2:
+		channels = shape[1]
+	default:
+		return fmt.Errorf("expected a 1D or 2D array, got shape %v", shape)
+	}
+	if channels < 1 || channels > 0xffff {
+		return fmt.Errorf("cannot write %d channels to a WAV file", channels)
+	}
+	
+	format := wavPCM
+	switch a.DType() {
+	case tensor.Uint8, tensor.Int16, tensor.Int32:
+	case tensor.Float32, tensor.Float64:
+		format = wavFloat
+	default:
+		return fmt.Errorf("cannot write %s samples to a WAV file", a.DType())
+	}
+	bits := 8 * a.DType().ItemSize()
+	data := a.Data()
+	if uint64(len(data)) > 0xffffffff-36 {
+		return fmt.Errorf("%d bytes of samples exceed the 4 GiB WAV limit", len(data))
+	}
+	
+	header := make([]byte, 44)
+	copy(header, "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(36+len(data)+len(data)%2))
+	copy(header[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)
+	binary.LittleEndian.PutUint16(header[20:], uint16(format))
+	binary.LittleEndian.PutUint16(header[22:], uint16(channels))
+	binary.LittleEndian.PutUint32(header[24:], uint32(rate))
+	binary.LittleEndian.PutUint32(header[28:], uint32(rate*channels*bits/8))
+	binary.LittleEndian.PutUint16(header[32:], uint16(channels*bits/8))
+	binary.LittleEndian.PutUint16(header[34:], uint16(bits))
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], uint32(len(data)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if len(data)%2 == 1 {
+		data = append(data, 0)
+	}
+	_, err := w.Write(data)
+	return err
+}
@@ -0,0 +1,152 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// SolveTridiag solves the tridiagonal system A x = b in O(n) with the
+// Thomas algorithm. dl is the subdiagonal (length n-1), d the diagonal
+// (length n) and du the superdiagonal (length n-1). b may be a vector of
+// shape (n) or a matrix of shape (n, k); the result has the same shape.
+//
+// The Thomas algorithm does not pivot, so it is only guaranteed to be
+// stable for diagonally dominant or symmetric positive definite matrices,
+// as arise from spline interpolation and PDE discretizations. Use
+// SolveBanded for general tridiagonal matrices.
+func SolveTridiag(dl, d, du, b *tensor.NDArray) *tensor.NDArray {
+	n := d.Size()
+	if dl.Size() != n-1 || du.Size() != n-1 {
+		panic(fmt.Sprintf("SolveTridiag: off-diagonals must have length %d, got %d and %d", n-1, dl.Size(), du.Size()))
+	}
+	rhs, k := rhsToDense(b, n)
+	
+	lower := dl.ToSliceFloat64()
+	diag := d.ToSliceFloat64()
+	upper := du.ToSliceFloat64()
+	
+	// Forward sweep: eliminate the subdiagonal
+	cp := make([]float64, n)
+	for i := 0; i < n; i++ {
+		denom := diag[i]
+		if i > 0 {
+			denom -= lower[i-1] * cp[i-1]
+		}
+		if denom == 0 {
+			panic("SolveTridiag: zero pivot encountered (matrix is singular or needs pivoting)")
+		}
+		if i < n-1 {
+			cp[i] = upper[i] / denom
+		}
+		for c := 0; c < k; c++ {
+			v := rhs[i*k+c]
+			if i > 0 {
+				v -= lower[i-1] * rhs[(i-1)*k+c]
+			}
+			rhs[i*k+c] = v / denom
+		}
+	}
+	
+	// Back substitution
+	for i := n - 2; i >= 0; i-- {
+		for c := 0; c < k; c++ {
+			rhs[i*k+c] -= cp[i] * rhs[(i+1)*k+c]
+		}
+	}
+	
+	if b.Ndim() == 1 {
+		return tensor.FromSliceFloat64(rhs, n)
+	}
+	return fromDense(rhs, n, k)
+}
+
+// SolveBanded solves A x = b where A is a banded matrix with l sub-diagonals
+// and u super-diagonals, using Gaussian elimination with partial pivoting
+// in O(n (l+u) l) time. A is given in the diagonal-ordered form used by
+// scipy.linalg.solve_banded: ab has shape (l+u+1, n) and
+// ab[u+i-j, j] = A[i, j]. b may be a vector of shape (n) or a matrix of
+// shape (n, k); the result has the same shape.
+func SolveBanded(l, u int, ab, b *tensor.NDArray) *tensor.NDArray {
+	if l < 0 || u < 0 {
+		panic("SolveBanded: l and u must be non-negative")
+	}
+	if ab.Ndim() != 2 || ab.Shape()[0] != l+u+1 {
+		panic(fmt.Sprintf("SolveBanded: ab must have shape (%d, n), got %v", l+u+1, ab.Shape()))
+	}
+	n := ab.Shape()[1]
+	rhs, k := rhsToDense(b, n)
+	
+	// Row i stores columns i-l .. i+u+l; the extra l columns hold fill-in
+	// created by row interchanges
+	width := 2*l + u + 1
+	work := make([]float64, n*width)
+	at := func(i, j int) *float64 {
+		return &work[i*width+j-i+l]
+	}
+	for j := 0; j < n; j++ {
+		for i := max(0, j-u); i <= min(n-1, j+l); i++ {
+			*at(i, j) = ab.GetFloat64(u+i-j, j)
+		}
+	}
+	
+	for col := 0; col < n; col++ {
+		last := min(n-1, col+l)
+		right := min(n-1, col+u+l)
+		
+		// Partial pivoting within the band
+		p := col
+		for i := col + 1; i <= last; i++ {
+			if math.Abs(*at(i, col)) > math.Abs(*at(p, col)) {
+				p = i
+			}
+		}
+		if *at(p, col) == 0 {
+			panic("matrix is singular")
+		}
+		if p != col {
+			for j := col; j <= right; j++ {
+				*at(col, j), *at(p, j) = *at(p, j), *at(col, j)
+			}
+			for c := 0; c < k; c++ {
+				rhs[col*k+c], rhs[p*k+c] = rhs[p*k+c], rhs[col*k+c]
+			}
+		}
+		
+		pivot := *at(col, col)
+		for i := col + 1; i <= last; i++ {
+			factor := *at(i, col) / pivot
+			if factor == 0 {
+				continue
+			}
+			*at(i, col) = 0
+			for j := col + 1; j <= right; j++ {
+				*at(i, j) -= factor * *at(col, j)
+			}
+			for c := 0; c < k; c++ {
+				rhs[i*k+c] -= factor * rhs[col*k+c]
+			}
+		}
+	}
+	
+	// Back substitution over the widened upper band
+	for i := n - 1; i >= 0; i-- {
+		right := min(n-1, i+u+l)
+		for j := i + 1; j <= right; j++ {
+			coef := *at(i, j)
+			for c := 0; c < k; c++ {
+				rhs[i*k+c] -= coef * rhs[j*k+c]
+			}
+		}
+		d := *at(i, i)
+		for c := 0; c < k; c++ {
+			rhs[i*k+c] /= d
+		}
+	}
+	
+	if b.Ndim() == 1 {
+		return tensor.FromSliceFloat64(rhs, n)
+	}
+	return fromDense(rhs, n, k)
+}
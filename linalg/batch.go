@@ -0,0 +1,150 @@
+package linalg
+
+import (
+	"fmt"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// splitBatch splits the shape of a stack of matrices (..., m, n) into its
+// batch dimensions, the number of matrices, and the matrix shape
+func splitBatch(a *tensor.NDArray) (batch []int, count, m, n int) {
+	if a.Ndim() < 2 {
+		panic(fmt.Sprintf("expected at least a 2D array, got %dD", a.Ndim()))
+	}
+	shape := a.Shape()
+	batch = shape[:len(shape)-2]
+	count = 1
+	for _, dim := range batch {
+		count *= dim
+	}
+	return batch, count, shape[len(shape)-2], shape[len(shape)-1]
+}
+
+// unstack splits an array whose trailing dimensions have shape inner into
+// count separate arrays of that shape, keeping its dtype
+func unstack(a *tensor.NDArray, count int, inner []int) []*tensor.NDArray {
+	data := a.Data()
+	width := a.DType().ItemSize()
+	for _, dim := range inner {
+		width *= dim
+	}
+	
+	parts := make([]*tensor.NDArray, count)
+	for i := range parts {
+		parts[i] = tensor.WrapBytes(data[i*width:(i+1)*width:(i+1)*width], a.DType(), inner...)
+	}
+	return parts
+}
+
+// restack joins equally shaped arrays of one dtype into one array with
+// leading batch dimensions
+func restack(parts []*tensor.NDArray, batch []int) *tensor.NDArray {
+	inner := parts[0].Shape()
+	data := make([]byte, 0, len(parts)*parts[0].Size()*parts[0].DType().ItemSize())
+	for _, p := range parts {
+		data = append(data, p.Data()...)
+	}
+	shape := append(append([]int{}, batch...), inner...)
+	return tensor.WrapBytes(data, parts[0].DType(), shape...)
+}
+
+// mapMatrices applies f to every matrix in a stack of shape (..., m, n) and
+// stacks the results behind the same batch dimensions
+func mapMatrices(a *tensor.NDArray, f func(*tensor.NDArray) *tensor.NDArray) *tensor.NDArray {
+	batch, count, m, n := splitBatch(a)
+	if count == 0 {
+		panic("cannot operate on an empty stack of matrices")
+	}
+	mats := unstack(a, count, []int{m, n})
+	
+	results := make([]*tensor.NDArray, count)
+	for i, mat := range mats {
+		results[i] = f(mat)
+	}
+	return restack(results, batch)
+}
+
+// DetBatched computes the determinants of a stack of square matrices with
+// shape (..., n, n), returning an array with the batch shape (...). A
+// single 2D matrix yields a 1-element array. Complex stacks give complex128
+// determinants.
+func DetBatched(a *tensor.NDArray) *tensor.NDArray {
+	batch, count, m, n := splitBatch(a)
+	if m != n {
+		panic("Det requires square matrices")
+	}
+	
+	shape := batch
+	if len(batch) == 0 {
+		shape = []int{1}
+	}
+	mats := unstack(a, count, []int{m, n})
+	if a.DType().IsComplex() {
+		dets := make([]complex128, count)
+		for i, mat := range mats {
+			dets[i] = DetComplex(mat)
+		}
+		return tensor.FromSliceComplex128(dets, shape...)
+	}
+	
+	dets := make([]float64, count)
+	for i, mat := range mats {
+		dets[i] = Det(mat)
+	}
+	return tensor.FromSliceFloat64(dets, shape...)
+}
+
+// solveBatched solves a stack of systems A[i] x[i] = b[i]. b is either a
+// stack of vectors (..., n), a stack of matrices (..., n, k), or a single
+// 1D/2D right-hand side shared by every system. A b whose leading
+// dimensions match the batch is always treated as a stack.
+func solveBatched(a, b *tensor.NDArray) *tensor.NDArray {
+	batch, count, m, n := splitBatch(a)
+	if m != n {
+		panic("Solve requires square matrices")
+	}
+	mats := unstack(a, count, []int{n, n})
+	
+	var rhs []*tensor.NDArray
+	bShape := b.Shape()
+	inner := bShape
+	switch {
+	case (b.Ndim() == a.Ndim()-1 || b.Ndim() == a.Ndim()) && sameBatch(bShape, batch):
+		inner = bShape[len(batch):]
+		rhs = unstack(b, count, inner)
+	case b.Ndim() <= 2:
+		rhs = make([]*tensor.NDArray, count)
+		for i := range rhs {
+			rhs[i] = b
+		}
+	default:
+		panic(fmt.Sprintf("batch dimensions of a %v and b %v do not match", a.Shape(), bShape))
+	}
+	if count == 0 {
+		dtype := tensor.Float64
+		if anyComplex(a, b) {
+			dtype = tensor.Complex128
+		}
+		return tensor.Zeros(append(append([]int{}, batch...), inner...), dtype)
+	}
+	
+	results := make([]*tensor.NDArray, count)
+	for i := range mats {
+		results[i] = Solve(mats[i], rhs[i])
+	}
+	return restack(results, batch)
+}
+
+// sameBatch reports whether shape starts with the batch dimensions
+func sameBatch(shape, batch []int) bool {
+	if len(shape) < len(batch) {
+		return false
+	}
+	for i, dim := range batch {
+		if shape[i] != dim {
+			return false
+		}
+	}
+	return true
+}
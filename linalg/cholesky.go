@@ -0,0 +1,43 @@
+package linalg
+
+import (
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Cholesky computes the Cholesky factorization A = L L^T of a symmetric
+// positive definite matrix, returning the lower triangular factor L. Only
+// the lower triangle of a is read. It panics if the matrix is not positive
+// definite. Stacks of matrices with shape (..., n, n) are factored
+// independently.
+func Cholesky(a *tensor.NDArray) *tensor.NDArray {
+	if a.Ndim() > 2 {
+		return mapMatrices(a, Cholesky)
+	}
+	
+	data, n := toSquareDense(a, "Cholesky")
+	l := make([]float64, n*n)
+	
+	for j := 0; j < n; j++ {
+		sum := data[j*n+j]
+		for k := 0; k < j; k++ {
+			sum -= l[j*n+k] * l[j*n+k]
+		}
+		if sum <= 0 {
+			panic("matrix is not positive definite")
+		}
+		ljj := math.Sqrt(sum)
+		l[j*n+j] = ljj
+		
+		for i := j + 1; i < n; i++ {
+			sum := data[i*n+j]
+			for k := 0; k < j; k++ {
+				sum -= l[i*n+k] * l[j*n+k]
+			}
+			l[i*n+j] = sum / ljj
+		}
+	}
+	
+	return fromDense(l, n, n)
+}
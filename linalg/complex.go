@@ -0,0 +1,307 @@
+package linalg
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/cmplx"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// anyComplex reports whether any of the arrays has a complex dtype
+func anyComplex(arrays ...*tensor.NDArray) bool {
+	for _, a := range arrays {
+		if a.DType().IsComplex() {
+			return true
+		}
+	}
+	return false
+}
+
+// ConjTranspose returns the conjugate (Hermitian) transpose A^H of a 2D
+// array. For real arrays it is the same as Transpose.
+func ConjTranspose(a *tensor.NDArray) *tensor.NDArray {
+	if a.Ndim() != 2 {
+		panic("ConjTranspose requires a 2D array")
+	}
+	return a.Transpose().Conj()
+}
+
+// toComplexDense copies a 2D array into a row-major complex128 slice
+func toComplexDense(a *tensor.NDArray) ([]complex128, int, int) {
+	if a.Ndim() != 2 {
+		panic(fmt.Sprintf("expected a 2D array, got %dD", a.Ndim()))
+	}
+	shape := a.Shape()
+	return a.ToSliceComplex128(), shape[0], shape[1]
+}
+
+// toSquareComplexDense copies a square 2D array into a row-major
+// complex128 slice, panicking with a message naming the calling function
+// otherwise
+func toSquareComplexDense(a *tensor.NDArray, name string) ([]complex128, int) {
+	if a.Ndim() != 2 {
+		panic(fmt.Sprintf("%s requires a 2D array", name))
+	}
+	shape := a.Shape()
+	if shape[0] != shape[1] {
+		panic(fmt.Sprintf("%s requires a square matrix", name))
+	}
+	return a.ToSliceComplex128(), shape[0]
+}
+
+// fromComplexDense wraps a row-major complex128 slice as an m x n array
+func fromComplexDense(data []complex128, m, n int) *tensor.NDArray {
+	return tensor.FromSliceComplex128(data, m, n)
+}
+
+// magnitudeDense copies a 2D array into a row-major float64 slice of
+// element magnitudes, so that entrywise norms work for complex inputs
+func magnitudeDense(a *tensor.NDArray) ([]float64, int, int) {
+	if !a.DType().IsComplex() {
+		return toDense(a)
+	}
+	values, m, n := toComplexDense(a)
+	data := make([]float64, len(values))
+	for i, v := range values {
+		data[i] = cmplx.Abs(v)
+	}
+	return data, m, n
+}
+
+// matMulComplex multiplies two 2D arrays in complex arithmetic, checking
+// ctx between rows of the result
+func matMulComplex(ctx context.Context, a, b *tensor.NDArray) (*tensor.NDArray, error) {
+	aData, m, n := toComplexDense(a)
+	bData, _, p := toComplexDense(b)
+	
+	result := make([]complex128, m*p)
+	for i := 0; i < m; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for k := 0; k < n; k++ {
+			aik := aData[i*n+k]
+			if aik == 0 {
+				continue
+			}
+			for j := 0; j < p; j++ {
+				result[i*p+j] += aik * bData[k*p+j]
+			}
+		}
+	}
+	
+	return fromComplexDense(result, m, p), nil
+}
+
+// solveComplex solves A x = b in complex arithmetic using LU decomposition
+// with partial pivoting
+func solveComplex(a, b *tensor.NDArray) *tensor.NDArray {
+	if a.Ndim() != 2 {
+		panic("Solve requires a 2D coefficient matrix")
+	}
+	lu, n, cols := toComplexDense(a)
+	if n != cols {
+		panic("Solve requires a square coefficient matrix")
+	}
+	
+	var k int
+	switch {
+	case b.Ndim() == 1 && b.Size() == n:
+		k = 1
+	case b.Ndim() == 2 && b.Shape()[0] == n:
+		k = b.Shape()[1]
+	default:
+		panic(fmt.Sprintf("b has shape %v, incompatible with a %dx%d matrix", b.Shape(), n, n))
+	}
+	rhs := b.ToSliceComplex128()
+	
+	piv, ok := cluFactor(lu, n)
+	if !ok {
+		panic("matrix is singular")
+	}
+	cluSolve(lu, piv, n, rhs, k)
+	
+	if b.Ndim() == 1 {
+		return tensor.FromSliceComplex128(rhs, n)
+	}
+	return fromComplexDense(rhs, n, k)
+}
+
+// invComplex inverts a square complex matrix by solving A X = I
+func invComplex(a *tensor.NDArray) *tensor.NDArray {
+	lu, n := toSquareComplexDense(a, "Inv")
+	piv, ok := cluFactor(lu, n)
+	if !ok {
+		panic("matrix is singular (not invertible)")
+	}
+	inv := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		inv[i*n+i] = 1
+	}
+	cluSolve(lu, piv, n, inv, n)
+	return fromComplexDense(inv, n, n)
+}
+
+// DetComplex computes the determinant of a square real or complex matrix
+// in complex128 via LU decomposition with partial pivoting
+func DetComplex(a *tensor.NDArray) complex128 {
+	lu, n := toSquareComplexDense(a, "DetComplex")
+	piv, ok := cluFactor(lu, n)
+	if !ok {
+		return 0
+	}
+	
+	// Each transposition in the permutation flips the sign
+	det := complex(1, 0)
+	seen := make([]bool, n)
+	for i := 0; i < n; i++ {
+		det *= lu[i*n+i]
+		if seen[i] {
+			continue
+		}
+		length := 0
+		for j := i; !seen[j]; j = piv[j] {
+			seen[j] = true
+			length++
+		}
+		if length%2 == 0 {
+			det = -det
+		}
+	}
+	return det
+}
+
+// dotComplex is Dot for a complex 1D or 2D a and a 1D b, without
+// conjugation
+func dotComplex(a, b *tensor.NDArray) *tensor.NDArray {
+	x := b.ToSliceComplex128()
+	if a.Ndim() == 1 {
+		if a.Size() != b.Size() {
+			panic(fmt.Sprintf("arrays must have same length: %d vs %d", a.Size(), b.Size()))
+		}
+		var sum complex128
+		for i, v := range a.ToSliceComplex128() {
+			sum += v * x[i]
+		}
+		return tensor.FromSliceComplex128([]complex128{sum}, 1)
+	}
+	
+	data, m, n := toComplexDense(a)
+	if n != len(x) {
+		panic(fmt.Sprintf("dimension mismatch: (%d,%d) x (%d)", m, n, len(x)))
+	}
+	result := make([]complex128, m)
+	for i := range result {
+		for j, v := range x {
+			result[i] += data[i*n+j] * v
+		}
+	}
+	return tensor.FromSliceComplex128(result, m)
+}
+
+// cluFactor is the complex analogue of luFactor: it factors the row-major
+// n x n matrix lu in place as P A = L U, returning the row permutation and
+// false if A is singular
+func cluFactor(lu []complex128, n int) ([]int, bool) {
+	piv := make([]int, n)
+	for i := range piv {
+		piv[i] = i
+	}
+	ok := true
+	
+	for k := 0; k < n; k++ {
+		p := k
+		maxVal := cmplx.Abs(lu[k*n+k])
+		for i := k + 1; i < n; i++ {
+			if v := cmplx.Abs(lu[i*n+k]); v > maxVal {
+				maxVal = v
+				p = i
+			}
+		}
+		
+		if p != k {
+			for j := 0; j < n; j++ {
+				lu[k*n+j], lu[p*n+j] = lu[p*n+j], lu[k*n+j]
+			}
+			piv[k], piv[p] = piv[p], piv[k]
+		}
+		
+		pivot := lu[k*n+k]
+		if pivot == 0 {
+			ok = false
+			continue
+		}
+		
+		for i := k + 1; i < n; i++ {
+			factor := lu[i*n+k] / pivot
+			lu[i*n+k] = factor
+			if factor == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				lu[i*n+j] -= factor * lu[k*n+j]
+			}
+		}
+	}
+	
+	return piv, ok
+}
+
+// cluSolve solves A X = B in place given the factorization from cluFactor,
+// where B is a row-major n x k matrix
+func cluSolve(lu []complex128, piv []int, n int, b []complex128, k int) {
+	permuted := make([]complex128, n*k)
+	for i := 0; i < n; i++ {
+		copy(permuted[i*k:(i+1)*k], b[piv[i]*k:(piv[i]+1)*k])
+	}
+	copy(b, permuted)
+	
+	// Forward substitution with unit lower triangular L
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			lij := lu[i*n+j]
+			if lij == 0 {
+				continue
+			}
+			for c := 0; c < k; c++ {
+				b[i*k+c] -= lij * b[j*k+c]
+			}
+		}
+	}
+	
+	// Back substitution with upper triangular U
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			uij := lu[i*n+j]
+			if uij == 0 {
+				continue
+			}
+			for c := 0; c < k; c++ {
+				b[i*k+c] -= uij * b[j*k+c]
+			}
+		}
+		for c := 0; c < k; c++ {
+			b[i*k+c] /= lu[i*n+i]
+		}
+	}
+}
+
+// cvecDot returns the conjugated inner product a^H b
+func cvecDot(a, b []complex128) complex128 {
+	var sum complex128
+	for i := range a {
+		sum += cmplx.Conj(a[i]) * b[i]
+	}
+	return sum
+}
+
+// cvecNorm returns the Euclidean norm of a complex slice
+func cvecNorm(a []complex128) float64 {
+	sum := 0.0
+	for _, v := range a {
+		sum += real(v)*real(v) + imag(v)*imag(v)
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,239 @@
+package linalg
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// eigvalsComplex computes the eigenvalues of a row-major n x n complex
+// matrix by Householder reduction to Hessenberg form followed by the
+// single-shift QR algorithm with Wilkinson shifts. It reports false if the
+// iteration failed to converge.
+func eigvalsComplex(data []complex128, n int) ([]complex128, bool) {
+	h := make([]complex128, n*n)
+	copy(h, data)
+	complexHessenberg(h, n)
+	
+	values := make([]complex128, n)
+	hi := n - 1
+	iter, totalIter := 0, 0
+	for hi >= 0 {
+		if hi == 0 {
+			values[0] = h[0]
+			break
+		}
+		
+		// Look for a negligible subdiagonal element
+		l := hi
+		for l > 0 {
+			s := cmplx.Abs(h[(l-1)*n+l-1]) + cmplx.Abs(h[l*n+l])
+			if cmplx.Abs(h[l*n+l-1]) <= epsilon*s {
+				h[l*n+l-1] = 0
+				break
+			}
+			l--
+		}
+		
+		if l == hi {
+			values[hi] = h[hi*n+hi]
+			hi--
+			iter = 0
+			continue
+		}
+		
+		if totalIter >= 30*n {
+			return values, false
+		}
+		
+		// Wilkinson shift: the eigenvalue of the trailing 2x2 block closer
+		// to its last diagonal entry, with occasional exceptional shifts
+		a, b := h[(hi-1)*n+hi-1], h[(hi-1)*n+hi]
+		c, d := h[hi*n+hi-1], h[hi*n+hi]
+		disc := cmplx.Sqrt((a-d)*(a-d)/4 + b*c)
+		mu := (a+d)/2 + disc
+		if alt := (a+d)/2 - disc; cmplx.Abs(alt-d) < cmplx.Abs(mu-d) {
+			mu = alt
+		}
+		if iter > 0 && iter%10 == 0 {
+			mu = d + complex(0.75*cmplx.Abs(c), 0)
+		}
+		iter++
+		totalIter++
+		
+		// QR step on the active block l..hi using Givens rotations
+		for k := l; k <= hi; k++ {
+			h[k*n+k] -= mu
+		}
+		cs := make([]float64, hi-l)
+		sn := make([]complex128, hi-l)
+		for k := l; k < hi; k++ {
+			c, s := complexGivens(h[k*n+k], h[(k+1)*n+k])
+			cs[k-l], sn[k-l] = c, s
+			for j := k; j <= hi; j++ {
+				x, y := h[k*n+j], h[(k+1)*n+j]
+				h[k*n+j] = complex(c, 0)*x + s*y
+				h[(k+1)*n+j] = -cmplx.Conj(s)*x + complex(c, 0)*y
+			}
+		}
+		for k := l; k < hi; k++ {
+			c, s := cs[k-l], sn[k-l]
+			for i := l; i <= min(k+2, hi); i++ {
+				x, y := h[i*n+k], h[i*n+k+1]
+				h[i*n+k] = complex(c, 0)*x + cmplx.Conj(s)*y
+				h[i*n+k+1] = -s*x + complex(c, 0)*y
+			}
+		}
+		for k := l; k <= hi; k++ {
+			h[k*n+k] += mu
+		}
+	}
+	
+	return values, true
+}
+
+// complexGivens returns c (real) and s such that the rotation
+// [c s; -conj(s) c] maps (a, b) to (r, 0)
+func complexGivens(a, b complex128) (float64, complex128) {
+	if b == 0 {
+		return 1, 0
+	}
+	if a == 0 {
+		return 0, cmplx.Conj(b) / complex(cmplx.Abs(b), 0)
+	}
+	absA := cmplx.Abs(a)
+	r := math.Hypot(absA, cmplx.Abs(b))
+	return absA / r, (a / complex(absA, 0)) * cmplx.Conj(b) / complex(r, 0)
+}
+
+// complexHessenberg reduces the row-major n x n complex matrix h to upper
+// Hessenberg form in place by Householder similarity transformations
+func complexHessenberg(h []complex128, n int) {
+	for k := 0; k < n-2; k++ {
+		x := make([]complex128, n-k-1)
+		for i := range x {
+			x[i] = h[(k+1+i)*n+k]
+		}
+		norm := cvecNorm(x)
+		if norm == 0 {
+			continue
+		}
+		
+		// v = x + e^{i arg x0} ||x|| e1, normalized
+		phase := complex(1, 0)
+		if x[0] != 0 {
+			phase = x[0] / complex(cmplx.Abs(x[0]), 0)
+		}
+		x[0] += phase * complex(norm, 0)
+		vnorm := cvecNorm(x)
+		for i := range x {
+			x[i] /= complex(vnorm, 0)
+		}
+		
+		// H = (I - 2 v v^H) H
+		for j := 0; j < n; j++ {
+			var dot complex128
+			for i := range x {
+				dot += cmplx.Conj(x[i]) * h[(k+1+i)*n+j]
+			}
+			dot *= 2
+			for i := range x {
+				h[(k+1+i)*n+j] -= x[i] * dot
+			}
+		}
+		// H = H (I - 2 v v^H)
+		for i := 0; i < n; i++ {
+			var dot complex128
+			for j := range x {
+				dot += h[i*n+k+1+j] * x[j]
+			}
+			dot *= 2
+			for j := range x {
+				h[i*n+k+1+j] -= dot * cmplx.Conj(x[j])
+			}
+		}
+		
+		for i := k + 2; i < n; i++ {
+			h[i*n+k] = 0
+		}
+	}
+}
+
+// eigenvectorsComplex computes a unit eigenvector for each eigenvalue by
+// inverse iteration on the row-major n x n matrix data. Vectors belonging
+// to repeated eigenvalues are kept orthogonal to each other so that
+// diagonalizable matrices get independent eigenvectors. The result is
+// returned as row-major n x n with eigenvectors in the columns.
+func eigenvectorsComplex(data []complex128, n int, values []complex128) []complex128 {
+	scale := 0.0
+	for _, v := range data {
+		scale = math.Max(scale, cmplx.Abs(v))
+	}
+	if scale == 0 {
+		scale = 1
+	}
+	perturb := complex(scale*1e3*epsilon, 0)
+	
+	vectors := make([][]complex128, n)
+	for k, lambda := range values {
+		lu := make([]complex128, n*n)
+		copy(lu, data)
+		for i := 0; i < n; i++ {
+			lu[i*n+i] -= lambda + perturb
+		}
+		piv, _ := cluFactor(lu, n)
+		for i := 0; i < n; i++ {
+			if lu[i*n+i] == 0 {
+				lu[i*n+i] = perturb
+			}
+		}
+		
+		// Vectors of (numerically) equal eigenvalues found so far
+		var related [][]complex128
+		for j := 0; j < k; j++ {
+			if cmplx.Abs(values[j]-lambda) <= 1e3*epsilon*scale {
+				related = append(related, vectors[j])
+			}
+		}
+		
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(1/math.Sqrt(float64(n)), 0) + complex(0.1*float64((i*7+k*3)%11)/11, 0)
+		}
+		for iter := 0; iter < 4; iter++ {
+			for _, r := range related {
+				d := cvecDot(r, x)
+				for i := range x {
+					x[i] -= d * r[i]
+				}
+			}
+			cluSolve(lu, piv, n, x, 1)
+			norm := cvecNorm(x)
+			if norm == 0 || math.IsInf(norm, 0) || math.IsNaN(norm) {
+				break
+			}
+			for i := range x {
+				x[i] /= complex(norm, 0)
+			}
+		}
+		for _, r := range related {
+			d := cvecDot(r, x)
+			for i := range x {
+				x[i] -= d * r[i]
+			}
+		}
+		if norm := cvecNorm(x); norm > 0 {
+			for i := range x {
+				x[i] /= complex(norm, 0)
+			}
+		}
+		vectors[k] = x
+	}
+	
+	result := make([]complex128, n*n)
+	for j, vec := range vectors {
+		for i := 0; i < n; i++ {
+			result[i*n+j] = vec[i]
+		}
+	}
+	return result
+}
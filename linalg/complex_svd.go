@@ -0,0 +1,227 @@
+package linalg
+
+import (
+	"context"
+	"math"
+	"math/cmplx"
+	"sort"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// svdComplex is the complex analogue of SVDContext: it computes
+// A = U diag(S) V^H, returning Vt = V^H
+func svdComplex(ctx context.Context, a *tensor.NDArray, fullMatrices bool) (u, s, vt *tensor.NDArray, err error) {
+	data, m, n := toComplexDense(a)
+	
+	// For wide matrices decompose A^H = U' S V'^H, so A = V' S U'^H
+	transposed := m < n
+	if transposed {
+		data = conjTransposeDense(data, m, n)
+		m, n = n, m
+	}
+	
+	uCols, sv, vCols, err := jacobiSVDComplex(ctx, data, m, n)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	
+	uCount := n
+	vCount := n
+	if fullMatrices {
+		uCount = m
+	}
+	uCols = completeBasisComplex(uCols, m, uCount)
+	
+	if transposed {
+		uCols, vCols = vCols, uCols
+		uCount, vCount = vCount, uCount
+		m, n = n, m
+	}
+	
+	uData := make([]complex128, m*uCount)
+	for j := 0; j < uCount; j++ {
+		for i := 0; i < m; i++ {
+			uData[i*uCount+j] = uCols[j][i]
+		}
+	}
+	vtData := make([]complex128, vCount*n)
+	for j := 0; j < vCount; j++ {
+		for i := 0; i < n; i++ {
+			vtData[j*n+i] = cmplx.Conj(vCols[j][i])
+		}
+	}
+	
+	return fromComplexDense(uData, m, uCount), tensor.FromSliceFloat64(sv, len(sv)), fromComplexDense(vtData, vCount, n), nil
+}
+
+// singularValuesComplex returns the singular values of a complex matrix in
+// descending order
+func singularValuesComplex(a *tensor.NDArray) []float64 {
+	data, m, n := toComplexDense(a)
+	if m < n {
+		data = conjTransposeDense(data, m, n)
+		m, n = n, m
+	}
+	_, sv, _, _ := jacobiSVDComplex(context.Background(), data, m, n)
+	return sv
+}
+
+// jacobiSVDComplex is the complex analogue of jacobiSVD. Each column pair
+// is first rotated in phase so that their inner product is real, and then
+// orthogonalized with a real Jacobi rotation.
+func jacobiSVDComplex(ctx context.Context, data []complex128, m, n int) ([][]complex128, []float64, [][]complex128, error) {
+	cols := make([][]complex128, n)
+	for j := 0; j < n; j++ {
+		cols[j] = make([]complex128, m)
+		for i := 0; i < m; i++ {
+			cols[j][i] = data[i*n+j]
+		}
+	}
+	v := make([][]complex128, n)
+	for j := 0; j < n; j++ {
+		v[j] = make([]complex128, n)
+		v[j][j] = 1
+	}
+	
+	const eps = 1e-15
+	for sweep := 0; sweep < svdMaxSweeps; sweep++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
+		
+		rotated := false
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				cp, cq := cols[p], cols[q]
+				alpha := cvecNorm(cp)
+				beta := cvecNorm(cq)
+				alpha, beta = alpha*alpha, beta*beta
+				gammaC := cvecDot(cp, cq)
+				gamma := cmplx.Abs(gammaC)
+				if gamma == 0 || gamma <= eps*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+				
+				// Rotate column q by the phase of a_p^H a_q
+				phase := cmplx.Conj(gammaC) / complex(gamma, 0)
+				vp, vq := v[p], v[q]
+				for i := 0; i < m; i++ {
+					cq[i] *= phase
+				}
+				for i := 0; i < n; i++ {
+					vq[i] *= phase
+				}
+				
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := complex(1/math.Sqrt(1+t*t), 0)
+				s := c * complex(t, 0)
+				
+				for i := 0; i < m; i++ {
+					x, y := cp[i], cq[i]
+					cp[i] = c*x - s*y
+					cq[i] = s*x + c*y
+				}
+				for i := 0; i < n; i++ {
+					x, y := vp[i], vq[i]
+					vp[i] = c*x - s*y
+					vq[i] = s*x + c*y
+				}
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+	
+	sv := make([]float64, n)
+	for j := 0; j < n; j++ {
+		sv[j] = cvecNorm(cols[j])
+	}
+	
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return sv[order[i]] > sv[order[j]] })
+	
+	sorted := make([]float64, n)
+	uCols := make([][]complex128, n)
+	vCols := make([][]complex128, n)
+	smax := 0.0
+	if n > 0 {
+		smax = sv[order[0]]
+	}
+	tol := smax * eps * float64(m)
+	for k, j := range order {
+		sorted[k] = sv[j]
+		vCols[k] = v[j]
+		if sv[j] > tol {
+			inv := complex(1/sv[j], 0)
+			for i := range cols[j] {
+				cols[j][i] *= inv
+			}
+			uCols[k] = cols[j]
+		}
+	}
+	
+	return uCols, sorted, vCols, nil
+}
+
+// completeBasisComplex is the complex analogue of completeBasis
+func completeBasisComplex(cols [][]complex128, m, count int) [][]complex128 {
+	result := make([][]complex128, count)
+	copy(result, cols)
+	
+	candidate := 0
+	for j := 0; j < count; j++ {
+		if result[j] != nil {
+			continue
+		}
+		for candidate < m {
+			e := make([]complex128, m)
+			e[candidate] = 1
+			candidate++
+			
+			for pass := 0; pass < 2; pass++ {
+				for _, c := range result {
+					if c == nil {
+						continue
+					}
+					d := cvecDot(c, e)
+					for i := range e {
+						e[i] -= d * c[i]
+					}
+				}
+			}
+			
+			if norm := cvecNorm(e); norm > 1e-10 {
+				inv := complex(1/norm, 0)
+				for i := range e {
+					e[i] *= inv
+				}
+				result[j] = e
+				break
+			}
+		}
+	}
+	
+	return result
+}
+
+// conjTransposeDense returns the conjugate transpose of a row-major m x n
+// complex slice
+func conjTransposeDense(data []complex128, m, n int) []complex128 {
+	out := make([]complex128, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			out[j*m+i] = cmplx.Conj(data[i*n+j])
+		}
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// NormOrder selects which matrix norm MatrixNorm and Cond use
+type NormOrder int
+
+const (
+	// NormTwo is the spectral norm, the largest singular value
+	NormTwo NormOrder = iota
+	// NormOne is the maximum absolute column sum
+	NormOne
+	// NormInf is the maximum absolute row sum
+	NormInf
+	// NormFro is the Frobenius norm, the square root of the sum of squares
+	NormFro
+)
+
+// String returns the string representation of a NormOrder
+func (o NormOrder) String() string {
+	switch o {
+	case NormTwo:
+		return "2"
+	case NormOne:
+		return "1"
+	case NormInf:
+		return "inf"
+	case NormFro:
+		return "fro"
+	default:
+		return "unknown"
+	}
+}
+
+// MatrixNorm computes the given norm of a 2D array. Complex matrices use
+// element magnitudes.
+func MatrixNorm(a *tensor.NDArray, ord NormOrder) float64 {
+	data, m, n := magnitudeDense(a)
+	
+	switch ord {
+	case NormTwo:
+		sv := SingularValues(a)
+		if len(sv) == 0 {
+			return 0
+		}
+		return sv[0]
+	case NormOne:
+		best := 0.0
+		for j := 0; j < n; j++ {
+			sum := 0.0
+			for i := 0; i < m; i++ {
+				sum += math.Abs(data[i*n+j])
+			}
+			best = math.Max(best, sum)
+		}
+		return best
+	case NormInf:
+		best := 0.0
+		for i := 0; i < m; i++ {
+			sum := 0.0
+			for j := 0; j < n; j++ {
+				sum += math.Abs(data[i*n+j])
+			}
+			best = math.Max(best, sum)
+		}
+		return best
+	case NormFro:
+		return vecNorm(data)
+	default:
+		panic(fmt.Sprintf("unsupported norm order: %d", ord))
+	}
+}
+
+// Cond computes the condition number of a matrix in the given norm. Large
+// values mean that solutions of A x = b are sensitive to perturbations, so
+// results from Inv or Solve should not be trusted; singular matrices have
+// an infinite condition number.
+//
+// NormTwo is computed from singular values as s_max / s_min and accepts
+// non-square matrices. The other norms compute ||A|| * ||A^-1|| and require
+// a square matrix.
+func Cond(a *tensor.NDArray, ord NormOrder) float64 {
+	if ord == NormTwo {
+		sv := SingularValues(a)
+		if len(sv) == 0 {
+			return 0
+		}
+		smin := sv[len(sv)-1]
+		if smin == 0 {
+			return math.Inf(1)
+		}
+		return sv[0] / smin
+	}
+	
+	f := LUFactor(a)
+	if f.IsSingular() {
+		return math.Inf(1)
+	}
+	inv := f.Solve(tensor.Eye(f.n, tensor.Float64))
+	return MatrixNorm(a, ord) * MatrixNorm(inv, ord)
+}
@@ -0,0 +1,47 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// epsilon is the float64 machine epsilon
+var epsilon = math.Nextafter(1, 2) - 1
+
+// toDense copies a 2D array into a row-major float64 slice
+func toDense(a *tensor.NDArray) ([]float64, int, int) {
+	if a.Ndim() != 2 {
+		panic(fmt.Sprintf("expected a 2D array, got %dD", a.Ndim()))
+	}
+	shape := a.Shape()
+	m, n := shape[0], shape[1]
+	
+	data := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			data[i*n+j] = a.GetFloat64(i, j)
+		}
+	}
+	return data, m, n
+}
+
+// toSquareDense copies a square 2D array into a row-major float64 slice,
+// panicking with a message naming the calling function otherwise
+func toSquareDense(a *tensor.NDArray, name string) ([]float64, int) {
+	if a.Ndim() != 2 {
+		panic(fmt.Sprintf("%s requires a 2D array", name))
+	}
+	shape := a.Shape()
+	if shape[0] != shape[1] {
+		panic(fmt.Sprintf("%s requires a square matrix", name))
+	}
+	data, n, _ := toDense(a)
+	return data, n
+}
+
+// fromDense wraps a row-major float64 slice as an m x n array
+func fromDense(data []float64, m, n int) *tensor.NDArray {
+	return tensor.FromSliceFloat64(data, m, n)
+}
@@ -0,0 +1,433 @@
+package linalg
+
+import (
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Eigvals computes the eigenvalues of a general real square matrix without
+// computing eigenvectors. Eigenvalues of a real matrix may be complex; they
+// are returned in no particular order, with complex conjugate pairs
+// adjacent (positive imaginary part first).
+//
+// The matrix is reduced to upper Hessenberg form and then to real Schur
+// form with the Francis double-shift QR algorithm. Complex matrices use the
+// single-shift complex QR algorithm instead. The result is a complex128
+// array of length n, like the eigenvalues returned by Eig.
+func Eigvals(a *tensor.NDArray) *tensor.NDArray {
+	values := eigvals(a)
+	return tensor.FromSliceComplex128(values, len(values))
+}
+
+// eigvals computes the eigenvalues returned by Eigvals as a slice
+func eigvals(a *tensor.NDArray) []complex128 {
+	if a.DType().IsComplex() {
+		data, n := toSquareComplexDense(a, "Eigvals")
+		values, ok := eigvalsComplex(data, n)
+		if !ok {
+			panic("eigenvalue computation did not converge")
+		}
+		return values
+	}
+	
+	data, n := toSquareDense(a, "Eigvals")
+	h := denseRows(data, n, n)
+	
+	hessenbergReduce(h, nil, n)
+	wr, wi, ok := schurQR(h, nil, n)
+	if !ok {
+		panic("eigenvalue computation did not converge")
+	}
+	
+	values := make([]complex128, n)
+	for i := 0; i < n; i++ {
+		values[i] = complex(wr[i], wi[i])
+	}
+	return values
+}
+
+// Eig computes the eigenvalues and right eigenvectors of a general square
+// matrix, real or complex. Both results are complex128: w holds the
+// eigenvalues in the order returned by Eigvals, and the unit-norm columns
+// of v are the matching eigenvectors, so that A v[:, i] = w[i] v[:, i].
+//
+// Eigenvectors are computed by inverse iteration. For defective matrices,
+// which lack a full set of eigenvectors, the columns for repeated
+// eigenvalues are not independent.
+func Eig(a *tensor.NDArray) (w, v *tensor.NDArray) {
+	values := eigvals(a)
+	data, n := toSquareComplexDense(a, "Eig")
+	vectors := eigenvectorsComplex(data, n, values)
+	return tensor.FromSliceComplex128(values, n), fromComplexDense(vectors, n, n)
+}
+
+// denseRows splits a row-major m x n slice into row slices (sharing data)
+func denseRows(data []float64, m, n int) [][]float64 {
+	rows := make([][]float64, m)
+	for i := 0; i < m; i++ {
+		rows[i] = data[i*n : (i+1)*n]
+	}
+	return rows
+}
+
+// hessenbergReduce reduces h to upper Hessenberg form in place by
+// orthogonal similarity transformations (EISPACK orthes). If v is non-nil
+// it receives the orthogonal matrix Q with A = Q H Q^T. Entries below the
+// first subdiagonal are set to zero.
+func hessenbergReduce(h, v [][]float64, n int) {
+	ort := make([]float64, n)
+	low, high := 0, n-1
+	
+	for m := low + 1; m <= high-1; m++ {
+		scale := 0.0
+		for i := m; i <= high; i++ {
+			scale += math.Abs(h[i][m-1])
+		}
+		if scale == 0 {
+			continue
+		}
+		
+		// Compute Householder transformation
+		hh := 0.0
+		for i := high; i >= m; i-- {
+			ort[i] = h[i][m-1] / scale
+			hh += ort[i] * ort[i]
+		}
+		g := math.Sqrt(hh)
+		if ort[m] > 0 {
+			g = -g
+		}
+		hh -= ort[m] * g
+		ort[m] -= g
+		
+		// Apply Householder similarity transformation H = (I-u*u'/h)*H*(I-u*u')/h)
+		for j := m; j < n; j++ {
+			f := 0.0
+			for i := high; i >= m; i-- {
+				f += ort[i] * h[i][j]
+			}
+			f /= hh
+			for i := m; i <= high; i++ {
+				h[i][j] -= f * ort[i]
+			}
+		}
+		for i := 0; i <= high; i++ {
+			f := 0.0
+			for j := high; j >= m; j-- {
+				f += ort[j] * h[i][j]
+			}
+			f /= hh
+			for j := m; j <= high; j++ {
+				h[i][j] -= f * ort[j]
+			}
+		}
+		ort[m] *= scale
+		h[m][m-1] = scale * g
+	}
+	
+	if v != nil {
+		// Accumulate transformations
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				v[i][j] = 0
+			}
+			v[i][i] = 1
+		}
+		for m := high - 1; m >= low+1; m-- {
+			if h[m][m-1] == 0 {
+				continue
+			}
+			for i := m + 1; i <= high; i++ {
+				ort[i] = h[i][m-1]
+			}
+			for j := m; j <= high; j++ {
+				g := 0.0
+				for i := m; i <= high; i++ {
+					g += ort[i] * v[i][j]
+				}
+				// Double division avoids possible underflow
+				g = (g / ort[m]) / h[m][m-1]
+				for i := m; i <= high; i++ {
+					v[i][j] += g * ort[i]
+				}
+			}
+		}
+	}
+	
+	for i := 2; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			h[i][j] = 0
+		}
+	}
+}
+
+// schurQR reduces the upper Hessenberg matrix h to real Schur form in place
+// using the Francis double-shift QR algorithm (the first half of EISPACK
+// hqr2). If v is non-nil the transformations are accumulated into it. It
+// returns the real and imaginary parts of the eigenvalues and false if the
+// iteration failed to converge.
+func schurQR(h, v [][]float64, n int) ([]float64, []float64, bool) {
+	d := make([]float64, n)
+	e := make([]float64, n)
+	if n == 0 {
+		return d, e, true
+	}
+	
+	nn := n
+	hi := nn - 1
+	low, high := 0, nn-1
+	eps := math.Pow(2, -52)
+	exshift := 0.0
+	var p, q, r, s, z, w, x, y float64
+	
+	// Matrix norm for the convergence test
+	norm := 0.0
+	for i := 0; i < nn; i++ {
+		for j := max(i-1, 0); j < nn; j++ {
+			norm += math.Abs(h[i][j])
+		}
+	}
+	
+	iter := 0
+	totalIter := 0
+	maxIter := 30 * nn
+	for hi >= low {
+		// Look for single small sub-diagonal element
+		l := hi
+		for l > low {
+			s = math.Abs(h[l-1][l-1]) + math.Abs(h[l][l])
+			if s == 0 {
+				s = norm
+			}
+			if math.Abs(h[l][l-1]) < eps*s {
+				break
+			}
+			l--
+		}
+		
+		if l == hi {
+			// One root found
+			h[hi][hi] += exshift
+			d[hi] = h[hi][hi]
+			e[hi] = 0
+			hi--
+			iter = 0
+		} else if l == hi-1 {
+			// Two roots found
+			w = h[hi][hi-1] * h[hi-1][hi]
+			p = (h[hi-1][hi-1] - h[hi][hi]) / 2
+			q = p*p + w
+			z = math.Sqrt(math.Abs(q))
+			h[hi][hi] += exshift
+			h[hi-1][hi-1] += exshift
+			x = h[hi][hi]
+			
+			if q >= 0 {
+				// Real pair
+				if p >= 0 {
+					z = p + z
+				} else {
+					z = p - z
+				}
+				d[hi-1] = x + z
+				d[hi] = d[hi-1]
+				if z != 0 {
+					d[hi] = x - w/z
+				}
+				e[hi-1] = 0
+				e[hi] = 0
+				x = h[hi][hi-1]
+				s = math.Abs(x) + math.Abs(z)
+				p = x / s
+				q = z / s
+				r = math.Sqrt(p*p + q*q)
+				p /= r
+				q /= r
+				
+				// Row modification
+				for j := hi - 1; j < nn; j++ {
+					z = h[hi-1][j]
+					h[hi-1][j] = q*z + p*h[hi][j]
+					h[hi][j] = q*h[hi][j] - p*z
+				}
+				// Column modification
+				for i := 0; i <= hi; i++ {
+					z = h[i][hi-1]
+					h[i][hi-1] = q*z + p*h[i][hi]
+					h[i][hi] = q*h[i][hi] - p*z
+				}
+				// Accumulate transformations
+				if v != nil {
+					for i := low; i <= high; i++ {
+						z = v[i][hi-1]
+						v[i][hi-1] = q*z + p*v[i][hi]
+						v[i][hi] = q*v[i][hi] - p*z
+					}
+				}
+			} else {
+				// Complex pair
+				d[hi-1] = x + p
+				d[hi] = x + p
+				e[hi-1] = z
+				e[hi] = -z
+			}
+			hi -= 2
+			iter = 0
+		} else {
+			// No convergence yet
+			if totalIter >= maxIter {
+				return d, e, false
+			}
+			
+			x = h[hi][hi]
+			y = 0
+			w = 0
+			if l < hi {
+				y = h[hi-1][hi-1]
+				w = h[hi][hi-1] * h[hi-1][hi]
+			}
+			
+			// Wilkinson's original ad hoc shift
+			if iter == 10 {
+				exshift += x
+				for i := low; i <= hi; i++ {
+					h[i][i] -= x
+				}
+				s = math.Abs(h[hi][hi-1]) + math.Abs(h[hi-1][hi-2])
+				x = 0.75 * s
+				y = x
+				w = -0.4375 * s * s
+			}
+			
+			// MATLAB's new ad hoc shift
+			if iter == 30 {
+				s = (y - x) / 2
+				s = s*s + w
+				if s > 0 {
+					s = math.Sqrt(s)
+					if y < x {
+						s = -s
+					}
+					s = x - w/((y-x)/2+s)
+					for i := low; i <= hi; i++ {
+						h[i][i] -= s
+					}
+					exshift += s
+					x = 0.964
+					y = x
+					w = x
+				}
+			}
+			
+			iter++
+			totalIter++
+			
+			// Look for two consecutive small sub-diagonal elements
+			m := hi - 2
+			for m >= l {
+				z = h[m][m]
+				r = x - z
+				s = y - z
+				p = (r*s-w)/h[m+1][m] + h[m][m+1]
+				q = h[m+1][m+1] - z - r - s
+				r = h[m+2][m+1]
+				s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+				p /= s
+				q /= s
+				r /= s
+				if m == l {
+					break
+				}
+				if math.Abs(h[m][m-1])*(math.Abs(q)+math.Abs(r)) <
+					eps*(math.Abs(p)*(math.Abs(h[m-1][m-1])+math.Abs(z)+math.Abs(h[m+1][m+1]))) {
+					break
+				}
+				m--
+			}
+			
+			for i := m + 2; i <= hi; i++ {
+				h[i][i-2] = 0
+				if i > m+2 {
+					h[i][i-3] = 0
+				}
+			}
+			
+			// Double QR step involving rows l:hi and columns m:hi
+			for k := m; k <= hi-1; k++ {
+				notlast := k != hi-1
+				if k != m {
+					p = h[k][k-1]
+					q = h[k+1][k-1]
+					r = 0
+					if notlast {
+						r = h[k+2][k-1]
+					}
+					x = math.Abs(p) + math.Abs(q) + math.Abs(r)
+					if x == 0 {
+						continue
+					}
+					p /= x
+					q /= x
+					r /= x
+				}
+				
+				s = math.Sqrt(p*p + q*q + r*r)
+				if p < 0 {
+					s = -s
+				}
+				if s == 0 {
+					continue
+				}
+				
+				if k != m {
+					h[k][k-1] = -s * x
+				} else if l != m {
+					h[k][k-1] = -h[k][k-1]
+				}
+				p += s
+				x = p / s
+				y = q / s
+				z = r / s
+				q /= p
+				r /= p
+				
+				// Row modification
+				for j := k; j < nn; j++ {
+					p = h[k][j] + q*h[k+1][j]
+					if notlast {
+						p += r * h[k+2][j]
+						h[k+2][j] -= p * z
+					}
+					h[k][j] -= p * x
+					h[k+1][j] -= p * y
+				}
+				
+				// Column modification
+				for i := 0; i <= min(hi, k+3); i++ {
+					p = x*h[i][k] + y*h[i][k+1]
+					if notlast {
+						p += z * h[i][k+2]
+						h[i][k+2] -= p * r
+					}
+					h[i][k] -= p
+					h[i][k+1] -= p * q
+				}
+				
+				// Accumulate transformations
+				if v != nil {
+					for i := low; i <= high; i++ {
+						p = x*v[i][k] + y*v[i][k+1]
+						if notlast {
+							p += z * v[i][k+2]
+							v[i][k+2] -= p * r
+						}
+						v[i][k] -= p
+						v[i][k+1] -= p * q
+					}
+				}
+			}
+		}
+	}
+	return d, e, true
+}
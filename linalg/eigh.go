@@ -0,0 +1,290 @@
+package linalg
+
+import (
+	"math"
+	"sort"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Eigh computes the eigenvalues and eigenvectors of a real symmetric matrix.
+// Only the lower triangle of a is used. Eigenvalues are returned in
+// ascending order in w, and the corresponding normalized eigenvectors are
+// the columns of v, so that A v[:, i] = w[i] v[:, i].
+//
+// The matrix is reduced to tridiagonal form with Householder reflections
+// and then diagonalized with the implicit QL algorithm.
+func Eigh(a *tensor.NDArray) (w, v *tensor.NDArray) {
+	data, n := toSquareDense(a, "Eigh")
+	vecs := symmetricFromLower(data, n)
+	
+	d, e := tridiagonalize(vecs, n, true)
+	tridiagonalQL(d, e, vecs, n)
+	
+	order := ascendingOrder(d)
+	values := make([]float64, n)
+	vData := make([]float64, n*n)
+	for k, j := range order {
+		values[k] = d[j]
+		for i := 0; i < n; i++ {
+			vData[i*n+k] = vecs[i][j]
+		}
+	}
+	
+	return tensor.FromSliceFloat64(values, n), fromDense(vData, n, n)
+}
+
+// Eigvalsh computes the eigenvalues of a real symmetric matrix in ascending
+// order, skipping the eigenvector accumulation that Eigh performs. Only the
+// lower triangle of a is used.
+func Eigvalsh(a *tensor.NDArray) *tensor.NDArray {
+	data, n := toSquareDense(a, "Eigvalsh")
+	work := symmetricFromLower(data, n)
+	
+	d, e := tridiagonalize(work, n, false)
+	tridiagonalQL(d, e, nil, n)
+	
+	values := make([]float64, n)
+	for k, j := range ascendingOrder(d) {
+		values[k] = d[j]
+	}
+	return tensor.FromSliceFloat64(values, n)
+}
+
+// symmetricFromLower builds a full symmetric matrix (as rows) from the lower
+// triangle of a row-major n x n slice
+func symmetricFromLower(data []float64, n int) [][]float64 {
+	rows := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		rows[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			rows[i][j] = data[i*n+j]
+			rows[j][i] = data[i*n+j]
+		}
+	}
+	return rows
+}
+
+// tridiagonalize reduces the symmetric matrix v to tridiagonal form by
+// Householder reflections (EISPACK tred2). On return d holds the diagonal
+// and e the subdiagonal in e[1:]. If wantVectors is true, v is overwritten
+// with the accumulated orthogonal transformation; otherwise v is left as
+// scratch space.
+func tridiagonalize(v [][]float64, n int, wantVectors bool) ([]float64, []float64) {
+	d := make([]float64, n)
+	e := make([]float64, n)
+	if n == 0 {
+		return d, e
+	}
+	
+	for j := 0; j < n; j++ {
+		d[j] = v[n-1][j]
+	}
+	
+	for i := n - 1; i > 0; i-- {
+		// Scale to avoid under/overflow
+		scale := 0.0
+		h := 0.0
+		for k := 0; k < i; k++ {
+			scale += math.Abs(d[k])
+		}
+		
+		if scale == 0 {
+			e[i] = d[i-1]
+			for j := 0; j < i; j++ {
+				d[j] = v[i-1][j]
+				v[i][j] = 0
+				v[j][i] = 0
+			}
+		} else {
+			// Generate Householder vector
+			for k := 0; k < i; k++ {
+				d[k] /= scale
+				h += d[k] * d[k]
+			}
+			f := d[i-1]
+			g := math.Sqrt(h)
+			if f > 0 {
+				g = -g
+			}
+			e[i] = scale * g
+			h -= f * g
+			d[i-1] = f - g
+			for j := 0; j < i; j++ {
+				e[j] = 0
+			}
+			
+			// Apply similarity transformation to remaining columns
+			for j := 0; j < i; j++ {
+				f = d[j]
+				v[j][i] = f
+				g = e[j] + v[j][j]*f
+				for k := j + 1; k <= i-1; k++ {
+					g += v[k][j] * d[k]
+					e[k] += v[k][j] * f
+				}
+				e[j] = g
+			}
+			f = 0
+			for j := 0; j < i; j++ {
+				e[j] /= h
+				f += e[j] * d[j]
+			}
+			hh := f / (h + h)
+			for j := 0; j < i; j++ {
+				e[j] -= hh * d[j]
+			}
+			for j := 0; j < i; j++ {
+				f = d[j]
+				g = e[j]
+				for k := j; k <= i-1; k++ {
+					v[k][j] -= f*e[k] + g*d[k]
+				}
+				d[j] = v[i-1][j]
+				v[i][j] = 0
+			}
+		}
+		d[i] = h
+	}
+	
+	if !wantVectors {
+		// The diagonal is left on the diagonal of v
+		for j := 0; j < n; j++ {
+			d[j] = v[j][j]
+		}
+		e[0] = 0
+		return d, e
+	}
+	
+	// Accumulate transformations
+	for i := 0; i < n-1; i++ {
+		v[n-1][i] = v[i][i]
+		v[i][i] = 1
+		h := d[i+1]
+		if h != 0 {
+			for k := 0; k <= i; k++ {
+				d[k] = v[k][i+1] / h
+			}
+			for j := 0; j <= i; j++ {
+				g := 0.0
+				for k := 0; k <= i; k++ {
+					g += v[k][i+1] * v[k][j]
+				}
+				for k := 0; k <= i; k++ {
+					v[k][j] -= g * d[k]
+				}
+			}
+		}
+		for k := 0; k <= i; k++ {
+			v[k][i+1] = 0
+		}
+	}
+	for j := 0; j < n; j++ {
+		d[j] = v[n-1][j]
+		v[n-1][j] = 0
+	}
+	v[n-1][n-1] = 1
+	e[0] = 0
+	
+	return d, e
+}
+
+// tridiagonalQL diagonalizes the symmetric tridiagonal matrix with
+// diagonal d and subdiagonal e[1:] using the implicit QL algorithm
+// (EISPACK tql2). On return d holds the eigenvalues (unsorted). If v is
+// non-nil, the rotations are accumulated into it so its columns become
+// the eigenvectors.
+func tridiagonalQL(d, e []float64, v [][]float64, n int) {
+	if n == 0 {
+		return
+	}
+	
+	for i := 1; i < n; i++ {
+		e[i-1] = e[i]
+	}
+	e[n-1] = 0
+	
+	f := 0.0
+	tst1 := 0.0
+	eps := math.Pow(2, -52)
+	for l := 0; l < n; l++ {
+		// Find small subdiagonal element
+		tst1 = math.Max(tst1, math.Abs(d[l])+math.Abs(e[l]))
+		m := l
+		for m < n-1 {
+			if math.Abs(e[m]) <= eps*tst1 {
+				break
+			}
+			m++
+		}
+		
+		// If m == l, d[l] is already an eigenvalue; otherwise iterate
+		if m > l {
+			for {
+				// Compute implicit shift
+				g := d[l]
+				p := (d[l+1] - g) / (2 * e[l])
+				r := math.Hypot(p, 1)
+				if p < 0 {
+					r = -r
+				}
+				d[l] = e[l] / (p + r)
+				d[l+1] = e[l] * (p + r)
+				dl1 := d[l+1]
+				h := g - d[l]
+				for i := l + 2; i < n; i++ {
+					d[i] -= h
+				}
+				f += h
+				
+				// Implicit QL transformation
+				p = d[m]
+				c, c2, c3 := 1.0, 1.0, 1.0
+				el1 := e[l+1]
+				s, s2 := 0.0, 0.0
+				for i := m - 1; i >= l; i-- {
+					c3 = c2
+					c2 = c
+					s2 = s
+					g = c * e[i]
+					h = c * p
+					r = math.Hypot(p, e[i])
+					e[i+1] = s * r
+					s = e[i] / r
+					c = p / r
+					p = c*d[i] - s*g
+					d[i+1] = h + s*(c*g+s*d[i])
+					
+					if v != nil {
+						for k := 0; k < n; k++ {
+							h = v[k][i+1]
+							v[k][i+1] = s*v[k][i] + c*h
+							v[k][i] = c*v[k][i] - s*h
+						}
+					}
+				}
+				p = -s * s2 * c3 * el1 * e[l] / dl1
+				e[l] = s * p
+				d[l] = c * p
+				
+				if math.Abs(e[l]) <= eps*tst1 {
+					break
+				}
+			}
+		}
+		d[l] += f
+		e[l] = 0
+	}
+}
+
+// ascendingOrder returns the indices that sort values in ascending order
+func ascendingOrder(values []float64) []int {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+	return order
+}
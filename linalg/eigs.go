@@ -0,0 +1,504 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// EigWhich selects which eigenvalues Eigs and Eigsh compute
+type EigWhich int
+
+const (
+	// LargestMagnitude selects the eigenvalues of largest absolute value
+	LargestMagnitude EigWhich = iota
+	// SmallestMagnitude selects the eigenvalues of smallest absolute value
+	SmallestMagnitude
+	// LargestReal selects the eigenvalues with the largest real part
+	LargestReal
+	// SmallestReal selects the eigenvalues with the smallest real part
+	SmallestReal
+)
+
+// String returns the string representation of an EigWhich
+func (w EigWhich) String() string {
+	switch w {
+	case LargestMagnitude:
+		return "LM"
+	case SmallestMagnitude:
+		return "SM"
+	case LargestReal:
+		return "LR"
+	case SmallestReal:
+		return "SR"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// eigsTol is the relative Ritz residual at which a pair is accepted
+	eigsTol = 1e-10
+	// eigsMaxRestarts bounds the number of implicit restarts
+	eigsMaxRestarts = 500
+)
+
+// Eigs computes k eigenvalues and eigenvectors of a square linear operator
+// using the implicitly restarted Arnoldi method, applying the operator only
+// through matrix-vector products. Results are complex128: w has length k,
+// ordered according to which, and the unit-norm columns of v (n x k) are
+// the eigenvectors.
+//
+// Only a Krylov basis of about max(2k+1, 20) vectors is stored, so Eigs
+// suits large sparse or matrix-free operators. SmallestMagnitude converges
+// slowly for most operators. It panics if the iteration does not converge.
+func Eigs(op LinearOperator, k int, which EigWhich) (w, v *tensor.NDArray) {
+	n := eigsCheck("Eigs", op, k)
+	values, vectors := eigsSolve(op, n, k, which, false)
+	
+	vData := make([]complex128, n*k)
+	for j, vec := range vectors {
+		for i := 0; i < n; i++ {
+			vData[i*k+j] = vec[i]
+		}
+	}
+	return tensor.FromSliceComplex128(values, k), fromComplexDense(vData, n, k)
+}
+
+// Eigsh computes k eigenvalues and eigenvectors of a symmetric linear
+// operator with the implicitly restarted Lanczos method. Eigenvalues are
+// real and ordered according to which (LargestReal and SmallestReal select
+// the algebraically largest and smallest); the columns of v (n x k) are
+// orthonormal eigenvectors. It panics if the iteration does not converge.
+func Eigsh(op LinearOperator, k int, which EigWhich) (w, v *tensor.NDArray) {
+	n := eigsCheck("Eigsh", op, k)
+	values, vectors := eigsSolve(op, n, k, which, true)
+	
+	wData := make([]float64, k)
+	vData := make([]float64, n*k)
+	for j, vec := range vectors {
+		wData[j] = real(values[j])
+		for i := 0; i < n; i++ {
+			vData[i*k+j] = real(vec[i])
+		}
+	}
+	return tensor.FromSliceFloat64(wData, k), fromDense(vData, n, k)
+}
+
+// eigsCheck validates the operator and k, returning the operator size
+func eigsCheck(name string, op LinearOperator, k int) int {
+	m, n := op.Dims()
+	if m != n {
+		panic(fmt.Sprintf("%s requires a square operator, got %dx%d", name, m, n))
+	}
+	if k < 1 || k > n {
+		panic(fmt.Sprintf("%s: k must be in [1, %d], got %d", name, n, k))
+	}
+	return n
+}
+
+// eigsSolve runs implicitly restarted Arnoldi (or Lanczos, when symmetric
+// is set) and returns the k wanted Ritz values and unit Ritz vectors.
+// Small operators, whose Krylov basis would span the whole space, are
+// solved densely instead.
+func eigsSolve(op LinearOperator, n, k int, which EigWhich, symmetric bool) ([]complex128, [][]complex128) {
+	m := min(n, max(2*k+1, 20))
+	if m >= n {
+		return eigsDense(op, n, k, which, symmetric)
+	}
+	
+	basis := make([][]complex128, m+1)
+	h := make([]complex128, (m+1)*m)
+	
+	// Deterministic, non-degenerate starting vector
+	start := make([]complex128, n)
+	for i := range start {
+		start[i] = complex(1+0.5*math.Sin(float64(i+1)), 0)
+	}
+	normalizeComplex(start)
+	basis[0] = start
+	
+	applier := newComplexApplier(op, n)
+	kept := 0
+	for restart := 0; restart < eigsMaxRestarts; restart++ {
+		arnoldiExtend(applier, basis, h, kept, m)
+		beta := cmplx.Abs(h[m*m+m-1])
+		
+		hm := make([]complex128, m*m)
+		copy(hm, h[:m*m])
+		values, vecs := ritzPairs(hm, m, symmetric)
+		order := eigsOrder(values, which)
+		
+		// Residual norm of Ritz pair i is beta * |y_i[m-1]|
+		converged := true
+		for _, i := range order[:k] {
+			tol := eigsTol * math.Max(cmplx.Abs(values[i]), math.Pow(epsilon, 2.0/3))
+			if beta*cmplx.Abs(vecs[i][m-1]) > tol {
+				converged = false
+				break
+			}
+		}
+		
+		if converged || beta == 0 {
+			resultValues := make([]complex128, k)
+			resultVectors := make([][]complex128, k)
+			for j, i := range order[:k] {
+				resultValues[j] = values[i]
+				x := make([]complex128, n)
+				for c := 0; c < m; c++ {
+					yc := vecs[i][c]
+					for r := 0; r < n; r++ {
+						x[r] += yc * basis[c][r]
+					}
+				}
+				normalizeComplex(x)
+				if symmetric {
+					alignPhase(x)
+				}
+				resultVectors[j] = x
+			}
+			return resultValues, resultVectors
+		}
+		
+		// Implicit restart with the unwanted Ritz values as exact shifts
+		q := denseIdentityComplex(m)
+		for _, i := range order[k:] {
+			shiftedQRStep(h, q, m, values[i])
+		}
+		
+		// New residual: f = V Q[:, k] H[k, k-1] + f_m Q[m-1, k-1]
+		f := make([]complex128, n)
+		hk := h[k*m+k-1]
+		sigma := q[(m-1)*m+k-1]
+		fm := h[m*m+m-1]
+		for r := 0; r < n; r++ {
+			var sum complex128
+			for c := 0; c < m; c++ {
+				sum += basis[c][r] * q[c*m+k]
+			}
+			f[r] = sum*hk + basis[m][r]*fm*sigma
+		}
+		
+		// V_k = V Q[:, :k]
+		newBasis := make([][]complex128, m+1)
+		for j := 0; j < k; j++ {
+			vec := make([]complex128, n)
+			for c := 0; c < m; c++ {
+				qc := q[c*m+j]
+				if qc == 0 {
+					continue
+				}
+				for r := 0; r < n; r++ {
+					vec[r] += basis[c][r] * qc
+				}
+			}
+			newBasis[j] = vec
+		}
+		basis = newBasis
+		
+		// Keep the leading k x k block of H and restart from f
+		for i := 0; i <= m; i++ {
+			for j := 0; j < m; j++ {
+				if i >= k || j >= k {
+					h[i*m+j] = 0
+				}
+			}
+		}
+		fNorm := cvecNorm(f)
+		if fNorm == 0 {
+			// The wanted subspace is invariant; continue with a fresh
+			// direction decoupled from it
+			f = randomOrthogonal(basis[:k], n, restart)
+		} else {
+			for r := range f {
+				f[r] /= complex(fNorm, 0)
+			}
+		}
+		h[k*m+k-1] = complex(fNorm, 0)
+		basis[k] = f
+		kept = k
+	}
+	
+	panic("Eigs did not converge")
+}
+
+// eigsDense solves a small eigenproblem by forming the operator's matrix
+func eigsDense(op LinearOperator, n, k int, which EigWhich, symmetric bool) ([]complex128, [][]complex128) {
+	data := make([]float64, n*n)
+	e := make([]float64, n)
+	col := make([]float64, n)
+	for j := 0; j < n; j++ {
+		e[j] = 1
+		op.Apply(col, e)
+		e[j] = 0
+		for i := 0; i < n; i++ {
+			data[i*n+j] = col[i]
+		}
+	}
+	
+	var values []complex128
+	var vectors [][]complex128
+	if symmetric {
+		w, v := Eigh(fromDense(data, n, n))
+		for j := 0; j < n; j++ {
+			values = append(values, complex(w.GetFloat64(j), 0))
+			vec := make([]complex128, n)
+			for i := 0; i < n; i++ {
+				vec[i] = complex(v.GetFloat64(i, j), 0)
+			}
+			vectors = append(vectors, vec)
+		}
+	} else {
+		w, v := Eig(fromDense(data, n, n))
+		values = w.ToSliceComplex128()
+		for j := 0; j < n; j++ {
+			vec := make([]complex128, n)
+			for i := 0; i < n; i++ {
+				vec[i] = v.GetComplex128(i, j)
+			}
+			vectors = append(vectors, vec)
+		}
+	}
+	
+	order := eigsOrder(values, which)
+	resultValues := make([]complex128, k)
+	resultVectors := make([][]complex128, k)
+	for j, i := range order[:k] {
+		resultValues[j] = values[i]
+		resultVectors[j] = vectors[i]
+	}
+	return resultValues, resultVectors
+}
+
+// complexApplier applies a real LinearOperator to complex vectors, using
+// one product for real vectors and two otherwise
+type complexApplier struct {
+	op     LinearOperator
+	re, im []float64
+	out    []float64
+}
+
+// newComplexApplier allocates the scratch space for an n x n operator
+func newComplexApplier(op LinearOperator, n int) *complexApplier {
+	return &complexApplier{op: op, re: make([]float64, n), im: make([]float64, n), out: make([]float64, n)}
+}
+
+// apply computes dst = A x
+func (c *complexApplier) apply(dst, x []complex128) {
+	hasImag := false
+	for i, v := range x {
+		c.re[i], c.im[i] = real(v), imag(v)
+		if imag(v) != 0 {
+			hasImag = true
+		}
+	}
+	c.op.Apply(c.out, c.re)
+	for i, v := range c.out {
+		dst[i] = complex(v, 0)
+	}
+	if hasImag {
+		c.op.Apply(c.out, c.im)
+		for i, v := range c.out {
+			dst[i] += complex(0, v)
+		}
+	}
+}
+
+// arnoldiExtend extends an Arnoldi factorization A V_j = V_j H_j + f e_j^T
+// from j = from to j = m columns, storing the basis in basis[0..m] and the
+// (m+1) x m Hessenberg matrix row-major in h. Each new vector is
+// orthogonalized twice against the whole basis.
+func arnoldiExtend(c *complexApplier, basis [][]complex128, h []complex128, from, m int) {
+	n := len(basis[0])
+	for j := from; j < m; j++ {
+		w := make([]complex128, n)
+		c.apply(w, basis[j])
+		
+		for pass := 0; pass < 2; pass++ {
+			for i := 0; i <= j; i++ {
+				d := cvecDot(basis[i], w)
+				h[i*m+j] += d
+				for r := range w {
+					w[r] -= d * basis[i][r]
+				}
+			}
+		}
+		
+		norm := cvecNorm(w)
+		if norm <= epsilon*cmplx.Abs(h[j*m+j]) || norm == 0 {
+			// Invariant subspace found; continue with a fresh direction
+			h[(j+1)*m+j] = 0
+			basis[j+1] = randomOrthogonal(basis[:j+1], n, j)
+			continue
+		}
+		h[(j+1)*m+j] = complex(norm, 0)
+		for r := range w {
+			w[r] /= complex(norm, 0)
+		}
+		basis[j+1] = w
+	}
+}
+
+// ritzPairs computes the eigenvalues and unit eigenvectors of the m x m
+// projected matrix h. For symmetric problems the matrix is symmetrized and
+// diagonalized with Eigh.
+func ritzPairs(h []complex128, m int, symmetric bool) ([]complex128, [][]complex128) {
+	values := make([]complex128, m)
+	vecs := make([][]complex128, m)
+	
+	if symmetric {
+		data := make([]float64, m*m)
+		for i := 0; i < m; i++ {
+			for j := 0; j < m; j++ {
+				data[i*m+j] = (real(h[i*m+j]) + real(h[j*m+i])) / 2
+			}
+		}
+		w, v := Eigh(fromDense(data, m, m))
+		for j := 0; j < m; j++ {
+			values[j] = complex(w.GetFloat64(j), 0)
+			vecs[j] = make([]complex128, m)
+			for i := 0; i < m; i++ {
+				vecs[j][i] = complex(v.GetFloat64(i, j), 0)
+			}
+		}
+		return values, vecs
+	}
+	
+	ev, ok := eigvalsComplex(h, m)
+	if !ok {
+		panic("Eigs did not converge")
+	}
+	vectors := eigenvectorsComplex(h, m, ev)
+	for j := 0; j < m; j++ {
+		values[j] = ev[j]
+		vecs[j] = make([]complex128, m)
+		for i := 0; i < m; i++ {
+			vecs[j][i] = vectors[i*m+j]
+		}
+	}
+	return values, vecs
+}
+
+// eigsOrder returns the indices of values sorted so that the most wanted
+// eigenvalues according to which come first
+func eigsOrder(values []complex128, which EigWhich) []int {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	
+	var key func(complex128) float64
+	switch which {
+	case LargestMagnitude:
+		key = func(z complex128) float64 { return -cmplx.Abs(z) }
+	case SmallestMagnitude:
+		key = func(z complex128) float64 { return cmplx.Abs(z) }
+	case LargestReal:
+		key = func(z complex128) float64 { return -real(z) }
+	case SmallestReal:
+		key = func(z complex128) float64 { return real(z) }
+	default:
+		panic(fmt.Sprintf("unsupported eigenvalue selection: %d", which))
+	}
+	sort.SliceStable(order, func(i, j int) bool { return key(values[order[i]]) < key(values[order[j]]) })
+	return order
+}
+
+// shiftedQRStep applies one QR step with shift mu to the leading m x m
+// upper Hessenberg block of h (stored with row stride m) and accumulates
+// the unitary factor into q
+func shiftedQRStep(h, q []complex128, m int, mu complex128) {
+	for i := 0; i < m; i++ {
+		h[i*m+i] -= mu
+	}
+	cs := make([]float64, m-1)
+	sn := make([]complex128, m-1)
+	for k := 0; k < m-1; k++ {
+		c, s := complexGivens(h[k*m+k], h[(k+1)*m+k])
+		cs[k], sn[k] = c, s
+		for j := k; j < m; j++ {
+			x, y := h[k*m+j], h[(k+1)*m+j]
+			h[k*m+j] = complex(c, 0)*x + s*y
+			h[(k+1)*m+j] = -cmplx.Conj(s)*x + complex(c, 0)*y
+		}
+	}
+	for k := 0; k < m-1; k++ {
+		c, s := cs[k], sn[k]
+		for i := 0; i <= min(k+1, m-1); i++ {
+			x, y := h[i*m+k], h[i*m+k+1]
+			h[i*m+k] = complex(c, 0)*x + cmplx.Conj(s)*y
+			h[i*m+k+1] = -s*x + complex(c, 0)*y
+		}
+		for i := 0; i < m; i++ {
+			x, y := q[i*m+k], q[i*m+k+1]
+			q[i*m+k] = complex(c, 0)*x + cmplx.Conj(s)*y
+			q[i*m+k+1] = -s*x + complex(c, 0)*y
+		}
+	}
+	for i := 0; i < m; i++ {
+		h[i*m+i] += mu
+	}
+}
+
+// randomOrthogonal returns a deterministic unit vector orthogonal to the
+// given orthonormal vectors
+func randomOrthogonal(basis [][]complex128, n, seed int) []complex128 {
+	for attempt := 0; ; attempt++ {
+		v := make([]complex128, n)
+		for i := range v {
+			v[i] = complex(math.Sin(float64((i+1)*(seed+attempt+2))*12.9898), 0)
+		}
+		for pass := 0; pass < 2; pass++ {
+			for _, b := range basis {
+				d := cvecDot(b, v)
+				for i := range v {
+					v[i] -= d * b[i]
+				}
+			}
+		}
+		if norm := cvecNorm(v); norm > 1e-8 {
+			for i := range v {
+				v[i] /= complex(norm, 0)
+			}
+			return v
+		}
+	}
+}
+
+// normalizeComplex scales x to unit norm in place
+func normalizeComplex(x []complex128) {
+	if norm := cvecNorm(x); norm > 0 {
+		for i := range x {
+			x[i] /= complex(norm, 0)
+		}
+	}
+}
+
+// alignPhase rotates x so that its largest component is real and positive
+func alignPhase(x []complex128) {
+	best := 0
+	for i, v := range x {
+		if cmplx.Abs(v) > cmplx.Abs(x[best]) {
+			best = i
+		}
+	}
+	if x[best] == 0 {
+		return
+	}
+	phase := cmplx.Conj(x[best]) / complex(cmplx.Abs(x[best]), 0)
+	for i := range x {
+		x[i] *= phase
+	}
+}
+
+// denseIdentityComplex returns the row-major n x n complex identity matrix
+func denseIdentityComplex(n int) []complex128 {
+	id := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		id[i*n+i] = 1
+	}
+	return id
+}
@@ -0,0 +1,83 @@
+package linalg
+
+import (
+	"fmt"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// VDot computes the dot product of two arrays of equal size after
+// flattening them, conjugating the first argument when it is complex
+// (sum of conj(a[i]) * b[i]). Like Dot, it returns a 1-element array: float64
+// for real inputs and complex128 if either input is complex.
+func VDot(a, b *tensor.NDArray) *tensor.NDArray {
+	if a.Size() != b.Size() {
+		panic(fmt.Sprintf("arrays must have same size: %d vs %d", a.Size(), b.Size()))
+	}
+	
+	if anyComplex(a, b) {
+		return tensor.FromSliceComplex128([]complex128{cvecDot(a.ToSliceComplex128(), b.ToSliceComplex128())}, 1)
+	}
+	return tensor.FromSliceFloat64([]float64{vecDot(a.ToSliceFloat64(), b.ToSliceFloat64())}, 1)
+}
+
+// InnerAxes generalizes Inner to ND arrays by contracting the last axes
+// axes of a with the last axes axes of b, which must have the same shape.
+// The result has shape a.shape[:-axes] + b.shape[:-axes]; with axes = 1
+// this matches NumPy's inner, and with axes = 0 it is the outer product.
+// Complex inputs are not conjugated (see VDot). A result with no remaining
+// axes is returned as a 1-element array.
+func InnerAxes(a, b *tensor.NDArray, axes int) *tensor.NDArray {
+	if axes < 0 || axes > a.Ndim() || axes > b.Ndim() {
+		panic(fmt.Sprintf("axes %d out of range for arrays of dimension %d and %d", axes, a.Ndim(), b.Ndim()))
+	}
+	aShape, bShape := a.Shape(), b.Shape()
+	aOuter, aInner := aShape[:len(aShape)-axes], aShape[len(aShape)-axes:]
+	bOuter, bInner := bShape[:len(bShape)-axes], bShape[len(bShape)-axes:]
+	for i := range aInner {
+		if aInner[i] != bInner[i] {
+			panic(fmt.Sprintf("shapes %v and %v are not aligned over the last %d axes", aShape, bShape, axes))
+		}
+	}
+	
+	k := 1
+	for _, d := range aInner {
+		k *= d
+	}
+	m, n := 1, 1
+	for _, d := range aOuter {
+		m *= d
+	}
+	for _, d := range bOuter {
+		n *= d
+	}
+	
+	shape := append(append([]int{}, aOuter...), bOuter...)
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	
+	if anyComplex(a, b) {
+		x, y := a.ToSliceComplex128(), b.ToSliceComplex128()
+		result := make([]complex128, m*n)
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				var sum complex128
+				for c := 0; c < k; c++ {
+					sum += x[i*k+c] * y[j*k+c]
+				}
+				result[i*n+j] = sum
+			}
+		}
+		return tensor.FromSliceComplex128(result, shape...)
+	}
+	
+	x, y := a.ToSliceFloat64(), b.ToSliceFloat64()
+	result := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			result[i*n+j] = vecDot(x[i*k:(i+1)*k], y[j*k:(j+1)*k])
+		}
+	}
+	return tensor.FromSliceFloat64(result, shape...)
+}
@@ -0,0 +1,420 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// LinearOperator is a linear map that can be applied to vectors without
+// forming its matrix, so iterative solvers work equally on dense arrays,
+// sparse storage, or matrix-free operators
+type LinearOperator interface {
+	// Dims returns the number of rows and columns of the operator
+	Dims() (rows, cols int)
+	// Apply computes dst = A x; dst has length rows and x length cols
+	Apply(dst, x []float64)
+}
+
+// denseOperator is a LinearOperator backed by a row-major matrix
+type denseOperator struct {
+	data []float64
+	m, n int
+}
+
+// Dims returns the matrix dimensions
+func (d *denseOperator) Dims() (int, int) {
+	return d.m, d.n
+}
+
+// Apply computes dst = A x
+func (d *denseOperator) Apply(dst, x []float64) {
+	for i := 0; i < d.m; i++ {
+		dst[i] = vecDot(d.data[i*d.n:(i+1)*d.n], x)
+	}
+}
+
+// DenseOperator wraps a 2D array as a LinearOperator. The array is copied,
+// so later changes to it are not seen by the operator.
+func DenseOperator(a *tensor.NDArray) LinearOperator {
+	data, m, n := toDense(a)
+	return &denseOperator{data: data, m: m, n: n}
+}
+
+// funcOperator is a LinearOperator backed by a function
+type funcOperator struct {
+	m, n  int
+	apply func(dst, x []float64)
+}
+
+// Dims returns the operator dimensions
+func (f *funcOperator) Dims() (int, int) {
+	return f.m, f.n
+}
+
+// Apply computes dst = A x
+func (f *funcOperator) Apply(dst, x []float64) {
+	f.apply(dst, x)
+}
+
+// FuncOperator returns a matrix-free LinearOperator of the given
+// dimensions whose action is computed by apply
+func FuncOperator(rows, cols int, apply func(dst, x []float64)) LinearOperator {
+	return &funcOperator{m: rows, n: cols, apply: apply}
+}
+
+// JacobiPreconditioner returns the diagonal preconditioner
+// M = diag(A)^-1 for a square matrix, for use as IterativeOptions.M.
+// Zero diagonal entries are left unscaled.
+func JacobiPreconditioner(a *tensor.NDArray) LinearOperator {
+	data, n := toSquareDense(a, "JacobiPreconditioner")
+	inv := make([]float64, n)
+	for i := 0; i < n; i++ {
+		inv[i] = 1
+		if d := data[i*n+i]; d != 0 {
+			inv[i] = 1 / d
+		}
+	}
+	return FuncOperator(n, n, func(dst, x []float64) {
+		for i := range dst {
+			dst[i] = inv[i] * x[i]
+		}
+	})
+}
+
+// IterativeOptions controls the iterative solvers. The zero value selects
+// the defaults.
+type IterativeOptions struct {
+	// Tol is the relative residual tolerance: iteration stops once
+	// ||b - A x|| <= Tol * ||b||. Zero selects 1e-8.
+	Tol float64
+	// AbsTol is an absolute residual tolerance, useful when b is near zero
+	AbsTol float64
+	// MaxIter bounds the number of iterations (matrix-vector products for
+	// GMRES). Zero selects 10 * n.
+	MaxIter int
+	// X0 is the initial guess; nil starts from zero
+	X0 *tensor.NDArray
+	// M is a preconditioner approximating A^-1; nil means none. CG
+	// requires M to be symmetric positive definite.
+	M LinearOperator
+	// Restart is the GMRES restart length. Zero selects min(n, 30).
+	Restart int
+}
+
+// IterativeResult reports the outcome of an iterative solve
+type IterativeResult struct {
+	// X is the final iterate
+	X *tensor.NDArray
+	// Iterations is the number of iterations performed
+	Iterations int
+	// Residual is the final residual norm ||b - A x||
+	Residual float64
+	// Converged reports whether the tolerance was reached
+	Converged bool
+}
+
+// iterativeSetup validates the operator and right-hand side and returns
+// the initial guess, b, the stopping threshold and the iteration limit
+func iterativeSetup(name string, op LinearOperator, b *tensor.NDArray, opts IterativeOptions) ([]float64, []float64, float64, int) {
+	m, n := op.Dims()
+	if m != n {
+		panic(fmt.Sprintf("%s requires a square operator, got %dx%d", name, m, n))
+	}
+	if b.Ndim() != 1 || b.Size() != n {
+		panic(fmt.Sprintf("%s: b has shape %v, expected (%d)", name, b.Shape(), n))
+	}
+	rhs := b.ToSliceFloat64()
+	
+	x := make([]float64, n)
+	if opts.X0 != nil {
+		if opts.X0.Size() != n {
+			panic(fmt.Sprintf("%s: X0 has size %d, expected %d", name, opts.X0.Size(), n))
+		}
+		copy(x, opts.X0.ToSliceFloat64())
+	}
+	
+	tol := opts.Tol
+	if tol <= 0 {
+		tol = 1e-8
+	}
+	threshold := math.Max(tol*vecNorm(rhs), opts.AbsTol)
+	
+	maxIter := opts.MaxIter
+	if maxIter <= 0 {
+		maxIter = 10 * n
+	}
+	return x, rhs, threshold, maxIter
+}
+
+// residual computes r = b - A x and returns its norm
+func residual(op LinearOperator, x, b, r []float64) float64 {
+	op.Apply(r, x)
+	for i := range r {
+		r[i] = b[i] - r[i]
+	}
+	return vecNorm(r)
+}
+
+// precondition computes dst = M x, or copies x when M is nil
+func precondition(m LinearOperator, dst, x []float64) {
+	if m == nil {
+		copy(dst, x)
+		return
+	}
+	m.Apply(dst, x)
+}
+
+// iterativeResult packages the final iterate with its true residual
+func iterativeResult(op LinearOperator, x, b []float64, iterations int, threshold float64) IterativeResult {
+	r := make([]float64, len(b))
+	res := residual(op, x, b, r)
+	return IterativeResult{
+		X:          tensor.FromSliceFloat64(x, len(x)),
+		Iterations: iterations,
+		Residual:   res,
+		Converged:  res <= threshold,
+	}
+}
+
+// CG solves A x = b with the (preconditioned) conjugate gradient method.
+// A must be symmetric positive definite. Failure to converge is reported
+// in the result rather than by panicking.
+func CG(op LinearOperator, b *tensor.NDArray, opts IterativeOptions) IterativeResult {
+	x, rhs, threshold, maxIter := iterativeSetup("CG", op, b, opts)
+	n := len(x)
+	
+	r := make([]float64, n)
+	z := make([]float64, n)
+	ap := make([]float64, n)
+	if residual(op, x, rhs, r) <= threshold {
+		return iterativeResult(op, x, rhs, 0, threshold)
+	}
+	precondition(opts.M, z, r)
+	p := append([]float64{}, z...)
+	rz := vecDot(r, z)
+	
+	iter := 0
+	for iter < maxIter {
+		iter++
+		op.Apply(ap, p)
+		pap := vecDot(p, ap)
+		if pap == 0 {
+			break
+		}
+		alpha := rz / pap
+		for i := range x {
+			x[i] += alpha * p[i]
+			r[i] -= alpha * ap[i]
+		}
+		if vecNorm(r) <= threshold {
+			break
+		}
+		
+		precondition(opts.M, z, r)
+		rzNew := vecDot(r, z)
+		beta := rzNew / rz
+		rz = rzNew
+		for i := range p {
+			p[i] = z[i] + beta*p[i]
+		}
+	}
+	
+	return iterativeResult(op, x, rhs, iter, threshold)
+}
+
+// BiCGSTAB solves A x = b for a general square A with the stabilized
+// biconjugate gradient method, using M (if set) as a right preconditioner
+func BiCGSTAB(op LinearOperator, b *tensor.NDArray, opts IterativeOptions) IterativeResult {
+	x, rhs, threshold, maxIter := iterativeSetup("BiCGSTAB", op, b, opts)
+	n := len(x)
+	
+	r := make([]float64, n)
+	if residual(op, x, rhs, r) <= threshold {
+		return iterativeResult(op, x, rhs, 0, threshold)
+	}
+	rhat := append([]float64{}, r...)
+	
+	p := make([]float64, n)
+	v := make([]float64, n)
+	s := make([]float64, n)
+	t := make([]float64, n)
+	phat := make([]float64, n)
+	shat := make([]float64, n)
+	rho, alpha, omega := 1.0, 1.0, 1.0
+	
+	iter := 0
+	for iter < maxIter {
+		iter++
+		rhoNew := vecDot(rhat, r)
+		if rhoNew == 0 || omega == 0 {
+			// Breakdown: the method cannot make further progress
+			break
+		}
+		beta := (rhoNew / rho) * (alpha / omega)
+		rho = rhoNew
+		for i := range p {
+			p[i] = r[i] + beta*(p[i]-omega*v[i])
+		}
+		
+		precondition(opts.M, phat, p)
+		op.Apply(v, phat)
+		rv := vecDot(rhat, v)
+		if rv == 0 {
+			break
+		}
+		alpha = rho / rv
+		for i := range s {
+			s[i] = r[i] - alpha*v[i]
+		}
+		if vecNorm(s) <= threshold {
+			for i := range x {
+				x[i] += alpha * phat[i]
+			}
+			break
+		}
+		
+		precondition(opts.M, shat, s)
+		op.Apply(t, shat)
+		tt := vecDot(t, t)
+		if tt == 0 {
+			omega = 0
+		} else {
+			omega = vecDot(t, s) / tt
+		}
+		for i := range x {
+			x[i] += alpha*phat[i] + omega*shat[i]
+			r[i] = s[i] - omega*t[i]
+		}
+		if vecNorm(r) <= threshold {
+			break
+		}
+	}
+	
+	return iterativeResult(op, x, rhs, iter, threshold)
+}
+
+// GMRES solves A x = b for a general square A with the restarted
+// generalized minimal residual method, using M (if set) as a right
+// preconditioner. Each restart cycle builds an orthonormal Krylov basis of
+// at most Restart vectors by Arnoldi iteration and minimizes the residual
+// over it.
+func GMRES(op LinearOperator, b *tensor.NDArray, opts IterativeOptions) IterativeResult {
+	x, rhs, threshold, maxIter := iterativeSetup("GMRES", op, b, opts)
+	n := len(x)
+	
+	restart := opts.Restart
+	if restart <= 0 {
+		restart = min(n, 30)
+	}
+	
+	r := make([]float64, n)
+	w := make([]float64, n)
+	z := make([]float64, n)
+	iter := 0
+	for iter < maxIter {
+		beta := residual(op, x, rhs, r)
+		if beta <= threshold {
+			break
+		}
+		
+		basis := [][]float64{make([]float64, n)}
+		for i := range r {
+			basis[0][i] = r[i] / beta
+		}
+		h := make([][]float64, restart+1)
+		for i := range h {
+			h[i] = make([]float64, restart)
+		}
+		cs := make([]float64, restart)
+		sn := make([]float64, restart)
+		g := make([]float64, restart+1)
+		g[0] = beta
+		
+		k := 0
+		for k < restart && iter < maxIter {
+			iter++
+			
+			// Arnoldi step with modified Gram-Schmidt
+			precondition(opts.M, z, basis[k])
+			op.Apply(w, z)
+			for i := 0; i <= k; i++ {
+				h[i][k] = vecDot(w, basis[i])
+				for j := range w {
+					w[j] -= h[i][k] * basis[i][j]
+				}
+			}
+			h[k+1][k] = vecNorm(w)
+			next := make([]float64, n)
+			if h[k+1][k] != 0 {
+				for j := range w {
+					next[j] = w[j] / h[k+1][k]
+				}
+			}
+			basis = append(basis, next)
+			
+			// Apply the previous Givens rotations to the new column, then
+			// eliminate its subdiagonal entry
+			for i := 0; i < k; i++ {
+				hi, hj := h[i][k], h[i+1][k]
+				h[i][k] = cs[i]*hi + sn[i]*hj
+				h[i+1][k] = -sn[i]*hi + cs[i]*hj
+			}
+			denom := math.Hypot(h[k][k], h[k+1][k])
+			if denom == 0 {
+				cs[k], sn[k] = 1, 0
+			} else {
+				cs[k], sn[k] = h[k][k]/denom, h[k+1][k]/denom
+			}
+			h[k][k] = denom
+			h[k+1][k] = 0
+			g[k+1] = -sn[k] * g[k]
+			g[k] = cs[k] * g[k]
+			k++
+			
+			if math.Abs(g[k]) <= threshold || basisExhausted(next) {
+				break
+			}
+		}
+		
+		// Solve the k x k triangular least-squares system and update x
+		y := make([]float64, k)
+		for i := k - 1; i >= 0; i-- {
+			sum := g[i]
+			for j := i + 1; j < k; j++ {
+				sum -= h[i][j] * y[j]
+			}
+			if h[i][i] != 0 {
+				y[i] = sum / h[i][i]
+			}
+		}
+		update := make([]float64, n)
+		for j := 0; j < k; j++ {
+			for i := range update {
+				update[i] += y[j] * basis[j][i]
+			}
+		}
+		precondition(opts.M, z, update)
+		for i := range x {
+			x[i] += z[i]
+		}
+		
+		if math.Abs(g[k]) <= threshold {
+			break
+		}
+	}
+	
+	return iterativeResult(op, x, rhs, iter, threshold)
+}
+
+// basisExhausted reports whether the Arnoldi process produced a zero
+// vector, meaning the Krylov subspace is invariant (a lucky breakdown)
+func basisExhausted(v []float64) bool {
+	for _, x := range v {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
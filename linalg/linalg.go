@@ -2,6 +2,7 @@
 package linalg
 
 import (
+	"context"
 	"fmt"
 	"math"
 	
@@ -11,7 +12,12 @@ import (
 // Dot computes the dot product of two arrays
 // For 1D arrays: sum of element-wise products
 // For 2D arrays: matrix multiplication
+// Complex operands give a complex128 result; unlike VDot, nothing is
+// conjugated.
 func Dot(a, b *tensor.NDArray) *tensor.NDArray {
+	if anyComplex(a, b) && a.Ndim() <= 2 && b.Ndim() == 1 {
+		return dotComplex(a, b)
+	}
 	if a.Ndim() == 1 && b.Ndim() == 1 {
 		// Vector dot product
 		if a.Size() != b.Size() {
@@ -55,6 +61,15 @@ func Dot(a, b *tensor.NDArray) *tensor.NDArray {
 
 // MatMul performs matrix multiplication
 func MatMul(a, b *tensor.NDArray) *tensor.NDArray {
+	// A background context is never cancelled, so no error can occur
+	result, _ := MatMulContext(context.Background(), a, b)
+	return result
+}
+
+// MatMulContext performs matrix multiplication, checking ctx between rows
+// of the result and returning ctx.Err() if the context is cancelled. If
+// either input is complex the product is computed in complex128.
+func MatMulContext(ctx context.Context, a, b *tensor.NDArray) (*tensor.NDArray, error) {
 	if a.Ndim() != 2 || b.Ndim() != 2 {
 		panic("MatMul requires 2D arrays")
 	}
@@ -66,11 +81,18 @@ func MatMul(a, b *tensor.NDArray) *tensor.NDArray {
 		panic(fmt.Sprintf("dimension mismatch: (%d,%d) x (%d,%d)", aShape[0], aShape[1], bShape[0], bShape[1]))
 	}
 	
+	if anyComplex(a, b) {
+		return matMulComplex(ctx, a, b)
+	}
+	
 	m, n, p := aShape[0], aShape[1], bShape[1]
 	result := tensor.Zeros([]int{m, p}, tensor.Float64)
 	
 	// Simple matrix multiplication (can be optimized with BLAS later)
 	for i := 0; i < m; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		for j := 0; j < p; j++ {
 			sum := 0.0
 			for k := 0; k < n; k++ {
@@ -80,7 +102,7 @@ func MatMul(a, b *tensor.NDArray) *tensor.NDArray {
 		}
 	}
 	
-	return result
+	return result, nil
 }
 
 // Outer computes the outer product of two vectors
@@ -101,7 +123,8 @@ func Outer(a, b *tensor.NDArray) *tensor.NDArray {
 	return result
 }
 
-// Inner computes the inner product (same as dot for 1D)
+// Inner computes the inner product (same as dot for 1D). See InnerAxes for
+// ND arrays and VDot for complex vectors.
 func Inner(a, b *tensor.NDArray) float64 {
 	if a.Ndim() != 1 || b.Ndim() != 1 {
 		panic("Inner requires 1D arrays")
@@ -118,8 +141,13 @@ func Inner(a, b *tensor.NDArray) float64 {
 	return sum
 }
 
-// Norm computes the L2 (Euclidean) norm of a vector
+// Norm computes the L2 (Euclidean) norm of a vector. Complex elements
+// contribute their squared magnitude.
 func Norm(a *tensor.NDArray) float64 {
+	if a.DType().IsComplex() {
+		return cvecNorm(a.ToSliceComplex128())
+	}
+	
 	sum := 0.0
 	for i := 0; i < a.Size(); i++ {
 		indices := a.Shape()
@@ -161,12 +189,17 @@ func Trace(a *tensor.NDArray) float64 {
 	return sum
 }
 
-// Det computes the determinant of a square matrix
-// Using simple recursive method (can be optimized with LU decomposition)
+// Det computes the determinant of a square matrix via LU decomposition
+// with partial pivoting in O(n^3). 1x1 and 2x2 matrices use the closed-form
+// expressions. Use DetBatched for stacks of matrices. Det is real-only;
+// use DetComplex for complex matrices.
 func Det(a *tensor.NDArray) float64 {
 	if a.Ndim() != 2 {
 		panic("Det requires a 2D array")
 	}
+	if a.DType().IsComplex() {
+		panic("Det requires a real matrix; use DetComplex for complex input")
+	}
 	
 	shape := a.Shape()
 	if shape[0] != shape[1] {
@@ -183,47 +216,22 @@ func Det(a *tensor.NDArray) float64 {
 		return a.GetFloat64(0, 0)*a.GetFloat64(1, 1) - a.GetFloat64(0, 1)*a.GetFloat64(1, 0)
 	}
 	
-	// For larger matrices, use cofactor expansion (inefficient but simple)
-	det := 0.0
-	for j := 0; j < n; j++ {
-		minor := getMinor(a, 0, j)
-		cofactor := a.GetFloat64(0, j) * Det(minor)
-		if j%2 == 0 {
-			det += cofactor
-		} else {
-			det -= cofactor
-		}
-	}
-	
-	return det
+	return LUFactor(a).Det()
 }
 
-// getMinor returns the minor matrix by removing row i and column j
-func getMinor(a *tensor.NDArray, row, col int) *tensor.NDArray {
-	shape := a.Shape()
-	n := shape[0]
-	
-	data := make([]float64, 0, (n-1)*(n-1))
-	for i := 0; i < n; i++ {
-		if i == row {
-			continue
-		}
-		for j := 0; j < n; j++ {
-			if j == col {
-				continue
-			}
-			data = append(data, a.GetFloat64(i, j))
-		}
-	}
-	
-	return tensor.FromSliceFloat64(data, n-1, n-1)
-}
-
-// Inv computes the inverse of a square matrix using Gauss-Jordan elimination
+// Inv computes the inverse of a square matrix using Gauss-Jordan elimination.
+// Stacks of matrices with shape (..., n, n) are inverted independently.
+// Complex matrices are inverted in complex128 by LU decomposition.
 func Inv(a *tensor.NDArray) *tensor.NDArray {
+	if a.Ndim() > 2 {
+		return mapMatrices(a, Inv)
+	}
 	if a.Ndim() != 2 {
 		panic("Inv requires a 2D array")
 	}
+	if a.DType().IsComplex() {
+		return invComplex(a)
+	}
 	
 	shape := a.Shape()
 	if shape[0] != shape[1] {
@@ -1,7 +1,10 @@
 package linalg
 
 import (
+	"context"
+	"errors"
 	"math"
+	"math/cmplx"
 	"testing"
 	
 	"github.com/iSundram/NumGo/tensor"
@@ -238,3 +241,1273 @@ func TestTranspose(t *testing.T) {
 		t.Errorf("expected 2 at [1,0], got %f", result.GetFloat64(1, 0))
 	}
 }
+
+func TestMatMulContext(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3, 4}, 2, 2)
+	
+	result, err := MatMulContext(context.Background(), a, a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.GetFloat64(1, 1) != 22 {
+		t.Errorf("expected 22 at [1,1], got %f", result.GetFloat64(1, 1))
+	}
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := MatMulContext(ctx, a, a); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	// Requires pivoting: a[0][0] is zero
+	a := tensor.FromSliceFloat64([]float64{
+		0, 2, 1,
+		1, 1, 1,
+		2, 1, 0,
+	}, 3, 3)
+	b := tensor.FromSliceFloat64([]float64{7, 6, 4}, 3)
+	
+	x := Solve(a, b)
+	
+	if x.Ndim() != 1 {
+		t.Fatalf("expected 1D result, got %dD", x.Ndim())
+	}
+	expected := []float64{1, 2, 3}
+	for i := 0; i < 3; i++ {
+		if math.Abs(x.GetFloat64(i)-expected[i]) > 1e-10 {
+			t.Errorf("expected %f at [%d], got %f", expected[i], i, x.GetFloat64(i))
+		}
+	}
+}
+
+func TestSolveMultipleRHS(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{4, 7, 2, 6}, 2, 2)
+	b := tensor.FromSliceFloat64([]float64{1, 0, 0, 1}, 2, 2)
+	
+	// Solving against the identity yields the inverse
+	x := Solve(a, b)
+	inv := Inv(a)
+	
+	if !x.AllClose(inv, 1e-10, 1e-10) {
+		t.Errorf("expected Solve(A, I) to match Inv(A), got %v", x.ToSliceFloat64())
+	}
+}
+
+func TestSolveSingular(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 2, 4}, 2, 2)
+	b := tensor.FromSliceFloat64([]float64{1, 2}, 2)
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Solve on singular matrix to panic")
+		}
+	}()
+	Solve(a, b)
+}
+
+// checkOrthonormalColumns verifies q^T q = I
+func checkOrthonormalColumns(t *testing.T, name string, q *tensor.NDArray) {
+	t.Helper()
+	k := q.Shape()[1]
+	qtq := MatMul(q.T(), q)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			expected := 0.0
+			if i == j {
+				expected = 1.0
+			}
+			if math.Abs(qtq.GetFloat64(i, j)-expected) > 1e-10 {
+				t.Errorf("%s: columns not orthonormal, (Q^T Q)[%d,%d] = %f", name, i, j, qtq.GetFloat64(i, j))
+			}
+		}
+	}
+}
+
+// reconstructSVD computes U diag(S) Vt using the first len(S) columns/rows
+func reconstructSVD(u, s, vt *tensor.NDArray) *tensor.NDArray {
+	m, n, k := u.Shape()[0], vt.Shape()[1], s.Size()
+	result := tensor.Zeros([]int{m, n}, tensor.Float64)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			sum := 0.0
+			for l := 0; l < k; l++ {
+				sum += u.GetFloat64(i, l) * s.GetFloat64(l) * vt.GetFloat64(l, j)
+			}
+			result.SetFloat64(sum, i, j)
+		}
+	}
+	return result
+}
+
+func TestSVD(t *testing.T) {
+	tall := tensor.FromSliceFloat64([]float64{
+		2, 0, 1,
+		1, 3, 0,
+		0, 1, 4,
+		1, 1, 1,
+	}, 4, 3)
+	
+	for _, a := range []*tensor.NDArray{tall, tall.T()} {
+		m, n := a.Shape()[0], a.Shape()[1]
+		k := m
+		if n < k {
+			k = n
+		}
+		
+		u, s, vt := SVD(a, false)
+		if u.Shape()[0] != m || u.Shape()[1] != k || s.Size() != k || vt.Shape()[0] != k || vt.Shape()[1] != n {
+			t.Fatalf("unexpected thin shapes U%v S%v Vt%v for A%v", u.Shape(), s.Shape(), vt.Shape(), a.Shape())
+		}
+		for i := 1; i < k; i++ {
+			if s.GetFloat64(i) > s.GetFloat64(i-1) {
+				t.Errorf("singular values not descending: %v", s.ToSliceFloat64())
+			}
+		}
+		if !reconstructSVD(u, s, vt).AllClose(a, 1e-10, 1e-10) {
+			t.Errorf("U S Vt does not reconstruct A%v", a.Shape())
+		}
+		checkOrthonormalColumns(t, "U", u)
+		checkOrthonormalColumns(t, "V", vt.T())
+		
+		uf, _, vtf := SVD(a, true)
+		if uf.Shape()[1] != m || vtf.Shape()[0] != n {
+			t.Fatalf("unexpected full shapes U%v Vt%v for A%v", uf.Shape(), vtf.Shape(), a.Shape())
+		}
+		checkOrthonormalColumns(t, "full U", uf)
+		checkOrthonormalColumns(t, "full V", vtf.T())
+	}
+}
+
+func TestSVDRankDeficient(t *testing.T) {
+	// Rank 1: every row is a multiple of [1 2 3]
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3, 2, 4, 6, 3, 6, 9}, 3, 3)
+	
+	u, s, vt := SVD(a, true)
+	
+	if math.Abs(s.GetFloat64(1)) > 1e-10 || math.Abs(s.GetFloat64(2)) > 1e-10 {
+		t.Errorf("expected two zero singular values, got %v", s.ToSliceFloat64())
+	}
+	if math.Abs(s.GetFloat64(0)-14) > 1e-10 {
+		t.Errorf("expected largest singular value 14, got %f", s.GetFloat64(0))
+	}
+	checkOrthonormalColumns(t, "U", u)
+	if !reconstructSVD(u, s, vt).AllClose(a, 1e-10, 1e-10) {
+		t.Error("U S Vt does not reconstruct rank-deficient A")
+	}
+}
+
+func TestLU(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{
+		1, 2, 3,
+		0, 1, 4,
+		5, 6, 0,
+	}, 3, 3)
+	
+	p, l, u := LU(a)
+	
+	if !MatMul(p, MatMul(l, u)).AllClose(a, 1e-10, 1e-10) {
+		t.Error("P L U does not reconstruct A")
+	}
+	for i := 0; i < 3; i++ {
+		if l.GetFloat64(i, i) != 1 {
+			t.Errorf("expected unit diagonal in L, got %f at [%d,%d]", l.GetFloat64(i, i), i, i)
+		}
+		for j := i + 1; j < 3; j++ {
+			if l.GetFloat64(i, j) != 0 || u.GetFloat64(j, i) != 0 {
+				t.Errorf("expected triangular factors, got nonzero at [%d,%d]", i, j)
+			}
+		}
+	}
+	
+	f := LUFactor(a)
+	if math.Abs(f.Det()-1.0) > 1e-10 {
+		t.Errorf("expected determinant 1, got %f", f.Det())
+	}
+	
+	x := f.Solve(tensor.FromSliceFloat64([]float64{14, 14, 17}, 3))
+	expected := []float64{1, 2, 3}
+	for i := 0; i < 3; i++ {
+		if math.Abs(x.GetFloat64(i)-expected[i]) > 1e-10 {
+			t.Errorf("expected %f at [%d], got %f", expected[i], i, x.GetFloat64(i))
+		}
+	}
+	
+	singular := LUFactor(tensor.FromSliceFloat64([]float64{1, 2, 2, 4}, 2, 2))
+	if !singular.IsSingular() || singular.Det() != 0 {
+		t.Error("expected singular factorization with zero determinant")
+	}
+}
+
+func TestEigh(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{
+		4, 1, 2, 0,
+		1, 3, 0, 1,
+		2, 0, 5, 1,
+		0, 1, 1, 2,
+	}, 4, 4)
+	
+	w, v := Eigh(a)
+	
+	for i := 1; i < 4; i++ {
+		if w.GetFloat64(i) < w.GetFloat64(i-1) {
+			t.Errorf("eigenvalues not ascending: %v", w.ToSliceFloat64())
+		}
+	}
+	if math.Abs(w.Sum()-Trace(a)) > 1e-10 {
+		t.Errorf("expected eigenvalues to sum to trace %f, got %f", Trace(a), w.Sum())
+	}
+	checkOrthonormalColumns(t, "eigenvectors", v)
+	
+	// A v = v diag(w)
+	av := MatMul(a, v)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			expected := v.GetFloat64(i, j) * w.GetFloat64(j)
+			if math.Abs(av.GetFloat64(i, j)-expected) > 1e-10 {
+				t.Errorf("A v != v diag(w) at [%d,%d]: %f vs %f", i, j, av.GetFloat64(i, j), expected)
+			}
+		}
+	}
+}
+
+func TestEighDiagonal(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{3, 0, 0, 0, 1, 0, 0, 0, 2}, 3, 3)
+	
+	w, _ := Eigh(a)
+	
+	expected := []float64{1, 2, 3}
+	for i := 0; i < 3; i++ {
+		if math.Abs(w.GetFloat64(i)-expected[i]) > 1e-12 {
+			t.Errorf("expected %f at [%d], got %f", expected[i], i, w.GetFloat64(i))
+		}
+	}
+}
+
+func TestEigvalsh(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{
+		4, 1, 2, 0,
+		1, 3, 0, 1,
+		2, 0, 5, 1,
+		0, 1, 1, 2,
+	}, 4, 4)
+	
+	w, _ := Eigh(a)
+	values := Eigvalsh(a)
+	
+	if !values.AllClose(w, 1e-10, 1e-10) {
+		t.Errorf("expected Eigvalsh to match Eigh: %v vs %v", values.ToSliceFloat64(), w.ToSliceFloat64())
+	}
+}
+
+func TestEigvals(t *testing.T) {
+	// Rotation-like block has eigenvalues 1±2i, plus a real eigenvalue 3
+	a := tensor.FromSliceFloat64([]float64{
+		1, -2, 0,
+		2, 1, 0,
+		0, 0, 3,
+	}, 3, 3)
+	
+	values := Eigvals(a).ToSliceComplex128()
+	
+	expected := []complex128{complex(1, 2), complex(1, -2), 3}
+	for _, e := range expected {
+		found := false
+		for _, v := range values {
+			if math.Abs(real(v)-real(e)) < 1e-10 && math.Abs(imag(v)-imag(e)) < 1e-10 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected eigenvalue %v in %v", e, values)
+		}
+	}
+	
+	// Non-symmetric with real eigenvalues 2 and 5 (trace 7, det 10)
+	b := tensor.FromSliceFloat64([]float64{4, 1, 2, 3}, 2, 2)
+	bv := Eigvals(b).ToSliceComplex128()
+	sum := real(bv[0]) + real(bv[1])
+	prod := real(bv[0]) * real(bv[1])
+	if math.Abs(sum-7) > 1e-10 || math.Abs(prod-10) > 1e-10 || imag(bv[0]) != 0 {
+		t.Errorf("expected eigenvalues 2 and 5, got %v", bv)
+	}
+	
+	// Larger random-ish matrix: eigenvalues sum to the trace
+	c := tensor.FromSliceFloat64([]float64{
+		2, 7, 1, 8, 2,
+		8, 1, 8, 2, 8,
+		4, 5, 9, 0, 4,
+		5, 2, 3, 5, 3,
+		6, 0, 2, 8, 7,
+	}, 5, 5)
+	var total complex128
+	for _, v := range Eigvals(c).ToSliceComplex128() {
+		total += v
+	}
+	if math.Abs(real(total)-Trace(c)) > 1e-9 || math.Abs(imag(total)) > 1e-9 {
+		t.Errorf("expected eigenvalues to sum to trace %f, got %v", Trace(c), total)
+	}
+}
+
+func TestPinv(t *testing.T) {
+	// For invertible matrices Pinv matches Inv
+	a := tensor.FromSliceFloat64([]float64{4, 7, 2, 6}, 2, 2)
+	if !Pinv(a, 0).AllClose(Inv(a), 1e-10, 1e-10) {
+		t.Error("expected Pinv to match Inv for an invertible matrix")
+	}
+	
+	// Rank-deficient tall matrix: check the Penrose conditions
+	b := tensor.FromSliceFloat64([]float64{1, 2, 2, 4, 3, 6}, 3, 2)
+	p := Pinv(b, 0)
+	if shape := p.Shape(); shape[0] != 2 || shape[1] != 3 {
+		t.Fatalf("expected shape [2 3], got %v", shape)
+	}
+	if !MatMul(MatMul(b, p), b).AllClose(b, 1e-10, 1e-10) {
+		t.Error("expected A A+ A = A")
+	}
+	if !MatMul(MatMul(p, b), p).AllClose(p, 1e-10, 1e-10) {
+		t.Error("expected A+ A A+ = A+")
+	}
+	bp := MatMul(b, p)
+	if !bp.AllClose(bp.T(), 1e-10, 1e-10) {
+		t.Error("expected A A+ to be symmetric")
+	}
+}
+
+func TestMatrixNorm(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, -2, 3, 4}, 2, 2)
+	
+	cases := []struct {
+		ord      NormOrder
+		expected float64
+	}{
+		{NormOne, 6},
+		{NormInf, 7},
+		{NormFro, math.Sqrt(30)},
+	}
+	for _, c := range cases {
+		if got := MatrixNorm(a, c.ord); math.Abs(got-c.expected) > 1e-10 {
+			t.Errorf("norm %s: expected %f, got %f", c.ord, c.expected, got)
+		}
+	}
+	
+	// Spectral norm of a diagonal matrix is its largest absolute entry
+	d := tensor.FromSliceFloat64([]float64{3, 0, 0, -5}, 2, 2)
+	if got := MatrixNorm(d, NormTwo); math.Abs(got-5) > 1e-10 {
+		t.Errorf("norm 2: expected 5, got %f", got)
+	}
+}
+
+func TestCond(t *testing.T) {
+	d := tensor.FromSliceFloat64([]float64{1, 0, 0, 100}, 2, 2)
+	for _, ord := range []NormOrder{NormTwo, NormOne, NormInf} {
+		if got := Cond(d, ord); math.Abs(got-100) > 1e-8 {
+			t.Errorf("cond %s: expected 100, got %f", ord, got)
+		}
+	}
+	
+	if got := Cond(tensor.Eye(3, tensor.Float64), NormTwo); math.Abs(got-1) > 1e-12 {
+		t.Errorf("expected identity to have condition number 1, got %f", got)
+	}
+	
+	singular := tensor.FromSliceFloat64([]float64{1, 2, 2, 4}, 2, 2)
+	if !math.IsInf(Cond(singular, NormOne), 1) {
+		t.Error("expected infinite condition number for singular matrix")
+	}
+}
+
+func TestMultiDot(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	b := tensor.FromSliceFloat64([]float64{1, 0, 0, 1, 1, 1}, 3, 2)
+	c := tensor.FromSliceFloat64([]float64{2, 1, 0, 1}, 2, 2)
+	
+	expected := MatMul(MatMul(a, b), c)
+	if !MultiDot(a, b, c).AllClose(expected, 1e-12, 1e-12) {
+		t.Error("expected MultiDot to match sequential MatMul")
+	}
+	
+	// Vector ends: x^T A b y is a scalar
+	x := tensor.FromSliceFloat64([]float64{1, 1}, 2)
+	y := tensor.FromSliceFloat64([]float64{1, 2}, 2)
+	r := MultiDot(x, a, b, y)
+	if r.Size() != 1 {
+		t.Fatalf("expected scalar result, got shape %v", r.Shape())
+	}
+	ab := MatMul(a, b)
+	want := 0.0
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			want += x.GetFloat64(i) * ab.GetFloat64(i, j) * y.GetFloat64(j)
+		}
+	}
+	if r.GetFloat64(0) != want {
+		t.Errorf("expected %f, got %f", want, r.GetFloat64(0))
+	}
+}
+
+func TestMatrixChainOrder(t *testing.T) {
+	// (10x100)(100x5)(5x50): ((AB)C) costs 7500, (A(BC)) costs 75000
+	split := matrixChainOrder([]int{10, 100, 5, 50})
+	if split[0][2] != 1 {
+		t.Errorf("expected split after B, got %d", split[0][2])
+	}
+}
+
+func TestSolveTriangular(t *testing.T) {
+	lower := tensor.FromSliceFloat64([]float64{
+		2, 0, 0,
+		1, 3, 0,
+		4, 5, 6,
+	}, 3, 3)
+	upper := lower.T()
+	x := tensor.FromSliceFloat64([]float64{1, -1, 2}, 3)
+	
+	cases := []struct {
+		name  string
+		a     *tensor.NDArray
+		lower bool
+		trans bool
+		op    *tensor.NDArray
+	}{
+		{"lower", lower, true, false, lower},
+		{"upper", upper, false, false, upper},
+		{"lower transposed", lower, true, true, upper},
+		{"upper transposed", upper, false, true, lower},
+	}
+	for _, c := range cases {
+		b := Dot(c.op, x)
+		got := SolveTriangular(c.a, b, c.lower, false, c.trans)
+		if !got.AllClose(x, 1e-12, 1e-12) {
+			t.Errorf("%s: expected %v, got %v", c.name, x.ToSliceFloat64(), got.ToSliceFloat64())
+		}
+	}
+	
+	// Unit diagonal ignores the stored diagonal
+	unit := tensor.FromSliceFloat64([]float64{9, 0, 2, 9}, 2, 2)
+	got := SolveTriangular(unit, tensor.FromSliceFloat64([]float64{1, 4}, 2), true, true, false)
+	if got.GetFloat64(0) != 1 || got.GetFloat64(1) != 2 {
+		t.Errorf("expected [1 2], got %v", got.ToSliceFloat64())
+	}
+}
+
+func TestSolveTridiag(t *testing.T) {
+	dl := tensor.FromSliceFloat64([]float64{1, 1, 1}, 3)
+	d := tensor.FromSliceFloat64([]float64{4, 4, 4, 4}, 4)
+	du := tensor.FromSliceFloat64([]float64{1, 1, 1}, 3)
+	
+	dense := tensor.FromSliceFloat64([]float64{
+		4, 1, 0, 0,
+		1, 4, 1, 0,
+		0, 1, 4, 1,
+		0, 0, 1, 4,
+	}, 4, 4)
+	b := tensor.FromSliceFloat64([]float64{1, 2, 3, 4}, 4)
+	
+	x := SolveTridiag(dl, d, du, b)
+	if !x.AllClose(Solve(dense, b), 1e-12, 1e-12) {
+		t.Errorf("expected SolveTridiag to match Solve, got %v", x.ToSliceFloat64())
+	}
+}
+
+func TestSolveBanded(t *testing.T) {
+	// Zero leading diagonal entry forces pivoting; l=1, u=2
+	dense := tensor.FromSliceFloat64([]float64{
+		0, 2, 1, 0, 0,
+		3, 1, 4, 1, 0,
+		0, 5, 9, 2, 6,
+		0, 0, 5, 3, 5,
+		0, 0, 0, 8, 9,
+	}, 5, 5)
+	l, u := 1, 2
+	ab := tensor.Zeros([]int{l + u + 1, 5}, tensor.Float64)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			if j-i <= u && i-j <= l {
+				ab.SetFloat64(dense.GetFloat64(i, j), u+i-j, j)
+			}
+		}
+	}
+	b := tensor.FromSliceFloat64([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 5, 2)
+	
+	x := SolveBanded(l, u, ab, b)
+	if !x.AllClose(Solve(dense, b), 1e-10, 1e-10) {
+		t.Errorf("expected SolveBanded to match Solve, got %v", x.ToSliceFloat64())
+	}
+}
+
+func TestDetLarge(t *testing.T) {
+	const n = 100
+	
+	// A = P L U with unit lower L, upper U with known diagonal, and P a
+	// cyclic shift (an odd permutation for even n), so det(A) = -prod(diag U)
+	l := tensor.Eye(n, tensor.Float64)
+	u := tensor.Eye(n, tensor.Float64)
+	expected := -1.0
+	for i := 0; i < n; i++ {
+		d := 1 + float64(i%7)/10
+		u.SetFloat64(d, i, i)
+		expected *= d
+		for j := 0; j < i; j++ {
+			l.SetFloat64(float64((i*j)%5)/10-0.2, i, j)
+			u.SetFloat64(float64((i+j)%3)/10, j, i)
+		}
+	}
+	p := tensor.Zeros([]int{n, n}, tensor.Float64)
+	for i := 0; i < n; i++ {
+		p.SetFloat64(1, i, (i+1)%n)
+	}
+	a := MatMul(p, MatMul(l, u))
+	
+	det := Det(a)
+	if math.Abs(det-expected) > 1e-8*math.Abs(expected) {
+		t.Errorf("expected det %g, got %g", expected, det)
+	}
+	
+	// det(A B) = det(A) det(B)
+	b := MatMul(u.T(), l.T())
+	if got := Det(MatMul(a, b)); math.Abs(got-det*Det(b)) > 1e-6*math.Abs(got) {
+		t.Errorf("expected det(AB) = det(A) det(B), got %g vs %g", got, det*Det(b))
+	}
+	
+	// Singular: duplicate a row
+	for j := 0; j < n; j++ {
+		a.SetFloat64(a.GetFloat64(0, j), 1, j)
+	}
+	if got := Det(a); math.Abs(got) > 1e-8 {
+		t.Errorf("expected det 0 for singular matrix, got %g", got)
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{
+		4, 12, -16,
+		12, 37, -43,
+		-16, -43, 98,
+	}, 3, 3)
+	
+	l := Cholesky(a)
+	
+	expected := []float64{2, 0, 0, 6, 1, 0, -8, 5, 3}
+	if !l.AllClose(tensor.FromSliceFloat64(expected, 3, 3), 1e-12, 1e-12) {
+		t.Errorf("expected %v, got %v", expected, l.ToSliceFloat64())
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Cholesky of indefinite matrix to panic")
+		}
+	}()
+	Cholesky(tensor.FromSliceFloat64([]float64{1, 2, 2, 1}, 2, 2))
+}
+
+func TestBatchedLinalg(t *testing.T) {
+	m1 := []float64{4, 7, 2, 6}
+	m2 := []float64{2, 1, 1, 3}
+	stack := tensor.FromSliceFloat64(append(append([]float64{}, m1...), m2...), 2, 2, 2)
+	a1 := tensor.FromSliceFloat64(m1, 2, 2)
+	a2 := tensor.FromSliceFloat64(m2, 2, 2)
+	
+	dets := DetBatched(stack)
+	if dets.Size() != 2 || dets.GetFloat64(0) != Det(a1) || dets.GetFloat64(1) != Det(a2) {
+		t.Errorf("expected dets [%f %f], got %v", Det(a1), Det(a2), dets.ToSliceFloat64())
+	}
+	
+	inv := Inv(stack)
+	if shape := inv.Shape(); len(shape) != 3 || shape[0] != 2 {
+		t.Fatalf("expected shape [2 2 2], got %v", shape)
+	}
+	for i, a := range []*tensor.NDArray{a1, a2} {
+		for r := 0; r < 2; r++ {
+			for c := 0; c < 2; c++ {
+				if math.Abs(inv.GetFloat64(i, r, c)-Inv(a).GetFloat64(r, c)) > 1e-12 {
+					t.Errorf("batched Inv differs for matrix %d at [%d,%d]", i, r, c)
+				}
+			}
+		}
+	}
+	
+	// Stack of vectors
+	b := tensor.FromSliceFloat64([]float64{1, 2, 3, 4}, 2, 2)
+	x := Solve(stack, b)
+	x1 := Solve(a1, tensor.FromSliceFloat64([]float64{1, 2}, 2))
+	x2 := Solve(a2, tensor.FromSliceFloat64([]float64{3, 4}, 2))
+	for j := 0; j < 2; j++ {
+		if math.Abs(x.GetFloat64(0, j)-x1.GetFloat64(j)) > 1e-12 || math.Abs(x.GetFloat64(1, j)-x2.GetFloat64(j)) > 1e-12 {
+			t.Errorf("batched Solve differs at component %d", j)
+		}
+	}
+	
+	// Shared right-hand side
+	shared := Solve(stack, tensor.FromSliceFloat64([]float64{1, 2}, 2))
+	if math.Abs(shared.GetFloat64(0, 0)-x1.GetFloat64(0)) > 1e-12 {
+		t.Error("expected shared right-hand side to be used for every system")
+	}
+	
+	spd := tensor.FromSliceFloat64([]float64{4, 2, 2, 3, 9, 3, 3, 5}, 2, 2, 2)
+	l := Cholesky(spd)
+	if math.Abs(l.GetFloat64(0, 0, 0)-2) > 1e-12 || math.Abs(l.GetFloat64(1, 0, 0)-3) > 1e-12 {
+		t.Errorf("unexpected batched Cholesky result %v", l.ToSliceFloat64())
+	}
+	
+	// Empty batches and complex stacks
+	empty := Solve(tensor.Zeros([]int{0, 3, 3}, tensor.Float64), tensor.Zeros([]int{0, 3}, tensor.Float64))
+	if shape := empty.Shape(); len(shape) != 2 || shape[0] != 0 || shape[1] != 3 {
+		t.Errorf("expected shape [0 3], got %v", shape)
+	}
+	cstack := tensor.FromSliceComplex128([]complex128{2i, 0, 0, 1, 1, 0, 0, 1i}, 2, 2, 2)
+	cx := Solve(cstack, tensor.FromSliceComplex128([]complex128{2i, 1, 1, 1i}, 2, 2))
+	if cx.DType() != tensor.Complex128 {
+		t.Fatalf("expected complex128 result, got %s", cx.DType())
+	}
+	for i, v := range cx.ToSliceComplex128() {
+		if cmplx.Abs(v-1) > 1e-12 {
+			t.Errorf("complex batched Solve: element %d is %v, expected 1", i, v)
+		}
+	}
+}
+
+func TestExpm(t *testing.T) {
+	// exp of a diagonal matrix exponentiates the diagonal
+	d := tensor.FromSliceFloat64([]float64{1, 0, 0, -2}, 2, 2)
+	e := Expm(d)
+	expected := tensor.FromSliceFloat64([]float64{math.E, 0, 0, math.Exp(-2)}, 2, 2)
+	if !e.AllClose(expected, 1e-12, 1e-14) {
+		t.Errorf("expected %v, got %v", expected.ToSliceFloat64(), e.ToSliceFloat64())
+	}
+	
+	// exp of a rotation generator is a rotation
+	theta := 2.5
+	r := Expm(tensor.FromSliceFloat64([]float64{0, -theta, theta, 0}, 2, 2))
+	rot := tensor.FromSliceFloat64([]float64{math.Cos(theta), -math.Sin(theta), math.Sin(theta), math.Cos(theta)}, 2, 2)
+	if !r.AllClose(rot, 1e-12, 1e-12) {
+		t.Errorf("expected %v, got %v", rot.ToSliceFloat64(), r.ToSliceFloat64())
+	}
+	
+	// Nilpotent matrix: exp(N) = I + N
+	n := Expm(tensor.FromSliceFloat64([]float64{0, 30, 0, 0}, 2, 2))
+	if !n.AllClose(tensor.FromSliceFloat64([]float64{1, 30, 0, 1}, 2, 2), 1e-10, 1e-10) {
+		t.Errorf("expected [1 30 0 1], got %v", n.ToSliceFloat64())
+	}
+}
+
+func TestSqrtm(t *testing.T) {
+	// Symmetric positive definite
+	spd := tensor.FromSliceFloat64([]float64{5, 4, 4, 5}, 2, 2)
+	s := Sqrtm(spd)
+	if !MatMul(s, s).AllClose(spd, 1e-12, 1e-12) {
+		t.Errorf("Sqrtm(A)^2 != A for symmetric A, got %v", MatMul(s, s).ToSliceFloat64())
+	}
+	if !s.AllClose(tensor.FromSliceFloat64([]float64{2, 1, 1, 2}, 2, 2), 1e-12, 1e-12) {
+		t.Errorf("expected principal root [2 1 1 2], got %v", s.ToSliceFloat64())
+	}
+	
+	// Nonsymmetric with positive eigenvalues
+	a := tensor.FromSliceFloat64([]float64{4, 1, 0, 2, 9, 3, 1, 0, 16}, 3, 3)
+	r := Sqrtm(a)
+	if !MatMul(r, r).AllClose(a, 1e-10, 1e-10) {
+		t.Errorf("Sqrtm(A)^2 != A, got %v", MatMul(r, r).ToSliceFloat64())
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Sqrtm of indefinite symmetric matrix to panic")
+		}
+	}()
+	Sqrtm(tensor.FromSliceFloat64([]float64{1, 2, 2, 1}, 2, 2))
+}
+
+func TestLogm(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{4, 1, 0, 2, 9, 3, 1, 0, 16}, 3, 3)
+	l := Logm(a)
+	if !Expm(l).AllClose(a, 1e-9, 1e-9) {
+		t.Errorf("Expm(Logm(A)) != A, got %v", Expm(l).ToSliceFloat64())
+	}
+	
+	// Logm inverts Expm for a matrix with small eigenvalues
+	x := tensor.FromSliceFloat64([]float64{0.1, 0.7, -0.3, 0.2}, 2, 2)
+	if !Logm(Expm(x)).AllClose(x, 1e-10, 1e-10) {
+		t.Errorf("Logm(Expm(X)) != X, got %v", Logm(Expm(x)).ToSliceFloat64())
+	}
+	
+	spd := tensor.FromSliceFloat64([]float64{2, 1, 1, 2}, 2, 2)
+	if !Expm(Logm(spd)).AllClose(spd, 1e-12, 1e-12) {
+		t.Errorf("Expm(Logm(A)) != A for symmetric A")
+	}
+}
+
+func TestHessenberg(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{
+		4, 1, -2, 2,
+		1, 2, 0, 1,
+		-2, 0, 3, -2,
+		2, 1, -2, -1,
+	}, 4, 4)
+	
+	h, q := Hessenberg(a)
+	
+	for i := 2; i < 4; i++ {
+		for j := 0; j < i-1; j++ {
+			if h.GetFloat64(i, j) != 0 {
+				t.Errorf("expected H[%d,%d] = 0, got %f", i, j, h.GetFloat64(i, j))
+			}
+		}
+	}
+	if !MatMul(Transpose(q), q).AllClose(tensor.Eye(4, tensor.Float64), 1e-12, 1e-12) {
+		t.Error("expected Q to be orthogonal")
+	}
+	if !MultiDot(q, h, Transpose(q)).AllClose(a, 1e-12, 1e-12) {
+		t.Error("expected Q H Q^T to reconstruct A")
+	}
+}
+
+func TestSchur(t *testing.T) {
+	// Rotation-like block gives a complex pair, plus two real eigenvalues
+	a := tensor.FromSliceFloat64([]float64{
+		1, -2, 0, 3,
+		2, 1, 1, 0,
+		0, 0, 5, 1,
+		1, 0, 0, -3,
+	}, 4, 4)
+	
+	tm, z := Schur(a)
+	
+	if !MatMul(Transpose(z), z).AllClose(tensor.Eye(4, tensor.Float64), 1e-12, 1e-12) {
+		t.Error("expected Z to be orthogonal")
+	}
+	if !MultiDot(z, tm, Transpose(z)).AllClose(a, 1e-10, 1e-10) {
+		t.Error("expected Z T Z^T to reconstruct A")
+	}
+	
+	// T is quasi-triangular: no two consecutive nonzero subdiagonals
+	for i := 2; i < 4; i++ {
+		for j := 0; j < i-1; j++ {
+			if tm.GetFloat64(i, j) != 0 {
+				t.Errorf("expected T[%d,%d] = 0, got %f", i, j, tm.GetFloat64(i, j))
+			}
+		}
+		if tm.GetFloat64(i, i-1) != 0 && tm.GetFloat64(i-1, i-2) != 0 {
+			t.Errorf("overlapping 2x2 blocks at row %d", i)
+		}
+	}
+	
+	// Upper triangular for a matrix with real eigenvalues
+	sym := tensor.FromSliceFloat64([]float64{2, 1, 1, 3}, 2, 2)
+	ts, _ := Schur(sym)
+	if ts.GetFloat64(1, 0) != 0 {
+		t.Errorf("expected triangular T, got %v", ts.ToSliceFloat64())
+	}
+}
+
+func TestComplexLinalg(t *testing.T) {
+	a := tensor.FromSliceComplex128([]complex128{
+		2 + 1i, 1 - 1i, 0,
+		-1i, 3, 1 + 2i,
+		1, 2 - 1i, 4 + 1i,
+	}, 3, 3)
+	
+	// MatMul agrees with a hand-computed entry
+	p := MatMul(a, ConjTranspose(a))
+	var expected complex128
+	for k := 0; k < 3; k++ {
+		z := a.GetComplex128(0, k)
+		expected += z * complex(real(z), -imag(z))
+	}
+	if cmplx.Abs(p.GetComplex128(0, 0)-expected) > 1e-12 {
+		t.Errorf("expected %v, got %v", expected, p.GetComplex128(0, 0))
+	}
+	
+	// Mixed real and complex operands promote to complex
+	mixed := MatMul(tensor.Eye(3, tensor.Float64), a)
+	if mixed.DType() != tensor.Complex128 || mixed.GetComplex128(2, 1) != 2-1i {
+		t.Errorf("expected complex identity product, got %v", mixed.ToSliceComplex128())
+	}
+	
+	// Solve
+	b := tensor.FromSliceComplex128([]complex128{1, 1i, 2 - 1i}, 3)
+	x := Solve(a, b)
+	ax := MatMul(a, x.Reshape(3, 1))
+	for i := 0; i < 3; i++ {
+		if cmplx.Abs(ax.GetComplex128(i, 0)-b.GetComplex128(i)) > 1e-12 {
+			t.Errorf("A x != b at %d: %v vs %v", i, ax.GetComplex128(i, 0), b.GetComplex128(i))
+		}
+	}
+	
+	// Norms use magnitudes
+	v := tensor.FromSliceComplex128([]complex128{3 + 4i, 0}, 2)
+	if Norm(v) != 5 {
+		t.Errorf("expected norm 5, got %f", Norm(v))
+	}
+	if fro := MatrixNorm(a, NormFro); math.Abs(fro*fro-real(trace(p))) > 1e-9 {
+		t.Errorf("unexpected Frobenius norm %f", fro)
+	}
+	
+	// SVD reconstructs A = U diag(S) V^H and the spectral norm matches
+	u, s, vt := SVD(a, false)
+	if u.DType() != tensor.Complex128 || vt.DType() != tensor.Complex128 {
+		t.Fatal("expected complex singular vectors")
+	}
+	sd := tensor.FromSliceComplex128([]complex128{
+		complex(s.GetFloat64(0), 0), 0, 0,
+		0, complex(s.GetFloat64(1), 0), 0,
+		0, 0, complex(s.GetFloat64(2), 0),
+	}, 3, 3)
+	if !complexClose(MultiDot(u, sd, vt), a, 1e-10) {
+		t.Error("expected U S V^H to reconstruct A")
+	}
+	if math.Abs(MatrixNorm(a, NormTwo)-s.GetFloat64(0)) > 1e-12 {
+		t.Error("expected spectral norm to equal the largest singular value")
+	}
+	
+	// Wide complex SVD
+	wide := tensor.FromSliceComplex128([]complex128{1i, 2, 0, 1 - 1i, 3i, 1}, 2, 3)
+	uw, sw, vtw := SVD(wide, false)
+	swd := tensor.FromSliceComplex128([]complex128{complex(sw.GetFloat64(0), 0), 0, 0, complex(sw.GetFloat64(1), 0)}, 2, 2)
+	if !complexClose(MultiDot(uw, swd, vtw), wide, 1e-10) {
+		t.Error("expected wide U S V^H to reconstruct A")
+	}
+	
+	// Eigenvalues and eigenvectors
+	w, vecs := Eig(a)
+	for j := 0; j < 3; j++ {
+		lambda := w.GetComplex128(j)
+		for i := 0; i < 3; i++ {
+			var av complex128
+			for k := 0; k < 3; k++ {
+				av += a.GetComplex128(i, k) * vecs.GetComplex128(k, j)
+			}
+			if cmplx.Abs(av-lambda*vecs.GetComplex128(i, j)) > 1e-9 {
+				t.Errorf("A v != lambda v for eigenpair %d", j)
+				break
+			}
+		}
+	}
+	var sum complex128
+	for _, ev := range Eigvals(a).ToSliceComplex128() {
+		sum += ev
+	}
+	if cmplx.Abs(sum-trace(a)) > 1e-10 {
+		t.Errorf("expected eigenvalues to sum to the trace %v, got %v", trace(a), sum)
+	}
+	
+	// Inv, DetComplex and Dot
+	prod := MatMul(a, Inv(a))
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			want := complex(0, 0)
+			if i == j {
+				want = 1
+			}
+			if cmplx.Abs(prod.GetComplex128(i, j)-want) > 1e-12 {
+				t.Errorf("A Inv(A) differs from I at [%d,%d]: %v", i, j, prod.GetComplex128(i, j))
+			}
+		}
+	}
+	if d := DetComplex(a); cmplx.Abs(d-complexDet3(a)) > 1e-12 {
+		t.Errorf("expected det %v, got %v", complexDet3(a), d)
+	}
+	swap := tensor.FromSliceComplex128([]complex128{0, 1, 1i, 0}, 2, 2)
+	if d := DetComplex(swap); d != -1i {
+		t.Errorf("expected det -i for a row swap, got %v", d)
+	}
+	dets := DetBatched(tensor.FromSliceComplex128(append(swap.ToSliceComplex128(), swap.ToSliceComplex128()...), 2, 2, 2))
+	if dets.DType() != tensor.Complex128 || dets.GetComplex128(1) != -1i {
+		t.Errorf("expected complex batched dets [-i -i], got %v", dets.ToSliceComplex128())
+	}
+	cv := tensor.FromSliceComplex128([]complex128{1i, 2}, 2)
+	if d := Dot(cv, tensor.FromSliceFloat64([]float64{3, 1}, 2)); d.GetComplex128(0) != 2+3i {
+		t.Errorf("expected unconjugated dot 2+3i, got %v", d.GetComplex128(0))
+	}
+	if mv := Dot(swap, cv); mv.GetComplex128(0) != 2 || mv.GetComplex128(1) != -1 {
+		t.Errorf("expected [2 -1], got %v", mv.ToSliceComplex128())
+	}
+	if w := Eigvals(a); w.DType() != tensor.Complex128 || w.Size() != 3 {
+		t.Errorf("expected 3 complex128 eigenvalues, got %s of size %d", w.DType(), w.Size())
+	}
+}
+
+func TestEigReal(t *testing.T) {
+	// Rotation block has eigenvalues +-i, plus a real eigenvalue 2
+	a := tensor.FromSliceFloat64([]float64{0, -1, 0, 1, 0, 0, 0, 0, 2}, 3, 3)
+	w, v := Eig(a)
+	ac := tensor.FromSliceComplex128(a.ToSliceComplex128(), 3, 3)
+	av := MatMul(ac, v)
+	for j := 0; j < 3; j++ {
+		for i := 0; i < 3; i++ {
+			if cmplx.Abs(av.GetComplex128(i, j)-w.GetComplex128(j)*v.GetComplex128(i, j)) > 1e-10 {
+				t.Errorf("A v != lambda v for eigenpair %d", j)
+			}
+		}
+	}
+	
+	// Repeated eigenvalues of the identity still give independent vectors
+	_, vi := Eig(tensor.Eye(3, tensor.Float64))
+	if math.Abs(cmplx.Abs(complexDet3(vi))-1) > 1e-10 {
+		t.Error("expected orthonormal eigenvectors for the identity")
+	}
+}
+
+// trace returns the complex trace of a square matrix
+func trace(a *tensor.NDArray) complex128 {
+	var sum complex128
+	for i := 0; i < a.Shape()[0]; i++ {
+		sum += a.GetComplex128(i, i)
+	}
+	return sum
+}
+
+// complexDet3 returns the determinant of a complex 3x3 matrix
+func complexDet3(a *tensor.NDArray) complex128 {
+	g := a.GetComplex128
+	return g(0, 0)*(g(1, 1)*g(2, 2)-g(1, 2)*g(2, 1)) -
+		g(0, 1)*(g(1, 0)*g(2, 2)-g(1, 2)*g(2, 0)) +
+		g(0, 2)*(g(1, 0)*g(2, 1)-g(1, 1)*g(2, 0))
+}
+
+// complexClose reports whether two complex arrays agree elementwise
+func complexClose(a, b *tensor.NDArray, tol float64) bool {
+	x, y := a.ToSliceComplex128(), b.ToSliceComplex128()
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if cmplx.Abs(x[i]-y[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestIterativeSolvers(t *testing.T) {
+	// 1D Poisson matrix: symmetric positive definite
+	n := 50
+	poisson := tensor.Zeros([]int{n, n}, tensor.Float64)
+	for i := 0; i < n; i++ {
+		poisson.SetFloat64(2, i, i)
+		if i > 0 {
+			poisson.SetFloat64(-1, i, i-1)
+			poisson.SetFloat64(-1, i-1, i)
+		}
+	}
+	b := tensor.Ones([]int{n}, tensor.Float64)
+	direct := Solve(poisson, b)
+	
+	res := CG(DenseOperator(poisson), b, IterativeOptions{})
+	if !res.Converged || !res.X.AllClose(direct, 1e-6, 1e-6) {
+		t.Errorf("CG did not converge to the direct solution (residual %g)", res.Residual)
+	}
+	if res.Iterations > n {
+		t.Errorf("expected CG to converge within %d iterations, took %d", n, res.Iterations)
+	}
+	
+	pre := CG(DenseOperator(poisson), b, IterativeOptions{M: JacobiPreconditioner(poisson)})
+	if !pre.Converged || !pre.X.AllClose(direct, 1e-6, 1e-6) {
+		t.Errorf("preconditioned CG did not converge (residual %g)", pre.Residual)
+	}
+	
+	// Nonsymmetric, diagonally dominant system
+	a := tensor.Zeros([]int{n, n}, tensor.Float64)
+	for i := 0; i < n; i++ {
+		a.SetFloat64(4, i, i)
+		if i > 0 {
+			a.SetFloat64(-1.5, i, i-1)
+		}
+		if i < n-1 {
+			a.SetFloat64(0.5, i, i+1)
+		}
+	}
+	expected := Solve(a, b)
+	
+	for name, solve := range map[string]func(LinearOperator, *tensor.NDArray, IterativeOptions) IterativeResult{
+		"BiCGSTAB": BiCGSTAB,
+		"GMRES":    GMRES,
+	} {
+		r := solve(DenseOperator(a), b, IterativeOptions{})
+		if !r.Converged || !r.X.AllClose(expected, 1e-6, 1e-6) {
+			t.Errorf("%s did not converge (residual %g)", name, r.Residual)
+		}
+		r = solve(DenseOperator(a), b, IterativeOptions{M: JacobiPreconditioner(a), Restart: 5})
+		if !r.Converged || !r.X.AllClose(expected, 1e-6, 1e-6) {
+			t.Errorf("preconditioned %s did not converge (residual %g)", name, r.Residual)
+		}
+	}
+	
+	// Matrix-free operator
+	diag := FuncOperator(3, 3, func(dst, x []float64) {
+		for i := range dst {
+			dst[i] = float64(i+1) * x[i]
+		}
+	})
+	fx := GMRES(diag, tensor.FromSliceFloat64([]float64{1, 4, 9}, 3), IterativeOptions{})
+	if !fx.X.AllClose(tensor.FromSliceFloat64([]float64{1, 2, 3}, 3), 1e-9, 1e-9) {
+		t.Errorf("expected [1 2 3], got %v", fx.X.ToSliceFloat64())
+	}
+	
+	// Non-convergence is reported, not panicked
+	capped := CG(DenseOperator(poisson), b, IterativeOptions{MaxIter: 2})
+	if capped.Converged || capped.Iterations != 2 {
+		t.Errorf("expected unconverged result after 2 iterations, got %+v", capped.Iterations)
+	}
+}
+
+func TestEigsh(t *testing.T) {
+	// Diagonal operator with eigenvalues 1..n
+	n := 200
+	op := FuncOperator(n, n, func(dst, x []float64) {
+		for i := range dst {
+			dst[i] = float64(i+1) * x[i]
+		}
+	})
+	
+	w, v := Eigsh(op, 3, LargestMagnitude)
+	for j, expected := range []float64{200, 199, 198} {
+		if math.Abs(w.GetFloat64(j)-expected) > 1e-8 {
+			t.Errorf("expected eigenvalue %f, got %f", expected, w.GetFloat64(j))
+		}
+		if math.Abs(math.Abs(v.GetFloat64(int(expected)-1, j))-1) > 1e-6 {
+			t.Errorf("expected eigenvector e_%d for eigenvalue %f", int(expected)-1, expected)
+		}
+	}
+	
+	w, _ = Eigsh(op, 2, SmallestReal)
+	if math.Abs(w.GetFloat64(0)-1) > 1e-8 || math.Abs(w.GetFloat64(1)-2) > 1e-8 {
+		t.Errorf("expected [1 2], got %v", w.ToSliceFloat64())
+	}
+	
+	// Small operators are solved densely
+	a := tensor.FromSliceFloat64([]float64{2, 1, 1, 2}, 2, 2)
+	ws, _ := Eigsh(DenseOperator(a), 1, LargestReal)
+	if math.Abs(ws.GetFloat64(0)-3) > 1e-12 {
+		t.Errorf("expected 3, got %f", ws.GetFloat64(0))
+	}
+}
+
+func TestEigs(t *testing.T) {
+	// Upper bidiagonal (nonsymmetric) matrix with eigenvalues 1..n
+	n := 100
+	a := tensor.Zeros([]int{n, n}, tensor.Float64)
+	for i := 0; i < n; i++ {
+		a.SetFloat64(float64(i+1), i, i)
+		if i < n-1 {
+			a.SetFloat64(0.5, i, i+1)
+		}
+	}
+	op := DenseOperator(a)
+	
+	w, v := Eigs(op, 4, LargestMagnitude)
+	for j, expected := range []float64{100, 99, 98, 97} {
+		if cmplx.Abs(w.GetComplex128(j)-complex(expected, 0)) > 1e-6 {
+			t.Errorf("expected eigenvalue %f, got %v", expected, w.GetComplex128(j))
+		}
+	}
+	
+	// Check A v = lambda v
+	ac := tensor.FromSliceComplex128(a.ToSliceComplex128(), n, n)
+	av := MatMul(ac, v)
+	for j := 0; j < 4; j++ {
+		for i := 0; i < n; i++ {
+			if cmplx.Abs(av.GetComplex128(i, j)-w.GetComplex128(j)*v.GetComplex128(i, j)) > 1e-6 {
+				t.Errorf("A v != lambda v for eigenpair %d", j)
+				break
+			}
+		}
+	}
+	
+	// Rotation blocks give complex conjugate pairs
+	rot := tensor.Zeros([]int{60, 60}, tensor.Float64)
+	for b := 0; b < 30; b++ {
+		r := float64(b + 1)
+		rot.SetFloat64(r, 2*b, 2*b)
+		rot.SetFloat64(-r, 2*b, 2*b+1)
+		rot.SetFloat64(r, 2*b+1, 2*b)
+		rot.SetFloat64(r, 2*b+1, 2*b+1)
+	}
+	wc, _ := Eigs(DenseOperator(rot), 2, LargestMagnitude)
+	for j := 0; j < 2; j++ {
+		z := wc.GetComplex128(j)
+		if math.Abs(real(z)-30) > 1e-6 || math.Abs(math.Abs(imag(z))-30) > 1e-6 {
+			t.Errorf("expected 30 +- 30i, got %v", z)
+		}
+	}
+}
+
+func TestRCond(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{4, 1, 2, 0, 3, 1, 1, 2, 5}, 3, 3)
+	expected := 1 / Cond(a, NormOne)
+	if rc := RCond(a); math.Abs(rc-expected) > 1e-12 {
+		t.Errorf("expected rcond %g, got %g", expected, rc)
+	}
+	if rc := RCond(tensor.Eye(4, tensor.Float64)); rc != 1 {
+		t.Errorf("expected rcond 1 for the identity, got %g", rc)
+	}
+	if rc := RCond(tensor.FromSliceFloat64([]float64{1, 2, 2, 4}, 2, 2)); rc != 0 {
+		t.Errorf("expected rcond 0 for a singular matrix, got %g", rc)
+	}
+}
+
+func TestInvSolveChecked(t *testing.T) {
+	// A permutation matrix needs pivoting, which Inv's Gauss-Jordan lacks
+	perm := tensor.FromSliceFloat64([]float64{0, 1, 1, 0}, 2, 2)
+	inv, rcond, err := InvChecked(perm, SingularOptions{})
+	if err != nil || rcond != 1 || !inv.AllClose(perm, 0, 0) {
+		t.Errorf("expected exact inverse with rcond 1, got %v, %g, %v", inv, rcond, err)
+	}
+	
+	singular := tensor.FromSliceFloat64([]float64{1, 2, 2, 4}, 2, 2)
+	_, rcond, err = InvChecked(singular, SingularOptions{})
+	var serr *SingularMatrixError
+	if !errors.Is(err, ErrSingular) || !errors.As(err, &serr) || serr.RCond != rcond {
+		t.Errorf("expected SingularMatrixError, got %v", err)
+	}
+	
+	fallback, _, err := InvChecked(singular, SingularOptions{Fallback: true})
+	if err != nil || !fallback.AllClose(Pinv(singular, 0), 1e-12, 1e-12) {
+		t.Errorf("expected pseudo-inverse fallback, got %v", err)
+	}
+	
+	// Hilbert matrices are notoriously ill-conditioned
+	n := 12
+	hilbert := tensor.Zeros([]int{n, n}, tensor.Float64)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			hilbert.SetFloat64(1/float64(i+j+1), i, j)
+		}
+	}
+	b := tensor.Ones([]int{n}, tensor.Float64)
+	if _, _, err := SolveChecked(hilbert, b, SingularOptions{}); !errors.Is(err, ErrSingular) {
+		t.Errorf("expected ill-conditioned Hilbert matrix to be rejected, got %v", err)
+	}
+	x, rcond, err := SolveChecked(hilbert, b, SingularOptions{Fallback: true})
+	if err != nil || x.Size() != n || rcond >= epsilon {
+		t.Errorf("expected fallback solution with tiny rcond, got rcond %g, err %v", rcond, err)
+	}
+	
+	// A looser threshold accepts it
+	if _, _, err := SolveChecked(hilbert, b, SingularOptions{RCond: 1e-20}); err != nil {
+		t.Errorf("expected solve to succeed with rcond threshold 1e-20, got %v", err)
+	}
+	
+	good := tensor.FromSliceFloat64([]float64{3, 1, 1, 2}, 2, 2)
+	sol, _, err := SolveChecked(good, tensor.FromSliceFloat64([]float64{9, 8}, 2), SingularOptions{})
+	if err != nil || !sol.AllClose(tensor.FromSliceFloat64([]float64{2, 3}, 2), 1e-12, 1e-12) {
+		t.Errorf("expected [2 3], got %v (%v)", sol, err)
+	}
+}
+
+func TestVDot(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3, 4}, 2, 2)
+	b := tensor.FromSliceFloat64([]float64{5, 6, 7, 8}, 4)
+	if r := VDot(a, b); r.GetFloat64(0) != 70 {
+		t.Errorf("expected 70, got %f", r.GetFloat64(0))
+	}
+	
+	// The first argument is conjugated
+	c := tensor.FromSliceComplex128([]complex128{1 + 2i, 3 - 1i}, 2)
+	d := tensor.FromSliceComplex128([]complex128{2i, 1}, 2)
+	expected := (1-2i)*2i + (3+1i)*1
+	if r := VDot(c, d); r.DType() != tensor.Complex128 || r.GetComplex128(0) != expected {
+		t.Errorf("expected %v, got %v", expected, r.GetComplex128(0))
+	}
+	if r := VDot(c, c); r.GetComplex128(0) != 15 {
+		t.Errorf("expected squared norm 15, got %v", r.GetComplex128(0))
+	}
+}
+
+func TestInnerAxes(t *testing.T) {
+	a := tensor.Arange(0, 24, 1).Reshape(2, 3, 4)
+	b := tensor.Arange(0, 8, 1).Reshape(2, 4)
+	
+	// axes = 1 matches np.inner: shape (2, 3, 2)
+	r := InnerAxes(a, b, 1)
+	if shape := r.Shape(); len(shape) != 3 || shape[0] != 2 || shape[1] != 3 || shape[2] != 2 {
+		t.Fatalf("expected shape [2 3 2], got %v", shape)
+	}
+	sum := 0.0
+	for k := 0; k < 4; k++ {
+		sum += a.GetFloat64(1, 2, k) * b.GetFloat64(1, k)
+	}
+	if r.GetFloat64(1, 2, 1) != sum {
+		t.Errorf("expected %f, got %f", sum, r.GetFloat64(1, 2, 1))
+	}
+	
+	// Contracting two axes
+	c := tensor.Arange(0, 12, 1).Reshape(3, 4)
+	r2 := InnerAxes(a, c, 2)
+	if shape := r2.Shape(); len(shape) != 1 || shape[0] != 2 {
+		t.Fatalf("expected shape [2], got %v", shape)
+	}
+	for i := 0; i < 2; i++ {
+		expected := 0.0
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 4; k++ {
+				expected += a.GetFloat64(i, j, k) * c.GetFloat64(j, k)
+			}
+		}
+		if r2.GetFloat64(i) != expected {
+			t.Errorf("expected %f at %d, got %f", expected, i, r2.GetFloat64(i))
+		}
+	}
+	
+	// axes = 0 is the outer product
+	u := tensor.FromSliceFloat64([]float64{1, 2}, 2)
+	v := tensor.FromSliceFloat64([]float64{3, 4, 5}, 3)
+	if !InnerAxes(u, v, 0).AllClose(Outer(u, v), 0, 0) {
+		t.Error("expected axes=0 to give the outer product")
+	}
+	
+	// 1D vectors agree with Inner
+	if InnerAxes(v, v, 1).GetFloat64(0) != Inner(v, v) {
+		t.Error("expected InnerAxes to agree with Inner for vectors")
+	}
+}
+
+func TestOrthNullSpace(t *testing.T) {
+	// Rank 2: the third column is the sum of the first two
+	a := tensor.FromSliceFloat64([]float64{
+		1, 0, 1,
+		0, 1, 1,
+		1, 1, 2,
+		2, 1, 3,
+	}, 4, 3)
+	
+	q := Orth(a)
+	if shape := q.Shape(); shape[0] != 4 || shape[1] != 2 {
+		t.Fatalf("expected shape [4 2], got %v", shape)
+	}
+	if !MatMul(Transpose(q), q).AllClose(tensor.Eye(2, tensor.Float64), 1e-12, 1e-12) {
+		t.Error("expected orthonormal columns")
+	}
+	// Projecting A onto range(Q) leaves it unchanged
+	if !MultiDot(q, Transpose(q), a).AllClose(a, 1e-12, 1e-12) {
+		t.Error("expected Q Q^T A = A")
+	}
+	
+	ns := NullSpace(a, 0)
+	if shape := ns.Shape(); shape[0] != 3 || shape[1] != 1 {
+		t.Fatalf("expected shape [3 1], got %v", shape)
+	}
+	if !MatMul(a, ns).AllClose(tensor.Zeros([]int{4, 1}, tensor.Float64), 0, 1e-12) {
+		t.Errorf("expected A N = 0, got %v", MatMul(a, ns).ToSliceFloat64())
+	}
+	if math.Abs(Norm(ns)-1) > 1e-12 {
+		t.Errorf("expected unit null vector, got norm %f", Norm(ns))
+	}
+	
+	// Full rank square matrix has an empty null space
+	if ns := NullSpace(tensor.Eye(3, tensor.Float64), 0); ns.Shape()[1] != 0 {
+		t.Errorf("expected empty null space, got shape %v", ns.Shape())
+	}
+	
+	// Wide matrix: null space of dimension n - rank
+	wide := tensor.FromSliceFloat64([]float64{1, 2, 3, 4}, 1, 4)
+	if ns := NullSpace(wide, 0); ns.Shape()[1] != 3 || !MatMul(wide, ns).AllClose(tensor.Zeros([]int{1, 3}, tensor.Float64), 0, 1e-12) {
+		t.Errorf("expected 3-dimensional null space, got shape %v", ns.Shape())
+	}
+	
+	// Complex input
+	c := tensor.FromSliceComplex128([]complex128{1, 1i, 1i, -1}, 2, 2)
+	cn := NullSpace(c, 0)
+	if cn.Shape()[1] != 1 || !complexClose(MatMul(c, cn), tensor.FromSliceComplex128([]complex128{0, 0}, 2, 1), 1e-12) {
+		t.Errorf("expected complex null vector, got %v", cn.ToSliceComplex128())
+	}
+}
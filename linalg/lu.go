@@ -0,0 +1,175 @@
+package linalg
+
+import (
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// LUFactorization holds a compact LU factorization P A = L U of a square
+// matrix computed with partial pivoting. It can be reused to solve many
+// systems with the same matrix or to compute the determinant.
+type LUFactorization struct {
+	lu       []float64 // L below the diagonal (unit diagonal implied), U on and above
+	piv      []int     // Row i of P A is row piv[i] of A
+	sign     float64   // Sign of the permutation
+	n        int
+	singular bool
+	anorm    float64 // 1-norm of A, for condition estimates
+}
+
+// LUFactor computes the LU factorization of a square matrix with partial
+// pivoting. Singular matrices are factored too; check IsSingular before
+// calling Solve.
+func LUFactor(a *tensor.NDArray) *LUFactorization {
+	data, n := toSquareDense(a, "LU")
+	anorm := denseNorm1(data, n)
+	piv, sign, ok := luFactor(data, n)
+	return &LUFactorization{
+		lu:       data,
+		piv:      piv,
+		sign:     sign,
+		n:        n,
+		singular: !ok,
+		anorm:    anorm,
+	}
+}
+
+// LU computes the LU decomposition of a square matrix with partial
+// pivoting, returning P, L and U such that A = P L U, with L unit lower
+// triangular and U upper triangular (same convention as scipy.linalg.lu)
+func LU(a *tensor.NDArray) (p, l, u *tensor.NDArray) {
+	f := LUFactor(a)
+	return f.P(), f.L(), f.U()
+}
+
+// IsSingular reports whether a zero pivot was encountered
+func (f *LUFactorization) IsSingular() bool {
+	return f.singular
+}
+
+// P returns the permutation matrix P such that A = P L U
+func (f *LUFactorization) P() *tensor.NDArray {
+	p := tensor.Zeros([]int{f.n, f.n}, tensor.Float64)
+	for i, row := range f.piv {
+		p.SetFloat64(1, row, i)
+	}
+	return p
+}
+
+// L returns the unit lower triangular factor
+func (f *LUFactorization) L() *tensor.NDArray {
+	n := f.n
+	data := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		copy(data[i*n:i*n+i], f.lu[i*n:i*n+i])
+		data[i*n+i] = 1
+	}
+	return fromDense(data, n, n)
+}
+
+// U returns the upper triangular factor
+func (f *LUFactorization) U() *tensor.NDArray {
+	n := f.n
+	data := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		copy(data[i*n+i:(i+1)*n], f.lu[i*n+i:(i+1)*n])
+	}
+	return fromDense(data, n, n)
+}
+
+// Det returns the determinant of the factored matrix
+func (f *LUFactorization) Det() float64 {
+	if f.singular {
+		return 0
+	}
+	det := f.sign
+	for i := 0; i < f.n; i++ {
+		det *= f.lu[i*f.n+i]
+	}
+	return det
+}
+
+// Solve solves A x = b using the factorization. b may be a vector of shape
+// (n) or a matrix of shape (n, k); the result has the same shape as b.
+func (f *LUFactorization) Solve(b *tensor.NDArray) *tensor.NDArray {
+	if f.singular {
+		panic("matrix is singular")
+	}
+	
+	rhs, k := rhsToDense(b, f.n)
+	luSolve(f.lu, f.piv, f.n, rhs, k)
+	
+	if b.Ndim() == 1 {
+		return tensor.FromSliceFloat64(rhs, f.n)
+	}
+	return fromDense(rhs, f.n, k)
+}
+
+// luFactor computes the LU factorization of the row-major n x n matrix lu
+// in place using partial pivoting, so that P A = L U with L unit lower
+// triangular (stored below the diagonal) and U upper triangular. It returns
+// the row permutation, the permutation sign, and false if A is singular.
+func luFactor(lu []float64, n int) ([]int, float64, bool) {
+	piv := make([]int, n)
+	for i := range piv {
+		piv[i] = i
+	}
+	sign := 1.0
+	ok := true
+	
+	for k := 0; k < n; k++ {
+		// Find the largest pivot in column k
+		p := k
+		maxVal := math.Abs(lu[k*n+k])
+		for i := k + 1; i < n; i++ {
+			if v := math.Abs(lu[i*n+k]); v > maxVal {
+				maxVal = v
+				p = i
+			}
+		}
+		
+		if p != k {
+			for j := 0; j < n; j++ {
+				lu[k*n+j], lu[p*n+j] = lu[p*n+j], lu[k*n+j]
+			}
+			piv[k], piv[p] = piv[p], piv[k]
+			sign = -sign
+		}
+		
+		pivot := lu[k*n+k]
+		if pivot == 0 {
+			ok = false
+			continue
+		}
+		
+		for i := k + 1; i < n; i++ {
+			factor := lu[i*n+k] / pivot
+			lu[i*n+k] = factor
+			if factor == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				lu[i*n+j] -= factor * lu[k*n+j]
+			}
+		}
+	}
+	
+	return piv, sign, ok
+}
+
+// luSolve solves A X = B in place given the factorization from luFactor,
+// where B is a row-major n x k matrix
+func luSolve(lu []float64, piv []int, n int, b []float64, k int) {
+	// Apply the row permutation
+	permuted := make([]float64, n*k)
+	for i := 0; i < n; i++ {
+		copy(permuted[i*k:(i+1)*k], b[piv[i]*k:(piv[i]+1)*k])
+	}
+	copy(b, permuted)
+	
+	// Forward substitution with unit lower triangular L, then back
+	// substitution with upper triangular U
+	triSolve(lu, n, b, k, true, true, false)
+	triSolve(lu, n, b, k, false, false, false)
+}
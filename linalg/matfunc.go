@@ -0,0 +1,357 @@
+package linalg
+
+import (
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// expmPadeOrder is the degree of the diagonal Padé approximant used by Expm
+const expmPadeOrder = 6
+
+// matfuncMaxIter bounds the square root iterations used by Sqrtm and Logm
+const matfuncMaxIter = 100
+
+// Expm computes the matrix exponential e^A using scaling and squaring with
+// a diagonal Padé approximant: A is scaled by 2^-s so that its norm is
+// below 1/2, the approximant is evaluated, and the result is squared s
+// times.
+func Expm(a *tensor.NDArray) *tensor.NDArray {
+	data, n := toSquareDense(a, "Expm")
+	if n == 0 {
+		return fromDense(data, 0, 0)
+	}
+	
+	// Scale so that ||A / 2^s||_inf <= 1/2
+	s := 0
+	if norm := denseNormInf(data, n); norm > 0 {
+		s = max(0, int(math.Floor(math.Log2(norm)))+2)
+	}
+	scale := math.Ldexp(1, -s)
+	for i := range data {
+		data[i] *= scale
+	}
+	
+	// Padé approximant N(A) / D(A), Golub & Van Loan algorithm 9.3.1
+	num := denseIdentity(n)
+	den := denseIdentity(n)
+	x := denseIdentity(n)
+	c := 1.0
+	q := expmPadeOrder
+	for k := 1; k <= q; k++ {
+		c *= float64(q-k+1) / float64(k*(2*q-k+1))
+		x = denseMul(data, x, n)
+		sign := 1.0
+		if k%2 == 1 {
+			sign = -1
+		}
+		for i := range x {
+			num[i] += c * x[i]
+			den[i] += sign * c * x[i]
+		}
+	}
+	
+	result, ok := denseSolve(den, num, n)
+	if !ok {
+		panic("Expm: Padé denominator is singular")
+	}
+	for ; s > 0; s-- {
+		result = denseMul(result, result, n)
+	}
+	
+	return fromDense(result, n, n)
+}
+
+// Sqrtm computes the principal square root X of a square matrix, so that
+// X X = A. Symmetric matrices are handled through their eigendecomposition
+// and must be positive semidefinite; other matrices use the scaled
+// Denman-Beavers iteration and must be nonsingular with no eigenvalues on
+// the negative real axis. It panics if no real principal root exists.
+func Sqrtm(a *tensor.NDArray) *tensor.NDArray {
+	data, n := toSquareDense(a, "Sqrtm")
+	
+	if isSymmetric(data, n) {
+		return fromDense(symmetricFunc(data, n, "Sqrtm", false, math.Sqrt), n, n)
+	}
+	
+	root, ok := denmanBeavers(data, n)
+	if !ok {
+		panic("Sqrtm: matrix has no real principal square root")
+	}
+	return fromDense(root, n, n)
+}
+
+// Logm computes the principal matrix logarithm X of a square matrix, so that
+// Expm(X) = A. Symmetric matrices are handled through their
+// eigendecomposition and must be positive definite; other matrices use
+// inverse scaling and squaring (repeated square roots followed by a Padé
+// approximant of log(I + X)) and must have no eigenvalues on the closed
+// negative real axis. It panics if no real principal logarithm exists.
+func Logm(a *tensor.NDArray) *tensor.NDArray {
+	data, n := toSquareDense(a, "Logm")
+	
+	if isSymmetric(data, n) {
+		return fromDense(symmetricFunc(data, n, "Logm", true, math.Log), n, n)
+	}
+	
+	// Take square roots until A is close enough to I for the approximant
+	k := 0
+	for {
+		diff := make([]float64, n*n)
+		copy(diff, data)
+		for i := 0; i < n; i++ {
+			diff[i*n+i] -= 1
+		}
+		if denseNormInf(diff, n) <= 0.25 {
+			data = diff
+			break
+		}
+		if k == matfuncMaxIter {
+			panic("Logm: failed to converge")
+		}
+		
+		root, ok := denmanBeavers(data, n)
+		if !ok {
+			panic("Logm: matrix has no real principal logarithm")
+		}
+		data = root
+		k++
+	}
+	
+	// log(I + X) = sum_j w_j X (I + t_j X)^-1, the Padé approximant given by
+	// Gauss-Legendre quadrature of the integral of X (I + t X)^-1 over [0, 1]
+	nodes, weights := gaussLegendre(8)
+	result := make([]float64, n*n)
+	for j, t := range nodes {
+		m := denseIdentity(n)
+		for i := range m {
+			m[i] += t * data[i]
+		}
+		term, ok := denseSolve(m, data, n)
+		if !ok {
+			panic("Logm: failed to converge")
+		}
+		for i := range result {
+			result[i] += weights[j] * term[i]
+		}
+	}
+	
+	scale := math.Ldexp(1, k)
+	for i := range result {
+		result[i] *= scale
+	}
+	return fromDense(result, n, n)
+}
+
+// denmanBeavers computes the principal square root of a using the
+// determinant-scaled Denman-Beavers iteration. It reports false if a is
+// singular or the iteration does not converge.
+func denmanBeavers(a []float64, n int) ([]float64, bool) {
+	y := make([]float64, n*n)
+	copy(y, a)
+	z := denseIdentity(n)
+	tol := math.Sqrt(float64(n)) * epsilon * 10
+	
+	for iter := 0; iter < matfuncMaxIter; iter++ {
+		yInv, okY := denseInv(y, n)
+		zInv, okZ := denseInv(z, n)
+		if !okY || !okZ {
+			return nil, false
+		}
+		
+		// Determinant scaling accelerates the early iterations
+		mu := 1.0
+		if iter < 10 {
+			detY := denseDet(y, n)
+			detZ := denseDet(z, n)
+			if p := math.Abs(detY * detZ); p > 0 && !math.IsInf(p, 0) {
+				mu = math.Pow(p, -1/(2*float64(n)))
+			}
+		}
+		
+		next := make([]float64, n*n)
+		for i := range y {
+			next[i] = 0.5 * (mu*y[i] + zInv[i]/mu)
+			z[i] = 0.5 * (mu*z[i] + yInv[i]/mu)
+		}
+		
+		diff := make([]float64, n*n)
+		for i := range next {
+			diff[i] = next[i] - y[i]
+		}
+		y = next
+		if denseNormInf(diff, n) <= tol*denseNormInf(y, n) {
+			return y, !hasNonFinite(y)
+		}
+	}
+	
+	return nil, false
+}
+
+// symmetricFunc evaluates f on a symmetric matrix through its
+// eigendecomposition V diag(f(w)) V^T. Eigenvalues that are negative (or
+// zero when strict is set) beyond rounding error cause a panic naming the
+// calling function; small negative ones are clamped to zero.
+func symmetricFunc(data []float64, n int, name string, strict bool, f func(float64) float64) []float64 {
+	vecs := symmetricFromLower(data, n)
+	d, e := tridiagonalize(vecs, n, true)
+	tridiagonalQL(d, e, vecs, n)
+	
+	largest := 0.0
+	for _, w := range d {
+		largest = max(largest, math.Abs(w))
+	}
+	tol := float64(n) * epsilon * largest
+	
+	fw := make([]float64, n)
+	for j, w := range d {
+		switch {
+		case strict && w <= 0:
+			panic(name + " requires a positive definite matrix")
+		case w < -tol:
+			panic(name + " requires a positive semidefinite matrix")
+		case w < 0:
+			w = 0
+		}
+		fw[j] = f(w)
+	}
+	
+	result := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			sum := 0.0
+			for k := 0; k < n; k++ {
+				sum += vecs[i][k] * fw[k] * vecs[j][k]
+			}
+			result[i*n+j] = sum
+		}
+	}
+	return result
+}
+
+// gaussLegendre returns the m-point Gauss-Legendre nodes and weights on
+// [0, 1], computed by Newton iteration on the Legendre polynomial P_m
+func gaussLegendre(m int) ([]float64, []float64) {
+	nodes := make([]float64, m)
+	weights := make([]float64, m)
+	
+	for i := 0; i < (m+1)/2; i++ {
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(m) + 0.5))
+		var dp float64
+		for iter := 0; iter < 100; iter++ {
+			p0, p1 := 1.0, x
+			for k := 2; k <= m; k++ {
+				p0, p1 = p1, ((2*float64(k)-1)*x*p1-(float64(k)-1)*p0)/float64(k)
+			}
+			dp = float64(m) * (x*p1 - p0) / (x*x - 1)
+			dx := p1 / dp
+			x -= dx
+			if math.Abs(dx) < 1e-15 {
+				break
+			}
+		}
+		w := 2 / ((1 - x*x) * dp * dp)
+		
+		// Map from [-1, 1] to [0, 1]
+		nodes[i], weights[i] = (1-x)/2, w/2
+		nodes[m-1-i], weights[m-1-i] = (1+x)/2, w/2
+	}
+	
+	return nodes, weights
+}
+
+// isSymmetric reports whether a row-major n x n matrix is symmetric up to
+// rounding error
+func isSymmetric(data []float64, n int) bool {
+	tol := 10 * epsilon * denseNormInf(data, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if math.Abs(data[i*n+j]-data[j*n+i]) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// denseIdentity returns the row-major n x n identity matrix
+func denseIdentity(n int) []float64 {
+	id := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		id[i*n+i] = 1
+	}
+	return id
+}
+
+// denseMul multiplies two row-major n x n matrices
+func denseMul(a, b []float64, n int) []float64 {
+	result := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for k := 0; k < n; k++ {
+			aik := a[i*n+k]
+			if aik == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				result[i*n+j] += aik * b[k*n+j]
+			}
+		}
+	}
+	return result
+}
+
+// denseNormInf returns the infinity norm (maximum absolute row sum) of a
+// row-major n x n matrix
+func denseNormInf(a []float64, n int) float64 {
+	norm := 0.0
+	for i := 0; i < n; i++ {
+		sum := 0.0
+		for j := 0; j < n; j++ {
+			sum += math.Abs(a[i*n+j])
+		}
+		norm = max(norm, sum)
+	}
+	return norm
+}
+
+// denseSolve solves A X = B for row-major n x n matrices without modifying
+// its arguments, reporting false if A is singular
+func denseSolve(a, b []float64, n int) ([]float64, bool) {
+	lu := make([]float64, n*n)
+	copy(lu, a)
+	piv, _, ok := luFactor(lu, n)
+	if !ok {
+		return nil, false
+	}
+	x := make([]float64, n*n)
+	copy(x, b)
+	luSolve(lu, piv, n, x, n)
+	return x, true
+}
+
+// denseInv inverts a row-major n x n matrix, reporting false if it is
+// singular
+func denseInv(a []float64, n int) ([]float64, bool) {
+	return denseSolve(a, denseIdentity(n), n)
+}
+
+// denseDet returns the determinant of a row-major n x n matrix
+func denseDet(a []float64, n int) float64 {
+	lu := make([]float64, n*n)
+	copy(lu, a)
+	_, det, _ := luFactor(lu, n)
+	for i := 0; i < n; i++ {
+		det *= lu[i*n+i]
+	}
+	return det
+}
+
+// hasNonFinite reports whether any element is NaN or infinite
+func hasNonFinite(data []float64) bool {
+	for _, v := range data {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package linalg
+
+import (
+	"fmt"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// MultiDot computes the product of two or more arrays, choosing the
+// multiplication order that minimizes the number of scalar multiplications
+// (the classic matrix-chain dynamic program). All arrays must be 2D except
+// that the first may be a 1D row vector and the last a 1D column vector,
+// like NumPy's multi_dot. The result is 2D, 1D or a 1-element array
+// depending on which ends are vectors.
+func MultiDot(arrays ...*tensor.NDArray) *tensor.NDArray {
+	if len(arrays) < 2 {
+		panic("MultiDot requires at least two arrays")
+	}
+	
+	first, last := arrays[0], arrays[len(arrays)-1]
+	mats := make([]*tensor.NDArray, len(arrays))
+	copy(mats, arrays)
+	if first.Ndim() == 1 {
+		mats[0] = first.Reshape(1, first.Size())
+	}
+	if last.Ndim() == 1 {
+		mats[len(mats)-1] = last.Reshape(last.Size(), 1)
+	}
+	
+	// dims[i], dims[i+1] is the shape of mats[i]
+	dims := make([]int, len(mats)+1)
+	for i, m := range mats {
+		if m.Ndim() != 2 {
+			panic(fmt.Sprintf("MultiDot: array %d must be 2D, got %dD", i, m.Ndim()))
+		}
+		shape := m.Shape()
+		if i > 0 && shape[0] != dims[i] {
+			panic(fmt.Sprintf("MultiDot: dimension mismatch between arrays %d and %d: %d vs %d", i-1, i, dims[i], shape[0]))
+		}
+		dims[i] = shape[0]
+		dims[i+1] = shape[1]
+	}
+	
+	split := matrixChainOrder(dims)
+	result := multiplyChain(mats, split, 0, len(mats)-1)
+	
+	switch {
+	case first.Ndim() == 1 && last.Ndim() == 1:
+		return result.Reshape(1)
+	case first.Ndim() == 1:
+		return result.Reshape(result.Shape()[1])
+	case last.Ndim() == 1:
+		return result.Reshape(result.Shape()[0])
+	default:
+		return result
+	}
+}
+
+// matrixChainOrder returns split[i][j], the index k at which the product of
+// matrices i..j should be split as (i..k)(k+1..j) to minimize cost
+func matrixChainOrder(dims []int) [][]int {
+	n := len(dims) - 1
+	cost := make([][]int, n)
+	split := make([][]int, n)
+	for i := range cost {
+		cost[i] = make([]int, n)
+		split[i] = make([]int, n)
+	}
+	
+	for length := 1; length < n; length++ {
+		for i := 0; i+length < n; i++ {
+			j := i + length
+			cost[i][j] = -1
+			for k := i; k < j; k++ {
+				c := cost[i][k] + cost[k+1][j] + dims[i]*dims[k+1]*dims[j+1]
+				if cost[i][j] < 0 || c < cost[i][j] {
+					cost[i][j] = c
+					split[i][j] = k
+				}
+			}
+		}
+	}
+	
+	return split
+}
+
+// multiplyChain multiplies mats[i..j] in the order given by split
+func multiplyChain(mats []*tensor.NDArray, split [][]int, i, j int) *tensor.NDArray {
+	if i == j {
+		return mats[i]
+	}
+	k := split[i][j]
+	return MatMul(multiplyChain(mats, split, i, k), multiplyChain(mats, split, k+1, j))
+}
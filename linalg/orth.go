@@ -0,0 +1,66 @@
+package linalg
+
+import (
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Orth returns an orthonormal basis for the range (column space) of a as
+// the columns of an m x r array, where r is the numerical rank. Singular
+// values at or below max(m, n) * eps * s_max are treated as zero.
+func Orth(a *tensor.NDArray) *tensor.NDArray {
+	u, s, _ := SVD(a, false)
+	shape := a.Shape()
+	rank := numericalRank(s, max(shape[0], shape[1]), 0)
+	return selectColumns(u, 0, rank)
+}
+
+// NullSpace returns an orthonormal basis for the null space (kernel) of a
+// as the columns of an n x (n - r) array, where r is the numerical rank:
+// a times the result is zero up to rounding. Singular values at or below
+// rcond * s_max are treated as zero; rcond <= 0 selects
+// max(m, n) * machine epsilon.
+func NullSpace(a *tensor.NDArray, rcond float64) *tensor.NDArray {
+	_, s, vt := SVD(a, true)
+	shape := a.Shape()
+	rank := numericalRank(s, max(shape[0], shape[1]), rcond)
+	
+	// The trailing rows of Vt span the null space; return them as columns
+	// (conjugated for complex input, since Vt = V^H)
+	v := vt.Transpose()
+	if a.DType().IsComplex() {
+		v = v.Conj()
+	}
+	return selectColumns(v, rank, shape[1])
+}
+
+// numericalRank counts the singular values (sorted descending) above
+// rcond * s_max, using max(m, n) * eps when rcond <= 0
+func numericalRank(s *tensor.NDArray, dim int, rcond float64) int {
+	if s.Size() == 0 {
+		return 0
+	}
+	if rcond <= 0 {
+		rcond = float64(dim) * epsilon
+	}
+	tol := rcond * s.GetFloat64(0)
+	
+	rank := 0
+	for i := 0; i < s.Size(); i++ {
+		if s.GetFloat64(i) > tol {
+			rank++
+		}
+	}
+	return rank
+}
+
+// selectColumns returns columns [from, to) of a 2D array as a new array
+func selectColumns(a *tensor.NDArray, from, to int) *tensor.NDArray {
+	m := a.Shape()[0]
+	result := tensor.Zeros([]int{m, to - from}, a.DType())
+	for i := 0; i < m; i++ {
+		for j := from; j < to; j++ {
+			result.SetComplex128(a.GetComplex128(i, j), i, j-from)
+		}
+	}
+	return result
+}
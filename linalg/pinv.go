@@ -0,0 +1,49 @@
+package linalg
+
+import (
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Pinv computes the Moore-Penrose pseudo-inverse of an m x n matrix from its
+// SVD. Singular values at or below rcond times the largest singular value
+// are treated as zero. If rcond <= 0, the cutoff max(m, n) * machine
+// epsilon is used.
+//
+// Pinv(A) b gives the minimum-norm least-squares solution of A x = b, even
+// when A is rank deficient.
+func Pinv(a *tensor.NDArray, rcond float64) *tensor.NDArray {
+	u, s, vt := SVD(a, false)
+	
+	shape := a.Shape()
+	m, n := shape[0], shape[1]
+	k := s.Size()
+	
+	if rcond <= 0 {
+		rcond = float64(max(m, n)) * epsilon
+	}
+	cutoff := 0.0
+	if k > 0 {
+		cutoff = rcond * s.GetFloat64(0)
+	}
+	
+	// Pinv = V diag(1/s) U^T
+	result := tensor.Zeros([]int{n, m}, tensor.Float64)
+	for l := 0; l < k; l++ {
+		sv := s.GetFloat64(l)
+		if sv <= cutoff {
+			continue
+		}
+		inv := 1 / sv
+		for i := 0; i < n; i++ {
+			vil := vt.GetFloat64(l, i) * inv
+			if vil == 0 {
+				continue
+			}
+			for j := 0; j < m; j++ {
+				result.SetFloat64(result.GetFloat64(i, j)+vil*u.GetFloat64(j, l), i, j)
+			}
+		}
+	}
+	
+	return result
+}
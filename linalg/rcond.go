@@ -0,0 +1,190 @@
+package linalg
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// ErrSingular reports that a matrix is singular or too ill-conditioned to
+// invert reliably. Errors returned by InvChecked and SolveChecked match it
+// with errors.Is.
+var ErrSingular = errors.New("matrix is singular")
+
+// SingularMatrixError is returned by InvChecked and SolveChecked when the
+// estimated reciprocal condition number falls below the threshold
+type SingularMatrixError struct {
+	// RCond is the estimated reciprocal condition number in the 1-norm
+	RCond float64
+}
+
+// Error implements the error interface
+func (e *SingularMatrixError) Error() string {
+	return fmt.Sprintf("matrix is singular to working precision (rcond=%g)", e.RCond)
+}
+
+// Unwrap returns ErrSingular so that errors.Is(err, ErrSingular) holds
+func (e *SingularMatrixError) Unwrap() error {
+	return ErrSingular
+}
+
+// SingularOptions controls how InvChecked and SolveChecked treat
+// ill-conditioned matrices. The zero value reports an error once the
+// estimated reciprocal condition number drops below machine epsilon.
+type SingularOptions struct {
+	// RCond is the reciprocal condition number below which the matrix is
+	// treated as singular. Zero selects machine epsilon.
+	RCond float64
+	// Fallback computes the Moore-Penrose pseudo-inverse (minimum-norm
+	// least-squares) result instead of returning an error
+	Fallback bool
+}
+
+// RCond estimates the reciprocal condition number 1 / (||A||_1 ||A^-1||_1)
+// of a square matrix from its LU factorization, as LAPACK's gecon does.
+// Values near 1 indicate a well-conditioned matrix and values near machine
+// epsilon a numerically singular one; singular matrices give 0.
+func RCond(a *tensor.NDArray) float64 {
+	return LUFactor(a).RCond()
+}
+
+// RCond estimates the reciprocal condition number of the factored matrix
+// in the 1-norm using Hager's method, which needs only a few O(n^2) solves
+func (f *LUFactorization) RCond() float64 {
+	if f.singular || f.anorm == 0 {
+		return 0
+	}
+	if f.n == 0 {
+		return math.Inf(1)
+	}
+	
+	invNorm := f.invNorm1Estimate()
+	if invNorm == 0 || math.IsInf(invNorm, 0) || math.IsNaN(invNorm) {
+		return 0
+	}
+	return 1 / (f.anorm * invNorm)
+}
+
+// invNorm1Estimate estimates ||A^-1||_1 by Hager's algorithm: a gradient
+// ascent of ||A^-1 x||_1 over the unit 1-norm ball
+func (f *LUFactorization) invNorm1Estimate() float64 {
+	n := f.n
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+	
+	estimate := 0.0
+	for iter := 0; iter < 5; iter++ {
+		y := append([]float64{}, x...)
+		luSolve(f.lu, f.piv, n, y, 1)
+		norm := 0.0
+		for _, v := range y {
+			norm += math.Abs(v)
+		}
+		if iter > 0 && norm <= estimate {
+			break
+		}
+		estimate = norm
+		
+		z := make([]float64, n)
+		for i, v := range y {
+			z[i] = 1
+			if v < 0 {
+				z[i] = -1
+			}
+		}
+		f.solveTrans(z)
+		
+		j := 0
+		for i := range z {
+			if math.Abs(z[i]) > math.Abs(z[j]) {
+				j = i
+			}
+		}
+		if iter > 0 && math.Abs(z[j]) <= vecDot(z, x) {
+			break
+		}
+		for i := range x {
+			x[i] = 0
+		}
+		x[j] = 1
+	}
+	
+	return estimate
+}
+
+// solveTrans solves A^T x = b in place. With P A = L U this is
+// U^T L^T (P x) = b.
+func (f *LUFactorization) solveTrans(b []float64) {
+	n := f.n
+	triSolve(f.lu, n, b, 1, false, false, true)
+	triSolve(f.lu, n, b, 1, true, true, true)
+	
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[f.piv[i]] = b[i]
+	}
+	copy(b, x)
+}
+
+// InvChecked computes the inverse of a square matrix like Inv, but uses
+// LU decomposition with partial pivoting and returns the estimated
+// reciprocal condition number. If it is below opts.RCond the result is
+// either the pseudo-inverse (when opts.Fallback is set) or a
+// *SingularMatrixError, instead of a panic.
+func InvChecked(a *tensor.NDArray, opts SingularOptions) (*tensor.NDArray, float64, error) {
+	f := LUFactor(a)
+	rcond := f.RCond()
+	if rcond < singularThreshold(opts) {
+		if opts.Fallback {
+			return Pinv(a, 0), rcond, nil
+		}
+		return nil, rcond, &SingularMatrixError{RCond: rcond}
+	}
+	return f.Solve(tensor.Eye(f.n, tensor.Float64)), rcond, nil
+}
+
+// SolveChecked solves A x = b like Solve and returns the estimated
+// reciprocal condition number of A. If it is below opts.RCond the result
+// is either the minimum-norm least-squares solution Pinv(A) b (when
+// opts.Fallback is set) or a *SingularMatrixError, instead of a panic.
+func SolveChecked(a, b *tensor.NDArray, opts SingularOptions) (*tensor.NDArray, float64, error) {
+	f := LUFactor(a)
+	rcond := f.RCond()
+	if rcond < singularThreshold(opts) {
+		if !opts.Fallback {
+			return nil, rcond, &SingularMatrixError{RCond: rcond}
+		}
+		pinv := Pinv(a, 0)
+		if b.Ndim() == 1 {
+			return Dot(pinv, b), rcond, nil
+		}
+		return MatMul(pinv, b), rcond, nil
+	}
+	return f.Solve(b), rcond, nil
+}
+
+// singularThreshold returns the rcond cutoff selected by opts
+func singularThreshold(opts SingularOptions) float64 {
+	if opts.RCond > 0 {
+		return opts.RCond
+	}
+	return epsilon
+}
+
+// denseNorm1 returns the 1-norm (maximum absolute column sum) of a
+// row-major n x n matrix
+func denseNorm1(a []float64, n int) float64 {
+	norm := 0.0
+	for j := 0; j < n; j++ {
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			sum += math.Abs(a[i*n+j])
+		}
+		norm = math.Max(norm, sum)
+	}
+	return norm
+}
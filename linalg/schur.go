@@ -0,0 +1,48 @@
+package linalg
+
+import (
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Hessenberg computes the Hessenberg decomposition A = Q H Q^T of a square
+// matrix, where H is upper Hessenberg (zero below the first subdiagonal)
+// and Q is orthogonal. The reduction uses Householder reflections.
+func Hessenberg(a *tensor.NDArray) (h, q *tensor.NDArray) {
+	data, n := toSquareDense(a, "Hessenberg")
+	qData := make([]float64, n*n)
+	
+	hessenbergReduce(denseRows(data, n, n), denseRows(qData, n, n), n)
+	
+	return fromDense(data, n, n), fromDense(qData, n, n)
+}
+
+// Schur computes the real Schur decomposition A = Z T Z^T of a square
+// matrix, where Z is orthogonal and T is upper quasi-triangular: real
+// eigenvalues appear on the diagonal of T and each complex conjugate pair
+// as a 2x2 block on the diagonal. It panics if the QR iteration does not
+// converge.
+func Schur(a *tensor.NDArray) (t, z *tensor.NDArray) {
+	data, n := toSquareDense(a, "Schur")
+	zData := make([]float64, n*n)
+	rows := denseRows(data, n, n)
+	zRows := denseRows(zData, n, n)
+	
+	hessenbergReduce(rows, zRows, n)
+	_, wi, ok := schurQR(rows, zRows, n)
+	if !ok {
+		panic("Schur decomposition did not converge")
+	}
+	
+	// Clear the roundoff left below the diagonal by the QR sweeps, keeping
+	// only the subdiagonal entries inside 2x2 blocks of complex pairs
+	for i := 1; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			rows[i][j] = 0
+		}
+		if wi[i-1] <= 0 {
+			rows[i][i-1] = 0
+		}
+	}
+	
+	return fromDense(data, n, n), fromDense(zData, n, n)
+}
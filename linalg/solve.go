@@ -0,0 +1,54 @@
+package linalg
+
+import (
+	"fmt"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Solve solves the linear system A x = b for x using LU decomposition with
+// partial pivoting. A must be square with shape (n, n); b may be a vector
+// of shape (n) or a matrix of shape (n, k) holding k right-hand sides. The
+// result has the same shape as b.
+//
+// Prefer Solve over computing Inv(A) and multiplying: it is faster and
+// numerically more stable.
+//
+// A may also be a stack of matrices with shape (..., n, n). b is then a
+// stack of vectors (..., n), a stack of matrices (..., n, k), or a single
+// right-hand side shared by every system.
+func Solve(a, b *tensor.NDArray) *tensor.NDArray {
+	if a.Ndim() > 2 {
+		return solveBatched(a, b)
+	}
+	if anyComplex(a, b) {
+		return solveComplex(a, b)
+	}
+	
+	f := LUFactor(a)
+	if f.IsSingular() {
+		panic("matrix is singular")
+	}
+	return f.Solve(b)
+}
+
+// rhsToDense copies a right-hand side of shape (n) or (n, k) into a
+// row-major (n, k) slice, returning it along with k
+func rhsToDense(b *tensor.NDArray, n int) ([]float64, int) {
+	switch b.Ndim() {
+	case 1:
+		if b.Size() != n {
+			panic(fmt.Sprintf("dimension mismatch: matrix is (%d,%d), b has length %d", n, n, b.Size()))
+		}
+		return b.ToSliceFloat64(), 1
+	case 2:
+		shape := b.Shape()
+		if shape[0] != n {
+			panic(fmt.Sprintf("dimension mismatch: matrix is (%d,%d), b is (%d,%d)", n, n, shape[0], shape[1]))
+		}
+		data, _, k := toDense(b)
+		return data, k
+	default:
+		panic(fmt.Sprintf("b must be 1D or 2D, got %dD", b.Ndim()))
+	}
+}
@@ -0,0 +1,264 @@
+package linalg
+
+import (
+	"context"
+	"math"
+	"sort"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// svdMaxSweeps bounds the number of Jacobi sweeps; convergence is normally
+// reached in well under 20
+const svdMaxSweeps = 60
+
+// SVD computes the singular value decomposition A = U diag(S) Vt of an
+// m x n matrix using one-sided Jacobi rotations, which are accurate even
+// for small singular values. Singular values are returned in descending
+// order.
+//
+// If fullMatrices is true, U is m x m and Vt is n x n; otherwise the thin
+// decomposition is returned with U m x k and Vt k x n, where k = min(m, n).
+// S always has length k.
+//
+// For complex inputs U and Vt are complex128 and Vt is the conjugate
+// transpose V^H, so that A = U diag(S) V^H.
+func SVD(a *tensor.NDArray, fullMatrices bool) (u, s, vt *tensor.NDArray) {
+	// A background context is never cancelled, so no error can occur
+	u, s, vt, _ = SVDContext(context.Background(), a, fullMatrices)
+	return u, s, vt
+}
+
+// SVDContext is like SVD but checks ctx between Jacobi sweeps, returning
+// ctx.Err() if the context is cancelled
+func SVDContext(ctx context.Context, a *tensor.NDArray, fullMatrices bool) (u, s, vt *tensor.NDArray, err error) {
+	if a.DType().IsComplex() {
+		return svdComplex(ctx, a, fullMatrices)
+	}
+	
+	data, m, n := toDense(a)
+	
+	// The Jacobi iteration works on tall matrices; for wide ones decompose
+	// A^T = U' S V'^T and swap the factors
+	transposed := m < n
+	if transposed {
+		data = transposeDense(data, m, n)
+		m, n = n, m
+	}
+	
+	uCols, sv, vCols, err := jacobiSVD(ctx, data, m, n)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	
+	uCount := n
+	vCount := n
+	if fullMatrices {
+		uCount = m
+	}
+	uCols = completeBasis(uCols, m, uCount)
+	
+	if transposed {
+		// A = V' S U'^T, so U = V' and Vt = U'^T
+		uCols, vCols = vCols, uCols
+		uCount, vCount = vCount, uCount
+		m, n = n, m
+	}
+	
+	uData := make([]float64, m*uCount)
+	for j := 0; j < uCount; j++ {
+		for i := 0; i < m; i++ {
+			uData[i*uCount+j] = uCols[j][i]
+		}
+	}
+	vtData := make([]float64, vCount*n)
+	for j := 0; j < vCount; j++ {
+		copy(vtData[j*n:(j+1)*n], vCols[j])
+	}
+	
+	return fromDense(uData, m, uCount), tensor.FromSliceFloat64(sv, len(sv)), fromDense(vtData, vCount, n), nil
+}
+
+// SingularValues returns the singular values of a in descending order,
+// without forming the singular vectors' output arrays
+func SingularValues(a *tensor.NDArray) []float64 {
+	if a.DType().IsComplex() {
+		return singularValuesComplex(a)
+	}
+	
+	data, m, n := toDense(a)
+	if m < n {
+		data = transposeDense(data, m, n)
+		m, n = n, m
+	}
+	_, sv, _, _ := jacobiSVD(context.Background(), data, m, n)
+	return sv
+}
+
+// jacobiSVD runs one-sided Jacobi on a row-major m x n matrix with m >= n.
+// It returns the left singular vectors as n columns of length m (columns
+// for zero singular values are nil), the singular values in descending
+// order, and the right singular vectors as n columns of length n.
+func jacobiSVD(ctx context.Context, data []float64, m, n int) ([][]float64, []float64, [][]float64, error) {
+	// Work on columns of A and V
+	cols := make([][]float64, n)
+	for j := 0; j < n; j++ {
+		cols[j] = make([]float64, m)
+		for i := 0; i < m; i++ {
+			cols[j][i] = data[i*n+j]
+		}
+	}
+	v := make([][]float64, n)
+	for j := 0; j < n; j++ {
+		v[j] = make([]float64, n)
+		v[j][j] = 1
+	}
+	
+	const eps = 1e-15
+	for sweep := 0; sweep < svdMaxSweeps; sweep++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
+		
+		rotated := false
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				alpha, beta, gamma := 0.0, 0.0, 0.0
+				cp, cq := cols[p], cols[q]
+				for i := 0; i < m; i++ {
+					alpha += cp[i] * cp[i]
+					beta += cq[i] * cq[i]
+					gamma += cp[i] * cq[i]
+				}
+				if gamma == 0 || math.Abs(gamma) <= eps*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+				
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+				
+				for i := 0; i < m; i++ {
+					x, y := cp[i], cq[i]
+					cp[i] = c*x - s*y
+					cq[i] = s*x + c*y
+				}
+				vp, vq := v[p], v[q]
+				for i := 0; i < n; i++ {
+					x, y := vp[i], vq[i]
+					vp[i] = c*x - s*y
+					vq[i] = s*x + c*y
+				}
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+	
+	// Singular values are the column norms
+	sv := make([]float64, n)
+	for j := 0; j < n; j++ {
+		sv[j] = vecNorm(cols[j])
+	}
+	
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return sv[order[i]] > sv[order[j]] })
+	
+	sorted := make([]float64, n)
+	uCols := make([][]float64, n)
+	vCols := make([][]float64, n)
+	smax := 0.0
+	if n > 0 {
+		smax = sv[order[0]]
+	}
+	tol := smax * eps * float64(m)
+	for k, j := range order {
+		sorted[k] = sv[j]
+		vCols[k] = v[j]
+		if sv[j] > tol {
+			for i := range cols[j] {
+				cols[j][i] /= sv[j]
+			}
+			uCols[k] = cols[j]
+		}
+	}
+	
+	return uCols, sorted, vCols, nil
+}
+
+// completeBasis returns count orthonormal columns of length m, keeping the
+// non-nil columns of cols in place and filling nil or missing ones with
+// vectors orthogonal to all others
+func completeBasis(cols [][]float64, m, count int) [][]float64 {
+	result := make([][]float64, count)
+	copy(result, cols)
+	
+	candidate := 0
+	for j := 0; j < count; j++ {
+		if result[j] != nil {
+			continue
+		}
+		for candidate < m {
+			e := make([]float64, m)
+			e[candidate] = 1
+			candidate++
+			
+			// Two passes of Gram-Schmidt for numerical orthogonality
+			for pass := 0; pass < 2; pass++ {
+				for _, c := range result {
+					if c == nil {
+						continue
+					}
+					d := vecDot(e, c)
+					for i := range e {
+						e[i] -= d * c[i]
+					}
+				}
+			}
+			
+			if norm := vecNorm(e); norm > 1e-10 {
+				for i := range e {
+					e[i] /= norm
+				}
+				result[j] = e
+				break
+			}
+		}
+	}
+	
+	return result
+}
+
+// transposeDense returns the transpose of a row-major m x n slice
+func transposeDense(data []float64, m, n int) []float64 {
+	out := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			out[j*m+i] = data[i*n+j]
+		}
+	}
+	return out
+}
+
+// vecDot returns the dot product of two equal-length slices
+func vecDot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// vecNorm returns the Euclidean norm of a slice
+func vecNorm(a []float64) float64 {
+	return math.Sqrt(vecDot(a, a))
+}
@@ -0,0 +1,75 @@
+package linalg
+
+import (
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// SolveTriangular solves A x = b (or A^T x = b if trans is true) where A is
+// a square triangular matrix, using forward or back substitution in O(n^2).
+// Only the triangle selected by lower is read. If unitDiag is true the
+// diagonal is assumed to be all ones and is not read. b may be a vector of
+// shape (n) or a matrix of shape (n, k); the result has the same shape.
+//
+// This makes factorizations such as LU or Cholesky cheap to reuse for
+// repeated solves.
+func SolveTriangular(a, b *tensor.NDArray, lower, unitDiag, trans bool) *tensor.NDArray {
+	data, n := toSquareDense(a, "SolveTriangular")
+	rhs, k := rhsToDense(b, n)
+	
+	if !unitDiag {
+		for i := 0; i < n; i++ {
+			if data[i*n+i] == 0 {
+				panic("matrix is singular")
+			}
+		}
+	}
+	triSolve(data, n, rhs, k, lower, unitDiag, trans)
+	
+	if b.Ndim() == 1 {
+		return tensor.FromSliceFloat64(rhs, n)
+	}
+	return fromDense(rhs, n, k)
+}
+
+// triSolve solves op(T) X = B in place, where T is the lower or upper
+// triangle of the row-major n x n slice t, op is identity or transpose, and
+// B is a row-major n x k slice
+func triSolve(t []float64, n int, b []float64, k int, lower, unitDiag, trans bool) {
+	// Transposing swaps the triangle, so the substitution direction is
+	// determined by whether op(T) is lower triangular
+	forward := lower != trans
+	at := func(i, j int) float64 {
+		if trans {
+			return t[j*n+i]
+		}
+		return t[i*n+j]
+	}
+	
+	for step := 0; step < n; step++ {
+		i := step
+		if !forward {
+			i = n - 1 - step
+		}
+		
+		for s := 0; s < step; s++ {
+			j := s
+			if !forward {
+				j = n - 1 - s
+			}
+			coef := at(i, j)
+			if coef == 0 {
+				continue
+			}
+			for c := 0; c < k; c++ {
+				b[i*k+c] -= coef * b[j*k+c]
+			}
+		}
+		
+		if !unitDiag {
+			d := at(i, i)
+			for c := 0; c < k; c++ {
+				b[i*k+c] /= d
+			}
+		}
+	}
+}
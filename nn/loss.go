@@ -0,0 +1,66 @@
+package nn
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/autograd"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// shiftLogits subtracts the maximum of each row of 2D logits, which leaves
+// softmax unchanged but keeps exp from overflowing
+func shiftLogits(logits *autograd.Variable) *autograd.Variable {
+	shape := logits.Shape()
+	if len(shape) != 2 {
+		panic(fmt.Sprintf("expected logits of shape (batch, classes), got %v", shape))
+	}
+	n, k := shape[0], shape[1]
+	values := logits.Value.ToSliceFloat64()
+	max := make([]float64, n)
+	for i := range max {
+		max[i] = math.Inf(-1)
+		for _, v := range values[i*k : (i+1)*k] {
+			max[i] = math.Max(max[i], v)
+		}
+	}
+	return logits.Sub(autograd.Constant(tensor.FromSliceFloat64(max, n, 1)))
+}
+
+// Softmax normalizes each row of logits of shape (batch, classes) into
+// probabilities
+func Softmax(logits *autograd.Variable) *autograd.Variable {
+	e := shiftLogits(logits).Exp()
+	return e.Div(e.SumAxis(1).Reshape(logits.Shape()[0], 1))
+}
+
+// LogSoftmax returns the logarithm of Softmax, computed stably
+func LogSoftmax(logits *autograd.Variable) *autograd.Variable {
+	z := shiftLogits(logits)
+	return z.Sub(z.Exp().SumAxis(1).Log().Reshape(logits.Shape()[0], 1))
+}
+
+// SoftmaxCrossEntropy returns the mean cross-entropy between the softmax of
+// logits of shape (batch, classes) and integer class labels of shape
+// (batch), like PyTorch's CrossEntropyLoss
+func SoftmaxCrossEntropy(logits *autograd.Variable, labels *tensor.NDArray) *autograd.Variable {
+	shape := logits.Shape()
+	if len(shape) != 2 || labels.Ndim() != 1 || labels.Size() != shape[0] {
+		panic(fmt.Sprintf("expected logits of shape (batch, classes) and labels of shape (batch), got %v and %v", shape, labels.Shape()))
+	}
+	n, k := shape[0], shape[1]
+	onehot := tensor.Zeros([]int{n, k}, tensor.Float64)
+	for i := 0; i < n; i++ {
+		c := int(labels.GetInt64(i))
+		if c < 0 || c >= k {
+			panic(fmt.Sprintf("label %d is out of range for %d classes", c, k))
+		}
+		onehot.SetFloat64(1, i, c)
+	}
+	return LogSoftmax(logits).Mul(autograd.Constant(onehot)).Sum().MulScalar(-1 / float64(n))
+}
+
+// MSE returns the mean squared error between pred and target
+func MSE(pred, target *autograd.Variable) *autograd.Variable {
+	return pred.Sub(target).Pow(2).Mean()
+}
@@ -0,0 +1,85 @@
+// Package nn provides neural network layers, losses and gradient-based
+// optimizers built on the autograd package
+package nn
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/autograd"
+	"github.com/iSundram/NumGo/random"
+)
+
+// Layer is a differentiable function of a batch of inputs with trainable
+// parameters
+type Layer interface {
+	// Forward applies the layer to x, recording the operations for
+	// autograd
+	Forward(x *autograd.Variable) *autograd.Variable
+	
+	// Parameters returns the trainable variables of the layer
+	Parameters() []*autograd.Variable
+}
+
+// Linear is a fully connected layer computing x W + b for inputs of shape
+// (batch, in)
+type Linear struct {
+	Weight *autograd.Variable // shape (in, out)
+	Bias   *autograd.Variable // shape (out)
+}
+
+// NewLinear creates a linear layer with weights and biases drawn uniformly
+// from [-1/sqrt(in), 1/sqrt(in)], as PyTorch does
+func NewLinear(in, out int, rng *random.Generator) *Linear {
+	if in < 1 || out < 1 {
+		panic(fmt.Sprintf("linear layer needs positive sizes, got %d x %d", in, out))
+	}
+	bound := 1 / math.Sqrt(float64(in))
+	return &Linear{
+		Weight: autograd.NewVariable(rng.Uniform(-bound, bound, in, out)),
+		Bias:   autograd.NewVariable(rng.Uniform(-bound, bound, out)),
+	}
+}
+
+// Forward returns x W + b
+func (l *Linear) Forward(x *autograd.Variable) *autograd.Variable {
+	return x.MatMul(l.Weight).Add(l.Bias)
+}
+
+// Parameters returns the weight and bias
+func (l *Linear) Parameters() []*autograd.Variable {
+	return []*autograd.Variable{l.Weight, l.Bias}
+}
+
+// ReLU is the elementwise activation max(x, 0)
+type ReLU struct{}
+
+// Forward returns max(x, 0)
+func (ReLU) Forward(x *autograd.Variable) *autograd.Variable {
+	return x.ReLU()
+}
+
+// Parameters returns nil; ReLU has no parameters
+func (ReLU) Parameters() []*autograd.Variable {
+	return nil
+}
+
+// Sequential applies layers in order
+type Sequential []Layer
+
+// Forward passes x through each layer in turn
+func (s Sequential) Forward(x *autograd.Variable) *autograd.Variable {
+	for _, l := range s {
+		x = l.Forward(x)
+	}
+	return x
+}
+
+// Parameters returns the parameters of all layers
+func (s Sequential) Parameters() []*autograd.Variable {
+	var params []*autograd.Variable
+	for _, l := range s {
+		params = append(params, l.Parameters()...)
+	}
+	return params
+}
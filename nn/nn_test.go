@@ -0,0 +1,82 @@
+package nn
+
+import (
+	"math"
+	"testing"
+	
+	"github.com/iSundram/NumGo/autograd"
+	"github.com/iSundram/NumGo/random"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func sliceClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSoftmaxCrossEntropy(t *testing.T) {
+	logits := autograd.NewVariable(tensor.FromSliceFloat64([]float64{1, 2, 3, 1000, 0, -1000}, 2, 3))
+	p := Softmax(logits).Value.ToSliceFloat64()
+	e := []float64{math.Exp(-2), math.Exp(-1), 1}
+	s := e[0] + e[1] + e[2]
+	want := []float64{e[0] / s, e[1] / s, e[2] / s, 1, 0, 0}
+	if !sliceClose(p, want, 1e-12) {
+		t.Errorf("expected %v, got %v", want, p)
+	}
+	
+	labels := tensor.FromSliceInt64([]int64{2, 1}, 2)
+	loss := SoftmaxCrossEntropy(logits, labels)
+	if got, want := loss.Value.GetFloat64(0), (math.Log(s)+1000)/2; math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected loss %f, got %f", want, got)
+	}
+	loss.Backward()
+	// The gradient is (softmax - onehot) / batch
+	grad := []float64{want[0] / 2, want[1] / 2, (want[2] - 1) / 2, 0.5, -0.5, 0}
+	if !sliceClose(logits.Grad.ToSliceFloat64(), grad, 1e-12) {
+		t.Errorf("expected gradient %v, got %v", grad, logits.Grad.ToSliceFloat64())
+	}
+}
+
+func TestTrainXOR(t *testing.T) {
+	x := autograd.Constant(tensor.FromSliceFloat64([]float64{0, 0, 0, 1, 1, 0, 1, 1}, 4, 2))
+	labels := tensor.FromSliceInt64([]int64{0, 1, 1, 0}, 4)
+	rng := random.New(1)
+	model := Sequential{NewLinear(2, 8, rng), ReLU{}, NewLinear(8, 2, rng)}
+	opt := NewAdam(model.Parameters(), 0.05)
+	for step := 0; step < 500; step++ {
+		opt.ZeroGrad()
+		SoftmaxCrossEntropy(model.Forward(x), labels).Backward()
+		opt.Step()
+	}
+	
+	p := Softmax(model.Forward(x)).Value
+	for i := 0; i < 4; i++ {
+		if c := labels.GetInt64(i); p.GetFloat64(i, int(c)) < 0.9 {
+			t.Errorf("sample %d: expected class %d with probability > 0.9, got %v", i, c, p.ToSliceFloat64())
+		}
+	}
+}
+
+func TestSGDMomentum(t *testing.T) {
+	x := autograd.Constant(tensor.FromSliceFloat64([]float64{0, 1, 2, 3}, 4, 1))
+	y := autograd.Constant(tensor.FromSliceFloat64([]float64{1, 3, 5, 7}, 4, 1))
+	layer := NewLinear(1, 1, random.New(2))
+	opt := NewSGD(layer.Parameters(), 0.02)
+	opt.Momentum = 0.9
+	for step := 0; step < 1000; step++ {
+		opt.ZeroGrad()
+		MSE(layer.Forward(x), y).Backward()
+		opt.Step()
+	}
+	got := []float64{layer.Weight.Value.GetFloat64(0, 0), layer.Bias.Value.GetFloat64(0)}
+	if !sliceClose(got, []float64{2, 1}, 1e-6) {
+		t.Errorf("expected weight 2 and bias 1, got %v", got)
+	}
+}
@@ -0,0 +1,115 @@
+package nn
+
+import (
+	"math"
+	
+	"github.com/iSundram/NumGo/autograd"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Optimizer updates parameters from the gradients left by Backward
+type Optimizer interface {
+	// Step updates every parameter that has a gradient
+	Step()
+	
+	// ZeroGrad clears the gradients of all parameters
+	ZeroGrad()
+}
+
+// SGD is stochastic gradient descent with optional momentum
+type SGD struct {
+	LearningRate float64
+	Momentum     float64 // zero for plain gradient descent
+	
+	params   []*autograd.Variable
+	velocity []*tensor.NDArray
+}
+
+// NewSGD creates an SGD optimizer for params without momentum
+func NewSGD(params []*autograd.Variable, learningRate float64) *SGD {
+	return &SGD{LearningRate: learningRate, params: params, velocity: make([]*tensor.NDArray, len(params))}
+}
+
+// Step moves each parameter against its gradient
+func (o *SGD) Step() {
+	for i, p := range o.params {
+		if p.Grad == nil {
+			continue
+		}
+		step := p.Grad
+		if o.Momentum != 0 {
+			if o.velocity[i] != nil {
+				step = o.velocity[i].MulScalar(o.Momentum).Add(step)
+			}
+			o.velocity[i] = step
+		}
+		p.Value = p.Value.Sub(step.MulScalar(o.LearningRate))
+	}
+}
+
+// ZeroGrad clears the gradients of all parameters
+func (o *SGD) ZeroGrad() {
+	zeroGrad(o.params)
+}
+
+// Adam is the Adam optimizer of Kingma and Ba, with bias-corrected
+// estimates of the first and second moments of the gradient
+type Adam struct {
+	LearningRate float64
+	Beta1        float64
+	Beta2        float64
+	Epsilon      float64
+	
+	params []*autograd.Variable
+	m, v   [][]float64
+	t      int
+}
+
+// NewAdam creates an Adam optimizer for params with the usual defaults
+// Beta1 = 0.9, Beta2 = 0.999 and Epsilon = 1e-8
+func NewAdam(params []*autograd.Variable, learningRate float64) *Adam {
+	return &Adam{
+		LearningRate: learningRate,
+		Beta1:        0.9,
+		Beta2:        0.999,
+		Epsilon:      1e-8,
+		params:       params,
+		m:            make([][]float64, len(params)),
+		v:            make([][]float64, len(params)),
+	}
+}
+
+// Step updates each parameter from the moment estimates
+func (o *Adam) Step() {
+	o.t++
+	c1 := 1 - math.Pow(o.Beta1, float64(o.t))
+	c2 := 1 - math.Pow(o.Beta2, float64(o.t))
+	for i, p := range o.params {
+		if p.Grad == nil {
+			continue
+		}
+		g := p.Grad.ToSliceFloat64()
+		if o.m[i] == nil {
+			o.m[i] = make([]float64, len(g))
+			o.v[i] = make([]float64, len(g))
+		}
+		values := p.Value.ToSliceFloat64()
+		for j, gj := range g {
+			o.m[i][j] = o.Beta1*o.m[i][j] + (1-o.Beta1)*gj
+			o.v[i][j] = o.Beta2*o.v[i][j] + (1-o.Beta2)*gj*gj
+			values[j] -= o.LearningRate * (o.m[i][j] / c1) / (math.Sqrt(o.v[i][j]/c2) + o.Epsilon)
+		}
+		p.Value = tensor.FromSliceFloat64(values, p.Value.Shape()...)
+	}
+}
+
+// ZeroGrad clears the gradients of all parameters
+func (o *Adam) ZeroGrad() {
+	zeroGrad(o.params)
+}
+
+func zeroGrad(params []*autograd.Variable) {
+	for _, p := range params {
+		p.ZeroGrad()
+	}
+}
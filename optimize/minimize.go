@@ -0,0 +1,300 @@
+package optimize
+
+import (
+	"math"
+	"sort"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// NelderMead minimizes f from x0 with the derivative-free downhill simplex
+// method. The initial simplex perturbs each coordinate of x0 by 5% (or
+// 0.00025 where it is zero), as in scipy.optimize.
+func NelderMead(f Objective, x0 *tensor.NDArray, opts Options) Result {
+	p, start := newProblem("NelderMead", f, nil, x0)
+	n := len(start)
+	maxIter := opts.MaxIter
+	if maxIter <= 0 {
+		maxIter = 200 * n
+	}
+	xTol, fTol := opts.XTol, opts.FTol
+	if xTol <= 0 {
+		xTol = 1e-4
+	}
+	if fTol <= 0 {
+		fTol = 1e-4
+	}
+	
+	const (
+		reflect  = 1.0
+		expand   = 2.0
+		contract = 0.5
+		shrink   = 0.5
+	)
+	
+	simplex := make([][]float64, n+1)
+	values := make([]float64, n+1)
+	simplex[0] = start
+	for i := 0; i < n; i++ {
+		v := append([]float64{}, start...)
+		if v[i] != 0 {
+			v[i] *= 1.05
+		} else {
+			v[i] = 0.00025
+		}
+		simplex[i+1] = v
+	}
+	for i, v := range simplex {
+		values[i] = p.value(v)
+	}
+	
+	// point returns (1 + t) * centroid - t * worst
+	point := func(centroid, worst []float64, t float64) []float64 {
+		out := make([]float64, n)
+		for i := range out {
+			out[i] = (1+t)*centroid[i] - t*worst[i]
+		}
+		return out
+	}
+	
+	converged := false
+	iter := 0
+	for ; iter < maxIter; iter++ {
+		order := make([]int, n+1)
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+		sortedSimplex := make([][]float64, n+1)
+		sortedValues := make([]float64, n+1)
+		for i, k := range order {
+			sortedSimplex[i], sortedValues[i] = simplex[k], values[k]
+		}
+		simplex, values = sortedSimplex, sortedValues
+		
+		xSpread, fSpread := 0.0, 0.0
+		for i := 1; i <= n; i++ {
+			for j := range simplex[i] {
+				xSpread = math.Max(xSpread, math.Abs(simplex[i][j]-simplex[0][j]))
+			}
+			fSpread = math.Max(fSpread, math.Abs(values[i]-values[0]))
+		}
+		if xSpread <= xTol && fSpread <= fTol {
+			converged = true
+			break
+		}
+		
+		centroid := make([]float64, n)
+		for i := 0; i < n; i++ {
+			for j, v := range simplex[i] {
+				centroid[j] += v / float64(n)
+			}
+		}
+		worst := simplex[n]
+		
+		xr := point(centroid, worst, reflect)
+		fr := p.value(xr)
+		switch {
+		case fr < values[0]:
+			xe := point(centroid, worst, reflect*expand)
+			if fe := p.value(xe); fe < fr {
+				simplex[n], values[n] = xe, fe
+			} else {
+				simplex[n], values[n] = xr, fr
+			}
+			continue
+		case fr < values[n-1]:
+			simplex[n], values[n] = xr, fr
+			continue
+		case fr < values[n]:
+			xc := point(centroid, worst, contract*reflect)
+			if fc := p.value(xc); fc <= fr {
+				simplex[n], values[n] = xc, fc
+				continue
+			}
+		default:
+			xcc := point(centroid, worst, -contract)
+			if fcc := p.value(xcc); fcc < values[n] {
+				simplex[n], values[n] = xcc, fcc
+				continue
+			}
+		}
+		
+		for i := 1; i <= n; i++ {
+			for j := range simplex[i] {
+				simplex[i][j] = simplex[0][j] + shrink*(simplex[i][j]-simplex[0][j])
+			}
+			values[i] = p.value(simplex[i])
+		}
+	}
+	
+	best := 0
+	for i := range values {
+		if values[i] < values[best] {
+			best = i
+		}
+	}
+	return p.result(simplex[best], values[best], iter, converged)
+}
+
+// GradientDescent minimizes f from x0 by gradient descent with heavy-ball
+// momentum: v = Momentum * v - LearningRate * grad(x), x = x + v. A nil
+// grad is approximated by central differences. Iteration stops early if
+// the iterate stops being finite.
+func GradientDescent(f Objective, grad Gradient, x0 *tensor.NDArray, opts Options) Result {
+	p, x := newProblem("GradientDescent", f, grad, x0)
+	maxIter := opts.MaxIter
+	if maxIter <= 0 {
+		maxIter = 10000
+	}
+	gradTol := opts.GradTol
+	if gradTol <= 0 {
+		gradTol = 1e-5
+	}
+	rate := opts.LearningRate
+	if rate <= 0 {
+		rate = 0.01
+	}
+	
+	velocity := make([]float64, len(x))
+	converged := false
+	iter := 0
+	for ; iter < maxIter; iter++ {
+		g := p.gradient(x)
+		gmax := maxAbs(g)
+		if gmax <= gradTol {
+			converged = true
+			break
+		}
+		if math.IsNaN(gmax) || math.IsInf(gmax, 0) {
+			break
+		}
+		for i := range x {
+			velocity[i] = opts.Momentum*velocity[i] - rate*g[i]
+			x[i] += velocity[i]
+		}
+	}
+	return p.result(x, p.value(x), iter, converged)
+}
+
+// LBFGS minimizes f from x0 with the limited-memory BFGS quasi-Newton
+// method and a backtracking line search. A nil grad is approximated by
+// central differences. Besides the gradient test, iteration stops once a
+// step reduces f by less than 2.2e-9 relative to its magnitude, like the
+// default factr of scipy's L-BFGS-B.
+func LBFGS(f Objective, grad Gradient, x0 *tensor.NDArray, opts Options) Result {
+	p, x := newProblem("LBFGS", f, grad, x0)
+	n := len(x)
+	maxIter := opts.MaxIter
+	if maxIter <= 0 {
+		maxIter = 1000
+	}
+	gradTol := opts.GradTol
+	if gradTol <= 0 {
+		gradTol = 1e-5
+	}
+	memory := opts.Memory
+	if memory <= 0 {
+		memory = 10
+	}
+	const (
+		armijo   = 1e-4
+		relFTol  = 2.220446049250313e-09
+		maxSteps = 60
+	)
+	
+	var sHist, yHist [][]float64
+	var rhoHist []float64
+	fx := p.value(x)
+	g := p.gradient(x)
+	converged := false
+	iter := 0
+	for ; iter < maxIter; iter++ {
+		if maxAbs(g) <= gradTol {
+			converged = true
+			break
+		}
+		
+		// Two-loop recursion for d = -H g
+		d := make([]float64, n)
+		for i := range d {
+			d[i] = -g[i]
+		}
+		alpha := make([]float64, len(sHist))
+		for k := len(sHist) - 1; k >= 0; k-- {
+			alpha[k] = rhoHist[k] * dot(sHist[k], d)
+			for i := range d {
+				d[i] -= alpha[k] * yHist[k][i]
+			}
+		}
+		step := 1.0
+		if k := len(sHist) - 1; k >= 0 {
+			gamma := dot(sHist[k], yHist[k]) / dot(yHist[k], yHist[k])
+			for i := range d {
+				d[i] *= gamma
+			}
+		} else {
+			step = math.Min(1, 1/math.Sqrt(dot(g, g)))
+		}
+		for k := range sHist {
+			beta := rhoHist[k] * dot(yHist[k], d)
+			for i := range d {
+				d[i] += (alpha[k] - beta) * sHist[k][i]
+			}
+		}
+		
+		slope := dot(g, d)
+		if !(slope < 0) {
+			// Not a descent direction: restart from steepest descent
+			sHist, yHist, rhoHist = nil, nil, nil
+			for i := range d {
+				d[i] = -g[i]
+			}
+			slope = -dot(g, g)
+			step = math.Min(1, 1/math.Sqrt(-slope))
+		}
+		
+		xNew := make([]float64, n)
+		fNew := math.Inf(1)
+		accepted := false
+		for k := 0; k < maxSteps; k++ {
+			for i := range xNew {
+				xNew[i] = x[i] + step*d[i]
+			}
+			fNew = p.value(xNew)
+			if fNew <= fx+armijo*step*slope {
+				accepted = true
+				break
+			}
+			step *= 0.5
+		}
+		if !accepted {
+			break
+		}
+		
+		gNew := p.gradient(xNew)
+		s := make([]float64, n)
+		y := make([]float64, n)
+		for i := range s {
+			s[i] = xNew[i] - x[i]
+			y[i] = gNew[i] - g[i]
+		}
+		if sy := dot(s, y); sy > 1e-10*dot(y, y) {
+			sHist = append(sHist, s)
+			yHist = append(yHist, y)
+			rhoHist = append(rhoHist, 1/sy)
+			if len(sHist) > memory {
+				sHist, yHist, rhoHist = sHist[1:], yHist[1:], rhoHist[1:]
+			}
+		}
+		
+		decrease := fx - fNew
+		x, fx, g = xNew, fNew, gNew
+		if decrease <= relFTol*math.Max(math.Max(math.Abs(fx), math.Abs(fx+decrease)), 1) {
+			iter++
+			converged = true
+			break
+		}
+	}
+	return p.result(x, fx, iter, converged)
+}
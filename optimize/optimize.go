@@ -0,0 +1,151 @@
+// Package optimize provides minimization and root finding for NumGo arrays
+package optimize
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Objective returns the value of the function being minimized at x
+type Objective func(x *tensor.NDArray) float64
+
+// Gradient returns the gradient of the objective at x, as an array with
+// the same number of elements as x
+type Gradient func(x *tensor.NDArray) *tensor.NDArray
+
+// Options controls the minimizers. The zero value selects the defaults.
+type Options struct {
+	// MaxIter bounds the number of iterations. Zero selects 200 * n for
+	// NelderMead, 10000 for GradientDescent and 1000 for LBFGS.
+	MaxIter int
+	// GradTol stops the gradient methods once every component of the
+	// gradient is at most GradTol in magnitude. Zero selects 1e-5.
+	GradTol float64
+	// XTol and FTol stop NelderMead once all vertices of the simplex are
+	// within XTol of the best one and their values within FTol of its
+	// value. Zero selects 1e-4 for both.
+	XTol, FTol float64
+	// LearningRate is the GradientDescent step size. Zero selects 0.01.
+	LearningRate float64
+	// Momentum is the GradientDescent heavy-ball coefficient in [0, 1).
+	// Zero gives plain gradient descent.
+	Momentum float64
+	// Memory is the number of correction pairs LBFGS keeps. Zero
+	// selects 10.
+	Memory int
+}
+
+// Result reports the outcome of a minimization
+type Result struct {
+	// X is the final iterate, with the shape of the starting point
+	X *tensor.NDArray
+	// F is the objective value at X
+	F float64
+	// Iterations is the number of iterations performed
+	Iterations int
+	// FuncEvals and GradEvals count calls to the objective and gradient.
+	// Finite-difference gradients are counted as function evaluations.
+	FuncEvals, GradEvals int
+	// Converged reports whether the tolerance was reached
+	Converged bool
+}
+
+// problem wraps an objective and optional gradient over flat float64
+// slices, counting evaluations
+type problem struct {
+	f         Objective
+	grad      Gradient
+	shape     []int
+	funcEvals int
+	gradEvals int
+}
+
+// newProblem validates the starting point and returns the problem and a
+// flat copy of x0
+func newProblem(name string, f Objective, grad Gradient, x0 *tensor.NDArray) (*problem, []float64) {
+	if f == nil {
+		panic(fmt.Sprintf("%s requires an objective", name))
+	}
+	if x0.Size() == 0 {
+		panic(fmt.Sprintf("%s requires a non-empty starting point", name))
+	}
+	if x0.DType().IsComplex() {
+		panic(fmt.Sprintf("%s does not support complex parameters", name))
+	}
+	return &problem{f: f, grad: grad, shape: x0.Shape()}, x0.ToSliceFloat64()
+}
+
+// array wraps x with the shape of the starting point
+func (p *problem) array(x []float64) *tensor.NDArray {
+	return tensor.FromSliceFloat64(append([]float64{}, x...), p.shape...)
+}
+
+// value evaluates the objective at x
+func (p *problem) value(x []float64) float64 {
+	p.funcEvals++
+	return p.f(p.array(x))
+}
+
+// gradient evaluates the gradient at x, falling back to central
+// differences when no gradient was supplied
+func (p *problem) gradient(x []float64) []float64 {
+	if p.grad == nil {
+		return p.numericGradient(x)
+	}
+	p.gradEvals++
+	g := p.grad(p.array(x))
+	if g.Size() != len(x) {
+		panic(fmt.Sprintf("gradient has %d elements, expected %d", g.Size(), len(x)))
+	}
+	return g.ToSliceFloat64()
+}
+
+// numericGradient approximates the gradient at x by central differences
+// with steps scaled to each coordinate
+func (p *problem) numericGradient(x []float64) []float64 {
+	h0 := math.Cbrt(2.220446049250313e-16)
+	g := make([]float64, len(x))
+	xh := append([]float64{}, x...)
+	for i, v := range x {
+		h := h0 * math.Max(1, math.Abs(v))
+		xh[i] = v + h
+		fp := p.value(xh)
+		xh[i] = v - h
+		fm := p.value(xh)
+		xh[i] = v
+		g[i] = (fp - fm) / (2 * h)
+	}
+	return g
+}
+
+// result packages the final iterate
+func (p *problem) result(x []float64, f float64, iterations int, converged bool) Result {
+	return Result{
+		X:          p.array(x),
+		F:          f,
+		Iterations: iterations,
+		FuncEvals:  p.funcEvals,
+		GradEvals:  p.gradEvals,
+		Converged:  converged,
+	}
+}
+
+// dot returns the inner product of a and b
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// maxAbs returns the largest magnitude in a
+func maxAbs(a []float64) float64 {
+	m := 0.0
+	for _, v := range a {
+		m = math.Max(m, math.Abs(v))
+	}
+	return m
+}
@@ -0,0 +1,187 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func sliceClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func rosenbrock(x *tensor.NDArray) float64 {
+	v := x.ToSliceFloat64()
+	sum := 0.0
+	for i := 0; i+1 < len(v); i++ {
+		a, b := 1-v[i], v[i+1]-v[i]*v[i]
+		sum += a*a + 100*b*b
+	}
+	return sum
+}
+
+func rosenbrockGrad(x *tensor.NDArray) *tensor.NDArray {
+	v := x.ToSliceFloat64()
+	g := make([]float64, len(v))
+	for i := 0; i+1 < len(v); i++ {
+		b := v[i+1] - v[i]*v[i]
+		g[i] += -2*(1-v[i]) - 400*v[i]*b
+		g[i+1] += 200 * b
+	}
+	return tensor.FromSliceFloat64(g, x.Shape()...)
+}
+
+func TestNelderMead(t *testing.T) {
+	x0 := tensor.FromSliceFloat64([]float64{-1.2, 1}, 2)
+	res := NelderMead(rosenbrock, x0, Options{XTol: 1e-8, FTol: 1e-10})
+	if !res.Converged {
+		t.Fatalf("did not converge in %d iterations", res.Iterations)
+	}
+	if got := res.X.ToSliceFloat64(); !sliceClose(got, []float64{1, 1}, 1e-4) {
+		t.Errorf("expected [1 1], got %v", got)
+	}
+	if res.FuncEvals == 0 || res.GradEvals != 0 {
+		t.Errorf("unexpected evaluation counts %d, %d", res.FuncEvals, res.GradEvals)
+	}
+}
+
+func TestGradientDescent(t *testing.T) {
+	// f(x) = (x0 - 3)^2 + 10 (x1 + 1)^2, an ill-conditioned bowl
+	f := func(x *tensor.NDArray) float64 {
+		v := x.ToSliceFloat64()
+		return (v[0]-3)*(v[0]-3) + 10*(v[1]+1)*(v[1]+1)
+	}
+	grad := func(x *tensor.NDArray) *tensor.NDArray {
+		v := x.ToSliceFloat64()
+		return tensor.FromSliceFloat64([]float64{2 * (v[0] - 3), 20 * (v[1] + 1)}, 2)
+	}
+	x0 := tensor.Zeros([]int{2}, tensor.Float64)
+	
+	plain := GradientDescent(f, grad, x0, Options{LearningRate: 0.01})
+	momentum := GradientDescent(f, grad, x0, Options{LearningRate: 0.01, Momentum: 0.9})
+	for _, res := range []Result{plain, momentum} {
+		if !res.Converged {
+			t.Fatalf("did not converge in %d iterations", res.Iterations)
+		}
+		if got := res.X.ToSliceFloat64(); !sliceClose(got, []float64{3, -1}, 1e-5) {
+			t.Errorf("expected [3 -1], got %v", got)
+		}
+	}
+	if momentum.Iterations >= plain.Iterations {
+		t.Errorf("momentum took %d iterations, plain descent %d", momentum.Iterations, plain.Iterations)
+	}
+	
+	numeric := GradientDescent(f, nil, x0, Options{LearningRate: 0.01, Momentum: 0.9})
+	if got := numeric.X.ToSliceFloat64(); !sliceClose(got, []float64{3, -1}, 1e-5) {
+		t.Errorf("numeric gradient: expected [3 -1], got %v", got)
+	}
+}
+
+func TestLBFGS(t *testing.T) {
+	x0 := tensor.FromSliceFloat64([]float64{-1.2, 1, -1.2, 1, 0.5, 0.3}, 2, 3)
+	res := LBFGS(rosenbrock, rosenbrockGrad, x0, Options{})
+	if !res.Converged {
+		t.Fatalf("did not converge in %d iterations", res.Iterations)
+	}
+	if s := res.X.Shape(); len(s) != 2 || s[0] != 2 || s[1] != 3 {
+		t.Errorf("expected shape [2 3], got %v", s)
+	}
+	if got := res.X.ToSliceFloat64(); !sliceClose(got, []float64{1, 1, 1, 1, 1, 1}, 1e-4) {
+		t.Errorf("expected all ones, got %v", got)
+	}
+	
+	numeric := LBFGS(rosenbrock, nil, tensor.FromSliceFloat64([]float64{-1.2, 1}, 2), Options{})
+	if got := numeric.X.ToSliceFloat64(); !sliceClose(got, []float64{1, 1}, 1e-4) {
+		t.Errorf("numeric gradient: expected [1 1], got %v", got)
+	}
+	if numeric.GradEvals != 0 {
+		t.Errorf("expected no gradient calls, got %d", numeric.GradEvals)
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	// Linear regression y = 2x + 3 by minimizing the mean squared error
+	xs := []float64{0, 1, 2, 3, 4, 5}
+	ys := []float64{3.1, 4.9, 7.2, 8.8, 11.1, 12.9}
+	mse := func(beta *tensor.NDArray) float64 {
+		b := beta.ToSliceFloat64()
+		sum := 0.0
+		for i, x := range xs {
+			r := b[0] + b[1]*x - ys[i]
+			sum += r * r
+		}
+		return sum / float64(len(xs))
+	}
+	res := LBFGS(mse, nil, tensor.Zeros([]int{2}, tensor.Float64), Options{})
+	
+	// Normal equations solution
+	want := []float64{3.057142857142857, 1.9771428571428573}
+	if got := res.X.ToSliceFloat64(); !sliceClose(got, want, 1e-5) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestScalarRoots(t *testing.T) {
+	f := func(x float64) float64 { return x*x*x - 2*x - 5 }
+	fprime := func(x float64) float64 { return 3*x*x - 2 }
+	want := 2.0945514815423265
+	
+	results := map[string]ScalarResult{
+		"Bisect": Bisect(f, 2, 3, ScalarOptions{}),
+		"Brentq": Brentq(f, 2, 3, ScalarOptions{}),
+		"Newton": Newton(f, fprime, 2, ScalarOptions{}),
+		"Secant": Newton(f, nil, 2, ScalarOptions{}),
+	}
+	for name, res := range results {
+		if !res.Converged || math.Abs(res.X-want) > 1e-10 {
+			t.Errorf("%s: expected %v, got %+v", name, want, res)
+		}
+	}
+	if b, q := results["Bisect"], results["Brentq"]; q.FuncEvals >= b.FuncEvals {
+		t.Errorf("Brentq used %d evaluations, Bisect %d", q.FuncEvals, b.FuncEvals)
+	}
+	
+	// A flat derivative stops Newton without converging
+	if res := Newton(func(x float64) float64 { return x*x + 1 }, func(x float64) float64 { return 2 * x }, 0, ScalarOptions{}); res.Converged {
+		t.Errorf("expected no convergence, got %+v", res)
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an interval without a sign change")
+		}
+	}()
+	Brentq(f, 3, 4, ScalarOptions{})
+}
+
+func TestMinimizeScalar(t *testing.T) {
+	f := func(x float64) float64 { return (x-2)*(x-2)*(x+1) + math.Sin(x) }
+	// Stationary point of f in [0, 4]: 3x^2 - 6x + cos(x) = 0
+	want := Brentq(func(x float64) float64 { return 3*x*x - 6*x + math.Cos(x) }, 1, 4, ScalarOptions{}).X
+	
+	for _, method := range []ScalarMethod{Brent, Golden} {
+		res := MinimizeScalar(f, 0, 4, ScalarOptions{XTol: 1e-8, Method: method})
+		if !res.Converged || math.Abs(res.X-want) > 1e-6 {
+			t.Errorf("method %d: expected %v, got %+v", method, want, res)
+		}
+	}
+	brent := MinimizeScalar(f, 0, 4, ScalarOptions{XTol: 1e-8})
+	golden := MinimizeScalar(f, 0, 4, ScalarOptions{XTol: 1e-8, Method: Golden})
+	if brent.FuncEvals >= golden.FuncEvals {
+		t.Errorf("Brent used %d evaluations, golden section %d", brent.FuncEvals, golden.FuncEvals)
+	}
+	
+	// The minimum of a monotone function is at the boundary
+	if res := MinimizeScalar(func(x float64) float64 { return x }, 1, 2, ScalarOptions{}); math.Abs(res.X-1) > 1e-4 {
+		t.Errorf("expected about 1, got %v", res.X)
+	}
+}
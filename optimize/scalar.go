@@ -0,0 +1,382 @@
+package optimize
+
+import (
+	"fmt"
+	"math"
+)
+
+// ScalarMethod selects the algorithm of MinimizeScalar
+type ScalarMethod int
+
+const (
+	// Brent combines golden-section steps with parabolic interpolation
+	Brent ScalarMethod = iota
+	// Golden uses golden-section search only
+	Golden
+)
+
+// ScalarOptions controls the scalar root finders and minimizers. The zero
+// value selects the defaults.
+type ScalarOptions struct {
+	// XTol is the absolute tolerance on x. Zero selects 2e-12 for the root
+	// finders and 1e-5 for MinimizeScalar.
+	XTol float64
+	// RTol is the relative tolerance on x. Zero selects 4 times machine
+	// epsilon for the root finders and its square root for MinimizeScalar.
+	RTol float64
+	// MaxIter bounds the number of iterations. Zero selects 100 for the
+	// root finders and 500 for MinimizeScalar.
+	MaxIter int
+	// Method selects the MinimizeScalar algorithm
+	Method ScalarMethod
+}
+
+// ScalarResult reports the outcome of a scalar root find or minimization
+type ScalarResult struct {
+	// X is the root or minimizer
+	X float64
+	// F is the function value at X
+	F float64
+	// Iterations and FuncEvals count iterations and function calls
+	Iterations, FuncEvals int
+	// Converged reports whether the tolerance was reached
+	Converged bool
+}
+
+// rootTolerances resolves the root finder defaults
+func rootTolerances(opts ScalarOptions) (xTol, rTol float64, maxIter int) {
+	xTol, rTol, maxIter = opts.XTol, opts.RTol, opts.MaxIter
+	if xTol <= 0 {
+		xTol = 2e-12
+	}
+	if rTol <= 0 {
+		rTol = 4 * 2.220446049250313e-16
+	}
+	if maxIter <= 0 {
+		maxIter = 100
+	}
+	return xTol, rTol, maxIter
+}
+
+// bracket evaluates f at the ends of [a, b], panicking unless they have
+// opposite signs
+func bracket(name string, f func(float64) float64, a, b float64) (fa, fb float64) {
+	fa, fb = f(a), f(b)
+	if fa*fb > 0 {
+		panic(fmt.Sprintf("%s: f(a) and f(b) must have opposite signs, got f(%v) = %v and f(%v) = %v", name, a, fa, b, fb))
+	}
+	return fa, fb
+}
+
+// Bisect finds a root of f in [a, b], where f(a) and f(b) have opposite
+// signs, by repeatedly halving the interval
+func Bisect(f func(float64) float64, a, b float64, opts ScalarOptions) ScalarResult {
+	xTol, rTol, maxIter := rootTolerances(opts)
+	fa, fb := bracket("Bisect", f, a, b)
+	evals := 2
+	if fa == 0 {
+		return ScalarResult{X: a, FuncEvals: evals, Converged: true}
+	}
+	if fb == 0 {
+		return ScalarResult{X: b, FuncEvals: evals, Converged: true}
+	}
+	
+	// Walk from the end where f is negative towards the other
+	x, dx := a, b-a
+	if fa > 0 {
+		x, dx = b, a-b
+	}
+	for iter := 1; iter <= maxIter; iter++ {
+		dx /= 2
+		mid := x + dx
+		fm := f(mid)
+		evals++
+		if fm <= 0 {
+			x = mid
+		}
+		if fm == 0 || math.Abs(dx) <= xTol+rTol*math.Abs(mid) {
+			return ScalarResult{X: mid, F: fm, Iterations: iter, FuncEvals: evals, Converged: true}
+		}
+	}
+	return ScalarResult{X: x, F: f(x), Iterations: maxIter, FuncEvals: evals + 1}
+}
+
+// Brentq finds a root of f in [a, b], where f(a) and f(b) have opposite
+// signs, with Brent's method: inverse quadratic interpolation safeguarded
+// by bisection. It is the method of choice for bracketed roots.
+func Brentq(f func(float64) float64, a, b float64, opts ScalarOptions) ScalarResult {
+	xTol, rTol, maxIter := rootTolerances(opts)
+	fa, fb := bracket("Brentq", f, a, b)
+	evals := 2
+	if fa == 0 {
+		return ScalarResult{X: a, FuncEvals: evals, Converged: true}
+	}
+	
+	c, fc := a, fa
+	d := b - a
+	e := d
+	for iter := 1; iter <= maxIter; iter++ {
+		if fb*fc > 0 {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		
+		tol := (xTol + rTol*math.Abs(b)) / 2
+		m := (c - b) / 2
+		if math.Abs(m) <= tol || fb == 0 {
+			return ScalarResult{X: b, F: fb, Iterations: iter - 1, FuncEvals: evals, Converged: true}
+		}
+		
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			// Secant or inverse quadratic interpolation
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*m*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*m*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = m
+				e = d
+			}
+		} else {
+			d = m
+			e = d
+		}
+		
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else if m > 0 {
+			b += tol
+		} else {
+			b -= tol
+		}
+		fb = f(b)
+		evals++
+	}
+	return ScalarResult{X: b, F: fb, Iterations: maxIter, FuncEvals: evals}
+}
+
+// Newton finds a root of f near x0 with Newton's method using the
+// derivative fprime, or with the secant method when fprime is nil. It does
+// not converge if the derivative vanishes along the way.
+func Newton(f, fprime func(float64) float64, x0 float64, opts ScalarOptions) ScalarResult {
+	xTol, rTol, maxIter := rootTolerances(opts)
+	
+	if fprime != nil {
+		x := x0
+		fx := f(x)
+		evals := 1
+		for iter := 1; iter <= maxIter; iter++ {
+			if fx == 0 {
+				return ScalarResult{X: x, Iterations: iter - 1, FuncEvals: evals, Converged: true}
+			}
+			dfx := fprime(x)
+			if dfx == 0 {
+				return ScalarResult{X: x, F: fx, Iterations: iter - 1, FuncEvals: evals}
+			}
+			next := x - fx/dfx
+			fx = f(next)
+			evals++
+			if math.Abs(next-x) <= xTol+rTol*math.Abs(next) {
+				return ScalarResult{X: next, F: fx, Iterations: iter, FuncEvals: evals, Converged: true}
+			}
+			x = next
+		}
+		return ScalarResult{X: x, F: fx, Iterations: maxIter, FuncEvals: evals}
+	}
+	
+	// Secant method, started from a small perturbation of x0 as in SciPy
+	p0 := x0
+	p1 := x0*(1+1e-4) + 1e-4
+	if x0 < 0 {
+		p1 = x0*(1+1e-4) - 1e-4
+	}
+	q0, q1 := f(p0), f(p1)
+	evals := 2
+	if math.Abs(q1) < math.Abs(q0) {
+		p0, p1, q0, q1 = p1, p0, q1, q0
+	}
+	for iter := 1; iter <= maxIter; iter++ {
+		if q1 == 0 {
+			return ScalarResult{X: p1, Iterations: iter - 1, FuncEvals: evals, Converged: true}
+		}
+		if q1 == q0 {
+			return ScalarResult{X: p1, F: q1, Iterations: iter - 1, FuncEvals: evals}
+		}
+		next := p1 - q1*(p1-p0)/(q1-q0)
+		p0, q0 = p1, q1
+		p1 = next
+		q1 = f(p1)
+		evals++
+		if math.Abs(p1-p0) <= xTol+rTol*math.Abs(p1) {
+			return ScalarResult{X: p1, F: q1, Iterations: iter, FuncEvals: evals, Converged: true}
+		}
+	}
+	return ScalarResult{X: p1, F: q1, Iterations: maxIter, FuncEvals: evals}
+}
+
+// MinimizeScalar finds a local minimum of f in the interval [a, b] with
+// Brent's method (the default) or golden-section search, like
+// scipy.optimize.minimize_scalar with method="bounded"
+func MinimizeScalar(f func(float64) float64, a, b float64, opts ScalarOptions) ScalarResult {
+	if !(a < b) {
+		panic(fmt.Sprintf("MinimizeScalar requires a < b, got [%v, %v]", a, b))
+	}
+	xTol, rTol, maxIter := opts.XTol, opts.RTol, opts.MaxIter
+	if xTol <= 0 {
+		xTol = 1e-5
+	}
+	if rTol <= 0 {
+		rTol = math.Sqrt(2.220446049250313e-16)
+	}
+	if maxIter <= 0 {
+		maxIter = 500
+	}
+	
+	switch opts.Method {
+	case Brent:
+		return brentMinimize(f, a, b, xTol, rTol, maxIter)
+	case Golden:
+		return goldenMinimize(f, a, b, xTol, rTol, maxIter)
+	default:
+		panic(fmt.Sprintf("unknown scalar method %d", int(opts.Method)))
+	}
+}
+
+// goldenRatio is (3 - sqrt(5)) / 2, the fraction of an interval at which
+// golden-section search places its probe
+const goldenRatio = 0.3819660112501051
+
+// goldenMinimize narrows [a, b] by the golden ratio each iteration
+func goldenMinimize(f func(float64) float64, a, b, xTol, rTol float64, maxIter int) ScalarResult {
+	x1 := a + goldenRatio*(b-a)
+	x2 := b - goldenRatio*(b-a)
+	f1, f2 := f(x1), f(x2)
+	evals := 2
+	for iter := 1; iter <= maxIter; iter++ {
+		if f1 < f2 {
+			b, x2, f2 = x2, x1, f1
+			x1 = a + goldenRatio*(b-a)
+			f1 = f(x1)
+		} else {
+			a, x1, f1 = x1, x2, f2
+			x2 = b - goldenRatio*(b-a)
+			f2 = f(x2)
+		}
+		evals++
+		
+		x, fx := x2, f2
+		if f1 < f2 {
+			x, fx = x1, f1
+		}
+		if b-a <= 2*(xTol+rTol*math.Abs(x)) {
+			return ScalarResult{X: x, F: fx, Iterations: iter, FuncEvals: evals, Converged: true}
+		}
+	}
+	x, fx := x2, f2
+	if f1 < f2 {
+		x, fx = x1, f1
+	}
+	return ScalarResult{X: x, F: fx, Iterations: maxIter, FuncEvals: evals}
+}
+
+// brentMinimize is Brent's localmin: parabolic interpolation through the
+// three best points, falling back to golden-section steps
+func brentMinimize(f func(float64) float64, a, b, xTol, rTol float64, maxIter int) ScalarResult {
+	x := a + goldenRatio*(b-a)
+	w, v := x, x
+	fx := f(x)
+	fw, fv := fx, fx
+	evals := 1
+	d, e := 0.0, 0.0
+	
+	for iter := 1; iter <= maxIter; iter++ {
+		mid := (a + b) / 2
+		tol1 := rTol*math.Abs(x) + xTol/3
+		tol2 := 2 * tol1
+		if math.Abs(x-mid) <= tol2-(b-a)/2 {
+			return ScalarResult{X: x, F: fx, Iterations: iter - 1, FuncEvals: evals, Converged: true}
+		}
+		
+		golden := true
+		if math.Abs(e) > tol1 {
+			// Fit a parabola through x, v and w
+			r := (x - w) * (fx - fv)
+			q := (x - v) * (fx - fw)
+			p := (x-v)*q - (x-w)*r
+			q = 2 * (q - r)
+			if q > 0 {
+				p = -p
+			}
+			q = math.Abs(q)
+			r, e = e, d
+			if math.Abs(p) < math.Abs(q*r/2) && p > q*(a-x) && p < q*(b-x) {
+				d = p / q
+				u := x + d
+				if u-a < tol2 || b-u < tol2 {
+					d = math.Copysign(tol1, mid-x)
+				}
+				golden = false
+			}
+		}
+		if golden {
+			if x >= mid {
+				e = a - x
+			} else {
+				e = b - x
+			}
+			d = goldenRatio * e
+		}
+		
+		u := x + d
+		if math.Abs(d) < tol1 {
+			u = x + math.Copysign(tol1, d)
+		}
+		fu := f(u)
+		evals++
+		
+		if fu <= fx {
+			if u >= x {
+				a = x
+			} else {
+				b = x
+			}
+			v, fv = w, fw
+			w, fw = x, fx
+			x, fx = u, fu
+		} else {
+			if u < x {
+				a = u
+			} else {
+				b = u
+			}
+			if fu <= fw || w == x {
+				v, fv = w, fw
+				w, fw = u, fu
+			} else if fu <= fv || v == x || v == w {
+				v, fv = u, fu
+			}
+		}
+	}
+	return ScalarResult{X: x, F: fx, Iterations: maxIter, FuncEvals: evals}
+}
@@ -0,0 +1,198 @@
+package polynomial
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/linalg"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// basis describes a family of polynomials B_0 = 1, B_1, B_2, ... through
+// its three-term recurrence
+//
+//	B_{k+1}(x) = alpha(k) x B_k(x) - beta(k) B_{k-1}(x)
+//
+// which is all that evaluation, conversion and fitting need
+type basis struct {
+	alpha func(k int) float64
+	beta  func(k int) float64
+	// mulx returns the coefficients of x times the series c
+	mulx func(c []float64) []float64
+}
+
+var powerBasis = basis{
+	alpha: func(int) float64 { return 1 },
+	beta:  func(int) float64 { return 0 },
+	mulx: func(c []float64) []float64 {
+		return append([]float64{0}, c...)
+	},
+}
+
+// chebyshevBasis: T_{k+1} = 2x T_k - T_{k-1}, with T_1 = x
+var chebyshevBasis = basis{
+	alpha: func(k int) float64 {
+		if k == 0 {
+			return 1
+		}
+		return 2
+	},
+	beta: func(k int) float64 {
+		if k == 0 {
+			return 0
+		}
+		return 1
+	},
+	mulx: func(c []float64) []float64 {
+		// x T_0 = T_1 and x T_k = (T_{k+1} + T_{k-1}) / 2
+		out := make([]float64, len(c)+1)
+		for k, v := range c {
+			if k == 0 {
+				out[1] += v
+				continue
+			}
+			out[k+1] += v / 2
+			out[k-1] += v / 2
+		}
+		return out
+	},
+}
+
+// legendreBasis: (k+1) P_{k+1} = (2k+1) x P_k - k P_{k-1}
+var legendreBasis = basis{
+	alpha: func(k int) float64 { return float64(2*k+1) / float64(k+1) },
+	beta:  func(k int) float64 { return float64(k) / float64(k+1) },
+	mulx: func(c []float64) []float64 {
+		// x P_k = ((k+1) P_{k+1} + k P_{k-1}) / (2k+1)
+		out := make([]float64, len(c)+1)
+		for k, v := range c {
+			d := float64(2*k + 1)
+			out[k+1] += v * float64(k+1) / d
+			if k > 0 {
+				out[k-1] += v * float64(k) / d
+			}
+		}
+		return out
+	},
+}
+
+// clenshaw evaluates the series sum c[k] B_k(x) with Clenshaw's
+// recurrence, which is numerically stable for orthogonal bases
+func (b basis) clenshaw(c []float64, x float64) float64 {
+	var b1, b2 float64
+	for k := len(c) - 1; k >= 0; k-- {
+		b1, b2 = c[k]+b.alpha(k)*x*b1-b.beta(k+1)*b2, b1
+	}
+	return b1
+}
+
+// convert re-expresses the series c from basis from in basis to, building
+// each from-polynomial in the target basis by its recurrence
+func convert(c []float64, from, to basis) []float64 {
+	out := make([]float64, len(c))
+	if len(c) == 0 {
+		return out
+	}
+	prev, cur := []float64(nil), []float64{1}
+	for k, v := range c {
+		for i, w := range cur {
+			out[i] += v * w
+		}
+		if k == len(c)-1 {
+			break
+		}
+		next := to.mulx(cur)
+		for i := range next {
+			next[i] *= from.alpha(k)
+		}
+		for i, w := range prev {
+			next[i] -= from.beta(k) * w
+		}
+		prev, cur = cur, next
+	}
+	return out
+}
+
+// addCoef returns the coefficient-wise sum a + s*b
+func addCoef(a, b []float64, s float64) []float64 {
+	c := make([]float64, max(len(a), len(b)))
+	copy(c, a)
+	for i, v := range b {
+		c[i] += s * v
+	}
+	return c
+}
+
+// scaleCoef returns s*c
+func scaleCoef(c []float64, s float64) []float64 {
+	out := make([]float64, len(c))
+	for i, v := range c {
+		out[i] = s * v
+	}
+	return out
+}
+
+// evalArray evaluates f at every element of x
+func evalArray(x *tensor.NDArray, f func(float64) float64) *tensor.NDArray {
+	values := x.ToSliceFloat64()
+	for i, v := range values {
+		values[i] = f(v)
+	}
+	return tensor.FromSliceFloat64(values, x.Shape()...)
+}
+
+// fitBasis returns the coefficients of the degree deg least-squares fit of
+// y by a series in basis b. The pseudo-Vandermonde matrix columns are
+// scaled to unit norm before solving through its pseudo-inverse, which
+// keeps higher degrees well conditioned.
+func fitBasis(name string, x, y *tensor.NDArray, deg int, b basis) []float64 {
+	if x.Ndim() != 1 || y.Ndim() != 1 {
+		panic(fmt.Sprintf("%s requires 1D x and y", name))
+	}
+	if x.Size() != y.Size() {
+		panic(fmt.Sprintf("x and y must have the same length: %d vs %d", x.Size(), y.Size()))
+	}
+	if deg < 0 {
+		panic(fmt.Sprintf("%s requires a non-negative degree", name))
+	}
+	m, n := x.Size(), deg+1
+	if m == 0 {
+		panic(fmt.Sprintf("%s requires at least one point", name))
+	}
+	
+	vander := make([]float64, m*n)
+	for i, xi := range x.ToSliceFloat64() {
+		prev, cur := 0.0, 1.0
+		for k := 0; k < n; k++ {
+			vander[i*n+k] = cur
+			prev, cur = cur, b.alpha(k)*xi*cur-b.beta(k)*prev
+		}
+	}
+	
+	scale := make([]float64, n)
+	for k := 0; k < n; k++ {
+		sum := 0.0
+		for i := 0; i < m; i++ {
+			sum += vander[i*n+k] * vander[i*n+k]
+		}
+		scale[k] = math.Sqrt(sum)
+		if scale[k] == 0 {
+			scale[k] = 1
+		}
+		for i := 0; i < m; i++ {
+			vander[i*n+k] /= scale[k]
+		}
+	}
+	
+	pinv := linalg.Pinv(tensor.FromSliceFloat64(vander, m, n), 0)
+	ys := y.ToSliceFloat64()
+	coef := make([]float64, n)
+	for k := 0; k < n; k++ {
+		sum := 0.0
+		for i := 0; i < m; i++ {
+			sum += pinv.GetFloat64(k, i) * ys[i]
+		}
+		coef[k] = sum / scale[k]
+	}
+	return coef
+}
@@ -0,0 +1,65 @@
+package polynomial
+
+import (
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Chebyshev is a series c[0] T_0(x) + c[1] T_1(x) + ... in Chebyshev
+// polynomials of the first kind, T_k(cos t) = cos(k t). The basis is
+// orthogonal on [-1, 1], so fits and evaluations there stay well
+// conditioned at degrees where the power basis breaks down. Like
+// Polynomial, values are immutable and trailing zeros are trimmed.
+type Chebyshev struct {
+	coef []float64
+}
+
+// NewChebyshev creates a Chebyshev series from its coefficients, lowest
+// degree first
+func NewChebyshev(coef ...float64) Chebyshev {
+	return Chebyshev{coef: trim(append([]float64{}, coef...))}
+}
+
+// Coef returns a copy of the coefficients, lowest degree first
+func (c Chebyshev) Coef() []float64 {
+	return Polynomial(c).Coef()
+}
+
+// Degree returns the degree of the series
+func (c Chebyshev) Degree() int {
+	return Polynomial(c).Degree()
+}
+
+// Eval evaluates the series at x using Clenshaw's recurrence
+func (c Chebyshev) Eval(x float64) float64 {
+	return chebyshevBasis.clenshaw(c.coef, x)
+}
+
+// EvalArray evaluates the series at every element of x
+func (c Chebyshev) EvalArray(x *tensor.NDArray) *tensor.NDArray {
+	return evalArray(x, c.Eval)
+}
+
+// Add returns c + d
+func (c Chebyshev) Add(d Chebyshev) Chebyshev {
+	return Chebyshev{coef: trim(addCoef(c.coef, d.coef, 1))}
+}
+
+// Sub returns c - d
+func (c Chebyshev) Sub(d Chebyshev) Chebyshev {
+	return Chebyshev{coef: trim(addCoef(c.coef, d.coef, -1))}
+}
+
+// Scale returns s * c
+func (c Chebyshev) Scale(s float64) Chebyshev {
+	return Chebyshev{coef: trim(scaleCoef(c.coef, s))}
+}
+
+// ToPolynomial returns the series expressed in the power basis
+func (c Chebyshev) ToPolynomial() Polynomial {
+	return Polynomial{coef: trim(convert(c.coef, chebyshevBasis, powerBasis))}
+}
+
+// ToLegendre returns the series expressed as a Legendre series
+func (c Chebyshev) ToLegendre() Legendre {
+	return Legendre{coef: trim(convert(c.coef, chebyshevBasis, legendreBasis))}
+}
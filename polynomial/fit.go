@@ -0,0 +1,37 @@
+package polynomial
+
+import (
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Polyval evaluates the power series with coefficients c (lowest degree
+// first) at every element of x
+func Polyval(x, c *tensor.NDArray) *tensor.NDArray {
+	return New(c.ToSliceFloat64()...).EvalArray(x)
+}
+
+// Polyfit returns the coefficients (lowest degree first) of the degree deg
+// polynomial that fits the points (x[i], y[i]) in the least-squares sense.
+// For high degrees, FitChebyshev or FitLegendre on data scaled to [-1, 1]
+// is better conditioned.
+func Polyfit(x, y *tensor.NDArray, deg int) *tensor.NDArray {
+	return tensor.FromSliceFloat64(fitBasis("Polyfit", x, y, deg, powerBasis), deg+1)
+}
+
+// Fit returns the degree deg least-squares polynomial through the points
+// (x[i], y[i]); see Polyfit
+func Fit(x, y *tensor.NDArray, deg int) Polynomial {
+	return New(fitBasis("Fit", x, y, deg, powerBasis)...)
+}
+
+// FitChebyshev returns the degree deg least-squares Chebyshev series
+// through the points (x[i], y[i])
+func FitChebyshev(x, y *tensor.NDArray, deg int) Chebyshev {
+	return NewChebyshev(fitBasis("FitChebyshev", x, y, deg, chebyshevBasis)...)
+}
+
+// FitLegendre returns the degree deg least-squares Legendre series through
+// the points (x[i], y[i])
+func FitLegendre(x, y *tensor.NDArray, deg int) Legendre {
+	return NewLegendre(fitBasis("FitLegendre", x, y, deg, legendreBasis)...)
+}
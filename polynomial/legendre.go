@@ -0,0 +1,63 @@
+package polynomial
+
+import (
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Legendre is a series c[0] P_0(x) + c[1] P_1(x) + ... in Legendre
+// polynomials, which are orthogonal on [-1, 1] with unit weight. Like
+// Polynomial, values are immutable and trailing zeros are trimmed.
+type Legendre struct {
+	coef []float64
+}
+
+// NewLegendre creates a Legendre series from its coefficients, lowest
+// degree first
+func NewLegendre(coef ...float64) Legendre {
+	return Legendre{coef: trim(append([]float64{}, coef...))}
+}
+
+// Coef returns a copy of the coefficients, lowest degree first
+func (l Legendre) Coef() []float64 {
+	return Polynomial(l).Coef()
+}
+
+// Degree returns the degree of the series
+func (l Legendre) Degree() int {
+	return Polynomial(l).Degree()
+}
+
+// Eval evaluates the series at x using Clenshaw's recurrence
+func (l Legendre) Eval(x float64) float64 {
+	return legendreBasis.clenshaw(l.coef, x)
+}
+
+// EvalArray evaluates the series at every element of x
+func (l Legendre) EvalArray(x *tensor.NDArray) *tensor.NDArray {
+	return evalArray(x, l.Eval)
+}
+
+// Add returns l + m
+func (l Legendre) Add(m Legendre) Legendre {
+	return Legendre{coef: trim(addCoef(l.coef, m.coef, 1))}
+}
+
+// Sub returns l - m
+func (l Legendre) Sub(m Legendre) Legendre {
+	return Legendre{coef: trim(addCoef(l.coef, m.coef, -1))}
+}
+
+// Scale returns s * l
+func (l Legendre) Scale(s float64) Legendre {
+	return Legendre{coef: trim(scaleCoef(l.coef, s))}
+}
+
+// ToPolynomial returns the series expressed in the power basis
+func (l Legendre) ToPolynomial() Polynomial {
+	return Polynomial{coef: trim(convert(l.coef, legendreBasis, powerBasis))}
+}
+
+// ToChebyshev returns the series expressed as a Chebyshev series
+func (l Legendre) ToChebyshev() Chebyshev {
+	return Chebyshev{coef: trim(convert(l.coef, legendreBasis, chebyshevBasis))}
+}
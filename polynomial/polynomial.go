@@ -0,0 +1,217 @@
+// Package polynomial provides polynomial series and fitting for NumGo arrays
+package polynomial
+
+import (
+	"fmt"
+	"strings"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Polynomial is a power series c[0] + c[1] x + ... + c[n] x^n with real
+// coefficients. Coefficients are stored lowest degree first, as in
+// numpy.polynomial, and trailing zeros are trimmed. The zero value is the
+// zero polynomial. Polynomials are immutable; every operation returns a
+// new value.
+type Polynomial struct {
+	coef []float64
+}
+
+// New creates a polynomial from its coefficients, lowest degree first
+func New(coef ...float64) Polynomial {
+	return Polynomial{coef: trim(append([]float64{}, coef...))}
+}
+
+// trim drops trailing zero coefficients, returning nil for the zero
+// polynomial
+func trim(c []float64) []float64 {
+	n := len(c)
+	for n > 0 && c[n-1] == 0 {
+		n--
+	}
+	if n == 0 {
+		return nil
+	}
+	return c[:n]
+}
+
+// Coef returns a copy of the coefficients, lowest degree first. The zero
+// polynomial has the single coefficient 0.
+func (p Polynomial) Coef() []float64 {
+	if len(p.coef) == 0 {
+		return []float64{0}
+	}
+	return append([]float64{}, p.coef...)
+}
+
+// Degree returns the degree of p; the zero polynomial has degree 0
+func (p Polynomial) Degree() int {
+	return max(len(p.coef)-1, 0)
+}
+
+// Eval evaluates p at x using Horner's method
+func (p Polynomial) Eval(x float64) float64 {
+	return horner(p.coef, x)
+}
+
+// EvalArray evaluates p at every element of x, returning a float64 array
+// of the same shape
+func (p Polynomial) EvalArray(x *tensor.NDArray) *tensor.NDArray {
+	return evalArray(x, p.Eval)
+}
+
+// horner evaluates the power series c at x
+func horner(c []float64, x float64) float64 {
+	sum := 0.0
+	for i := len(c) - 1; i >= 0; i-- {
+		sum = sum*x + c[i]
+	}
+	return sum
+}
+
+// Add returns p + q
+func (p Polynomial) Add(q Polynomial) Polynomial {
+	return Polynomial{coef: trim(addCoef(p.coef, q.coef, 1))}
+}
+
+// Sub returns p - q
+func (p Polynomial) Sub(q Polynomial) Polynomial {
+	return Polynomial{coef: trim(addCoef(p.coef, q.coef, -1))}
+}
+
+// Scale returns s * p
+func (p Polynomial) Scale(s float64) Polynomial {
+	return Polynomial{coef: trim(scaleCoef(p.coef, s))}
+}
+
+// Mul returns p * q
+func (p Polynomial) Mul(q Polynomial) Polynomial {
+	if len(p.coef) == 0 || len(q.coef) == 0 {
+		return Polynomial{}
+	}
+	c := make([]float64, len(p.coef)+len(q.coef)-1)
+	for i, a := range p.coef {
+		for j, b := range q.coef {
+			c[i+j] += a * b
+		}
+	}
+	return Polynomial{coef: trim(c)}
+}
+
+// DivMod divides p by q, returning the quotient and remainder such that
+// p = quo*q + rem with deg(rem) < deg(q). It panics if q is zero.
+func (p Polynomial) DivMod(q Polynomial) (quo, rem Polynomial) {
+	if len(q.coef) == 0 {
+		panic("polynomial division by zero")
+	}
+	if len(p.coef) < len(q.coef) {
+		return Polynomial{}, p
+	}
+	
+	r := append([]float64{}, p.coef...)
+	dq := len(q.coef) - 1
+	lead := q.coef[dq]
+	c := make([]float64, len(r)-dq)
+	for i := len(c) - 1; i >= 0; i-- {
+		c[i] = r[i+dq] / lead
+		for j, v := range q.coef {
+			r[i+j] -= c[i] * v
+		}
+	}
+	return Polynomial{coef: trim(c)}, Polynomial{coef: trim(r[:dq])}
+}
+
+// Pow returns p raised to the non-negative integer power n
+func (p Polynomial) Pow(n int) Polynomial {
+	if n < 0 {
+		panic("Pow requires a non-negative power")
+	}
+	result := New(1)
+	base := p
+	for n > 0 {
+		if n&1 == 1 {
+			result = result.Mul(base)
+		}
+		base = base.Mul(base)
+		n >>= 1
+	}
+	return result
+}
+
+// Deriv returns the m-th derivative of p
+func (p Polynomial) Deriv(m int) Polynomial {
+	if m < 0 {
+		panic("Deriv requires a non-negative order")
+	}
+	c := append([]float64{}, p.coef...)
+	for ; m > 0 && len(c) > 0; m-- {
+		for i := 1; i < len(c); i++ {
+			c[i-1] = float64(i) * c[i]
+		}
+		c = c[:len(c)-1]
+	}
+	return Polynomial{coef: trim(c)}
+}
+
+// Integ returns the m-th antiderivative of p. The optional k gives the
+// integration constant for each order (the value of the i-th integral at
+// x = 0); missing constants are zero.
+func (p Polynomial) Integ(m int, k ...float64) Polynomial {
+	if m < 0 {
+		panic("Integ requires a non-negative order")
+	}
+	if len(k) > m {
+		panic(fmt.Sprintf("got %d integration constants for order %d", len(k), m))
+	}
+	c := append([]float64{}, p.coef...)
+	for i := 0; i < m; i++ {
+		next := make([]float64, len(c)+1)
+		for j, v := range c {
+			next[j+1] = v / float64(j+1)
+		}
+		if i < len(k) {
+			next[0] = k[i]
+		}
+		c = next
+	}
+	return Polynomial{coef: trim(c)}
+}
+
+// ToChebyshev returns p expressed as a Chebyshev series
+func (p Polynomial) ToChebyshev() Chebyshev {
+	return Chebyshev{coef: trim(convert(p.coef, powerBasis, chebyshevBasis))}
+}
+
+// ToLegendre returns p expressed as a Legendre series
+func (p Polynomial) ToLegendre() Legendre {
+	return Legendre{coef: trim(convert(p.coef, powerBasis, legendreBasis))}
+}
+
+// String formats p as, for example, "1 - 2x + 0.5x^2"
+func (p Polynomial) String() string {
+	if len(p.coef) == 0 {
+		return "0"
+	}
+	var b strings.Builder
+	for i, v := range p.coef {
+		if v == 0 && len(p.coef) > 1 {
+			continue
+		}
+		switch {
+		case b.Len() == 0:
+			fmt.Fprintf(&b, "%g", v)
+		case v < 0:
+			fmt.Fprintf(&b, " - %g", -v)
+		default:
+			fmt.Fprintf(&b, " + %g", v)
+		}
+		switch i {
+		case 0:
+		case 1:
+			b.WriteString("x")
+		default:
+			fmt.Fprintf(&b, "x^%d", i)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,247 @@
+package polynomial
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func coefClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEval(t *testing.T) {
+	p := New(1, -2, 3) // 1 - 2x + 3x^2
+	if got := p.Eval(2); got != 9 {
+		t.Errorf("expected 9, got %f", got)
+	}
+	if p.Degree() != 2 {
+		t.Errorf("expected degree 2, got %d", p.Degree())
+	}
+	
+	x := tensor.FromSliceFloat64([]float64{0, 1, 2, -1}, 2, 2)
+	got := p.EvalArray(x)
+	want := []float64{1, 2, 9, 6}
+	if !coefClose(got.ToSliceFloat64(), want, 0) {
+		t.Errorf("expected %v, got %v", want, got.ToSliceFloat64())
+	}
+	if s := got.Shape(); s[0] != 2 || s[1] != 2 {
+		t.Errorf("expected shape [2 2], got %v", s)
+	}
+	
+	c := tensor.FromSliceFloat64([]float64{1, -2, 3}, 3)
+	if v := Polyval(x, c).ToSliceFloat64(); !coefClose(v, want, 0) {
+		t.Errorf("Polyval: expected %v, got %v", want, v)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	p := New(1, 2)     // 1 + 2x
+	q := New(-1, 0, 1) // x^2 - 1
+	
+	if c := p.Add(q).Coef(); !coefClose(c, []float64{0, 2, 1}, 0) {
+		t.Errorf("Add: got %v", c)
+	}
+	if c := q.Sub(q).Coef(); !coefClose(c, []float64{0}, 0) {
+		t.Errorf("Sub: expected the zero polynomial, got %v", c)
+	}
+	if c := p.Mul(q).Coef(); !coefClose(c, []float64{-1, -2, 1, 2}, 0) {
+		t.Errorf("Mul: got %v", c)
+	}
+	if c := p.Pow(3).Coef(); !coefClose(c, []float64{1, 6, 12, 8}, 0) {
+		t.Errorf("Pow: got %v", c)
+	}
+	
+	quo, rem := p.Mul(q).Add(New(3)).DivMod(q)
+	if !coefClose(quo.Coef(), []float64{1, 2}, 1e-12) || !coefClose(rem.Coef(), []float64{3}, 1e-12) {
+		t.Errorf("DivMod: got quo %v rem %v", quo.Coef(), rem.Coef())
+	}
+	
+	if s := New(1, -2, 0, 0.5).String(); s != "1 - 2x + 0.5x^3" {
+		t.Errorf("String: got %q", s)
+	}
+}
+
+func TestCalculus(t *testing.T) {
+	p := New(1, 2, 3) // 1 + 2x + 3x^2
+	if c := p.Deriv(1).Coef(); !coefClose(c, []float64{2, 6}, 0) {
+		t.Errorf("Deriv(1): got %v", c)
+	}
+	if c := p.Deriv(3).Coef(); !coefClose(c, []float64{0}, 0) {
+		t.Errorf("Deriv(3): got %v", c)
+	}
+	if c := p.Integ(1, 5).Coef(); !coefClose(c, []float64{5, 1, 1, 1}, 1e-15) {
+		t.Errorf("Integ(1, 5): got %v", c)
+	}
+	if c := p.Integ(2).Deriv(2).Coef(); !coefClose(c, p.Coef(), 1e-15) {
+		t.Errorf("Deriv(Integ(p)) != p: got %v", c)
+	}
+}
+
+func TestRoots(t *testing.T) {
+	p := FromRoots(-1, 2, 3)
+	roots := p.Roots()
+	want := []complex128{-1, 2, 3}
+	if len(roots) != len(want) {
+		t.Fatalf("expected %d roots, got %d", len(want), len(roots))
+	}
+	for i := range want {
+		if cmplx.Abs(roots[i]-want[i]) > 1e-10 {
+			t.Errorf("root %d: expected %v, got %v", i, want[i], roots[i])
+		}
+	}
+	
+	// x^2 + 1 has roots -i and i
+	roots = New(1, 0, 1).Roots()
+	if len(roots) != 2 || cmplx.Abs(roots[0]+1i) > 1e-12 || cmplx.Abs(roots[1]-1i) > 1e-12 {
+		t.Errorf("expected [-i i], got %v", roots)
+	}
+	
+	if r := New(4, 2).Roots(); len(r) != 1 || r[0] != -2 {
+		t.Errorf("expected [-2], got %v", r)
+	}
+	if r := New(3).Roots(); len(r) != 0 {
+		t.Errorf("expected no roots for a constant, got %v", r)
+	}
+}
+
+func TestPolyfit(t *testing.T) {
+	xs := make([]float64, 20)
+	ys := make([]float64, 20)
+	for i := range xs {
+		xs[i] = float64(i) / 2
+		ys[i] = 2 - 3*xs[i] + 0.5*xs[i]*xs[i]
+	}
+	x := tensor.FromSliceFloat64(xs, 20)
+	y := tensor.FromSliceFloat64(ys, 20)
+	
+	coef := Polyfit(x, y, 2).ToSliceFloat64()
+	if !coefClose(coef, []float64{2, -3, 0.5}, 1e-9) {
+		t.Errorf("expected [2 -3 0.5], got %v", coef)
+	}
+	
+	// Least-squares line through points that are not collinear
+	line := Fit(tensor.FromSliceFloat64([]float64{0, 1, 2, 3}, 4), tensor.FromSliceFloat64([]float64{1, 2, 4, 5}, 4), 1)
+	if !coefClose(line.Coef(), []float64{0.9, 1.4}, 1e-12) {
+		t.Errorf("expected [0.9 1.4], got %v", line.Coef())
+	}
+}
+
+func TestChebyshevLegendreEval(t *testing.T) {
+	for _, x := range []float64{-1, -0.3, 0, 0.5, 1} {
+		// T_3(x) = 4x^3 - 3x and T_3(cos t) = cos(3t)
+		if got, want := NewChebyshev(0, 0, 0, 1).Eval(x), 4*x*x*x-3*x; math.Abs(got-want) > 1e-14 {
+			t.Errorf("T_3(%g): expected %g, got %g", x, want, got)
+		}
+		// P_2(x) = (3x^2 - 1) / 2
+		if got, want := NewLegendre(0, 0, 1).Eval(x), (3*x*x-1)/2; math.Abs(got-want) > 1e-14 {
+			t.Errorf("P_2(%g): expected %g, got %g", x, want, got)
+		}
+	}
+	
+	c := NewChebyshev(1, 2, 3)
+	x := tensor.FromSliceFloat64([]float64{-0.5, 0.25}, 2)
+	got := c.Add(c.Scale(-0.5)).EvalArray(x).ToSliceFloat64()
+	for i, xi := range x.ToSliceFloat64() {
+		if want := 0.5 * c.Eval(xi); math.Abs(got[i]-want) > 1e-14 {
+			t.Errorf("EvalArray: index %d expected %g, got %g", i, want, got[i])
+		}
+	}
+}
+
+func TestBasisConversion(t *testing.T) {
+	p := New(1, -2, 0.5, 3, -1)
+	
+	if c := p.ToChebyshev().ToPolynomial().Coef(); !coefClose(c, p.Coef(), 1e-12) {
+		t.Errorf("power -> Chebyshev -> power: got %v", c)
+	}
+	if c := p.ToLegendre().ToPolynomial().Coef(); !coefClose(c, p.Coef(), 1e-12) {
+		t.Errorf("power -> Legendre -> power: got %v", c)
+	}
+	if c := p.ToChebyshev().ToLegendre().ToPolynomial().Coef(); !coefClose(c, p.Coef(), 1e-12) {
+		t.Errorf("Chebyshev -> Legendre: got %v", c)
+	}
+	
+	// x^2 = (T_0 + T_2) / 2 = (P_0 + 2 P_2) / 3
+	sq := New(0, 0, 1)
+	if c := sq.ToChebyshev().Coef(); !coefClose(c, []float64{0.5, 0, 0.5}, 1e-15) {
+		t.Errorf("x^2 in Chebyshev basis: got %v", c)
+	}
+	if c := sq.ToLegendre().Coef(); !coefClose(c, []float64{1.0 / 3, 0, 2.0 / 3}, 1e-15) {
+		t.Errorf("x^2 in Legendre basis: got %v", c)
+	}
+	
+	for _, x := range []float64{-0.9, 0.1, 0.7} {
+		l := p.ToLegendre()
+		if math.Abs(l.Eval(x)-p.Eval(x)) > 1e-12 {
+			t.Errorf("Legendre form differs from power form at %g", x)
+		}
+	}
+}
+
+func TestFitChebyshevHighDegree(t *testing.T) {
+	// Interpolate exp on 60 Chebyshev points with a degree 30 series; the
+	// power basis is hopelessly ill-conditioned here
+	n := 60
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	for i := range xs {
+		xs[i] = math.Cos(math.Pi * (float64(i) + 0.5) / float64(n))
+		ys[i] = math.Exp(xs[i])
+	}
+	x := tensor.FromSliceFloat64(xs, n)
+	y := tensor.FromSliceFloat64(ys, n)
+	
+	c := FitChebyshev(x, y, 30)
+	l := FitLegendre(x, y, 30)
+	for _, v := range []float64{-1, -0.37, 0.2, 0.99} {
+		if math.Abs(c.Eval(v)-math.Exp(v)) > 1e-12 {
+			t.Errorf("Chebyshev fit at %g: error %g", v, c.Eval(v)-math.Exp(v))
+		}
+		if math.Abs(l.Eval(v)-math.Exp(v)) > 1e-12 {
+			t.Errorf("Legendre fit at %g: error %g", v, l.Eval(v)-math.Exp(v))
+		}
+	}
+}
+
+func TestGaussQuadrature(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 20, 64} {
+		x, w := LegGauss(n)
+		xs, ws := x.ToSliceFloat64(), w.ToSliceFloat64()
+		for i := 1; i < n; i++ {
+			if xs[i] <= xs[i-1] {
+				t.Fatalf("LegGauss(%d): nodes not ascending", n)
+			}
+		}
+		// Exact for x^k with k <= 2n-1: integral is 2/(k+1) for even k
+		for k := 0; k <= 2*n-1; k += 2 {
+			sum := 0.0
+			for i := range xs {
+				sum += ws[i] * math.Pow(xs[i], float64(k))
+			}
+			if math.Abs(sum-2/float64(k+1)) > 1e-13 {
+				t.Errorf("LegGauss(%d): integral of x^%d is %g", n, k, sum)
+			}
+		}
+	}
+	
+	// integral of x^2 / sqrt(1 - x^2) over [-1, 1] is pi/2
+	x, w := ChebGauss(4)
+	sum := 0.0
+	for i, xi := range x.ToSliceFloat64() {
+		sum += w.ToSliceFloat64()[i] * xi * xi
+	}
+	if math.Abs(sum-math.Pi/2) > 1e-14 {
+		t.Errorf("ChebGauss: expected pi/2, got %g", sum)
+	}
+}
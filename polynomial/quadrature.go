@@ -0,0 +1,71 @@
+package polynomial
+
+import (
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// LegGauss returns the n nodes (in ascending order) and weights of
+// Gauss-Legendre quadrature, which integrates polynomials of degree up to
+// 2n-1 on [-1, 1] exactly:
+//
+//	integral of f over [-1, 1] ~= sum_i w[i] f(x[i])
+//
+// The nodes are the roots of P_n, found by Newton's method from
+// Tricomi's initial approximations.
+func LegGauss(n int) (nodes, weights *tensor.NDArray) {
+	if n < 1 {
+		panic("LegGauss requires n >= 1")
+	}
+	x := make([]float64, n)
+	w := make([]float64, n)
+	for i := 0; i < (n+1)/2; i++ {
+		// Roots are symmetric; compute the positive ones, largest first
+		z := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var dp float64
+		for iter := 0; iter < 100; iter++ {
+			var p float64
+			p, dp = legendreWithDeriv(n, z)
+			dz := p / dp
+			z -= dz
+			if math.Abs(dz) <= 1e-16*math.Max(1, math.Abs(z)) {
+				break
+			}
+		}
+		_, dp = legendreWithDeriv(n, z)
+		weight := 2 / ((1 - z*z) * dp * dp)
+		x[i], x[n-1-i] = -z, z
+		w[i], w[n-1-i] = weight, weight
+	}
+	if n%2 == 1 {
+		x[n/2] = 0
+	}
+	return tensor.FromSliceFloat64(x, n), tensor.FromSliceFloat64(w, n)
+}
+
+// legendreWithDeriv returns P_n(x) and P_n'(x)
+func legendreWithDeriv(n int, x float64) (float64, float64) {
+	prev, cur := 1.0, x
+	for k := 1; k < n; k++ {
+		prev, cur = cur, (float64(2*k+1)*x*cur-float64(k)*prev)/float64(k+1)
+	}
+	// (1 - x^2) P_n' = n (P_{n-1} - x P_n)
+	return cur, float64(n) * (prev - x*cur) / (1 - x*x)
+}
+
+// ChebGauss returns the n nodes (in ascending order) and weights of
+// Gauss-Chebyshev quadrature, which integrates f(x) / sqrt(1 - x^2) on
+// [-1, 1] exactly for polynomials f of degree up to 2n-1
+func ChebGauss(n int) (nodes, weights *tensor.NDArray) {
+	if n < 1 {
+		panic("ChebGauss requires n >= 1")
+	}
+	x := make([]float64, n)
+	w := make([]float64, n)
+	for i := range x {
+		x[i] = -math.Cos(math.Pi * float64(2*i+1) / float64(2*n))
+		w[i] = math.Pi / float64(n)
+	}
+	return tensor.FromSliceFloat64(x, n), tensor.FromSliceFloat64(w, n)
+}
@@ -0,0 +1,57 @@
+package polynomial
+
+import (
+	"sort"
+	
+	"github.com/iSundram/NumGo/linalg"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Companion returns the companion matrix of p, whose eigenvalues are the
+// roots of p. It panics if p has degree less than 1.
+func (p Polynomial) Companion() *tensor.NDArray {
+	n := len(p.coef) - 1
+	if n < 1 {
+		panic("Companion requires a polynomial of degree at least 1")
+	}
+	lead := p.coef[n]
+	data := make([]float64, n*n)
+	for i := 1; i < n; i++ {
+		data[i*n+i-1] = 1
+	}
+	for i := 0; i < n; i++ {
+		data[i*n+n-1] = -p.coef[i] / lead
+	}
+	return tensor.FromSliceFloat64(data, n, n)
+}
+
+// Roots returns the roots of p, computed as the eigenvalues of its
+// companion matrix and sorted by real part, then imaginary part. Repeated
+// roots are less accurate than simple ones. Constant polynomials have no
+// roots.
+func (p Polynomial) Roots() []complex128 {
+	switch len(p.coef) {
+	case 0, 1:
+		return nil
+	case 2:
+		return []complex128{complex(-p.coef[0]/p.coef[1], 0)}
+	}
+	
+	roots := linalg.Eigvals(p.Companion()).ToSliceComplex128()
+	sort.Slice(roots, func(i, j int) bool {
+		if real(roots[i]) != real(roots[j]) {
+			return real(roots[i]) < real(roots[j])
+		}
+		return imag(roots[i]) < imag(roots[j])
+	})
+	return roots
+}
+
+// FromRoots returns the monic polynomial with the given real roots
+func FromRoots(roots ...float64) Polynomial {
+	p := New(1)
+	for _, r := range roots {
+		p = p.Mul(New(-r, 1))
+	}
+	return p
+}
@@ -0,0 +1,81 @@
+package quant
+
+import (
+	"fmt"
+)
+
+// MatMul multiplies two quantized 2D arrays. Products are accumulated
+// exactly in integers and the result is quantized to the dtype of a with
+// parameters fitted to its range.
+func MatMul(a, b *QArray) *QArray {
+	return MatMulWith(a, b, QuantizeOptions{DType: a.Data.DType()})
+}
+
+// MatMulWith is MatMul with control over how the result is quantized
+func MatMulWith(a, b *QArray, opts QuantizeOptions) *QArray {
+	as, bs := a.Shape(), b.Shape()
+	if len(as) != 2 || len(bs) != 2 || as[1] != bs[0] {
+		panic(fmt.Sprintf("cannot multiply quantized arrays of shapes %v and %v", as, bs))
+	}
+	m, k, n := as[0], as[1], bs[1]
+	
+	// Subtract the zero points once so the inner loop is a plain dot product
+	qa, qb := a.ints(), b.ints()
+	for i := range qa {
+		qa[i] -= int64(a.ZeroPoint)
+	}
+	for i := range qb {
+		qb[i] -= int64(b.ZeroPoint)
+	}
+	out := make([]float64, m*n)
+	acc := make([]int64, n)
+	for i := 0; i < m; i++ {
+		clear(acc)
+		for p := 0; p < k; p++ {
+			x := qa[i*k+p]
+			if x == 0 {
+				continue
+			}
+			for j, y := range qb[p*n : (p+1)*n] {
+				acc[j] += x * y
+			}
+		}
+		for j, v := range acc {
+			out[i*n+j] = a.Scale * b.Scale * float64(v)
+		}
+	}
+	return quantize(out, []int{m, n}, opts)
+}
+
+// Add adds two quantized arrays of the same shape element by element. The
+// result is quantized to the dtype of a with parameters fitted to its range.
+func Add(a, b *QArray) *QArray {
+	return AddWith(a, b, QuantizeOptions{DType: a.Data.DType()})
+}
+
+// AddWith is Add with control over how the result is quantized
+func AddWith(a, b *QArray, opts QuantizeOptions) *QArray {
+	shape := a.Shape()
+	if !sameShape(shape, b.Shape()) {
+		panic(fmt.Sprintf("cannot add quantized arrays of shapes %v and %v", shape, b.Shape()))
+	}
+	qa, qb := a.ints(), b.ints()
+	out := make([]float64, len(qa))
+	for i := range out {
+		out[i] = a.Scale*float64(qa[i]-int64(a.ZeroPoint)) + b.Scale*float64(qb[i]-int64(b.ZeroPoint))
+	}
+	return quantize(out, shape, opts)
+}
+
+// sameShape reports whether two shapes are identical
+func sameShape(s1, s2 []int) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+	return true
+}
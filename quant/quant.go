@@ -0,0 +1,127 @@
+// Package quant provides affine-quantized int8 and uint8 arrays for running
+// compressed models on the CPU
+package quant
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// QArray is an affine-quantized array. Each stored integer q stands for the
+// real value Scale * (q - ZeroPoint).
+type QArray struct {
+	Data      *tensor.NDArray // Int8 or Uint8
+	Scale     float64
+	ZeroPoint int
+}
+
+// QuantizeOptions controls how real values are mapped to integers. The zero
+// value quantizes to Int8 with the scale and zero point fitted to the range
+// of the data.
+type QuantizeOptions struct {
+	// DType is Int8 or Uint8; the zero value selects Int8
+	DType tensor.DType
+	// Symmetric fits a range centred on zero, with a zero point of 0 for
+	// Int8 and 128 for Uint8, as usually done for weights
+	Symmetric bool
+	// Scale, if positive, is used with ZeroPoint instead of fitting them,
+	// e.g. to match the parameters a model was calibrated with
+	Scale     float64
+	ZeroPoint int
+}
+
+// Quantize converts a to Int8 with the scale and zero point fitted to its
+// range, which always includes zero so that zero is represented exactly
+func Quantize(a *tensor.NDArray) *QArray {
+	return QuantizeWith(a, QuantizeOptions{})
+}
+
+// QuantizeWith is Quantize with options
+func QuantizeWith(a *tensor.NDArray, opts QuantizeOptions) *QArray {
+	return quantize(a.ToSliceFloat64(), a.Shape(), opts)
+}
+
+// Shape returns the shape of the array
+func (q *QArray) Shape() []int {
+	return q.Data.Shape()
+}
+
+// Dequantize returns the real values as a Float64 array
+func (q *QArray) Dequantize() *tensor.NDArray {
+	values := q.ints()
+	out := make([]float64, len(values))
+	for i, v := range values {
+		out[i] = q.Scale * float64(v-int64(q.ZeroPoint))
+	}
+	return tensor.FromSliceFloat64(out, q.Shape()...)
+}
+
+// ints returns the stored integers
+func (q *QArray) ints() []int64 {
+	data := q.Data.Data()
+	out := make([]int64, len(data))
+	switch q.Data.DType() {
+	case tensor.Int8:
+		for i, b := range data {
+			out[i] = int64(int8(b))
+		}
+	case tensor.Uint8:
+		for i, b := range data {
+			out[i] = int64(b)
+		}
+	default:
+		panic(fmt.Sprintf("quantized data must be int8 or uint8, got %s", q.Data.DType()))
+	}
+	return out
+}
+
+// quantize maps real values to integers of the given shape
+func quantize(values []float64, shape []int, opts QuantizeOptions) *QArray {
+	dtype := opts.DType
+	if dtype == tensor.Bool {
+		dtype = tensor.Int8
+	}
+	var qmin, qmax float64
+	switch dtype {
+	case tensor.Int8:
+		qmin, qmax = math.MinInt8, math.MaxInt8
+	case tensor.Uint8:
+		qmin, qmax = 0, math.MaxUint8
+	default:
+		panic(fmt.Sprintf("quantized dtype must be int8 or uint8, got %s", dtype))
+	}
+	
+	scale, zero := opts.Scale, opts.ZeroPoint
+	if scale <= 0 {
+		lo, hi := 0.0, 0.0
+		for _, v := range values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				panic(fmt.Sprintf("cannot quantize %v", v))
+			}
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+		if opts.Symmetric {
+			scale, zero = math.Max(-lo, hi)/127, 0
+			if dtype == tensor.Uint8 {
+				zero = 128
+			}
+		} else {
+			scale = (hi - lo) / (qmax - qmin)
+		}
+		if scale == 0 {
+			scale = 1
+		}
+		if !opts.Symmetric {
+			zero = int(math.Max(qmin, math.Min(qmax, math.Round(qmin-lo/scale))))
+		}
+	}
+	
+	data := make([]byte, len(values))
+	for i, v := range values {
+		// Converting through int keeps the two's complement bits of int8
+		data[i] = byte(int(math.Max(qmin, math.Min(qmax, math.Round(v/scale)+float64(zero)))))
+	}
+	return &QArray{Data: tensor.WrapBytes(data, dtype, shape...), Scale: scale, ZeroPoint: zero}
+}
@@ -0,0 +1,76 @@
+package quant
+
+import (
+	"math"
+	"testing"
+	
+	"github.com/iSundram/NumGo/linalg"
+	"github.com/iSundram/NumGo/random"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// sliceClose reports whether two slices are element-wise within tol
+func sliceClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestQuantize(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{-1, 0, 0.5, 2.5, 3}, 5)
+	q := Quantize(a)
+	if q.Data.DType() != tensor.Int8 || q.Scale != 4.0/255 || q.ZeroPoint != -64 {
+		t.Errorf("unexpected parameters %s scale %v zero %d", q.Data.DType(), q.Scale, q.ZeroPoint)
+	}
+	back := q.Dequantize().ToSliceFloat64()
+	if !sliceClose(back, a.ToSliceFloat64(), q.Scale/2) || back[1] != 0 {
+		t.Errorf("round trip %v is not within half a step of %v", back, a.ToSliceFloat64())
+	}
+	
+	// Symmetric uint8 centres the range on 128
+	q = QuantizeWith(a, QuantizeOptions{DType: tensor.Uint8, Symmetric: true})
+	if q.ZeroPoint != 128 || q.Scale != 3.0/127 || q.Data.GetInt64(4) != 255 || q.Data.GetInt64(0) != 128-42 {
+		t.Errorf("unexpected symmetric uint8 %v scale %v", q.Data.ToSliceInt64(), q.Scale)
+	}
+	
+	// Fixed parameters clamp values outside the representable range
+	q = QuantizeWith(a, QuantizeOptions{Scale: 0.01, ZeroPoint: 0})
+	if q.Data.GetInt64(0) != -100 || q.Data.GetInt64(4) != 127 {
+		t.Errorf("expected clamping to [-128, 127], got %v", q.Data.ToSliceInt64())
+	}
+}
+
+func TestMatMulAdd(t *testing.T) {
+	rng := random.New(1)
+	a := rng.Uniform(-1, 1, 8, 16)
+	b := rng.Uniform(-2, 2, 16, 4)
+	qa, qb := Quantize(a), QuantizeWith(b, QuantizeOptions{Symmetric: true})
+	
+	want := linalg.MatMul(qa.Dequantize(), qb.Dequantize())
+	got := MatMul(qa, qb)
+	if !sameShape(got.Shape(), []int{8, 4}) {
+		t.Fatalf("expected shape (8, 4), got %v", got.Shape())
+	}
+	// Accumulation is exact, so only the final rounding differs
+	if !sliceClose(got.Dequantize().ToSliceFloat64(), want.ToSliceFloat64(), got.Scale/2+1e-12) {
+		t.Errorf("quantized product is more than half a step from %v", want.ToSliceFloat64())
+	}
+	
+	sum := Add(Quantize(b), qb)
+	if !sliceClose(sum.Dequantize().ToSliceFloat64(), b.MulScalar(2).ToSliceFloat64(), 0.05) {
+		t.Errorf("quantized sum is far from %v", b.MulScalar(2).ToSliceFloat64())
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for mismatched shapes")
+		}
+	}()
+	MatMul(qa, qa)
+}
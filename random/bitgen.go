@@ -0,0 +1,73 @@
+package random
+
+import (
+	"math/rand"
+)
+
+// BitGenerator is a source of uniformly distributed raw 64-bit values.
+// A Generator turns this stream into samples from distributions, so any
+// BitGenerator can be plugged in without touching the distribution code.
+type BitGenerator interface {
+	// Uint64 returns the next 64 random bits in the stream
+	Uint64() uint64
+}
+
+// Seeder is implemented by bit generators that can be reset to the start
+// of the stream for a seed
+type Seeder interface {
+	Seed(seed int64)
+}
+
+// Jumper is implemented by bit generators that can produce a copy of
+// themselves advanced far enough along the stream that the copies do not
+// overlap in practice
+type Jumper interface {
+	Jumped(jumps int) BitGenerator
+}
+
+// StdSource is a BitGenerator backed by the math/rand source. It is the
+// bit generator behind New, so seeded streams match earlier releases.
+type StdSource struct {
+	src   rand.Source64
+	seed  int64
+	draws uint64 // values drawn since seeding, for State
+}
+
+// NewStdSource creates a math/rand backed bit generator with the given seed
+func NewStdSource(seed int64) *StdSource {
+	return &StdSource{src: rand.NewSource(seed).(rand.Source64), seed: seed}
+}
+
+// Uint64 returns the next 64 random bits
+func (s *StdSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+// Seed resets the source to the stream for seed
+func (s *StdSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed, s.draws = seed, 0
+}
+
+// bitSource adapts a BitGenerator to rand.Source64 so that Generator can
+// reuse the math/rand algorithms for floats, integers and normals
+type bitSource struct {
+	bits BitGenerator
+}
+
+func (s bitSource) Int63() int64 {
+	return int64(s.bits.Uint64() & (1<<63 - 1))
+}
+
+func (s bitSource) Uint64() uint64 {
+	return s.bits.Uint64()
+}
+
+func (s bitSource) Seed(seed int64) {
+	seeder, ok := s.bits.(Seeder)
+	if !ok {
+		panic("bit generator does not support reseeding")
+	}
+	seeder.Seed(seed)
+}
@@ -0,0 +1,64 @@
+package random
+
+import (
+	"sync"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Bootstrap draws nResamples bootstrap samples of arr, each made of
+// arr.shape[0] rows chosen with replacement, and returns stat evaluated
+// on every sample. The spread of the result estimates the sampling
+// distribution of the statistic, e.g. for percentile confidence intervals.
+func (rng *Generator) Bootstrap(arr *tensor.NDArray, nResamples int, stat func(*tensor.NDArray) float64) *tensor.NDArray {
+	results := make([]float64, nResamples)
+	for i := range results {
+		results[i] = stat(rng.resampleRows(arr))
+	}
+	return tensor.FromSliceFloat64(results, nResamples)
+}
+
+// BootstrapParallel is Bootstrap spread over the given number of worker
+// goroutines. Each resample uses its own child generator from Spawn, so
+// the result is reproducible and does not depend on the number of
+// workers. stat must be safe to call concurrently, and the bit generator
+// must support jumping.
+func (rng *Generator) BootstrapParallel(arr *tensor.NDArray, nResamples int, stat func(*tensor.NDArray) float64, workers int) *tensor.NDArray {
+	if workers < 1 {
+		workers = 1
+	}
+	children := rng.Spawn(nResamples)
+	results := make([]float64, nResamples)
+	
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = stat(children[i].resampleRows(arr))
+			}
+		}()
+	}
+	for i := range results {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	
+	return tensor.FromSliceFloat64(results, nResamples)
+}
+
+// resampleRows returns arr.shape[0] rows of arr chosen with replacement
+func (rng *Generator) resampleRows(arr *tensor.NDArray) *tensor.NDArray {
+	rows := arr.Shape()[0]
+	if rows == 0 {
+		panic("cannot bootstrap an empty array")
+	}
+	chosen := make([]int, rows)
+	for i := range chosen {
+		chosen[i] = rng.source.Intn(rows)
+	}
+	return gatherAxis(arr, 0, chosen, []int{rows})
+}
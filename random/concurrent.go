@@ -0,0 +1,73 @@
+package random
+
+import (
+	"sync"
+)
+
+// lockedBits serializes access to a BitGenerator
+type lockedBits struct {
+	mu   sync.Mutex
+	bits BitGenerator
+}
+
+func (l *lockedBits) Uint64() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.bits.Uint64()
+}
+
+func (l *lockedBits) Seed(seed int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	seeder, ok := l.bits.(Seeder)
+	if !ok {
+		panic("bit generator does not support reseeding")
+	}
+	seeder.Seed(seed)
+}
+
+// Jumped returns an unlocked jumped copy: children are meant to be owned
+// by a single goroutine
+func (l *lockedBits) Jumped(jumps int) BitGenerator {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	jumper, ok := l.bits.(Jumper)
+	if !ok {
+		panic("bit generator does not support jumping")
+	}
+	return jumper.Jumped(jumps)
+}
+
+// NewLocked creates a generator that is safe for concurrent use by
+// multiple goroutines: every draw from bits is made under a mutex. The
+// interleaving of draws between goroutines is not deterministic, so for
+// reproducible parallel work prefer Spawn or Local. Generators returned by
+// Jumped and Spawn are not locked.
+func NewLocked(bits BitGenerator) *Generator {
+	return NewGenerator(&lockedBits{bits: bits})
+}
+
+var (
+	// localRoot hands out the independent streams behind Local
+	localRoot = NewLocked(NewPCG64(int64(NewCryptoSource().Uint64())))
+	
+	localPool = sync.Pool{
+		New: func() any {
+			return localRoot.Spawn(1)[0]
+		},
+	}
+)
+
+// Local returns a PCG64 generator for the calling goroutine's exclusive
+// use, taken from a pool of generators on non-overlapping streams. It
+// needs no locking; pass it back with PutLocal when done so that it can be
+// reused. Local generators are randomly seeded and not reproducible.
+func Local() *Generator {
+	return localPool.Get().(*Generator)
+}
+
+// PutLocal returns a generator obtained from Local to the pool. The
+// generator must not be used afterwards.
+func PutLocal(rng *Generator) {
+	localPool.Put(rng)
+}
@@ -0,0 +1,283 @@
+package random
+
+import (
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// ChiSquare generates random floats from a chi-square distribution with df
+// degrees of freedom, drawn as 2 * Gamma(df/2)
+func (rng *Generator) ChiSquare(df float64, shape ...int) *tensor.NDArray {
+	if df <= 0 {
+		panic("df must be positive")
+	}
+	return fill(shape, func() float64 {
+		return 2 * rng.standardGamma(df/2)
+	})
+}
+
+// StandardT generates random floats from Student's t distribution with df
+// degrees of freedom, drawn as Z / sqrt(V / df) for a standard normal Z and
+// chi-square V
+func (rng *Generator) StandardT(df float64, shape ...int) *tensor.NDArray {
+	if df <= 0 {
+		panic("df must be positive")
+	}
+	return fill(shape, func() float64 {
+		z := rng.standardNormal()
+		v := 2 * rng.standardGamma(df/2)
+		return z / math.Sqrt(v/df)
+	})
+}
+
+// F generates random floats from an F (Fisher-Snedecor) distribution: the
+// ratio of chi-square variables divided by their degrees of freedom
+func (rng *Generator) F(dfnum, dfden float64, shape ...int) *tensor.NDArray {
+	if dfnum <= 0 || dfden <= 0 {
+		panic("dfnum and dfden must be positive")
+	}
+	return fill(shape, func() float64 {
+		num := 2 * rng.standardGamma(dfnum/2) / dfnum
+		den := 2 * rng.standardGamma(dfden/2) / dfden
+		return num / den
+	})
+}
+
+// openUniform returns a uniform float in the open interval (0, 1)
+func (rng *Generator) openUniform() float64 {
+	for {
+		if u := rng.source.Float64(); u != 0 {
+			return u
+		}
+	}
+}
+
+// Weibull generates random floats from a Weibull distribution with shape a
+// and unit scale, by inversion
+func (rng *Generator) Weibull(a float64, shape ...int) *tensor.NDArray {
+	if a < 0 {
+		panic("a must be non-negative")
+	}
+	if a == 0 {
+		return fill(shape, func() float64 { return 0 })
+	}
+	return fill(shape, func() float64 {
+		return math.Pow(-math.Log(rng.openUniform()), 1/a)
+	})
+}
+
+// Lognormal generates random floats whose logarithm is normally distributed
+// with the given mean and standard deviation sigma
+func (rng *Generator) Lognormal(mean, sigma float64, shape ...int) *tensor.NDArray {
+	if sigma < 0 {
+		panic("sigma must be non-negative")
+	}
+	return fill(shape, func() float64 {
+		return math.Exp(mean + sigma*rng.standardNormal())
+	})
+}
+
+// Laplace generates random floats from a Laplace (double exponential)
+// distribution with the given location and scale
+func (rng *Generator) Laplace(loc, scale float64, shape ...int) *tensor.NDArray {
+	if scale < 0 {
+		panic("scale must be non-negative")
+	}
+	return fill(shape, func() float64 {
+		u := rng.openUniform()
+		if u < 0.5 {
+			return loc + scale*math.Log(2*u)
+		}
+		return loc - scale*math.Log(2*(1-u))
+	})
+}
+
+// Logistic generates random floats from a logistic distribution with the
+// given location and scale
+func (rng *Generator) Logistic(loc, scale float64, shape ...int) *tensor.NDArray {
+	if scale < 0 {
+		panic("scale must be non-negative")
+	}
+	return fill(shape, func() float64 {
+		u := rng.openUniform()
+		return loc + scale*math.Log(u/(1-u))
+	})
+}
+
+// Gumbel generates random floats from a Gumbel (type I extreme value)
+// distribution with the given location and scale
+func (rng *Generator) Gumbel(loc, scale float64, shape ...int) *tensor.NDArray {
+	if scale < 0 {
+		panic("scale must be non-negative")
+	}
+	return fill(shape, func() float64 {
+		return loc - scale*math.Log(-math.Log(rng.openUniform()))
+	})
+}
+
+// Pareto generates random floats from a Pareto II (Lomax) distribution with
+// shape a, as NumPy does; add 1 and multiply by the minimum for the
+// classical Pareto distribution
+func (rng *Generator) Pareto(a float64, shape ...int) *tensor.NDArray {
+	if a <= 0 {
+		panic("a must be positive")
+	}
+	return fill(shape, func() float64 {
+		return math.Expm1(rng.standardExponential() / a)
+	})
+}
+
+// Rayleigh generates random floats from a Rayleigh distribution with the
+// given scale
+func (rng *Generator) Rayleigh(scale float64, shape ...int) *tensor.NDArray {
+	if scale < 0 {
+		panic("scale must be non-negative")
+	}
+	return fill(shape, func() float64 {
+		return scale * math.Sqrt(2*rng.standardExponential())
+	})
+}
+
+// Triangular generates random floats from a triangular distribution on
+// [left, right] with peak at mode
+func (rng *Generator) Triangular(left, mode, right float64, shape ...int) *tensor.NDArray {
+	if left > mode || mode > right || left == right {
+		panic("Triangular requires left <= mode <= right and left < right")
+	}
+	
+	base := right - left
+	ratio := (mode - left) / base
+	leftProd := (mode - left) * base
+	rightProd := (right - mode) * base
+	return fill(shape, func() float64 {
+		u := rng.source.Float64()
+		if u <= ratio {
+			return left + math.Sqrt(u*leftProd)
+		}
+		return right - math.Sqrt((1-u)*rightProd)
+	})
+}
+
+// VonMises generates random angles in [-pi, pi] from a von Mises (circular
+// normal) distribution with mode mu and concentration kappa, using the
+// rejection method of Best and Fisher (1979)
+func (rng *Generator) VonMises(mu, kappa float64, shape ...int) *tensor.NDArray {
+	if kappa < 0 {
+		panic("kappa must be non-negative")
+	}
+	return fill(shape, func() float64 {
+		return rng.vonMises(mu, kappa)
+	})
+}
+
+func (rng *Generator) vonMises(mu, kappa float64) float64 {
+	if kappa < 1e-8 {
+		return math.Pi * (2*rng.source.Float64() - 1)
+	}
+	
+	var angle float64
+	if kappa > 1e6 {
+		// The distribution is indistinguishable from a wrapped normal
+		angle = mu + rng.standardNormal()/math.Sqrt(kappa)
+	} else {
+		var s float64
+		if kappa < 1e-5 {
+			// Second order expansion around kappa = 0
+			s = 1/kappa + kappa
+		} else {
+			r := 1 + math.Sqrt(1+4*kappa*kappa)
+			rho := (r - math.Sqrt(2*r)) / (2 * kappa)
+			s = (1 + rho*rho) / (2 * rho)
+		}
+		
+		var w float64
+		for {
+			z := math.Cos(math.Pi * rng.source.Float64())
+			w = (1 + s*z) / (s + z)
+			y := kappa * (s - w)
+			v := rng.source.Float64()
+			if y*(2-y)-v >= 0 || math.Log(y/v)+1-y >= 0 {
+				break
+			}
+		}
+		
+		angle = math.Acos(math.Max(-1, math.Min(1, w)))
+		if rng.source.Float64() < 0.5 {
+			angle = -angle
+		}
+		angle += mu
+	}
+	
+	// Wrap into [-pi, pi]
+	wrapped := math.Mod(math.Abs(angle)+math.Pi, 2*math.Pi) - math.Pi
+	if angle < 0 {
+		wrapped = -wrapped
+	}
+	return wrapped
+}
+
+// Wald generates random floats from a Wald (inverse Gaussian) distribution
+// with the given mean and scale, using the method of Michael, Schucany and
+// Haas (1976)
+func (rng *Generator) Wald(mean, scale float64, shape ...int) *tensor.NDArray {
+	if mean <= 0 || scale <= 0 {
+		panic("mean and scale must be positive")
+	}
+	
+	mu2l := mean / (2 * scale)
+	return fill(shape, func() float64 {
+		y := rng.standardNormal()
+		y = mean * y * y
+		x := mean + mu2l*(y-math.Sqrt(4*scale*y+y*y))
+		if rng.source.Float64() <= mean/(mean+x) {
+			return x
+		}
+		return mean * mean / x
+	})
+}
+
+// Zipf generates random integers from a Zipf (zeta) distribution with
+// exponent a > 1, using the rejection method of Devroye (1986)
+func (rng *Generator) Zipf(a float64, shape ...int) *tensor.NDArray {
+	if a <= 1 {
+		panic("a must be greater than 1")
+	}
+	
+	am1 := a - 1
+	b := math.Pow(2, am1)
+	return fill(shape, func() float64 {
+		for {
+			u := 1 - rng.source.Float64()
+			v := rng.source.Float64()
+			x := math.Floor(math.Pow(u, -1/am1))
+			// Reject values that cannot be represented as int64
+			if x > math.MaxInt64 || x < 1 {
+				continue
+			}
+			t := math.Pow(1+1/x, am1)
+			if v*x*(t-1)/(b-1) <= t/b {
+				return x
+			}
+		}
+	})
+}
+
+// StandardCauchy generates random floats from a standard Cauchy (Lorentz)
+// distribution, drawn as the ratio of two standard normals
+func (rng *Generator) StandardCauchy(shape ...int) *tensor.NDArray {
+	return fill(shape, func() float64 {
+		return rng.standardNormal() / rng.standardNormal()
+	})
+}
+
+// Power generates random floats in [0, 1] from a power function
+// distribution with exponent a - 1, whose CDF is x^a
+func (rng *Generator) Power(a float64, shape ...int) *tensor.NDArray {
+	if a <= 0 {
+		panic("a must be positive")
+	}
+	return fill(shape, func() float64 {
+		return math.Pow(-math.Expm1(-rng.standardExponential()), 1/a)
+	})
+}
@@ -0,0 +1,26 @@
+package random
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+)
+
+// CryptoSource is a BitGenerator backed by crypto/rand, for sampling that
+// must be unpredictable (keys, nonces, randomized response). It cannot be
+// seeded or jumped, so its streams are not reproducible, and it is much
+// slower than the algorithmic bit generators.
+type CryptoSource struct{}
+
+// NewCryptoSource creates a cryptographically secure bit generator
+func NewCryptoSource() *CryptoSource {
+	return &CryptoSource{}
+}
+
+// Uint64 returns 64 bits from the operating system's secure source
+func (CryptoSource) Uint64() uint64 {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic("crypto/rand: " + err.Error())
+	}
+	return binary.LittleEndian.Uint64(buf[:])
+}
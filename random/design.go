@@ -0,0 +1,104 @@
+package random
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// LatinHypercube returns an n x d design matrix in [0, 1)^d. With
+// strength 1 each column places exactly one point in each of the n
+// intervals [k/n, (k+1)/n). Strength 2 builds an orthogonal-array based
+// design (Owen, 1992) that is additionally stratified on every pair of
+// columns; it requires n = p^2 for a prime p and d <= p + 1.
+func (rng *Generator) LatinHypercube(n, d, strength int) *tensor.NDArray {
+	if n <= 0 || d <= 0 {
+		panic("n and d must be positive")
+	}
+	
+	var data []float64
+	switch strength {
+	case 1:
+		data = make([]float64, n*d)
+		for j := 0; j < d; j++ {
+			column := rng.latinColumn(n)
+			for i := 0; i < n; i++ {
+				data[i*d+j] = column[i]
+			}
+		}
+	case 2:
+		data = rng.orthogonalArrayLHS(n, d)
+	default:
+		panic(fmt.Sprintf("strength must be 1 or 2, got %d", strength))
+	}
+	
+	return tensor.FromSliceFloat64(data, n, d)
+}
+
+// latinColumn returns n points in [0, 1), one in each interval of width
+// 1/n, in random order
+func (rng *Generator) latinColumn(n int) []float64 {
+	perm := rng.source.Perm(n)
+	column := make([]float64, n)
+	for i, stratum := range perm {
+		column[i] = (float64(stratum) + rng.source.Float64()) / float64(n)
+	}
+	return column
+}
+
+// orthogonalArrayLHS builds a strength 2 design from the Bush
+// construction of an orthogonal array OA(p^2, p+1, p, 2), with randomly
+// relabelled levels, then spreads each level's p points over a Latin
+// hypercube inside that level
+func (rng *Generator) orthogonalArrayLHS(n, d int) []float64 {
+	p := int(math.Round(math.Sqrt(float64(n))))
+	if p*p != n || !isPrime(p) {
+		panic(fmt.Sprintf("strength 2 requires n to be the square of a prime, got %d", n))
+	}
+	if d > p+1 {
+		panic(fmt.Sprintf("strength 2 with n = %d supports at most %d dimensions, got %d", n, p+1, d))
+	}
+	
+	data := make([]float64, n*d)
+	for j := 0; j < d; j++ {
+		relabel := rng.source.Perm(p)
+		levels := make([][]int, p) // rows holding each level
+		for row := 0; row < n; row++ {
+			a, b := row/p, row%p
+			var level int
+			switch j {
+			case 0:
+				level = a
+			case 1:
+				level = b
+			default:
+				level = (a*(j-1) + b) % p
+			}
+			level = relabel[level]
+			levels[level] = append(levels[level], row)
+		}
+		
+		for level, rows := range levels {
+			column := rng.latinColumn(len(rows))
+			for k, row := range rows {
+				data[row*d+j] = (float64(level) + column[k]) / float64(p)
+			}
+		}
+	}
+	
+	return data
+}
+
+// isPrime reports whether n is prime
+func isPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for f := 2; f*f <= n; f++ {
+		if n%f == 0 {
+			return false
+		}
+	}
+	return true
+}
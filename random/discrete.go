@@ -0,0 +1,378 @@
+package random
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// fill returns a float64 array of the given shape with each element drawn
+// by draw
+func fill(shape []int, draw func() float64) *tensor.NDArray {
+	size := 1
+	for _, dim := range shape {
+		size *= dim
+	}
+	
+	data := make([]float64, size)
+	for i := range data {
+		data[i] = draw()
+	}
+	
+	return tensor.FromSliceFloat64(data, shape...)
+}
+
+// logFactorial returns log(k!)
+func logFactorial(k int64) float64 {
+	v, _ := math.Lgamma(float64(k) + 1)
+	return v
+}
+
+// Hypergeometric generates random integers from a hypergeometric
+// distribution: the number of good items in nsample draws without
+// replacement from an urn of ngood good and nbad bad items
+func (rng *Generator) Hypergeometric(ngood, nbad, nsample int, shape ...int) *tensor.NDArray {
+	if ngood < 0 || nbad < 0 {
+		panic("ngood and nbad must be non-negative")
+	}
+	if nsample < 0 || nsample > ngood+nbad {
+		panic(fmt.Sprintf("nsample must be in [0, %d], got %d", ngood+nbad, nsample))
+	}
+	
+	good, bad, sample := int64(ngood), int64(nbad), int64(nsample)
+	if sample >= 10 && sample <= good+bad-10 {
+		return fill(shape, func() float64 {
+			return float64(rng.hypergeometricHRUA(good, bad, sample))
+		})
+	}
+	return fill(shape, func() float64 {
+		return float64(rng.hypergeometricUrn(good, bad, sample))
+	})
+}
+
+// hypergeometricUrn simulates the draws directly, which is fastest when
+// the sample (or its complement) is small
+func (rng *Generator) hypergeometricUrn(good, bad, sample int64) int64 {
+	total := good + bad
+	selected := sample
+	if sample > total/2 {
+		selected = total - sample
+	}
+	
+	remainingTotal, remainingGood := total, good
+	for ; selected > 0 && remainingGood > 0; selected-- {
+		if rng.source.Int63n(remainingTotal) < remainingGood {
+			remainingGood--
+		}
+		remainingTotal--
+	}
+	
+	k := good - remainingGood
+	if sample > total/2 {
+		k = good - k
+	}
+	return k
+}
+
+// hypergeometricHRUA uses the ratio-of-uniforms rejection method of
+// Stadlober ("The ratio of uniforms approach for generating discrete
+// random variates", 1990), as in NumPy
+func (rng *Generator) hypergeometricHRUA(good, bad, sample int64) int64 {
+	const (
+		d1 = 1.7155277699214135 // 2 sqrt(2/e)
+		d2 = 0.8989161620588988 // 3 - 2 sqrt(3/e)
+	)
+	
+	popsize := good + bad
+	computed := min(sample, popsize-sample)
+	minGB, maxGB := min(good, bad), max(good, bad)
+	
+	p := float64(minGB) / float64(popsize)
+	q := float64(maxGB) / float64(popsize)
+	mu := float64(computed) * p
+	a := mu + 0.5
+	variance := float64(popsize-computed) * float64(computed) * p * q / float64(popsize-1)
+	c := math.Sqrt(variance + 0.5)
+	h := d1*c + d2
+	m := int64(math.Floor(float64(computed+1) * float64(minGB+1) / float64(popsize+2)))
+	g := logFactorial(m) + logFactorial(minGB-m) + logFactorial(computed-m) + logFactorial(maxGB-computed+m)
+	b := math.Min(float64(min(computed, minGB)+1), math.Floor(a+16*c))
+	
+	var k int64
+	for {
+		u := rng.source.Float64()
+		v := rng.source.Float64()
+		x := a + h*(v-0.5)/u
+		if x < 0 || x >= b {
+			continue
+		}
+		k = int64(math.Floor(x))
+		t := g - (logFactorial(k) + logFactorial(minGB-k) + logFactorial(computed-k) + logFactorial(maxGB-computed+k))
+		if u*(4-u)-3 <= t {
+			break
+		}
+		if u*(u-t) >= 1 {
+			continue
+		}
+		if 2*math.Log(u) <= t {
+			break
+		}
+	}
+	
+	if good > bad {
+		k = computed - k
+	}
+	if computed < sample {
+		k = good - k
+	}
+	return k
+}
+
+// poisson draws from a Poisson distribution, multiplying uniforms (Knuth)
+// for small means and using the PTRS transformed rejection method of
+// Hörmann ("The transformed rejection method for generating Poisson
+// random variables", 1993) otherwise
+func (rng *Generator) poisson(lambda float64) int64 {
+	if lambda == 0 {
+		return 0
+	}
+	if lambda < 10 {
+		limit := math.Exp(-lambda)
+		k := int64(0)
+		for p := rng.source.Float64(); p > limit; p *= rng.source.Float64() {
+			k++
+		}
+		return k
+	}
+	
+	slam := math.Sqrt(lambda)
+	logLam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	
+	for {
+		u := rng.source.Float64() - 0.5
+		v := rng.source.Float64()
+		us := 0.5 - math.Abs(u)
+		k := int64(math.Floor((2*a/us+b)*u + lambda + 0.43))
+		if us >= 0.07 && v <= vr {
+			return k
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -lambda+float64(k)*logLam-logFactorial(k) {
+			return k
+		}
+	}
+}
+
+// NegativeBinomial generates random integers from a negative binomial
+// distribution: the number of failures before n successes with success
+// probability p. n may be fractional. Samples are drawn as a gamma-Poisson
+// mixture, so the cost does not grow with n.
+func (rng *Generator) NegativeBinomial(n, p float64, shape ...int) *tensor.NDArray {
+	if n <= 0 {
+		panic("n must be positive")
+	}
+	if p <= 0 || p > 1 {
+		panic("p must be in (0, 1]")
+	}
+	if p == 1 {
+		return fill(shape, func() float64 { return 0 })
+	}
+	
+	scale := (1 - p) / p
+	return fill(shape, func() float64 {
+		return float64(rng.poisson(scale * rng.standardGamma(n)))
+	})
+}
+
+// Geometric generates random integers from a geometric distribution: the
+// number of trials up to and including the first success, with success
+// probability p. Samples are drawn by inversion in constant time.
+func (rng *Generator) Geometric(p float64, shape ...int) *tensor.NDArray {
+	if p <= 0 || p > 1 {
+		panic("p must be in (0, 1]")
+	}
+	if p == 1 {
+		return fill(shape, func() float64 { return 1 })
+	}
+	
+	logQ := math.Log1p(-p)
+	return fill(shape, func() float64 {
+		// 1 - Float64() lies in (0, 1], so the logarithm is finite
+		return math.Max(1, math.Ceil(math.Log(1-rng.source.Float64())/logQ))
+	})
+}
+
+// binomial draws from a binomial distribution, by inversion when the
+// expected number of successes (or failures) is small and otherwise with
+// the BTPE algorithm of Kachitvichyanukul and Schmeiser ("Binomial random
+// variate generation", 1988), so the cost does not grow with n
+func (rng *Generator) binomial(n int64, p float64) int64 {
+	if n == 0 || p == 0 {
+		return 0
+	}
+	if p == 1 {
+		return n
+	}
+	if p <= 0.5 {
+		if float64(n)*p <= 30 {
+			return rng.binomialInversion(n, p)
+		}
+		return rng.binomialBTPE(n, p)
+	}
+	if float64(n)*(1-p) <= 30 {
+		return n - rng.binomialInversion(n, 1-p)
+	}
+	return rng.binomialBTPE(n, p)
+}
+
+// binomialInversion walks the CDF from zero, restarting if roundoff
+// carries the search past a safe bound
+func (rng *Generator) binomialInversion(n int64, p float64) int64 {
+	q := 1 - p
+	qn := math.Exp(float64(n) * math.Log(q))
+	np := float64(n) * p
+	bound := math.Min(float64(n), np+10*math.Sqrt(np*q+1))
+	
+	x := int64(0)
+	px := qn
+	u := rng.source.Float64()
+	for u > px {
+		x++
+		if float64(x) > bound {
+			x = 0
+			px = qn
+			u = rng.source.Float64()
+		} else {
+			u -= px
+			px = float64(n-x+1) * p * px / (float64(x) * q)
+		}
+	}
+	return x
+}
+
+// binomialBTPE is the triangle-parallelogram-exponential rejection
+// sampler, following NumPy's implementation
+func (rng *Generator) binomialBTPE(n int64, p float64) int64 {
+	nf := float64(n)
+	r := math.Min(p, 1-p)
+	q := 1 - r
+	fm := nf*r + r
+	m := int64(math.Floor(fm))
+	mf := float64(m)
+	p1 := math.Floor(2.195*math.Sqrt(nf*r*q)-4.6*q) + 0.5
+	xm := mf + 0.5
+	xl := xm - p1
+	xr := xm + p1
+	c := 0.134 + 20.5/(15.3+mf)
+	a := (fm - xl) / (fm - xl*r)
+	lamL := a * (1 + a/2)
+	a = (xr - fm) / (xr * q)
+	lamR := a * (1 + a/2)
+	p2 := p1 * (1 + 2*c)
+	p3 := p2 + c/lamL
+	p4 := p3 + c/lamR
+	nrq := nf * r * q
+	
+	var y int64
+	for {
+		u := rng.source.Float64() * p4
+		v := rng.source.Float64()
+		
+		if u <= p1 {
+			// Triangular region: accept immediately
+			y = int64(math.Floor(xm - p1*v + u))
+			break
+		}
+		
+		switch {
+		case u <= p2:
+			// Parallelogram region
+			x := xl + (u-p1)/c
+			v = v*c + 1 - math.Abs(mf-x+0.5)/p1
+			if v > 1 {
+				continue
+			}
+			y = int64(math.Floor(x))
+		case u <= p3:
+			// Left exponential tail
+			y = int64(math.Floor(xl + math.Log(v)/lamL))
+			if y < 0 || v == 0 {
+				continue
+			}
+			v = v * (u - p2) * lamL
+		default:
+			// Right exponential tail
+			y = int64(math.Floor(xr - math.Log(v)/lamR))
+			if y > n || v == 0 {
+				continue
+			}
+			v = v * (u - p3) * lamR
+		}
+		
+		k := y - m
+		if k < 0 {
+			k = -k
+		}
+		kf := float64(k)
+		if k <= 20 || kf >= nrq/2-1 {
+			// Evaluate f(y)/f(m) by the recurrence
+			s := r / q
+			a := s * (nf + 1)
+			f := 1.0
+			if m < y {
+				for i := m + 1; i <= y; i++ {
+					f *= a/float64(i) - s
+				}
+			} else if m > y {
+				for i := y + 1; i <= m; i++ {
+					f /= a/float64(i) - s
+				}
+			}
+			if v > f {
+				continue
+			}
+			break
+		}
+		
+		// Squeeze using the normal approximation, then the Stirling bound
+		rho := (kf / nrq) * ((kf*(kf/3+0.625)+0.16666666666666666)/nrq + 0.5)
+		t := -kf * kf / (2 * nrq)
+		logV := math.Log(v)
+		if logV < t-rho {
+			break
+		}
+		if logV > t+rho {
+			continue
+		}
+		
+		yf := float64(y)
+		x1 := yf + 1
+		f1 := mf + 1
+		z := nf + 1 - mf
+		w := nf - yf + 1
+		if logV > xm*math.Log(f1/x1)+(nf-mf+0.5)*math.Log(z/w)+(yf-mf)*math.Log(w*r/(x1*q))+
+			stirlingCorrection(f1)+stirlingCorrection(z)+stirlingCorrection(x1)+stirlingCorrection(w) {
+			continue
+		}
+		break
+	}
+	
+	if p > 0.5 {
+		y = n - y
+	}
+	return y
+}
+
+// stirlingCorrection returns the series correction to Stirling's
+// approximation of log(x!) used by the BTPE acceptance test
+func stirlingCorrection(x float64) float64 {
+	x2 := x * x
+	return (13680 - (462-(132-(99-140/x2)/x2)/x2)/x2) / x / 166320
+}
@@ -0,0 +1,131 @@
+package random
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Integers generates random integers of the given dtype, uniformly
+// distributed in [low, high), or in [low, high] when endpoint is true.
+// Spans up to the full int64 range are sampled without modulo bias using
+// Lemire's multiply-and-reject method. Both bounds must be representable
+// in dtype, which may be any integer dtype or Bool.
+func (rng *Generator) Integers(low, high int64, shape []int, dtype tensor.DType, endpoint bool) *tensor.NDArray {
+	minVal, maxVal := integerRange(dtype)
+	last := high
+	if !endpoint {
+		if high <= low {
+			panic(fmt.Sprintf("low >= high: %d >= %d", low, high))
+		}
+		last = high - 1
+	} else if high < low {
+		panic(fmt.Sprintf("low > high: %d > %d", low, high))
+	}
+	if low < minVal || last > maxVal {
+		panic(fmt.Sprintf("range [%d, %d] is out of bounds for %s", low, last, dtype))
+	}
+	
+	// Number of values in the range; zero stands for all 2^64 values
+	span := uint64(last) - uint64(low) + 1
+	
+	result := tensor.Zeros(shape, dtype)
+	indices := make([]int, len(shape))
+	for i := 0; i < result.Size(); i++ {
+		unravel(shape, i, indices)
+		result.SetInt64(low+int64(rng.boundedUint64(span)), indices...)
+	}
+	
+	return result
+}
+
+// boundedUint64 returns a uniform value in [0, n), or any uint64 when n is
+// zero (Lemire, "Fast Random Integer Generation in an Interval", 2019)
+func (rng *Generator) boundedUint64(n uint64) uint64 {
+	x := rng.bits.Uint64()
+	if n == 0 {
+		return x
+	}
+	hi, lo := bits.Mul64(x, n)
+	if lo < n {
+		threshold := -n % n
+		for lo < threshold {
+			x = rng.bits.Uint64()
+			hi, lo = bits.Mul64(x, n)
+		}
+	}
+	return hi
+}
+
+// integerRange returns the smallest and largest value Integers can store
+// in dtype. Uint64 is limited to the non-negative int64 values.
+func integerRange(dtype tensor.DType) (int64, int64) {
+	switch dtype {
+	case tensor.Bool:
+		return 0, 1
+	case tensor.Int8:
+		return math.MinInt8, math.MaxInt8
+	case tensor.Int16:
+		return math.MinInt16, math.MaxInt16
+	case tensor.Int32:
+		return math.MinInt32, math.MaxInt32
+	case tensor.Int64:
+		return math.MinInt64, math.MaxInt64
+	case tensor.Uint8:
+		return 0, math.MaxUint8
+	case tensor.Uint16:
+		return 0, math.MaxUint16
+	case tensor.Uint32:
+		return 0, math.MaxUint32
+	case tensor.Uint64:
+		return 0, math.MaxInt64
+	default:
+		panic(fmt.Sprintf("Integers requires an integer or bool dtype, got %s", dtype))
+	}
+}
+
+// Bytes returns n random bytes taken directly from the bit generator
+func (rng *Generator) Bytes(n int) []byte {
+	buf := make([]byte, (n+7)/8*8)
+	for i := 0; i < len(buf); i += 8 {
+		binary.LittleEndian.PutUint64(buf[i:], rng.bits.Uint64())
+	}
+	return buf[:n]
+}
+
+// Bits returns an array of the given integer dtype (or Bool) filled with
+// raw random bits, so every value of the dtype is equally likely. Narrow
+// dtypes are packed several to a 64-bit draw.
+func (rng *Generator) Bits(shape []int, dtype tensor.DType) *tensor.NDArray {
+	width := dtype.ItemSize() * 8
+	if dtype == tensor.Bool {
+		width = 1
+	} else if !dtype.IsInt() {
+		panic(fmt.Sprintf("Bits requires an integer or bool dtype, got %s", dtype))
+	}
+	
+	result := tensor.Zeros(shape, dtype)
+	indices := make([]int, len(shape))
+	var word uint64
+	available := 0
+	for i := 0; i < result.Size(); i++ {
+		if available < width {
+			word = rng.bits.Uint64()
+			available = 64
+		}
+		value := word
+		if width < 64 {
+			value &= 1<<width - 1
+		}
+		word >>= width % 64
+		available -= width
+		
+		unravel(shape, i, indices)
+		result.SetInt64(int64(value), indices...)
+	}
+	
+	return result
+}
@@ -0,0 +1,125 @@
+package random
+
+import (
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// OrthogonalMatrix returns a random n x n orthogonal matrix distributed
+// uniformly (by Haar measure) over the orthogonal group. It orthonormalizes
+// the columns of a standard normal matrix, which is the QR decomposition
+// with a positive diagonal in R (Mezzadri, "How to generate random
+// matrices from the classical compact groups", 2007).
+func (rng *Generator) OrthogonalMatrix(n int) *tensor.NDArray {
+	if n <= 0 {
+		panic("n must be positive")
+	}
+	
+	cols := make([][]float64, n)
+	for j := range cols {
+		for {
+			col := make([]float64, n)
+			for i := range col {
+				col[i] = rng.standardNormal()
+			}
+			
+			// Modified Gram-Schmidt, applied twice for full precision
+			for pass := 0; pass < 2; pass++ {
+				for _, prev := range cols[:j] {
+					dot := 0.0
+					for i := range col {
+						dot += prev[i] * col[i]
+					}
+					for i := range col {
+						col[i] -= dot * prev[i]
+					}
+				}
+			}
+			
+			norm := 0.0
+			for _, v := range col {
+				norm += v * v
+			}
+			norm = math.Sqrt(norm)
+			// A numerically dependent column has probability zero; redraw it
+			if norm > 1e-8 {
+				for i := range col {
+					col[i] /= norm
+				}
+				cols[j] = col
+				break
+			}
+		}
+	}
+	
+	data := make([]float64, n*n)
+	for j, col := range cols {
+		for i, v := range col {
+			data[i*n+j] = v
+		}
+	}
+	return tensor.FromSliceFloat64(data, n, n)
+}
+
+// CorrelationMatrix returns a random d x d correlation matrix from the LKJ
+// distribution with concentration eta > 0, using the onion method of
+// Lewandowski, Kurowicka and Joe (2009). eta = 1 is uniform over all
+// correlation matrices; larger eta concentrates around the identity.
+func (rng *Generator) CorrelationMatrix(d int, eta float64) *tensor.NDArray {
+	if d <= 0 {
+		panic("d must be positive")
+	}
+	if eta <= 0 {
+		panic("eta must be positive")
+	}
+	
+	// Build the Cholesky factor L of R one row at a time: a new row is
+	// sqrt(y) u for a uniform unit vector u, followed by sqrt(1 - y)
+	chol := make([][]float64, d)
+	chol[0] = []float64{1}
+	beta := eta + float64(d-2)/2
+	if d > 1 {
+		r := 2*rng.betaDraw(beta, beta) - 1
+		chol[1] = []float64{r, math.Sqrt(1 - r*r)}
+	}
+	for k := 2; k < d; k++ {
+		beta -= 0.5
+		y := rng.betaDraw(float64(k)/2, beta)
+		
+		u := make([]float64, k)
+		norm := 0.0
+		for i := range u {
+			u[i] = rng.standardNormal()
+			norm += u[i] * u[i]
+		}
+		scale := math.Sqrt(y / norm)
+		row := make([]float64, k+1)
+		for i := range u {
+			row[i] = u[i] * scale
+		}
+		row[k] = math.Sqrt(1 - y)
+		chol[k] = row
+	}
+	
+	data := make([]float64, d*d)
+	for i := 0; i < d; i++ {
+		for j := 0; j <= i; j++ {
+			sum := 0.0
+			for k := 0; k <= j; k++ {
+				sum += chol[i][k] * chol[j][k]
+			}
+			data[i*d+j] = sum
+			data[j*d+i] = sum
+		}
+		data[i*d+i] = 1
+	}
+	return tensor.FromSliceFloat64(data, d, d)
+}
+
+// betaDraw draws from Beta(a, b) as a ratio of gamma variates
+func (rng *Generator) betaDraw(a, b float64) float64 {
+	x := rng.standardGamma(a)
+	y := rng.standardGamma(b)
+	return x / (x + y)
+}
@@ -0,0 +1,133 @@
+package random
+
+import (
+	"math/bits"
+)
+
+// pcgCheapMultiplier is the 64-bit multiplier used both by the LCG step
+// and by the DXSM output function
+const pcgCheapMultiplier = 0xda942042e4dd58b5
+
+// PCG64 is the PCG64DXSM bit generator: a 128-bit linear congruential
+// generator whose state is scrambled by the "double xorshift multiply"
+// output function. It has a period of 2^128, a 32-byte state and passes
+// the standard statistical test batteries, and is the recommended bit
+// generator for new code.
+type PCG64 struct {
+	hi, lo       uint64 // state
+	incHi, incLo uint64 // odd increment selecting the stream
+}
+
+// NewPCG64 creates a PCG64 bit generator with the given seed
+func NewPCG64(seed int64) *PCG64 {
+	p := &PCG64{}
+	p.Seed(seed)
+	return p
+}
+
+// Seed resets the generator to the stream for seed. The seed is expanded
+// with SplitMix64 into a 128-bit initial state and stream increment.
+func (p *PCG64) Seed(seed int64) {
+	sm := uint64(seed)
+	p.seedWords(splitMix64(&sm), splitMix64(&sm), splitMix64(&sm), splitMix64(&sm))
+}
+
+// seedWords sets the initial 128-bit state and the stream increment, which
+// is made odd by shifting in a 1 bit
+func (p *PCG64) seedWords(stateHi, stateLo, incHi, incLo uint64) {
+	// Standard PCG seeding: set the increment, step, add the state, step
+	p.incHi = incHi<<1 | incLo>>63
+	p.incLo = incLo<<1 | 1
+	p.hi, p.lo = 0, 0
+	p.step()
+	p.hi, p.lo = add128(p.hi, p.lo, stateHi, stateLo)
+	p.step()
+}
+
+// Uint64 returns the next 64 random bits
+func (p *PCG64) Uint64() uint64 {
+	// DXSM output on the pre-step state
+	hi, lo := p.hi, p.lo|1
+	hi ^= hi >> 32
+	hi *= pcgCheapMultiplier
+	hi ^= hi >> 48
+	hi *= lo
+	
+	p.step()
+	return hi
+}
+
+// step advances the LCG: state = state * multiplier + increment (mod 2^128)
+func (p *PCG64) step() {
+	p.hi, p.lo = mul128(p.hi, p.lo, 0, pcgCheapMultiplier)
+	p.hi, p.lo = add128(p.hi, p.lo, p.incHi, p.incLo)
+}
+
+// Advance moves the generator delta steps forward in its stream, as if
+// Uint64 had been called delta times
+func (p *PCG64) Advance(delta uint64) {
+	p.advance(0, delta)
+}
+
+// Jumped returns a copy of the generator advanced by jumps * 2^64 steps,
+// leaving p unchanged. Streams obtained with different jump counts do not
+// overlap unless one of them draws 2^64 or more values.
+func (p *PCG64) Jumped(jumps int) BitGenerator {
+	if jumps < 0 {
+		panic("jumps must be non-negative")
+	}
+	q := *p
+	q.advance(uint64(jumps), 0)
+	return &q
+}
+
+// advance jumps the LCG ahead by the 128-bit step count (deltaHi, deltaLo)
+// in O(log delta) time using Brown's algorithm ("Random Number Generation
+// with Arbitrary Strides", 1994)
+func (p *PCG64) advance(deltaHi, deltaLo uint64) {
+	accMulHi, accMulLo := uint64(0), uint64(1)
+	accAddHi, accAddLo := uint64(0), uint64(0)
+	curMulHi, curMulLo := uint64(0), uint64(pcgCheapMultiplier)
+	curAddHi, curAddLo := p.incHi, p.incLo
+	
+	for deltaHi != 0 || deltaLo != 0 {
+		if deltaLo&1 == 1 {
+			accMulHi, accMulLo = mul128(accMulHi, accMulLo, curMulHi, curMulLo)
+			accAddHi, accAddLo = mul128(accAddHi, accAddLo, curMulHi, curMulLo)
+			accAddHi, accAddLo = add128(accAddHi, accAddLo, curAddHi, curAddLo)
+		}
+		// cur_add = (cur_mul + 1) * cur_add; cur_mul = cur_mul^2
+		plusHi, plusLo := add128(curMulHi, curMulLo, 0, 1)
+		curAddHi, curAddLo = mul128(plusHi, plusLo, curAddHi, curAddLo)
+		curMulHi, curMulLo = mul128(curMulHi, curMulLo, curMulHi, curMulLo)
+		deltaLo = deltaLo>>1 | deltaHi<<63
+		deltaHi >>= 1
+	}
+	
+	p.hi, p.lo = mul128(accMulHi, accMulLo, p.hi, p.lo)
+	p.hi, p.lo = add128(p.hi, p.lo, accAddHi, accAddLo)
+}
+
+// mul128 returns the low 128 bits of the product of two 128-bit values
+func mul128(aHi, aLo, bHi, bLo uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(aLo, bLo)
+	hi += aHi*bLo + aLo*bHi
+	return hi, lo
+}
+
+// add128 returns the sum of two 128-bit values modulo 2^128
+func add128(aHi, aLo, bHi, bLo uint64) (uint64, uint64) {
+	lo, carry := bits.Add64(aLo, bLo, 0)
+	hi, _ := bits.Add64(aHi, bHi, carry)
+	return hi, lo
+}
+
+// splitMix64 advances state and returns the next SplitMix64 output. It is
+// used to expand a 64-bit seed into the larger states of bit generators.
+func splitMix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
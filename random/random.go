@@ -4,30 +4,61 @@ package random
 import (
 	"math"
 	"math/rand"
+	"sync/atomic"
 	"time"
 	
 	"github.com/iSundram/NumGo/tensor"
 )
 
-// RNG represents a random number generator
-type RNG struct {
-	source *rand.Rand
+// Generator draws samples from probability distributions using the raw
+// bits produced by a BitGenerator
+type Generator struct {
+	bits     BitGenerator
+	source   *rand.Rand
+	spawnKey []uint64     // entropy and spawn path, see Spawn
+	spawned  atomic.Int64 // children handed out by Spawn
 }
 
-// New creates a new random number generator with the given seed
-func New(seed int64) *RNG {
-	return &RNG{
-		source: rand.New(rand.NewSource(seed)),
+// RNG is the original name of Generator, kept for compatibility
+type RNG = Generator
+
+// NewGenerator creates a generator that draws its randomness from bits
+func NewGenerator(bits BitGenerator) *Generator {
+	return &Generator{
+		bits:     bits,
+		source:   rand.New(bitSource{bits}),
+		spawnKey: spawnEntropy(bits),
 	}
 }
 
-// NewDefault creates a new random number generator with a time-based seed
-func NewDefault() *RNG {
-	return New(time.Now().UnixNano())
+// New creates a new random number generator with the given seed
+func New(seed int64) *Generator {
+	return NewGenerator(NewStdSource(seed))
+}
+
+// NewDefault creates a new random number generator with a time-based seed,
+// backed by PCG64
+func NewDefault() *Generator {
+	return NewGenerator(NewPCG64(time.Now().UnixNano()))
+}
+
+// BitGenerator returns the bit generator backing rng
+func (rng *Generator) BitGenerator() BitGenerator {
+	return rng.bits
+}
+
+// Jumped returns a new generator whose bit generator is rng's advanced by
+// jumps jumps (see Jumper). It panics if the bit generator cannot jump.
+func (rng *Generator) Jumped(jumps int) *Generator {
+	jumper, ok := rng.bits.(Jumper)
+	if !ok {
+		panic("bit generator does not support jumping")
+	}
+	return NewGenerator(jumper.Jumped(jumps))
 }
 
 // Uniform generates random floats from a uniform distribution [low, high)
-func (rng *RNG) Uniform(low, high float64, shape ...int) *tensor.NDArray {
+func (rng *Generator) Uniform(low, high float64, shape ...int) *tensor.NDArray {
 	size := 1
 	for _, dim := range shape {
 		size *= dim
@@ -41,8 +72,9 @@ func (rng *RNG) Uniform(low, high float64, shape ...int) *tensor.NDArray {
 	return tensor.FromSliceFloat64(data, shape...)
 }
 
-// Normal generates random floats from a normal (Gaussian) distribution
-func (rng *RNG) Normal(mean, std float64, shape ...int) *tensor.NDArray {
+// Normal generates random floats from a normal (Gaussian) distribution,
+// using the ziggurat method
+func (rng *Generator) Normal(mean, std float64, shape ...int) *tensor.NDArray {
 	size := 1
 	for _, dim := range shape {
 		size *= dim
@@ -50,19 +82,20 @@ func (rng *RNG) Normal(mean, std float64, shape ...int) *tensor.NDArray {
 	
 	data := make([]float64, size)
 	for i := 0; i < size; i++ {
-		data[i] = mean + std*rng.source.NormFloat64()
+		data[i] = mean + std*rng.standardNormal()
 	}
 	
 	return tensor.FromSliceFloat64(data, shape...)
 }
 
 // StandardNormal generates random floats from a standard normal distribution (mean=0, std=1)
-func (rng *RNG) StandardNormal(shape ...int) *tensor.NDArray {
+func (rng *Generator) StandardNormal(shape ...int) *tensor.NDArray {
 	return rng.Normal(0, 1, shape...)
 }
 
-// Binomial generates random integers from a binomial distribution
-func (rng *RNG) Binomial(n int, p float64, shape ...int) *tensor.NDArray {
+// Binomial generates random integers from a binomial distribution, using
+// inversion for small means and BTPE otherwise
+func (rng *Generator) Binomial(n int, p float64, shape ...int) *tensor.NDArray {
 	size := 1
 	for _, dim := range shape {
 		size *= dim
@@ -70,45 +103,31 @@ func (rng *RNG) Binomial(n int, p float64, shape ...int) *tensor.NDArray {
 	
 	data := make([]float64, size)
 	for i := 0; i < size; i++ {
-		count := 0
-		for j := 0; j < n; j++ {
-			if rng.source.Float64() < p {
-				count++
-			}
-		}
-		data[i] = float64(count)
+		data[i] = float64(rng.binomial(int64(n), p))
 	}
 	
 	return tensor.FromSliceFloat64(data, shape...)
 }
 
-// Poisson generates random integers from a Poisson distribution
-func (rng *RNG) Poisson(lambda float64, shape ...int) *tensor.NDArray {
+// Poisson generates random integers from a Poisson distribution, using
+// Knuth's method for small lambda and PTRS otherwise
+func (rng *Generator) Poisson(lambda float64, shape ...int) *tensor.NDArray {
 	size := 1
 	for _, dim := range shape {
 		size *= dim
 	}
 	
 	data := make([]float64, size)
-	L := math.Exp(-lambda)
-	
 	for i := 0; i < size; i++ {
-		k := 0
-		p := 1.0
-		
-		for p > L {
-			k++
-			p *= rng.source.Float64()
-		}
-		
-		data[i] = float64(k - 1)
+		data[i] = float64(rng.poisson(lambda))
 	}
 	
 	return tensor.FromSliceFloat64(data, shape...)
 }
 
-// Exponential generates random floats from an exponential distribution
-func (rng *RNG) Exponential(scale float64, shape ...int) *tensor.NDArray {
+// Exponential generates random floats from an exponential distribution,
+// using the ziggurat method
+func (rng *Generator) Exponential(scale float64, shape ...int) *tensor.NDArray {
 	size := 1
 	for _, dim := range shape {
 		size *= dim
@@ -116,7 +135,7 @@ func (rng *RNG) Exponential(scale float64, shape ...int) *tensor.NDArray {
 	
 	data := make([]float64, size)
 	for i := 0; i < size; i++ {
-		data[i] = -scale * math.Log(rng.source.Float64())
+		data[i] = scale * rng.standardExponential()
 	}
 	
 	return tensor.FromSliceFloat64(data, shape...)
@@ -124,46 +143,50 @@ func (rng *RNG) Exponential(scale float64, shape ...int) *tensor.NDArray {
 
 // Gamma generates random floats from a gamma distribution
 // Uses the Marsaglia and Tsang method
-func (rng *RNG) Gamma(shape, scale float64, size ...int) *tensor.NDArray {
-	n := 1
-	for _, dim := range size {
-		n *= dim
+func (rng *Generator) Gamma(shape, scale float64, size ...int) *tensor.NDArray {
+	if shape <= 0 {
+		panic("gamma shape must be positive")
+	}
+	return fill(size, func() float64 {
+		return scale * rng.standardGamma(shape)
+	})
+}
+
+// standardGamma draws from Gamma(shape, 1) with the Marsaglia and Tsang
+// method. Shapes below 1 are boosted: if X ~ Gamma(shape+1) and U is
+// uniform, X * U^(1/shape) ~ Gamma(shape).
+func (rng *Generator) standardGamma(shape float64) float64 {
+	if shape < 1 {
+		u := rng.source.Float64()
+		return rng.standardGamma(shape+1) * math.Pow(u, 1/shape)
 	}
-	
-	data := make([]float64, n)
 	
 	d := shape - 1.0/3.0
 	c := 1.0 / math.Sqrt(9.0*d)
 	
-	for i := 0; i < n; i++ {
-		for {
-			x := rng.source.NormFloat64()
-			v := 1.0 + c*x
-			
-			if v <= 0 {
-				continue
-			}
-			
-			v = v * v * v
-			u := rng.source.Float64()
-			
-			if u < 1.0-0.0331*(x*x)*(x*x) {
-				data[i] = scale * d * v
-				break
-			}
-			
-			if math.Log(u) < 0.5*x*x+d*(1.0-v+math.Log(v)) {
-				data[i] = scale * d * v
-				break
-			}
+	for {
+		x := rng.standardNormal()
+		v := 1.0 + c*x
+		
+		if v <= 0 {
+			continue
+		}
+		
+		v = v * v * v
+		u := rng.source.Float64()
+		
+		if u < 1.0-0.0331*(x*x)*(x*x) {
+			return d * v
+		}
+		
+		if math.Log(u) < 0.5*x*x+d*(1.0-v+math.Log(v)) {
+			return d * v
 		}
 	}
-	
-	return tensor.FromSliceFloat64(data, size...)
 }
 
 // Beta generates random floats from a beta distribution
-func (rng *RNG) Beta(alpha, beta float64, shape ...int) *tensor.NDArray {
+func (rng *Generator) Beta(alpha, beta float64, shape ...int) *tensor.NDArray {
 	size := 1
 	for _, dim := range shape {
 		size *= dim
@@ -186,12 +209,12 @@ func (rng *RNG) Beta(alpha, beta float64, shape ...int) *tensor.NDArray {
 }
 
 // Rand generates random floats from a uniform distribution [0, 1)
-func (rng *RNG) Rand(shape ...int) *tensor.NDArray {
+func (rng *Generator) Rand(shape ...int) *tensor.NDArray {
 	return rng.Uniform(0, 1, shape...)
 }
 
 // Randint generates random integers in [low, high)
-func (rng *RNG) Randint(low, high int, shape ...int) *tensor.NDArray {
+func (rng *Generator) Randint(low, high int, shape ...int) *tensor.NDArray {
 	size := 1
 	for _, dim := range shape {
 		size *= dim
@@ -208,7 +231,7 @@ func (rng *RNG) Randint(low, high int, shape ...int) *tensor.NDArray {
 }
 
 // Choice randomly selects elements from an array
-func (rng *RNG) Choice(arr *tensor.NDArray, size int) *tensor.NDArray {
+func (rng *Generator) Choice(arr *tensor.NDArray, size int) *tensor.NDArray {
 	n := arr.Size()
 	data := make([]float64, size)
 	
@@ -227,7 +250,7 @@ func (rng *RNG) Choice(arr *tensor.NDArray, size int) *tensor.NDArray {
 }
 
 // Permutation returns a random permutation of integers [0, n)
-func (rng *RNG) Permutation(n int) *tensor.NDArray {
+func (rng *Generator) Permutation(n int) *tensor.NDArray {
 	data := make([]int64, n)
 	for i := 0; i < n; i++ {
 		data[i] = int64(i)
@@ -242,37 +265,31 @@ func (rng *RNG) Permutation(n int) *tensor.NDArray {
 	return tensor.FromSliceInt64(data, n)
 }
 
-// Shuffle randomly shuffles an array in-place (modifies the array)
-func (rng *RNG) Shuffle(arr *tensor.NDArray) {
-	n := arr.Size()
-	
-	for i := n - 1; i > 0; i-- {
-		j := rng.source.Intn(i + 1)
-		
-		// Get indices for both positions
-		indicesI := make([]int, arr.Ndim())
-		indicesJ := make([]int, arr.Ndim())
-		
-		remainingI := i
-		remainingJ := j
-		
-		for k := arr.Ndim() - 1; k >= 0; k-- {
-			indicesI[k] = remainingI % arr.Shape()[k]
-			remainingI /= arr.Shape()[k]
-			
-			indicesJ[k] = remainingJ % arr.Shape()[k]
-			remainingJ /= arr.Shape()[k]
-		}
-		
-		// Swap values
-		valI := arr.GetFloat64(indicesI...)
-		valJ := arr.GetFloat64(indicesJ...)
-		arr.SetFloat64(valJ, indicesI...)
-		arr.SetFloat64(valI, indicesJ...)
+// PermutationOf returns a copy of arr with its slices along axis 0 in
+// random order, e.g. the rows of a dataset for a train/test split. It is
+// the out-of-place form of Shuffle.
+func (rng *Generator) PermutationOf(arr *tensor.NDArray) *tensor.NDArray {
+	result := arr.Copy()
+	rng.shuffleAxis(result, normalizeAxis(result, 0), false)
+	return result
+}
+
+// Shuffle randomly shuffles an array in-place along an axis (default 0),
+// moving whole slices so that e.g. the rows of a dataset keep their
+// features together. Use Permuted to shuffle elements independently.
+func (rng *Generator) Shuffle(arr *tensor.NDArray, axis ...int) {
+	ax := 0
+	if len(axis) > 0 {
+		ax = axis[0]
 	}
+	rng.shuffleAxis(arr, normalizeAxis(arr, ax), false)
 }
 
-// Seed sets the seed for the random number generator
-func (rng *RNG) Seed(seed int64) {
-	rng.source = rand.New(rand.NewSource(seed))
+// Seed resets the underlying bit generator to the stream for seed and
+// restarts Spawn, so children match those of a new generator with that
+// seed. It panics if the bit generator does not implement Seeder.
+func (rng *Generator) Seed(seed int64) {
+	rng.source.Seed(seed)
+	rng.spawnKey = spawnEntropy(rng.bits)
+	rng.spawned.Store(0)
 }
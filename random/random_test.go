@@ -1,7 +1,11 @@
 package random
 
 import (
+	"encoding/binary"
+	"encoding/json"
 	"math"
+	"math/rand"
+	"sync"
 	"testing"
 	
 	"github.com/iSundram/NumGo/tensor"
@@ -263,3 +267,1167 @@ func TestMultiDimensional(t *testing.T) {
 		t.Errorf("expected size 60, got %d", arr.Size())
 	}
 }
+
+// counterBits is a deliberately poor bit generator used to check that
+// Generator only depends on the BitGenerator interface
+type counterBits struct {
+	state uint64
+}
+
+func (c *counterBits) Uint64() uint64 {
+	return splitMix64(&c.state)
+}
+
+func TestGeneratorBitGenerator(t *testing.T) {
+	bits := &counterBits{}
+	rng := NewGenerator(bits)
+	if rng.BitGenerator() != bits {
+		t.Error("expected BitGenerator to return the wrapped source")
+	}
+	
+	arr := rng.Uniform(0, 1, 1000)
+	if mean := arr.Mean(); math.Abs(mean-0.5) > 0.05 {
+		t.Errorf("expected mean close to 0.5, got %f", mean)
+	}
+	
+	// Reseeding is only possible when the bit generator supports it
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic reseeding a bit generator without Seed")
+		}
+	}()
+	rng.Seed(1)
+}
+
+func TestNewMatchesStdSource(t *testing.T) {
+	// New keeps producing the math/rand stream for a given seed
+	rng := New(7)
+	std := rand.New(rand.NewSource(7))
+	arr := rng.Rand(20)
+	for i := 0; i < 20; i++ {
+		if want := std.Float64(); arr.GetFloat64(i) != want {
+			t.Fatalf("index %d: expected %v, got %v", i, want, arr.GetFloat64(i))
+		}
+	}
+	
+	rng.Seed(7)
+	if got := rng.Rand(1).GetFloat64(0); got != arr.GetFloat64(0) {
+		t.Errorf("expected reseeding to restart the stream, got %v", got)
+	}
+}
+
+func TestPCG64(t *testing.T) {
+	a, b := NewPCG64(42), NewPCG64(42)
+	for i := 0; i < 100; i++ {
+		if a.Uint64() != b.Uint64() {
+			t.Fatal("same seed produced different streams")
+		}
+	}
+	if NewPCG64(1).Uint64() == NewPCG64(2).Uint64() {
+		t.Error("different seeds produced the same first value")
+	}
+	
+	// Every output bit should be set about half of the time
+	p := NewPCG64(7)
+	const n = 20000
+	var counts [64]int
+	for i := 0; i < n; i++ {
+		x := p.Uint64()
+		for bit := 0; bit < 64; bit++ {
+			counts[bit] += int(x >> bit & 1)
+		}
+	}
+	for bit, c := range counts {
+		if frac := float64(c) / n; math.Abs(frac-0.5) > 0.02 {
+			t.Errorf("bit %d set with frequency %f", bit, frac)
+		}
+	}
+	
+	// Reseeding restarts the stream
+	first := NewPCG64(9).Uint64()
+	p.Seed(9)
+	if p.Uint64() != first {
+		t.Error("expected Seed to restart the stream")
+	}
+	
+	rng := NewGenerator(NewPCG64(3))
+	arr := rng.Normal(0, 1, 2000)
+	if math.Abs(arr.Mean()) > 0.1 || math.Abs(arr.Std()-1) > 0.1 {
+		t.Errorf("unexpected normal moments: mean %f std %f", arr.Mean(), arr.Std())
+	}
+}
+
+func TestPhilox(t *testing.T) {
+	// Known-answer vectors from the Random123 distribution
+	cases := []struct {
+		ctr  [4]uint64
+		key  [2]uint64
+		want [4]uint64
+	}{
+		{
+			[4]uint64{0, 0, 0, 0},
+			[2]uint64{0, 0},
+			[4]uint64{0x16554d9eca36314c, 0xdb20fe9d672d0fdc, 0xd7e772cee186176b, 0x7e68b68aec7ba23b},
+		},
+		{
+			[4]uint64{math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64},
+			[2]uint64{math.MaxUint64, math.MaxUint64},
+			[4]uint64{0x87b092c3013fe90b, 0x438c3c67be8d0224, 0x9cc7d7c69cd777b6, 0xa09caebf594f0ba0},
+		},
+		{
+			[4]uint64{0x243f6a8885a308d3, 0x13198a2e03707344, 0xa4093822299f31d0, 0x082efa98ec4e6c89},
+			[2]uint64{0x452821e638d01377, 0xbe5466cf34e90c6c},
+			[4]uint64{0xa528f45403e61d95, 0x38c72dbd566e9788, 0xa5a1610e72fd18b5, 0x57bd43b5e52b7fe6},
+		},
+	}
+	for _, c := range cases {
+		p := NewPhiloxKey(c.key, c.ctr)
+		for i, want := range c.want {
+			if got := p.Uint64(); got != want {
+				t.Errorf("key %x ctr %x output %d: expected %#x, got %#x", c.key, c.ctr, i, want, got)
+			}
+		}
+	}
+	
+	// The counter carries across words
+	p := NewPhiloxKey([2]uint64{1, 2}, [4]uint64{math.MaxUint64, 0, 0, 0})
+	p.Uint64()
+	if ctr := p.Counter(); ctr != [4]uint64{0, 1, 0, 0} {
+		t.Errorf("expected counter to carry, got %x", ctr)
+	}
+	
+	// Streams with different keys differ; seeded streams are reproducible
+	a := NewPhiloxKey([2]uint64{42, 0}, [4]uint64{})
+	b := NewPhiloxKey([2]uint64{42, 1}, [4]uint64{})
+	if a.Uint64() == b.Uint64() {
+		t.Error("different keys produced the same output")
+	}
+	s1, s2 := NewPhilox(5), NewPhilox(5)
+	for i := 0; i < 10; i++ {
+		if s1.Uint64() != s2.Uint64() {
+			t.Fatal("same seed produced different streams")
+		}
+	}
+	
+	arr := NewGenerator(NewPhilox(11)).Uniform(0, 1, 2000)
+	if math.Abs(arr.Mean()-0.5) > 0.03 {
+		t.Errorf("expected mean close to 0.5, got %f", arr.Mean())
+	}
+}
+
+func TestJumpedSpawn(t *testing.T) {
+	// Advance matches stepping the generator
+	p, q := NewPCG64(1), NewPCG64(1)
+	for i := 0; i < 1000; i++ {
+		p.Uint64()
+	}
+	q.Advance(1000)
+	if p.Uint64() != q.Uint64() {
+		t.Error("Advance does not match repeated Uint64")
+	}
+	
+	// Jumps compose and leave the original untouched
+	base := NewPCG64(2)
+	first := *base
+	twice := base.Jumped(1).(*PCG64).Jumped(1).(*PCG64)
+	if twice.Uint64() != base.Jumped(2).Uint64() {
+		t.Error("two single jumps differ from a double jump")
+	}
+	if *base != first {
+		t.Error("Jumped modified the original generator")
+	}
+	
+	// Philox jumps move the counter and keep the position within a block
+	ph := NewPhilox(3)
+	ph.Uint64()
+	jumped := ph.Jumped(1).(*Philox)
+	ctr := ph.Counter()
+	ctr[2]++
+	if jumped.Counter() != ctr {
+		t.Errorf("expected counter %x, got %x", ctr, jumped.Counter())
+	}
+	direct := NewPhiloxKey(ph.Key(), subCounter(ctr, 1))
+	direct.Uint64()
+	if jumped.Uint64() != direct.Uint64() {
+		t.Error("jumped Philox does not resume mid-block")
+	}
+	
+	// Spawned children are reproducible and distinct
+	rng1, rng2 := NewGenerator(NewPCG64(4)), NewGenerator(NewPCG64(4))
+	c1 := append(rng1.Spawn(2), rng1.Spawn(1)...)
+	c2 := rng2.Spawn(3)
+	seen := map[uint64]bool{}
+	for i := range c1 {
+		a, b := c1[i].BitGenerator().Uint64(), c2[i].BitGenerator().Uint64()
+		if a != b {
+			t.Errorf("child %d is not reproducible", i)
+		}
+		if seen[a] {
+			t.Errorf("child %d repeats another child's stream", i)
+		}
+		seen[a] = true
+	}
+	
+	// Nested spawns never repeat a sibling's stream
+	for _, bits := range []BitGenerator{NewPCG64(5), NewPhilox(5), NewStdSource(5)} {
+		root := NewGenerator(bits)
+		children := root.Spawn(2)
+		grandchild := children[0].Spawn(1)[0]
+		if grandchild.BitGenerator().Uint64() == children[1].BitGenerator().Uint64() {
+			t.Errorf("%T: grandchild repeats its parent's sibling", bits)
+		}
+	}
+	
+	// Spawning resumes from a saved state and restarts after reseeding
+	rng3 := New(6)
+	state := rng3.State()
+	want := rng3.Spawn(1)[0].Rand(1).GetFloat64(0)
+	restored, err := NewFromState(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := restored.Spawn(1)[0].Rand(1).GetFloat64(0); got != want {
+		t.Errorf("restored generator spawned %v, expected %v", got, want)
+	}
+	rng3.Seed(6)
+	if got := rng3.Spawn(1)[0].Rand(1).GetFloat64(0); got != want {
+		t.Errorf("reseeded generator spawned %v, expected %v", got, want)
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic jumping a StdSource")
+		}
+	}()
+	New(1).Jumped(1)
+}
+
+func TestCryptoSource(t *testing.T) {
+	rng := NewGenerator(NewCryptoSource())
+	arr := rng.Randint(0, 100, 500)
+	for i := 0; i < arr.Size(); i++ {
+		if v := arr.GetInt64(i); v < 0 || v >= 100 {
+			t.Fatalf("value %d out of range [0, 100)", v)
+		}
+	}
+	if math.Abs(rng.Rand(2000).Mean()-0.5) > 0.05 {
+		t.Error("expected uniform mean close to 0.5")
+	}
+	
+	if _, ok := rng.BitGenerator().(Seeder); ok {
+		t.Error("crypto source must not be seedable")
+	}
+}
+
+func TestLockedAndLocal(t *testing.T) {
+	rng := NewLocked(NewPCG64(1))
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			arr := rng.Normal(0, 1, 500)
+			if arr.Size() != 500 {
+				t.Errorf("expected size 500, got %d", arr.Size())
+			}
+		}()
+	}
+	wg.Wait()
+	
+	// Locked generators can still be reseeded and spawn unlocked children
+	rng.Seed(2)
+	if _, ok := rng.Spawn(1)[0].BitGenerator().(*PCG64); !ok {
+		t.Error("expected an unlocked PCG64 child")
+	}
+	
+	means := make([]float64, 8)
+	for g := range means {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := Local()
+			defer PutLocal(local)
+			means[g] = local.Rand(1000).Mean()
+		}()
+	}
+	wg.Wait()
+	for g, mean := range means {
+		if math.Abs(mean-0.5) > 0.05 {
+			t.Errorf("goroutine %d: expected mean close to 0.5, got %f", g, mean)
+		}
+	}
+	if means[0] == means[1] {
+		t.Error("expected local generators on different streams")
+	}
+}
+
+func TestChoiceWith(t *testing.T) {
+	rng := New(42)
+	arr := tensor.FromSliceFloat64([]float64{10, 20, 30, 40}, 4)
+	
+	// Weighted with replacement follows p
+	p := tensor.FromSliceFloat64([]float64{0.1, 0, 0.6, 0.3}, 4)
+	draws := rng.ChoiceWith(arr, ChoiceOptions{P: p}, 100, 50)
+	if shape := draws.Shape(); len(shape) != 2 || shape[0] != 100 || shape[1] != 50 {
+		t.Fatalf("expected shape [100 50], got %v", shape)
+	}
+	counts := map[float64]int{}
+	for _, v := range draws.ToSliceFloat64() {
+		counts[v]++
+	}
+	if counts[20] != 0 {
+		t.Errorf("zero-probability candidate chosen %d times", counts[20])
+	}
+	for v, want := range map[float64]float64{10: 0.1, 30: 0.6, 40: 0.3} {
+		if frac := float64(counts[v]) / 5000; math.Abs(frac-want) > 0.03 {
+			t.Errorf("value %v: expected frequency %f, got %f", v, want, frac)
+		}
+	}
+	
+	// Without replacement every candidate appears at most once
+	for _, opts := range []ChoiceOptions{{NoReplace: true}, {NoReplace: true, P: p}} {
+		sample := rng.ChoiceWith(arr, opts, 3).ToSliceFloat64()
+		seen := map[float64]bool{}
+		for _, v := range sample {
+			if seen[v] {
+				t.Errorf("value %v chosen twice without replacement", v)
+			}
+			seen[v] = true
+		}
+		if opts.P != nil && seen[20] {
+			t.Error("zero-probability candidate chosen without replacement")
+		}
+	}
+	
+	// Weighted sampling without replacement picks heavy items first
+	first := 0
+	for i := 0; i < 2000; i++ {
+		if rng.ChoiceWith(arr, ChoiceOptions{P: p, NoReplace: true}, 1).GetFloat64(0) == 30 {
+			first++
+		}
+	}
+	if frac := float64(first) / 2000; math.Abs(frac-0.6) > 0.04 {
+		t.Errorf("expected first draw frequency 0.6, got %f", frac)
+	}
+	
+	// Sampling rows and columns of a matrix
+	m := tensor.FromSliceInt64([]int64{0, 1, 2, 10, 11, 12, 20, 21, 22}, 3, 3)
+	rows := rng.ChoiceWith(m, ChoiceOptions{NoReplace: true}, 3)
+	if rows.DType() != tensor.Int64 {
+		t.Errorf("expected dtype preserved, got %v", rows.DType())
+	}
+	seen := map[int64]bool{}
+	for i := 0; i < 3; i++ {
+		r := rows.GetInt64(i, 0)
+		for j := 0; j < 3; j++ {
+			if rows.GetInt64(i, j) != r+int64(j) {
+				t.Fatalf("row %d is not a row of the input: %v", i, rows.ToSliceInt64())
+			}
+		}
+		seen[r] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected a permutation of rows, got %v", rows.ToSliceInt64())
+	}
+	cols := rng.ChoiceWith(m, ChoiceOptions{Axis: 1}, 2, 2)
+	if shape := cols.Shape(); len(shape) != 3 || shape[0] != 3 || shape[1] != 2 || shape[2] != 2 {
+		t.Fatalf("expected shape [3 2 2], got %v", shape)
+	}
+	for i := 0; i < 3; i++ {
+		if cols.GetInt64(i, 1, 0)-cols.GetInt64(0, 1, 0) != int64(10*i) {
+			t.Errorf("column samples are inconsistent across rows: %v", cols.ToSliceInt64())
+		}
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic sampling more than available without replacement")
+		}
+	}()
+	rng.ChoiceWith(arr, ChoiceOptions{NoReplace: true}, 5)
+}
+
+func TestHypergeometric(t *testing.T) {
+	rng := New(42)
+	cases := []struct{ good, bad, sample int }{
+		{7, 5, 4},       // direct simulation
+		{7, 5, 10},      // sample larger than half the population
+		{300, 700, 200}, // ratio-of-uniforms
+		{900, 100, 850},
+	}
+	for _, c := range cases {
+		arr := rng.Hypergeometric(c.good, c.bad, c.sample, 4000)
+		total := float64(c.good + c.bad)
+		mean := float64(c.sample) * float64(c.good) / total
+		variance := mean * float64(c.bad) / total * (total - float64(c.sample)) / (total - 1)
+		
+		for _, v := range arr.ToSliceFloat64() {
+			if v < math.Max(0, float64(c.sample-c.bad)) || v > math.Min(float64(c.sample), float64(c.good)) || v != math.Floor(v) {
+				t.Fatalf("%v: value %v outside the support", c, v)
+			}
+		}
+		if math.Abs(arr.Mean()-mean) > 4*math.Sqrt(variance/4000)+1e-9 {
+			t.Errorf("%v: expected mean %f, got %f", c, mean, arr.Mean())
+		}
+		if got := arr.Std() * arr.Std(); math.Abs(got-variance) > 0.15*variance+1e-9 {
+			t.Errorf("%v: expected variance %f, got %f", c, variance, got)
+		}
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for nsample larger than the population")
+		}
+	}()
+	rng.Hypergeometric(2, 3, 6)
+}
+
+func TestNegativeBinomialGeometric(t *testing.T) {
+	rng := New(42)
+	
+	for _, c := range []struct{ n, p float64 }{{5, 0.5}, {2.5, 0.3}, {1000, 0.2}} {
+		arr := rng.NegativeBinomial(c.n, c.p, 5000)
+		mean := c.n * (1 - c.p) / c.p
+		variance := mean / c.p
+		if math.Abs(arr.Mean()-mean) > 4*math.Sqrt(variance/5000) {
+			t.Errorf("NegativeBinomial(%v, %v): expected mean %f, got %f", c.n, c.p, mean, arr.Mean())
+		}
+		if got := arr.Std() * arr.Std(); math.Abs(got-variance) > 0.1*variance {
+			t.Errorf("NegativeBinomial(%v, %v): expected variance %f, got %f", c.n, c.p, variance, got)
+		}
+	}
+	
+	geo := rng.Geometric(0.25, 5000)
+	for _, v := range geo.ToSliceFloat64() {
+		if v < 1 || v != math.Floor(v) {
+			t.Fatalf("geometric value %v outside the support", v)
+		}
+	}
+	if math.Abs(geo.Mean()-4) > 0.2 {
+		t.Errorf("expected geometric mean close to 4, got %f", geo.Mean())
+	}
+	if rng.Geometric(1, 3).Sum() != 3 {
+		t.Error("expected Geometric(1) to always be 1")
+	}
+	
+	// Fractional gamma shapes are now supported
+	if g := rng.Gamma(0.5, 2, 5000); math.Abs(g.Mean()-1) > 0.1 {
+		t.Errorf("expected Gamma(0.5, 2) mean close to 1, got %f", g.Mean())
+	}
+}
+
+func TestChiSquareStudentF(t *testing.T) {
+	rng := New(42)
+	
+	chi := rng.ChiSquare(4, 5000)
+	if math.Abs(chi.Mean()-4) > 0.2 {
+		t.Errorf("expected chi-square mean close to 4, got %f", chi.Mean())
+	}
+	if v := chi.Std() * chi.Std(); math.Abs(v-8) > 0.8 {
+		t.Errorf("expected chi-square variance close to 8, got %f", v)
+	}
+	
+	st := rng.StandardT(10, 5000)
+	if math.Abs(st.Mean()) > 0.1 {
+		t.Errorf("expected t mean close to 0, got %f", st.Mean())
+	}
+	if v := st.Std() * st.Std(); math.Abs(v-1.25) > 0.15 {
+		t.Errorf("expected t variance close to 1.25, got %f", v)
+	}
+	
+	f := rng.F(5, 20, 5000)
+	if math.Abs(f.Mean()-20.0/18) > 0.06 {
+		t.Errorf("expected F mean close to %f, got %f", 20.0/18, f.Mean())
+	}
+	if f.Min() < 0 {
+		t.Error("expected non-negative F samples")
+	}
+}
+
+func TestExtremeValueFamilies(t *testing.T) {
+	rng := New(42)
+	const n = 20000
+	cases := []struct {
+		name           string
+		arr            *tensor.NDArray
+		mean, variance float64
+	}{
+		// Weibull(2): Gamma(1.5) = sqrt(pi)/2, variance 1 - pi/4
+		{"Weibull", rng.Weibull(2, n), math.Sqrt(math.Pi) / 2, 1 - math.Pi/4},
+		{"Lognormal", rng.Lognormal(0, 0.5, n), math.Exp(0.125), (math.Exp(0.25) - 1) * math.Exp(0.25)},
+		{"Laplace", rng.Laplace(1, 2, n), 1, 8},
+		{"Logistic", rng.Logistic(-1, 0.5, n), -1, 0.25 * math.Pi * math.Pi / 3},
+		{"Gumbel", rng.Gumbel(0, 1, n), 0.5772156649015329, math.Pi * math.Pi / 6},
+	}
+	for _, c := range cases {
+		if c.arr.Size() != n {
+			t.Errorf("%s: expected size %d, got %d", c.name, n, c.arr.Size())
+		}
+		if math.Abs(c.arr.Mean()-c.mean) > 5*math.Sqrt(c.variance/n) {
+			t.Errorf("%s: expected mean %f, got %f", c.name, c.mean, c.arr.Mean())
+		}
+		if v := c.arr.Std() * c.arr.Std(); math.Abs(v-c.variance) > 0.1*c.variance {
+			t.Errorf("%s: expected variance %f, got %f", c.name, c.variance, v)
+		}
+	}
+	
+	if rng.Weibull(1.5, 100).Min() < 0 || rng.Lognormal(0, 1, 100).Min() <= 0 {
+		t.Error("expected positive Weibull and lognormal samples")
+	}
+}
+
+func TestMoreContinuousDistributions(t *testing.T) {
+	rng := New(42)
+	const n = 20000
+	cases := []struct {
+		name           string
+		arr            *tensor.NDArray
+		mean, variance float64
+	}{
+		{"Pareto", rng.Pareto(5, n), 0.25, 5.0 / (16 * 3)},
+		{"Rayleigh", rng.Rayleigh(2, n), 2 * math.Sqrt(math.Pi/2), (4 - math.Pi) / 2 * 4},
+		{"Triangular", rng.Triangular(0, 1, 4, n), 5.0 / 3, (16 + 1 - 4) / 18.0},
+		{"Wald", rng.Wald(2, 3, n), 2, 8.0 / 3},
+	}
+	for _, c := range cases {
+		if math.Abs(c.arr.Mean()-c.mean) > 5*math.Sqrt(c.variance/n) {
+			t.Errorf("%s: expected mean %f, got %f", c.name, c.mean, c.arr.Mean())
+		}
+		if v := c.arr.Std() * c.arr.Std(); math.Abs(v-c.variance) > 0.15*c.variance {
+			t.Errorf("%s: expected variance %f, got %f", c.name, c.variance, v)
+		}
+	}
+	
+	tri := rng.Triangular(-1, 0, 3, 1000)
+	if tri.Min() < -1 || tri.Max() > 3 {
+		t.Errorf("triangular samples outside [-1, 3]: [%f, %f]", tri.Min(), tri.Max())
+	}
+	
+	// Von Mises: mean direction mu, E[cos(x - mu)] = I1(kappa)/I0(kappa)
+	vm := rng.VonMises(1, 4, n)
+	var sumSin, sumCos float64
+	for _, x := range vm.ToSliceFloat64() {
+		if x < -math.Pi || x > math.Pi {
+			t.Fatalf("von Mises sample %f outside [-pi, pi]", x)
+		}
+		sumSin += math.Sin(x)
+		sumCos += math.Cos(x)
+	}
+	if dir := math.Atan2(sumSin, sumCos); math.Abs(dir-1) > 0.03 {
+		t.Errorf("expected mean direction 1, got %f", dir)
+	}
+	if r := math.Hypot(sumSin, sumCos) / n; math.Abs(r-0.8635) > 0.01 {
+		t.Errorf("expected mean resultant length 0.8635, got %f", r)
+	}
+	if wide := rng.VonMises(3, 1e7, 100); wide.Min() < -math.Pi || wide.Max() > math.Pi {
+		t.Error("expected wrapped samples for large kappa near pi")
+	}
+	
+	// Zipf(3): P(1) = 1/zeta(3)
+	z := rng.Zipf(3, n)
+	ones := 0
+	for _, v := range z.ToSliceFloat64() {
+		if v < 1 || v != math.Floor(v) {
+			t.Fatalf("zipf value %v outside the support", v)
+		}
+		if v == 1 {
+			ones++
+		}
+	}
+	if frac := float64(ones) / n; math.Abs(frac-1/1.2020569031595942) > 0.015 {
+		t.Errorf("expected P(1) = %f, got %f", 1/1.2020569031595942, frac)
+	}
+}
+
+func TestStandardCauchyPower(t *testing.T) {
+	rng := New(42)
+	const n = 20000
+	
+	// The Cauchy distribution has no mean; check its quartiles at -1 and 1
+	c := rng.StandardCauchy(n)
+	inside := 0
+	for _, v := range c.ToSliceFloat64() {
+		if math.Abs(v) <= 1 {
+			inside++
+		}
+	}
+	if frac := float64(inside) / n; math.Abs(frac-0.5) > 0.02 {
+		t.Errorf("expected half of the samples in [-1, 1], got %f", frac)
+	}
+	
+	p := rng.Power(3, n)
+	if p.Min() < 0 || p.Max() > 1 {
+		t.Errorf("power samples outside [0, 1]: [%f, %f]", p.Min(), p.Max())
+	}
+	if math.Abs(p.Mean()-0.75) > 0.01 {
+		t.Errorf("expected mean close to 0.75, got %f", p.Mean())
+	}
+}
+
+func TestIntegers(t *testing.T) {
+	rng := NewGenerator(NewPCG64(42))
+	
+	// Inclusive upper bound on a small dtype
+	arr := rng.Integers(-3, 3, []int{20, 50}, tensor.Int8, true)
+	if arr.DType() != tensor.Int8 {
+		t.Errorf("expected int8, got %v", arr.DType())
+	}
+	counts := map[int64]int{}
+	for _, v := range arr.ToSliceInt64() {
+		if v < -3 || v > 3 {
+			t.Fatalf("value %d out of range [-3, 3]", v)
+		}
+		counts[v]++
+	}
+	if len(counts) != 7 {
+		t.Errorf("expected all 7 values to appear, got %v", counts)
+	}
+	
+	u := rng.Integers(250, 256, []int{100}, tensor.Uint8, false)
+	for _, v := range u.ToSliceInt64() {
+		if v < 250 || v > 255 {
+			t.Fatalf("uint8 value %d out of range [250, 256)", v)
+		}
+	}
+	
+	// Spans beyond int32 and the full int64 range
+	big := rng.Integers(0, 1<<40, []int{2000}, tensor.Int64, false)
+	if mean := big.Mean() / (1 << 40); math.Abs(mean-0.5) > 0.03 {
+		t.Errorf("expected mean close to half the span, got %f", mean)
+	}
+	full := rng.Integers(math.MinInt64, math.MaxInt64, []int{1000}, tensor.Int64, true)
+	negative := 0
+	for _, v := range full.ToSliceInt64() {
+		if v < 0 {
+			negative++
+		}
+	}
+	if negative < 400 || negative > 600 {
+		t.Errorf("expected about half negative over the full range, got %d", negative)
+	}
+	
+	if single := rng.Integers(7, 7, []int{3}, tensor.Int32, true); single.Sum() != 21 {
+		t.Errorf("expected [7 7 7], got %v", single.ToSliceInt64())
+	}
+	
+	for _, bad := range []func(){
+		func() { rng.Integers(5, 5, []int{1}, tensor.Int64, false) },
+		func() { rng.Integers(0, 300, []int{1}, tensor.Uint8, false) },
+		func() { rng.Integers(0, 1, []int{1}, tensor.Float64, false) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			bad()
+		}()
+	}
+}
+
+func TestBytesBits(t *testing.T) {
+	rng := NewGenerator(NewPCG64(1))
+	
+	buf := rng.Bytes(13)
+	if len(buf) != 13 {
+		t.Fatalf("expected 13 bytes, got %d", len(buf))
+	}
+	// Bytes are the bit generator's output in little-endian order
+	ref := NewPCG64(1)
+	first := ref.Uint64()
+	for i := 0; i < 8; i++ {
+		if buf[i] != byte(first>>(8*i)) {
+			t.Fatalf("byte %d does not match the bit stream", i)
+		}
+	}
+	
+	u8 := rng.Bits([]int{4000}, tensor.Uint8)
+	if u8.DType() != tensor.Uint8 {
+		t.Errorf("expected uint8, got %v", u8.DType())
+	}
+	if mean := u8.Mean(); math.Abs(mean-127.5) > 5 {
+		t.Errorf("expected mean close to 127.5, got %f", mean)
+	}
+	
+	mask := rng.Bits([]int{100, 40}, tensor.Bool)
+	if ones := mask.Sum(); math.Abs(ones-2000) > 150 {
+		t.Errorf("expected about 2000 true values, got %f", ones)
+	}
+	
+	i64 := rng.Bits([]int{1000}, tensor.Int64)
+	negative := 0
+	for _, v := range i64.ToSliceInt64() {
+		if v < 0 {
+			negative++
+		}
+	}
+	if negative < 400 || negative > 600 {
+		t.Errorf("expected about half negative int64 values, got %d", negative)
+	}
+}
+
+func TestShuffleAxisPermuted(t *testing.T) {
+	rng := New(42)
+	
+	// Shuffling rows keeps each row intact
+	m := tensor.FromSliceInt64([]int64{0, 1, 2, 10, 11, 12, 20, 21, 22, 30, 31, 32}, 4, 3)
+	rng.Shuffle(m)
+	rows := map[int64]bool{}
+	for i := 0; i < 4; i++ {
+		r := m.GetInt64(i, 0)
+		for j := 0; j < 3; j++ {
+			if m.GetInt64(i, j) != r+int64(j) {
+				t.Fatalf("row %d was broken up: %v", i, m.ToSliceInt64())
+			}
+		}
+		rows[r] = true
+	}
+	if len(rows) != 4 {
+		t.Errorf("expected a permutation of the rows, got %v", m.ToSliceInt64())
+	}
+	
+	// Shuffling along axis 1 keeps each column intact
+	rng.Shuffle(m, -1)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			if m.GetInt64(i, j)%10 != m.GetInt64(0, j)%10 {
+				t.Fatalf("column %d was broken up: %v", j, m.ToSliceInt64())
+			}
+		}
+	}
+	
+	// Permuted shuffles each row independently and leaves the input alone
+	src := tensor.FromSliceInt64([]int64{
+		0, 1, 2, 3, 4, 5, 6, 7,
+		0, 1, 2, 3, 4, 5, 6, 7,
+		0, 1, 2, 3, 4, 5, 6, 7,
+	}, 3, 8)
+	p := rng.Permuted(src, 1)
+	if src.GetInt64(0, 1) != 1 {
+		t.Error("Permuted modified its input")
+	}
+	for i := 0; i < 3; i++ {
+		seen := map[int64]bool{}
+		for j := 0; j < 8; j++ {
+			seen[p.GetInt64(i, j)] = true
+		}
+		if len(seen) != 8 {
+			t.Errorf("row %d is not a permutation: %v", i, p.ToSliceInt64())
+		}
+	}
+	same := true
+	for j := 0; j < 8; j++ {
+		if p.GetInt64(0, j) != p.GetInt64(1, j) || p.GetInt64(1, j) != p.GetInt64(2, j) {
+			same = false
+		}
+	}
+	if same {
+		t.Error("expected rows to be shuffled independently")
+	}
+	
+	flat := rng.Permuted(src)
+	if flat.Sum() != src.Sum() || len(flat.Shape()) != 2 {
+		t.Errorf("flat permutation lost elements or shape: %v", flat.Shape())
+	}
+}
+
+func TestPermutationOf(t *testing.T) {
+	rng := New(7)
+	data := tensor.FromSliceFloat64([]float64{
+		1, 10,
+		2, 20,
+		3, 30,
+		4, 40,
+		5, 50,
+	}, 5, 2)
+	
+	perm := rng.PermutationOf(data)
+	if data.GetFloat64(0, 0) != 1 {
+		t.Error("PermutationOf modified its input")
+	}
+	seen := map[float64]bool{}
+	for i := 0; i < 5; i++ {
+		x := perm.GetFloat64(i, 0)
+		if perm.GetFloat64(i, 1) != 10*x {
+			t.Fatalf("row %d was broken up: %v", i, perm.ToSliceFloat64())
+		}
+		seen[x] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("expected a permutation of the rows, got %v", perm.ToSliceFloat64())
+	}
+	
+	// 1D arrays are permuted element-wise
+	v := rng.PermutationOf(tensor.FromSliceFloat64([]float64{1, 2, 3, 4}, 4))
+	if v.Sum() != 10 {
+		t.Errorf("expected the same elements, got %v", v.ToSliceFloat64())
+	}
+}
+
+func TestSample(t *testing.T) {
+	rng := NewGenerator(NewPCG64(3))
+	
+	s := rng.Sample(1_000_000_000, 1000)
+	if s.Size() != 1000 || s.DType() != tensor.Int64 {
+		t.Fatalf("expected 1000 int64 values, got %d %v", s.Size(), s.DType())
+	}
+	seen := map[int64]bool{}
+	for _, v := range s.ToSliceInt64() {
+		if v < 0 || v >= 1_000_000_000 {
+			t.Fatalf("value %d out of range", v)
+		}
+		if seen[v] {
+			t.Fatalf("duplicate value %d", v)
+		}
+		seen[v] = true
+	}
+	
+	// Sampling everything gives a permutation; each value is equally likely
+	all := rng.Sample(5, 5).ToSliceInt64()
+	if len(all) != 5 {
+		t.Fatalf("expected 5 values, got %v", all)
+	}
+	counts := make([]int, 10)
+	for i := 0; i < 5000; i++ {
+		for _, v := range rng.Sample(10, 3).ToSliceInt64() {
+			counts[v]++
+		}
+	}
+	for v, c := range counts {
+		if math.Abs(float64(c)-1500) > 150 {
+			t.Errorf("value %d chosen %d times, expected about 1500", v, c)
+		}
+	}
+}
+
+func TestGeneratorState(t *testing.T) {
+	for _, rng := range []*Generator{
+		New(1),
+		NewGenerator(NewPCG64(2)),
+		NewGenerator(NewPhilox(3)),
+		NewLocked(NewPCG64(4)),
+	} {
+		rng.Normal(0, 1, 7)
+		if _, ok := rng.BitGenerator().(Jumper); ok {
+			rng.Spawn(2)
+		}
+		state := rng.State()
+		want := rng.Rand(5).ToSliceFloat64()
+		
+		// Restoring in place rewinds the stream
+		if err := rng.SetState(state); err != nil {
+			t.Fatalf("SetState: %v", err)
+		}
+		if got := rng.Rand(5).ToSliceFloat64(); !sliceEqual(got, want) {
+			t.Errorf("%T: expected %v after SetState, got %v", rng.BitGenerator(), want, got)
+		}
+		
+		// So does building a new generator, from bytes or JSON
+		restored, err := NewFromState(state)
+		if err != nil {
+			t.Fatalf("NewFromState: %v", err)
+		}
+		if got := restored.Rand(5).ToSliceFloat64(); !sliceEqual(got, want) {
+			t.Errorf("%T: expected %v from NewFromState, got %v", rng.BitGenerator(), want, got)
+		}
+		if restored.spawned.Load() != rng.spawned.Load() {
+			t.Errorf("expected the spawn count to be restored, got %d", restored.spawned.Load())
+		}
+		
+		rng.SetState(state)
+		data, err := json.Marshal(rng)
+		if err != nil {
+			t.Fatalf("MarshalJSON: %v", err)
+		}
+		var decoded Generator
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("UnmarshalJSON: %v", err)
+		}
+		if got := decoded.Rand(5).ToSliceFloat64(); !sliceEqual(got, want) {
+			t.Errorf("%T: expected %v from JSON, got %v", rng.BitGenerator(), want, got)
+		}
+	}
+	
+	if err := NewGenerator(NewPhilox(1)).SetState(New(1).State()); err == nil {
+		t.Error("expected an error restoring a state from another bit generator")
+	}
+	if _, err := NewFromState([]byte{3, 'a'}); err == nil {
+		t.Error("expected an error for a truncated state")
+	}
+	
+	// Corrupt StdSource states fail instead of replaying forever
+	corrupt := make([]byte, 16)
+	binary.LittleEndian.PutUint64(corrupt[8:], math.MaxUint64)
+	if err := NewStdSource(1).UnmarshalBinary(corrupt); err == nil {
+		t.Error("expected an error for a StdSource state past the replay limit")
+	}
+}
+
+func TestLockedState(t *testing.T) {
+	// Snapshots of a locked generator are taken under its lock; run with
+	// -race to check
+	rng := NewLocked(NewPCG64(1))
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng.Rand(2000)
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		if _, err := NewFromState(rng.State()); err != nil {
+			t.Errorf("snapshot %d: %v", i, err)
+		}
+		if _, err := rng.MarshalJSON(); err != nil {
+			t.Errorf("snapshot %d: %v", i, err)
+		}
+	}
+	wg.Wait()
+}
+
+func sliceEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestZigguratAndRejectionSamplers(t *testing.T) {
+	rng := NewGenerator(NewPCG64(5))
+	const n = 200000
+	
+	// Empirical CDFs match the normal and exponential distributions,
+	// including the tails handled outside the ziggurat layers
+	normal := rng.StandardNormal(n).ToSliceFloat64()
+	for _, x := range []float64{-3.8, -2, -0.5, 0, 1, 2.5, 3.8} {
+		below := 0
+		for _, v := range normal {
+			if v < x {
+				below++
+			}
+		}
+		want := 0.5 * math.Erfc(-x/math.Sqrt2)
+		if got := float64(below) / n; math.Abs(got-want) > 4*math.Sqrt(want*(1-want)/n)+1e-4 {
+			t.Errorf("normal CDF at %v: expected %f, got %f", x, want, got)
+		}
+	}
+	expo := rng.Exponential(1, n).ToSliceFloat64()
+	for _, x := range []float64{0.1, 1, 3, 8} {
+		below := 0
+		for _, v := range expo {
+			if v < 0 {
+				t.Fatalf("negative exponential sample %f", v)
+			}
+			if v < x {
+				below++
+			}
+		}
+		want := -math.Expm1(-x)
+		if got := float64(below) / n; math.Abs(got-want) > 4*math.Sqrt(want*(1-want)/n)+1e-4 {
+			t.Errorf("exponential CDF at %v: expected %f, got %f", x, want, got)
+		}
+	}
+	
+	// Binomial moments across the inversion and BTPE regimes
+	for _, c := range []struct {
+		n int
+		p float64
+	}{{20, 0.3}, {1000, 0.02}, {1000, 0.3}, {1000000, 0.5}, {500, 0.97}} {
+		arr := rng.Binomial(c.n, c.p, 20000)
+		mean := float64(c.n) * c.p
+		variance := mean * (1 - c.p)
+		if math.Abs(arr.Mean()-mean) > 4*math.Sqrt(variance/20000) {
+			t.Errorf("Binomial(%d, %v): expected mean %f, got %f", c.n, c.p, mean, arr.Mean())
+		}
+		if v := arr.Std() * arr.Std(); math.Abs(v-variance) > 0.05*variance {
+			t.Errorf("Binomial(%d, %v): expected variance %f, got %f", c.n, c.p, variance, v)
+		}
+		if arr.Min() < 0 || arr.Max() > float64(c.n) {
+			t.Errorf("Binomial(%d, %v): samples outside [0, n]", c.n, c.p)
+		}
+	}
+	
+	// Poisson no longer underflows for large lambda
+	for _, lambda := range []float64{3, 50, 1e6} {
+		arr := rng.Poisson(lambda, 20000)
+		if math.Abs(arr.Mean()-lambda) > 4*math.Sqrt(lambda/20000) {
+			t.Errorf("Poisson(%v): expected mean %f, got %f", lambda, lambda, arr.Mean())
+		}
+		if v := arr.Std() * arr.Std(); math.Abs(v-lambda) > 0.05*lambda {
+			t.Errorf("Poisson(%v): expected variance %f, got %f", lambda, lambda, v)
+		}
+	}
+}
+
+func TestLatinHypercube(t *testing.T) {
+	rng := NewGenerator(NewPCG64(8))
+	
+	// Strength 1: one point per stratum in every column
+	design := rng.LatinHypercube(10, 3, 1)
+	if shape := design.Shape(); shape[0] != 10 || shape[1] != 3 {
+		t.Fatalf("expected shape [10 3], got %v", shape)
+	}
+	for j := 0; j < 3; j++ {
+		strata := map[int]bool{}
+		for i := 0; i < 10; i++ {
+			strata[int(design.GetFloat64(i, j)*10)] = true
+		}
+		if len(strata) != 10 {
+			t.Errorf("column %d is not stratified: %v", j, strata)
+		}
+	}
+	
+	// Strength 2: stratified in each column and in every pair of columns
+	// on the coarse p x p grid
+	const p = 5
+	oa := rng.LatinHypercube(p*p, 4, 2)
+	for j := 0; j < 4; j++ {
+		strata := map[int]bool{}
+		for i := 0; i < p*p; i++ {
+			v := oa.GetFloat64(i, j)
+			if v < 0 || v >= 1 {
+				t.Fatalf("value %f outside [0, 1)", v)
+			}
+			strata[int(v*p*p)] = true
+		}
+		if len(strata) != p*p {
+			t.Errorf("column %d is not a Latin hypercube", j)
+		}
+		for k := j + 1; k < 4; k++ {
+			cells := map[[2]int]bool{}
+			for i := 0; i < p*p; i++ {
+				cells[[2]int{int(oa.GetFloat64(i, j) * p), int(oa.GetFloat64(i, k) * p)}] = true
+			}
+			if len(cells) != p*p {
+				t.Errorf("columns %d and %d are not jointly stratified", j, k)
+			}
+		}
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for n that is not a prime square")
+		}
+	}()
+	rng.LatinHypercube(16, 2, 2)
+}
+
+func TestBootstrap(t *testing.T) {
+	data := NewGenerator(NewPCG64(1)).Normal(10, 2, 400)
+	mean := func(a *tensor.NDArray) float64 { return a.Mean() }
+	
+	rng := NewGenerator(NewPCG64(2))
+	dist := rng.Bootstrap(data, 2000, mean)
+	if dist.Size() != 2000 {
+		t.Fatalf("expected 2000 statistics, got %d", dist.Size())
+	}
+	// The bootstrap standard error of the mean is about sigma / sqrt(n)
+	if se := dist.Std(); math.Abs(se-0.1) > 0.015 {
+		t.Errorf("expected standard error close to 0.1, got %f", se)
+	}
+	if math.Abs(dist.Mean()-data.Mean()) > 0.02 {
+		t.Errorf("expected bootstrap means centred on %f, got %f", data.Mean(), dist.Mean())
+	}
+	
+	// Rows are resampled whole
+	pairs := tensor.FromSliceFloat64([]float64{1, 2, 3, 6, 5, 10}, 3, 2)
+	ratio := func(a *tensor.NDArray) float64 {
+		for i := 0; i < a.Shape()[0]; i++ {
+			if a.GetFloat64(i, 1) != 2*a.GetFloat64(i, 0) {
+				return 0
+			}
+		}
+		return 1
+	}
+	if rng.Bootstrap(pairs, 50, ratio).Min() != 1 {
+		t.Error("expected rows to stay intact when resampling")
+	}
+	
+	// Parallel results do not depend on the number of workers
+	a := NewGenerator(NewPCG64(3)).BootstrapParallel(data, 200, mean, 1)
+	b := NewGenerator(NewPCG64(3)).BootstrapParallel(data, 200, mean, 8)
+	if !sliceEqual(a.ToSliceFloat64(), b.ToSliceFloat64()) {
+		t.Error("expected identical results for different worker counts")
+	}
+}
+
+func TestRandomMatrices(t *testing.T) {
+	rng := NewGenerator(NewPCG64(6))
+	
+	q := rng.OrthogonalMatrix(6)
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 6; j++ {
+			dot := 0.0
+			for k := 0; k < 6; k++ {
+				dot += q.GetFloat64(k, i) * q.GetFloat64(k, j)
+			}
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(dot-want) > 1e-12 {
+				t.Fatalf("Q^T Q differs from I at (%d, %d): %g", i, j, dot)
+			}
+		}
+	}
+	
+	// Haar measure: entries have mean 0 and variance 1/n, and the
+	// determinant is +1 or -1 with equal probability
+	const trials = 2000
+	sum, sumSq, positive := 0.0, 0.0, 0
+	for i := 0; i < trials; i++ {
+		m := rng.OrthogonalMatrix(3)
+		sum += m.GetFloat64(0, 2)
+		sumSq += m.GetFloat64(0, 2) * m.GetFloat64(0, 2)
+		det := m.GetFloat64(0, 0)*(m.GetFloat64(1, 1)*m.GetFloat64(2, 2)-m.GetFloat64(1, 2)*m.GetFloat64(2, 1)) -
+			m.GetFloat64(0, 1)*(m.GetFloat64(1, 0)*m.GetFloat64(2, 2)-m.GetFloat64(1, 2)*m.GetFloat64(2, 0)) +
+			m.GetFloat64(0, 2)*(m.GetFloat64(1, 0)*m.GetFloat64(2, 1)-m.GetFloat64(1, 1)*m.GetFloat64(2, 0))
+		if det > 0 {
+			positive++
+		}
+	}
+	if math.Abs(sum/trials) > 0.05 || math.Abs(sumSq/trials-1.0/3) > 0.03 {
+		t.Errorf("unexpected entry moments: mean %f, second moment %f", sum/trials, sumSq/trials)
+	}
+	if math.Abs(float64(positive)/trials-0.5) > 0.05 {
+		t.Errorf("expected half of the determinants positive, got %d of %d", positive, trials)
+	}
+	
+	// Correlation matrices are symmetric with unit diagonal and positive
+	// definite; for eta = 1 and d = 3 each off-diagonal entry has
+	// variance 1/4
+	offSq := 0.0
+	for trial := 0; trial < trials; trial++ {
+		c := rng.CorrelationMatrix(3, 1)
+		for i := 0; i < 3; i++ {
+			if c.GetFloat64(i, i) != 1 {
+				t.Fatalf("diagonal entry %d is %f", i, c.GetFloat64(i, i))
+			}
+			for j := 0; j < 3; j++ {
+				if c.GetFloat64(i, j) != c.GetFloat64(j, i) || math.Abs(c.GetFloat64(i, j)) > 1 {
+					t.Fatalf("invalid correlation matrix %v", c.ToSliceFloat64())
+				}
+			}
+		}
+		r01, r02, r12 := c.GetFloat64(0, 1), c.GetFloat64(0, 2), c.GetFloat64(1, 2)
+		if det := 1 + 2*r01*r02*r12 - r01*r01 - r02*r02 - r12*r12; det <= 0 {
+			t.Fatalf("correlation matrix is not positive definite: %v", c.ToSliceFloat64())
+		}
+		offSq += r02 * r02
+	}
+	if v := offSq / trials; math.Abs(v-0.25) > 0.025 {
+		t.Errorf("expected off-diagonal variance 0.25, got %f", v)
+	}
+	
+	// Large eta concentrates around the identity
+	if c := rng.CorrelationMatrix(5, 1000); math.Abs(c.GetFloat64(1, 3)) > 0.2 {
+		t.Errorf("expected a near-identity matrix for large eta, got %v", c.ToSliceFloat64())
+	}
+}
@@ -0,0 +1,330 @@
+package random
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// ChoiceOptions configures ChoiceWith, mirroring the p=, replace= and
+// axis= arguments of NumPy's Generator.choice
+type ChoiceOptions struct {
+	// P is an optional 1D array with the probability of each candidate. It
+	// must be non-negative and sum to 1. nil means uniform.
+	P *tensor.NDArray
+	
+	// NoReplace draws without replacement, so each candidate is chosen at
+	// most once
+	NoReplace bool
+	
+	// Axis is the axis whose slices are the candidates: for a matrix, axis
+	// 0 samples rows and axis 1 samples columns
+	Axis int
+}
+
+// ChoiceWith randomly selects slices of arr along opts.Axis. The result
+// has shape arr.shape[:axis] + shape + arr.shape[axis+1:] and arr's dtype;
+// an empty shape draws a single candidate.
+func (rng *Generator) ChoiceWith(arr *tensor.NDArray, opts ChoiceOptions, shape ...int) *tensor.NDArray {
+	axis := normalizeAxis(arr, opts.Axis)
+	n := arr.Shape()[axis]
+	
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+	k := 1
+	for _, dim := range shape {
+		k *= dim
+	}
+	
+	var chosen []int
+	switch {
+	case opts.P == nil && !opts.NoReplace:
+		if n == 0 && k > 0 {
+			panic("cannot choose from an empty array")
+		}
+		chosen = make([]int, k)
+		for i := range chosen {
+			chosen[i] = rng.source.Intn(n)
+		}
+	case opts.P == nil:
+		chosen = rng.sampleIndices(n, k)
+	case !opts.NoReplace:
+		chosen = rng.weightedIndices(choiceWeights(opts.P, n), k)
+	default:
+		chosen = rng.weightedSampleIndices(choiceWeights(opts.P, n), k)
+	}
+	
+	return gatherAxis(arr, axis, chosen, shape)
+}
+
+// sampleIndices draws k distinct indices from [0, n) uniformly, by a
+// partial Fisher-Yates shuffle
+func (rng *Generator) sampleIndices(n, k int) []int {
+	if k > n {
+		panic(fmt.Sprintf("cannot take a sample of %d from %d candidates without replacement", k, n))
+	}
+	pool := make([]int, n)
+	for i := range pool {
+		pool[i] = i
+	}
+	for i := 0; i < k; i++ {
+		j := i + rng.source.Intn(n-i)
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+	return pool[:k]
+}
+
+// Sample returns k distinct integers drawn uniformly from [0, n), in
+// random order. It uses Floyd's algorithm, so time and memory are O(k)
+// however large n is.
+func (rng *Generator) Sample(n, k int) *tensor.NDArray {
+	if n < 0 || k < 0 {
+		panic("n and k must be non-negative")
+	}
+	if k > n {
+		panic(fmt.Sprintf("cannot take a sample of %d from %d candidates without replacement", k, n))
+	}
+	
+	chosen := make(map[int64]bool, k)
+	data := make([]int64, 0, k)
+	for j := int64(n - k); j < int64(n); j++ {
+		t := int64(rng.boundedUint64(uint64(j + 1)))
+		if chosen[t] {
+			t = j
+		}
+		chosen[t] = true
+		data = append(data, t)
+	}
+	
+	// Floyd's algorithm fixes the set but not a uniform order
+	for i := len(data) - 1; i > 0; i-- {
+		j := rng.source.Intn(i + 1)
+		data[i], data[j] = data[j], data[i]
+	}
+	
+	return tensor.FromSliceInt64(data, k)
+}
+
+// weightedIndices draws k indices with replacement, index i having
+// probability p[i], by binary search in the cumulative distribution
+func (rng *Generator) weightedIndices(p []float64, k int) []int {
+	cdf := make([]float64, len(p))
+	sum := 0.0
+	for i, v := range p {
+		sum += v
+		cdf[i] = sum
+	}
+	
+	chosen := make([]int, k)
+	for i := range chosen {
+		u := rng.source.Float64() * sum
+		j := sort.Search(len(cdf), func(j int) bool { return cdf[j] > u })
+		// Guard against u rounding up to the total
+		for j == len(cdf) || p[j] == 0 {
+			j--
+		}
+		chosen[i] = j
+	}
+	return chosen
+}
+
+// weightedSampleIndices draws k distinct indices where each successive
+// draw picks among the remaining indices in proportion to p. It uses the
+// exponential-clock form of the Efraimidis-Spirakis algorithm: the k
+// indices with the smallest Exp(1)/p[i] are chosen, in increasing order.
+func (rng *Generator) weightedSampleIndices(p []float64, k int) []int {
+	keys := make([]float64, 0, len(p))
+	candidates := make([]int, 0, len(p))
+	for i, w := range p {
+		if w > 0 {
+			keys = append(keys, rng.standardExponential()/w)
+			candidates = append(candidates, i)
+		}
+	}
+	if k > len(candidates) {
+		panic(fmt.Sprintf("cannot take a sample of %d without replacement: only %d entries of p are non-zero", k, len(candidates)))
+	}
+	
+	sort.Sort(byKey{candidates, keys})
+	return candidates[:k]
+}
+
+// byKey sorts candidate indices by their sampling keys
+type byKey struct {
+	indices []int
+	keys    []float64
+}
+
+func (s byKey) Len() int           { return len(s.indices) }
+func (s byKey) Less(i, j int) bool { return s.keys[i] < s.keys[j] }
+func (s byKey) Swap(i, j int) {
+	s.indices[i], s.indices[j] = s.indices[j], s.indices[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+// choiceWeights validates a probability array for n candidates
+func choiceWeights(p *tensor.NDArray, n int) []float64 {
+	if p.Ndim() != 1 || p.Size() != n {
+		panic(fmt.Sprintf("p must be 1D with %d entries, got shape %v", n, p.Shape()))
+	}
+	weights := p.ToSliceFloat64()
+	sum := 0.0
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) {
+			panic("probabilities must be non-negative")
+		}
+		sum += w
+	}
+	if math.Abs(sum-1) > 1e-8 {
+		panic(fmt.Sprintf("probabilities sum to %g, not 1", sum))
+	}
+	return weights
+}
+
+// gatherAxis builds the array whose axis is replaced by the chosen slices
+// of arr, laid out in the given shape
+func gatherAxis(arr *tensor.NDArray, axis int, chosen []int, shape []int) *tensor.NDArray {
+	src := arr.Shape()
+	outShape := make([]int, 0, len(src)-1+len(shape))
+	outShape = append(outShape, src[:axis]...)
+	outShape = append(outShape, shape...)
+	outShape = append(outShape, src[axis+1:]...)
+	result := tensor.Zeros(outShape, arr.DType())
+	
+	srcIdx := make([]int, len(src))
+	dstIdx := make([]int, len(outShape))
+	for flat := 0; flat < result.Size(); flat++ {
+		unravel(outShape, flat, dstIdx)
+		
+		// Flatten the sample dimensions to find which choice this is
+		sample := 0
+		for d := 0; d < len(shape); d++ {
+			sample = sample*shape[d] + dstIdx[axis+d]
+		}
+		copy(srcIdx[:axis], dstIdx[:axis])
+		srcIdx[axis] = chosen[sample]
+		copy(srcIdx[axis+1:], dstIdx[axis+len(shape):])
+		result.SetFloat64(arr.GetFloat64(srcIdx...), dstIdx...)
+	}
+	
+	return result
+}
+
+// Permuted returns a shuffled copy of arr. With an axis, each 1D slice
+// along that axis is shuffled independently of the others; without one,
+// all elements are shuffled as if the array were flat.
+func (rng *Generator) Permuted(arr *tensor.NDArray, axis ...int) *tensor.NDArray {
+	result := arr.Copy()
+	if len(axis) == 0 {
+		rng.shuffleFlat(result)
+	} else {
+		rng.shuffleAxis(result, normalizeAxis(arr, axis[0]), true)
+	}
+	return result
+}
+
+// shuffleAxis applies a Fisher-Yates shuffle along axis. If independent
+// is false the same permutation moves every lane, so slices stay intact;
+// otherwise each lane gets its own permutation.
+func (rng *Generator) shuffleAxis(arr *tensor.NDArray, axis int, independent bool) {
+	shape := arr.Shape()
+	n := shape[axis]
+	lanes := 1
+	for d, dim := range shape {
+		if d != axis {
+			lanes *= dim
+		}
+	}
+	
+	idxI := make([]int, len(shape))
+	idxJ := make([]int, len(shape))
+	shuffleLanes := func(from, to int) {
+		for i := n - 1; i > 0; i-- {
+			j := rng.source.Intn(i + 1)
+			if i == j {
+				continue
+			}
+			for lane := from; lane < to; lane++ {
+				laneIndex(shape, axis, lane, idxI)
+				copy(idxJ, idxI)
+				idxI[axis], idxJ[axis] = i, j
+				swapElements(arr, idxI, idxJ)
+			}
+		}
+	}
+	
+	if !independent {
+		shuffleLanes(0, lanes)
+		return
+	}
+	for lane := 0; lane < lanes; lane++ {
+		shuffleLanes(lane, lane+1)
+	}
+}
+
+// shuffleFlat shuffles all elements of arr in row-major order
+func (rng *Generator) shuffleFlat(arr *tensor.NDArray) {
+	shape := arr.Shape()
+	idxI := make([]int, len(shape))
+	idxJ := make([]int, len(shape))
+	for i := arr.Size() - 1; i > 0; i-- {
+		j := rng.source.Intn(i + 1)
+		unravel(shape, i, idxI)
+		unravel(shape, j, idxJ)
+		swapElements(arr, idxI, idxJ)
+	}
+}
+
+// laneIndex fills idx with the indices of the lane-th 1D slice along
+// axis, leaving idx[axis] for the caller
+func laneIndex(shape []int, axis, lane int, idx []int) {
+	for d := len(shape) - 1; d >= 0; d-- {
+		if d == axis {
+			continue
+		}
+		idx[d] = lane % shape[d]
+		lane /= shape[d]
+	}
+}
+
+// unravel converts a row-major flat index into indices for shape
+func unravel(shape []int, flat int, idx []int) {
+	for d := len(shape) - 1; d >= 0; d-- {
+		idx[d] = flat % shape[d]
+		flat /= shape[d]
+	}
+}
+
+// swapElements exchanges two elements of arr without losing precision
+// for integer or complex dtypes
+func swapElements(arr *tensor.NDArray, a, b []int) {
+	switch dtype := arr.DType(); {
+	case dtype.IsComplex():
+		va, vb := arr.GetComplex128(a...), arr.GetComplex128(b...)
+		arr.SetComplex128(vb, a...)
+		arr.SetComplex128(va, b...)
+	case dtype.IsInt():
+		va, vb := arr.GetInt64(a...), arr.GetInt64(b...)
+		arr.SetInt64(vb, a...)
+		arr.SetInt64(va, b...)
+	default:
+		va, vb := arr.GetFloat64(a...), arr.GetFloat64(b...)
+		arr.SetFloat64(vb, a...)
+		arr.SetFloat64(va, b...)
+	}
+}
+
+// normalizeAxis resolves a possibly negative axis of arr
+func normalizeAxis(arr *tensor.NDArray, axis int) int {
+	ax := axis
+	if ax < 0 {
+		ax += arr.Ndim()
+	}
+	if ax < 0 || ax >= arr.Ndim() {
+		panic(fmt.Sprintf("axis %d is out of bounds for array of dimension %d", axis, arr.Ndim()))
+	}
+	return ax
+}
@@ -0,0 +1,82 @@
+package random
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Spawn returns k child generators with independent streams, in the
+// manner of NumPy's SeedSequence. Every generator carries a spawn key: a
+// hash of its initial state followed by its path in the spawn tree. The
+// i-th child ever spawned by rng gets rng's key with i appended, and its
+// bit generator is seeded from a hash of that key, giving PCG64 children
+// their own stream increment and Philox children their own key. Children
+// are therefore reproducible from rng's seed, and distinct paths, such as
+// a grandchild and a sibling of its parent, never share a stream. StdSource
+// children are reseeded from the hash. Other bit generators panic.
+func (rng *Generator) Spawn(k int) []*Generator {
+	children := make([]*Generator, k)
+	for i := range children {
+		key := append(append([]uint64{}, rng.spawnKey...), uint64(rng.spawned.Add(1)))
+		child := NewGenerator(spawnBits(rng.bits, key))
+		child.spawnKey = key
+		children[i] = child
+	}
+	return children
+}
+
+// spawnBits creates an unlocked bit generator of the same kind as bits,
+// seeded from key
+func spawnBits(bits BitGenerator, key []uint64) BitGenerator {
+	if locked, ok := bits.(*lockedBits); ok {
+		bits = locked.bits
+	}
+	words := hashKey(key, 4)
+	switch bits.(type) {
+	case *PCG64:
+		p := &PCG64{}
+		p.seedWords(words[0], words[1], words[2], words[3])
+		return p
+	case *Philox:
+		return NewPhiloxKey([2]uint64{words[0], words[1]}, [4]uint64{})
+	case *StdSource:
+		return NewStdSource(int64(words[0]))
+	default:
+		panic(fmt.Sprintf("bit generator %T does not support spawning", bits))
+	}
+}
+
+// spawnEntropy returns the root of the spawn key for a new generator: a
+// hash of the bit generator's kind and serialized state, or nil if it
+// cannot be serialized
+func spawnEntropy(bits BitGenerator) []uint64 {
+	name, data, err := marshalBits(bits)
+	if err != nil {
+		return nil
+	}
+	data = append([]byte(name), data...)
+	words := make([]uint64, (len(data)+7)/8)
+	for i := range words {
+		var chunk [8]byte
+		copy(chunk[:], data[8*i:])
+		words[i] = binary.LittleEndian.Uint64(chunk[:])
+	}
+	return hashKey(append(words, uint64(len(data))), 2)
+}
+
+// hashKey mixes all words of key into n output words. Each output runs
+// the key through a SplitMix64 chain with its own starting value, and the
+// key length is folded in so that keys differing only in trailing zeros
+// hash differently.
+func hashKey(key []uint64, n int) []uint64 {
+	out := make([]uint64, n)
+	for j := range out {
+		h := uint64(j+1)*0x6a09e667f3bcc909 ^ uint64(len(key))
+		for _, w := range key {
+			state := h ^ w
+			h = splitMix64(&state)
+		}
+		out[j] = h
+	}
+	return out
+}
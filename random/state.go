@@ -0,0 +1,252 @@
+package random
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// bitGenerators constructs empty bit generators by name, for restoring a
+// generator from a saved state
+var bitGenerators = map[string]func() BitGenerator{
+	"PCG64":     func() BitGenerator { return &PCG64{} },
+	"Philox":    func() BitGenerator { return &Philox{} },
+	"StdSource": func() BitGenerator { return &StdSource{} },
+}
+
+// generatorState is the serialized form of a Generator
+type generatorState struct {
+	BitGenerator string   `json:"bit_generator"`
+	State        []byte   `json:"state"`
+	Spawned      int64    `json:"spawned"`
+	SpawnKey     []uint64 `json:"spawn_key"`
+}
+
+// State returns a snapshot of the generator, from which SetState or
+// NewFromState resume the exact same stream. It panics if the bit
+// generator cannot be serialized, as with CryptoSource.
+func (rng *Generator) State() []byte {
+	st := rng.snapshot()
+	buf := make([]byte, 0, 1+len(st.BitGenerator)+8+binary.MaxVarintLen64+8*len(st.SpawnKey)+len(st.State))
+	buf = append(buf, byte(len(st.BitGenerator)))
+	buf = append(buf, st.BitGenerator...)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(st.Spawned))
+	buf = binary.AppendUvarint(buf, uint64(len(st.SpawnKey)))
+	for _, w := range st.SpawnKey {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+	return append(buf, st.State...)
+}
+
+// SetState restores a snapshot taken with State. The snapshot must come
+// from a generator using the same kind of bit generator.
+func (rng *Generator) SetState(state []byte) error {
+	if len(state) < 1 || len(state) < 1+int(state[0])+9 {
+		return errors.New("random: truncated generator state")
+	}
+	n := int(state[0])
+	st := generatorState{
+		BitGenerator: string(state[1 : 1+n]),
+		Spawned:      int64(binary.LittleEndian.Uint64(state[1+n:])),
+	}
+	state = state[1+n+8:]
+	words, m := binary.Uvarint(state)
+	if m <= 0 || words > uint64(len(state)-m)/8 {
+		return errors.New("random: truncated generator state")
+	}
+	st.SpawnKey = make([]uint64, words)
+	for i := range st.SpawnKey {
+		st.SpawnKey[i] = binary.LittleEndian.Uint64(state[m+8*i:])
+	}
+	st.State = state[m+8*int(words):]
+	return rng.restore(st)
+}
+
+// NewFromState creates a generator from a snapshot taken with State,
+// constructing the bit generator it names
+func NewFromState(state []byte) (*Generator, error) {
+	rng := &Generator{}
+	if err := rng.SetState(state); err != nil {
+		return nil, err
+	}
+	return rng, nil
+}
+
+// MarshalJSON encodes the generator state as JSON
+func (rng *Generator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rng.snapshot())
+}
+
+// UnmarshalJSON restores a generator state encoded by MarshalJSON. A zero
+// Generator is given a new bit generator of the recorded kind.
+func (rng *Generator) UnmarshalJSON(data []byte) error {
+	var st generatorState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	return rng.restore(st)
+}
+
+func (rng *Generator) snapshot() generatorState {
+	name, data, err := marshalBits(rng.bits)
+	if err != nil {
+		panic(err)
+	}
+	return generatorState{BitGenerator: name, State: data, Spawned: rng.spawned.Load(), SpawnKey: rng.spawnKey}
+}
+
+func (rng *Generator) restore(st generatorState) error {
+	if rng.bits == nil {
+		newBits, ok := bitGenerators[st.BitGenerator]
+		if !ok {
+			return fmt.Errorf("random: unknown bit generator %q", st.BitGenerator)
+		}
+		bits := newBits()
+		rng.bits, rng.source = bits, rand.New(bitSource{bits})
+	}
+	
+	name, bits := bitGeneratorName(rng.bits)
+	if name != st.BitGenerator {
+		return fmt.Errorf("random: state is for %s, generator uses %s", st.BitGenerator, name)
+	}
+	unmarshaler, ok := bits.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return fmt.Errorf("random: bit generator %T does not support restoring its state", bits)
+	}
+	
+	if locked, ok := rng.bits.(*lockedBits); ok {
+		locked.mu.Lock()
+		defer locked.mu.Unlock()
+	}
+	if err := unmarshaler.UnmarshalBinary(st.State); err != nil {
+		return err
+	}
+	rng.spawned.Store(st.Spawned)
+	rng.spawnKey = st.SpawnKey
+	return nil
+}
+
+// marshalBits serializes a bit generator and returns it with its name. The
+// lock of NewLocked generators is held so that concurrent draws cannot tear
+// the state.
+func marshalBits(bits BitGenerator) (string, []byte, error) {
+	name, inner := bitGeneratorName(bits)
+	marshaler, ok := inner.(encoding.BinaryMarshaler)
+	if !ok {
+		return name, nil, fmt.Errorf("bit generator %T does not support saving its state", inner)
+	}
+	if locked, ok := bits.(*lockedBits); ok {
+		locked.mu.Lock()
+		defer locked.mu.Unlock()
+	}
+	data, err := marshaler.MarshalBinary()
+	return name, data, err
+}
+
+// bitGeneratorName returns the registered name of a bit generator and the
+// generator itself, looking through the lock of NewLocked generators
+func bitGeneratorName(bits BitGenerator) (string, BitGenerator) {
+	if locked, ok := bits.(*lockedBits); ok {
+		bits = locked.bits
+	}
+	switch bits.(type) {
+	case *PCG64:
+		return "PCG64", bits
+	case *Philox:
+		return "Philox", bits
+	case *StdSource:
+		return "StdSource", bits
+	default:
+		return fmt.Sprintf("%T", bits), bits
+	}
+}
+
+// MarshalBinary encodes the generator's 128-bit state and increment
+func (p *PCG64) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 32)
+	for _, v := range []uint64{p.hi, p.lo, p.incHi, p.incLo} {
+		buf = binary.LittleEndian.AppendUint64(buf, v)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary restores a state encoded by MarshalBinary
+func (p *PCG64) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return fmt.Errorf("random: PCG64 state must be 32 bytes, got %d", len(data))
+	}
+	p.hi = binary.LittleEndian.Uint64(data[0:])
+	p.lo = binary.LittleEndian.Uint64(data[8:])
+	p.incHi = binary.LittleEndian.Uint64(data[16:])
+	p.incLo = binary.LittleEndian.Uint64(data[24:])
+	return nil
+}
+
+// MarshalBinary encodes the key, counter and position within the current
+// block
+func (p *Philox) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 49)
+	for _, v := range p.key {
+		buf = binary.LittleEndian.AppendUint64(buf, v)
+	}
+	for _, v := range p.counter {
+		buf = binary.LittleEndian.AppendUint64(buf, v)
+	}
+	return append(buf, byte(p.used)), nil
+}
+
+// UnmarshalBinary restores a state encoded by MarshalBinary
+func (p *Philox) UnmarshalBinary(data []byte) error {
+	if len(data) != 49 || data[48] > 4 {
+		return errors.New("random: invalid Philox state")
+	}
+	for i := range p.key {
+		p.key[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	for i := range p.counter {
+		p.counter[i] = binary.LittleEndian.Uint64(data[16+8*i:])
+	}
+	p.used = int(data[48])
+	if p.used < 4 {
+		// The buffered block is a function of the previous counter
+		p.buf = philoxBlock(subCounter(p.counter, 1), p.key)
+	}
+	return nil
+}
+
+// maxStdReplay bounds the draws StdSource.UnmarshalBinary replays, so that
+// a corrupt state fails instead of spinning for years
+const maxStdReplay = 1 << 32
+
+// MarshalBinary encodes the seed and the number of values drawn since.
+// The math/rand source does not expose its internal state, so restoring
+// replays the stream and takes time proportional to the draws; states past
+// 2^32 draws cannot be restored.
+func (s *StdSource) MarshalBinary() ([]byte, error) {
+	buf := binary.LittleEndian.AppendUint64(nil, uint64(s.seed))
+	return binary.LittleEndian.AppendUint64(buf, s.draws), nil
+}
+
+// UnmarshalBinary restores a state encoded by MarshalBinary
+func (s *StdSource) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("random: StdSource state must be 16 bytes, got %d", len(data))
+	}
+	seed := int64(binary.LittleEndian.Uint64(data))
+	draws := binary.LittleEndian.Uint64(data[8:])
+	if draws > maxStdReplay {
+		return fmt.Errorf("random: StdSource state of %d draws exceeds the replay limit of %d", draws, uint64(maxStdReplay))
+	}
+	
+	if s.src == nil {
+		s.src = rand.NewSource(seed).(rand.Source64)
+	}
+	s.Seed(seed)
+	for s.draws < draws {
+		s.Uint64()
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package random
+
+import (
+	"math"
+)
+
+// Ziggurat tables for the standard normal and exponential distributions
+// with 256 layers (Marsaglia and Tsang, "The Ziggurat Method for
+// Generating Random Variables", 2000). Each draw takes the layer from the
+// low 8 bits of one 64-bit value and the abscissa from its high bits, so
+// about 99% of samples cost a single Uint64 and a multiplication.
+const (
+	zigNormR = 3.6541528853610088   // start of the normal tail
+	zigNormV = 4.92867323399e-3     // area of each normal layer
+	zigExpR  = 7.69711747013104972  // start of the exponential tail
+	zigExpV  = 3.949659822581572e-3 // area of each exponential layer
+	zigNormM = 1 << 52              // range of the 52-bit normal abscissa
+	zigExpM  = 1 << 53              // range of the 53-bit exponential abscissa
+)
+
+var (
+	zigNormK [256]uint64
+	zigNormW [256]float64
+	zigNormF [256]float64
+	zigExpK  [256]uint64
+	zigExpW  [256]float64
+	zigExpF  [256]float64
+)
+
+func init() {
+	// Normal layers, from the tail inwards
+	dn, tn := zigNormR, zigNormR
+	q := zigNormV / math.Exp(-0.5*dn*dn)
+	zigNormK[0] = uint64(dn / q * zigNormM)
+	zigNormK[1] = 0
+	zigNormW[0] = q / zigNormM
+	zigNormW[255] = dn / zigNormM
+	zigNormF[0] = 1
+	zigNormF[255] = math.Exp(-0.5 * dn * dn)
+	for i := 254; i >= 1; i-- {
+		dn = math.Sqrt(-2 * math.Log(zigNormV/dn+math.Exp(-0.5*dn*dn)))
+		zigNormK[i+1] = uint64(dn / tn * zigNormM)
+		tn = dn
+		zigNormF[i] = math.Exp(-0.5 * dn * dn)
+		zigNormW[i] = dn / zigNormM
+	}
+	
+	// Exponential layers
+	de, te := zigExpR, zigExpR
+	q = zigExpV / math.Exp(-de)
+	zigExpK[0] = uint64(de / q * zigExpM)
+	zigExpK[1] = 0
+	zigExpW[0] = q / zigExpM
+	zigExpW[255] = de / zigExpM
+	zigExpF[0] = 1
+	zigExpF[255] = math.Exp(-de)
+	for i := 254; i >= 1; i-- {
+		de = -math.Log(zigExpV/de + math.Exp(-de))
+		zigExpK[i+1] = uint64(de / te * zigExpM)
+		te = de
+		zigExpF[i] = math.Exp(-de)
+		zigExpW[i] = de / zigExpM
+	}
+}
+
+// standardNormal draws from N(0, 1) with the ziggurat method
+func (rng *Generator) standardNormal() float64 {
+	for {
+		u := rng.bits.Uint64()
+		i := u & 0xff
+		negative := u&0x100 != 0
+		j := u >> 12
+		x := float64(j) * zigNormW[i]
+		
+		if j < zigNormK[i] {
+			// Inside the rectangular part of the layer
+			if negative {
+				return -x
+			}
+			return x
+		}
+		
+		if i == 0 {
+			// Tail beyond r (Marsaglia, 1964)
+			for {
+				xx := -math.Log(rng.openUniform()) / zigNormR
+				yy := -math.Log(rng.openUniform())
+				if yy+yy > xx*xx {
+					x = zigNormR + xx
+					break
+				}
+			}
+		} else if zigNormF[i]+rng.source.Float64()*(zigNormF[i-1]-zigNormF[i]) >= math.Exp(-0.5*x*x) {
+			// Outside the density in the wedge; retry
+			continue
+		}
+		
+		if negative {
+			return -x
+		}
+		return x
+	}
+}
+
+// standardExponential draws from Exp(1) with the ziggurat method
+func (rng *Generator) standardExponential() float64 {
+	for {
+		u := rng.bits.Uint64()
+		i := u & 0xff
+		j := u >> 11
+		x := float64(j) * zigExpW[i]
+		
+		if j < zigExpK[i] {
+			return x
+		}
+		if i == 0 {
+			// The tail is itself exponential, shifted to r
+			return zigExpR - math.Log(rng.openUniform())
+		}
+		if zigExpF[i]+rng.source.Float64()*(zigExpF[i-1]-zigExpF[i]) < math.Exp(-x) {
+			return x
+		}
+	}
+}
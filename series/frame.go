@@ -0,0 +1,310 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	
+	"github.com/iSundram/NumGo/io"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Agg selects the reduction applied to each group by Groups.Agg. All
+// reductions skip NaN values.
+type Agg int
+
+const (
+	// Sum adds the values
+	Sum Agg = iota
+	// Mean averages the values
+	Mean
+	// Min takes the smallest value
+	Min
+	// Max takes the largest value
+	Max
+	// Count counts the values
+	Count
+)
+
+// String returns the name of the reduction
+func (g Agg) String() string {
+	switch g {
+	case Sum:
+		return "sum"
+	case Mean:
+		return "mean"
+	case Min:
+		return "min"
+	case Max:
+		return "max"
+	case Count:
+		return "count"
+	}
+	return fmt.Sprintf("Agg(%d)", int(g))
+}
+
+// reduce applies the reduction to values, skipping NaN. Mean, Min and Max
+// of no values are NaN.
+func (g Agg) reduce(values []float64) float64 {
+	n, sum := 0, 0.0
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		n++
+		sum += v
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	switch g {
+	case Sum:
+		return sum
+	case Count:
+		return float64(n)
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	switch g {
+	case Mean:
+		return sum / float64(n)
+	case Min:
+		return min
+	case Max:
+		return max
+	}
+	panic(fmt.Sprintf("unknown aggregation %d", int(g)))
+}
+
+// Frame is a table of named 1D columns of equal length sharing one index
+type Frame struct {
+	// Index holds one label per row
+	Index []string
+	
+	names   []string
+	columns []*tensor.NDArray
+}
+
+// NewFrame creates a frame from named 1D columns. A nil index labels the
+// rows "0", "1", and so on.
+func NewFrame(names []string, columns []*tensor.NDArray, index []string) *Frame {
+	if len(names) != len(columns) {
+		panic(fmt.Sprintf("got %d names for %d columns", len(names), len(columns)))
+	}
+	if index == nil && len(columns) > 0 {
+		index = rangeIndex(columns[0].Size())
+	}
+	f := &Frame{Index: append([]string{}, index...)}
+	for i, name := range names {
+		f.checkColumn(name, columns[i])
+		if f.has(name) {
+			panic(fmt.Sprintf("repeated column name %q", name))
+		}
+		f.names = append(f.names, name)
+		f.columns = append(f.columns, columns[i])
+	}
+	return f
+}
+
+// FromTable creates a frame from a table read by io.GenFromTxt or another
+// loader in the io package. Missing entries become NaN, so integer columns
+// with missing entries are converted to Float64.
+func FromTable(t *io.Table) *Frame {
+	columns := make([]*tensor.NDArray, len(t.Columns))
+	for i, col := range t.Columns {
+		if col.Ndim() != 1 {
+			panic(fmt.Sprintf("column %q is not 1D", t.Names[i]))
+		}
+		columns[i] = col
+		if i >= len(t.Missing) || t.Missing[i] == nil || t.Missing[i].Sum() == 0 {
+			continue
+		}
+		values := col.ToSliceFloat64()
+		for j, m := range t.Missing[i].ToSliceFloat64() {
+			if m != 0 {
+				values[j] = math.NaN()
+			}
+		}
+		columns[i] = tensor.FromSliceFloat64(values, len(values))
+	}
+	return NewFrame(t.Names, columns, nil)
+}
+
+// checkColumn panics unless a can be a column of f
+func (f *Frame) checkColumn(name string, a *tensor.NDArray) {
+	if a.Ndim() != 1 {
+		panic(fmt.Sprintf("column %q is not 1D", name))
+	}
+	if a.Size() != len(f.Index) {
+		panic(fmt.Sprintf("column %q has %d rows, expected %d", name, a.Size(), len(f.Index)))
+	}
+}
+
+func (f *Frame) has(name string) bool {
+	for _, n := range f.names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of rows
+func (f *Frame) Len() int {
+	return len(f.Index)
+}
+
+// Names returns the column names in order
+func (f *Frame) Names() []string {
+	return append([]string{}, f.names...)
+}
+
+// Column returns the named column as a series sharing the frame's index
+func (f *Frame) Column(name string) *Series {
+	return &Series{Name: name, Index: append([]string{}, f.Index...), Values: f.columns[f.columnIndex(name)]}
+}
+
+func (f *Frame) columnIndex(name string) int {
+	for i, n := range f.names {
+		if n == name {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("no column named %q", name))
+}
+
+// Set adds a column, or replaces the column with the same name in place
+func (f *Frame) Set(name string, values *tensor.NDArray) {
+	f.checkColumn(name, values)
+	if f.has(name) {
+		f.columns[f.columnIndex(name)] = values
+		return
+	}
+	f.names = append(f.names, name)
+	f.columns = append(f.columns, values)
+}
+
+// Select returns a frame with only the named columns, in that order
+func (f *Frame) Select(names ...string) *Frame {
+	columns := make([]*tensor.NDArray, len(names))
+	for i, name := range names {
+		columns[i] = f.columns[f.columnIndex(name)]
+	}
+	return NewFrame(names, columns, f.Index)
+}
+
+// SetIndex returns a frame indexed by the values of the named column, which
+// is removed from the columns
+func (f *Frame) SetIndex(name string) *Frame {
+	key := f.columnIndex(name)
+	g := &Frame{Index: labels(f.columns[key])}
+	for i, n := range f.names {
+		if i != key {
+			g.names = append(g.names, n)
+			g.columns = append(g.columns, f.columns[i])
+		}
+	}
+	return g
+}
+
+// labels formats the values of a 1D array as index labels
+func labels(a *tensor.NDArray) []string {
+	out := make([]string, a.Size())
+	dtype := a.DType()
+	for i := range out {
+		switch {
+		case dtype == tensor.Bool:
+			out[i] = strconv.FormatBool(a.GetFloat64(i) != 0)
+		case dtype.IsInt():
+			out[i] = strconv.FormatInt(a.GetInt64(i), 10)
+		case dtype == tensor.Complex64 || dtype == tensor.Complex128:
+			out[i] = fmt.Sprint(a.GetComplex128(i))
+		default:
+			out[i] = strconv.FormatFloat(a.GetFloat64(i), 'g', -1, 64)
+		}
+	}
+	return out
+}
+
+// Groups holds the rows of a frame split by the values of a key column
+type Groups struct {
+	// Keys are the distinct key values as labels, in ascending order of
+	// value
+	Keys []string
+	
+	frame *Frame
+	rows  [][]int
+}
+
+// GroupBy splits the rows of f by the values of the named column. Rows
+// whose key is NaN belong to no group.
+func (f *Frame) GroupBy(name string) *Groups {
+	col := f.columns[f.columnIndex(name)]
+	keys := labels(col)
+	values := col.ToSliceFloat64()
+	
+	g := &Groups{frame: f}
+	group := make(map[string]int)
+	var first []int
+	for i, key := range keys {
+		if math.IsNaN(values[i]) {
+			continue
+		}
+		k, ok := group[key]
+		if !ok {
+			k = len(g.rows)
+			group[key] = k
+			g.rows = append(g.rows, nil)
+			first = append(first, i)
+		}
+		g.rows[k] = append(g.rows[k], i)
+	}
+	
+	order := make([]int, len(g.rows))
+	for i := range order {
+		order[i] = i
+	}
+	numeric := col.DType() != tensor.Complex64 && col.DType() != tensor.Complex128
+	sort.Slice(order, func(i, j int) bool {
+		a, b := first[order[i]], first[order[j]]
+		if numeric {
+			return values[a] < values[b]
+		}
+		return keys[a] < keys[b]
+	})
+	rows := make([][]int, len(order))
+	g.Keys = make([]string, len(order))
+	for i, k := range order {
+		rows[i] = g.rows[k]
+		g.Keys[i] = keys[first[k]]
+	}
+	g.rows = rows
+	return g
+}
+
+// Agg reduces the named column within each group, returning a series
+// indexed by the group keys
+func (g *Groups) Agg(name string, agg Agg) *Series {
+	values := g.frame.columns[g.frame.columnIndex(name)].ToSliceFloat64()
+	out := make([]float64, len(g.rows))
+	group := make([]float64, 0)
+	for i, rows := range g.rows {
+		group = group[:0]
+		for _, r := range rows {
+			group = append(group, values[r])
+		}
+		out[i] = agg.reduce(group)
+	}
+	return New(name, tensor.FromSliceFloat64(out, len(out)), g.Keys)
+}
+
+// Size returns the number of rows in each group
+func (g *Groups) Size() *Series {
+	out := make([]int64, len(g.rows))
+	for i, rows := range g.rows {
+		out[i] = int64(len(rows))
+	}
+	return New("size", tensor.FromSliceInt64(out, len(out)), g.Keys)
+}
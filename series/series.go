@@ -0,0 +1,236 @@
+// Package series provides labeled 1D arrays and a lightweight column table
+// built on NumGo arrays, for dataframe-style analysis
+package series
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Series is a 1D array with a label for each element, like a pandas Series
+type Series struct {
+	// Name describes the values, such as the column they came from
+	Name string
+	
+	// Index holds one label per value
+	Index []string
+	
+	// Values is a 1D array of any dtype
+	Values *tensor.NDArray
+}
+
+// New creates a series from a 1D array. A nil index labels the values "0",
+// "1", and so on.
+func New(name string, values *tensor.NDArray, index []string) *Series {
+	if values.Ndim() != 1 {
+		panic(fmt.Sprintf("series values must be 1D, got shape %v", values.Shape()))
+	}
+	if index == nil {
+		index = rangeIndex(values.Size())
+	}
+	if len(index) != values.Size() {
+		panic(fmt.Sprintf("index has %d labels for %d values", len(index), values.Size()))
+	}
+	return &Series{Name: name, Index: append([]string{}, index...), Values: values}
+}
+
+// rangeIndex returns the labels "0" to "n-1"
+func rangeIndex(n int) []string {
+	index := make([]string, n)
+	for i := range index {
+		index[i] = strconv.Itoa(i)
+	}
+	return index
+}
+
+// Len returns the number of values
+func (s *Series) Len() int {
+	return len(s.Index)
+}
+
+// At returns the value with the given label. If the label is repeated the
+// first match is used.
+func (s *Series) At(label string) float64 {
+	return s.Values.GetFloat64(s.position(label))
+}
+
+// position returns the position of the first value with the given label
+func (s *Series) position(label string) int {
+	for i, l := range s.Index {
+		if l == label {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("no label %q in index", label))
+}
+
+// Loc returns the values with the given labels, in that order
+func (s *Series) Loc(labels ...string) *Series {
+	positions := make([]int, len(labels))
+	for i, label := range labels {
+		positions[i] = s.position(label)
+	}
+	return &Series{Name: s.Name, Index: append([]string{}, labels...), Values: take(s.Values, positions)}
+}
+
+// Add returns s + other with the two series aligned by label
+func (s *Series) Add(other *Series) *Series {
+	return s.combine(other, func(x, y float64) float64 { return x + y })
+}
+
+// Sub returns s - other with the two series aligned by label
+func (s *Series) Sub(other *Series) *Series {
+	return s.combine(other, func(x, y float64) float64 { return x - y })
+}
+
+// Mul returns s * other with the two series aligned by label
+func (s *Series) Mul(other *Series) *Series {
+	return s.combine(other, func(x, y float64) float64 { return x * y })
+}
+
+// Div returns s / other with the two series aligned by label
+func (s *Series) Div(other *Series) *Series {
+	return s.combine(other, func(x, y float64) float64 { return x / y })
+}
+
+// combine applies op to the values of s and other with matching labels.
+// When the indexes differ the result is indexed by the sorted union of
+// labels, and a label missing from either side gives NaN, as in pandas.
+// The result is Float64 and keeps the name only if both names agree.
+func (s *Series) combine(other *Series, op func(x, y float64) float64) *Series {
+	name := ""
+	if s.Name == other.Name {
+		name = s.Name
+	}
+	
+	x, y := s.Values.ToSliceFloat64(), other.Values.ToSliceFloat64()
+	if equalIndex(s.Index, other.Index) {
+		out := make([]float64, len(x))
+		for i := range out {
+			out[i] = op(x[i], y[i])
+		}
+		return New(name, tensor.FromSliceFloat64(out, len(out)), s.Index)
+	}
+	
+	left, right := labelPositions(s.Index), labelPositions(other.Index)
+	index := make([]string, 0, len(left)+len(right))
+	for label := range left {
+		index = append(index, label)
+	}
+	for label := range right {
+		if _, ok := left[label]; !ok {
+			index = append(index, label)
+		}
+	}
+	sort.Strings(index)
+	
+	out := make([]float64, len(index))
+	for i, label := range index {
+		li, lok := left[label]
+		ri, rok := right[label]
+		if lok && rok {
+			out[i] = op(x[li], y[ri])
+		} else {
+			out[i] = math.NaN()
+		}
+	}
+	return New(name, tensor.FromSliceFloat64(out, len(out)), index)
+}
+
+func equalIndex(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// labelPositions maps each label to its position, panicking on repeats
+// since such an index cannot be aligned
+func labelPositions(index []string) map[string]int {
+	positions := make(map[string]int, len(index))
+	for i, label := range index {
+		if _, ok := positions[label]; ok {
+			panic(fmt.Sprintf("cannot align an index with repeated label %q", label))
+		}
+		positions[label] = i
+	}
+	return positions
+}
+
+// DropNA returns the series without its NaN values
+func (s *Series) DropNA() *Series {
+	values := s.Values.ToSliceFloat64()
+	positions := make([]int, 0, len(values))
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			positions = append(positions, i)
+		}
+	}
+	index := make([]string, len(positions))
+	for i, p := range positions {
+		index[i] = s.Index[p]
+	}
+	return &Series{Name: s.Name, Index: index, Values: take(s.Values, positions)}
+}
+
+// SortIndex returns the series ordered by label
+func (s *Series) SortIndex() *Series {
+	positions := make([]int, len(s.Index))
+	for i := range positions {
+		positions[i] = i
+	}
+	sort.SliceStable(positions, func(i, j int) bool {
+		return s.Index[positions[i]] < s.Index[positions[j]]
+	})
+	index := make([]string, len(positions))
+	for i, p := range positions {
+		index[i] = s.Index[p]
+	}
+	return &Series{Name: s.Name, Index: index, Values: take(s.Values, positions)}
+}
+
+// Sum returns the sum of the values, skipping NaN
+func (s *Series) Sum() float64 {
+	return Sum.reduce(s.Values.ToSliceFloat64())
+}
+
+// Mean returns the mean of the values, skipping NaN
+func (s *Series) Mean() float64 {
+	return Mean.reduce(s.Values.ToSliceFloat64())
+}
+
+// Min returns the smallest value, skipping NaN
+func (s *Series) Min() float64 {
+	return Min.reduce(s.Values.ToSliceFloat64())
+}
+
+// Max returns the largest value, skipping NaN
+func (s *Series) Max() float64 {
+	return Max.reduce(s.Values.ToSliceFloat64())
+}
+
+// Count returns the number of values that are not NaN
+func (s *Series) Count() int {
+	return int(Count.reduce(s.Values.ToSliceFloat64()))
+}
+
+// take returns the elements of the 1D array a at the given positions,
+// keeping its dtype
+func take(a *tensor.NDArray, positions []int) *tensor.NDArray {
+	itemsize := a.DType().ItemSize()
+	data := a.Data()
+	out := make([]byte, 0, len(positions)*itemsize)
+	for _, p := range positions {
+		out = append(out, data[p*itemsize:(p+1)*itemsize]...)
+	}
+	return tensor.WrapBytes(out, a.DType(), len(positions))
+}
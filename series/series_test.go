@@ -0,0 +1,138 @@
+package series
+
+import (
+	"math"
+	"strings"
+	"testing"
+	
+	"github.com/iSundram/NumGo/io"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func sliceClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.IsNaN(a[i]) || math.IsNaN(b[i]) {
+			if math.IsNaN(a[i]) != math.IsNaN(b[i]) {
+				return false
+			}
+			continue
+		}
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func labelsEqual(a, b []string) bool {
+	return strings.Join(a, ",") == strings.Join(b, ",")
+}
+
+func TestSeries(t *testing.T) {
+	s := New("x", tensor.FromSliceFloat64([]float64{1, 2, 3}, 3), nil)
+	if !labelsEqual(s.Index, []string{"0", "1", "2"}) {
+		t.Errorf("expected a range index, got %v", s.Index)
+	}
+	if got := s.At("1"); got != 2 {
+		t.Errorf("expected 2 at label 1, got %f", got)
+	}
+	
+	a := New("v", tensor.FromSliceInt64([]int64{1, 2, 3}, 3), []string{"c", "a", "b"})
+	loc := a.Loc("b", "c")
+	if loc.Values.DType() != tensor.Int64 || !sliceClose(loc.Values.ToSliceFloat64(), []float64{3, 1}, 0) {
+		t.Errorf("expected Int64 [3 1], got %s %v", loc.Values.DType(), loc.Values.ToSliceFloat64())
+	}
+	if got := a.SortIndex(); !labelsEqual(got.Index, []string{"a", "b", "c"}) || !sliceClose(got.Values.ToSliceFloat64(), []float64{2, 3, 1}, 0) {
+		t.Errorf("expected [2 3 1] by label, got %v %v", got.Index, got.Values.ToSliceFloat64())
+	}
+	
+	b := New("v", tensor.FromSliceFloat64([]float64{10, 20, 30}, 3), []string{"a", "b", "d"})
+	sum := a.Add(b)
+	if sum.Name != "v" || !labelsEqual(sum.Index, []string{"a", "b", "c", "d"}) {
+		t.Errorf("expected v indexed by [a b c d], got %q %v", sum.Name, sum.Index)
+	}
+	if want := []float64{12, 23, math.NaN(), math.NaN()}; !sliceClose(sum.Values.ToSliceFloat64(), want, 0) {
+		t.Errorf("expected %v, got %v", want, sum.Values.ToSliceFloat64())
+	}
+	if got := sum.DropNA(); !labelsEqual(got.Index, []string{"a", "b"}) {
+		t.Errorf("expected labels [a b] after DropNA, got %v", got.Index)
+	}
+	if sum.Sum() != 35 || sum.Mean() != 17.5 || sum.Min() != 12 || sum.Max() != 23 || sum.Count() != 2 {
+		t.Errorf("unexpected reductions %f %f %f %f %d", sum.Sum(), sum.Mean(), sum.Min(), sum.Max(), sum.Count())
+	}
+	if got := a.Sub(a).Values.ToSliceFloat64(); !sliceClose(got, []float64{0, 0, 0}, 0) {
+		t.Errorf("expected zeros, got %v", got)
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a repeated label")
+		}
+	}()
+	New("", tensor.FromSliceFloat64([]float64{1, 2}, 2), []string{"a", "a"}).Mul(b)
+}
+
+func TestFrameGroupBy(t *testing.T) {
+	text := "city,year,sales\n2,2020,10\n1,2020,\n2,2021,30\n1,2021,5\n"
+	table, err := io.ReadGenFromTxt(strings.NewReader(text), io.GenFromTxtOptions{
+		Delimiter: ",",
+		Names:     true,
+		DTypes:    map[string]tensor.DType{"city": tensor.Int64, "sales": tensor.Int64},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := FromTable(table)
+	if f.Len() != 4 || !labelsEqual(f.Names(), []string{"city", "year", "sales"}) {
+		t.Fatalf("expected 4 rows of city, year, sales, got %d %v", f.Len(), f.Names())
+	}
+	sales := f.Column("sales")
+	if sales.Values.DType() != tensor.Float64 || !math.IsNaN(sales.At("1")) {
+		t.Errorf("expected the missing sale as a Float64 NaN, got %s %v", sales.Values.DType(), sales.Values.ToSliceFloat64())
+	}
+	if f.Column("city").Values.DType() != tensor.Int64 {
+		t.Errorf("expected city to stay Int64")
+	}
+	
+	g := f.GroupBy("city")
+	if !labelsEqual(g.Keys, []string{"1", "2"}) {
+		t.Errorf("expected keys [1 2], got %v", g.Keys)
+	}
+	cases := []struct {
+		agg  Agg
+		want []float64
+	}{
+		{Sum, []float64{5, 40}},
+		{Mean, []float64{5, 20}},
+		{Min, []float64{5, 10}},
+		{Max, []float64{5, 30}},
+		{Count, []float64{1, 2}},
+	}
+	for _, c := range cases {
+		if got := g.Agg("sales", c.agg).Values.ToSliceFloat64(); !sliceClose(got, c.want, 1e-12) {
+			t.Errorf("%s: expected %v, got %v", c.agg, c.want, got)
+		}
+	}
+	if got := g.Size().Values.ToSliceFloat64(); !sliceClose(got, []float64{2, 2}, 0) {
+		t.Errorf("expected sizes [2 2], got %v", got)
+	}
+	
+	byYear := f.SetIndex("year")
+	if !labelsEqual(byYear.Index, []string{"2020", "2020", "2021", "2021"}) || !labelsEqual(byYear.Names(), []string{"city", "sales"}) {
+		t.Errorf("unexpected frame after SetIndex: %v %v", byYear.Index, byYear.Names())
+	}
+	f.Set("double", f.Column("sales").Add(f.Column("sales")).Values)
+	if got := f.Select("double", "city"); !labelsEqual(got.Names(), []string{"double", "city"}) || got.Column("double").At("3") != 10 {
+		t.Errorf("expected double = 10 at row 3, got %v", got.Column("double").Values.ToSliceFloat64())
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a short column")
+		}
+	}()
+	f.Set("short", tensor.Zeros([]int{2}, tensor.Float64))
+}
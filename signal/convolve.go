@@ -0,0 +1,178 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/fft"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Method selects how a 2D convolution is computed
+type Method int
+
+const (
+	// Auto picks whichever of Direct and FFT is estimated to be faster
+	Auto Method = iota
+	// Direct sums the products explicitly, in O(n1 * n2) time
+	Direct
+	// FFT multiplies the zero-padded transforms, in O(n log n) time; it is
+	// much faster for large kernels but rounds small values less exactly
+	FFT
+)
+
+// ConvolveOptions configures Convolve2DWith and Correlate2DWith
+type ConvolveOptions struct {
+	Mode   Mode
+	Method Method
+}
+
+// Convolve returns the discrete linear convolution of the 1D arrays a and
+// v, like numpy.convolve except that Same mode keeps the length of a
+func Convolve(a, v *tensor.NDArray, mode Mode) *tensor.NDArray {
+	x, k := vector(a, "a"), vector(v, "v")
+	checkValid(mode, []int{len(x)}, []int{len(k)})
+	start, length := cropRange(mode, len(x), len(k))
+	return tensor.FromSliceFloat64(convolveSlice(x, k, start, length), length)
+}
+
+// convolveSlice returns elements start..start+length of the full
+// convolution of x and k
+func convolveSlice(x, k []float64, start, length int) []float64 {
+	out := make([]float64, length)
+	for n := range out {
+		pos := n + start
+		sum := 0.0
+		for j := max(0, pos-len(x)+1); j <= min(pos, len(k)-1); j++ {
+			sum += x[pos-j] * k[j]
+		}
+		out[n] = sum
+	}
+	return out
+}
+
+// Convolve2D convolves the 2D arrays in1 and in2, choosing the method
+// automatically
+func Convolve2D(in1, in2 *tensor.NDArray, mode Mode) *tensor.NDArray {
+	return Convolve2DWith(in1, in2, ConvolveOptions{Mode: mode})
+}
+
+// Convolve2DWith is Convolve2D with explicit options
+func Convolve2DWith(in1, in2 *tensor.NDArray, opts ConvolveOptions) *tensor.NDArray {
+	if in1.Ndim() != 2 || in2.Ndim() != 2 {
+		panic("Convolve2D requires 2D arrays")
+	}
+	s1, s2 := in1.Shape(), in2.Shape()
+	checkValid(opts.Mode, s1, s2)
+	r0, rows := cropRange(opts.Mode, s1[0], s2[0])
+	c0, cols := cropRange(opts.Mode, s1[1], s2[1])
+	
+	method := opts.Method
+	if method == Auto {
+		method = chooseMethod(s1, s2)
+	}
+	
+	var full func(i, j int) float64
+	switch method {
+	case Direct:
+		a, b := in1.ToSliceFloat64(), in2.ToSliceFloat64()
+		full = func(i, j int) float64 {
+			sum := 0.0
+			for p := max(0, i-s1[0]+1); p <= min(i, s2[0]-1); p++ {
+				for q := max(0, j-s1[1]+1); q <= min(j, s2[1]-1); q++ {
+					sum += a[(i-p)*s1[1]+j-q] * b[p*s2[1]+q]
+				}
+			}
+			return sum
+		}
+	case FFT:
+		shape := []int{s1[0] + s2[0] - 1, s1[1] + s2[1] - 1}
+		padded := fft.NOptions{Shape: shape}
+		fa := fft.FFTNWith(in1, nil, padded).ToSliceComplex128()
+		fb := fft.FFTNWith(in2, nil, padded).ToSliceComplex128()
+		for i := range fa {
+			fa[i] *= fb[i]
+		}
+		product := tensor.FromSliceComplex128(fa, shape...)
+		conv := fft.IFFTN(product).ToSliceComplex128()
+		full = func(i, j int) float64 { return real(conv[i*shape[1]+j]) }
+	default:
+		panic(fmt.Sprintf("unknown convolution method %d", int(method)))
+	}
+	
+	out := make([]float64, rows*cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			out[i*cols+j] = full(r0+i, c0+j)
+		}
+	}
+	return tensor.FromSliceFloat64(out, rows, cols)
+}
+
+// Correlate2D cross-correlates the 2D arrays in1 and in2, choosing the
+// method automatically. It equals Convolve2D with in2 rotated by 180
+// degrees.
+func Correlate2D(in1, in2 *tensor.NDArray, mode Mode) *tensor.NDArray {
+	return Correlate2DWith(in1, in2, ConvolveOptions{Mode: mode})
+}
+
+// Correlate2DWith is Correlate2D with explicit options
+func Correlate2DWith(in1, in2 *tensor.NDArray, opts ConvolveOptions) *tensor.NDArray {
+	if in2.Ndim() != 2 {
+		panic("Correlate2D requires 2D arrays")
+	}
+	data := in2.ToSliceFloat64()
+	for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+		data[i], data[j] = data[j], data[i]
+	}
+	return Convolve2DWith(in1, tensor.FromSliceFloat64(data, in2.Shape()...), opts)
+}
+
+// SepFilter2D convolves the 2D array in with the separable kernel
+// outer(colKernel, rowKernel): each row with rowKernel, then each column
+// with colKernel. For a k x k kernel this costs O(k) per output element
+// instead of O(k^2).
+func SepFilter2D(in, rowKernel, colKernel *tensor.NDArray, mode Mode) *tensor.NDArray {
+	if in.Ndim() != 2 {
+		panic("SepFilter2D requires a 2D array")
+	}
+	rk, ck := vector(rowKernel, "rowKernel"), vector(colKernel, "colKernel")
+	shape := in.Shape()
+	checkValid(mode, shape, []int{len(ck), len(rk)})
+	
+	c0, cols := cropRange(mode, shape[1], len(rk))
+	rows := mapAxis(in, 1, cols, func(lane []float64) []float64 {
+		return convolveSlice(lane, rk, c0, cols)
+	})
+	r0, n := cropRange(mode, shape[0], len(ck))
+	return mapAxis(rows, 0, n, func(lane []float64) []float64 {
+		return convolveSlice(lane, ck, r0, n)
+	})
+}
+
+// checkValid panics if mode is Valid and neither shape is at least as
+// large as the other along every axis
+func checkValid(mode Mode, s1, s2 []int) {
+	if mode != Valid {
+		return
+	}
+	ge, le := true, true
+	for i := range s1 {
+		ge = ge && s1[i] >= s2[i]
+		le = le && s1[i] <= s2[i]
+	}
+	if !ge && !le {
+		panic(fmt.Sprintf("valid mode requires one input to be at least as large as the other in every dimension: %v vs %v", s1, s2))
+	}
+}
+
+// chooseMethod estimates whether direct or FFT convolution is cheaper
+func chooseMethod(s1, s2 []int) Method {
+	direct := float64(s1[0] * s1[1] * s2[0] * s2[1])
+	size := float64((s1[0] + s2[0] - 1) * (s1[1] + s2[1] - 1))
+	// Three transforms plus the complex overhead of each butterfly
+	if direct > 30*size*math.Log2(size+1) {
+		return FFT
+	}
+	return Direct
+}
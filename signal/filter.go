@@ -0,0 +1,137 @@
+package signal
+
+import (
+	"fmt"
+	
+	"github.com/iSundram/NumGo/linalg"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// LFilter filters x along axis with the rational transfer function
+//
+//	Y(z) = (b[0] + b[1] z^-1 + ... ) / (a[0] + a[1] z^-1 + ...) X(z)
+//
+// using the transposed direct form II structure, starting from rest. An FIR
+// filter has a = [1]. a[0] must be non-zero.
+func LFilter(b, a, x *tensor.NDArray, axis int) *tensor.NDArray {
+	nb, na := normalizeCoefficients(b, a)
+	ax := normalizeAxis(x, axis)
+	return mapAxis(x, ax, x.Shape()[ax], func(lane []float64) []float64 {
+		return lfilter(nb, na, lane, nil)
+	})
+}
+
+// LFilterZI returns the initial state for LFilter's delay line that
+// corresponds to the steady state of a unit step input. Scaling it by the
+// first input sample suppresses the start-up transient.
+func LFilterZI(b, a *tensor.NDArray) *tensor.NDArray {
+	nb, na := normalizeCoefficients(b, a)
+	zi := lfilterZI(nb, na)
+	return tensor.FromSliceFloat64(zi, len(zi))
+}
+
+// FiltFilt applies the filter forwards and then backwards along axis,
+// giving zero phase distortion and the squared magnitude response. The
+// signal is extended at both ends by odd reflection of 3*max(len(a),
+// len(b)) samples and each pass starts from the scaled LFilterZI state,
+// as in scipy.signal.filtfilt.
+func FiltFilt(b, a, x *tensor.NDArray, axis int) *tensor.NDArray {
+	nb, na := normalizeCoefficients(b, a)
+	ax := normalizeAxis(x, axis)
+	n := x.Shape()[ax]
+	pad := 3 * len(nb)
+	if n <= pad {
+		panic(fmt.Sprintf("FiltFilt requires more than %d samples along the axis, got %d", pad, n))
+	}
+	zi := lfilterZI(nb, na)
+	
+	return mapAxis(x, ax, n, func(lane []float64) []float64 {
+		ext := make([]float64, n+2*pad)
+		for i := 0; i < pad; i++ {
+			ext[i] = 2*lane[0] - lane[pad-i]
+			ext[n+pad+i] = 2*lane[n-1] - lane[n-2-i]
+		}
+		copy(ext[pad:], lane)
+		
+		y := lfilter(nb, na, ext, scaled(zi, ext[0]))
+		reverse(y)
+		y = lfilter(nb, na, y, scaled(zi, y[0]))
+		reverse(y)
+		return y[pad : pad+n]
+	})
+}
+
+// normalizeCoefficients returns b and a divided by a[0] and zero-padded to
+// a common length
+func normalizeCoefficients(b, a *tensor.NDArray) ([]float64, []float64) {
+	bs, as := vector(b, "b"), vector(a, "a")
+	if len(bs) == 0 || len(as) == 0 {
+		panic("filter coefficients must not be empty")
+	}
+	if as[0] == 0 {
+		panic("a[0] must be non-zero")
+	}
+	n := max(len(bs), len(as))
+	nb := make([]float64, n)
+	na := make([]float64, n)
+	for i, v := range bs {
+		nb[i] = v / as[0]
+	}
+	for i, v := range as {
+		na[i] = v / as[0]
+	}
+	return nb, na
+}
+
+// lfilter runs the transposed direct form II filter over x with
+// normalized, equal-length coefficients and optional initial state zi
+func lfilter(b, a, x, zi []float64) []float64 {
+	n := len(b)
+	z := make([]float64, n)
+	copy(z, zi)
+	y := make([]float64, len(x))
+	for i, xi := range x {
+		yi := b[0]*xi + z[0]
+		for k := 1; k < n; k++ {
+			z[k-1] = b[k]*xi + z[k] - a[k]*yi
+		}
+		y[i] = yi
+	}
+	return y
+}
+
+// lfilterZI solves (I - A^T) zi = b[1:] - a[1:] b[0] for the steady-state
+// delay line, where A is the companion matrix of a
+func lfilterZI(b, a []float64) []float64 {
+	n := len(b) - 1
+	if n == 0 {
+		return nil
+	}
+	m := make([]float64, n*n)
+	rhs := make([]float64, n)
+	for i := 0; i < n; i++ {
+		m[i*n+i] = 1
+		// Column 0 of A^T is the first row of A, -a[1:]
+		m[i*n] += a[i+1]
+		if i+1 < n {
+			m[i*n+i+1] -= 1
+		}
+		rhs[i] = b[i+1] - a[i+1]*b[0]
+	}
+	zi := linalg.Solve(tensor.FromSliceFloat64(m, n, n), tensor.FromSliceFloat64(rhs, n))
+	return zi.ToSliceFloat64()
+}
+
+func scaled(x []float64, s float64) []float64 {
+	out := make([]float64, len(x))
+	for i, v := range x {
+		out[i] = s * v
+	}
+	return out
+}
+
+func reverse(x []float64) {
+	for i, j := 0, len(x)-1; i < j; i, j = i+1, j-1 {
+		x[i], x[j] = x[j], x[i]
+	}
+}
@@ -0,0 +1,214 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/fft"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Resample changes the number of samples of x along axis to num using the
+// Fourier method: the spectrum is truncated or zero-padded and transformed
+// back. The signal is assumed to be periodic, so for non-periodic signals
+// ResamplePoly usually has smaller edge artefacts.
+func Resample(x *tensor.NDArray, num, axis int) *tensor.NDArray {
+	if num < 1 {
+		panic("Resample requires num >= 1")
+	}
+	if x.DType().IsComplex() {
+		panic("Resample requires a real array")
+	}
+	ax := normalizeAxis(x, axis)
+	n := x.Shape()[ax]
+	spec := fft.RFFT(x, ax)
+	
+	// Copy the shared positive frequencies, splitting or joining the
+	// Nyquist term when the shorter length is even
+	keep := min(num, n)
+	data := spec.ToSliceComplex128()
+	shape := spec.Shape()
+	outShape := append([]int{}, shape...)
+	outShape[ax] = num/2 + 1
+	out := tensor.Zeros(outShape, tensor.Complex128)
+	idx := make([]int, len(shape))
+	for flat, v := range data {
+		unravel(shape, flat, idx)
+		k := idx[ax]
+		if k > keep/2 {
+			continue
+		}
+		if keep%2 == 0 && k == keep/2 {
+			switch {
+			case num < n:
+				v *= 2
+			case num > n:
+				v *= 0.5
+			}
+		}
+		out.SetComplex128(v, idx...)
+	}
+	
+	y := fft.IRFFTWith(out, ax, fft.Options{N: num}).ToSliceFloat64()
+	scale := float64(num) / float64(n)
+	for i := range y {
+		y[i] *= scale
+	}
+	return tensor.FromSliceFloat64(y, replaceAxis(x.Shape(), ax, num)...)
+}
+
+// ResamplePoly changes the sample rate of x along axis by the rational
+// factor up/down: it inserts up-1 zeros between samples, applies a
+// Kaiser-windowed (beta = 5) low-pass FIR filter and keeps every down-th
+// sample. The output has ceil(n*up/down) samples and is aligned with the
+// input.
+func ResamplePoly(x *tensor.NDArray, up, down, axis int) *tensor.NDArray {
+	if up < 1 || down < 1 {
+		panic("ResamplePoly requires positive up and down factors")
+	}
+	g := gcd(up, down)
+	up, down = up/g, down/g
+	rate := max(up, down)
+	half := 10 * rate
+	h := lowpassFIR(2*half+1, 1/float64(rate), Kaiser(2*half+1, 5, true).ToSliceFloat64())
+	for i := range h {
+		h[i] *= float64(up)
+	}
+	return upFIRDown(x, h, up, down, axis)
+}
+
+// Decimate downsamples x along axis by the integer factor q after
+// low-pass filtering with a zero-phase Hamming-windowed FIR filter of
+// 20*q+1 taps, like scipy.signal.decimate with ftype="fir"
+func Decimate(x *tensor.NDArray, q, axis int) *tensor.NDArray {
+	if q < 1 {
+		panic("Decimate requires a positive factor")
+	}
+	taps := 20*q + 1
+	h := lowpassFIR(taps, 1/float64(q), Hamming(taps, true).ToSliceFloat64())
+	return upFIRDown(x, h, 1, q, axis)
+}
+
+// upFIRDown upsamples x by up, convolves with the odd-length filter h
+// centred on each sample, and downsamples by down
+func upFIRDown(x *tensor.NDArray, h []float64, up, down, axis int) *tensor.NDArray {
+	ax := normalizeAxis(x, axis)
+	n := x.Shape()[ax]
+	outLen := (n*up + down - 1) / down
+	delay := len(h) / 2
+	return mapAxis(x, ax, outLen, func(lane []float64) []float64 {
+		out := make([]float64, outLen)
+		for k := range out {
+			// Position in the upsampled, filtered signal, compensating for
+			// the filter's group delay
+			pos := k*down + delay
+			sum := 0.0
+			// Only every up-th upsampled sample is non-zero
+			for j := pos % up; j < len(h); j += up {
+				if src := (pos - j) / up; src >= 0 && src < n {
+					sum += h[j] * lane[src]
+				}
+			}
+			out[k] = sum
+		}
+		return out
+	})
+}
+
+// lowpassFIR designs a linear-phase low-pass FIR filter by the window
+// method, with the cutoff as a fraction of the Nyquist frequency, scaled
+// to unit gain at zero frequency
+func lowpassFIR(taps int, cutoff float64, window []float64) []float64 {
+	h := make([]float64, taps)
+	mid := float64(taps-1) / 2
+	sum := 0.0
+	for i := range h {
+		h[i] = cutoff * sinc(cutoff*(float64(i)-mid)) * window[i]
+		sum += h[i]
+	}
+	for i := range h {
+		h[i] /= sum
+	}
+	return h
+}
+
+// sinc returns the normalized sinc function sin(pi x) / (pi x)
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Trend selects what Detrend removes
+type Trend int
+
+const (
+	// LinearTrend removes the least-squares straight line
+	LinearTrend Trend = iota
+	// ConstantTrend removes the mean
+	ConstantTrend
+)
+
+// Detrend removes a constant or linear trend from each lane of x along
+// axis
+func Detrend(x *tensor.NDArray, trend Trend, axis int) *tensor.NDArray {
+	ax := normalizeAxis(x, axis)
+	n := x.Shape()[ax]
+	return mapAxis(x, ax, n, func(lane []float64) []float64 {
+		out := make([]float64, n)
+		mean := 0.0
+		for _, v := range lane {
+			mean += v
+		}
+		mean /= float64(n)
+		
+		switch trend {
+		case ConstantTrend:
+			for i, v := range lane {
+				out[i] = v - mean
+			}
+		case LinearTrend:
+			// Centre t so that the fitted line passes through the mean
+			tMean := float64(n-1) / 2
+			var stt, sty float64
+			for i, v := range lane {
+				dt := float64(i) - tMean
+				stt += dt * dt
+				sty += dt * (v - mean)
+			}
+			slope := 0.0
+			if stt > 0 {
+				slope = sty / stt
+			}
+			for i, v := range lane {
+				out[i] = v - mean - slope*(float64(i)-tMean)
+			}
+		default:
+			panic(fmt.Sprintf("unknown trend type %d", int(trend)))
+		}
+		return out
+	})
+}
+
+// replaceAxis returns a copy of shape with shape[axis] set to n
+func replaceAxis(shape []int, axis, n int) []int {
+	out := append([]int{}, shape...)
+	out[axis] = n
+	return out
+}
+
+// unravel converts a flat row-major index into idx for the given shape
+func unravel(shape []int, flat int, idx []int) {
+	for d := len(shape) - 1; d >= 0; d-- {
+		idx[d] = flat % shape[d]
+		flat /= shape[d]
+	}
+}
@@ -0,0 +1,110 @@
+// Package signal provides signal processing for NumGo arrays: convolution,
+// filtering, windows and resampling
+package signal
+
+import (
+	"fmt"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Mode selects the size of a convolution or correlation output
+type Mode int
+
+const (
+	// Full returns the complete result, of size n1+n2-1 along each axis
+	Full Mode = iota
+	// Same returns the part of the full result centred on, and the same
+	// size as, the first input
+	Same
+	// Valid returns only the part computed without zero padding, of size
+	// |n1-n2|+1 along each axis
+	Valid
+)
+
+// String returns the NumPy name of the mode
+func (m Mode) String() string {
+	switch m {
+	case Full:
+		return "full"
+	case Same:
+		return "same"
+	case Valid:
+		return "valid"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// cropRange returns the start and length of the mode's output within a
+// full convolution of inputs of lengths n1 and n2 along one axis
+func cropRange(mode Mode, n1, n2 int) (start, length int) {
+	full := n1 + n2 - 1
+	switch mode {
+	case Full:
+		length = full
+	case Same:
+		length = n1
+	case Valid:
+		length = max(n1, n2) - min(n1, n2) + 1
+	default:
+		panic(fmt.Sprintf("unknown convolution mode %d", int(mode)))
+	}
+	return (full - length) / 2, length
+}
+
+// normalizeAxis resolves a possibly negative axis against a's dimensions
+func normalizeAxis(a *tensor.NDArray, axis int) int {
+	ax := axis
+	if ax < 0 {
+		ax += a.Ndim()
+	}
+	if ax < 0 || ax >= a.Ndim() {
+		panic(fmt.Sprintf("axis %d is out of bounds for array of dimension %d", axis, a.Ndim()))
+	}
+	return ax
+}
+
+// mapAxis replaces every 1D lane of a along axis by f(lane), giving a
+// float64 array whose axis has the length of f's results, which must all
+// be outLen long
+func mapAxis(a *tensor.NDArray, axis, outLen int, f func([]float64) []float64) *tensor.NDArray {
+	ax := normalizeAxis(a, axis)
+	shape := a.Shape()
+	data := a.ToSliceFloat64()
+	
+	length := shape[ax]
+	outer, inner := 1, 1
+	for _, s := range shape[:ax] {
+		outer *= s
+	}
+	for _, s := range shape[ax+1:] {
+		inner *= s
+	}
+	
+	result := make([]float64, outer*outLen*inner)
+	lane := make([]float64, length)
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			for k := range lane {
+				lane[k] = data[(o*length+k)*inner+i]
+			}
+			for k, v := range f(lane) {
+				result[(o*outLen+k)*inner+i] = v
+			}
+		}
+	}
+	
+	outShape := append([]int{}, shape...)
+	outShape[ax] = outLen
+	return tensor.FromSliceFloat64(result, outShape...)
+}
+
+// vector returns the elements of a 1D array, panicking with a message
+// naming the argument otherwise
+func vector(a *tensor.NDArray, name string) []float64 {
+	if a.Ndim() != 1 {
+		panic(fmt.Sprintf("%s must be a 1D array", name))
+	}
+	return a.ToSliceFloat64()
+}
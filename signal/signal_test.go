@@ -0,0 +1,298 @@
+package signal
+
+import (
+	"math"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func sliceClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestConvolve(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3}, 3)
+	v := tensor.FromSliceFloat64([]float64{0, 1, 0.5}, 3)
+	
+	cases := []struct {
+		mode Mode
+		want []float64
+	}{
+		{Full, []float64{0, 1, 2.5, 4, 1.5}},
+		{Same, []float64{1, 2.5, 4}},
+		{Valid, []float64{2.5}},
+	}
+	for _, c := range cases {
+		if got := Convolve(a, v, c.mode).ToSliceFloat64(); !sliceClose(got, c.want, 1e-15) {
+			t.Errorf("%s: expected %v, got %v", c.mode, c.want, got)
+		}
+	}
+}
+
+func TestConvolve2D(t *testing.T) {
+	in1 := tensor.FromSliceFloat64([]float64{1, 2, 3, 4}, 2, 2)
+	ones := tensor.Ones([]int{2, 2}, tensor.Float64)
+	
+	full := Convolve2D(in1, ones, Full)
+	if s := full.Shape(); s[0] != 3 || s[1] != 3 {
+		t.Fatalf("expected shape [3 3], got %v", s)
+	}
+	want := []float64{1, 3, 2, 4, 10, 6, 3, 7, 4}
+	if got := full.ToSliceFloat64(); !sliceClose(got, want, 1e-15) {
+		t.Errorf("full: expected %v, got %v", want, got)
+	}
+	if got := Convolve2D(in1, ones, Same).ToSliceFloat64(); !sliceClose(got, []float64{1, 3, 4, 10}, 1e-15) {
+		t.Errorf("same: got %v", got)
+	}
+	
+	// Direct and FFT agree on a larger, asymmetric problem
+	img := make([]float64, 9*7)
+	for i := range img {
+		img[i] = math.Sin(float64(i) * 0.37)
+	}
+	ker := []float64{1, -2, 0.5, 3, 0.25, -1}
+	a := tensor.FromSliceFloat64(img, 9, 7)
+	k := tensor.FromSliceFloat64(ker, 2, 3)
+	for _, mode := range []Mode{Full, Same, Valid} {
+		direct := Convolve2DWith(a, k, ConvolveOptions{Mode: mode, Method: Direct})
+		viaFFT := Convolve2DWith(a, k, ConvolveOptions{Mode: mode, Method: FFT})
+		if !sliceClose(direct.ToSliceFloat64(), viaFFT.ToSliceFloat64(), 1e-12) {
+			t.Errorf("%s: direct and FFT results differ", mode)
+		}
+	}
+	if s := Convolve2D(a, k, Valid).Shape(); s[0] != 8 || s[1] != 5 {
+		t.Errorf("valid: expected shape [8 5], got %v", s)
+	}
+}
+
+func TestCorrelate2D(t *testing.T) {
+	in1 := tensor.FromSliceFloat64([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, 3, 3)
+	tmpl := tensor.FromSliceFloat64([]float64{5, 6, 8, 9}, 2, 2)
+	
+	// The template matches the bottom-right block, where the valid
+	// correlation is largest
+	got := Correlate2D(in1, tmpl, Valid).ToSliceFloat64()
+	want := []float64{1*5 + 2*6 + 4*8 + 5*9, 2*5 + 3*6 + 5*8 + 6*9, 4*5 + 5*6 + 7*8 + 8*9, 5*5 + 6*6 + 8*8 + 9*9}
+	if !sliceClose(got, want, 1e-12) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSepFilter2D(t *testing.T) {
+	img := make([]float64, 6*5)
+	for i := range img {
+		img[i] = float64((i * 7) % 11)
+	}
+	a := tensor.FromSliceFloat64(img, 6, 5)
+	row := []float64{1, 2, 1}
+	col := []float64{-1, 0, 1}
+	kernel := make([]float64, 9)
+	for i, c := range col {
+		for j, r := range row {
+			kernel[i*3+j] = c * r
+		}
+	}
+	
+	for _, mode := range []Mode{Full, Same, Valid} {
+		got := SepFilter2D(a, tensor.FromSliceFloat64(row, 3), tensor.FromSliceFloat64(col, 3), mode)
+		want := Convolve2DWith(a, tensor.FromSliceFloat64(kernel, 3, 3), ConvolveOptions{Mode: mode, Method: Direct})
+		if !sliceClose(got.ToSliceFloat64(), want.ToSliceFloat64(), 1e-12) {
+			t.Errorf("%s: separable result differs from the full kernel", mode)
+		}
+	}
+}
+
+func TestLFilter(t *testing.T) {
+	x := tensor.FromSliceFloat64([]float64{1, 0, 0, 0, 2}, 5)
+	
+	// FIR moving average
+	fir := LFilter(tensor.FromSliceFloat64([]float64{0.5, 0.5}, 2), tensor.FromSliceFloat64([]float64{1}, 1), x, 0)
+	if got := fir.ToSliceFloat64(); !sliceClose(got, []float64{0.5, 0.5, 0, 0, 1}, 1e-15) {
+		t.Errorf("FIR: got %v", got)
+	}
+	
+	// IIR y[n] = x[n] + 0.5 y[n-1], with unnormalized coefficients
+	iir := LFilter(tensor.FromSliceFloat64([]float64{2}, 1), tensor.FromSliceFloat64([]float64{2, -1}, 2), x, 0)
+	if got := iir.ToSliceFloat64(); !sliceClose(got, []float64{1, 0.5, 0.25, 0.125, 2.0625}, 1e-15) {
+		t.Errorf("IIR: got %v", got)
+	}
+	
+	// Filtering along axis 0 of a 2D array filters each column
+	m := tensor.FromSliceFloat64([]float64{1, 10, 0, 0, 0, 0}, 3, 2)
+	cols := LFilter(tensor.FromSliceFloat64([]float64{1}, 1), tensor.FromSliceFloat64([]float64{1, -0.5}, 2), m, 0)
+	if got := cols.ToSliceFloat64(); !sliceClose(got, []float64{1, 10, 0.5, 5, 0.25, 2.5}, 1e-15) {
+		t.Errorf("axis 0: got %v", got)
+	}
+	
+	zi := LFilterZI(tensor.FromSliceFloat64([]float64{1}, 1), tensor.FromSliceFloat64([]float64{1, -0.5}, 2))
+	if got := zi.ToSliceFloat64(); !sliceClose(got, []float64{1}, 1e-15) {
+		t.Errorf("LFilterZI: expected [1], got %v", got)
+	}
+}
+
+func TestFiltFilt(t *testing.T) {
+	// Second-order low-pass: b = (1 - p)^2 [1], a = [1, -2p, p^2]
+	p := 0.6
+	b := tensor.FromSliceFloat64([]float64{(1 - p) * (1 - p)}, 1)
+	a := tensor.FromSliceFloat64([]float64{1, -2 * p, p * p}, 3)
+	
+	n := 200
+	constant := make([]float64, n)
+	slow := make([]float64, n)
+	for i := range constant {
+		constant[i] = 3
+		slow[i] = math.Sin(2 * math.Pi * float64(i) / 100)
+	}
+	
+	// The steady-state start removes the transient for a constant input
+	got := FiltFilt(b, a, tensor.FromSliceFloat64(constant, n), 0).ToSliceFloat64()
+	if !sliceClose(got, constant, 1e-12) {
+		t.Errorf("constant input not preserved: %v", got[:5])
+	}
+	
+	// A slow sine passes with slightly reduced gain and no phase shift,
+	// so its peak stays at index 25
+	out := FiltFilt(b, a, tensor.FromSliceFloat64(slow, n), -1).ToSliceFloat64()
+	peak := 0
+	for i := 1; i < n/2; i++ {
+		if out[i] > out[peak] {
+			peak = i
+		}
+	}
+	if peak != 25 || out[peak] > 1 || out[peak] < 0.9 {
+		t.Errorf("expected an unshifted peak at 25, got %d with value %g", peak, out[peak])
+	}
+}
+
+func TestWindows(t *testing.T) {
+	cases := []struct {
+		name string
+		got  *tensor.NDArray
+		want []float64
+	}{
+		// Reference values from numpy.hanning, numpy.hamming, numpy.blackman,
+		// numpy.bartlett and numpy.kaiser
+		{"Hann", Hann(5, true), []float64{0, 0.5, 1, 0.5, 0}},
+		{"Hamming", Hamming(5, true), []float64{0.08, 0.54, 1, 0.54, 0.08}},
+		{"Blackman", Blackman(5, true), []float64{0, 0.34, 1, 0.34, 0}},
+		{"Bartlett", Bartlett(5, true), []float64{0, 0.5, 1, 0.5, 0}},
+		{"Kaiser", Kaiser(4, 5, true), []float64{0.03671089, 0.7753221, 0.7753221, 0.03671089}},
+		{"periodic Hann", Hann(4, false), []float64{0, 0.5, 1, 0.5}},
+	}
+	for _, c := range cases {
+		if got := c.got.ToSliceFloat64(); !sliceClose(got, c.want, 1e-7) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+		}
+	}
+	
+	if got := Kaiser(6, 0, true).ToSliceFloat64(); !sliceClose(got, []float64{1, 1, 1, 1, 1, 1}, 1e-15) {
+		t.Errorf("Kaiser with beta 0 should be rectangular, got %v", got)
+	}
+	if got := Hamming(1, true).ToSliceFloat64(); len(got) != 1 || got[0] != 1 {
+		t.Errorf("length-1 window: got %v", got)
+	}
+	if got := Blackman(0, false); got.Size() != 0 {
+		t.Errorf("length-0 window: got %d elements", got.Size())
+	}
+}
+
+func TestResample(t *testing.T) {
+	// A band-limited periodic signal is resampled exactly
+	n := 16
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Cos(2*math.Pi*2*float64(i)/float64(n)) + 0.5*math.Sin(2*math.Pi*3*float64(i)/float64(n))
+	}
+	a := tensor.FromSliceFloat64(x, n)
+	for _, num := range []int{10, 16, 25, 40} {
+		got := Resample(a, num, 0).ToSliceFloat64()
+		if len(got) != num {
+			t.Fatalf("expected %d samples, got %d", num, len(got))
+		}
+		for i, v := range got {
+			tt := float64(i) / float64(num)
+			want := math.Cos(2*math.Pi*2*tt) + 0.5*math.Sin(2*math.Pi*3*tt)
+			if math.Abs(v-want) > 1e-12 {
+				t.Fatalf("num %d: index %d expected %g, got %g", num, i, want, v)
+			}
+		}
+	}
+	
+	// Resampling works along any axis
+	m := tensor.FromSliceFloat64(append(append([]float64{}, x...), x...), 2, n)
+	if s := Resample(m, 8, -1).Shape(); s[0] != 2 || s[1] != 8 {
+		t.Errorf("expected shape [2 8], got %v", s)
+	}
+}
+
+func TestResamplePoly(t *testing.T) {
+	n := 200
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * float64(i) / 50)
+	}
+	a := tensor.FromSliceFloat64(x, n)
+	
+	// Upsampling by 3/2 keeps a slow sine, away from the edges
+	got := ResamplePoly(a, 3, 2, 0).ToSliceFloat64()
+	if len(got) != 300 {
+		t.Fatalf("expected 300 samples, got %d", len(got))
+	}
+	for i := 40; i < 260; i++ {
+		want := math.Sin(2 * math.Pi * float64(i) * 2 / 3 / 50)
+		if math.Abs(got[i]-want) > 1e-3 {
+			t.Fatalf("index %d: expected %g, got %g", i, want, got[i])
+		}
+	}
+	
+	// Odd lengths round up
+	if s := ResamplePoly(tensor.FromSliceFloat64(x[:7], 7), 1, 2, 0).Shape(); s[0] != 4 {
+		t.Errorf("expected 4 samples, got %v", s)
+	}
+}
+
+func TestDecimate(t *testing.T) {
+	n := 400
+	x := make([]float64, n)
+	for i := range x {
+		// A slow sine plus a tone above the new Nyquist frequency
+		x[i] = math.Sin(2*math.Pi*float64(i)/80) + 0.5*math.Cos(2*math.Pi*0.4*float64(i))
+	}
+	got := Decimate(tensor.FromSliceFloat64(x, n), 4, 0).ToSliceFloat64()
+	if len(got) != 100 {
+		t.Fatalf("expected 100 samples, got %d", len(got))
+	}
+	for i := 10; i < 90; i++ {
+		want := math.Sin(2 * math.Pi * float64(4*i) / 80)
+		if math.Abs(got[i]-want) > 0.01 {
+			t.Fatalf("index %d: expected %g, got %g (aliasing not removed)", i, want, got[i])
+		}
+	}
+}
+
+func TestDetrend(t *testing.T) {
+	x := tensor.FromSliceFloat64([]float64{1, 3, 5, 7, 2, 2, 2, 8}, 2, 4)
+	
+	lin := Detrend(x, LinearTrend, 1).ToSliceFloat64()
+	if !sliceClose(lin[:4], []float64{0, 0, 0, 0}, 1e-14) {
+		t.Errorf("a straight line should detrend to zero, got %v", lin[:4])
+	}
+	if !sliceClose(lin[4:], []float64{1.2, -0.6, -2.4, 1.8}, 1e-14) {
+		t.Errorf("unexpected residuals %v", lin[4:])
+	}
+	
+	con := Detrend(x, ConstantTrend, 0).ToSliceFloat64()
+	want := []float64{-0.5, 0.5, 1.5, -0.5, 0.5, -0.5, -1.5, 0.5}
+	if !sliceClose(con, want, 1e-14) {
+		t.Errorf("expected %v, got %v", want, con)
+	}
+}
@@ -0,0 +1,83 @@
+package signal
+
+import (
+	"math"
+	
+	"github.com/iSundram/NumGo/special"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Window functions return n samples as a float64 array. A symmetric
+// window (sym = true) is the usual choice for FIR filter design; a
+// periodic one (sym = false) is the first n samples of a symmetric window
+// of length n+1, which is what spectral analysis with the FFT wants.
+
+// Hann returns the Hann (raised cosine) window
+func Hann(n int, sym bool) *tensor.NDArray {
+	return cosineWindow(n, sym, 0.5, 0.5)
+}
+
+// Hamming returns the Hamming window, a raised cosine that does not reach
+// zero at the ends
+func Hamming(n int, sym bool) *tensor.NDArray {
+	return cosineWindow(n, sym, 0.54, 0.46)
+}
+
+// Blackman returns the Blackman window, which has lower side lobes than
+// Hann or Hamming at the cost of a wider main lobe
+func Blackman(n int, sym bool) *tensor.NDArray {
+	return cosineWindow(n, sym, 0.42, 0.5, 0.08)
+}
+
+// Bartlett returns the Bartlett (triangular) window, which is zero at
+// both ends
+func Bartlett(n int, sym bool) *tensor.NDArray {
+	return window(n, sym, func(x float64) float64 {
+		return 1 - math.Abs(2*x-1)
+	})
+}
+
+// Kaiser returns the Kaiser window with shape parameter beta, which trades
+// main-lobe width against side-lobe level: beta = 0 is rectangular and
+// beta near 8.6 resembles Blackman
+func Kaiser(n int, beta float64, sym bool) *tensor.NDArray {
+	scale := special.I0(beta)
+	return window(n, sym, func(x float64) float64 {
+		r := 2*x - 1
+		return special.I0(beta*math.Sqrt(math.Max(0, 1-r*r))) / scale
+	})
+}
+
+// cosineWindow returns the generalized cosine window
+// sum_k (-1)^k a[k] cos(2 pi k x) over x in [0, 1]
+func cosineWindow(n int, sym bool, a ...float64) *tensor.NDArray {
+	return window(n, sym, func(x float64) float64 {
+		sum, sign := 0.0, 1.0
+		for k, ak := range a {
+			sum += sign * ak * math.Cos(2*math.Pi*float64(k)*x)
+			sign = -sign
+		}
+		return sum
+	})
+}
+
+// window samples f at n points spread evenly over [0, 1], or over [0, 1)
+// for a periodic window
+func window(n int, sym bool, f func(x float64) float64) *tensor.NDArray {
+	if n < 0 {
+		panic("window length must be non-negative")
+	}
+	values := make([]float64, n)
+	if n == 1 {
+		values[0] = 1
+		return tensor.FromSliceFloat64(values, n)
+	}
+	span := float64(n - 1)
+	if !sym {
+		span = float64(n)
+	}
+	for i := range values {
+		values[i] = f(float64(i) / span)
+	}
+	return tensor.FromSliceFloat64(values, n)
+}
@@ -0,0 +1,153 @@
+// Package spatial provides distance computations and nearest-neighbour
+// search over sets of points stored as NumGo arrays
+package spatial
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Metric is a distance between two points of equal dimension. Custom
+// metrics may be used wherever a Metric is accepted.
+type Metric func(x, y []float64) float64
+
+// Euclidean is the straight-line (L2) distance
+func Euclidean(x, y []float64) float64 {
+	return math.Sqrt(SqEuclidean(x, y))
+}
+
+// SqEuclidean is the squared Euclidean distance. It is not a metric in the
+// strict sense, so it cannot be used with BallTree.
+func SqEuclidean(x, y []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		d := x[i] - y[i]
+		sum += d * d
+	}
+	return sum
+}
+
+// Manhattan is the city-block (L1) distance
+func Manhattan(x, y []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += math.Abs(x[i] - y[i])
+	}
+	return sum
+}
+
+// Chebyshev is the largest coordinate difference (L∞ distance)
+func Chebyshev(x, y []float64) float64 {
+	max := 0.0
+	for i := range x {
+		max = math.Max(max, math.Abs(x[i]-y[i]))
+	}
+	return max
+}
+
+// Cosine is one minus the cosine of the angle between x and y. It is not a
+// metric in the strict sense, so it cannot be used with BallTree.
+func Cosine(x, y []float64) float64 {
+	var dot, xx, yy float64
+	for i := range x {
+		dot += x[i] * y[i]
+		xx += x[i] * x[i]
+		yy += y[i] * y[i]
+	}
+	return 1 - dot/math.Sqrt(xx*yy)
+}
+
+// Minkowski returns the L^p distance for p >= 1. Minkowski(1) is
+// Manhattan and Minkowski(2) is Euclidean.
+func Minkowski(p float64) Metric {
+	if p < 1 {
+		panic(fmt.Sprintf("Minkowski distance requires p >= 1, got %g", p))
+	}
+	return func(x, y []float64) float64 {
+		sum := 0.0
+		for i := range x {
+			sum += math.Pow(math.Abs(x[i]-y[i]), p)
+		}
+		return math.Pow(sum, 1/p)
+	}
+}
+
+// rows returns the rows of a 2D array of points as a flat slice, with the
+// number of points and their dimension
+func rows(a *tensor.NDArray, name string) ([]float64, int, int) {
+	if a.Ndim() != 2 {
+		panic(fmt.Sprintf("%s must be a 2D array of points, got shape %v", name, a.Shape()))
+	}
+	shape := a.Shape()
+	return a.ToSliceFloat64(), shape[0], shape[1]
+}
+
+// Cdist returns the (m, n) matrix of distances between each of the m rows
+// of xa and each of the n rows of xb, like scipy.spatial.distance.cdist
+func Cdist(xa, xb *tensor.NDArray, metric Metric) *tensor.NDArray {
+	a, m, d := rows(xa, "xa")
+	b, n, db := rows(xb, "xb")
+	if d != db {
+		panic(fmt.Sprintf("points have different dimensions: %d and %d", d, db))
+	}
+	out := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			out[i*n+j] = metric(a[i*d:(i+1)*d], b[j*d:(j+1)*d])
+		}
+	}
+	return tensor.FromSliceFloat64(out, m, n)
+}
+
+// Pdist returns the distances between all pairs of rows of x in condensed
+// form, like scipy.spatial.distance.pdist: a 1D array holding the upper
+// triangle of the distance matrix row by row, n(n-1)/2 entries in all
+func Pdist(x *tensor.NDArray, metric Metric) *tensor.NDArray {
+	a, n, d := rows(x, "x")
+	out := make([]float64, 0, n*(n-1)/2)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			out = append(out, metric(a[i*d:(i+1)*d], a[j*d:(j+1)*d]))
+		}
+	}
+	return tensor.FromSliceFloat64(out, len(out))
+}
+
+// SquareForm converts a condensed distance vector from Pdist to a square
+// symmetric matrix with a zero diagonal, or such a matrix back to condensed
+// form
+func SquareForm(d *tensor.NDArray) *tensor.NDArray {
+	values := d.ToSliceFloat64()
+	switch d.Ndim() {
+	case 1:
+		// Solve n(n-1)/2 = len for n
+		n := int(math.Round((1 + math.Sqrt(1+8*float64(len(values)))) / 2))
+		if n*(n-1)/2 != len(values) {
+			panic(fmt.Sprintf("%d is not the length of a condensed distance vector", len(values)))
+		}
+		out := make([]float64, n*n)
+		k := 0
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				out[i*n+j] = values[k]
+				out[j*n+i] = values[k]
+				k++
+			}
+		}
+		return tensor.FromSliceFloat64(out, n, n)
+	case 2:
+		shape := d.Shape()
+		n := shape[0]
+		if shape[1] != n {
+			panic(fmt.Sprintf("expected a square matrix, got shape %v", shape))
+		}
+		out := make([]float64, 0, n*(n-1)/2)
+		for i := 0; i < n; i++ {
+			out = append(out, values[i*n+i+1:(i+1)*n]...)
+		}
+		return tensor.FromSliceFloat64(out, len(out))
+	}
+	panic(fmt.Sprintf("expected a 1D or 2D array, got shape %v", d.Shape()))
+}
@@ -0,0 +1,166 @@
+package spatial
+
+import (
+	"math"
+	"sort"
+	"testing"
+	
+	"github.com/iSundram/NumGo/random"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func sliceClose(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMetrics(t *testing.T) {
+	x, y := []float64{1, 2, 3}, []float64{4, 0, 3}
+	cases := []struct {
+		name   string
+		metric Metric
+		want   float64
+	}{
+		{"Euclidean", Euclidean, math.Sqrt(13)},
+		{"SqEuclidean", SqEuclidean, 13},
+		{"Manhattan", Manhattan, 5},
+		{"Chebyshev", Chebyshev, 3},
+		{"Cosine", Cosine, 1 - 13/(math.Sqrt(14)*5)},
+		{"Minkowski(3)", Minkowski(3), math.Cbrt(35)},
+		{"Minkowski(2)", Minkowski(2), math.Sqrt(13)},
+	}
+	for _, c := range cases {
+		if got := c.metric(x, y); math.Abs(got-c.want) > 1e-12 {
+			t.Errorf("%s: expected %f, got %f", c.name, c.want, got)
+		}
+	}
+}
+
+func TestCdistPdist(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{0, 0, 3, 4, 6, 8}, 3, 2)
+	b := tensor.FromSliceFloat64([]float64{0, 0, 1, 1}, 2, 2)
+	d := Cdist(a, b, Manhattan)
+	if want := []float64{0, 2, 7, 5, 14, 12}; !shapeIs(d, 3, 2) || !sliceClose(d.ToSliceFloat64(), want, 0) {
+		t.Errorf("expected %v, got %v", want, d.ToSliceFloat64())
+	}
+	
+	p := Pdist(a, Euclidean)
+	if want := []float64{5, 10, 5}; !sliceClose(p.ToSliceFloat64(), want, 1e-12) {
+		t.Errorf("expected %v, got %v", want, p.ToSliceFloat64())
+	}
+	sq := SquareForm(p)
+	if want := []float64{0, 5, 10, 5, 0, 5, 10, 5, 0}; !shapeIs(sq, 3, 3) || !sliceClose(sq.ToSliceFloat64(), want, 1e-12) {
+		t.Errorf("expected %v, got %v", want, sq.ToSliceFloat64())
+	}
+	if back := SquareForm(sq); !sliceClose(back.ToSliceFloat64(), p.ToSliceFloat64(), 0) {
+		t.Errorf("expected %v back, got %v", p.ToSliceFloat64(), back.ToSliceFloat64())
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an invalid condensed length")
+		}
+	}()
+	SquareForm(tensor.FromSliceFloat64([]float64{1, 2}, 2))
+}
+
+func shapeIs(a *tensor.NDArray, shape ...int) bool {
+	got := a.Shape()
+	if len(got) != len(shape) {
+		return false
+	}
+	for i := range got {
+		if got[i] != shape[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// bruteForce returns the k nearest neighbours of q among the rows of
+// points, nearest first
+func bruteForce(points []float64, d int, q []float64, k int, metric Metric) ([]float64, []int64) {
+	n := len(points) / d
+	order := make([]int, n)
+	dist := make([]float64, n)
+	for i := range order {
+		order[i] = i
+		dist[i] = metric(q, points[i*d:(i+1)*d])
+	}
+	sort.SliceStable(order, func(i, j int) bool { return dist[order[i]] < dist[order[j]] })
+	ds := make([]float64, k)
+	is := make([]int64, k)
+	for i := 0; i < k; i++ {
+		ds[i], is[i] = dist[order[i]], int64(order[i])
+	}
+	return ds, is
+}
+
+func TestTrees(t *testing.T) {
+	rng := random.New(7)
+	points := rng.Uniform(-1, 1, 500, 3)
+	queries := rng.Uniform(-1, 1, 20, 3)
+	data, qs := points.ToSliceFloat64(), queries.ToSliceFloat64()
+	const k = 5
+	
+	check := func(name string, dist, idx *tensor.NDArray, metric Metric) {
+		if !shapeIs(dist, 20, k) || !shapeIs(idx, 20, k) {
+			t.Fatalf("%s: expected (20, %d) results, got %v and %v", name, k, dist.Shape(), idx.Shape())
+		}
+		ds, is := dist.ToSliceFloat64(), idx.ToSliceInt64()
+		for i := 0; i < 20; i++ {
+			wantD, wantI := bruteForce(data, 3, qs[i*3:(i+1)*3], k, metric)
+			if !sliceClose(ds[i*k:(i+1)*k], wantD, 1e-12) {
+				t.Errorf("%s: query %d: expected distances %v, got %v", name, i, wantD, ds[i*k:(i+1)*k])
+			}
+			for j := range wantI {
+				if is[i*k+j] != wantI[j] {
+					t.Errorf("%s: query %d: expected indices %v, got %v", name, i, wantI, is[i*k:(i+1)*k])
+					break
+				}
+			}
+		}
+	}
+	
+	kd := NewKDTree(points)
+	kdDist, kdIdx := kd.Query(queries, k)
+	check("KDTree", kdDist, kdIdx, Euclidean)
+	dist, idx := NewBallTree(points, Manhattan).Query(queries, k)
+	check("BallTree", dist, idx, Manhattan)
+	
+	q := tensor.FromSliceFloat64(qs[:3], 3)
+	got := kd.QueryRadius(q, 0.3).ToSliceInt64()
+	var want []int64
+	for i := 0; i < 500; i++ {
+		if Euclidean(qs[:3], data[i*3:(i+1)*3]) <= 0.3 {
+			want = append(want, int64(i))
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v within radius, got %v", want, got)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v within radius, got %v", want, got)
+		}
+	}
+	
+	d1, i1 := kd.Query(q, 1)
+	if !shapeIs(d1, 1) || i1.GetInt64(0) != kdIdx.GetInt64(0, 0) || d1.GetFloat64(0) != kdDist.GetFloat64(0, 0) {
+		t.Errorf("expected a single nearest neighbour for a 1D query, got %v %v", d1.Shape(), i1.ToSliceInt64())
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for k larger than the number of points")
+		}
+	}()
+	kd.Query(q, 501)
+}
@@ -0,0 +1,334 @@
+package spatial
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// leafSize is the largest number of points a tree leaf holds
+const leafSize = 16
+
+// KDTree indexes points for fast Euclidean nearest-neighbour queries, like
+// scipy.spatial.KDTree
+type KDTree struct {
+	data  []float64
+	n, d  int
+	index []int // point order; each node covers a range of it
+	nodes []kdNode
+}
+
+type kdNode struct {
+	lo, hi      int
+	axis        int
+	split       float64
+	left, right int // child nodes, or -1 for a leaf
+}
+
+// NewKDTree builds a tree over the rows of points, a 2D array of shape
+// (n, d). The points are copied.
+func NewKDTree(points *tensor.NDArray) *KDTree {
+	data, n, d := rows(points, "points")
+	t := &KDTree{data: data, n: n, d: d, index: identity(n)}
+	if n > 0 {
+		t.build(0, n)
+	}
+	return t
+}
+
+// build creates the node covering index[lo:hi] and returns its position
+func (t *KDTree) build(lo, hi int) int {
+	id := len(t.nodes)
+	t.nodes = append(t.nodes, kdNode{lo: lo, hi: hi, left: -1, right: -1})
+	if hi-lo <= leafSize {
+		return id
+	}
+	
+	// Split at the median of the dimension with the widest spread
+	axis := widestAxis(t.data, t.d, t.index[lo:hi])
+	part := t.index[lo:hi]
+	sort.Slice(part, func(i, j int) bool {
+		return t.data[part[i]*t.d+axis] < t.data[part[j]*t.d+axis]
+	})
+	mid := (lo + hi) / 2
+	split := t.data[t.index[mid]*t.d+axis]
+	left := t.build(lo, mid)
+	right := t.build(mid, hi)
+	t.nodes[id].axis, t.nodes[id].split = axis, split
+	t.nodes[id].left, t.nodes[id].right = left, right
+	return id
+}
+
+// Query returns the distances to and indices of the k nearest points to
+// each query point, nearest first. x is a single point of shape (d), giving
+// results of shape (k), or m points of shape (m, d), giving (m, k).
+func (t *KDTree) Query(x *tensor.NDArray, k int) (dist, idx *tensor.NDArray) {
+	return query(x, k, t.n, t.d, func(q []float64, h *neighbours) {
+		t.search(0, q, h)
+		for i := range h.items {
+			h.items[i].dist = math.Sqrt(h.items[i].dist)
+		}
+	})
+}
+
+// search offers the points under node to h, using squared distances
+func (t *KDTree) search(node int, q []float64, h *neighbours) {
+	nd := t.nodes[node]
+	if nd.left < 0 {
+		for _, p := range t.index[nd.lo:nd.hi] {
+			h.offer(SqEuclidean(q, t.data[p*t.d:(p+1)*t.d]), p)
+		}
+		return
+	}
+	diff := q[nd.axis] - nd.split
+	near, far := nd.left, nd.right
+	if diff >= 0 {
+		near, far = far, near
+	}
+	t.search(near, q, h)
+	if diff*diff <= h.worst() {
+		t.search(far, q, h)
+	}
+}
+
+// QueryRadius returns the indices, in ascending order, of the points within
+// distance r of the point x of shape (d)
+func (t *KDTree) QueryRadius(x *tensor.NDArray, r float64) *tensor.NDArray {
+	q := queryPoint(x, t.d)
+	var found []int64
+	var visit func(node int)
+	visit = func(node int) {
+		nd := t.nodes[node]
+		if nd.left < 0 {
+			for _, p := range t.index[nd.lo:nd.hi] {
+				if SqEuclidean(q, t.data[p*t.d:(p+1)*t.d]) <= r*r {
+					found = append(found, int64(p))
+				}
+			}
+			return
+		}
+		diff := q[nd.axis] - nd.split
+		if diff <= r {
+			visit(nd.left)
+		}
+		if diff >= -r {
+			visit(nd.right)
+		}
+	}
+	if t.n > 0 {
+		visit(0)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i] < found[j] })
+	return tensor.FromSliceInt64(found, len(found))
+}
+
+// BallTree indexes points for nearest-neighbour queries under any metric
+// satisfying the triangle inequality, like sklearn.neighbors.BallTree
+type BallTree struct {
+	data   []float64
+	n, d   int
+	metric Metric
+	index  []int
+	nodes  []ballNode
+}
+
+type ballNode struct {
+	lo, hi      int
+	center      []float64
+	radius      float64
+	left, right int
+}
+
+// NewBallTree builds a tree over the rows of points, a 2D array of shape
+// (n, d), for the given metric
+func NewBallTree(points *tensor.NDArray, metric Metric) *BallTree {
+	data, n, d := rows(points, "points")
+	t := &BallTree{data: data, n: n, d: d, metric: metric, index: identity(n)}
+	if n > 0 {
+		t.build(0, n)
+	}
+	return t
+}
+
+// build creates the node covering index[lo:hi] and returns its position
+func (t *BallTree) build(lo, hi int) int {
+	part := t.index[lo:hi]
+	center := make([]float64, t.d)
+	for _, p := range part {
+		for j := range center {
+			center[j] += t.data[p*t.d+j]
+		}
+	}
+	for j := range center {
+		center[j] /= float64(len(part))
+	}
+	radius := 0.0
+	for _, p := range part {
+		radius = math.Max(radius, t.metric(center, t.data[p*t.d:(p+1)*t.d]))
+	}
+	
+	id := len(t.nodes)
+	t.nodes = append(t.nodes, ballNode{lo: lo, hi: hi, center: center, radius: radius, left: -1, right: -1})
+	if hi-lo <= leafSize {
+		return id
+	}
+	axis := widestAxis(t.data, t.d, part)
+	sort.Slice(part, func(i, j int) bool {
+		return t.data[part[i]*t.d+axis] < t.data[part[j]*t.d+axis]
+	})
+	mid := (lo + hi) / 2
+	left := t.build(lo, mid)
+	right := t.build(mid, hi)
+	t.nodes[id].left, t.nodes[id].right = left, right
+	return id
+}
+
+// Query returns the distances to and indices of the k nearest points to
+// each query point, nearest first, with the same shapes as KDTree.Query
+func (t *BallTree) Query(x *tensor.NDArray, k int) (dist, idx *tensor.NDArray) {
+	return query(x, k, t.n, t.d, func(q []float64, h *neighbours) {
+		t.search(0, q, h)
+	})
+}
+
+// search offers the points under node to h, skipping balls that cannot
+// hold a closer point than the current kth nearest
+func (t *BallTree) search(node int, q []float64, h *neighbours) {
+	nd := t.nodes[node]
+	if t.metric(q, nd.center)-nd.radius > h.worst() {
+		return
+	}
+	if nd.left < 0 {
+		for _, p := range t.index[nd.lo:nd.hi] {
+			h.offer(t.metric(q, t.data[p*t.d:(p+1)*t.d]), p)
+		}
+		return
+	}
+	near, far := nd.left, nd.right
+	if t.metric(q, t.nodes[far].center) < t.metric(q, t.nodes[near].center) {
+		near, far = far, near
+	}
+	t.search(near, q, h)
+	t.search(far, q, h)
+}
+
+func identity(n int) []int {
+	index := make([]int, n)
+	for i := range index {
+		index[i] = i
+	}
+	return index
+}
+
+// widestAxis returns the dimension along which the given points spread the
+// most
+func widestAxis(data []float64, d int, points []int) int {
+	best, bestSpread := 0, -1.0
+	for j := 0; j < d; j++ {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, p := range points {
+			lo = math.Min(lo, data[p*d+j])
+			hi = math.Max(hi, data[p*d+j])
+		}
+		if hi-lo > bestSpread {
+			best, bestSpread = j, hi-lo
+		}
+	}
+	return best
+}
+
+// queryPoint returns the coordinates of a single query point of shape (d)
+func queryPoint(x *tensor.NDArray, d int) []float64 {
+	if x.Ndim() != 1 || x.Size() != d {
+		panic(fmt.Sprintf("expected a query point of shape (%d), got %v", d, x.Shape()))
+	}
+	return x.ToSliceFloat64()
+}
+
+// query runs search for each query point in x and collects the k nearest
+// neighbours it finds
+func query(x *tensor.NDArray, k, n, d int, search func(q []float64, h *neighbours)) (dist, idx *tensor.NDArray) {
+	if k < 1 || k > n {
+		panic(fmt.Sprintf("k = %d must be between 1 and the number of points %d", k, n))
+	}
+	var qs []float64
+	var m int
+	switch {
+	case x.Ndim() == 1:
+		qs, m = queryPoint(x, d), 1
+	case x.Ndim() == 2 && x.Shape()[1] == d:
+		qs, m = x.ToSliceFloat64(), x.Shape()[0]
+	default:
+		panic(fmt.Sprintf("expected query points of shape (%d) or (m, %d), got %v", d, d, x.Shape()))
+	}
+	
+	dists := make([]float64, 0, m*k)
+	indices := make([]int64, 0, m*k)
+	for i := 0; i < m; i++ {
+		h := &neighbours{k: k}
+		search(qs[i*d:(i+1)*d], h)
+		for _, nb := range h.sorted() {
+			dists = append(dists, nb.dist)
+			indices = append(indices, int64(nb.idx))
+		}
+	}
+	if x.Ndim() == 1 {
+		return tensor.FromSliceFloat64(dists, k), tensor.FromSliceInt64(indices, k)
+	}
+	return tensor.FromSliceFloat64(dists, m, k), tensor.FromSliceInt64(indices, m, k)
+}
+
+// neighbour is a candidate point and its distance from the query
+type neighbour struct {
+	dist float64
+	idx  int
+}
+
+// neighbours keeps the k nearest candidates seen so far in a max-heap
+type neighbours struct {
+	k     int
+	items []neighbour
+}
+
+func (h *neighbours) Len() int           { return len(h.items) }
+func (h *neighbours) Less(i, j int) bool { return h.items[i].dist > h.items[j].dist }
+func (h *neighbours) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *neighbours) Push(x any)         { h.items = append(h.items, x.(neighbour)) }
+func (h *neighbours) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// offer adds point i at distance d if it is among the k nearest so far
+func (h *neighbours) offer(d float64, i int) {
+	if len(h.items) < h.k {
+		heap.Push(h, neighbour{d, i})
+	} else if d < h.items[0].dist {
+		h.items[0] = neighbour{d, i}
+		heap.Fix(h, 0)
+	}
+}
+
+// worst returns the distance a point must beat to be kept
+func (h *neighbours) worst() float64 {
+	if len(h.items) < h.k {
+		return math.Inf(1)
+	}
+	return h.items[0].dist
+}
+
+// sorted returns the candidates nearest first, ties broken by index
+func (h *neighbours) sorted() []neighbour {
+	out := append([]neighbour{}, h.items...)
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].dist != out[j].dist {
+			return out[i].dist < out[j].dist
+		}
+		return out[i].idx < out[j].idx
+	})
+	return out
+}
@@ -0,0 +1,27 @@
+package special
+
+import (
+	"math"
+)
+
+// I0 returns the modified Bessel function of the first kind of order
+// zero,
+//
+//	I0(x) = sum_{k>=0} (x/2)^(2k) / (k!)^2
+//
+// evaluated by its power series, whose terms are all positive
+func I0(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	}
+	q := x * x / 4
+	term, sum := 1.0, 1.0
+	for k := 1; k < maxIterations; k++ {
+		term *= q / float64(k*k)
+		sum += term
+		if term < epsilon*sum {
+			break
+		}
+	}
+	return sum
+}
@@ -0,0 +1,65 @@
+// Package special provides special mathematical functions for NumGo,
+// modelled on scipy.special. The functions work on float64 scalars and
+// return NaN outside their domain.
+package special
+
+import (
+	"math"
+)
+
+const (
+	// epsilon is the relative accuracy targeted by the series and continued
+	// fraction evaluations
+	epsilon = 1e-15
+	// tiny guards the modified Lentz algorithm against division by zero
+	tiny = 1e-300
+	// maxIterations bounds every series and continued fraction
+	maxIterations = 10000
+)
+
+// Ndtr returns the standard normal cumulative distribution function
+func Ndtr(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// Ndtri returns the inverse of Ndtr: the x for which Ndtr(x) = p
+func Ndtri(p float64) float64 {
+	if p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return -math.Sqrt2 * math.Erfcinv(2*p)
+}
+
+// Kolmogorov returns the complementary cumulative distribution function
+// (survival function) of the Kolmogorov distribution, the limiting
+// distribution of sqrt(n) times the Kolmogorov-Smirnov statistic:
+//
+//	Kolmogorov(y) = 2 sum_{k>=1} (-1)^(k-1) exp(-2 k^2 y^2)
+func Kolmogorov(y float64) float64 {
+	switch {
+	case math.IsNaN(y):
+		return math.NaN()
+	case y <= 0:
+		return 1
+	case y < 1.18:
+		// The alternating series converges slowly for small y; use the
+		// Jacobi theta form of the CDF instead
+		sum := 0.0
+		for k := 1; k <= 20; k++ {
+			j := float64(2*k - 1)
+			sum += math.Exp(-j * j * math.Pi * math.Pi / (8 * y * y))
+		}
+		return 1 - math.Sqrt(2*math.Pi)/y*sum
+	}
+	
+	sum, sign := 0.0, 1.0
+	for k := 1; k <= 100; k++ {
+		term := math.Exp(-2 * float64(k*k) * y * y)
+		sum += sign * term
+		if term < epsilon*sum {
+			break
+		}
+		sign = -sign
+	}
+	return 2 * sum
+}
@@ -0,0 +1,99 @@
+package special
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNdtr(t *testing.T) {
+	if Ndtr(0) != 0.5 {
+		t.Errorf("expected Ndtr(0) = 0.5, got %g", Ndtr(0))
+	}
+	if got := Ndtri(0.975); math.Abs(got-1.959963984540054) > 1e-12 {
+		t.Errorf("expected Ndtri(0.975) = 1.959964, got %.15g", got)
+	}
+	for _, x := range []float64{-6, -1.3, 0.2, 4} {
+		if got := Ndtri(Ndtr(x)); math.Abs(got-x) > 1e-9 {
+			t.Errorf("Ndtri(Ndtr(%g)) = %g", x, got)
+		}
+	}
+	if !math.IsNaN(Ndtri(1.5)) {
+		t.Errorf("expected NaN outside [0, 1]")
+	}
+}
+
+func TestGammaInc(t *testing.T) {
+	for _, x := range []float64{0.01, 0.5, 1, 3, 10, 40} {
+		// P(1, x) = 1 - e^-x and P(1/2, x) = erf(sqrt(x))
+		if got, want := GammaInc(1, x), -math.Expm1(-x); math.Abs(got-want) > 1e-14 {
+			t.Errorf("GammaInc(1, %g): expected %g, got %g", x, want, got)
+		}
+		if got, want := GammaInc(0.5, x), math.Erf(math.Sqrt(x)); math.Abs(got-want) > 1e-14 {
+			t.Errorf("GammaInc(0.5, %g): expected %g, got %g", x, want, got)
+		}
+		if sum := GammaInc(2.5, x) + GammaIncC(2.5, x); math.Abs(sum-1) > 1e-14 {
+			t.Errorf("P + Q = %g at x = %g", sum, x)
+		}
+	}
+	// Q(3, 2) = e^-2 (1 + 2 + 2^2/2)
+	if got, want := GammaIncC(3, 2), 5*math.Exp(-2); math.Abs(got-want) > 1e-15 {
+		t.Errorf("GammaIncC(3, 2): expected %g, got %g", want, got)
+	}
+	// Deep upper tail keeps relative accuracy: Q(1, 700) = e^-700
+	if got, want := GammaIncC(1, 700), math.Exp(-700); math.Abs(got-want) > 1e-12*want {
+		t.Errorf("GammaIncC(1, 700): expected %g, got %g", want, got)
+	}
+	if !math.IsNaN(GammaInc(-1, 1)) {
+		t.Errorf("expected NaN for a <= 0")
+	}
+}
+
+func TestBetaInc(t *testing.T) {
+	for _, x := range []float64{0, 0.1, 0.5, 0.9, 1} {
+		if got := BetaInc(1, 1, x); math.Abs(got-x) > 1e-15 {
+			t.Errorf("BetaInc(1, 1, %g) = %g", x, got)
+		}
+		if got, want := BetaInc(3.5, 1, x), math.Pow(x, 3.5); math.Abs(got-want) > 1e-14 {
+			t.Errorf("BetaInc(3.5, 1, %g): expected %g, got %g", x, want, got)
+		}
+	}
+	if got := BetaInc(2, 3, 0.4); math.Abs(got-0.5248) > 1e-14 {
+		t.Errorf("BetaInc(2, 3, 0.4): expected 0.5248, got %.15g", got)
+	}
+	// Symmetry I_x(a, b) = 1 - I_{1-x}(b, a)
+	if got := BetaInc(7, 2.5, 0.3) + BetaInc(2.5, 7, 0.7); math.Abs(got-1) > 1e-14 {
+		t.Errorf("symmetry violated: %g", got)
+	}
+	if got, want := LogBeta(2, 3), math.Log(1.0/12); math.Abs(got-want) > 1e-14 {
+		t.Errorf("LogBeta(2, 3): expected %g, got %g", want, got)
+	}
+}
+
+func TestKolmogorov(t *testing.T) {
+	// 1.3581 is the asymptotic 5% critical value
+	if got := Kolmogorov(1.3581); math.Abs(got-0.05) > 1e-4 {
+		t.Errorf("Kolmogorov(1.3581): expected 0.05, got %g", got)
+	}
+	// Both series agree where they switch over
+	lo, hi := Kolmogorov(1.18-1e-12), Kolmogorov(1.18)
+	if math.Abs(lo-hi) > 1e-12 {
+		t.Errorf("discontinuity at 1.18: %g vs %g", lo, hi)
+	}
+	if Kolmogorov(0) != 1 || Kolmogorov(10) > 1e-80 {
+		t.Errorf("unexpected tails %g %g", Kolmogorov(0), Kolmogorov(10))
+	}
+}
+
+func TestI0(t *testing.T) {
+	cases := []struct{ x, want float64 }{
+		{0, 1},
+		{1, 1.2660658777520082},
+		{-2.5, 3.2898391440501231},
+		{10, 2815.716628466254},
+	}
+	for _, c := range cases {
+		if got := I0(c.x); math.Abs(got-c.want) > 1e-14*c.want {
+			t.Errorf("I0(%g): expected %.17g, got %.17g", c.x, c.want, got)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// PearsonR returns the Pearson correlation coefficient of x and y as the
+// statistic, with the two-sided p-value for the hypothesis of no
+// correlation from Student's t distribution with n-2 degrees of freedom
+func PearsonR(x, y *tensor.NDArray) TestResult {
+	return pearson(pairedSlices("PearsonR", x, y))
+}
+
+// SpearmanR returns Spearman's rank correlation coefficient of x and y,
+// the Pearson correlation of their ranks with ties given average ranks.
+// The p-value uses the same t approximation as PearsonR.
+func SpearmanR(x, y *tensor.NDArray) TestResult {
+	return spearman(pairedSlices("SpearmanR", x, y))
+}
+
+// KendallTau returns Kendall's tau-b rank correlation of x and y, which
+// accounts for ties, with a two-sided p-value from the tie-corrected
+// normal approximation
+func KendallTau(x, y *tensor.NDArray) TestResult {
+	return kendall(pairedSlices("KendallTau", x, y))
+}
+
+// PearsonRMatrix computes PearsonR between every pair of columns of the 2D
+// array a, whose rows are observations. Both results are k x k for k
+// columns.
+func PearsonRMatrix(a *tensor.NDArray) (r, p *tensor.NDArray) {
+	return pairwise("PearsonRMatrix", a, pearson)
+}
+
+// SpearmanRMatrix computes SpearmanR between every pair of columns of a
+func SpearmanRMatrix(a *tensor.NDArray) (r, p *tensor.NDArray) {
+	return pairwise("SpearmanRMatrix", a, spearman)
+}
+
+// KendallTauMatrix computes KendallTau between every pair of columns of a
+func KendallTauMatrix(a *tensor.NDArray) (r, p *tensor.NDArray) {
+	return pairwise("KendallTauMatrix", a, kendall)
+}
+
+// pairedSlices flattens x and y, checking that they have matching lengths
+// of at least two
+func pairedSlices(name string, x, y *tensor.NDArray) ([]float64, []float64) {
+	if x.Size() != y.Size() {
+		panic(fmt.Sprintf("%s requires arrays of the same length: %d vs %d", name, x.Size(), y.Size()))
+	}
+	if x.Size() < 2 {
+		panic(fmt.Sprintf("%s requires at least two observations", name))
+	}
+	return x.ToSliceFloat64(), y.ToSliceFloat64()
+}
+
+// pairwise applies corr to every pair of columns of the 2D array a
+func pairwise(name string, a *tensor.NDArray, corr func(x, y []float64) TestResult) (r, p *tensor.NDArray) {
+	if a.Ndim() != 2 {
+		panic(fmt.Sprintf("%s requires a 2D array", name))
+	}
+	shape := a.Shape()
+	m, k := shape[0], shape[1]
+	if m < 2 {
+		panic(fmt.Sprintf("%s requires at least two observations", name))
+	}
+	data := a.ToSliceFloat64()
+	cols := make([][]float64, k)
+	for j := range cols {
+		cols[j] = make([]float64, m)
+		for i := 0; i < m; i++ {
+			cols[j][i] = data[i*k+j]
+		}
+	}
+	
+	rData := make([]float64, k*k)
+	pData := make([]float64, k*k)
+	for i := 0; i < k; i++ {
+		for j := i; j < k; j++ {
+			res := corr(cols[i], cols[j])
+			rData[i*k+j], rData[j*k+i] = res.Statistic, res.Statistic
+			pData[i*k+j], pData[j*k+i] = res.PValue, res.PValue
+		}
+	}
+	return tensor.FromSliceFloat64(rData, k, k), tensor.FromSliceFloat64(pData, k, k)
+}
+
+func pearson(x, y []float64) TestResult {
+	mx, my := mean(x), mean(y)
+	var sxy, sxx, syy float64
+	for i := range x {
+		dx, dy := x[i]-mx, y[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	r := sxy / math.Sqrt(sxx*syy)
+	// Rounding can push |r| slightly past 1
+	r = math.Max(-1, math.Min(1, r))
+	
+	df := float64(len(x) - 2)
+	switch {
+	case math.IsNaN(r):
+		return TestResult{Statistic: r, PValue: math.NaN()}
+	case df == 0:
+		return TestResult{Statistic: r, PValue: 1}
+	case math.Abs(r) == 1:
+		return TestResult{Statistic: r, PValue: 0}
+	}
+	t := r * math.Sqrt(df/(1-r*r))
+	return TestResult{Statistic: r, PValue: studentTwoSided(t, df)}
+}
+
+func spearman(x, y []float64) TestResult {
+	rx, _ := rank(x)
+	ry, _ := rank(y)
+	return pearson(rx, ry)
+}
+
+func kendall(x, y []float64) TestResult {
+	n := len(x)
+	// S = concordant - discordant pairs; pairs tied in either variable
+	// count as neither
+	s := 0.0
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			s += sign(x[i]-x[j]) * sign(y[i]-y[j])
+		}
+	}
+	
+	xtie, x0, x1 := tieTerms(x)
+	ytie, y0, y1 := tieTerms(y)
+	fn := float64(n)
+	total := fn * (fn - 1) / 2
+	tau := s / math.Sqrt(total-xtie) / math.Sqrt(total-ytie)
+	
+	m := fn * (fn - 1)
+	v := (m*(2*fn+5)-x1-y1)/18 + 2*xtie*ytie/m
+	if n > 2 {
+		v += x0 * y0 / (9 * m * (fn - 2))
+	}
+	z := s / math.Sqrt(v)
+	return TestResult{Statistic: tau, PValue: math.Erfc(math.Abs(z) / math.Sqrt2)}
+}
+
+// tieTerms returns, over groups of t tied values in x, the sums of
+// t(t-1)/2, t(t-1)(t-2) and t(t-1)(2t+5) used by Kendall's tau
+func tieTerms(x []float64) (pairs, cubic, variance float64) {
+	counts := make(map[float64]int)
+	for _, v := range x {
+		counts[v]++
+	}
+	for _, c := range counts {
+		t := float64(c)
+		pairs += t * (t - 1) / 2
+		cubic += t * (t - 1) * (t - 2)
+		variance += t * (t - 1) * (2*t + 5)
+	}
+	return pairs, cubic, variance
+}
+
+func sign(v float64) float64 {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
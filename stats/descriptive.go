@@ -0,0 +1,262 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Description summarizes a sample, like scipy.stats.describe
+type Description struct {
+	N        int
+	Min      float64
+	Max      float64
+	Mean     float64
+	Variance float64 // unbiased (ddof = 1)
+	Skewness float64 // biased, see Skew
+	Kurtosis float64 // biased excess kurtosis, see Kurtosis
+}
+
+// AxisDescription is Description computed along an axis, with one entry
+// per lane in each array
+type AxisDescription struct {
+	N        int
+	Min      *tensor.NDArray
+	Max      *tensor.NDArray
+	Mean     *tensor.NDArray
+	Variance *tensor.NDArray
+	Skewness *tensor.NDArray
+	Kurtosis *tensor.NDArray
+}
+
+// Describe summarizes all elements of a
+func Describe(a *tensor.NDArray) Description {
+	return describe(a.ToSliceFloat64())
+}
+
+// DescribeAxis summarizes each 1D lane of a along axis
+func DescribeAxis(a *tensor.NDArray, axis int) AxisDescription {
+	return AxisDescription{
+		N:        a.Shape()[normalizeAxis(a, axis)],
+		Min:      reduceAxis(a, axis, minimum),
+		Max:      reduceAxis(a, axis, maximum),
+		Mean:     reduceAxis(a, axis, mean),
+		Variance: reduceAxis(a, axis, func(x []float64) float64 { return variance(x, 1) }),
+		Skewness: reduceAxis(a, axis, skew),
+		Kurtosis: reduceAxis(a, axis, kurtosis),
+	}
+}
+
+func describe(x []float64) Description {
+	return Description{
+		N:        len(x),
+		Min:      minimum(x),
+		Max:      maximum(x),
+		Mean:     mean(x),
+		Variance: variance(x, 1),
+		Skewness: skew(x),
+		Kurtosis: kurtosis(x),
+	}
+}
+
+func minimum(x []float64) float64 {
+	m := math.NaN()
+	for i, v := range x {
+		if i == 0 || v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func maximum(x []float64) float64 {
+	m := math.NaN()
+	for i, v := range x {
+		if i == 0 || v > m {
+			m = v
+		}
+	}
+	return m
+}
+
+// Skew returns the (biased) sample skewness m3 / m2^1.5 of all elements,
+// where mk is the k-th central moment
+func Skew(a *tensor.NDArray) float64 {
+	return skew(a.ToSliceFloat64())
+}
+
+// SkewAxis returns the sample skewness of each lane along axis
+func SkewAxis(a *tensor.NDArray, axis int) *tensor.NDArray {
+	return reduceAxis(a, axis, skew)
+}
+
+// Kurtosis returns the (biased) excess kurtosis m4 / m2^2 - 3 of all
+// elements, which is zero for a normal distribution
+func Kurtosis(a *tensor.NDArray) float64 {
+	return kurtosis(a.ToSliceFloat64())
+}
+
+// KurtosisAxis returns the excess kurtosis of each lane along axis
+func KurtosisAxis(a *tensor.NDArray, axis int) *tensor.NDArray {
+	return reduceAxis(a, axis, kurtosis)
+}
+
+// ZScore standardizes all elements to zero mean and unit (population)
+// standard deviation
+func ZScore(a *tensor.NDArray) *tensor.NDArray {
+	return tensor.FromSliceFloat64(zscore(a.ToSliceFloat64()), a.Shape()...)
+}
+
+// ZScoreAxis standardizes each lane along axis separately
+func ZScoreAxis(a *tensor.NDArray, axis int) *tensor.NDArray {
+	return mapAxis(a, axis, zscore)
+}
+
+// SEM returns the standard error of the mean, std / sqrt(n), using the
+// unbiased (ddof = 1) standard deviation
+func SEM(a *tensor.NDArray) float64 {
+	return sem(a.ToSliceFloat64())
+}
+
+// SEMAxis returns the standard error of the mean of each lane along axis
+func SEMAxis(a *tensor.NDArray, axis int) *tensor.NDArray {
+	return reduceAxis(a, axis, sem)
+}
+
+// GeometricMean returns exp(mean(log x)) over all elements. It is zero if
+// any element is zero and NaN if any is negative.
+func GeometricMean(a *tensor.NDArray) float64 {
+	return geometricMean(a.ToSliceFloat64())
+}
+
+// GeometricMeanAxis returns the geometric mean of each lane along axis
+func GeometricMeanAxis(a *tensor.NDArray, axis int) *tensor.NDArray {
+	return reduceAxis(a, axis, geometricMean)
+}
+
+// HarmonicMean returns n / sum(1/x) over all elements, which must be
+// non-negative. It is zero if any element is zero.
+func HarmonicMean(a *tensor.NDArray) float64 {
+	return harmonicMean(a.ToSliceFloat64())
+}
+
+// HarmonicMeanAxis returns the harmonic mean of each lane along axis
+func HarmonicMeanAxis(a *tensor.NDArray, axis int) *tensor.NDArray {
+	return reduceAxis(a, axis, harmonicMean)
+}
+
+// TrimmedMean returns the mean of all elements after discarding the
+// proportion of smallest and of largest values (rounded down to whole
+// elements at each end). proportion must be in [0, 0.5).
+func TrimmedMean(a *tensor.NDArray, proportion float64) float64 {
+	return trimmedMean(a.ToSliceFloat64(), proportion)
+}
+
+// TrimmedMeanAxis returns the trimmed mean of each lane along axis
+func TrimmedMeanAxis(a *tensor.NDArray, axis int, proportion float64) *tensor.NDArray {
+	return reduceAxis(a, axis, func(x []float64) float64 { return trimmedMean(x, proportion) })
+}
+
+func mean(x []float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, v := range x {
+		sum += v
+	}
+	return sum / float64(len(x))
+}
+
+// variance returns sum((x - mean)^2) / (n - ddof)
+func variance(x []float64, ddof int) float64 {
+	if len(x)-ddof <= 0 {
+		return math.NaN()
+	}
+	m := mean(x)
+	sum := 0.0
+	for _, v := range x {
+		sum += (v - m) * (v - m)
+	}
+	return sum / float64(len(x)-ddof)
+}
+
+// centralMoments returns the second, third and fourth central moments
+func centralMoments(x []float64) (m2, m3, m4 float64) {
+	m := mean(x)
+	for _, v := range x {
+		d := v - m
+		d2 := d * d
+		m2 += d2
+		m3 += d2 * d
+		m4 += d2 * d2
+	}
+	n := float64(len(x))
+	return m2 / n, m3 / n, m4 / n
+}
+
+func skew(x []float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	m2, m3, _ := centralMoments(x)
+	return m3 / math.Pow(m2, 1.5)
+}
+
+func kurtosis(x []float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	m2, _, m4 := centralMoments(x)
+	return m4/(m2*m2) - 3
+}
+
+func zscore(x []float64) []float64 {
+	m := mean(x)
+	sd := math.Sqrt(variance(x, 0))
+	out := make([]float64, len(x))
+	for i, v := range x {
+		out[i] = (v - m) / sd
+	}
+	return out
+}
+
+func sem(x []float64) float64 {
+	return math.Sqrt(variance(x, 1) / float64(len(x)))
+}
+
+func geometricMean(x []float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, v := range x {
+		sum += math.Log(v)
+	}
+	return math.Exp(sum / float64(len(x)))
+}
+
+func harmonicMean(x []float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, v := range x {
+		if v < 0 {
+			panic("HarmonicMean requires non-negative values")
+		}
+		sum += 1 / v
+	}
+	return float64(len(x)) / sum
+}
+
+func trimmedMean(x []float64, proportion float64) float64 {
+	if proportion < 0 || proportion >= 0.5 {
+		panic("TrimmedMean requires a proportion in [0, 0.5)")
+	}
+	sorted := append([]float64{}, x...)
+	sort.Float64s(sorted)
+	cut := int(proportion * float64(len(sorted)))
+	return mean(sorted[cut : len(sorted)-cut])
+}
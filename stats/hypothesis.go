@@ -0,0 +1,281 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	
+	"github.com/iSundram/NumGo/special"
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// TestResult holds the outcome of a hypothesis test. All tests are
+// two-sided.
+type TestResult struct {
+	Statistic float64
+	PValue    float64
+}
+
+// TTest1Samp tests whether the mean of the sample a equals popmean, using
+// Student's t distribution with n-1 degrees of freedom
+func TTest1Samp(a *tensor.NDArray, popmean float64) TestResult {
+	x := a.ToSliceFloat64()
+	n := float64(len(x))
+	t := (mean(x) - popmean) / math.Sqrt(variance(x, 1)/n)
+	return TestResult{Statistic: t, PValue: studentTwoSided(t, n-1)}
+}
+
+// TTestInd tests whether two independent samples have equal means. With
+// equalVar it is Student's test with a pooled variance; otherwise it is
+// Welch's test with Welch-Satterthwaite degrees of freedom.
+func TTestInd(a, b *tensor.NDArray, equalVar bool) TestResult {
+	x, y := a.ToSliceFloat64(), b.ToSliceFloat64()
+	n1, n2 := float64(len(x)), float64(len(y))
+	v1, v2 := variance(x, 1), variance(y, 1)
+	
+	var se2, df float64
+	if equalVar {
+		df = n1 + n2 - 2
+		pooled := ((n1-1)*v1 + (n2-1)*v2) / df
+		se2 = pooled * (1/n1 + 1/n2)
+	} else {
+		s1, s2 := v1/n1, v2/n2
+		se2 = s1 + s2
+		df = se2 * se2 / (s1*s1/(n1-1) + s2*s2/(n2-1))
+	}
+	t := (mean(x) - mean(y)) / math.Sqrt(se2)
+	return TestResult{Statistic: t, PValue: studentTwoSided(t, df)}
+}
+
+// TTestRel tests whether two paired samples have equal means, by a
+// one-sample t-test on their differences
+func TTestRel(a, b *tensor.NDArray) TestResult {
+	x, y := a.ToSliceFloat64(), b.ToSliceFloat64()
+	if len(x) != len(y) {
+		panic(fmt.Sprintf("paired samples must have the same length: %d vs %d", len(x), len(y)))
+	}
+	diff := make([]float64, len(x))
+	for i := range x {
+		diff[i] = x[i] - y[i]
+	}
+	return TTest1Samp(tensor.FromSliceFloat64(diff, len(diff)), 0)
+}
+
+// studentTwoSided returns P(|T| >= |t|) for Student's t distribution with
+// df degrees of freedom
+func studentTwoSided(t, df float64) float64 {
+	if math.IsNaN(t) || math.IsNaN(df) || df <= 0 {
+		return math.NaN()
+	}
+	if math.IsInf(t, 0) {
+		return 0
+	}
+	return special.BetaInc(df/2, 0.5, df/(df+t*t))
+}
+
+// ChiSquare is Pearson's chi-square goodness-of-fit test of observed
+// counts against expected counts, with k-1 degrees of freedom for k
+// categories. A nil expected means all categories are equally likely.
+func ChiSquare(observed, expected *tensor.NDArray) TestResult {
+	obs := observed.ToSliceFloat64()
+	k := len(obs)
+	if k < 2 {
+		panic("ChiSquare requires at least two categories")
+	}
+	var exp []float64
+	if expected == nil {
+		total := 0.0
+		for _, o := range obs {
+			total += o
+		}
+		exp = make([]float64, k)
+		for i := range exp {
+			exp[i] = total / float64(k)
+		}
+	} else {
+		exp = expected.ToSliceFloat64()
+		if len(exp) != k {
+			panic(fmt.Sprintf("observed and expected must have the same length: %d vs %d", k, len(exp)))
+		}
+	}
+	
+	stat := 0.0
+	for i, o := range obs {
+		d := o - exp[i]
+		stat += d * d / exp[i]
+	}
+	return TestResult{Statistic: stat, PValue: special.GammaIncC(float64(k-1)/2, stat/2)}
+}
+
+// KSTest is the one-sample Kolmogorov-Smirnov test of the sample a against
+// the continuous distribution with the given CDF. The statistic is the
+// largest distance between the empirical and reference CDFs. The p-value
+// uses the Kolmogorov distribution with Stephens' small-sample
+// correction, which is accurate to about two digits for n >= 5.
+func KSTest(a *tensor.NDArray, cdf func(float64) float64) TestResult {
+	x := a.ToSliceFloat64()
+	sort.Float64s(x)
+	n := float64(len(x))
+	d := 0.0
+	for i, v := range x {
+		f := cdf(v)
+		d = math.Max(d, math.Max(float64(i+1)/n-f, f-float64(i)/n))
+	}
+	return TestResult{Statistic: d, PValue: kolmogorovPValue(d, n)}
+}
+
+// KS2Samp is the two-sample Kolmogorov-Smirnov test of whether a and b are
+// drawn from the same continuous distribution, with an asymptotic p-value
+func KS2Samp(a, b *tensor.NDArray) TestResult {
+	x, y := a.ToSliceFloat64(), b.ToSliceFloat64()
+	sort.Float64s(x)
+	sort.Float64s(y)
+	n1, n2 := float64(len(x)), float64(len(y))
+	
+	d := 0.0
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		v := math.Min(x[i], y[j])
+		for i < len(x) && x[i] == v {
+			i++
+		}
+		for j < len(y) && y[j] == v {
+			j++
+		}
+		d = math.Max(d, math.Abs(float64(i)/n1-float64(j)/n2))
+	}
+	return TestResult{Statistic: d, PValue: kolmogorovPValue(d, n1*n2/(n1+n2))}
+}
+
+// kolmogorovPValue returns the asymptotic p-value of the KS statistic d
+// for effective sample size n
+func kolmogorovPValue(d, n float64) float64 {
+	en := math.Sqrt(n)
+	return math.Min(1, special.Kolmogorov((en+0.12+0.11/en)*d))
+}
+
+// MannWhitneyU is the Mann-Whitney U (Wilcoxon rank-sum) test of whether
+// values in a tend to be larger or smaller than those in b. The statistic
+// is U for a; the p-value uses the normal approximation with tie and
+// continuity corrections.
+func MannWhitneyU(a, b *tensor.NDArray) TestResult {
+	x, y := a.ToSliceFloat64(), b.ToSliceFloat64()
+	n1, n2 := float64(len(x)), float64(len(y))
+	if n1 == 0 || n2 == 0 {
+		panic("MannWhitneyU requires non-empty samples")
+	}
+	ranks, ties := rank(append(append([]float64{}, x...), y...))
+	
+	r1 := 0.0
+	for _, r := range ranks[:len(x)] {
+		r1 += r
+	}
+	u1 := r1 - n1*(n1+1)/2
+	u := math.Max(u1, n1*n2-u1)
+	
+	n := n1 + n2
+	mu := n1 * n2 / 2
+	sigma := math.Sqrt(n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1))))
+	z := (u - mu - 0.5) / sigma
+	return TestResult{Statistic: u1, PValue: math.Min(1, 2*special.Ndtr(-z))}
+}
+
+// ShapiroWilk tests whether the sample a comes from a normal distribution,
+// using Royston's (1995) approximations for the coefficients and the
+// p-value (algorithm AS R94). It needs 3 <= n <= 5000 values.
+func ShapiroWilk(a *tensor.NDArray) TestResult {
+	x := a.ToSliceFloat64()
+	n := len(x)
+	if n < 3 || n > 5000 {
+		panic(fmt.Sprintf("ShapiroWilk requires 3 to 5000 values, got %d", n))
+	}
+	sort.Float64s(x)
+	if x[n-1]-x[0] == 0 {
+		panic("ShapiroWilk requires values that are not all identical")
+	}
+	
+	coef := shapiroCoefficients(n)
+	num := 0.0
+	for i, v := range x {
+		num += coef[i] * v
+	}
+	m := mean(x)
+	ss := 0.0
+	for _, v := range x {
+		ss += (v - m) * (v - m)
+	}
+	w := math.Min(num*num/ss, 1)
+	return TestResult{Statistic: w, PValue: shapiroPValue(w, n)}
+}
+
+// shapiroCoefficients returns Royston's approximation to the
+// Shapiro-Wilk weights for a sample of size n, in ascending order
+func shapiroCoefficients(n int) []float64 {
+	coef := make([]float64, n)
+	if n == 3 {
+		coef[0], coef[2] = -math.Sqrt(0.5), math.Sqrt(0.5)
+		return coef
+	}
+	
+	fn := float64(n)
+	m := make([]float64, n)
+	summ2 := 0.0
+	for i := range m {
+		m[i] = special.Ndtri((float64(i+1) - 0.375) / (fn + 0.25))
+		summ2 += m[i] * m[i]
+	}
+	ssumm2 := math.Sqrt(summ2)
+	u := 1 / math.Sqrt(fn)
+	
+	an := m[n-1]/ssumm2 + polyval([]float64{0, 0.221157, -0.147981, -2.07119, 4.434685, -2.706056}, u)
+	coef[n-1], coef[0] = an, -an
+	first := 1
+	var phi float64
+	if n > 5 {
+		an1 := m[n-2]/ssumm2 + polyval([]float64{0, 0.042981, -0.293762, -1.752461, 5.682633, -3.582633}, u)
+		coef[n-2], coef[1] = an1, -an1
+		first = 2
+		phi = (summ2 - 2*m[n-1]*m[n-1] - 2*m[n-2]*m[n-2]) / (1 - 2*an*an - 2*an1*an1)
+	} else {
+		phi = (summ2 - 2*m[n-1]*m[n-1]) / (1 - 2*an*an)
+	}
+	for i := first; i < n-first; i++ {
+		coef[i] = m[i] / math.Sqrt(phi)
+	}
+	return coef
+}
+
+// shapiroPValue returns Royston's p-value for the Shapiro-Wilk statistic
+func shapiroPValue(w float64, n int) float64 {
+	fn := float64(n)
+	if n == 3 {
+		p := 6 / math.Pi * (math.Asin(math.Sqrt(w)) - math.Asin(math.Sqrt(0.75)))
+		return math.Max(p, 0)
+	}
+	
+	y := math.Log1p(-w)
+	var mu, sigma float64
+	if n <= 11 {
+		gamma := polyval([]float64{-2.273, 0.459}, fn)
+		if y >= gamma {
+			return 0
+		}
+		y = -math.Log(gamma - y)
+		mu = polyval([]float64{0.544, -0.39978, 0.025054, -6.714e-4}, fn)
+		sigma = math.Exp(polyval([]float64{1.3822, -0.77857, 0.062767, -0.0020322}, fn))
+	} else {
+		ln := math.Log(fn)
+		mu = polyval([]float64{-1.5861, -0.31082, -0.083751, 0.0038915}, ln)
+		sigma = math.Exp(polyval([]float64{-0.4803, -0.082676, 0.0030302}, ln))
+	}
+	return special.Ndtr(-(y - mu) / sigma)
+}
+
+// polyval evaluates c[0] + c[1] x + ... by Horner's method
+func polyval(c []float64, x float64) float64 {
+	sum := 0.0
+	for i := len(c) - 1; i >= 0; i-- {
+		sum = sum*x + c[i]
+	}
+	return sum
+}
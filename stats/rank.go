@@ -0,0 +1,34 @@
+package stats
+
+import (
+	"sort"
+)
+
+// rank returns the 1-based ranks of x, giving tied values the average of
+// the ranks they span, together with the tie correction sum(t^3 - t) over
+// groups of t tied values
+func rank(x []float64) ([]float64, float64) {
+	order := make([]int, len(x))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return x[order[i]] < x[order[j]] })
+	
+	ranks := make([]float64, len(x))
+	ties := 0.0
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && x[order[end]] == x[order[start]] {
+			end++
+		}
+		avg := float64(start+end+1) / 2
+		for _, idx := range order[start:end] {
+			ranks[idx] = avg
+		}
+		if t := float64(end - start); t > 1 {
+			ties += t*t*t - t
+		}
+		start = end
+	}
+	return ranks, ties
+}
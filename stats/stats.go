@@ -0,0 +1,83 @@
+// Package stats provides statistical functions for NumGo arrays
+package stats
+
+import (
+	"fmt"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// normalizeAxis resolves a possibly negative axis against a's dimensions
+func normalizeAxis(a *tensor.NDArray, axis int) int {
+	ax := axis
+	if ax < 0 {
+		ax += a.Ndim()
+	}
+	if ax < 0 || ax >= a.Ndim() {
+		panic(fmt.Sprintf("axis %d is out of bounds for array of dimension %d", axis, a.Ndim()))
+	}
+	return ax
+}
+
+// laneLayout splits shape around axis into the number of lanes before it,
+// the lane length and the stride between lane elements in row-major order
+func laneLayout(shape []int, axis int) (outer, length, inner int) {
+	outer, inner = 1, 1
+	for _, s := range shape[:axis] {
+		outer *= s
+	}
+	for _, s := range shape[axis+1:] {
+		inner *= s
+	}
+	return outer, shape[axis], inner
+}
+
+// reduceAxis applies f to every 1D lane of a along axis and returns the
+// results with that axis removed. As with SumAxis, reducing a 1D array
+// gives a one-element array.
+func reduceAxis(a *tensor.NDArray, axis int, f func([]float64) float64) *tensor.NDArray {
+	ax := normalizeAxis(a, axis)
+	shape := a.Shape()
+	data := a.ToSliceFloat64()
+	outer, length, inner := laneLayout(shape, ax)
+	
+	result := make([]float64, outer*inner)
+	lane := make([]float64, length)
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			for k := range lane {
+				lane[k] = data[(o*length+k)*inner+i]
+			}
+			result[o*inner+i] = f(lane)
+		}
+	}
+	
+	outShape := append(append([]int{}, shape[:ax]...), shape[ax+1:]...)
+	if len(outShape) == 0 {
+		outShape = []int{1}
+	}
+	return tensor.FromSliceFloat64(result, outShape...)
+}
+
+// mapAxis replaces every 1D lane of a along axis by f(lane), which must
+// return a slice of the same length, giving a float64 array of a's shape
+func mapAxis(a *tensor.NDArray, axis int, f func([]float64) []float64) *tensor.NDArray {
+	ax := normalizeAxis(a, axis)
+	shape := a.Shape()
+	data := a.ToSliceFloat64()
+	outer, length, inner := laneLayout(shape, ax)
+	
+	result := make([]float64, len(data))
+	lane := make([]float64, length)
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			for k := range lane {
+				lane[k] = data[(o*length+k)*inner+i]
+			}
+			for k, v := range f(lane) {
+				result[(o*length+k)*inner+i] = v
+			}
+		}
+	}
+	return tensor.FromSliceFloat64(result, shape...)
+}
@@ -0,0 +1,314 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func closeTo(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
+
+func TestDescribe(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{2, 8, 0, 4, 1, 9, 9, 0}, 8)
+	d := Describe(a)
+	
+	// Reference values computed from the moment definitions
+	if d.N != 8 || d.Min != 0 || d.Max != 9 || d.Mean != 4.125 {
+		t.Errorf("unexpected summary %+v", d)
+	}
+	if !closeTo(d.Variance, 15.839285714285714, 1e-12) {
+		t.Errorf("expected variance 15.8393, got %f", d.Variance)
+	}
+	if !closeTo(d.Skewness, 0.2650554122698573, 1e-12) {
+		t.Errorf("unexpected skewness %f", d.Skewness)
+	}
+	if !closeTo(d.Kurtosis, -1.6660010752838508, 1e-12) {
+		t.Errorf("unexpected kurtosis %f", d.Kurtosis)
+	}
+}
+
+func TestDescribeAxis(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3, 4, 6, 8}, 2, 3)
+	d := DescribeAxis(a, 1)
+	if d.N != 3 {
+		t.Errorf("expected N 3, got %d", d.N)
+	}
+	if m := d.Mean.ToSliceFloat64(); m[0] != 2 || m[1] != 6 {
+		t.Errorf("expected means [2 6], got %v", m)
+	}
+	if v := d.Variance.ToSliceFloat64(); v[0] != 1 || v[1] != 4 {
+		t.Errorf("expected variances [1 4], got %v", v)
+	}
+	if m := d.Min.ToSliceFloat64(); m[0] != 1 || m[1] != 4 {
+		t.Errorf("expected minima [1 4], got %v", m)
+	}
+	
+	cols := DescribeAxis(a, 0)
+	if s := cols.Max.Shape(); len(s) != 1 || s[0] != 3 {
+		t.Errorf("expected shape [3], got %v", s)
+	}
+}
+
+func TestSkewKurtosisAxis(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 10, 3, 3, 3, 1, 5, 9}, 3, 3)
+	skews := SkewAxis(a, -1).ToSliceFloat64()
+	kurts := KurtosisAxis(a, 1).ToSliceFloat64()
+	for i, lane := range [][]float64{{1, 2, 10}, {3, 3, 3}, {1, 5, 9}} {
+		row := tensor.FromSliceFloat64(lane, 3)
+		if want := Skew(row); !(closeTo(skews[i], want, 1e-12) || math.IsNaN(want) && math.IsNaN(skews[i])) {
+			t.Errorf("row %d: expected skew %f, got %f", i, want, skews[i])
+		}
+		if want := Kurtosis(row); !(closeTo(kurts[i], want, 1e-12) || math.IsNaN(want) && math.IsNaN(kurts[i])) {
+			t.Errorf("row %d: expected kurtosis %f, got %f", i, want, kurts[i])
+		}
+	}
+	if skews[2] != 0 || !closeTo(kurts[2], -1.5, 1e-12) {
+		t.Errorf("symmetric row: expected skew 0 and kurtosis -1.5, got %f and %f", skews[2], kurts[2])
+	}
+}
+
+func TestZScore(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3, 10, 20, 30}, 2, 3)
+	z := ZScoreAxis(a, 1).ToSliceFloat64()
+	r := math.Sqrt(1.5)
+	want := []float64{-r, 0, r, -r, 0, r}
+	for i := range want {
+		if !closeTo(z[i], want[i], 1e-12) {
+			t.Fatalf("expected %v, got %v", want, z)
+		}
+	}
+	
+	g := ZScore(a)
+	if !closeTo(g.Mean(), 0, 1e-12) || !closeTo(g.Std(), 1, 1e-12) {
+		t.Errorf("global z-scores should have mean 0 and std 1")
+	}
+}
+
+func TestMeans(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{1, 2, 4, 8}, 4)
+	if g := GeometricMean(a); !closeTo(g, math.Sqrt(8), 1e-12) {
+		t.Errorf("expected geometric mean sqrt(8), got %f", g)
+	}
+	if h := HarmonicMean(a); !closeTo(h, 4/1.875, 1e-12) {
+		t.Errorf("expected harmonic mean %f, got %f", 4/1.875, h)
+	}
+	if s := SEM(a); !closeTo(s, math.Sqrt(9.583333333333334/4), 1e-12) {
+		t.Errorf("unexpected SEM %f", s)
+	}
+	
+	b := tensor.FromSliceFloat64([]float64{100, 1, 2, 3, 4, 5, 6, 7, 8, -50}, 10)
+	if m := TrimmedMean(b, 0.1); m != 4.5 {
+		t.Errorf("expected trimmed mean 4.5, got %f", m)
+	}
+	
+	c := tensor.FromSliceFloat64([]float64{1, 4, 2, 8, 0, 5}, 3, 2)
+	geo := GeometricMeanAxis(c, 1).ToSliceFloat64()
+	if !closeTo(geo[0], 2, 1e-12) || !closeTo(geo[1], 4, 1e-12) || geo[2] != 0 {
+		t.Errorf("expected [2 4 0], got %v", geo)
+	}
+	harm := HarmonicMeanAxis(c, 0).ToSliceFloat64()
+	if harm[0] != 0 || !closeTo(harm[1], 3/(0.25+0.125+0.2), 1e-12) {
+		t.Errorf("unexpected harmonic means %v", harm)
+	}
+	sems := SEMAxis(c, 1).ToSliceFloat64()
+	if !closeTo(sems[0], 1.5, 1e-12) {
+		t.Errorf("expected SEM 1.5, got %f", sems[0])
+	}
+	trim := TrimmedMeanAxis(c, 0, 0).ToSliceFloat64()
+	if trim[0] != 1 || trim[1] != 17.0/3 {
+		t.Errorf("expected [1 17/3], got %v", trim)
+	}
+}
+
+func TestHarmonicMeanRejectsNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for negative input")
+		}
+	}()
+	HarmonicMean(tensor.FromSliceFloat64([]float64{1, -1}, 2))
+}
+
+func TestTTests(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{5.1, 4.9, 5.6, 5.8, 6.0, 5.3, 5.7}, 7)
+	b := tensor.FromSliceFloat64([]float64{6.2, 5.9, 6.6, 6.1, 5.8, 6.4}, 6)
+	
+	// p-values checked by numerically integrating the t density
+	cases := []struct {
+		name string
+		got  TestResult
+		t, p float64
+	}{
+		{"one-sample", TTest1Samp(a, 5), 3.231993677674838, 0.017865665063777},
+		{"Student", TTestInd(a, b, true), -3.428520881485579, 0.005637759163762},
+		{"Welch", TTestInd(a, b, false), -3.5072625050193458, 0.004991582882098},
+	}
+	for _, c := range cases {
+		if !closeTo(c.got.Statistic, c.t, 1e-12) || !closeTo(c.got.PValue, c.p, 1e-10) {
+			t.Errorf("%s: expected (%g, %g), got (%g, %g)", c.name, c.t, c.p, c.got.Statistic, c.got.PValue)
+		}
+	}
+	
+	before := tensor.FromSliceFloat64([]float64{10, 12, 9, 11, 13}, 5)
+	after := tensor.FromSliceFloat64([]float64{11, 14, 10, 11, 15}, 5)
+	rel := TTestRel(before, after)
+	diff := tensor.FromSliceFloat64([]float64{-1, -2, -1, 0, -2}, 5)
+	if want := TTest1Samp(diff, 0); rel != want {
+		t.Errorf("paired test: expected %+v, got %+v", want, rel)
+	}
+}
+
+func TestChiSquare(t *testing.T) {
+	obs := tensor.FromSliceFloat64([]float64{16, 18, 16, 14, 12, 12}, 6)
+	res := ChiSquare(obs, nil)
+	// statistic 2.0 with 5 degrees of freedom
+	if !closeTo(res.Statistic, 2, 1e-12) || !closeTo(res.PValue, 0.8491450360846096, 1e-12) {
+		t.Errorf("unexpected result %+v", res)
+	}
+	
+	exp := tensor.FromSliceFloat64([]float64{10, 20}, 2)
+	res = ChiSquare(tensor.FromSliceFloat64([]float64{20, 10}, 2), exp)
+	if !closeTo(res.Statistic, 15, 1e-12) || !closeTo(res.PValue, math.Erfc(math.Sqrt(7.5)), 1e-15) {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestKolmogorovSmirnov(t *testing.T) {
+	n := 200
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = (float64(i) + 0.5) / float64(n)
+	}
+	uniform := func(x float64) float64 { return math.Max(0, math.Min(1, x)) }
+	
+	res := KSTest(tensor.FromSliceFloat64(xs, n), uniform)
+	if !closeTo(res.Statistic, 0.5/float64(n), 1e-12) || res.PValue < 0.99 {
+		t.Errorf("evenly spread sample should fit the uniform CDF: %+v", res)
+	}
+	
+	shifted := make([]float64, n)
+	for i, v := range xs {
+		shifted[i] = v * v
+	}
+	res = KSTest(tensor.FromSliceFloat64(shifted, n), uniform)
+	if !closeTo(res.Statistic, 0.25, 0.01) || res.PValue > 1e-8 {
+		t.Errorf("squared sample should be rejected: %+v", res)
+	}
+	
+	a := tensor.FromSliceFloat64([]float64{1, 2, 3, 4, 5}, 5)
+	b := tensor.FromSliceFloat64([]float64{3, 4, 5, 6, 7, 8}, 6)
+	res = KS2Samp(a, b)
+	if !closeTo(res.Statistic, 0.5, 1e-12) {
+		t.Errorf("expected D = 0.5, got %g", res.Statistic)
+	}
+	if same := KS2Samp(a, a); same.Statistic != 0 || same.PValue != 1 {
+		t.Errorf("identical samples: got %+v", same)
+	}
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{5.1, 4.9, 5.6, 5.8, 6.0, 5.3, 5.7}, 7)
+	b := tensor.FromSliceFloat64([]float64{6.2, 5.9, 6.6, 6.1, 5.8, 6.4}, 6)
+	res := MannWhitneyU(a, b)
+	// One tie (5.8) gives U = 2.5; the p-value follows the tie-corrected
+	// normal approximation
+	n1, n2 := 7.0, 6.0
+	sigma := math.Sqrt(n1 * n2 / 12 * (14 - 6.0/(13*12)))
+	want := math.Erfc((39.5 - 21 - 0.5) / sigma / math.Sqrt2)
+	if res.Statistic != 2.5 || !closeTo(res.PValue, want, 1e-15) {
+		t.Errorf("expected (2.5, %g), got %+v", want, res)
+	}
+}
+
+func TestShapiroWilk(t *testing.T) {
+	// Weights from Shapiro and Wilk (1965); matches R's shapiro.test
+	x := tensor.FromSliceFloat64([]float64{148, 154, 158, 160, 161, 162, 166, 170, 182, 195, 236}, 11)
+	res := ShapiroWilk(x)
+	if !closeTo(res.Statistic, 0.78881, 1e-5) || !closeTo(res.PValue, 0.006704, 1e-6) {
+		t.Errorf("expected (0.78881, 0.006704), got %+v", res)
+	}
+	
+	// Normal quantiles look normal at every size regime
+	for _, n := range []int{3, 8, 50} {
+		q := make([]float64, n)
+		for i := range q {
+			q[i] = math.Sqrt2 * math.Erfinv(2*(float64(i)+0.5)/float64(n)-1)
+		}
+		if res := ShapiroWilk(tensor.FromSliceFloat64(q, n)); res.PValue < 0.5 || res.Statistic > 1 {
+			t.Errorf("n = %d: normal quantiles rejected: %+v", n, res)
+		}
+	}
+}
+
+func TestCorrelations(t *testing.T) {
+	// Reference values from the SciPy documentation examples
+	x := tensor.FromSliceFloat64([]float64{1, 2, 3, 4, 5}, 5)
+	
+	p := PearsonR(x, tensor.FromSliceFloat64([]float64{10, 9, 2.5, 6, 4}, 5))
+	if !closeTo(p.Statistic, -0.7426106572325057, 1e-12) || !closeTo(p.PValue, 0.1505558088534455, 1e-10) {
+		t.Errorf("PearsonR: got %+v", p)
+	}
+	
+	s := SpearmanR(x, tensor.FromSliceFloat64([]float64{5, 6, 7, 8, 7}, 5))
+	if !closeTo(s.Statistic, 0.8207826816681233, 1e-12) || !closeTo(s.PValue, 0.0885870053135438, 1e-10) {
+		t.Errorf("SpearmanR: got %+v", s)
+	}
+	
+	k := KendallTau(
+		tensor.FromSliceFloat64([]float64{12, 2, 1, 12, 2}, 5),
+		tensor.FromSliceFloat64([]float64{1, 4, 7, 1, 0}, 5),
+	)
+	if !closeTo(k.Statistic, -0.47140452079103173, 1e-12) || !closeTo(k.PValue, 0.2827454599327748, 1e-10) {
+		t.Errorf("KendallTau: got %+v", k)
+	}
+	
+	perfect := PearsonR(x, tensor.FromSliceFloat64([]float64{2, 4, 6, 8, 10}, 5))
+	if perfect.Statistic != 1 || perfect.PValue != 0 {
+		t.Errorf("perfect correlation: got %+v", perfect)
+	}
+}
+
+func TestCorrelationMatrices(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{
+		1, 10, 5,
+		2, 9, 6,
+		3, 2.5, 7,
+		4, 6, 8,
+		5, 4, 7,
+	}, 5, 3)
+	col := func(j int) *tensor.NDArray {
+		v := make([]float64, 5)
+		for i := range v {
+			v[i] = a.GetFloat64(i, j)
+		}
+		return tensor.FromSliceFloat64(v, 5)
+	}
+	
+	for name, c := range map[string]struct {
+		matrix func(*tensor.NDArray) (*tensor.NDArray, *tensor.NDArray)
+		pair   func(x, y *tensor.NDArray) TestResult
+	}{
+		"Pearson":  {PearsonRMatrix, PearsonR},
+		"Spearman": {SpearmanRMatrix, SpearmanR},
+		"Kendall":  {KendallTauMatrix, KendallTau},
+	} {
+		r, p := c.matrix(a)
+		if s := r.Shape(); s[0] != 3 || s[1] != 3 {
+			t.Fatalf("%s: expected shape [3 3], got %v", name, s)
+		}
+		for i := 0; i < 3; i++ {
+			if !closeTo(r.GetFloat64(i, i), 1, 1e-12) {
+				t.Errorf("%s: diagonal entry %d is %g", name, i, r.GetFloat64(i, i))
+			}
+			for j := 0; j < 3; j++ {
+				want := c.pair(col(i), col(j))
+				if !closeTo(r.GetFloat64(i, j), want.Statistic, 1e-12) || !closeTo(p.GetFloat64(i, j), want.PValue, 1e-12) {
+					t.Errorf("%s[%d,%d]: expected %+v, got (%g, %g)", name, i, j, want, r.GetFloat64(i, j), p.GetFloat64(i, j))
+				}
+			}
+		}
+	}
+}
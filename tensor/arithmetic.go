@@ -63,8 +63,9 @@ func (a *NDArray) broadcastTo(targetShape []int) (*NDArray, error) {
 	
 	// Create result array
 	result := Zeros(targetShape, a.dtype)
+	item := a.dtype.ItemSize()
 	
-	// Copy data with broadcasting
+	// Copy element bytes with broadcasting so every dtype is kept exactly
 	for i := 0; i < result.size; i++ {
 		dstIndices := result.unravelIndex(i)
 		srcIndices := make([]int, a.ndim)
@@ -77,8 +78,8 @@ func (a *NDArray) broadcastTo(targetShape []int) (*NDArray, error) {
 			srcIndices[j] = srcIdx
 		}
 		
-		val := a.GetFloat64(srcIndices...)
-		result.SetFloat64(val, dstIndices...)
+		offset := a.flatIndex(srcIndices...)
+		copy(result.data[i*item:(i+1)*item], a.data[offset:offset+item])
 	}
 	
 	return result, nil
@@ -86,6 +87,10 @@ func (a *NDArray) broadcastTo(targetShape []int) (*NDArray, error) {
 
 // Add performs element-wise addition with broadcasting
 func (a *NDArray) Add(b *NDArray) *NDArray {
+	if result := a.overflowOp(b, "+"); result != nil {
+		return result
+	}
+	
 	// Compute broadcast shape
 	targetShape, err := broadcastShapes(a.shape, b.shape)
 	if err != nil {
@@ -116,6 +121,10 @@ func (a *NDArray) Add(b *NDArray) *NDArray {
 
 // Sub performs element-wise subtraction with broadcasting
 func (a *NDArray) Sub(b *NDArray) *NDArray {
+	if result := a.overflowOp(b, "-"); result != nil {
+		return result
+	}
+	
 	targetShape, err := broadcastShapes(a.shape, b.shape)
 	if err != nil {
 		panic(err)
@@ -143,6 +152,10 @@ func (a *NDArray) Sub(b *NDArray) *NDArray {
 
 // Mul performs element-wise multiplication with broadcasting
 func (a *NDArray) Mul(b *NDArray) *NDArray {
+	if result := a.overflowOp(b, "*"); result != nil {
+		return result
+	}
+	
 	targetShape, err := broadcastShapes(a.shape, b.shape)
 	if err != nil {
 		panic(err)
@@ -197,6 +210,10 @@ func (a *NDArray) Div(b *NDArray) *NDArray {
 
 // AddScalar adds a scalar value to all elements
 func (a *NDArray) AddScalar(scalar float64) *NDArray {
+	if result := a.overflowScalarOp(scalar, "+"); result != nil {
+		return result
+	}
+	
 	result := a.Copy()
 	for i := 0; i < result.size; i++ {
 		indices := result.unravelIndex(i)
@@ -208,6 +225,10 @@ func (a *NDArray) AddScalar(scalar float64) *NDArray {
 
 // MulScalar multiplies all elements by a scalar value
 func (a *NDArray) MulScalar(scalar float64) *NDArray {
+	if result := a.overflowScalarOp(scalar, "*"); result != nil {
+		return result
+	}
+	
 	result := a.Copy()
 	for i := 0; i < result.size; i++ {
 		indices := result.unravelIndex(i)
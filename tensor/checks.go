@@ -0,0 +1,63 @@
+package tensor
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// CheckMode controls how much validation element accessors perform
+type CheckMode int32
+
+const (
+	// CheckBounds validates index count and per-axis bounds on every access.
+	// This is the default.
+	CheckBounds CheckMode = iota
+	
+	// CheckNone skips index validation in element accessors for speed.
+	// Out-of-range indices may then read or write the wrong element or
+	// trigger a runtime slice panic instead of a descriptive one.
+	CheckNone
+	
+	// CheckDebug performs the CheckBounds validation plus extra checks:
+	// byte offsets are verified against the data buffer (catching corrupt
+	// strides) and writing a NaN through SetFloat64 panics.
+	CheckDebug
+)
+
+// String returns the string representation of a CheckMode
+func (m CheckMode) String() string {
+	switch m {
+	case CheckBounds:
+		return "bounds"
+	case CheckNone:
+		return "none"
+	case CheckDebug:
+		return "debug"
+	default:
+		return "unknown"
+	}
+}
+
+// checkMode holds the active CheckMode. Its initial value is selected by
+// build tags: numgo_debug selects CheckDebug and numgo_nocheck selects
+// CheckNone.
+var checkMode = func() *atomic.Int32 {
+	m := new(atomic.Int32)
+	m.Store(int32(defaultCheckMode))
+	return m
+}()
+
+// SetCheckMode sets the validation level used by element accessors and
+// returns the previous mode. It is safe to call concurrently, but arrays
+// being accessed while the mode changes may observe either mode.
+func SetCheckMode(mode CheckMode) CheckMode {
+	if mode < CheckBounds || mode > CheckDebug {
+		panic(fmt.Sprintf("invalid check mode: %d", mode))
+	}
+	return CheckMode(checkMode.Swap(int32(mode)))
+}
+
+// GetCheckMode returns the active validation level
+func GetCheckMode() CheckMode {
+	return CheckMode(checkMode.Load())
+}
@@ -0,0 +1,5 @@
+//go:build numgo_debug
+
+package tensor
+
+const defaultCheckMode = CheckDebug
@@ -0,0 +1,5 @@
+//go:build !numgo_debug && !numgo_nocheck
+
+package tensor
+
+const defaultCheckMode = CheckBounds
@@ -3,6 +3,8 @@ package tensor
 import (
 	"fmt"
 	"math"
+	"math/big"
+	"sort"
 )
 
 // Equal checks if two arrays are element-wise equal
@@ -58,6 +60,81 @@ func closeEnough(a, b, rtol, atol float64) bool {
 	return diff <= atol+rtol*math.Abs(b)
 }
 
+// ArrayEqual reports whether a and b have the same shape and elements.
+// Values are compared exactly, so arrays of different dtypes holding the
+// same numbers are equal and large integers are not rounded. If equalNaN is
+// true, NaNs in matching positions are treated as equal (similar to NumPy's
+// array_equal(equal_nan=True)); complex values compare each part this way.
+func ArrayEqual(a, b *NDArray, equalNaN bool) bool {
+	if !sameShape(a.shape, b.shape) {
+		return false
+	}
+	
+	var x, y big.Float
+	for i := 0; i < a.size; i++ {
+		indices := a.unravelIndex(i)
+		switch {
+		case a.dtype.IsComplex() || b.dtype.IsComplex():
+			aVal := a.GetComplex128(indices...)
+			bVal := b.GetComplex128(indices...)
+			if !floatEqual(real(aVal), real(bVal), equalNaN) || !floatEqual(imag(aVal), imag(bVal), equalNaN) {
+				return false
+			}
+		case a.dtype.IsInt() || b.dtype.IsInt():
+			// An integer never equals NaN, and big.Float compares
+			// int64, uint64 and float64 values without rounding
+			if !exactValue(&x, a, indices) || !exactValue(&y, b, indices) || x.Cmp(&y) != 0 {
+				return false
+			}
+		default:
+			if !floatEqual(a.GetFloat64(indices...), b.GetFloat64(indices...), equalNaN) {
+				return false
+			}
+		}
+	}
+	
+	return true
+}
+
+// floatEqual reports whether x == y, or whether both are NaN if equalNaN
+func floatEqual(x, y float64, equalNaN bool) bool {
+	return x == y || equalNaN && math.IsNaN(x) && math.IsNaN(y)
+}
+
+// ArrayEquiv reports whether a and b are shape-consistent and element-wise
+// equal, i.e. one can be broadcast to the other's shape and all resulting
+// pairs of elements compare equal (similar to NumPy's array_equiv)
+func ArrayEquiv(a, b *NDArray) bool {
+	targetShape, err := broadcastShapes(a.shape, b.shape)
+	if err != nil {
+		return false
+	}
+	
+	aBroad, err := a.broadcastTo(targetShape)
+	if err != nil {
+		return false
+	}
+	bBroad, err := b.broadcastTo(targetShape)
+	if err != nil {
+		return false
+	}
+	
+	return ArrayEqual(aBroad, bBroad, false)
+}
+
+// sameShape reports whether two shapes are identical
+func sameShape(s1, s2 []int) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // Gt (greater than) returns element-wise comparison a > b
 func (a *NDArray) Gt(b *NDArray) *NDArray {
 	targetShape, err := broadcastShapes(a.shape, b.shape)
@@ -174,6 +251,96 @@ func Where(condition, a, b *NDArray) *NDArray {
 	return result
 }
 
+// Select returns, for each element, the value from the first choice whose
+// condition is true, or def where no condition holds (similar to NumPy's
+// select). Conditions must be boolean; conditions and choices broadcast
+// together, and each element is read directly rather than through
+// broadcast copies. The result has the dtype of the first choice.
+func Select(conditions, choices []*NDArray, def float64) *NDArray {
+	if len(conditions) != len(choices) {
+		panic(fmt.Sprintf("%d conditions for %d choices", len(conditions), len(choices)))
+	}
+	if len(choices) == 0 {
+		panic("Select needs at least one choice")
+	}
+	shape := choices[0].shape
+	for i := range conditions {
+		if conditions[i].dtype != Bool {
+			panic("condition array must be boolean")
+		}
+		var err error
+		if shape, err = broadcastShapes(shape, conditions[i].shape); err != nil {
+			panic(err)
+		}
+		if shape, err = broadcastShapes(shape, choices[i].shape); err != nil {
+			panic(err)
+		}
+	}
+	
+	result := Zeros(shape, choices[0].dtype)
+	for i := 0; i < result.size; i++ {
+		indices := result.unravelIndex(i)
+		value := def
+		for j, cond := range conditions {
+			if cond.GetFloat64(broadcastIndex(cond, indices)...) != 0 {
+				value = choices[j].GetFloat64(broadcastIndex(choices[j], indices)...)
+				break
+			}
+		}
+		result.SetFloat64(value, indices...)
+	}
+	return result
+}
+
+// Piecewise evaluates funcs[i] on the elements of x where conditions[i] is
+// true (similar to NumPy's piecewise). Later conditions take precedence over
+// earlier ones. funcs may have one extra function, applied where no
+// condition holds; otherwise those elements are zero. Each element is
+// passed to a single function. Conditions must be boolean and broadcastable
+// to the shape of x, and the result has the dtype of x.
+func Piecewise(x *NDArray, conditions []*NDArray, funcs []func(float64) float64) *NDArray {
+	if len(funcs) != len(conditions) && len(funcs) != len(conditions)+1 {
+		panic(fmt.Sprintf("%d functions for %d conditions", len(funcs), len(conditions)))
+	}
+	for _, cond := range conditions {
+		if cond.dtype != Bool {
+			panic("condition array must be boolean")
+		}
+		if shape, err := broadcastShapes(x.shape, cond.shape); err != nil || !sameShape(shape, x.shape) {
+			panic(fmt.Sprintf("condition of shape %v cannot be broadcast to %v", cond.shape, x.shape))
+		}
+	}
+	
+	result := Zeros(x.shape, x.dtype)
+	for i := 0; i < result.size; i++ {
+		indices := result.unravelIndex(i)
+		chosen := len(conditions)
+		for j := len(conditions) - 1; j >= 0; j-- {
+			if conditions[j].GetFloat64(broadcastIndex(conditions[j], indices)...) != 0 {
+				chosen = j
+				break
+			}
+		}
+		if chosen < len(funcs) {
+			result.SetFloat64(funcs[chosen](x.GetFloat64(indices...)), indices...)
+		}
+	}
+	return result
+}
+
+// broadcastIndex maps indices into a broadcast result back to the indices
+// of a, which has been broadcast to that result's shape
+func broadcastIndex(a *NDArray, indices []int) []int {
+	offset := len(indices) - a.ndim
+	src := make([]int, a.ndim)
+	for j := range src {
+		if a.shape[j] != 1 {
+			src[j] = indices[offset+j]
+		}
+	}
+	return src
+}
+
 // Concatenate joins arrays along an existing axis
 func Concatenate(arrays []*NDArray, axis int) *NDArray {
 	if len(arrays) == 0 {
@@ -282,6 +449,56 @@ func (a *NDArray) Unique() *NDArray {
 	return FromSliceFloat64(uniqueSlice, len(uniqueSlice))
 }
 
+// UniqueTol returns the sorted distinct values of an array, treating values
+// within tol of each other as equal. See GroupByValue for how values are
+// grouped; each group is represented by its smallest member.
+func (a *NDArray) UniqueTol(tol float64) *NDArray {
+	values, _ := a.GroupByValue(tol)
+	return values
+}
+
+// GroupByValue clusters the elements of an array whose values lie within
+// tol of each other, which exact comparison cannot do for computed floats.
+// Sorted values are grouped greedily: a group starts at its smallest member
+// and takes every value at most tol above it, so no group spans more than
+// tol. It returns the smallest member of each group in ascending order and
+// an Int64 array of a's shape giving each element's group. All NaNs form one
+// last group.
+func (a *NDArray) GroupByValue(tol float64) (*NDArray, *NDArray) {
+	if tol < 0 || math.IsNaN(tol) {
+		panic(fmt.Sprintf("tolerance must be non-negative, got %v", tol))
+	}
+	values := a.ToSliceFloat64()
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		x, y := values[order[i]], values[order[j]]
+		return x < y || !math.IsNaN(x) && math.IsNaN(y)
+	})
+	
+	var groups []float64
+	labels := make([]int64, len(values))
+	for _, i := range order {
+		v := values[i]
+		last := len(groups) - 1
+		switch {
+		case last < 0:
+			groups = append(groups, v)
+		case math.IsNaN(v):
+			if !math.IsNaN(groups[last]) {
+				groups = append(groups, v)
+			}
+		case v-groups[last] > tol:
+			groups = append(groups, v)
+		}
+		labels[i] = int64(len(groups) - 1)
+	}
+	
+	return FromSliceFloat64(groups, len(groups)), FromSliceInt64(labels, a.shape...)
+}
+
 // Repeat repeats elements of an array
 func (a *NDArray) Repeat(repeats int) *NDArray {
 	if repeats < 0 {
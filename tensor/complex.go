@@ -0,0 +1,118 @@
+package tensor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// GetComplex128 returns the element at the given indices as complex128.
+// Real dtypes are returned with a zero imaginary part.
+func (a *NDArray) GetComplex128(indices ...int) complex128 {
+	switch a.dtype {
+	case Complex128:
+		offset := a.flatIndex(indices...)
+		re := math.Float64frombits(binary.LittleEndian.Uint64(a.data[offset : offset+8]))
+		im := math.Float64frombits(binary.LittleEndian.Uint64(a.data[offset+8 : offset+16]))
+		return complex(re, im)
+	case Complex64:
+		offset := a.flatIndex(indices...)
+		re := math.Float32frombits(binary.LittleEndian.Uint32(a.data[offset : offset+4]))
+		im := math.Float32frombits(binary.LittleEndian.Uint32(a.data[offset+4 : offset+8]))
+		return complex(float64(re), float64(im))
+	default:
+		return complex(a.GetFloat64(indices...), 0)
+	}
+}
+
+// SetComplex128 sets the element at the given indices from a complex128
+// value. Storing into a real dtype panics unless the imaginary part is zero.
+func (a *NDArray) SetComplex128(value complex128, indices ...int) {
+	switch a.dtype {
+	case Complex128:
+		a.checkWritable()
+		offset := a.flatIndex(indices...)
+		binary.LittleEndian.PutUint64(a.data[offset:offset+8], math.Float64bits(real(value)))
+		binary.LittleEndian.PutUint64(a.data[offset+8:offset+16], math.Float64bits(imag(value)))
+	case Complex64:
+		a.checkWritable()
+		offset := a.flatIndex(indices...)
+		binary.LittleEndian.PutUint32(a.data[offset:offset+4], math.Float32bits(float32(real(value))))
+		binary.LittleEndian.PutUint32(a.data[offset+4:offset+8], math.Float32bits(float32(imag(value))))
+	default:
+		if imag(value) != 0 {
+			panic(fmt.Sprintf("cannot store complex value %v in %s array", value, a.dtype))
+		}
+		a.SetFloat64(real(value), indices...)
+	}
+}
+
+// FromSliceComplex128 creates a complex128 array from a slice with given shape
+func FromSliceComplex128(data []complex128, shape ...int) *NDArray {
+	size := computeSize(shape)
+	if len(data) != size {
+		panic(fmt.Sprintf("data length %d does not match shape size %d", len(data), size))
+	}
+	
+	itemsize := Complex128.ItemSize()
+	byteData := make([]byte, size*itemsize)
+	
+	for i, val := range data {
+		offset := i * 16
+		binary.LittleEndian.PutUint64(byteData[offset:offset+8], math.Float64bits(real(val)))
+		binary.LittleEndian.PutUint64(byteData[offset+8:offset+16], math.Float64bits(imag(val)))
+	}
+	
+	return &NDArray{
+		data:    byteData,
+		shape:   append([]int{}, shape...),
+		strides: computeStrides(shape, itemsize),
+		dtype:   Complex128,
+		size:    size,
+		ndim:    len(shape),
+	}
+}
+
+// ToSliceComplex128 converts the entire array to a flat complex128 slice
+func (a *NDArray) ToSliceComplex128() []complex128 {
+	result := make([]complex128, a.size)
+	
+	for i := 0; i < a.size; i++ {
+		indices := a.unravelIndex(i)
+		result[i] = a.GetComplex128(indices...)
+	}
+	
+	return result
+}
+
+// Real returns the real part of each element as a float64 array
+func (a *NDArray) Real() *NDArray {
+	result := make([]float64, a.size)
+	for i, v := range a.ToSliceComplex128() {
+		result[i] = real(v)
+	}
+	return FromSliceFloat64(result, a.shape...)
+}
+
+// Imag returns the imaginary part of each element as a float64 array.
+// Real arrays yield zeros.
+func (a *NDArray) Imag() *NDArray {
+	result := make([]float64, a.size)
+	for i, v := range a.ToSliceComplex128() {
+		result[i] = imag(v)
+	}
+	return FromSliceFloat64(result, a.shape...)
+}
+
+// Conj returns the complex conjugate of each element. Real arrays are
+// returned as an unchanged copy.
+func (a *NDArray) Conj() *NDArray {
+	if !a.dtype.IsComplex() {
+		return a.Copy()
+	}
+	result := Zeros(a.shape, a.dtype)
+	for i, v := range a.ToSliceComplex128() {
+		result.SetComplex128(complex(real(v), -imag(v)), result.unravelIndex(i)...)
+	}
+	return result
+}
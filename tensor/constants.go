@@ -0,0 +1,107 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+)
+
+// Mathematical constants, as in NumPy
+const (
+	Pi         = math.Pi
+	E          = math.E
+	EulerGamma = 0.57721566490153286060651209008240243104215933593992 // Euler–Mascheroni constant γ
+)
+
+// Inf returns positive infinity, NumPy's np.inf
+func Inf() float64 {
+	return math.Inf(1)
+}
+
+// NegInf returns negative infinity, NumPy's -np.inf
+func NegInf() float64 {
+	return math.Inf(-1)
+}
+
+// NaN returns an IEEE 754 "not a number" value, NumPy's np.nan
+func NaN() float64 {
+	return math.NaN()
+}
+
+// FloatInfo describes the limits of a floating-point dtype, like
+// numpy.finfo
+type FloatInfo struct {
+	DType             DType   // the real dtype described
+	Bits              int     // storage size in bits
+	Eps               float64 // gap between 1 and the next larger value
+	Max               float64 // largest finite value
+	Min               float64 // most negative finite value, -Max
+	SmallestNormal    float64 // smallest positive normal value
+	SmallestSubnormal float64 // smallest positive subnormal value
+	Precision         int     // decimal digits of precision
+	Resolution        float64 // 10^-Precision
+	MantissaBits      int     // explicit bits in the mantissa
+	ExponentBits      int     // bits in the exponent
+	MinExp            int     // smallest power of 2 giving a normal value
+	MaxExp            int     // smallest power of 2 that overflows
+}
+
+// Finfo returns the limits of a floating-point dtype. For a complex dtype
+// it describes the dtype of the real and imaginary parts.
+func Finfo(dtype DType) FloatInfo {
+	switch dtype {
+	case Float32, Complex64:
+		return FloatInfo{
+			DType:             Float32,
+			Bits:              32,
+			Eps:               0x1p-23,
+			Max:               math.MaxFloat32,
+			Min:               -math.MaxFloat32,
+			SmallestNormal:    0x1p-126,
+			SmallestSubnormal: math.SmallestNonzeroFloat32,
+			Precision:         6,
+			Resolution:        1e-6,
+			MantissaBits:      23,
+			ExponentBits:      8,
+			MinExp:            -126,
+			MaxExp:            128,
+		}
+	case Float64, Complex128:
+		return FloatInfo{
+			DType:             Float64,
+			Bits:              64,
+			Eps:               0x1p-52,
+			Max:               math.MaxFloat64,
+			Min:               -math.MaxFloat64,
+			SmallestNormal:    0x1p-1022,
+			SmallestSubnormal: math.SmallestNonzeroFloat64,
+			Precision:         15,
+			Resolution:        1e-15,
+			MantissaBits:      52,
+			ExponentBits:      11,
+			MinExp:            -1022,
+			MaxExp:            1024,
+		}
+	}
+	panic(fmt.Sprintf("Finfo requires a floating-point or complex dtype, got %s", dtype))
+}
+
+// IntInfo describes the limits of an integer dtype, like numpy.iinfo. Max
+// is unsigned so that it can hold the largest Uint64.
+type IntInfo struct {
+	DType DType
+	Bits  int
+	Min   int64
+	Max   uint64
+}
+
+// Iinfo returns the limits of an integer dtype
+func Iinfo(dtype DType) IntInfo {
+	if !dtype.IsInt() {
+		panic(fmt.Sprintf("Iinfo requires an integer dtype, got %s", dtype))
+	}
+	bits := 8 * dtype.ItemSize()
+	if dtype >= Uint8 {
+		return IntInfo{DType: dtype, Bits: bits, Max: math.MaxUint64 >> (64 - bits)}
+	}
+	return IntInfo{DType: dtype, Bits: bits, Min: math.MinInt64 >> (64 - bits), Max: math.MaxInt64 >> (64 - bits)}
+}
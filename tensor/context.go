@@ -0,0 +1,88 @@
+package tensor
+
+import (
+	"context"
+)
+
+// ctxCheckInterval is the number of elements processed between checks of
+// ctx.Done() in the *Context variants of long-running operations
+const ctxCheckInterval = 4096
+
+// SumContext computes the sum of all elements, aborting with ctx.Err() if
+// the context is cancelled before the reduction completes
+func (a *NDArray) SumContext(ctx context.Context) (float64, error) {
+	sum := 0.0
+	for i := 0; i < a.size; i++ {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+		indices := a.unravelIndex(i)
+		sum += a.GetFloat64(indices...)
+	}
+	return sum, nil
+}
+
+// MeanContext computes the mean of all elements, aborting with ctx.Err() if
+// the context is cancelled before the reduction completes
+func (a *NDArray) MeanContext(ctx context.Context) (float64, error) {
+	sum, err := a.SumContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if a.size == 0 {
+		return a.Mean(), nil
+	}
+	return sum / float64(a.size), nil
+}
+
+// SumAxisContext computes the sum along a specific axis, aborting with
+// ctx.Err() if the context is cancelled before the reduction completes
+func (a *NDArray) SumAxisContext(ctx context.Context, axis int) (*NDArray, error) {
+	if axis < 0 {
+		axis = a.ndim + axis
+	}
+	if axis < 0 || axis >= a.ndim {
+		panic("axis out of bounds")
+	}
+	
+	resultShape := make([]int, 0, a.ndim-1)
+	for i := 0; i < a.ndim; i++ {
+		if i != axis {
+			resultShape = append(resultShape, a.shape[i])
+		}
+	}
+	
+	if len(resultShape) == 0 {
+		sum, err := a.SumContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return FromSliceFloat64([]float64{sum}, 1), nil
+	}
+	
+	result := Zeros(resultShape, a.dtype)
+	
+	for i := 0; i < a.size; i++ {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		srcIndices := a.unravelIndex(i)
+		
+		dstIndices := make([]int, 0, len(resultShape))
+		for j := 0; j < a.ndim; j++ {
+			if j != axis {
+				dstIndices = append(dstIndices, srcIndices[j])
+			}
+		}
+		
+		val := a.GetFloat64(srcIndices...)
+		currentSum := result.GetFloat64(dstIndices...)
+		result.SetFloat64(currentSum+val, dstIndices...)
+	}
+	
+	return result, nil
+}
@@ -167,6 +167,48 @@ func FromSliceFloat32(data []float32, shape ...int) *NDArray {
 	}
 }
 
+// FromBytes creates an array of the given dtype and shape from raw
+// little-endian element data in C order, the layout returned by Data. The
+// bytes are copied.
+func FromBytes(data []byte, dtype DType, shape ...int) *NDArray {
+	return WrapBytes(append([]byte{}, data...), dtype, shape...)
+}
+
+// FromBytesOrder is FromBytes for element data in the given byte order,
+// such as binary.BigEndian for data from big-endian instruments or files.
+// Arrays always store little-endian data, so big-endian input is converted.
+func FromBytesOrder(data []byte, dtype DType, order binary.ByteOrder, shape ...int) *NDArray {
+	a := FromBytes(data, dtype, shape...)
+	if isBigEndian(order) {
+		swapElements(a.data, dtype)
+	}
+	return a
+}
+
+// WrapBytes is FromBytes without the copy: the array uses data as its
+// buffer, so changes through either are visible in both. The caller must
+// keep data valid for as long as the array is used, which allows arrays
+// backed by memory-mapped files.
+func WrapBytes(data []byte, dtype DType, shape ...int) *NDArray {
+	itemsize := dtype.ItemSize()
+	if itemsize == 0 {
+		panic(fmt.Sprintf("unsupported dtype %d", int(dtype)))
+	}
+	size := computeSize(shape)
+	if len(data) != size*itemsize {
+		panic(fmt.Sprintf("data length %d does not match %d elements of %s", len(data), size, dtype))
+	}
+	
+	return &NDArray{
+		data:    data,
+		shape:   append([]int{}, shape...),
+		strides: computeStrides(shape, itemsize),
+		dtype:   dtype,
+		size:    size,
+		ndim:    len(shape),
+	}
+}
+
 // Arange creates an array with evenly spaced values within a given interval
 // Similar to NumPy's arange(start, stop, step)
 func Arange(start, stop, step float64) *NDArray {
@@ -0,0 +1,148 @@
+package tensor
+
+import (
+	"fmt"
+)
+
+// WithDims returns an array labelling each axis with a name, similar to
+// xarray's dimension names. The result shares the underlying data with a.
+// Labels let reductions and selections refer to axes by name, e.g.
+// x.WithDims("batch", "feature").SumDim("feature").
+func (a *NDArray) WithDims(names ...string) *NDArray {
+	if len(names) != a.ndim {
+		panic(fmt.Sprintf("expected %d dimension names, got %d", a.ndim, len(names)))
+	}
+	
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if name == "" {
+			panic("dimension names cannot be empty")
+		}
+		if seen[name] {
+			panic(fmt.Sprintf("repeated dimension name: %q", name))
+		}
+		seen[name] = true
+	}
+	
+	return &NDArray{
+		data:    a.data,
+		shape:   append([]int{}, a.shape...),
+		strides: append([]int{}, a.strides...),
+		dtype:   a.dtype,
+		size:    a.size,
+		ndim:    a.ndim,
+		frozen:  a.frozen,
+		dims:    append([]string{}, names...),
+	}
+}
+
+// Dims returns the axis labels, or nil if the array is unlabeled
+func (a *NDArray) Dims() []string {
+	return copyDims(a.dims)
+}
+
+// AxisOf returns the axis number of the dimension with the given name
+func (a *NDArray) AxisOf(name string) int {
+	if a.dims == nil {
+		panic("array has no dimension names")
+	}
+	for i, dim := range a.dims {
+		if dim == name {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("no dimension named %q in %v", name, a.dims))
+}
+
+// SumDim computes the sum along the named dimension. The result keeps the
+// labels of the remaining dimensions.
+func (a *NDArray) SumDim(name string) *NDArray {
+	axis := a.AxisOf(name)
+	return a.labelReduced(a.SumAxis(axis), axis)
+}
+
+// MeanDim computes the mean along the named dimension. The result keeps the
+// labels of the remaining dimensions.
+func (a *NDArray) MeanDim(name string) *NDArray {
+	axis := a.AxisOf(name)
+	return a.labelReduced(a.MeanAxis(axis), axis)
+}
+
+// SelectDim returns the slice at index along the named dimension, removing
+// that dimension. Negative indices count from the end.
+func (a *NDArray) SelectDim(name string, index int) *NDArray {
+	axis := a.AxisOf(name)
+	return a.labelReduced(a.selectAxis(axis, index), axis)
+}
+
+// TransposeDims reorders the axes to match the given dimension names
+func (a *NDArray) TransposeDims(names ...string) *NDArray {
+	if len(names) != a.ndim {
+		panic(fmt.Sprintf("expected %d dimension names, got %d", a.ndim, len(names)))
+	}
+	axes := make([]int, len(names))
+	for i, name := range names {
+		axes[i] = a.AxisOf(name)
+	}
+	return a.Transpose(axes...)
+}
+
+// selectAxis returns a copy of the slice at index along axis, with that
+// axis removed
+func (a *NDArray) selectAxis(axis, index int) *NDArray {
+	if axis < 0 || axis >= a.ndim {
+		panic(fmt.Sprintf("axis %d is out of bounds for array of dimension %d", axis, a.ndim))
+	}
+	if index < 0 {
+		index = a.shape[axis] + index
+	}
+	if index < 0 || index >= a.shape[axis] {
+		panic(fmt.Sprintf("index %d is out of bounds for axis %d with size %d", index, axis, a.shape[axis]))
+	}
+	
+	resultShape := make([]int, 0, a.ndim-1)
+	resultShape = append(resultShape, a.shape[:axis]...)
+	resultShape = append(resultShape, a.shape[axis+1:]...)
+	
+	// Element bytes are copied so every dtype keeps its exact values
+	item := a.dtype.ItemSize()
+	if len(resultShape) == 0 {
+		result := Zeros([]int{1}, a.dtype)
+		offset := a.flatIndex(index)
+		copy(result.data, a.data[offset:offset+item])
+		return result
+	}
+	
+	result := Zeros(resultShape, a.dtype)
+	srcIndices := make([]int, a.ndim)
+	for i := 0; i < result.size; i++ {
+		dstIndices := result.unravelIndex(i)
+		copy(srcIndices[:axis], dstIndices[:axis])
+		srcIndices[axis] = index
+		copy(srcIndices[axis+1:], dstIndices[axis:])
+		offset := a.flatIndex(srcIndices...)
+		copy(result.data[i*item:(i+1)*item], a.data[offset:offset+item])
+	}
+	
+	return result
+}
+
+// labelReduced attaches a's labels, minus the removed axis, to result
+func (a *NDArray) labelReduced(result *NDArray, removed int) *NDArray {
+	if a.dims == nil || result.ndim != a.ndim-1 {
+		return result
+	}
+	dims := make([]string, 0, result.ndim)
+	dims = append(dims, a.dims[:removed]...)
+	dims = append(dims, a.dims[removed+1:]...)
+	result.dims = dims
+	return result
+}
+
+// copyDims returns a copy of a label slice, preserving nil
+func copyDims(dims []string) []string {
+	if dims == nil {
+		return nil
+	}
+	return append([]string{}, dims...)
+}
@@ -0,0 +1,327 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Rearrange reorders, splits and merges axes as described by an einops
+// pattern such as "b h w c -> b c h w". Names on the left label the axes of
+// a; the right gives the output layout. Parentheses group axes that share
+// one dimension, so "b (h w) -> b h w" splits an axis and "b h w -> b (h w)"
+// merges two. "1" or "()" stands for an axis of length one, and "..."
+// for any number of leading, middle or trailing axes.
+func Rearrange(a *NDArray, pattern string) *NDArray {
+	return RearrangeWith(a, pattern, nil)
+}
+
+// RearrangeWith is Rearrange with the lengths of axes that cannot be
+// inferred from the shape, as in "(h w) c -> h w c" with sizes {"h": 4}
+func RearrangeWith(a *NDArray, pattern string, sizes map[string]int) *NDArray {
+	return einops(a, pattern, "", sizes)
+}
+
+// ReduceExpr reduces the axes that appear on the left of an einops pattern
+// but not on the right, as in ReduceExpr(a, "b h w c -> b c", "mean") for
+// global average pooling. The reduction is "sum", "mean", "min", "max" or
+// "prod". Mean gives Float64 for non-float input; the others keep the dtype.
+func ReduceExpr(a *NDArray, pattern, reduction string) *NDArray {
+	return ReduceExprWith(a, pattern, reduction, nil)
+}
+
+// ReduceExprWith is ReduceExpr with the lengths of axes that cannot be
+// inferred from the shape, as in "b (h h2) -> b h" with sizes {"h2": 2}
+func ReduceExprWith(a *NDArray, pattern, reduction string, sizes map[string]int) *NDArray {
+	switch reduction {
+	case "sum", "mean", "min", "max", "prod":
+	default:
+		panic(fmt.Sprintf("unknown reduction %q", reduction))
+	}
+	return einops(a, pattern, reduction, sizes)
+}
+
+// einops applies a pattern, reducing the dropped axes if reduction is set
+func einops(a *NDArray, pattern, reduction string, sizes map[string]int) *NDArray {
+	sides := strings.Split(pattern, "->")
+	if len(sides) != 2 {
+		panic(fmt.Sprintf("pattern %q must have exactly one \"->\"", pattern))
+	}
+	left, leftEllipsis := parseEinopsSide(sides[0], true)
+	right, rightEllipsis := parseEinopsSide(sides[1], false)
+	if rightEllipsis && !leftEllipsis {
+		panic(fmt.Sprintf("pattern %q uses \"...\" only on the right", pattern))
+	}
+	
+	// Expand the ellipsis into as many anonymous axes as it covers
+	var covered []string
+	if leftEllipsis {
+		n := a.ndim - len(left) + 1
+		if n < 0 {
+			panic(fmt.Sprintf("pattern %q has more axes than the array's %d", pattern, a.ndim))
+		}
+		for i := 0; i < n; i++ {
+			covered = append(covered, fmt.Sprintf("...%d", i))
+		}
+	}
+	left = expandEllipsis(left, covered)
+	right = expandEllipsis(right, covered)
+	if len(left) != a.ndim {
+		panic(fmt.Sprintf("pattern %q has %d axes on the left, array has %d", pattern, len(left), a.ndim))
+	}
+	
+	// Find the length of every elementary axis
+	length := map[string]int{}
+	for name, n := range sizes {
+		if n < 1 {
+			panic(fmt.Sprintf("axis %s has invalid length %d", name, n))
+		}
+		length[name] = n
+	}
+	var elems []string
+	inLeft := map[string]bool{}
+	for i, group := range left {
+		known, unknown := 1, ""
+		for _, name := range group {
+			if inLeft[name] {
+				panic(fmt.Sprintf("axis %s appears twice on the left of %q", name, pattern))
+			}
+			inLeft[name] = true
+			elems = append(elems, name)
+			if n, ok := length[name]; ok {
+				known *= n
+			} else if unknown != "" {
+				panic(fmt.Sprintf("cannot infer the lengths of both %s and %s in %q", unknown, name, pattern))
+			} else {
+				unknown = name
+			}
+		}
+		switch {
+		case unknown != "" && a.shape[i]%known == 0:
+			length[unknown] = a.shape[i] / known
+		case unknown != "" || known != a.shape[i]:
+			panic(fmt.Sprintf("axis %d of length %d does not match %v in %q", i, a.shape[i], group, pattern))
+		}
+	}
+	for name := range sizes {
+		if !inLeft[name] {
+			panic(fmt.Sprintf("axis %s is not on the left of %q", name, pattern))
+		}
+	}
+	
+	// Order the elementary axes as on the right, followed by any reduced ones
+	var order []string
+	inRight := map[string]bool{}
+	for _, group := range right {
+		for _, name := range group {
+			if !inLeft[name] {
+				panic(fmt.Sprintf("axis %s is not on the left of %q", name, pattern))
+			}
+			if inRight[name] {
+				panic(fmt.Sprintf("axis %s appears twice on the right of %q", name, pattern))
+			}
+			inRight[name] = true
+			order = append(order, name)
+		}
+	}
+	kept, nkept := 1, len(order)
+	for _, name := range order {
+		kept *= length[name]
+	}
+	for _, name := range elems {
+		if !inRight[name] {
+			if reduction == "" {
+				panic(fmt.Sprintf("axis %s is missing on the right of %q", name, pattern))
+			}
+			order = append(order, name)
+		}
+	}
+	
+	outShape := make([]int, len(right))
+	for i, group := range right {
+		outShape[i] = 1
+		for _, name := range group {
+			outShape[i] *= length[name]
+		}
+	}
+	if len(outShape) == 0 {
+		// NumGo has no 0-d arrays; a scalar result is a one-element 1D array
+		outShape = []int{1}
+	}
+	if len(elems) == 0 {
+		return a.Reshape(outShape...)
+	}
+	
+	elemShape := make([]int, len(elems))
+	position := map[string]int{}
+	for i, name := range elems {
+		elemShape[i] = length[name]
+		position[name] = i
+	}
+	x := a.Reshape(elemShape...)
+	perm := make([]int, len(order))
+	identity := true
+	for i, name := range order {
+		perm[i] = position[name]
+		identity = identity && perm[i] == i
+	}
+	if !identity {
+		x = x.Transpose(perm...)
+	}
+	if len(order) == nkept {
+		return x.Reshape(outShape...)
+	}
+	return reduceRows(x, kept, reduction).Reshape(outShape...)
+}
+
+// reduceRows views x as kept rows of equal length and reduces each row
+func reduceRows(x *NDArray, kept int, reduction string) *NDArray {
+	values := x.ToSliceFloat64()
+	n := 0
+	if kept > 0 {
+		n = len(values) / kept
+	}
+	if kept > 0 && n == 0 && (reduction == "min" || reduction == "max") {
+		panic(fmt.Sprintf("cannot take the %s of an empty axis", reduction))
+	}
+	dtype := x.dtype
+	if reduction == "mean" && !dtype.IsFloat() {
+		dtype = Float64
+	}
+	result := Zeros([]int{kept}, dtype)
+	for i := 0; i < kept; i++ {
+		row := values[i*n : (i+1)*n]
+		var v float64
+		switch reduction {
+		case "sum", "mean":
+			for _, r := range row {
+				v += r
+			}
+			if reduction == "mean" {
+				v /= float64(n)
+			}
+		case "prod":
+			v = 1
+			for _, r := range row {
+				v *= r
+			}
+		case "min":
+			v = math.Inf(1)
+			for _, r := range row {
+				v = math.Min(v, r)
+			}
+		case "max":
+			v = math.Inf(-1)
+			for _, r := range row {
+				v = math.Max(v, r)
+			}
+		}
+		result.SetFloat64(v, i)
+	}
+	return result
+}
+
+// parseEinopsSide splits one side of a pattern into groups of axis names.
+// A bare name is a group of one and "1" or "()" an empty group. An ellipsis
+// is a nil group at the top level and the name "..." inside parentheses;
+// its presence is reported.
+func parseEinopsSide(side string, left bool) ([][]string, bool) {
+	var groups [][]string
+	var group []string
+	open, ellipsis := false, false
+	for i := 0; i < len(side); {
+		c := side[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			if open {
+				panic(fmt.Sprintf("nested parentheses in %q", side))
+			}
+			open, group = true, []string{}
+			i++
+		case c == ')':
+			if !open {
+				panic(fmt.Sprintf("unbalanced parentheses in %q", side))
+			}
+			groups = append(groups, group)
+			open = false
+			i++
+		default:
+			j := i
+			for j < len(side) && !strings.ContainsRune(" \t()", rune(side[j])) {
+				j++
+			}
+			token := side[i:j]
+			i = j
+			switch {
+			case token == "...":
+				if ellipsis {
+					panic(fmt.Sprintf("more than one \"...\" in %q", side))
+				}
+				if open && left {
+					panic(fmt.Sprintf("\"...\" inside parentheses on the left of %q", side))
+				}
+				ellipsis = true
+				if !open {
+					groups = append(groups, nil)
+					continue
+				}
+			case token == "1":
+				if open {
+					continue
+				}
+				groups = append(groups, []string{})
+				continue
+			case !isIdentifier(token):
+				panic(fmt.Sprintf("invalid axis name %q", token))
+			}
+			if open {
+				group = append(group, token)
+			} else {
+				groups = append(groups, []string{token})
+			}
+		}
+	}
+	if open {
+		panic(fmt.Sprintf("unbalanced parentheses in %q", side))
+	}
+	return groups, ellipsis
+}
+
+// expandEllipsis replaces the ellipsis with the axes it covers. At the top
+// level each covered axis becomes a group of its own; inside parentheses
+// they join the group.
+func expandEllipsis(groups [][]string, covered []string) [][]string {
+	var out [][]string
+	for _, group := range groups {
+		if group == nil {
+			for _, name := range covered {
+				out = append(out, []string{name})
+			}
+			continue
+		}
+		var expanded []string
+		for _, name := range group {
+			if name == "..." {
+				expanded = append(expanded, covered...)
+			} else {
+				expanded = append(expanded, name)
+			}
+		}
+		out = append(out, expanded)
+	}
+	return out
+}
+
+// isIdentifier reports whether s is a valid axis name: a letter or
+// underscore followed by letters, digits or underscores
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		letter := r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		if !letter && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
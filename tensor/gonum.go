@@ -0,0 +1,88 @@
+package tensor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unsafe"
+	
+	"gonum.org/v1/gonum/mat"
+)
+
+// nativeLittleEndian reports whether the host stores values little-endian,
+// the layout of every NDArray buffer
+var nativeLittleEndian = binary.NativeEndian.Uint16([]byte{1, 0}) == 1
+
+// GonumMatrix exposes a 2D array as a gonum mat.Mutable, converting each
+// element to and from float64 on access. Set panics if the array is frozen.
+type GonumMatrix struct {
+	a *NDArray
+}
+
+// Dims returns the number of rows and columns
+func (m *GonumMatrix) Dims() (r, c int) {
+	return m.a.shape[0], m.a.shape[1]
+}
+
+// At returns the element at row i, column j
+func (m *GonumMatrix) At(i, j int) float64 {
+	return m.a.GetFloat64(i, j)
+}
+
+// Set sets the element at row i, column j
+func (m *GonumMatrix) Set(i, j int, v float64) {
+	m.a.SetFloat64(v, i, j)
+}
+
+// T returns the transpose without copying
+func (m *GonumMatrix) T() mat.Matrix {
+	return mat.Transpose{Matrix: m}
+}
+
+// AsGonum exposes a 2D array as a gonum matrix. A writable Float64 array is
+// wrapped in a *mat.Dense sharing its memory, so writes through either are
+// seen by both and gonum's BLAS-backed routines work on it directly. Other
+// dtypes and frozen arrays are wrapped in a *GonumMatrix instead.
+func AsGonum(a *NDArray) mat.Mutable {
+	if a.ndim != 2 {
+		panic(fmt.Sprintf("AsGonum requires a 2D array, got shape %v", a.shape))
+	}
+	if a.dtype == Float64 && !a.frozen && a.size > 0 {
+		if data, ok := float64View(a.data); ok {
+			return mat.NewDense(a.shape[0], a.shape[1], data)
+		}
+	}
+	return &GonumMatrix{a: a}
+}
+
+// FromGonum converts a gonum matrix to a 2D Float64 array. A matrix whose
+// rows are stored back to back, such as a freshly allocated *mat.Dense,
+// shares its memory with the result; anything else is copied.
+func FromGonum(m mat.Matrix) *NDArray {
+	if g, ok := m.(*GonumMatrix); ok && g.a.dtype == Float64 {
+		return g.a
+	}
+	r, c := m.Dims()
+	if raw, ok := m.(mat.RawMatrixer); ok && nativeLittleEndian {
+		if rm := raw.RawMatrix(); rm.Stride == c && len(rm.Data) >= r*c {
+			data := rm.Data[:r*c]
+			return WrapBytes(unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(data))), 8*len(data)), Float64, r, c)
+		}
+	}
+	
+	values := make([]float64, 0, r*c)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			values = append(values, m.At(i, j))
+		}
+	}
+	return FromSliceFloat64(values, r, c)
+}
+
+// float64View reinterprets a byte buffer as float64 values, which is only
+// possible on little-endian hosts when the buffer is 8-byte aligned
+func float64View(data []byte) ([]float64, bool) {
+	if !nativeLittleEndian || len(data)%8 != 0 || uintptr(unsafe.Pointer(unsafe.SliceData(data)))%8 != 0 {
+		return nil, false
+	}
+	return unsafe.Slice((*float64)(unsafe.Pointer(unsafe.SliceData(data))), len(data)/8), true
+}
@@ -0,0 +1,48 @@
+package tensor
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+)
+
+// Hash returns a stable 64-bit FNV-1a digest of the array's dtype, shape
+// and elements. Arrays with equal dtype, shape and element bytes always
+// hash to the same value, across processes and platforms.
+func (a *NDArray) Hash() uint64 {
+	h := fnv.New64a()
+	a.writeHash(h)
+	return h.Sum64()
+}
+
+// Hash128 returns a stable 128-bit FNV-1a digest of the array's dtype,
+// shape and elements, for use where 64-bit collisions are a concern
+func (a *NDArray) Hash128() [16]byte {
+	h := fnv.New128a()
+	a.writeHash(h)
+	
+	var digest [16]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
+
+// writeHash streams the dtype, shape and element bytes (in logical C order)
+// into h without copying the data buffer
+func (a *NDArray) writeHash(h hash.Hash) {
+	var buf [8]byte
+	
+	binary.LittleEndian.PutUint64(buf[:], uint64(a.dtype))
+	h.Write(buf[:])
+	binary.LittleEndian.PutUint64(buf[:], uint64(a.ndim))
+	h.Write(buf[:])
+	for _, dim := range a.shape {
+		binary.LittleEndian.PutUint64(buf[:], uint64(dim))
+		h.Write(buf[:])
+	}
+	
+	itemsize := a.dtype.ItemSize()
+	for i := 0; i < a.size; i++ {
+		offset := a.flatIndex(a.unravelIndex(i)...)
+		h.Write(a.data[offset : offset+itemsize])
+	}
+}
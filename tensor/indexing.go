@@ -8,6 +8,18 @@ import (
 
 // flatIndex converts multi-dimensional indices to a flat byte offset
 func (a *NDArray) flatIndex(indices ...int) int {
+	mode := GetCheckMode()
+	if mode == CheckNone {
+		offset := 0
+		for i, idx := range indices {
+			if idx < 0 {
+				idx = a.shape[i] + idx
+			}
+			offset += idx * a.strides[i]
+		}
+		return offset
+	}
+	
 	if len(indices) != a.ndim {
 		panic(fmt.Sprintf("expected %d indices, got %d", a.ndim, len(indices)))
 	}
@@ -23,6 +35,14 @@ func (a *NDArray) flatIndex(indices ...int) int {
 		}
 		offset += idx * a.strides[i]
 	}
+	
+	if mode == CheckDebug {
+		itemsize := a.dtype.ItemSize()
+		if offset < 0 || offset+itemsize > len(a.data) {
+			panic(fmt.Sprintf("byte offset %d for indices %v exceeds buffer of %d bytes (shape %v, strides %v)",
+				offset, indices, len(a.data), a.shape, a.strides))
+		}
+	}
 	return offset
 }
 
@@ -102,6 +122,10 @@ func (a *NDArray) GetInt64(indices ...int) int64 {
 
 // SetFloat64 sets the element at the given indices from a float64 value
 func (a *NDArray) SetFloat64(value float64, indices ...int) {
+	a.checkWritable()
+	if math.IsNaN(value) && GetCheckMode() == CheckDebug {
+		panic(fmt.Sprintf("NaN written at indices %v", indices))
+	}
 	offset := a.flatIndex(indices...)
 	
 	switch a.dtype {
@@ -138,6 +162,7 @@ func (a *NDArray) SetFloat64(value float64, indices ...int) {
 
 // SetInt64 sets the element at the given indices from an int64 value
 func (a *NDArray) SetInt64(value int64, indices ...int) {
+	a.checkWritable()
 	offset := a.flatIndex(indices...)
 	
 	switch a.dtype {
@@ -210,7 +235,8 @@ func (a *NDArray) unravelIndex(flatIdx int) []int {
 	return indices
 }
 
-// Copy creates a deep copy of the array
+// Copy creates a deep copy of the array. The copy is always writable, even
+// if the original is frozen.
 func (a *NDArray) Copy() *NDArray {
 	newData := make([]byte, len(a.data))
 	copy(newData, a.data)
@@ -222,5 +248,6 @@ func (a *NDArray) Copy() *NDArray {
 		dtype:   a.dtype,
 		size:    a.size,
 		ndim:    a.ndim,
+		dims:    copyDims(a.dims),
 	}
 }
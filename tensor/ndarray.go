@@ -1,8 +1,15 @@
 // Package tensor provides the core N-dimensional array (NDArray) implementation
 // for NumGo, similar to NumPy's ndarray.
+//
+// Concurrency: an NDArray is not safe for concurrent use if any goroutine
+// writes to it. Operations that only read an array (arithmetic, reductions,
+// Get* accessors, Copy) may run concurrently with each other. An array marked
+// read-only with Freeze can never be written again, so it can be shared
+// freely across goroutines, e.g. for cached weights or lookup tables.
 package tensor
 
 import (
+	"encoding/binary"
 	"fmt"
 )
 
@@ -100,6 +107,8 @@ type NDArray struct {
 	dtype  DType    // Data type of elements
 	size   int      // Total number of elements
 	ndim   int      // Number of dimensions
+	frozen bool     // Whether the array is read-only
+	dims   []string // Optional axis labels (nil if unlabeled)
 }
 
 // Shape returns the shape of the array
@@ -132,6 +141,26 @@ func (a *NDArray) ItemSize() int {
 	return a.dtype.ItemSize()
 }
 
+// Freeze marks the array as read-only and returns it. Any later Set* call on
+// the array panics. Freezing cannot be undone; use Copy to obtain a writable
+// array with the same contents.
+func (a *NDArray) Freeze() *NDArray {
+	a.frozen = true
+	return a
+}
+
+// IsFrozen reports whether the array has been marked read-only by Freeze
+func (a *NDArray) IsFrozen() bool {
+	return a.frozen
+}
+
+// checkWritable panics if the array is read-only
+func (a *NDArray) checkWritable() {
+	if a.frozen {
+		panic("assignment to frozen (read-only) array")
+	}
+}
+
 // computeStrides calculates C-contiguous strides for a given shape
 func computeStrides(shape []int, itemsize int) []int {
 	if len(shape) == 0 {
@@ -168,3 +197,32 @@ func (a *NDArray) String() string {
 func (a *NDArray) Data() []byte {
 	return append([]byte{}, a.data...)
 }
+
+// DataOrder returns a copy of the underlying data buffer with every element
+// in the given byte order, for writing to big-endian files or devices
+func (a *NDArray) DataOrder(order binary.ByteOrder) []byte {
+	data := a.Data()
+	if isBigEndian(order) {
+		swapElements(data, a.dtype)
+	}
+	return data
+}
+
+// isBigEndian reports whether order stores the most significant byte first
+func isBigEndian(order binary.ByteOrder) bool {
+	return order.Uint16([]byte{0, 1}) == 1
+}
+
+// swapElements reverses the byte order of every element of data in place.
+// The two parts of a complex number are swapped separately.
+func swapElements(data []byte, dtype DType) {
+	width := dtype.ItemSize()
+	if dtype.IsComplex() {
+		width /= 2
+	}
+	for start := 0; start+width <= len(data); start += width {
+		for i, j := start, start+width-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package tensor
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ObjectArray is an n-dimensional array of arbitrary Go values, like
+// NumPy's object dtype. It holds labels, records or other metadata next to
+// numeric arrays. Elements cannot live in the byte buffer of an NDArray, so
+// this is a separate type; comparisons take user-supplied functions since
+// the values have no natural order.
+type ObjectArray struct {
+	data  []any
+	shape []int
+}
+
+// NewObjectArray creates an array of the given shape filled with nil
+func NewObjectArray(shape ...int) *ObjectArray {
+	for _, dim := range shape {
+		if dim < 0 {
+			panic(fmt.Sprintf("negative dimensions not allowed: %v", shape))
+		}
+	}
+	return &ObjectArray{
+		data:  make([]any, computeSize(shape)),
+		shape: append([]int{}, shape...),
+	}
+}
+
+// FromSliceObject creates an ObjectArray from a slice of values, which is
+// copied
+func FromSliceObject(data []any, shape ...int) *ObjectArray {
+	if len(shape) == 0 {
+		shape = []int{len(data)}
+	}
+	o := NewObjectArray(shape...)
+	if len(data) != len(o.data) {
+		panic(fmt.Sprintf("data length %d doesn't match shape %v (size %d)", len(data), shape, len(o.data)))
+	}
+	copy(o.data, data)
+	return o
+}
+
+// Shape returns the shape of the array
+func (o *ObjectArray) Shape() []int {
+	return append([]int{}, o.shape...)
+}
+
+// Size returns the number of elements
+func (o *ObjectArray) Size() int {
+	return len(o.data)
+}
+
+// Ndim returns the number of dimensions
+func (o *ObjectArray) Ndim() int {
+	return len(o.shape)
+}
+
+// Get returns the element at the given indices
+func (o *ObjectArray) Get(indices ...int) any {
+	return o.data[o.flatIndex(indices)]
+}
+
+// Set stores v at the given indices
+func (o *ObjectArray) Set(v any, indices ...int) {
+	o.data[o.flatIndex(indices)] = v
+}
+
+// ToSlice returns a copy of the elements in row-major order
+func (o *ObjectArray) ToSlice() []any {
+	return append([]any{}, o.data...)
+}
+
+// Reshape returns a copy with a new shape; one dimension may be -1
+func (o *ObjectArray) Reshape(newShape ...int) *ObjectArray {
+	shape := append([]int{}, newShape...)
+	inferIdx, known := -1, 1
+	for i, dim := range shape {
+		switch {
+		case dim == -1 && inferIdx == -1:
+			inferIdx = i
+		case dim < 0:
+			panic(fmt.Sprintf("invalid dimension %d in shape %v", dim, newShape))
+		default:
+			known *= dim
+		}
+	}
+	if inferIdx != -1 {
+		if known == 0 || len(o.data)%known != 0 {
+			panic(fmt.Sprintf("cannot reshape array of size %d into shape %v", len(o.data), newShape))
+		}
+		shape[inferIdx] = len(o.data) / known
+	}
+	if computeSize(shape) != len(o.data) {
+		panic(fmt.Sprintf("cannot reshape array of size %d into shape %v", len(o.data), newShape))
+	}
+	return &ObjectArray{data: o.ToSlice(), shape: shape}
+}
+
+// Flatten returns a 1D copy of the array
+func (o *ObjectArray) Flatten() *ObjectArray {
+	return o.Reshape(len(o.data))
+}
+
+// Take selects elements at the given positions along an axis. Negative
+// positions count from the end and may repeat, as in numpy.take.
+func (o *ObjectArray) Take(indices []int, axis int) *ObjectArray {
+	axis = o.checkAxis(axis)
+	n := o.shape[axis]
+	outer, inner := o.around(axis)
+	
+	shape := o.Shape()
+	shape[axis] = len(indices)
+	result := NewObjectArray(shape...)
+	for j, idx := range indices {
+		if idx < 0 {
+			idx += n
+		}
+		if idx < 0 || idx >= n {
+			panic(fmt.Sprintf("index %d out of bounds for axis %d with size %d", indices[j], axis, n))
+		}
+		for i := 0; i < outer; i++ {
+			copy(result.data[(i*len(indices)+j)*inner:][:inner], o.data[(i*n+idx)*inner:][:inner])
+		}
+	}
+	return result
+}
+
+// ConcatenateObjects joins object arrays along an existing axis
+func ConcatenateObjects(arrays []*ObjectArray, axis int) *ObjectArray {
+	if len(arrays) == 0 {
+		panic("need at least one array to concatenate")
+	}
+	first := arrays[0]
+	axis = first.checkAxis(axis)
+	
+	shape := first.Shape()
+	shape[axis] = 0
+	for _, arr := range arrays {
+		if len(arr.shape) != len(first.shape) {
+			panic("all arrays must have same number of dimensions")
+		}
+		for i, dim := range arr.shape {
+			if i != axis && dim != first.shape[i] {
+				panic(fmt.Sprintf("array dimensions must match except on axis %d", axis))
+			}
+		}
+		shape[axis] += arr.shape[axis]
+	}
+	
+	// Each outer slice of the result is the arrays' outer slices in turn
+	result := NewObjectArray(shape...)
+	outer, _ := first.around(axis)
+	pos := 0
+	for i := 0; i < outer; i++ {
+		for _, arr := range arrays {
+			_, inner := arr.around(axis)
+			chunk := arr.shape[axis] * inner
+			pos += copy(result.data[pos:], arr.data[i*chunk:(i+1)*chunk])
+		}
+	}
+	return result
+}
+
+// Equal compares two arrays of the same shape element by element with eq
+// and returns a Bool array
+func (o *ObjectArray) Equal(b *ObjectArray, eq func(x, y any) bool) *NDArray {
+	if !sameShape(o.shape, b.shape) {
+		panic(fmt.Sprintf("shape mismatch: %v vs %v", o.shape, b.shape))
+	}
+	result := Zeros(o.shape, Bool)
+	for i, x := range o.data {
+		if eq(x, b.data[i]) {
+			result.data[i] = 1
+		}
+	}
+	return result
+}
+
+// Mask returns a Bool array that is true where pred holds, for combining
+// conditions on metadata with numeric masks
+func (o *ObjectArray) Mask(pred func(x any) bool) *NDArray {
+	result := Zeros(o.shape, Bool)
+	for i, x := range o.data {
+		if pred(x) {
+			result.data[i] = 1
+		}
+	}
+	return result
+}
+
+// ArgSort returns the positions that stably sort a 1D array by less
+func (o *ObjectArray) ArgSort(less func(x, y any) bool) []int {
+	if len(o.shape) != 1 {
+		panic(fmt.Sprintf("ArgSort requires a 1D array, got shape %v", o.shape))
+	}
+	order := make([]int, len(o.data))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return less(o.data[order[i]], o.data[order[j]])
+	})
+	return order
+}
+
+// Sort returns a stably sorted copy of a 1D array
+func (o *ObjectArray) Sort(less func(x, y any) bool) *ObjectArray {
+	return o.Take(o.ArgSort(less), 0)
+}
+
+// Unique returns the distinct elements of the flattened array in sorted
+// order, treating x and y as equal when neither is less than the other
+func (o *ObjectArray) Unique(less func(x, y any) bool) *ObjectArray {
+	sorted := o.Flatten().Sort(less)
+	var out []any
+	for _, x := range sorted.data {
+		if len(out) == 0 || less(out[len(out)-1], x) {
+			out = append(out, x)
+		}
+	}
+	return FromSliceObject(out, len(out))
+}
+
+// Map applies f to every element and returns the results as a Float64
+// array of the same shape
+func (o *ObjectArray) Map(f func(x any) float64) *NDArray {
+	values := make([]float64, len(o.data))
+	for i, x := range o.data {
+		values[i] = f(x)
+	}
+	return FromSliceFloat64(values, o.shape...)
+}
+
+// flatIndex returns the row-major position of the element at indices
+func (o *ObjectArray) flatIndex(indices []int) int {
+	if len(indices) != len(o.shape) {
+		panic(fmt.Sprintf("expected %d indices, got %d", len(o.shape), len(indices)))
+	}
+	flat := 0
+	for i, idx := range indices {
+		if idx < 0 || idx >= o.shape[i] {
+			panic(fmt.Sprintf("index %d out of bounds for axis %d with size %d", idx, i, o.shape[i]))
+		}
+		flat = flat*o.shape[i] + idx
+	}
+	return flat
+}
+
+// checkAxis normalizes a possibly negative axis
+func (o *ObjectArray) checkAxis(axis int) int {
+	if axis < 0 {
+		axis += len(o.shape)
+	}
+	if axis < 0 || axis >= len(o.shape) {
+		panic(fmt.Sprintf("axis %d out of bounds for %d-D array", axis, len(o.shape)))
+	}
+	return axis
+}
+
+// around returns the number of elements before and after an axis
+func (o *ObjectArray) around(axis int) (outer, inner int) {
+	outer, inner = 1, 1
+	for _, dim := range o.shape[:axis] {
+		outer *= dim
+	}
+	for _, dim := range o.shape[axis+1:] {
+		inner *= dim
+	}
+	return outer, inner
+}
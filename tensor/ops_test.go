@@ -1,6 +1,7 @@
 package tensor
 
 import (
+	"context"
 	"math"
 	"testing"
 )
@@ -263,3 +264,210 @@ func TestMeanAxis(t *testing.T) {
 		}
 	}
 }
+
+func TestArrayEqual(t *testing.T) {
+	a := FromSliceFloat64([]float64{1, 2, math.NaN()}, 3)
+	b := FromSliceFloat64([]float64{1, 2, math.NaN()}, 3)
+	
+	if ArrayEqual(a, b, false) {
+		t.Error("expected NaN != NaN without equalNaN")
+	}
+	if !ArrayEqual(a, b, true) {
+		t.Error("expected arrays to be equal with equalNaN")
+	}
+	
+	// Same values, different dtype
+	c := FromSliceInt64([]int64{1, 2, 3}, 3)
+	d := FromSliceFloat64([]float64{1, 2, 3}, 3)
+	if !ArrayEqual(c, d, false) {
+		t.Error("expected int64 and float64 arrays with same values to be equal")
+	}
+	
+	// Same size, different shape
+	e := FromSliceFloat64([]float64{1, 2, 3, 4}, 2, 2)
+	f := FromSliceFloat64([]float64{1, 2, 3, 4}, 4)
+	if ArrayEqual(e, f, false) {
+		t.Error("expected arrays with different shapes to be unequal")
+	}
+	
+	// Integers above 2^53 are compared without rounding
+	if ArrayEqual(FromSliceInt64([]int64{1<<62 + 1}, 1), FromSliceInt64([]int64{1 << 62}, 1), false) {
+		t.Error("expected distinct large int64 values to be unequal")
+	}
+	if ArrayEqual(FromSliceInt64([]int64{1<<53 + 1}, 1), FromSliceFloat64([]float64{1 << 53}, 1), false) {
+		t.Error("expected 2^53+1 and float64 2^53 to be unequal")
+	}
+	maxUint := WrapBytes(FromSliceInt64([]int64{-1}, 1).Data(), Uint64, 1)
+	if ArrayEqual(maxUint, FromSliceInt64([]int64{-1}, 1), false) {
+		t.Error("expected uint64 max and int64 -1 to be unequal")
+	}
+	if !ArrayEqual(maxUint, WrapBytes(FromSliceInt64([]int64{-1}, 1).Data(), Uint64, 1), false) {
+		t.Error("expected equal uint64 arrays to be equal")
+	}
+	
+	// Complex values compare both parts, with NaN in either
+	g := FromSliceComplex128([]complex128{1 + 2i, complex(math.NaN(), 1)}, 2)
+	h := FromSliceComplex128([]complex128{1 + 2i, complex(math.NaN(), 1)}, 2)
+	if ArrayEqual(g, h, false) || !ArrayEqual(g, h, true) {
+		t.Error("expected complex NaNs to match only with equalNaN")
+	}
+	if ArrayEqual(FromSliceComplex128([]complex128{1 + 2i}, 1), FromSliceFloat64([]float64{1}, 1), false) {
+		t.Error("expected a nonzero imaginary part to make arrays unequal")
+	}
+	c64 := Zeros([]int{1}, Complex64)
+	c64.SetComplex128(3, 0)
+	if !ArrayEqual(c64, FromSliceInt64([]int64{3}, 1), false) {
+		t.Error("expected complex64 3+0i to equal int64 3")
+	}
+}
+
+func TestArrayEquiv(t *testing.T) {
+	a := FromSliceFloat64([]float64{1, 2, 1, 2}, 2, 2)
+	b := FromSliceFloat64([]float64{1, 2}, 2)
+	
+	if !ArrayEquiv(a, b) {
+		t.Error("expected arrays to be equivalent after broadcasting")
+	}
+	
+	c := FromSliceFloat64([]float64{1, 2, 3}, 3)
+	if ArrayEquiv(a, c) {
+		t.Error("expected non-broadcastable arrays to be non-equivalent")
+	}
+	
+	d := FromSliceFloat64([]float64{1, 3}, 2)
+	if ArrayEquiv(a, d) {
+		t.Error("expected arrays with different values to be non-equivalent")
+	}
+	
+	e := FromSliceComplex128([]complex128{1i, 2, 1i, 2}, 2, 2)
+	if !ArrayEquiv(e, FromSliceComplex128([]complex128{1i, 2}, 2)) || ArrayEquiv(e, b) {
+		t.Error("expected complex arrays to be compared by both parts")
+	}
+	if ArrayEquiv(FromSliceInt64([]int64{1<<62 + 1, 1 << 62}, 2), FromSliceInt64([]int64{1 << 62}, 1)) {
+		t.Error("expected distinct large int64 values to be non-equivalent")
+	}
+}
+
+func TestReduceWhere(t *testing.T) {
+	a := FromSliceFloat64([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	where := Zeros([]int{3}, Bool)
+	where.SetFloat64(1, 0)
+	where.SetFloat64(1, 2)
+	opts := ReduceOptions{Where: where}
+	
+	// Mask broadcasts over rows: selects columns 0 and 2
+	if sum := a.SumWith(opts); sum != 14 {
+		t.Errorf("expected masked sum 14, got %f", sum)
+	}
+	if mean := a.MeanWith(opts); mean != 3.5 {
+		t.Errorf("expected masked mean 3.5, got %f", mean)
+	}
+	if min := a.MinWith(opts); min != 1 {
+		t.Errorf("expected masked min 1, got %f", min)
+	}
+	if max := a.MaxWith(opts); max != 6 {
+		t.Errorf("expected masked max 6, got %f", max)
+	}
+	if prod := a.ProdWith(opts); prod != 72 {
+		t.Errorf("expected masked prod 72, got %f", prod)
+	}
+	
+	sum1 := a.SumAxisWith(1, opts)
+	expected := []float64{4, 10}
+	for i := 0; i < 2; i++ {
+		if sum1.GetFloat64(i) != expected[i] {
+			t.Errorf("expected %f at index %d, got %f", expected[i], i, sum1.GetFloat64(i))
+		}
+	}
+	
+	empty := ReduceOptions{Where: Zeros([]int{2, 3}, Bool)}
+	if !math.IsNaN(a.MeanWith(empty)) {
+		t.Error("expected NaN mean for empty selection")
+	}
+	
+	// Selected NaNs propagate like np.min and np.max; masked-out ones do not
+	a.SetFloat64(math.NaN(), 1, 1)
+	if !math.IsNaN(a.MinWith(ReduceOptions{})) || !math.IsNaN(a.MaxWith(ReduceOptions{})) {
+		t.Error("expected NaN min and max with a NaN element")
+	}
+	if min := a.MinWith(opts); min != 1 {
+		t.Errorf("expected masked min 1 ignoring the unselected NaN, got %f", min)
+	}
+}
+
+func TestReduceDType(t *testing.T) {
+	a := Full([]int{3}, 100, Int64)
+	
+	// 300 wraps around in an int8 accumulator
+	if sum := a.SumWith(ReduceOptions{DType: Int8}); sum != 44 {
+		t.Errorf("expected int8 accumulated sum 44, got %f", sum)
+	}
+	if sum := a.SumWith(ReduceOptions{}); sum != 300 {
+		t.Errorf("expected default sum 300, got %f", sum)
+	}
+	
+	b := FromSliceFloat64([]float64{0.1, 0.2, 0.3, 0.4}, 2, 2)
+	sum0 := b.SumAxisWith(0, ReduceOptions{DType: Float32})
+	if sum0.DType() != Float32 {
+		t.Errorf("expected result dtype float32, got %s", sum0.DType())
+	}
+	
+	// 1D arrays reduce to a one-element array of the same dtype
+	if got := a.SumAxisWith(0, ReduceOptions{}); got.DType() != Int64 || got.GetInt64(0) != 300 {
+		t.Errorf("expected int64 [300], got %s %v", got.DType(), got.ToSliceFloat64())
+	}
+	if got := a.SumAxisWith(0, ReduceOptions{DType: Int8}); got.DType() != Int8 || got.GetInt64(0) != 44 {
+		t.Errorf("expected int8 [44], got %s %v", got.DType(), got.ToSliceFloat64())
+	}
+}
+
+func TestSumContext(t *testing.T) {
+	a := Ones([]int{100, 100}, Float64)
+	
+	sum, err := a.SumContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sum != 10000 {
+		t.Errorf("expected sum 10000, got %f", sum)
+	}
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := a.SumContext(ctx); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if _, err := a.SumAxisContext(ctx, 0); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	a := FromSliceFloat64([]float64{1, 2, 3, 4}, 2, 2)
+	b := FromSliceFloat64([]float64{1, 1}, 2)
+	
+	out, err := a.Pipe().Add(b).MulScalar(2).Reshape(4).Result()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []float64{4, 6, 8, 10}
+	for i := 0; i < 4; i++ {
+		if out.GetFloat64(i) != expected[i] {
+			t.Errorf("expected %f at index %d, got %f", expected[i], i, out.GetFloat64(i))
+		}
+	}
+	
+	// A failing step captures the error and skips the rest
+	calls := 0
+	bad := FromSliceFloat64([]float64{1, 2, 3}, 3)
+	_, err = NewPipeline(a).Add(bad).Map("count", func(x *NDArray) *NDArray {
+		calls++
+		return x
+	}).Result()
+	if err == nil {
+		t.Error("expected broadcast error from pipeline")
+	}
+	if calls != 0 {
+		t.Errorf("expected steps after failure to be skipped, got %d calls", calls)
+	}
+}
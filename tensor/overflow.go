@@ -0,0 +1,183 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sync/atomic"
+)
+
+// OverflowMode controls what Add, Sub, Mul, AddScalar and MulScalar on
+// integer arrays do when a result does not fit the dtype. Float operands
+// are combined exactly and truncated toward zero, as in the default mode.
+type OverflowMode int32
+
+const (
+	// OverflowWrap lets results wrap around silently. This is the default.
+	OverflowWrap OverflowMode = iota
+	
+	// OverflowPanic computes exactly and panics on overflow
+	OverflowPanic
+	
+	// OverflowSaturate computes exactly and clamps results to the range of
+	// the dtype
+	OverflowSaturate
+)
+
+// String returns the string representation of an OverflowMode
+func (m OverflowMode) String() string {
+	switch m {
+	case OverflowWrap:
+		return "wrap"
+	case OverflowPanic:
+		return "panic"
+	case OverflowSaturate:
+		return "saturate"
+	default:
+		return "unknown"
+	}
+}
+
+// overflowMode holds the active OverflowMode
+var overflowMode atomic.Int32
+
+// SetOverflowMode sets how integer arithmetic handles overflow and
+// returns the previous mode. Like SetCheckMode it applies to the whole
+// program; use AddChecked and friends to check individual operations.
+func SetOverflowMode(mode OverflowMode) OverflowMode {
+	if mode < OverflowWrap || mode > OverflowSaturate {
+		panic(fmt.Sprintf("invalid overflow mode: %d", mode))
+	}
+	return OverflowMode(overflowMode.Swap(int32(mode)))
+}
+
+// GetOverflowMode returns the active overflow mode
+func GetOverflowMode() OverflowMode {
+	return OverflowMode(overflowMode.Load())
+}
+
+// AddChecked is Add for integer arrays that returns an error instead of a
+// wrapped result when an element overflows the dtype of a, or when b holds
+// a NaN. If a is not an integer array or b is complex, the arrays are added
+// as usual.
+func (a *NDArray) AddChecked(b *NDArray) (*NDArray, error) {
+	return a.checkedOp(b, "+", OverflowPanic)
+}
+
+// SubChecked is Sub for integer arrays that returns an error instead of a
+// wrapped result when an element overflows the dtype of a
+func (a *NDArray) SubChecked(b *NDArray) (*NDArray, error) {
+	return a.checkedOp(b, "-", OverflowPanic)
+}
+
+// MulChecked is Mul for integer arrays that returns an error instead of a
+// wrapped result when an element overflows the dtype of a
+func (a *NDArray) MulChecked(b *NDArray) (*NDArray, error) {
+	return a.checkedOp(b, "*", OverflowPanic)
+}
+
+// exactPrec is enough mantissa bits to hold the exact sum or product of
+// an integer below 2^64 and any finite float64
+const exactPrec = 1200
+
+// checkedOp applies op element-wise without rounding. Integer and float
+// elements of b are combined exactly with those of a, the result is
+// truncated toward zero as a float result would be, and on overflow an
+// error is returned or, with OverflowSaturate, the result is clamped.
+// Non-integer a and complex b use the usual arithmetic.
+func (a *NDArray) checkedOp(b *NDArray, op string, mode OverflowMode) (*NDArray, error) {
+	if !a.dtype.IsInt() || b.dtype.IsComplex() {
+		switch op {
+		case "+":
+			return a.Add(b), nil
+		case "-":
+			return a.Sub(b), nil
+		default:
+			return a.Mul(b), nil
+		}
+	}
+	shape, err := broadcastShapes(a.shape, b.shape)
+	if err != nil {
+		return nil, err
+	}
+	
+	info := Iinfo(a.dtype)
+	lo, hi := big.NewInt(info.Min), new(big.Int).SetUint64(info.Max)
+	x, y, z := new(big.Float).SetPrec(exactPrec), new(big.Float).SetPrec(exactPrec), new(big.Float).SetPrec(exactPrec)
+	n := new(big.Int)
+	result := Zeros(shape, a.dtype)
+	for i := 0; i < result.size; i++ {
+		indices := result.unravelIndex(i)
+		exactValue(x, a, broadcastIndex(a, indices))
+		if !exactValue(y, b, broadcastIndex(b, indices)) || op == "*" && y.IsInf() && x.Sign() == 0 {
+			return nil, fmt.Errorf("%s %s %v at index %v is not a number", x.Text('g', -1), op, b.GetFloat64(broadcastIndex(b, indices)...), indices)
+		}
+		switch op {
+		case "+":
+			z.Add(x, y)
+		case "-":
+			z.Sub(x, y)
+		default:
+			z.Mul(x, y)
+		}
+		
+		if !z.IsInf() {
+			z.Int(n)
+		}
+		if z.IsInf() || n.Cmp(lo) < 0 || n.Cmp(hi) > 0 {
+			if mode != OverflowSaturate {
+				return nil, fmt.Errorf("%s overflow: %s %s %s = %s at index %v", a.dtype, x.Text('g', -1), op, y.Text('g', -1), z.Text('g', -1), indices)
+			}
+			if z.Sign() < 0 {
+				n.Set(lo)
+			} else {
+				n.Set(hi)
+			}
+		}
+		if n.IsInt64() {
+			result.SetInt64(n.Int64(), indices...)
+		} else {
+			// Only Uint64 values above MaxInt64 get here; SetInt64 keeps
+			// their bits
+			result.SetInt64(int64(n.Uint64()), indices...)
+		}
+	}
+	return result, nil
+}
+
+// overflowOp runs op under the active overflow mode, or returns nil when
+// the usual arithmetic applies
+func (a *NDArray) overflowOp(b *NDArray, op string) *NDArray {
+	mode := GetOverflowMode()
+	if mode == OverflowWrap || !a.dtype.IsInt() || b.dtype.IsComplex() {
+		return nil
+	}
+	result, err := a.checkedOp(b, op, mode)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// overflowScalarOp is overflowOp for AddScalar and MulScalar
+func (a *NDArray) overflowScalarOp(scalar float64, op string) *NDArray {
+	return a.overflowOp(FromSliceFloat64([]float64{scalar}, 1), op)
+}
+
+// exactValue sets z to the element of a at indices without rounding. It
+// returns false for NaN, which big.Float cannot hold.
+func exactValue(z *big.Float, a *NDArray, indices []int) bool {
+	switch {
+	case a.dtype == Uint64:
+		z.SetUint64(uint64(a.GetInt64(indices...)))
+	case a.dtype.IsInt() || a.dtype == Bool:
+		z.SetInt64(a.GetInt64(indices...))
+	default:
+		v := a.GetFloat64(indices...)
+		if math.IsNaN(v) {
+			return false
+		}
+		z.SetFloat64(v)
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+package tensor
+
+import (
+	"fmt"
+)
+
+// Pipeline chains array operations fluently. The first operation that
+// fails is recorded and all later steps become no-ops, so the error only
+// needs to be checked once when calling Result.
+//
+//	out, err := tensor.NewPipeline(x).Sub(mean).Div(std).Clip(-3, 3).Result()
+type Pipeline struct {
+	arr *NDArray
+	err error
+}
+
+// NewPipeline starts a pipeline from the given array
+func NewPipeline(a *NDArray) *Pipeline {
+	p := &Pipeline{arr: a}
+	if a == nil {
+		p.err = fmt.Errorf("pipeline: nil input array")
+	}
+	return p
+}
+
+// Pipe starts a pipeline from this array
+func (a *NDArray) Pipe() *Pipeline {
+	return NewPipeline(a)
+}
+
+// Result returns the final array, or the first error encountered
+func (p *Pipeline) Result() (*NDArray, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.arr, nil
+}
+
+// Err returns the first error encountered, if any
+func (p *Pipeline) Err() error {
+	return p.err
+}
+
+// step runs f on the current array unless an earlier step failed,
+// converting a panic from f into the pipeline's error
+func (p *Pipeline) step(name string, f func(*NDArray) (*NDArray, error)) *Pipeline {
+	if p.err != nil {
+		return p
+	}
+	
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				p.err = fmt.Errorf("pipeline %s: %v", name, r)
+			}
+		}()
+		
+		out, err := f(p.arr)
+		if err != nil {
+			p.err = fmt.Errorf("pipeline %s: %w", name, err)
+			return
+		}
+		p.arr = out
+	}()
+	
+	return p
+}
+
+// Apply runs an arbitrary error-returning operation as a pipeline step
+func (p *Pipeline) Apply(name string, f func(*NDArray) (*NDArray, error)) *Pipeline {
+	return p.step(name, f)
+}
+
+// Map runs an arbitrary operation as a pipeline step; a panic in f is
+// captured as the pipeline's error
+func (p *Pipeline) Map(name string, f func(*NDArray) *NDArray) *Pipeline {
+	return p.step(name, func(a *NDArray) (*NDArray, error) {
+		return f(a), nil
+	})
+}
+
+// Add performs element-wise addition with broadcasting
+func (p *Pipeline) Add(b *NDArray) *Pipeline {
+	return p.Map("Add", func(a *NDArray) *NDArray { return a.Add(b) })
+}
+
+// Sub performs element-wise subtraction with broadcasting
+func (p *Pipeline) Sub(b *NDArray) *Pipeline {
+	return p.Map("Sub", func(a *NDArray) *NDArray { return a.Sub(b) })
+}
+
+// Mul performs element-wise multiplication with broadcasting
+func (p *Pipeline) Mul(b *NDArray) *Pipeline {
+	return p.Map("Mul", func(a *NDArray) *NDArray { return a.Mul(b) })
+}
+
+// Div performs element-wise division with broadcasting
+func (p *Pipeline) Div(b *NDArray) *Pipeline {
+	return p.Map("Div", func(a *NDArray) *NDArray { return a.Div(b) })
+}
+
+// AddScalar adds a scalar value to all elements
+func (p *Pipeline) AddScalar(scalar float64) *Pipeline {
+	return p.Map("AddScalar", func(a *NDArray) *NDArray { return a.AddScalar(scalar) })
+}
+
+// MulScalar multiplies all elements by a scalar value
+func (p *Pipeline) MulScalar(scalar float64) *Pipeline {
+	return p.Map("MulScalar", func(a *NDArray) *NDArray { return a.MulScalar(scalar) })
+}
+
+// Pow raises each element to the power of exponent
+func (p *Pipeline) Pow(exponent float64) *Pipeline {
+	return p.Map("Pow", func(a *NDArray) *NDArray { return a.Pow(exponent) })
+}
+
+// Sqrt computes the square root of each element
+func (p *Pipeline) Sqrt() *Pipeline {
+	return p.Map("Sqrt", (*NDArray).Sqrt)
+}
+
+// Exp computes e^x for each element
+func (p *Pipeline) Exp() *Pipeline {
+	return p.Map("Exp", (*NDArray).Exp)
+}
+
+// Log computes natural logarithm for each element
+func (p *Pipeline) Log() *Pipeline {
+	return p.Map("Log", (*NDArray).Log)
+}
+
+// Abs computes the absolute value of each element
+func (p *Pipeline) Abs() *Pipeline {
+	return p.Map("Abs", (*NDArray).Abs)
+}
+
+// Neg computes the negation of each element
+func (p *Pipeline) Neg() *Pipeline {
+	return p.Map("Neg", (*NDArray).Neg)
+}
+
+// Clip limits the values between min and max
+func (p *Pipeline) Clip(min, max float64) *Pipeline {
+	return p.Map("Clip", func(a *NDArray) *NDArray { return a.Clip(min, max) })
+}
+
+// Reshape changes the shape of the array
+func (p *Pipeline) Reshape(newShape ...int) *Pipeline {
+	return p.Map("Reshape", func(a *NDArray) *NDArray {
+		return a.Reshape(append([]int{}, newShape...)...)
+	})
+}
+
+// Transpose permutes the axes of the array
+func (p *Pipeline) Transpose(axes ...int) *Pipeline {
+	return p.Map("Transpose", func(a *NDArray) *NDArray { return a.Transpose(axes...) })
+}
+
+// Flatten flattens the array to 1D
+func (p *Pipeline) Flatten() *Pipeline {
+	return p.Map("Flatten", (*NDArray).Flatten)
+}
+
+// SumAxis sums along a specific axis
+func (p *Pipeline) SumAxis(axis int) *Pipeline {
+	return p.Map("SumAxis", func(a *NDArray) *NDArray { return a.SumAxis(axis) })
+}
+
+// MeanAxis computes the mean along a specific axis
+func (p *Pipeline) MeanAxis(axis int) *Pipeline {
+	return p.Map("MeanAxis", func(a *NDArray) *NDArray { return a.MeanAxis(axis) })
+}
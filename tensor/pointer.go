@@ -0,0 +1,95 @@
+package tensor
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// UnsafePointer returns the address of the first element, for handing the
+// array to C code (BLAS, codecs, CUDA) without a copy, in the spirit of
+// DLPack. The elements are little-endian and C-ordered with the byte strides
+// reported by Strides. The pointer is nil for an empty array.
+//
+// The memory belongs to the array: it stays valid only while the array is
+// reachable, so call runtime.KeepAlive(a) after the foreign call returns,
+// and C code must not keep the pointer past that call. Writing through the
+// pointer to a frozen array is undefined.
+func (a *NDArray) UnsafePointer() unsafe.Pointer {
+	if len(a.data) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(unsafe.SliceData(a.data))
+}
+
+// FromPointer wraps memory owned elsewhere, such as a buffer allocated by a C
+// library, as an array. The memory must hold little-endian elements of dtype;
+// strides are in bytes, and nil means C order.
+//
+// C-ordered memory is used in place. The deleter, if not nil, is called once
+// the array has been garbage collected, so it must not free anything the
+// caller still needs; arrays sharing the buffer, such as those from WithDims
+// or AsGonum, do not keep it alive. Use Copy for an independent array. Any
+// other layout is copied and the deleter is called before FromPointer
+// returns.
+func FromPointer(ptr unsafe.Pointer, shape, strides []int, dtype DType, deleter func()) *NDArray {
+	itemsize := dtype.ItemSize()
+	if itemsize == 0 {
+		panic(fmt.Sprintf("unsupported dtype %d", int(dtype)))
+	}
+	size := computeSize(shape)
+	if ptr == nil && size > 0 {
+		panic("nil pointer for a non-empty array")
+	}
+	contiguous := computeStrides(shape, itemsize)
+	if strides == nil {
+		strides = contiguous
+	}
+	if len(strides) != len(shape) {
+		panic(fmt.Sprintf("%d strides for %d dimensions", len(strides), len(shape)))
+	}
+	
+	if size == 0 || equalStrides(shape, strides, contiguous) {
+		var data []byte
+		if size > 0 {
+			data = unsafe.Slice((*byte)(ptr), size*itemsize)
+		}
+		a := WrapBytes(data, dtype, shape...)
+		if deleter != nil {
+			runtime.AddCleanup(a, func(f func()) { f() }, deleter)
+		}
+		return a
+	}
+	
+	// Gather a strided layout into a fresh C-ordered buffer
+	data := make([]byte, size*itemsize)
+	index := make([]int, len(shape))
+	for i := 0; i < size; i++ {
+		offset := 0
+		for d, idx := range index {
+			offset += idx * strides[d]
+		}
+		copy(data[i*itemsize:], unsafe.Slice((*byte)(unsafe.Add(ptr, offset)), itemsize))
+		for d := len(index) - 1; d >= 0; d-- {
+			if index[d]++; index[d] < shape[d] {
+				break
+			}
+			index[d] = 0
+		}
+	}
+	if deleter != nil {
+		deleter()
+	}
+	return WrapBytes(data, dtype, shape...)
+}
+
+// equalStrides reports whether two sets of strides address the same
+// elements, ignoring axes of length one where the stride is irrelevant
+func equalStrides(shape, a, b []int) bool {
+	for i, n := range shape {
+		if n > 1 && a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -218,3 +218,217 @@ func (a *NDArray) MeanAxis(axis int) *NDArray {
 	
 	return sumResult
 }
+
+// ReduceOptions configures the *With reduction variants, mirroring the
+// where= and dtype= arguments of NumPy reductions
+type ReduceOptions struct {
+	// Where is an optional boolean mask broadcastable to the array's shape.
+	// Only elements where the mask is true take part in the reduction.
+	Where *NDArray
+	
+	// DType is the accumulator dtype. Each partial result is rounded to this
+	// dtype, so e.g. Int8 wraps on overflow. The zero value (Bool) means
+	// accumulate in float64.
+	DType DType
+}
+
+// accumulate rounds an intermediate value to the accumulator dtype
+func (opts ReduceOptions) accumulate(v float64) float64 {
+	if opts.DType == Bool {
+		return v
+	}
+	return castFloat64(v, opts.DType)
+}
+
+// mask returns the where mask broadcast to shape, or nil if none was given
+func (opts ReduceOptions) mask(shape []int) *NDArray {
+	if opts.Where == nil {
+		return nil
+	}
+	if opts.Where.dtype != Bool {
+		panic("where mask must be boolean")
+	}
+	m, err := opts.Where.broadcastTo(shape)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// castFloat64 converts v to the nearest value representable in dtype,
+// wrapping integers the same way SetFloat64 does
+func castFloat64(v float64, dtype DType) float64 {
+	switch dtype {
+	case Float32:
+		return float64(float32(v))
+	case Int64:
+		return float64(int64(v))
+	case Int32:
+		return float64(int32(v))
+	case Int16:
+		return float64(int16(v))
+	case Int8:
+		return float64(int8(v))
+	case Uint64:
+		return float64(uint64(v))
+	case Uint32:
+		return float64(uint32(v))
+	case Uint16:
+		return float64(uint16(v))
+	case Uint8:
+		return float64(uint8(v))
+	case Bool:
+		if v != 0 {
+			return 1
+		}
+		return 0
+	default:
+		return v
+	}
+}
+
+// SumWith computes the sum of the elements selected by opts.Where using
+// the accumulator dtype opts.DType
+func (a *NDArray) SumWith(opts ReduceOptions) float64 {
+	mask := opts.mask(a.shape)
+	sum := 0.0
+	for i := 0; i < a.size; i++ {
+		indices := a.unravelIndex(i)
+		if mask != nil && mask.GetFloat64(indices...) == 0 {
+			continue
+		}
+		sum = opts.accumulate(sum + a.GetFloat64(indices...))
+	}
+	return sum
+}
+
+// ProdWith computes the product of the elements selected by opts.Where
+// using the accumulator dtype opts.DType
+func (a *NDArray) ProdWith(opts ReduceOptions) float64 {
+	mask := opts.mask(a.shape)
+	prod := 1.0
+	for i := 0; i < a.size; i++ {
+		indices := a.unravelIndex(i)
+		if mask != nil && mask.GetFloat64(indices...) == 0 {
+			continue
+		}
+		prod = opts.accumulate(prod * a.GetFloat64(indices...))
+	}
+	return prod
+}
+
+// MeanWith computes the mean of the elements selected by opts.Where.
+// Returns NaN if no elements are selected.
+func (a *NDArray) MeanWith(opts ReduceOptions) float64 {
+	mask := opts.mask(a.shape)
+	count := a.size
+	if mask != nil {
+		count = 0
+		for i := 0; i < mask.size; i++ {
+			if mask.GetFloat64(mask.unravelIndex(i)...) != 0 {
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return math.NaN()
+	}
+	return a.SumWith(opts) / float64(count)
+}
+
+// MinWith returns the minimum of the elements selected by opts.Where.
+// Returns NaN if no elements are selected or, as np.min does, if any
+// selected element is NaN.
+func (a *NDArray) MinWith(opts ReduceOptions) float64 {
+	mask := opts.mask(a.shape)
+	min, found := math.NaN(), false
+	for i := 0; i < a.size; i++ {
+		indices := a.unravelIndex(i)
+		if mask != nil && mask.GetFloat64(indices...) == 0 {
+			continue
+		}
+		val := a.GetFloat64(indices...)
+		if math.IsNaN(val) {
+			return val
+		}
+		if !found || val < min {
+			min, found = val, true
+		}
+	}
+	return min
+}
+
+// MaxWith returns the maximum of the elements selected by opts.Where.
+// Returns NaN if no elements are selected or, as np.max does, if any
+// selected element is NaN.
+func (a *NDArray) MaxWith(opts ReduceOptions) float64 {
+	mask := opts.mask(a.shape)
+	max, found := math.NaN(), false
+	for i := 0; i < a.size; i++ {
+		indices := a.unravelIndex(i)
+		if mask != nil && mask.GetFloat64(indices...) == 0 {
+			continue
+		}
+		val := a.GetFloat64(indices...)
+		if math.IsNaN(val) {
+			return val
+		}
+		if !found || val > max {
+			max, found = val, true
+		}
+	}
+	return max
+}
+
+// SumAxisWith computes the sum along a specific axis, honouring opts.Where
+// and opts.DType. The result has dtype opts.DType if set, otherwise the
+// array's dtype.
+func (a *NDArray) SumAxisWith(axis int, opts ReduceOptions) *NDArray {
+	if axis < 0 {
+		axis = a.ndim + axis
+	}
+	if axis < 0 || axis >= a.ndim {
+		panic("axis out of bounds")
+	}
+	
+	resultShape := make([]int, 0, a.ndim-1)
+	for i := 0; i < a.ndim; i++ {
+		if i != axis {
+			resultShape = append(resultShape, a.shape[i])
+		}
+	}
+	
+	dtype := a.dtype
+	if opts.DType != Bool {
+		dtype = opts.DType
+	}
+	
+	if len(resultShape) == 0 {
+		result := Zeros([]int{1}, dtype)
+		result.SetFloat64(a.SumWith(opts), 0)
+		return result
+	}
+	
+	mask := opts.mask(a.shape)
+	result := Zeros(resultShape, dtype)
+	
+	for i := 0; i < a.size; i++ {
+		srcIndices := a.unravelIndex(i)
+		if mask != nil && mask.GetFloat64(srcIndices...) == 0 {
+			continue
+		}
+		
+		dstIndices := make([]int, 0, len(resultShape))
+		for j := 0; j < a.ndim; j++ {
+			if j != axis {
+				dstIndices = append(dstIndices, srcIndices[j])
+			}
+		}
+		
+		val := a.GetFloat64(srcIndices...)
+		currentSum := result.GetFloat64(dstIndices...)
+		result.SetFloat64(opts.accumulate(currentSum+val), dstIndices...)
+	}
+	
+	return result
+}
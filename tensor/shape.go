@@ -101,6 +101,14 @@ func (a *NDArray) Transpose(axes ...int) *NDArray {
 		ndim:    a.ndim,
 	}
 	
+	// Axis labels follow their axes
+	if a.dims != nil {
+		newArr.dims = make([]string, a.ndim)
+		for i, axis := range axes {
+			newArr.dims[i] = a.dims[axis]
+		}
+	}
+	
 	// Copy data in transposed order
 	for i := 0; i < a.size; i++ {
 		srcIndices := a.unravelIndex(i)
@@ -1,7 +1,14 @@
 package tensor
 
 import (
+	"encoding/binary"
+	"math"
+	"runtime"
 	"testing"
+	"time"
+	"unsafe"
+	
+	"gonum.org/v1/gonum/mat"
 )
 
 func TestZeros(t *testing.T) {
@@ -71,6 +78,38 @@ func TestFromSliceFloat64(t *testing.T) {
 	}
 }
 
+func TestFromBytes(t *testing.T) {
+	src := FromSliceInt64([]int64{1, -2, 3, 4, 5, -6}, 3, 2)
+	arr := FromBytes(src.Data(), Int64, 2, 3)
+	
+	if arr.DType() != Int64 || arr.Size() != 6 {
+		t.Fatalf("expected 6 int64 elements, got %d %s", arr.Size(), arr.DType())
+	}
+	if got := arr.GetInt64(1, 2); got != -6 {
+		t.Errorf("expected -6 at [1,2], got %d", got)
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a length mismatch")
+		}
+	}()
+	FromBytes(make([]byte, 7), Float64, 1)
+}
+
+func TestWrapBytes(t *testing.T) {
+	buf := FromSliceFloat64([]float64{1, 2, 3, 4}, 4).Data()
+	arr := WrapBytes(buf, Float64, 2, 2)
+	
+	arr.SetFloat64(9, 1, 0)
+	if got := FromBytes(buf, Float64, 4).GetFloat64(2); got != 9 {
+		t.Errorf("expected the write to reach the buffer, got %f", got)
+	}
+	if got := arr.GetFloat64(0, 1); got != 2 {
+		t.Errorf("expected 2 at [0,1], got %f", got)
+	}
+}
+
 func TestArange(t *testing.T) {
 	arr := Arange(0, 10, 2)
 	
@@ -251,3 +290,764 @@ func TestToSlice(t *testing.T) {
 		}
 	}
 }
+
+func TestFreeze(t *testing.T) {
+	a := FromSliceFloat64([]float64{1, 2, 3}, 3).Freeze()
+	
+	if !a.IsFrozen() {
+		t.Error("expected array to be frozen")
+	}
+	
+	// Copies are writable
+	b := a.Copy()
+	if b.IsFrozen() {
+		t.Error("expected copy of frozen array to be writable")
+	}
+	b.SetFloat64(10, 0)
+	
+	// Derived arrays are writable and reads are unaffected
+	if c := a.MulScalar(2); c.IsFrozen() || c.GetFloat64(2) != 6 {
+		t.Error("expected MulScalar result to be writable with value 6")
+	}
+	
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected SetFloat64 on frozen array to panic")
+			}
+		}()
+		a.SetFloat64(10, 0)
+	}()
+}
+
+func TestCheckMode(t *testing.T) {
+	if GetCheckMode() != defaultCheckMode {
+		t.Fatalf("expected default check mode %s, got %s", defaultCheckMode, GetCheckMode())
+	}
+	
+	arr := FromSliceFloat64([]float64{1, 2, 3, 4}, 2, 2)
+	
+	prev := SetCheckMode(CheckNone)
+	defer SetCheckMode(prev)
+	
+	// Negative indices still work without checks
+	if arr.GetFloat64(-1, -1) != 4 {
+		t.Errorf("expected 4 at [-1,-1], got %f", arr.GetFloat64(-1, -1))
+	}
+	
+	SetCheckMode(CheckDebug)
+	panicked := func(f func()) (p bool) {
+		defer func() { p = recover() != nil }()
+		f()
+		return false
+	}
+	if !panicked(func() { arr.SetFloat64(math.NaN(), 0, 0) }) {
+		t.Error("expected NaN tripwire to panic in debug mode")
+	}
+	
+	// Corrupt strides point past the end of the buffer
+	bad := arr.Copy()
+	bad.strides[0] = 64
+	if !panicked(func() { bad.GetFloat64(1, 0) }) {
+		t.Error("expected stride overflow to panic in debug mode")
+	}
+}
+
+func TestHash(t *testing.T) {
+	a := FromSliceFloat64([]float64{1, 2, 3, 4}, 2, 2)
+	b := FromSliceFloat64([]float64{1, 2, 3, 4}, 2, 2)
+	
+	if a.Hash() != b.Hash() {
+		t.Error("expected equal arrays to have equal hashes")
+	}
+	if a.Hash128() != b.Hash128() {
+		t.Error("expected equal arrays to have equal 128-bit hashes")
+	}
+	
+	// Shape and dtype are part of the digest
+	if a.Hash() == a.Reshape(4).Hash() {
+		t.Error("expected different shapes to hash differently")
+	}
+	if a.Hash() == FromSliceInt64([]int64{1, 2, 3, 4}, 2, 2).Hash() {
+		t.Error("expected different dtypes to hash differently")
+	}
+	
+	b.SetFloat64(5, 1, 1)
+	if a.Hash() == b.Hash() {
+		t.Error("expected modified array to hash differently")
+	}
+}
+
+func TestNamedDims(t *testing.T) {
+	// 2 (batch) x 3 (feature)
+	a := FromSliceFloat64([]float64{1, 2, 3, 4, 5, 6}, 2, 3).WithDims("batch", "feature")
+	
+	sum := a.SumDim("feature")
+	if sum.GetFloat64(0) != 6 || sum.GetFloat64(1) != 15 {
+		t.Errorf("expected [6 15], got %v", sum.ToSliceFloat64())
+	}
+	if dims := sum.Dims(); len(dims) != 1 || dims[0] != "batch" {
+		t.Errorf("expected dims [batch], got %v", dims)
+	}
+	
+	row := a.SelectDim("batch", 1)
+	expected := []float64{4, 5, 6}
+	for i := 0; i < 3; i++ {
+		if row.GetFloat64(i) != expected[i] {
+			t.Errorf("expected %f at index %d, got %f", expected[i], i, row.GetFloat64(i))
+		}
+	}
+	if dims := row.Dims(); len(dims) != 1 || dims[0] != "feature" {
+		t.Errorf("expected dims [feature], got %v", dims)
+	}
+	
+	// Selecting keeps the dtype, also down to a single element
+	big := FromSliceInt64([]int64{1<<60 + 1, 2}, 2).WithDims("x")
+	if got := big.SelectDim("x", 0); got.DType() != Int64 || got.GetInt64(0) != 1<<60+1 {
+		t.Errorf("expected int64 %d, got %s %d", int64(1<<60+1), got.DType(), got.GetInt64(0))
+	}
+	c := FromSliceComplex128([]complex128{1 + 2i, 3 - 4i}, 1, 2).WithDims("row", "col")
+	if got := c.SelectDim("col", 1); got.DType() != Complex128 || got.GetComplex128(0) != 3-4i {
+		t.Errorf("expected complex128 3-4i, got %s %v", got.DType(), got.GetComplex128(0))
+	}
+	if got := c.SelectDim("row", 0).SelectDim("col", 0); got.GetComplex128(0) != 1+2i {
+		t.Errorf("expected 1+2i, got %v", got.GetComplex128(0))
+	}
+	
+	tr := a.TransposeDims("feature", "batch")
+	if tr.Shape()[0] != 3 || tr.GetFloat64(2, 1) != 6 {
+		t.Errorf("expected transposed shape [3 2] with 6 at [2,1], got %v", tr.Shape())
+	}
+	if tr.AxisOf("batch") != 1 {
+		t.Errorf("expected batch at axis 1, got %d", tr.AxisOf("batch"))
+	}
+	
+	mean := a.MeanDim("batch")
+	if mean.GetFloat64(0) != 2.5 {
+		t.Errorf("expected mean 2.5, got %f", mean.GetFloat64(0))
+	}
+}
+
+func TestComplex(t *testing.T) {
+	arr := FromSliceComplex128([]complex128{1 + 2i, 3 - 1i, -2i, 4}, 2, 2)
+	
+	if arr.DType() != Complex128 {
+		t.Errorf("expected complex128, got %s", arr.DType())
+	}
+	if arr.GetComplex128(0, 1) != 3-1i {
+		t.Errorf("expected 3-1i, got %v", arr.GetComplex128(0, 1))
+	}
+	
+	arr.SetComplex128(5+5i, 1, 1)
+	if arr.GetComplex128(1, 1) != 5+5i {
+		t.Errorf("expected 5+5i, got %v", arr.GetComplex128(1, 1))
+	}
+	
+	re, im := arr.Real(), arr.Imag()
+	if re.GetFloat64(0, 0) != 1 || im.GetFloat64(0, 0) != 2 || im.GetFloat64(1, 0) != -2 {
+		t.Errorf("unexpected real/imag parts %v %v", re.ToSliceFloat64(), im.ToSliceFloat64())
+	}
+	if arr.Conj().GetComplex128(0, 0) != 1-2i {
+		t.Errorf("expected conjugate 1-2i, got %v", arr.Conj().GetComplex128(0, 0))
+	}
+	
+	// Transposes move the 16-byte elements intact
+	if arr.Transpose().GetComplex128(1, 0) != 3-1i {
+		t.Errorf("expected 3-1i after transpose, got %v", arr.Transpose().GetComplex128(1, 0))
+	}
+	
+	// Real arrays read as complex with zero imaginary part
+	f := FromSliceFloat64([]float64{1.5}, 1)
+	if f.GetComplex128(0) != 1.5 {
+		t.Errorf("expected 1.5+0i, got %v", f.GetComplex128(0))
+	}
+	
+	c64 := Zeros([]int{1}, Complex64)
+	c64.SetComplex128(0.5-0.25i, 0)
+	if c64.GetComplex128(0) != 0.5-0.25i {
+		t.Errorf("expected 0.5-0.25i, got %v", c64.GetComplex128(0))
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected storing a complex value in a float array to panic")
+		}
+	}()
+	f.SetComplex128(1+1i, 0)
+}
+
+func TestConstants(t *testing.T) {
+	if !math.IsInf(Inf(), 1) || !math.IsInf(NegInf(), -1) || !math.IsNaN(NaN()) {
+		t.Error("expected +Inf, -Inf and NaN")
+	}
+	if Pi != math.Pi || E != math.E || math.Abs(EulerGamma-0.5772156649015329) > 1e-16 {
+		t.Error("unexpected mathematical constants")
+	}
+	
+	f32 := Finfo(Complex64)
+	if f32.DType != Float32 || float32(1)+float32(f32.Eps) == 1 || float32(1)+float32(f32.Eps/2) != 1 {
+		t.Errorf("expected the float32 machine epsilon, got %g", f32.Eps)
+	}
+	if float32(f32.Max) != math.MaxFloat32 || f32.SmallestNormal != 1.1754943508222875e-38 {
+		t.Errorf("unexpected float32 limits %+v", f32)
+	}
+	f64 := Finfo(Float64)
+	if 1+f64.Eps == 1 || 1+f64.Eps/2 != 1 || f64.Min != -math.MaxFloat64 || f64.SmallestSubnormal != 5e-324 {
+		t.Errorf("unexpected float64 limits %+v", f64)
+	}
+	
+	cases := []struct {
+		dtype DType
+		min   int64
+		max   uint64
+	}{
+		{Int8, math.MinInt8, math.MaxInt8},
+		{Int32, math.MinInt32, math.MaxInt32},
+		{Int64, math.MinInt64, math.MaxInt64},
+		{Uint16, 0, math.MaxUint16},
+		{Uint64, 0, math.MaxUint64},
+	}
+	for _, c := range cases {
+		if info := Iinfo(c.dtype); info.Min != c.min || info.Max != c.max || info.Bits != 8*c.dtype.ItemSize() {
+			t.Errorf("%s: expected [%d, %d], got %+v", c.dtype, c.min, c.max, info)
+		}
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for Iinfo of a float dtype")
+		}
+	}()
+	Iinfo(Float64)
+}
+
+func TestGonum(t *testing.T) {
+	a := FromSliceFloat64([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	m := AsGonum(a)
+	if _, ok := m.(*mat.Dense); !ok {
+		t.Fatalf("expected a *mat.Dense for a Float64 array, got %T", m)
+	}
+	if r, c := m.Dims(); r != 2 || c != 3 || m.At(1, 2) != 6 {
+		t.Errorf("unexpected matrix %v", mat.Formatted(m))
+	}
+	
+	// The matrix shares memory with the array in both directions
+	m.Set(0, 1, 20)
+	a.SetFloat64(40, 1, 0)
+	if a.GetFloat64(0, 1) != 20 || m.At(1, 0) != 40 {
+		t.Error("expected AsGonum to share memory")
+	}
+	
+	var p mat.Dense
+	p.Mul(m, m.T())
+	got := FromGonum(&p)
+	if got.DType() != Float64 || got.Shape()[0] != 2 || got.Shape()[1] != 2 || got.GetFloat64(0, 1) != 1*40+20*5+3*6 {
+		t.Errorf("unexpected product %v", got.ToSliceFloat64())
+	}
+	got.SetFloat64(0, 0, 0)
+	if p.At(0, 0) != 0 {
+		t.Error("expected FromGonum to share memory with a dense matrix")
+	}
+	
+	// A transpose is not laid out row by row, so it is copied
+	tr := FromGonum(m.T())
+	if tr.Shape()[0] != 3 || tr.GetFloat64(1, 0) != 20 {
+		t.Errorf("unexpected transpose %v", tr.ToSliceFloat64())
+	}
+	
+	// Other dtypes and frozen arrays go through the converting adapter
+	i := FromSliceInt64([]int64{1, 2, 3, 4}, 2, 2)
+	g := AsGonum(i)
+	g.Set(1, 1, 7)
+	if _, ok := g.(*GonumMatrix); !ok || i.GetInt64(1, 1) != 7 || mat.Trace(g) != 8 {
+		t.Errorf("unexpected adapter %T", g)
+	}
+	if FromGonum(g).DType() != Float64 {
+		t.Error("expected FromGonum to give a Float64 array")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic writing to a frozen array")
+		}
+	}()
+	AsGonum(a.Copy().Freeze()).Set(0, 0, 1)
+}
+
+func TestPointer(t *testing.T) {
+	buf := []float64{1, 2, 3, 4, 5, 6}
+	a := FromPointer(unsafe.Pointer(&buf[0]), []int{2, 3}, nil, Float64, nil)
+	buf[4] = 50
+	if a.GetFloat64(1, 1) != 50 || a.UnsafePointer() != unsafe.Pointer(&buf[0]) {
+		t.Error("expected FromPointer to share C-ordered memory")
+	}
+	
+	// A transposed layout is gathered into a copy and released at once
+	released := false
+	tr := FromPointer(unsafe.Pointer(&buf[0]), []int{3, 2}, []int{8, 24}, Float64, func() { released = true })
+	if !released {
+		t.Error("expected the deleter to run after copying")
+	}
+	for i, want := range []float64{1, 4, 2, 50, 3, 6} {
+		if got := tr.ToSliceFloat64()[i]; got != want {
+			t.Errorf("element %d: expected %v, got %v", i, want, got)
+		}
+	}
+	
+	// The deleter of shared memory runs once the array is collected
+	done := make(chan struct{})
+	func() {
+		FromPointer(unsafe.Pointer(&buf[0]), []int{6}, nil, Float64, func() { close(done) })
+	}()
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case <-done:
+			return
+		case <-deadline:
+			t.Fatal("deleter was not called")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestRearrange(t *testing.T) {
+	values := make([]float64, 24)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	a := FromSliceFloat64(values, 2, 3, 4)
+	
+	same := func(x, y *NDArray) bool {
+		xs, ys := x.Shape(), y.Shape()
+		if len(xs) != len(ys) {
+			return false
+		}
+		for i := range xs {
+			if xs[i] != ys[i] {
+				return false
+			}
+		}
+		xv, yv := x.ToSliceFloat64(), y.ToSliceFloat64()
+		for i := range xv {
+			if xv[i] != yv[i] {
+				return false
+			}
+		}
+		return true
+	}
+	
+	cases := []struct {
+		pattern string
+		want    *NDArray
+	}{
+		{"b h w -> b w h", a.Transpose(0, 2, 1)},
+		{"b h w -> b (h w)", a.Reshape(2, 12)},
+		{"b h w -> (b h w)", a.Flatten()},
+		{"b h w -> h b w", a.Transpose(1, 0, 2)},
+		{"b h w -> b 1 h w", a.Reshape(2, 1, 3, 4)},
+		{"... w -> w ...", a.Transpose(2, 0, 1)},
+		{"b ... -> b (...)", a.Reshape(2, 12)},
+		{"b h w -> (w b) h", a.Transpose(2, 0, 1).Reshape(8, 3)},
+	}
+	for _, c := range cases {
+		if got := Rearrange(a, c.pattern); !same(got, c.want) {
+			t.Errorf("%s: expected %v %v, got %v %v", c.pattern, c.want.Shape(), c.want.ToSliceFloat64(), got.Shape(), got.ToSliceFloat64())
+		}
+	}
+	
+	// Splitting an axis needs the length of all but one part
+	got := RearrangeWith(a, "b h (w1 w2) -> b (h w1) w2", map[string]int{"w2": 2})
+	if !same(got, a.Reshape(2, 6, 2)) {
+		t.Errorf("split gave %v", got.Shape())
+	}
+	got = RearrangeWith(a, "b h (w1 w2) -> w2 b h w1", map[string]int{"w1": 2})
+	if !same(got, a.Reshape(2, 3, 2, 2).Transpose(3, 0, 1, 2)) {
+		t.Errorf("split and move gave %v", got.Shape())
+	}
+	
+	for _, bad := range []string{
+		"b h w -> b h",      // w dropped
+		"b h -> b h",        // too few axes
+		"b h w -> b h w c",  // unknown axis
+		"b b w -> b w",      // repeated axis
+		"b (h w) -> b h w",  // too many axes
+		"b h w",             // no arrow
+		"b (h w c -> b h w", // unbalanced
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic for %q", bad)
+				}
+			}()
+			Rearrange(a, bad)
+		}()
+	}
+}
+
+func TestReduceExpr(t *testing.T) {
+	a := FromSliceFloat64([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, 2, 3, 2)
+	cases := []struct {
+		pattern, reduction string
+		shape              []int
+		want               []float64
+	}{
+		{"b h c -> b c", "mean", []int{2, 2}, []float64{3, 4, 9, 10}},
+		{"b h c -> c b", "sum", []int{2, 2}, []float64{9, 27, 12, 30}},
+		{"b h c -> h", "max", []int{3}, []float64{8, 10, 12}},
+		{"b h c -> b h", "min", []int{2, 3}, []float64{1, 3, 5, 7, 9, 11}},
+		{"b h c -> b () h", "prod", []int{2, 1, 3}, []float64{2, 12, 30, 56, 90, 132}},
+		{"b h c -> ", "sum", []int{1}, []float64{78}},
+		{"b ... -> b", "sum", []int{2}, []float64{21, 57}},
+	}
+	for _, c := range cases {
+		got := ReduceExpr(a, c.pattern, c.reduction)
+		if len(got.Shape()) != len(c.shape) || got.Size() != len(c.want) {
+			t.Errorf("%s %s: expected shape %v, got %v", c.pattern, c.reduction, c.shape, got.Shape())
+			continue
+		}
+		for i, v := range got.ToSliceFloat64() {
+			if v != c.want[i] {
+				t.Errorf("%s %s: expected %v, got %v", c.pattern, c.reduction, c.want, got.ToSliceFloat64())
+				break
+			}
+		}
+	}
+	
+	// Pooling over pairs of rows
+	got := ReduceExprWith(a, "(b h2) h c -> b h c", "max", map[string]int{"h2": 2})
+	if got.Shape()[0] != 1 || got.GetFloat64(0, 2, 1) != 12 {
+		t.Errorf("pooling gave %v %v", got.Shape(), got.ToSliceFloat64())
+	}
+	
+	// The mean of integers is a float
+	ints := FromSliceInt64([]int64{1, 2, 3, 4}, 2, 2)
+	if m := ReduceExpr(ints, "r c -> r", "mean"); m.DType() != Float64 || m.GetFloat64(0) != 1.5 {
+		t.Errorf("expected float64 mean 1.5, got %s %v", m.DType(), m.ToSliceFloat64())
+	}
+	if s := ReduceExpr(ints, "r c -> c", "sum"); s.DType() != Int64 || s.GetInt64(1) != 6 {
+		t.Errorf("expected int64 sum 6, got %s %v", s.DType(), s.ToSliceFloat64())
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an unknown reduction")
+		}
+	}()
+	ReduceExpr(a, "b h c -> b", "median")
+}
+
+func TestSelect(t *testing.T) {
+	x := FromSliceFloat64([]float64{-2, -1, 0, 1, 2, 3}, 2, 3)
+	choices := []*NDArray{x.Neg(), FromSliceFloat64([]float64{10, 20, 30}, 3)}
+	got := Select([]*NDArray{x.LtScalar(0), x.GtScalar(1)}, choices, -7)
+	want := []float64{2, 1, -7, -7, 20, 30}
+	for i, v := range got.ToSliceFloat64() {
+		if v != want[i] {
+			t.Fatalf("expected %v, got %v", want, got.ToSliceFloat64())
+		}
+	}
+	
+	// The first true condition wins
+	got = Select([]*NDArray{x.GtScalar(0), x.GtScalar(1)}, []*NDArray{Ones([]int{1}, Float64), Full([]int{1}, 2.0, Float64)}, 0)
+	if got.GetFloat64(1, 2) != 1 || got.GetFloat64(0, 0) != 0 {
+		t.Errorf("unexpected selection %v", got.ToSliceFloat64())
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a non-boolean condition")
+		}
+	}()
+	Select([]*NDArray{x}, []*NDArray{x}, 0)
+}
+
+func TestPiecewise(t *testing.T) {
+	x := FromSliceFloat64([]float64{-2, -0.5, 0, 0.5, 2}, 5)
+	calls := 0
+	square := func(v float64) float64 { calls++; return v * v }
+	got := Piecewise(x, []*NDArray{x.LtScalar(0), x.GtScalar(1)}, []func(float64) float64{
+		math.Abs, square, func(v float64) float64 { return 100 + v },
+	})
+	want := []float64{2, 0.5, 100, 100.5, 4}
+	for i, v := range got.ToSliceFloat64() {
+		if v != want[i] {
+			t.Fatalf("expected %v, got %v", want, got.ToSliceFloat64())
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected square to be called once, got %d", calls)
+	}
+	
+	// Later conditions override earlier ones; uncovered elements are zero
+	got = Piecewise(x, []*NDArray{x.GtScalar(-1), x.GtScalar(1)}, []func(float64) float64{
+		func(float64) float64 { return 1 }, func(float64) float64 { return 2 },
+	})
+	want = []float64{0, 1, 1, 1, 2}
+	for i, v := range got.ToSliceFloat64() {
+		if v != want[i] {
+			t.Fatalf("expected %v, got %v", want, got.ToSliceFloat64())
+		}
+	}
+}
+
+func TestVectorize(t *testing.T) {
+	hypot := Vectorize(math.Hypot)
+	a := FromSliceFloat64([]float64{3, 5, 8}, 3, 1)
+	b := FromSliceFloat64([]float64{4, 12}, 2)
+	got := hypot(a, b)
+	want := []float64{5, math.Hypot(3, 12), math.Hypot(5, 4), 13, math.Hypot(8, 4), math.Hypot(8, 12)}
+	if !sameShape(got.Shape(), []int{3, 2}) || got.DType() != Float64 {
+		t.Fatalf("expected float64 of shape (3, 2), got %s %v", got.DType(), got.Shape())
+	}
+	for i, v := range got.ToSliceFloat64() {
+		if v != want[i] {
+			t.Fatalf("expected %v, got %v", want, got.ToSliceFloat64())
+		}
+	}
+	
+	// Float32 survives small integers but not wide ones
+	f32 := Full([]int{2}, 1.5, Float32)
+	cases := []struct {
+		other DType
+		want  DType
+	}{
+		{Float32, Float32}, {Int16, Float32}, {Uint8, Float32}, {Int32, Float64}, {Float64, Float64},
+	}
+	for _, c := range cases {
+		if got := hypot(f32, Ones([]int{2}, c.other)).DType(); got != c.want {
+			t.Errorf("float32 with %s: expected %s, got %s", c.other, c.want, got)
+		}
+	}
+	if got := Vectorize(math.Max)(FromSliceInt64([]int64{1, 5}, 2), FromSliceInt64([]int64{3, 2}, 2)); got.DType() != Float64 || got.GetFloat64(0) != 3 {
+		t.Errorf("expected float64 max, got %s %v", got.DType(), got.ToSliceFloat64())
+	}
+	
+	// An explicit dtype and several workers
+	floor := VectorizeUnaryWith(math.Floor, VectorizeOptions{Workers: 4, DType: Int64})
+	values := make([]float64, 1000)
+	for i := range values {
+		values[i] = float64(i) / 3
+	}
+	got = floor(FromSliceFloat64(values, 10, 100))
+	if got.DType() != Int64 || got.GetInt64(9, 99) != 333 || got.GetInt64(0, 5) != 1 {
+		t.Errorf("unexpected parallel floor %s %v", got.DType(), got.GetInt64(9, 99))
+	}
+	if got := VectorizeUnary(math.Sqrt)(Full([]int{2}, 4.0, Float32)); got.DType() != Float32 || got.GetFloat64(1) != 2 {
+		t.Errorf("expected float32 sqrt, got %s %v", got.DType(), got.ToSliceFloat64())
+	}
+}
+
+func TestUniqueTol(t *testing.T) {
+	tenth := 0.1
+	a := FromSliceFloat64([]float64{tenth + 0.2, 1, 0.3, math.NaN(), 1 + 1e-12, 0.30000001, 2, math.NaN()}, 2, 4)
+	if got := a.Unique().Size(); got != 8 {
+		t.Errorf("expected exact Unique to keep 8 values, got %d", got)
+	}
+	
+	values, labels := a.GroupByValue(1e-6)
+	want := []float64{0.3, 1, 2}
+	got := values.ToSliceFloat64()
+	if len(got) != 4 || !math.IsNaN(got[3]) {
+		t.Fatalf("expected %v and NaN, got %v", want, got)
+	}
+	for i, v := range want {
+		if v != got[i] {
+			t.Errorf("group %d: expected %v, got %v", i, v, got[i])
+		}
+	}
+	wantLabels := []int64{0, 1, 0, 3, 1, 0, 2, 3}
+	if !sameShape(labels.Shape(), []int{2, 4}) || labels.DType() != Int64 {
+		t.Fatalf("expected int64 labels of shape (2, 4), got %s %v", labels.DType(), labels.Shape())
+	}
+	for i, l := range labels.ToSliceInt64() {
+		if l != wantLabels[i] {
+			t.Errorf("expected labels %v, got %v", wantLabels, labels.ToSliceInt64())
+			break
+		}
+	}
+	
+	// Groups never span more than tol, even along a chain of close values
+	chain := FromSliceFloat64([]float64{0, 0.4, 0.8, 1.2, 1.6}, 5)
+	if got := chain.UniqueTol(0.5).ToSliceFloat64(); len(got) != 3 || got[1] != 0.8 || got[2] != 1.6 {
+		t.Errorf("expected [0 0.8 1.6], got %v", got)
+	}
+}
+
+func TestByteOrder(t *testing.T) {
+	be := []byte{0x3f, 0xf8, 0, 0, 0, 0, 0, 0, 0x00, 0x02}
+	a := FromBytesOrder(be[:8], Float64, binary.BigEndian, 1)
+	if a.GetFloat64(0) != 1.5 {
+		t.Errorf("expected 1.5, got %v", a.GetFloat64(0))
+	}
+	if got := a.DataOrder(binary.BigEndian); string(got) != string(be[:8]) {
+		t.Errorf("expected big-endian bytes % x, got % x", be[:8], got)
+	}
+	if got := FromBytesOrder(be[8:], Int16, binary.BigEndian, 1).GetInt64(0); got != 2 {
+		t.Errorf("expected int16 2, got %d", got)
+	}
+	
+	// Big-endian complex64 input swaps the real and imaginary parts
+	// separately: 1.5 is 0x3fc00000 and -2 is 0xc0000000 as float32
+	cbe := []byte{0x3f, 0xc0, 0, 0, 0xc0, 0, 0, 0}
+	c64 := FromBytesOrder(cbe, Complex64, binary.BigEndian, 1)
+	if c64.GetComplex128(0) != complex(1.5, -2) {
+		t.Errorf("expected (1.5-2i), got %v", c64.GetComplex128(0))
+	}
+	if got := c64.DataOrder(binary.BigEndian); string(got) != string(cbe) {
+		t.Errorf("expected big-endian bytes % x, got % x", cbe, got)
+	}
+	
+	// Complex parts are swapped separately; little-endian input is unchanged
+	c := FromSliceComplex128([]complex128{complex(1, -2)}, 1)
+	back := FromBytesOrder(c.DataOrder(binary.BigEndian), Complex128, binary.BigEndian, 1)
+	if back.GetComplex128(0) != complex(1, -2) || string(c.DataOrder(binary.LittleEndian)) != string(c.Data()) {
+		t.Errorf("complex round trip gave %v", back.GetComplex128(0))
+	}
+}
+
+// intArray returns an n-element array of dtype filled with v
+func intArray(dtype DType, n int, v int64) *NDArray {
+	a := Zeros([]int{n}, dtype)
+	for i := 0; i < n; i++ {
+		a.SetInt64(v, i)
+	}
+	return a
+}
+
+func TestOverflow(t *testing.T) {
+	a := intArray(Int8, 2, 100)
+	b := intArray(Int8, 2, 50)
+	if got := a.Add(b).GetInt64(0); got != -106 {
+		t.Errorf("expected wrapped -106, got %d", got)
+	}
+	if _, err := a.AddChecked(b); err == nil {
+		t.Error("expected overflow error for int8 100+50")
+	}
+	if got, err := a.SubChecked(b); err != nil || got.GetInt64(1) != 50 {
+		t.Errorf("expected 50, got %v (%v)", got, err)
+	}
+	
+	// Products above MaxInt64 are exact for Uint64
+	u := intArray(Uint64, 1, 1<<32)
+	if _, err := u.MulChecked(u); err == nil {
+		t.Error("expected uint64 overflow for 2^32*2^32")
+	}
+	if got, err := u.MulChecked(intArray(Uint64, 1, 1<<31)); err != nil || uint64(got.GetInt64(0)) != 1<<63 {
+		t.Errorf("expected 2^63, got %v (%v)", got, err)
+	}
+	
+	prev := SetOverflowMode(OverflowSaturate)
+	defer SetOverflowMode(prev)
+	if got := a.Add(b).GetInt64(0); got != 127 {
+		t.Errorf("expected saturated 127, got %d", got)
+	}
+	if got := intArray(Uint8, 1, 3).Sub(intArray(Uint8, 1, 5)).GetInt64(0); got != 0 {
+		t.Errorf("expected saturated 0, got %d", got)
+	}
+	
+	SetOverflowMode(OverflowPanic)
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic in OverflowPanic mode")
+		}
+	}()
+	a.Mul(b)
+}
+
+func TestObjectArray(t *testing.T) {
+	type point struct{ x, y int }
+	o := FromSliceObject([]any{"a", 1, point{1, 2}, nil, 2.5, "f"}, 2, 3)
+	if o.Get(0, 2) != (point{1, 2}) || o.Get(1, 0) != nil {
+		t.Errorf("unexpected elements %v", o.ToSlice())
+	}
+	o.Set("d", 1, 0)
+	
+	r := o.Reshape(3, -1)
+	if r.Shape()[1] != 2 || r.Get(1, 1) != "d" {
+		t.Errorf("expected 3x2 with d at (1, 1), got %v %v", r.Shape(), r.ToSlice())
+	}
+	
+	cols := o.Take([]int{2, -3, 2}, 1)
+	if got := cols.ToSlice(); got[0] != (point{1, 2}) || got[1] != "a" || got[3] != "f" || got[4] != "d" {
+		t.Errorf("unexpected take result %v", got)
+	}
+	
+	c := ConcatenateObjects([]*ObjectArray{o, FromSliceObject([]any{"x", "y"}, 2, 1)}, 1)
+	if got := c.ToSlice(); c.Shape()[1] != 4 || got[3] != "x" || got[7] != "y" || got[4] != "d" {
+		t.Errorf("unexpected concatenation %v %v", c.Shape(), got)
+	}
+	rows := ConcatenateObjects([]*ObjectArray{o, o}, 0)
+	if rows.Shape()[0] != 4 || rows.Get(3, 2) != "f" {
+		t.Errorf("unexpected row concatenation %v", rows.ToSlice())
+	}
+}
+
+func TestObjectCompare(t *testing.T) {
+	names := FromSliceObject([]any{"carol", "alice", "bob", "alice"})
+	less := func(x, y any) bool { return x.(string) < y.(string) }
+	
+	if got := names.ArgSort(less); got[0] != 1 || got[1] != 3 || got[3] != 0 {
+		t.Errorf("expected stable order [1 3 2 0], got %v", got)
+	}
+	if got := names.Unique(less).ToSlice(); len(got) != 3 || got[0] != "alice" || got[2] != "carol" {
+		t.Errorf("expected [alice bob carol], got %v", got)
+	}
+	
+	// Metadata masks combine with numeric arrays
+	mask := names.Mask(func(x any) bool { return x == "alice" })
+	scores := FromSliceFloat64([]float64{1, 2, 3, 4}, 4)
+	if got := Where(mask, scores, Zeros([]int{4}, Float64)).Sum(); got != 6 {
+		t.Errorf("expected alice total 6, got %v", got)
+	}
+	eq := names.Equal(names.Sort(less), func(x, y any) bool { return x == y })
+	if eq.DType() != Bool || eq.Sum() != 2 {
+		t.Errorf("expected positions 1 and 2 to match, got %v", eq.ToSliceFloat64())
+	}
+	if got := names.Map(func(x any) float64 { return float64(len(x.(string))) }).Sum(); got != 18 {
+		t.Errorf("expected total length 18, got %v", got)
+	}
+}
+
+func TestOverflowMixed(t *testing.T) {
+	prev := SetOverflowMode(OverflowPanic)
+	defer SetOverflowMode(prev)
+	
+	// Float operands stay legal and are truncated as in wrap mode
+	i64 := FromSliceInt64([]int64{7, -7}, 2)
+	sum := i64.Add(FromSliceFloat64([]float64{0.5, 0.5}, 2))
+	if sum.DType() != Int64 || sum.GetInt64(0) != 7 || sum.GetInt64(1) != -6 {
+		t.Errorf("expected int64 [7 -6], got %v", sum.ToSliceFloat64())
+	}
+	if _, err := i64.AddChecked(FromSliceFloat64([]float64{1e30, 0}, 2)); err == nil {
+		t.Error("expected overflow error adding 1e30 to int64")
+	}
+	if _, err := i64.MulChecked(FromSliceFloat64([]float64{math.NaN(), 1}, 2)); err == nil {
+		t.Error("expected error multiplying by NaN")
+	}
+	
+	// Scalar operations follow the mode
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for int8 100 + 100")
+			}
+		}()
+		intArray(Int8, 1, 100).AddScalar(100)
+	}()
+	
+	SetOverflowMode(OverflowSaturate)
+	if got := intArray(Int8, 1, 100).AddScalar(100).GetInt64(0); got != 127 {
+		t.Errorf("expected saturated 127, got %d", got)
+	}
+	if got := FromSliceInt64([]int64{3}, 1).MulScalar(1e30).GetInt64(0); got != math.MaxInt64 {
+		t.Errorf("expected MaxInt64, got %d", got)
+	}
+	if got := FromSliceInt64([]int64{-3}, 1).MulScalar(math.Inf(1)).GetInt64(0); got != math.MinInt64 {
+		t.Errorf("expected MinInt64, got %d", got)
+	}
+	if got := FromSliceInt64([]int64{5}, 1).MulScalar(0.5).GetInt64(0); got != 2 {
+		t.Errorf("expected truncated 2, got %d", got)
+	}
+}
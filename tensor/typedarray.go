@@ -0,0 +1,84 @@
+//go:build js && wasm
+
+package tensor
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// typedArrays names the JS typed array constructor for each dtype. Booleans
+// travel as bytes and complex numbers as interleaved real and imaginary
+// parts.
+var typedArrays = map[DType]string{
+	Bool:       "Uint8Array",
+	Int8:       "Int8Array",
+	Int16:      "Int16Array",
+	Int32:      "Int32Array",
+	Int64:      "BigInt64Array",
+	Uint8:      "Uint8Array",
+	Uint16:     "Uint16Array",
+	Uint32:     "Uint32Array",
+	Uint64:     "BigUint64Array",
+	Float32:    "Float32Array",
+	Float64:    "Float64Array",
+	Complex64:  "Float32Array",
+	Complex128: "Float64Array",
+}
+
+// ToTypedArray copies the elements of a, in C order, into a new JS typed
+// array of the matching type, such as a Float64Array for Float64. The data
+// is copied in one block rather than element by element.
+func ToTypedArray(a *NDArray) js.Value {
+	name, ok := typedArrays[a.dtype]
+	if !ok {
+		panic(fmt.Sprintf("no typed array for dtype %s", a.dtype))
+	}
+	buf := js.Global().Get("ArrayBuffer").New(len(a.data))
+	js.CopyBytesToJS(js.Global().Get("Uint8Array").New(buf), a.data)
+	return js.Global().Get(name).New(buf)
+}
+
+// FromTypedArray copies a JS typed array into a new array with the given
+// shape, or a 1D array when no shape is given. The dtype follows the
+// typed array type; a Uint8ClampedArray gives Uint8.
+func FromTypedArray(v js.Value, shape ...int) *NDArray {
+	name := v.Get("constructor").Get("name").String()
+	var dtype DType
+	switch name {
+	case "Int8Array":
+		dtype = Int8
+	case "Int16Array":
+		dtype = Int16
+	case "Int32Array":
+		dtype = Int32
+	case "BigInt64Array":
+		dtype = Int64
+	case "Uint8Array", "Uint8ClampedArray":
+		dtype = Uint8
+	case "Uint16Array":
+		dtype = Uint16
+	case "Uint32Array":
+		dtype = Uint32
+	case "BigUint64Array":
+		dtype = Uint64
+	case "Float32Array":
+		dtype = Float32
+	case "Float64Array":
+		dtype = Float64
+	default:
+		panic(fmt.Sprintf("FromTypedArray requires a typed array, got %s", name))
+	}
+	
+	n := v.Get("length").Int()
+	if len(shape) == 0 {
+		shape = []int{n}
+	}
+	if computeSize(shape) != n {
+		panic(fmt.Sprintf("typed array of length %d does not match shape %v", n, shape))
+	}
+	data := make([]byte, v.Get("byteLength").Int())
+	view := js.Global().Get("Uint8Array").New(v.Get("buffer"), v.Get("byteOffset"), len(data))
+	js.CopyBytesToGo(data, view)
+	return WrapBytes(data, dtype, shape...)
+}
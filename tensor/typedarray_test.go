@@ -0,0 +1,36 @@
+//go:build js && wasm
+
+package tensor
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+func TestTypedArray(t *testing.T) {
+	a := FromSliceFloat64([]float64{1, 2.5, -3, 4, 5, 6}, 2, 3)
+	v := ToTypedArray(a)
+	if name := v.Get("constructor").Get("name").String(); name != "Float64Array" || v.Get("length").Int() != 6 || v.Index(1).Float() != 2.5 {
+		t.Errorf("unexpected typed array %s of length %d", name, v.Get("length").Int())
+	}
+	
+	got := FromTypedArray(v, 3, 2)
+	if got.DType() != Float64 || got.Shape()[0] != 3 || got.GetFloat64(1, 0) != -3 {
+		t.Errorf("unexpected round trip %v", got.ToSliceFloat64())
+	}
+	
+	// A view into part of a larger buffer
+	buf := js.Global().Get("Int16Array").New(js.ValueOf([]any{1, -2, 3, -4}))
+	view := js.Global().Get("Int16Array").New(buf.Get("buffer"), 2, 2)
+	got = FromTypedArray(view)
+	if got.DType() != Int16 || got.Size() != 2 || got.GetInt64(0) != -2 || got.GetInt64(1) != 3 {
+		t.Errorf("expected int16 [-2 3], got %v", got.ToSliceInt64())
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a plain JS array")
+		}
+	}()
+	FromTypedArray(js.ValueOf([]any{1, 2}))
+}
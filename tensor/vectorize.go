@@ -0,0 +1,110 @@
+package tensor
+
+import (
+	"fmt"
+	"sync"
+)
+
+// VectorizeOptions controls the array functions made by VectorizeWith and
+// VectorizeUnaryWith. The zero value runs on the calling goroutine and picks
+// the result dtype from the inputs.
+type VectorizeOptions struct {
+	// Workers is the number of goroutines that share the elements; values
+	// below 2 run serially. The scalar function must then be safe for
+	// concurrent use.
+	Workers int
+	
+	// DType is the result dtype. The zero value (Bool) promotes the inputs
+	// as NumPy does: Float32 if every input is Float32 or an integer of at
+	// most 16 bits, with at least one Float32, and Float64 otherwise.
+	DType DType
+}
+
+// Vectorize turns a scalar function of two arguments into an element-wise
+// array function with broadcasting (similar to NumPy's vectorize)
+func Vectorize(f func(x, y float64) float64) func(a, b *NDArray) *NDArray {
+	return VectorizeWith(f, VectorizeOptions{})
+}
+
+// VectorizeWith is Vectorize with a result dtype and parallelism
+func VectorizeWith(f func(x, y float64) float64, opts VectorizeOptions) func(a, b *NDArray) *NDArray {
+	return func(a, b *NDArray) *NDArray {
+		shape, err := broadcastShapes(a.shape, b.shape)
+		if err != nil {
+			panic(err)
+		}
+		result := Zeros(shape, opts.resultType(a.dtype, b.dtype))
+		opts.each(result, func(indices []int) float64 {
+			return f(a.GetFloat64(broadcastIndex(a, indices)...), b.GetFloat64(broadcastIndex(b, indices)...))
+		})
+		return result
+	}
+}
+
+// VectorizeUnary turns a scalar function into an element-wise array
+// function
+func VectorizeUnary(f func(x float64) float64) func(a *NDArray) *NDArray {
+	return VectorizeUnaryWith(f, VectorizeOptions{})
+}
+
+// VectorizeUnaryWith is VectorizeUnary with a result dtype and parallelism
+func VectorizeUnaryWith(f func(x float64) float64, opts VectorizeOptions) func(a *NDArray) *NDArray {
+	return func(a *NDArray) *NDArray {
+		result := Zeros(a.shape, opts.resultType(a.dtype))
+		opts.each(result, func(indices []int) float64 {
+			return f(a.GetFloat64(indices...))
+		})
+		return result
+	}
+}
+
+// resultType returns the dtype of a vectorized result for the input dtypes
+func (opts VectorizeOptions) resultType(inputs ...DType) DType {
+	for _, dt := range inputs {
+		if dt.IsComplex() {
+			panic(fmt.Sprintf("cannot vectorize a real function over %s data", dt))
+		}
+	}
+	if opts.DType != Bool {
+		return opts.DType
+	}
+	float32s := 0
+	for _, dt := range inputs {
+		switch {
+		case dt == Float32:
+			float32s++
+		case dt == Float64 || dt.ItemSize() > 2:
+			return Float64
+		}
+	}
+	if float32s > 0 {
+		return Float32
+	}
+	return Float64
+}
+
+// each sets every element of result to value(indices), spreading the
+// elements over opts.Workers goroutines
+func (opts VectorizeOptions) each(result *NDArray, value func(indices []int) float64) {
+	fill := func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			indices := result.unravelIndex(i)
+			result.SetFloat64(value(indices), indices...)
+		}
+	}
+	workers := min(opts.Workers, result.size)
+	if workers < 2 {
+		fill(0, result.size)
+		return
+	}
+	
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			fill(lo, hi)
+		}(w*result.size/workers, (w+1)*result.size/workers)
+	}
+	wg.Wait()
+}
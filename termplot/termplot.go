@@ -0,0 +1,246 @@
+// Package termplot renders NumGo arrays as text - sparklines, histograms
+// and heatmaps - for a quick look at data in a terminal or log file
+package termplot
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+// Options controls rendering. The zero value draws with Unicode block
+// characters at the default sizes.
+type Options struct {
+	// ASCII draws with ASCII characters only, for output that does not
+	// survive Unicode
+	ASCII bool
+	// Width is the number of columns: the sparkline length, the longest
+	// histogram bar or the heatmap width. Longer data is averaged down to
+	// fit. Zero selects the data length for sparklines and heatmaps and 40
+	// for histograms.
+	Width int
+	// Bins is the number of histogram bins. Zero selects 10.
+	Bins int
+}
+
+// Character ramps from low to high
+var (
+	sparkUnicode = []rune("▁▂▃▄▅▆▇█")
+	shadeUnicode = []rune(" ░▒▓█")
+	rampASCII    = []rune(" .:-=+*#%@")
+	barEighths   = []rune(" ▏▎▍▌▋▊▉█")
+)
+
+// Sparkline renders the elements of a, flattened, as a one-line chart
+func Sparkline(a *tensor.NDArray) string {
+	return SparklineWith(a, Options{})
+}
+
+// SparklineWith is Sparkline with options. NaN values are drawn as spaces.
+func SparklineWith(a *tensor.NDArray, opts Options) string {
+	values := resample(a.ToSliceFloat64(), opts.Width)
+	ramp := sparkUnicode
+	if opts.ASCII {
+		ramp = rampASCII[1:]
+	}
+	lo, hi := bounds(values)
+	var sb strings.Builder
+	for _, v := range values {
+		if math.IsNaN(v) {
+			sb.WriteByte(' ')
+			continue
+		}
+		sb.WriteRune(ramp[level(v, lo, hi, len(ramp))])
+	}
+	return sb.String()
+}
+
+// Histogram renders a histogram of the elements of a with one horizontal
+// bar per bin, labelled with the bin edges and count
+func Histogram(a *tensor.NDArray) string {
+	return HistogramWith(a, Options{})
+}
+
+// HistogramWith is Histogram with options. NaN values are ignored. When
+// all values are equal the bins span half a unit either side of them, as
+// in numpy.histogram.
+func HistogramWith(a *tensor.NDArray, opts Options) string {
+	bins, width := opts.Bins, opts.Width
+	if bins == 0 {
+		bins = 10
+	}
+	if width == 0 {
+		width = 40
+	}
+	if bins < 1 || width < 1 {
+		panic(fmt.Sprintf("histogram needs positive bins and width, got %d and %d", bins, width))
+	}
+	
+	values := a.ToSliceFloat64()
+	lo, hi := bounds(values)
+	if math.IsNaN(lo) {
+		return ""
+	}
+	if lo == hi {
+		lo, hi = lo-0.5, hi+0.5
+	}
+	counts := make([]int, bins)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		b := int(float64(bins) * (v - lo) / (hi - lo))
+		if b == bins {
+			// The last bin includes its right edge
+			b--
+		}
+		counts[b]++
+	}
+	most := 0
+	for _, c := range counts {
+		most = max(most, c)
+	}
+	
+	edges := make([]string, bins+1)
+	edgeWidth := 0
+	for i := range edges {
+		edges[i] = fmt.Sprintf("%.4g", lo+(hi-lo)*float64(i)/float64(bins))
+		edgeWidth = max(edgeWidth, len(edges[i]))
+	}
+	sep := " │"
+	if opts.ASCII {
+		sep = " |"
+	}
+	var sb strings.Builder
+	for i, c := range counts {
+		fmt.Fprintf(&sb, "%*s - %*s%s%s %d\n", edgeWidth, edges[i], edgeWidth, edges[i+1], sep, bar(c, most, width, opts.ASCII), c)
+	}
+	return sb.String()
+}
+
+// bar returns a bar for count scaled so that most fills width columns
+func bar(count, most, width int, ascii bool) string {
+	if most == 0 {
+		return ""
+	}
+	length := float64(count) / float64(most) * float64(width)
+	if ascii {
+		return strings.Repeat("#", int(math.Round(length)))
+	}
+	full := int(length)
+	bar := strings.Repeat("█", full)
+	if eighths := int(math.Round((length - float64(full)) * 8)); eighths > 0 {
+		bar += string(barEighths[eighths])
+	}
+	return bar
+}
+
+// Heatmap renders a 2D array as a grid of characters shaded from the
+// smallest value to the largest, one line per row
+func Heatmap(a *tensor.NDArray) string {
+	return HeatmapWith(a, Options{})
+}
+
+// HeatmapWith is Heatmap with options. When the array has more columns
+// than Width, blocks of rows and columns are averaged so that it fits while
+// keeping its proportions. NaN values are drawn as spaces.
+func HeatmapWith(a *tensor.NDArray, opts Options) string {
+	if a.Ndim() != 2 {
+		panic(fmt.Sprintf("Heatmap requires a 2D array, got shape %v", a.Shape()))
+	}
+	rows, cols := a.Shape()[0], a.Shape()[1]
+	values := a.ToSliceFloat64()
+	if opts.Width > 0 && cols > opts.Width {
+		step := int(math.Ceil(float64(cols) / float64(opts.Width)))
+		values, rows, cols = pool(values, rows, cols, step)
+	}
+	
+	ramp := shadeUnicode
+	if opts.ASCII {
+		ramp = rampASCII
+	}
+	lo, hi := bounds(values)
+	var sb strings.Builder
+	for i := 0; i < rows; i++ {
+		for _, v := range values[i*cols : (i+1)*cols] {
+			if math.IsNaN(v) {
+				sb.WriteByte(' ')
+				continue
+			}
+			sb.WriteRune(ramp[level(v, lo, hi, len(ramp))])
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// pool averages step x step blocks of a rows x cols grid, ignoring NaN
+func pool(values []float64, rows, cols, step int) ([]float64, int, int) {
+	pr, pc := (rows+step-1)/step, (cols+step-1)/step
+	out := make([]float64, pr*pc)
+	for i := 0; i < pr; i++ {
+		for j := 0; j < pc; j++ {
+			sum, n := 0.0, 0
+			for r := i * step; r < min((i+1)*step, rows); r++ {
+				for c := j * step; c < min((j+1)*step, cols); c++ {
+					if v := values[r*cols+c]; !math.IsNaN(v) {
+						sum += v
+						n++
+					}
+				}
+			}
+			out[i*pc+j] = sum / float64(n)
+		}
+	}
+	return out, pr, pc
+}
+
+// resample averages values down to width buckets, ignoring NaN. A width of
+// zero or at least the length leaves values unchanged.
+func resample(values []float64, width int) []float64 {
+	if width <= 0 || len(values) <= width {
+		return values
+	}
+	out := make([]float64, width)
+	for i := range out {
+		lo, hi := i*len(values)/width, (i+1)*len(values)/width
+		sum, n := 0.0, 0
+		for _, v := range values[lo:hi] {
+			if !math.IsNaN(v) {
+				sum += v
+				n++
+			}
+		}
+		out[i] = sum / float64(n)
+	}
+	return out
+}
+
+// bounds returns the smallest and largest values that are not NaN, or NaN
+// if there are none
+func bounds(values []float64) (lo, hi float64) {
+	lo, hi = math.NaN(), math.NaN()
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		if math.IsNaN(lo) || v < lo {
+			lo = v
+		}
+		if math.IsNaN(hi) || v > hi {
+			hi = v
+		}
+	}
+	return lo, hi
+}
+
+// level maps v in [lo, hi] to one of n levels. Constant data takes the
+// middle level.
+func level(v, lo, hi float64, n int) int {
+	if hi == lo {
+		return n / 2
+	}
+	return min(n-1, int(float64(n)*(v-lo)/(hi-lo)))
+}
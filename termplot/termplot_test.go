@@ -0,0 +1,74 @@
+package termplot
+
+import (
+	"math"
+	"strings"
+	"testing"
+	
+	"github.com/iSundram/NumGo/tensor"
+)
+
+func TestSparkline(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{0, 1, 2, 3, 4, 5, 6, 7}, 8)
+	if got := Sparkline(a); got != "▁▂▃▄▅▆▇█" {
+		t.Errorf("Sparkline = %q", got)
+	}
+	
+	b := tensor.FromSliceFloat64([]float64{0, math.NaN(), 8}, 3)
+	if got := SparklineWith(b, Options{ASCII: true}); got != ". @" {
+		t.Errorf("ASCII sparkline = %q", got)
+	}
+	
+	// Averaging pairs halves the length
+	c := tensor.FromSliceFloat64([]float64{0, 0, 1, 1, 2, 2, 3, 3}, 8)
+	if got := SparklineWith(c, Options{Width: 4}); got != "▁▃▆█" {
+		t.Errorf("resampled sparkline = %q", got)
+	}
+	
+	if got := Sparkline(tensor.Full([]int{3}, 2.0, tensor.Float64)); got != "▅▅▅" {
+		t.Errorf("constant sparkline = %q", got)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{0, 1, 1, 2, 2, 2, 3, 3, 3, 3}, 10)
+	got := HistogramWith(a, Options{Bins: 4, Width: 8, ASCII: true})
+	want := "   0 - 0.75 |## 1\n0.75 -  1.5 |#### 2\n 1.5 - 2.25 |###### 3\n2.25 -    3 |######## 4\n"
+	if got != want {
+		t.Errorf("Histogram =\n%s\nwant\n%s", got, want)
+	}
+	
+	// Unicode bars use eighth blocks for the remainder
+	lines := strings.Split(strings.TrimSpace(HistogramWith(a, Options{Bins: 4, Width: 3})), "\n")
+	if !strings.Contains(lines[0], "│▊ 1") {
+		t.Errorf("expected a partial bar, got %q", lines[0])
+	}
+	
+	// Constant data spans half a unit either side
+	b := tensor.Full([]int{5}, 1.0, tensor.Float64)
+	if got := HistogramWith(b, Options{Bins: 1, Width: 5, ASCII: true}); got != "0.5 - 1.5 |##### 5\n" {
+		t.Errorf("constant Histogram = %q", got)
+	}
+}
+
+func TestHeatmap(t *testing.T) {
+	a := tensor.FromSliceFloat64([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, 3, 4)
+	if got := Heatmap(a); got != "   ░\n░▒▒▓\n▓███\n" {
+		t.Errorf("Heatmap =\n%s", got)
+	}
+	if got := HeatmapWith(a, Options{ASCII: true}); got != "  .:\n-=+*\n#%@@\n" {
+		t.Errorf("ASCII Heatmap =\n%s", got)
+	}
+	
+	// Fitting into two columns averages 2x2 blocks
+	if got := HeatmapWith(a, Options{Width: 2, ASCII: true}); got != " :\n#@\n" {
+		t.Errorf("pooled Heatmap =\n%s", got)
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a 1D array")
+		}
+	}()
+	Heatmap(tensor.Zeros([]int{3}, tensor.Float64))
+}